@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+const (
+	// CallerMethodPresharedKey is the method of callers authenticated with a preshared key.
+	CallerMethodPresharedKey = "preshared-key"
+
+	// CallerMethodJWT is the method of callers authenticated with a JWT bearer token.
+	CallerMethodJWT = "jwt"
+)
+
+// presharedKeyIDLength is the number of hex characters of the hash of a preshared
+// key used to identify it, which is enough to tell keys apart without revealing them.
+const presharedKeyIDLength = 16
+
+type callerCtxKey struct{}
+
+// Caller identifies the authenticated caller of a request.
+type Caller struct {
+	// Method is the method by which the caller was authenticated.
+	Method string
+
+	// ID identifies the caller amongst those authenticated with the same method. For
+	// preshared keys, it is a prefix of the hash of the key. For JWTs, it is the subject
+	// of the token or, if the token has no subject, the ID of the key which signed it.
+	ID string
+}
+
+// String returns the caller in the form `method:id`.
+func (c Caller) String() string {
+	return c.Method + ":" + c.ID
+}
+
+// ContextWithCaller returns a context holding the authenticated caller.
+func ContextWithCaller(ctx context.Context, caller Caller) context.Context {
+	return context.WithValue(ctx, callerCtxKey{}, caller)
+}
+
+// CallerFromContext returns the authenticated caller held by the context, if any.
+func CallerFromContext(ctx context.Context) (Caller, bool) {
+	caller, ok := ctx.Value(callerCtxKey{}).(Caller)
+	return caller, ok
+}
+
+func presharedKeyCaller(presharedKey string) Caller {
+	sum := sha256.Sum256([]byte(presharedKey))
+	return Caller{Method: CallerMethodPresharedKey, ID: hex.EncodeToString(sum[:])[:presharedKeyIDLength]}
+}
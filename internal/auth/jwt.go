@@ -0,0 +1,209 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	grpcauth "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	errInvalidJWT       = "invalid bearer token: %s"
+	errMissingJWT       = "missing bearer token"
+	errMethodNotInScope = "bearer token is not permitted to call %s"
+)
+
+// jwtSignatureAlgorithms are the asymmetric signature algorithms accepted for
+// tokens; symmetric algorithms are not supported, as the key set is public.
+var jwtSignatureAlgorithms = []jose.SignatureAlgorithm{
+	jose.RS256, jose.RS384, jose.RS512,
+	jose.PS256, jose.PS384, jose.PS512,
+	jose.ES256, jose.ES384, jose.ES512,
+	jose.EdDSA,
+}
+
+// JWTConfig configures the validation of JWT bearer tokens.
+type JWTConfig struct {
+	// JWKSPath is the path to a JSON Web Key Set file holding the public keys
+	// used to verify the signatures of tokens.
+	JWKSPath string
+
+	// Issuer, if non-empty, is the required value of the `iss` claim.
+	Issuer string
+
+	// Audience, if non-empty, must be contained in the `aud` claim.
+	Audience string
+
+	// KeyScopes maps the ID of a key in the key set to the gRPC methods which
+	// tokens signed by that key are permitted to call. Keys without an entry
+	// are permitted to call all methods. See ParseKeyScopes for the format of
+	// the methods.
+	KeyScopes map[string][]string
+}
+
+// ParseKeyScopes parses key scopes of the form `keyid=Method1,Method2`.
+//
+// Each method is either the full gRPC method name, such as
+// `authzed.api.v1.PermissionsService/CheckPermission`, the name of the method
+// alone, such as `CheckPermission`, all methods of a service, such as
+// `authzed.api.v1.SchemaService/*`, or `*` for all methods.
+func ParseKeyScopes(values []string) (map[string][]string, error) {
+	keyScopes := make(map[string][]string, len(values))
+	for _, value := range values {
+		keyID, methods, ok := strings.Cut(value, "=")
+		if !ok || keyID == "" || methods == "" {
+			return nil, fmt.Errorf("invalid key scope `%s`: expected the form `keyid=Method1,Method2`", value)
+		}
+
+		for method := range strings.SplitSeq(methods, ",") {
+			method = strings.TrimSpace(method)
+			if method == "" {
+				return nil, fmt.Errorf("invalid key scope `%s`: empty method", value)
+			}
+			keyScopes[keyID] = append(keyScopes[keyID], method)
+		}
+	}
+	return keyScopes, nil
+}
+
+// RequireJWT requires that gRPC requests have a Bearer Token value which is a
+// JWT signed by one of the keys in the configured key set, and that the key is
+// permitted to call the requested method. The subject of the token is recorded
+// as the caller of the request.
+func RequireJWT(config JWTConfig) (grpcauth.AuthFunc, error) {
+	contents, err := os.ReadFile(config.JWKSPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+	}
+
+	var keySet jose.JSONWebKeySet
+	if err := json.Unmarshal(contents, &keySet); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS file: %w", err)
+	}
+
+	if len(keySet.Keys) == 0 {
+		return nil, errors.New("JWKS file contains no keys")
+	}
+
+	for _, key := range keySet.Keys {
+		if !key.IsPublic() {
+			return nil, fmt.Errorf("JWKS file contains non-public key `%s`", key.KeyID)
+		}
+	}
+
+	for keyID := range config.KeyScopes {
+		if len(keySet.Key(keyID)) == 0 {
+			return nil, fmt.Errorf("scopes configured for unknown key `%s`", keyID)
+		}
+	}
+
+	expected := jwt.Expected{Issuer: config.Issuer}
+	if config.Audience != "" {
+		expected.AnyAudience = jwt.Audience{config.Audience}
+	}
+
+	return func(ctx context.Context) (context.Context, error) {
+		token, err := grpcauth.AuthFromMD(ctx, "bearer")
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, errInvalidJWT, err.Error())
+		}
+
+		if token == "" {
+			return nil, status.Errorf(codes.Unauthenticated, errMissingJWT)
+		}
+
+		key, claims, err := verifyJWT(token, keySet, expected.WithTime(time.Now()))
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, errInvalidJWT, err.Error())
+		}
+
+		method, _ := grpc.Method(ctx)
+		if scopes, ok := config.KeyScopes[key.KeyID]; ok && !MethodAllowed(scopes, method) {
+			return nil, status.Errorf(codes.PermissionDenied, errMethodNotInScope, method)
+		}
+
+		callerID := claims.Subject
+		if callerID == "" {
+			callerID = key.KeyID
+		}
+		return ContextWithCaller(ctx, Caller{Method: CallerMethodJWT, ID: callerID}), nil
+	}, nil
+}
+
+// verifyJWT verifies the signature and claims of the token, returning the key
+// which signed it and the claims of the token.
+func verifyJWT(token string, keySet jose.JSONWebKeySet, expected jwt.Expected) (jose.JSONWebKey, jwt.Claims, error) {
+	parsed, err := jwt.ParseSigned(token, jwtSignatureAlgorithms)
+	if err != nil {
+		return jose.JSONWebKey{}, jwt.Claims{}, err
+	}
+
+	if len(parsed.Headers) != 1 {
+		return jose.JSONWebKey{}, jwt.Claims{}, errors.New("expected a single signature")
+	}
+
+	// The key ID may only be omitted when the key set holds a single key.
+	var key jose.JSONWebKey
+	switch keys := keySet.Key(parsed.Headers[0].KeyID); {
+	case len(keys) > 0:
+		key = keys[0]
+	case parsed.Headers[0].KeyID == "" && len(keySet.Keys) == 1:
+		key = keySet.Keys[0]
+	default:
+		return jose.JSONWebKey{}, jwt.Claims{}, errors.New("unknown signing key")
+	}
+
+	var claims jwt.Claims
+	if err := parsed.Claims(key, &claims); err != nil {
+		return jose.JSONWebKey{}, jwt.Claims{}, err
+	}
+
+	if claims.Expiry == nil {
+		return jose.JSONWebKey{}, jwt.Claims{}, errors.New("missing exp claim")
+	}
+
+	if err := claims.ValidateWithLeeway(expected, jwt.DefaultLeeway); err != nil {
+		return jose.JSONWebKey{}, jwt.Claims{}, err
+	}
+
+	return key, claims, nil
+}
+
+// MethodAllowed returns whether any of the method patterns permits calling the
+// full gRPC method name, which is of the form `/package.Service/Method`. See
+// ParseKeyScopes for the format of the patterns.
+func MethodAllowed(patterns []string, fullMethod string) bool {
+	for _, pattern := range patterns {
+		switch {
+		case pattern == "*":
+			return true
+
+		case strings.HasSuffix(pattern, "/*"):
+			if strings.HasPrefix(fullMethod, "/"+strings.TrimPrefix(strings.TrimSuffix(pattern, "*"), "/")) {
+				return true
+			}
+
+		case strings.Contains(pattern, "/"):
+			if fullMethod == "/"+strings.TrimPrefix(pattern, "/") {
+				return true
+			}
+
+		default:
+			if path.Base(fullMethod) == pattern {
+				return true
+			}
+		}
+	}
+	return false
+}
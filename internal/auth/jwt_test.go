@@ -0,0 +1,297 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/authzed/grpcutil"
+)
+
+const (
+	checkPermissionMethod = "/authzed.api.v1.PermissionsService/CheckPermission"
+	writeSchemaMethod     = "/authzed.api.v1.SchemaService/WriteSchema"
+)
+
+func TestJWT(t *testing.T) {
+	checkKey := generateKey(t, "check")
+	adminKey := generateKey(t, "admin")
+	unknownKey := generateKey(t, "unknown")
+
+	jwksPath := writeJWKS(t, checkKey, adminKey)
+	f, err := RequireJWT(JWTConfig{
+		JWKSPath: jwksPath,
+		Issuer:   "https://issuer.example.com",
+		Audience: "spicedb",
+		KeyScopes: map[string][]string{
+			"check": {"CheckPermission"},
+		},
+	})
+	require.NoError(t, err)
+
+	validClaims := jwt.Claims{
+		Issuer:   "https://issuer.example.com",
+		Audience: jwt.Audience{"spicedb"},
+		Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+
+	withClaims := func(update func(*jwt.Claims)) jwt.Claims {
+		claims := validClaims
+		update(&claims)
+		return claims
+	}
+
+	testcases := []struct {
+		name           string
+		key            testKey
+		keyID          string
+		claims         jwt.Claims
+		method         string
+		expectedStatus codes.Code
+	}{
+		{"scoped key may call method in scope", checkKey, "check", validClaims, checkPermissionMethod, codes.OK},
+		{"scoped key may not call method out of scope", checkKey, "check", validClaims, writeSchemaMethod, codes.PermissionDenied},
+		{"unscoped key may call any method", adminKey, "admin", validClaims, writeSchemaMethod, codes.OK},
+		{"unknown key", unknownKey, "unknown", validClaims, checkPermissionMethod, codes.Unauthenticated},
+		{"key used with the wrong key ID", checkKey, "admin", validClaims, writeSchemaMethod, codes.Unauthenticated},
+		{"missing key ID with multiple keys", adminKey, "", validClaims, writeSchemaMethod, codes.Unauthenticated},
+		{"expired", adminKey, "admin", withClaims(func(c *jwt.Claims) {
+			c.Expiry = jwt.NewNumericDate(time.Now().Add(-time.Hour))
+		}), checkPermissionMethod, codes.Unauthenticated},
+		{"missing expiry", adminKey, "admin", withClaims(func(c *jwt.Claims) {
+			c.Expiry = nil
+		}), checkPermissionMethod, codes.Unauthenticated},
+		{"not yet valid", adminKey, "admin", withClaims(func(c *jwt.Claims) {
+			c.NotBefore = jwt.NewNumericDate(time.Now().Add(time.Hour))
+		}), checkPermissionMethod, codes.Unauthenticated},
+		{"wrong issuer", adminKey, "admin", withClaims(func(c *jwt.Claims) {
+			c.Issuer = "https://other.example.com"
+		}), checkPermissionMethod, codes.Unauthenticated},
+		{"wrong audience", adminKey, "admin", withClaims(func(c *jwt.Claims) {
+			c.Audience = jwt.Audience{"other"}
+		}), checkPermissionMethod, codes.Unauthenticated},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			token := signToken(t, testcase.key.key, testcase.keyID, testcase.claims)
+			ctx := withMethod(withTokenMetadata("bearer "+token), testcase.method)
+			_, err := f(ctx)
+			if testcase.expectedStatus != codes.OK {
+				require.Error(t, err)
+				grpcutil.RequireStatus(t, testcase.expectedStatus, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("malformed token", func(t *testing.T) {
+		_, err := f(withMethod(withTokenMetadata("bearer notatoken"), checkPermissionMethod))
+		grpcutil.RequireStatus(t, codes.Unauthenticated, err)
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		_, err := f(withMethod(withTokenMetadata("bearer "), checkPermissionMethod))
+		grpcutil.RequireStatus(t, codes.Unauthenticated, err)
+	})
+
+	t.Run("missing metadata", func(t *testing.T) {
+		_, err := f(withMethod(t.Context(), checkPermissionMethod))
+		grpcutil.RequireStatus(t, codes.Unauthenticated, err)
+	})
+}
+
+func TestJWTSingleKeyWithoutKeyID(t *testing.T) {
+	key := generateKey(t, "")
+	f, err := RequireJWT(JWTConfig{JWKSPath: writeJWKS(t, key)})
+	require.NoError(t, err)
+
+	token := signToken(t, key.key, "", jwt.Claims{Expiry: jwt.NewNumericDate(time.Now().Add(time.Hour))})
+	_, err = f(withMethod(withTokenMetadata("bearer "+token), writeSchemaMethod))
+	require.NoError(t, err)
+}
+
+func TestJWTCaller(t *testing.T) {
+	key := generateKey(t, "admin")
+	f, err := RequireJWT(JWTConfig{JWKSPath: writeJWKS(t, key)})
+	require.NoError(t, err)
+
+	expiry := jwt.NewNumericDate(time.Now().Add(time.Hour))
+
+	token := signToken(t, key.key, "admin", jwt.Claims{Subject: "deployer", Expiry: expiry})
+	ctx, err := f(withMethod(withTokenMetadata("bearer "+token), writeSchemaMethod))
+	require.NoError(t, err)
+
+	caller, ok := CallerFromContext(ctx)
+	require.True(t, ok)
+	require.Equal(t, "jwt:deployer", caller.String())
+
+	token = signToken(t, key.key, "admin", jwt.Claims{Expiry: expiry})
+	ctx, err = f(withMethod(withTokenMetadata("bearer "+token), writeSchemaMethod))
+	require.NoError(t, err)
+
+	caller, ok = CallerFromContext(ctx)
+	require.True(t, ok)
+	require.Equal(t, Caller{Method: CallerMethodJWT, ID: "admin"}, caller)
+}
+
+func TestRequireJWTErrors(t *testing.T) {
+	key := generateKey(t, "key")
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := RequireJWT(JWTConfig{JWKSPath: filepath.Join(t.TempDir(), "missing.json")})
+		require.ErrorContains(t, err, "failed to read JWKS file")
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "jwks.json")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+		_, err := RequireJWT(JWTConfig{JWKSPath: path})
+		require.ErrorContains(t, err, "failed to parse JWKS file")
+	})
+
+	t.Run("empty key set", func(t *testing.T) {
+		_, err := RequireJWT(JWTConfig{JWKSPath: writeJWKS(t)})
+		require.ErrorContains(t, err, "contains no keys")
+	})
+
+	t.Run("private key", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "jwks.json")
+		contents, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: key.key, KeyID: "key", Algorithm: string(jose.ES256), Use: "sig"},
+		}})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, contents, 0o600))
+
+		_, err = RequireJWT(JWTConfig{JWKSPath: path})
+		require.ErrorContains(t, err, "non-public key")
+	})
+
+	t.Run("scopes for unknown key", func(t *testing.T) {
+		_, err := RequireJWT(JWTConfig{
+			JWKSPath:  writeJWKS(t, key),
+			KeyScopes: map[string][]string{"other": {"CheckPermission"}},
+		})
+		require.ErrorContains(t, err, "unknown key `other`")
+	})
+}
+
+func TestParseKeyScopes(t *testing.T) {
+	scopes, err := ParseKeyScopes([]string{
+		"check=CheckPermission, CheckBulkPermissions",
+		"schema=authzed.api.v1.SchemaService/*",
+		"check=LookupResources",
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{
+		"check":  {"CheckPermission", "CheckBulkPermissions", "LookupResources"},
+		"schema": {"authzed.api.v1.SchemaService/*"},
+	}, scopes)
+
+	for _, invalid := range []string{"check", "=CheckPermission", "check=", "check=CheckPermission,"} {
+		_, err := ParseKeyScopes([]string{invalid})
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestMethodAllowed(t *testing.T) {
+	testcases := []struct {
+		scope   string
+		allowed bool
+	}{
+		{"*", true},
+		{"CheckPermission", true},
+		{"WriteSchema", false},
+		{"authzed.api.v1.PermissionsService/CheckPermission", true},
+		{"/authzed.api.v1.PermissionsService/CheckPermission", true},
+		{"authzed.api.v1.SchemaService/CheckPermission", false},
+		{"authzed.api.v1.PermissionsService/*", true},
+		{"authzed.api.v1.SchemaService/*", false},
+		{"authzed.api.v1.Permissions/*", false},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.scope, func(t *testing.T) {
+			require.Equal(t, testcase.allowed, MethodAllowed([]string{testcase.scope}, checkPermissionMethod))
+		})
+	}
+}
+
+// testKey is a signing key along with its ID in the key set.
+type testKey struct {
+	id  string
+	key *ecdsa.PrivateKey
+}
+
+func generateKey(t *testing.T, keyID string) testKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return testKey{id: keyID, key: key}
+}
+
+func writeJWKS(t *testing.T, keys ...testKey) string {
+	t.Helper()
+
+	keySet := jose.JSONWebKeySet{Keys: make([]jose.JSONWebKey, 0, len(keys))}
+	for _, key := range keys {
+		keySet.Keys = append(keySet.Keys, jose.JSONWebKey{
+			Key:       key.key.Public(),
+			KeyID:     key.id,
+			Algorithm: string(jose.ES256),
+			Use:       "sig",
+		})
+	}
+
+	contents, err := json.Marshal(keySet)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, contents, 0o600))
+	return path
+}
+
+func signToken(t *testing.T, key *ecdsa.PrivateKey, keyID string, claims jwt.Claims) string {
+	t.Helper()
+
+	opts := &jose.SignerOptions{}
+	if keyID != "" {
+		opts = opts.WithHeader(jose.HeaderKey("kid"), keyID)
+	}
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, opts.WithType("JWT"))
+	require.NoError(t, err)
+
+	token, err := jwt.Signed(signer).Claims(claims).Serialize()
+	require.NoError(t, err)
+	return token
+}
+
+// withMethod returns a context for which grpc.Method returns the given method,
+// as it does within a server handler.
+func withMethod(ctx context.Context, method string) context.Context {
+	return grpc.NewContextWithServerTransportStream(ctx, &methodTransportStream{method: method})
+}
+
+type methodTransportStream struct {
+	method string
+}
+
+func (m *methodTransportStream) Method() string               { return m.method }
+func (m *methodTransportStream) SetHeader(metadata.MD) error  { return nil }
+func (m *methodTransportStream) SendHeader(metadata.MD) error { return nil }
+func (m *methodTransportStream) SetTrailer(metadata.MD) error { return nil }
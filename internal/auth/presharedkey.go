@@ -17,7 +17,8 @@ const (
 var errInvalidToken = "invalid token"
 
 // MustRequirePresharedKey requires that gRPC requests have a Bearer Token value
-// equivalent to one of the provided preshared key(s).
+// equivalent to one of the provided preshared key(s). The key is recorded as the
+// caller of the request.
 func MustRequirePresharedKey(presharedKeys []string) grpcauth.AuthFunc {
 	if len(presharedKeys) == 0 {
 		panic("RequirePresharedKey was given an empty preshared keys slice")
@@ -41,7 +42,7 @@ func MustRequirePresharedKey(presharedKeys []string) grpcauth.AuthFunc {
 
 		for _, presharedKey := range presharedKeys {
 			if match := subtle.ConstantTimeCompare([]byte(presharedKey), []byte(token)); match == 1 {
-				return ctx, nil
+				return ContextWithCaller(ctx, presharedKeyCaller(presharedKey)), nil
 			}
 		}
 
@@ -48,6 +48,29 @@ func TestPresharedKeys(t *testing.T) {
 	}
 }
 
+func TestPresharedKeyCaller(t *testing.T) {
+	f := MustRequirePresharedKey([]string{"one", "two"})
+
+	firstCtx, err := f(withTokenMetadata("bearer one"))
+	require.NoError(t, err)
+
+	first, ok := CallerFromContext(firstCtx)
+	require.True(t, ok)
+	require.Equal(t, CallerMethodPresharedKey, first.Method)
+	require.Len(t, first.ID, presharedKeyIDLength)
+	require.NotContains(t, first.String(), "one")
+
+	secondCtx, err := f(withTokenMetadata("bearer two"))
+	require.NoError(t, err)
+
+	second, ok := CallerFromContext(secondCtx)
+	require.True(t, ok)
+	require.NotEqual(t, first, second)
+
+	_, ok = CallerFromContext(t.Context())
+	require.False(t, ok)
+}
+
 func TestMustRequirePresharedKeyPanics(t *testing.T) {
 	t.Run("panics with empty slice", func(t *testing.T) {
 		require.Panics(t, func() {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"maps"
+	"sync"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -46,8 +47,11 @@ func RunSingleCaveatExpression(
 }
 
 // CaveatRunner is a helper for running caveats, providing a cache for deserialized caveats.
+// It is safe for concurrent use.
 type CaveatRunner struct {
-	caveatTypeSet       *caveattypes.TypeSet
+	caveatTypeSet *caveattypes.TypeSet
+
+	cacheLock           sync.RWMutex
 	caveatDefs          map[string]*core.CaveatDefinition
 	deserializedCaveats map[string]*caveats.CompiledCaveat
 }
@@ -98,9 +102,11 @@ func (cr *CaveatRunner) PopulateCaveatDefinitionsForExpr(ctx context.Context, ex
 	}
 
 	// Remove any caveats already loaded.
+	cr.cacheLock.RLock()
 	for name := range cr.caveatDefs {
 		caveatNames.Delete(name)
 	}
+	cr.cacheLock.RUnlock()
 
 	if caveatNames.IsEmpty() {
 		return nil
@@ -113,6 +119,8 @@ func (cr *CaveatRunner) PopulateCaveatDefinitionsForExpr(ctx context.Context, ex
 	}
 	span.AddEvent(otelconv.EventCaveatsLookedUp)
 
+	cr.cacheLock.Lock()
+	defer cr.cacheLock.Unlock()
 	for _, cd := range caveatDefs {
 		cr.caveatDefs[cd.Definition.GetName()] = cd.Definition
 	}
@@ -123,13 +131,16 @@ func (cr *CaveatRunner) PopulateCaveatDefinitionsForExpr(ctx context.Context, ex
 // get retrieves a caveat definition and its deserialized form. The caveat name must be
 // present in the CaveatRunner's cache.
 func (cr *CaveatRunner) get(caveatDefName string) (*core.CaveatDefinition, *caveats.CompiledCaveat, error) {
+	cr.cacheLock.RLock()
 	caveat, ok := cr.caveatDefs[caveatDefName]
+	deserialized, deserializedOk := cr.deserializedCaveats[caveatDefName]
+	cr.cacheLock.RUnlock()
+
 	if !ok {
 		return nil, nil, datastore.NewCaveatNameNotFoundErr(caveatDefName)
 	}
 
-	deserialized, ok := cr.deserializedCaveats[caveatDefName]
-	if ok {
+	if deserializedOk {
 		return caveat, deserialized, nil
 	}
 
@@ -143,7 +154,9 @@ func (cr *CaveatRunner) get(caveatDefName string) (*core.CaveatDefinition, *cave
 		return caveat, nil, err
 	}
 
+	cr.cacheLock.Lock()
 	cr.deserializedCaveats[caveatDefName] = justDeserialized
+	cr.cacheLock.Unlock()
 	return caveat, justDeserialized, nil
 }
 
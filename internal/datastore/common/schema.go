@@ -58,6 +58,10 @@ type SchemaInformation struct {
 	// ExpirationDisabled is a flag to indicate whether expiration support is disabled.
 	ExpirationDisabled bool `debugmap:"visible"`
 
+	// ExplicitLikeEscape is a flag to indicate whether LIKE clauses must declare their escape
+	// character, for datastores which do not use a backslash by default.
+	ExplicitLikeEscape bool `debugmap:"visible"`
+
 	// SortByResourceColumnOrder is the order of the resource columns in the schema to use
 	// when sorting by resource. If unspecified, the default will be used.
 	SortByResourceColumnOrder []string `debugmap:"visible"`
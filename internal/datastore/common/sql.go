@@ -404,7 +404,13 @@ func (sqf SchemaQueryFilterer) MustFilterToResourceIDs(resourceIds []string) Sch
 // FilterWithResourceIDPrefix returns new SchemaQueryFilterer that is limited to resources whose ID
 // starts with the specified prefix.
 func (sqf SchemaQueryFilterer) FilterWithResourceIDPrefix(prefix string) (SchemaQueryFilterer, error) {
-	likeClause, err := BuildLikePrefixClause(sqf.schema.ColObjectID, prefix)
+	var likeClause sq.Sqlizer
+	var err error
+	if sqf.schema.ExplicitLikeEscape {
+		likeClause, err = BuildEscapedLikePrefixClause(sqf.schema.ColObjectID, prefix)
+	} else {
+		likeClause, err = BuildLikePrefixClause(sqf.schema.ColObjectID, prefix)
+	}
 	if err != nil {
 		return sqf, err
 	}
@@ -976,15 +982,35 @@ func ColumnsToSelect[CN any, CC any, EC any](
 
 // BuildLikePrefixClause builds a LIKE clause for the given column name and prefix.
 func BuildLikePrefixClause(columnName string, prefix string) (sq.Like, error) {
+	pattern, err := likePrefixPattern(prefix)
+	if err != nil {
+		return sq.Like{}, err
+	}
+
+	return sq.Like{columnName: pattern}, nil
+}
+
+// BuildEscapedLikePrefixClause builds a LIKE clause for the given column name and prefix which
+// explicitly declares the backslash as its escape character.
+func BuildEscapedLikePrefixClause(columnName string, prefix string) (sq.Sqlizer, error) {
+	pattern, err := likePrefixPattern(prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return sq.Expr(columnName+` LIKE ? ESCAPE '\'`, pattern), nil
+}
+
+func likePrefixPattern(prefix string) (string, error) {
 	if prefix == "" {
-		return sq.Like{}, spiceerrors.MustBugf("prefix cannot be empty")
+		return "", spiceerrors.MustBugf("prefix cannot be empty")
 	}
 
 	if strings.Contains(prefix, "%") {
-		return sq.Like{}, fmt.Errorf("prefix cannot contain the percent sign. found: %q", prefix)
+		return "", fmt.Errorf("prefix cannot contain the percent sign. found: %q", prefix)
 	}
 
 	prefix = strings.ReplaceAll(prefix, `\`, `\\`)
 	prefix = strings.ReplaceAll(prefix, "_", `\_`)
-	return sq.Like{columnName: prefix + "%"}, nil
+	return prefix + "%", nil
 }
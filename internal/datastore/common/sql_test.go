@@ -1801,3 +1801,16 @@ func TestBuildLikeCla(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildEscapedLikeClause(t *testing.T) {
+	clause, err := BuildEscapedLikePrefixClause("testcol", `foo_\bar`)
+	require.NoError(t, err)
+
+	sql, args, err := clause.ToSql()
+	require.NoError(t, err)
+	require.Equal(t, `testcol LIKE ? ESCAPE '\'`, sql)
+	require.Equal(t, []any{`foo\_\\bar%`}, args)
+
+	_, err = BuildEscapedLikePrefixClause("testcol", "foo%")
+	require.ErrorContains(t, err, "prefix cannot contain the percent sign")
+}
@@ -51,6 +51,7 @@ func (s *SchemaInformation) ToOption() SchemaInformationOption {
 		to.ColumnOptimization = s.ColumnOptimization
 		to.IntegrityEnabled = s.IntegrityEnabled
 		to.ExpirationDisabled = s.ExpirationDisabled
+		to.ExplicitLikeEscape = s.ExplicitLikeEscape
 		to.SortByResourceColumnOrder = s.SortByResourceColumnOrder
 		to.SortBySubjectColumnOrder = s.SortBySubjectColumnOrder
 	}
@@ -79,6 +80,7 @@ func (s SchemaInformation) DebugMap() map[string]any {
 	debugMap["ColumnOptimization"] = helpers.DebugValue(s.ColumnOptimization, false)
 	debugMap["IntegrityEnabled"] = helpers.DebugValue(s.IntegrityEnabled, false)
 	debugMap["ExpirationDisabled"] = helpers.DebugValue(s.ExpirationDisabled, false)
+	debugMap["ExplicitLikeEscape"] = helpers.DebugValue(s.ExplicitLikeEscape, false)
 	debugMap["SortByResourceColumnOrder"] = helpers.DebugValue(s.SortByResourceColumnOrder, false)
 	debugMap["SortBySubjectColumnOrder"] = helpers.DebugValue(s.SortBySubjectColumnOrder, false)
 	return debugMap
@@ -247,6 +249,13 @@ func WithExpirationDisabled(expirationDisabled bool) SchemaInformationOption {
 	}
 }
 
+// WithExplicitLikeEscape returns an option that can set ExplicitLikeEscape on a SchemaInformation
+func WithExplicitLikeEscape(explicitLikeEscape bool) SchemaInformationOption {
+	return func(s *SchemaInformation) {
+		s.ExplicitLikeEscape = explicitLikeEscape
+	}
+}
+
 // WithSortByResourceColumnOrder returns an option that can append SortByResourceColumnOrders to SchemaInformation.SortByResourceColumnOrder
 func WithSortByResourceColumnOrder(sortByResourceColumnOrder string) SchemaInformationOption {
 	return func(s *SchemaInformation) {
@@ -112,6 +112,10 @@ func (r *ctxReader) LookupCounters(ctx context.Context) ([]datastore.Relationshi
 	return r.delegate.LookupCounters(context.WithoutCancel(ctx))
 }
 
+func (r *ctxReader) ReadSchemaHistory(ctx context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	return r.delegate.ReadSchemaHistory(context.WithoutCancel(ctx), beforeRevision, limit)
+}
+
 func (r *ctxReader) ReadCaveatByName(ctx context.Context, name string) (*core.CaveatDefinition, datastore.Revision, error) {
 	return r.delegate.ReadCaveatByName(context.WithoutCancel(ctx), name)
 }
@@ -0,0 +1,31 @@
+package counters
+
+import "time"
+
+// expirationEntry records the time at which a counted relationship expires.
+type expirationEntry struct {
+	at      time.Time
+	counter string
+	key     string
+}
+
+// expirationQueue is a min-heap of expiration entries, ordered by expiration
+// time. Entries are never removed when the relationship changes; instead they
+// are checked against the counter when popped.
+type expirationQueue []expirationEntry
+
+func (eq expirationQueue) Len() int           { return len(eq) }
+func (eq expirationQueue) Less(i, j int) bool { return eq[i].at.Before(eq[j].at) }
+func (eq expirationQueue) Swap(i, j int)      { eq[i], eq[j] = eq[j], eq[i] }
+
+func (eq *expirationQueue) Push(x any) {
+	*eq = append(*eq, x.(expirationEntry))
+}
+
+func (eq *expirationQueue) Pop() any {
+	old := *eq
+	n := len(old)
+	item := old[n-1]
+	*eq = old[:n-1]
+	return item
+}
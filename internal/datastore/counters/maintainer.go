@@ -0,0 +1,462 @@
+// Package counters maintains the values of registered relationship counters
+// incrementally from the datastore's Watch stream.
+package counters
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/datastore/options"
+	"github.com/authzed/spicedb/pkg/datastore/queryshape"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// lookupBatchSize is the maximum number of resource IDs loaded in a single
+// query when reading the previous versions of changed relationships.
+const lookupBatchSize = 100
+
+// Maintainer keeps the stored values of the registered relationship counters
+// up to date by applying the relationship changes reported by the Watch API,
+// rather than recounting the relationships on demand.
+//
+// Counted relationships with an expiration are tracked in memory, as the
+// Watch API does not report relationships expiring; the count is decremented
+// once they expire.
+//
+// Every node may run a Maintainer over the same datastore: each stores the full
+// count as of the revision it has processed, rather than applying deltas to the
+// stored count, and never overwrites a count stored as of a newer revision.
+type Maintainer struct {
+	ds      datastore.Datastore
+	options maintainerOptions
+
+	counters     map[string]*trackedCounter
+	expirations  expirationQueue
+	lastRevision datastore.Revision
+}
+
+type trackedCounter struct {
+	name       string
+	coreFilter *core.RelationshipFilter
+
+	// filter is the full filter of the counter, while structural is the filter
+	// without its caveat and expiration options. The latter matches all versions
+	// of a relationship, and so selects the changes which can affect the count.
+	filter     datastore.RelationshipsFilter
+	structural datastore.RelationshipsFilter
+
+	count int
+	dirty bool
+
+	// expirations holds the expiration time of each counted relationship which
+	// expires, keyed by the relationship without its caveat or expiration.
+	expirations map[string]time.Time
+}
+
+// NewMaintainer creates a new counter Maintainer over the given datastore.
+func NewMaintainer(ds datastore.Datastore, opts ...Option) *Maintainer {
+	return &Maintainer{
+		ds:      ds,
+		options: generateConfig(opts),
+	}
+}
+
+// Run maintains the counters until the context is canceled. If the watch
+// fails, the counters are recomputed and the watch restarted after the
+// configured retry delay.
+func (m *Maintainer) Run(ctx context.Context) error {
+	for {
+		err := m.run(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		log.Ctx(ctx).Warn().Err(err).Stringer("retry-delay", m.options.retryDelay).Msg("relationship counter maintenance failed, restarting")
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(m.options.retryDelay):
+		}
+	}
+}
+
+func (m *Maintainer) run(ctx context.Context) error {
+	headRev, err := m.ds.HeadRevision(ctx)
+	if err != nil {
+		return err
+	}
+
+	m.counters = make(map[string]*trackedCounter)
+	m.expirations = nil
+	m.lastRevision = headRev
+
+	if err := m.refreshCounters(ctx); err != nil {
+		return err
+	}
+
+	if err := m.flush(ctx); err != nil {
+		return err
+	}
+
+	changes, errs := m.ds.Watch(ctx, headRev, datastore.WatchOptions{
+		Content: datastore.WatchRelationships | datastore.WatchCheckpoints,
+	})
+
+	flushTicker := time.NewTicker(m.options.flushInterval)
+	defer flushTicker.Stop()
+
+	log.Ctx(ctx).Debug().Str("revision", headRev.String()).Int("counters", len(m.counters)).Msg("started relationship counter maintenance")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case change, ok := <-changes:
+			if !ok {
+				// The error, if any, is reported on the error channel.
+				changes = nil
+				continue
+			}
+
+			if err := m.applyChanges(ctx, change); err != nil {
+				return err
+			}
+
+		case err := <-errs:
+			return err
+
+		case <-flushTicker.C:
+			if err := m.refreshCounters(ctx); err != nil {
+				return err
+			}
+
+			m.expire(time.Now())
+			if err := m.flush(ctx); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// refreshCounters reloads the registered counters, computing the full count of
+// any counter which is new or whose filter has changed, and dropping those
+// which are no longer registered.
+func (m *Maintainer) refreshCounters(ctx context.Context) error {
+	registered, err := m.ds.SnapshotReader(m.lastRevision).LookupCounters(ctx)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]struct{}, len(registered))
+	for _, counter := range registered {
+		seen[counter.Name] = struct{}{}
+
+		if existing, ok := m.counters[counter.Name]; ok && existing.coreFilter.EqualVT(counter.Filter) {
+			continue
+		}
+
+		tracked, err := m.computeCounter(ctx, counter.Name, counter.Filter)
+		if err != nil {
+			var invalidFilterErr invalidFilterError
+			if errors.As(err, &invalidFilterErr) {
+				log.Ctx(ctx).Warn().Err(err).Str("counter", counter.Name).Msg("skipping relationship counter with invalid filter")
+				delete(m.counters, counter.Name)
+				continue
+			}
+			return err
+		}
+
+		m.counters[counter.Name] = tracked
+	}
+
+	for name := range m.counters {
+		if _, ok := seen[name]; !ok {
+			delete(m.counters, name)
+		}
+	}
+
+	return nil
+}
+
+type invalidFilterError struct {
+	error
+}
+
+func (err invalidFilterError) Unwrap() error {
+	return err.error
+}
+
+// computeCounter counts all relationships matching the counter's filter at the
+// last processed revision.
+func (m *Maintainer) computeCounter(ctx context.Context, name string, coreFilter *core.RelationshipFilter) (*trackedCounter, error) {
+	filter, err := datastore.RelationshipsFilterFromCoreFilter(coreFilter)
+	if err != nil {
+		return nil, invalidFilterError{err}
+	}
+
+	structural := filter
+	structural.OptionalCaveatNameFilter = datastore.CaveatNameFilter{}
+	structural.OptionalExpirationOption = datastore.ExpirationFilterOptionNone
+
+	tracked := &trackedCounter{
+		name:        name,
+		coreFilter:  coreFilter,
+		filter:      filter,
+		structural:  structural,
+		dirty:       true,
+		expirations: make(map[string]time.Time),
+	}
+
+	iter, err := m.ds.SnapshotReader(m.lastRevision).QueryRelationships(ctx, filter, options.WithQueryShape(queryshape.Varying))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for rel, err := range iter {
+		if err != nil {
+			return nil, err
+		}
+
+		m.track(tracked, rel, now)
+	}
+
+	return tracked, nil
+}
+
+// track adds the relationship to the counter if it matches the counter's
+// filter and has not expired.
+func (m *Maintainer) track(counter *trackedCounter, rel tuple.Relationship, now time.Time) {
+	if !counter.filter.Test(rel) {
+		return
+	}
+
+	if rel.OptionalExpiration != nil {
+		if !rel.OptionalExpiration.After(now) {
+			return
+		}
+
+		key := tuple.StringWithoutCaveatOrExpiration(rel)
+		counter.expirations[key] = *rel.OptionalExpiration
+		heap.Push(&m.expirations, expirationEntry{
+			at:      *rel.OptionalExpiration,
+			counter: counter.name,
+			key:     key,
+		})
+	}
+
+	counter.count++
+	counter.dirty = true
+}
+
+// untrack removes the previous version of a changed relationship from the
+// counter, if that version was counted.
+func (m *Maintainer) untrack(counter *trackedCounter, key string, previous tuple.Relationship, hasPrevious bool) {
+	// Counted relationships with an expiration are always tracked until they
+	// expire, so their presence in the map is authoritative.
+	if _, ok := counter.expirations[key]; ok {
+		delete(counter.expirations, key)
+		counter.count--
+		counter.dirty = true
+		return
+	}
+
+	if hasPrevious && previous.OptionalExpiration == nil && counter.filter.Test(previous) {
+		counter.count--
+		counter.dirty = true
+	}
+}
+
+// applyChanges applies the relationship changes at a revision to the counters.
+func (m *Maintainer) applyChanges(ctx context.Context, change datastore.RevisionChanges) error {
+	if change.IsCheckpoint {
+		m.lastRevision = change.Revision
+		return nil
+	}
+
+	now := time.Now()
+	m.expire(now)
+
+	var candidates []tuple.RelationshipUpdate
+	for _, update := range change.RelationshipChanges {
+		for _, counter := range m.counters {
+			if counter.structural.Test(update.Relationship) {
+				candidates = append(candidates, update)
+				break
+			}
+		}
+	}
+
+	if len(candidates) > 0 {
+		previous, err := m.loadPrevious(ctx, candidates)
+		if err != nil {
+			return err
+		}
+
+		for _, update := range candidates {
+			key := tuple.StringWithoutCaveatOrExpiration(update.Relationship)
+			previousRel, hasPrevious := previous[key]
+
+			for _, counter := range m.counters {
+				if !counter.structural.Test(update.Relationship) {
+					continue
+				}
+
+				m.untrack(counter, key, previousRel, hasPrevious)
+				if update.Operation != tuple.UpdateOperationDelete {
+					m.track(counter, update.Relationship, now)
+				}
+			}
+		}
+	}
+
+	m.lastRevision = change.Revision
+	return nil
+}
+
+type lookupKey struct {
+	resourceType string
+	relation     string
+}
+
+// loadPrevious reads the versions of the changed relationships which existed at
+// the last processed revision. As the watch reports every relationship change,
+// these are the versions which existed immediately before the change.
+func (m *Maintainer) loadPrevious(ctx context.Context, updates []tuple.RelationshipUpdate) (map[string]tuple.Relationship, error) {
+	wanted := make(map[string]struct{}, len(updates))
+	resourceIDs := make(map[lookupKey][]string)
+	for _, update := range updates {
+		key := tuple.StringWithoutCaveatOrExpiration(update.Relationship)
+		if _, ok := wanted[key]; ok {
+			continue
+		}
+		wanted[key] = struct{}{}
+
+		lk := lookupKey{update.Relationship.Resource.ObjectType, update.Relationship.Resource.Relation}
+		if !slices.Contains(resourceIDs[lk], update.Relationship.Resource.ObjectID) {
+			resourceIDs[lk] = append(resourceIDs[lk], update.Relationship.Resource.ObjectID)
+		}
+	}
+
+	reader := m.ds.SnapshotReader(m.lastRevision)
+	previous := make(map[string]tuple.Relationship, len(wanted))
+	for lk, ids := range resourceIDs {
+		for chunk := range slices.Chunk(ids, lookupBatchSize) {
+			iter, err := reader.QueryRelationships(ctx, datastore.RelationshipsFilter{
+				OptionalResourceType:     lk.resourceType,
+				OptionalResourceIds:      chunk,
+				OptionalResourceRelation: lk.relation,
+			}, options.WithQueryShape(queryshape.AllSubjectsForResources))
+			if err != nil {
+				return nil, err
+			}
+
+			for rel, err := range iter {
+				if err != nil {
+					return nil, err
+				}
+
+				key := tuple.StringWithoutCaveatOrExpiration(rel)
+				if _, ok := wanted[key]; ok {
+					previous[key] = rel
+				}
+			}
+		}
+	}
+
+	return previous, nil
+}
+
+// expire removes the relationships which have expired as of the given time
+// from the counters.
+func (m *Maintainer) expire(now time.Time) {
+	for m.expirations.Len() > 0 && !m.expirations[0].at.After(now) {
+		entry := heap.Pop(&m.expirations).(expirationEntry)
+
+		counter, ok := m.counters[entry.counter]
+		if !ok {
+			continue
+		}
+
+		// The relationship may have been changed or removed since the entry was
+		// queued, in which case the entry is stale.
+		at, ok := counter.expirations[entry.key]
+		if !ok || !at.Equal(entry.at) {
+			continue
+		}
+
+		delete(counter.expirations, entry.key)
+		counter.count--
+		counter.dirty = true
+	}
+}
+
+// flush stores the values of all changed counters at the last processed
+// revision, unless a value at a newer revision has already been stored by the
+// Maintainer of another node.
+func (m *Maintainer) flush(ctx context.Context) error {
+	var dirty []*trackedCounter
+	for _, counter := range m.counters {
+		if counter.dirty {
+			dirty = append(dirty, counter)
+		}
+	}
+
+	if len(dirty) == 0 {
+		return nil
+	}
+
+	var unregistered []string
+	_, err := m.ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		unregistered = nil
+
+		stored, err := rwt.LookupCounters(ctx)
+		if err != nil {
+			return err
+		}
+
+		storedAt := make(map[string]datastore.Revision, len(stored))
+		for _, counter := range stored {
+			storedAt[counter.Name] = counter.ComputedAtRevision
+		}
+
+		for _, counter := range dirty {
+			if rev, ok := storedAt[counter.name]; ok && rev != datastore.NoRevision && rev.GreaterThan(m.lastRevision) {
+				continue
+			}
+
+			err := rwt.StoreCounterValue(ctx, counter.name, counter.count, m.lastRevision)
+			if err != nil {
+				var notRegisteredErr datastore.CounterNotRegisteredError
+				if errors.As(err, &notRegisteredErr) {
+					unregistered = append(unregistered, counter.name)
+					continue
+				}
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, counter := range dirty {
+		counter.dirty = false
+	}
+
+	// Counters unregistered since the last refresh are dropped; should they be
+	// registered again, they will be recomputed on the next refresh.
+	for _, name := range unregistered {
+		delete(m.counters, name)
+	}
+
+	return nil
+}
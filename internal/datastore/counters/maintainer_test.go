@@ -0,0 +1,184 @@
+package counters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	"github.com/authzed/spicedb/internal/testfixtures"
+	"github.com/authzed/spicedb/pkg/datastore"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+func TestMaintainer(t *testing.T) {
+	rawDS, err := memdb.NewMemdbDatastore(0, 0, memdb.DisableGC)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = rawDS.Close() })
+
+	ds, _ := testfixtures.StandardDatastoreWithData(rawDS, require.New(t))
+
+	registerCounters(t, ds, map[string]*core.RelationshipFilter{
+		"documents": {
+			ResourceType: testfixtures.DocumentNS.Name,
+		},
+		"caveated": {
+			ResourceType:       testfixtures.DocumentNS.Name,
+			OptionalCaveatName: "test",
+		},
+		"expiring": {
+			ResourceType:             testfixtures.DocumentNS.Name,
+			OptionalExpirationFilter: core.RelationshipFilter_EXPIRATION_FILTER_HAS_EXPIRATION,
+		},
+		"nonexpiring": {
+			ResourceType:             testfixtures.DocumentNS.Name,
+			OptionalExpirationFilter: core.RelationshipFilter_EXPIRATION_FILTER_NO_EXPIRATION,
+		},
+	})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() {
+		done <- NewMaintainer(ds, FlushInterval(10*time.Millisecond)).Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	requireCountersMatch(t, ds)
+
+	// Create, recaveat and delete relationships.
+	expiresAt := time.Now().Add(500 * time.Millisecond).UTC().Format(time.RFC3339Nano)
+	writeUpdates(t, ds,
+		tuple.Create(tuple.MustParse("document:newdoc#viewer@user:tom")),
+		tuple.Create(tuple.MustParse("document:newdoc#caveated_viewer@user:tom[test]")),
+		tuple.Create(tuple.MustParse("document:newdoc#expiring_viewer@user:tom[expiration:"+expiresAt+"]")),
+		tuple.Create(tuple.MustParse("document:newdoc#expiring_viewer@user:fred[expiration:2320-01-01T00:00:00Z]")),
+	)
+	requireCountersMatch(t, ds)
+	require.Equal(t, 2, storedCount(t, ds, "expiring"))
+
+	writeUpdates(t, ds,
+		tuple.Touch(tuple.MustParse("document:newdoc#caveated_viewer@user:tom")),
+		tuple.Delete(tuple.MustParse("document:newdoc#viewer@user:tom")),
+		tuple.Touch(tuple.MustParse("document:newdoc#expiring_viewer@user:fred")),
+	)
+	requireCountersMatch(t, ds)
+	require.Equal(t, 1, storedCount(t, ds, "expiring"))
+
+	// Wait for the relationship to expire, which is not reported by the watch.
+	require.Eventually(t, func() bool {
+		return storedCount(t, ds, "expiring") == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	requireCountersMatch(t, ds)
+
+	// Register a counter after the maintainer has started.
+	registerCounters(t, ds, map[string]*core.RelationshipFilter{
+		"newdoc": {
+			ResourceType:       testfixtures.DocumentNS.Name,
+			OptionalResourceId: "newdoc",
+		},
+	})
+	requireCountersMatch(t, ds)
+	require.Equal(t, 2, storedCount(t, ds, "newdoc"))
+}
+
+func TestMaintainerDoesNotOverwriteNewerValue(t *testing.T) {
+	rawDS, err := memdb.NewMemdbDatastore(0, 0, memdb.DisableGC)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = rawDS.Close() })
+
+	ds, revision := testfixtures.StandardDatastoreWithData(rawDS, require.New(t))
+	registerCounters(t, ds, map[string]*core.RelationshipFilter{
+		"documents": {
+			ResourceType: testfixtures.DocumentNS.Name,
+		},
+	})
+
+	// A maintainer which has fallen behind has processed changes up to an older revision than
+	// the one at which another has stored the count.
+	newerRev, err := ds.ReadWriteTx(t.Context(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.StoreCounterValue(ctx, "documents", 42, revision)
+	})
+	require.NoError(t, err)
+	_, err = ds.ReadWriteTx(t.Context(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.StoreCounterValue(ctx, "documents", 42, newerRev)
+	})
+	require.NoError(t, err)
+
+	m := NewMaintainer(ds)
+	m.counters = map[string]*trackedCounter{
+		"documents": {name: "documents", count: 7, dirty: true},
+	}
+	m.lastRevision = revision
+	require.NoError(t, m.flush(t.Context()))
+	require.Equal(t, 42, storedCount(t, ds, "documents"))
+
+	// Once it has caught up, its count is stored.
+	m.counters["documents"].dirty = true
+	m.lastRevision = newerRev
+	require.NoError(t, m.flush(t.Context()))
+	require.Equal(t, 7, storedCount(t, ds, "documents"))
+}
+
+func registerCounters(t *testing.T, ds datastore.Datastore, filters map[string]*core.RelationshipFilter) {
+	_, err := ds.ReadWriteTx(t.Context(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		for name, filter := range filters {
+			if err := rwt.RegisterCounter(ctx, name, filter); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func writeUpdates(t *testing.T, ds datastore.Datastore, updates ...tuple.RelationshipUpdate) {
+	_, err := ds.ReadWriteTx(t.Context(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteRelationships(ctx, updates)
+	})
+	require.NoError(t, err)
+}
+
+func storedCount(t *testing.T, ds datastore.Datastore, name string) int {
+	headRev, err := ds.HeadRevision(t.Context())
+	require.NoError(t, err)
+
+	counters, err := ds.SnapshotReader(headRev).LookupCounters(t.Context())
+	require.NoError(t, err)
+
+	for _, counter := range counters {
+		if counter.Name == name {
+			return counter.Count
+		}
+	}
+
+	require.Failf(t, "counter not found", "counter %s is not registered", name)
+	return 0
+}
+
+// requireCountersMatch waits until the stored value of every counter matches
+// its count computed on demand.
+func requireCountersMatch(t *testing.T, ds datastore.Datastore) {
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		headRev, err := ds.HeadRevision(t.Context())
+		require.NoError(c, err)
+
+		reader := ds.SnapshotReader(headRev)
+		counters, err := reader.LookupCounters(t.Context())
+		require.NoError(c, err)
+
+		for _, counter := range counters {
+			require.NotEqual(c, datastore.NoRevision, counter.ComputedAtRevision, "counter %s has not been computed", counter.Name)
+
+			expected, err := reader.CountRelationships(t.Context(), counter.Name)
+			require.NoError(c, err)
+			require.Equal(c, expected, counter.Count, "mismatch for counter %s", counter.Name)
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,52 @@
+package counters
+
+import "time"
+
+const (
+	defaultFlushInterval = 1 * time.Second
+	defaultRetryDelay    = 5 * time.Second
+)
+
+type maintainerOptions struct {
+	flushInterval time.Duration
+	retryDelay    time.Duration
+}
+
+// Option provides the facility to configure optional behavior of the
+// counter Maintainer.
+type Option func(*maintainerOptions)
+
+func generateConfig(options []Option) maintainerOptions {
+	computed := maintainerOptions{
+		flushInterval: defaultFlushInterval,
+		retryDelay:    defaultRetryDelay,
+	}
+
+	for _, option := range options {
+		option(&computed)
+	}
+
+	return computed
+}
+
+// FlushInterval is the interval at which changed counter values are written
+// back to the datastore. At each interval, expired relationships are removed
+// from the counts and the set of registered counters is reloaded, to pick up
+// counters which have been registered or unregistered.
+//
+// This value defaults to 1 second.
+func FlushInterval(interval time.Duration) Option {
+	return func(mo *maintainerOptions) {
+		mo.flushInterval = interval
+	}
+}
+
+// RetryDelay is the delay before the counters are recomputed and the watch is
+// restarted, after the watch has failed.
+//
+// This value defaults to 5 seconds.
+func RetryDelay(delay time.Duration) Option {
+	return func(mo *maintainerOptions) {
+		mo.retryDelay = delay
+	}
+}
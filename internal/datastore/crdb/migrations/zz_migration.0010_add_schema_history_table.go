@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	addSchemaHistoryTableQuery = `
+		CREATE TABLE schema_history (
+			id UUID NOT NULL DEFAULT gen_random_uuid() PRIMARY KEY,
+			serialized_entry BYTEA NOT NULL
+		);
+	`
+)
+
+func init() {
+	err := CRDBMigrations.Register("add-schema-history-table", "add-expiration-support", addSchemaHistoryTable, noAtomicMigration)
+	if err != nil {
+		panic("failed to register migration: " + err.Error())
+	}
+}
+
+func addSchemaHistoryTable(ctx context.Context, conn *pgx.Conn) error {
+	if _, err := conn.Exec(ctx, addSchemaHistoryTableQuery); err != nil {
+		return err
+	}
+	return nil
+}
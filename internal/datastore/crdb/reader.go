@@ -25,6 +25,7 @@ const (
 	errUnableToReadConfig     = "unable to read namespace config: %w"
 	errUnableToListNamespaces = "unable to list namespaces: %w"
 	errUnableToReadCounter    = "unable to read relationship counter: %w"
+	errUnableToReadHistory    = "unable to read schema history: %w"
 )
 
 var (
@@ -38,6 +39,13 @@ var (
 		schema.ColCounterCurrentCount,
 		schema.ColCounterUpdatedAt,
 	)
+
+	// The entries of the schema history are ordered by the commit timestamps of the transactions
+	// which wrote them.
+	querySchemaHistory = psql.Select(
+		schema.ColSchemaHistoryEntry,
+		schema.ColMVCCTimestamp,
+	).OrderBy(schema.ColMVCCTimestamp + " DESC")
 )
 
 type crdbReader struct {
@@ -198,6 +206,55 @@ func (cr *crdbReader) lookupCounters(ctx context.Context, optionalFilterName str
 	return counters, nil
 }
 
+func (cr *crdbReader) ReadSchemaHistory(ctx context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	query := cr.addFromToQuery(querySchemaHistory, schema.TableSchemaHistory, noIndexHint)
+	if beforeRevision != datastore.NoRevision {
+		before, err := beforeRevision.(revisions.HLCRevision).AsDecimal()
+		if err != nil {
+			return nil, fmt.Errorf(errUnableToReadHistory, err)
+		}
+		query = query.Where(sq.Lt{schema.ColMVCCTimestamp: before})
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToReadHistory, err)
+	}
+	cr.assertHasExpectedAsOfSystemTime(sql)
+
+	var entries []datastore.SchemaHistoryEntry
+	err = cr.query.QueryFunc(ctx, func(ctx context.Context, rows pgx.Rows) error {
+		for rows.Next() {
+			var entryBytes []byte
+			var revisionDecimal decimal.Decimal
+			if err := rows.Scan(&entryBytes, &revisionDecimal); err != nil {
+				return err
+			}
+
+			loaded := &core.SchemaHistoryEntry{}
+			if err := loaded.UnmarshalVT(entryBytes); err != nil {
+				return err
+			}
+
+			revision, err := revisions.NewForHLC(revisionDecimal)
+			if err != nil {
+				return err
+			}
+
+			entries = append(entries, datastore.SchemaHistoryEntry{Entry: loaded, WrittenRevision: revision})
+		}
+		return rows.Err()
+	}, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToReadHistory, err)
+	}
+
+	return entries, nil
+}
+
 func (cr *crdbReader) ReadNamespaceByName(
 	ctx context.Context,
 	nsName string,
@@ -123,6 +123,8 @@ var (
 	queryUpdateCounter = psql.Update(schema.TableRelationshipCounter)
 
 	queryDeleteCounter = psql.Delete(schema.TableRelationshipCounter)
+
+	queryWriteSchemaHistory = psql.Insert(schema.TableSchemaHistory).Columns(schema.ColSchemaHistoryEntry)
 )
 
 func (rwt *crdbReadWriteTXN) insertQuery() sq.InsertBuilder {
@@ -276,6 +278,26 @@ func (rwt *crdbReadWriteTXN) StoreCounterValue(ctx context.Context, name string,
 	return nil
 }
 
+func (rwt *crdbReadWriteTXN) WriteSchemaHistoryEntry(ctx context.Context, entry *core.SchemaHistoryEntry) error {
+	rwt.hasNonExpiredDeletionChange = true
+
+	serialized, err := entry.MarshalVT()
+	if err != nil {
+		return fmt.Errorf("unable to write schema history: %w", err)
+	}
+
+	sql, args, err := queryWriteSchemaHistory.Values(serialized).ToSql()
+	if err != nil {
+		return fmt.Errorf("unable to write schema history: %w", err)
+	}
+
+	if _, err := rwt.tx.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("unable to write schema history: %w", err)
+	}
+
+	return nil
+}
+
 func (rwt *crdbReadWriteTXN) WriteRelationships(ctx context.Context, mutations []tuple.RelationshipUpdate) error {
 	bulkWrite := rwt.queryWriteTuple()
 	var bulkWriteCount int64
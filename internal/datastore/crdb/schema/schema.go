@@ -14,6 +14,7 @@ const (
 	TableCaveat              = "caveat"
 	TableRelationshipCounter = "relationship_counter"
 	TableTransactionMetadata = "transaction_metadata"
+	TableSchemaHistory       = "schema_history"
 
 	ColNamespace      = "namespace"
 	ColConfig         = "serialized_config"
@@ -42,6 +43,9 @@ const (
 	ColCounterUpdatedAt        = "updated_at_timestamp"
 	ColExpiresAt               = "expires_at"
 	ColMetadata                = "metadata"
+
+	ColSchemaHistoryEntry = "serialized_entry"
+	ColMVCCTimestamp      = "crdb_internal_mvcc_timestamp"
 )
 
 func Schema(colOptimizationOpt common.ColumnOptimizationOption, withIntegrity bool, expirationDisabled bool) *common.SchemaInformation {
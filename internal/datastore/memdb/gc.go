@@ -0,0 +1,237 @@
+package memdb
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/pkg/datastore"
+)
+
+var (
+	_ common.GarbageCollectableDatastore = (*memdbDatastore)(nil)
+	_ common.GarbageCollector            = (*memdbGarbageCollector)(nil)
+)
+
+type memdbGarbageCollector struct {
+	mdb      *memdbDatastore
+	gcWindow time.Duration
+}
+
+func (mdb *memdbDatastore) BuildGarbageCollector(_ context.Context) (common.GarbageCollector, error) {
+	return &memdbGarbageCollector{mdb: mdb, gcWindow: time.Duration(-1 * mdb.negativeGCWindow)}, nil
+}
+
+func (mdb *memdbDatastore) HasGCRun() bool {
+	return mdb.gcHasRun.Load()
+}
+
+func (mdb *memdbDatastore) MarkGCCompleted() {
+	mdb.gcHasRun.Store(true)
+}
+
+func (mdb *memdbDatastore) ResetGCCompleted() {
+	mdb.gcHasRun.Store(false)
+}
+
+func (mdb *memdbDatastore) startGarbageCollector(interval, window, timeout time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	mdb.gcCancel = cancel
+	mdb.gcDone = make(chan struct{})
+
+	go func() {
+		defer close(mdb.gcDone)
+		err := common.StartGarbageCollector(ctx, mdb, interval, window, timeout)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			log.Warn().Err(err).Msg("memdb garbage collection worker stopped")
+		}
+	}()
+}
+
+func (mdb *memdbDatastore) stopGarbageCollector() {
+	if mdb.gcCancel == nil {
+		return
+	}
+
+	mdb.gcCancel()
+	<-mdb.gcDone
+	mdb.gcCancel = nil
+}
+
+func (mgc *memdbGarbageCollector) Close() {}
+
+func (mgc *memdbGarbageCollector) LockForGCRun(_ context.Context) (bool, error) {
+	return mgc.mdb.gcLock.TryLock(), nil
+}
+
+func (mgc *memdbGarbageCollector) UnlockAfterGCRun() error {
+	mgc.mdb.gcLock.Unlock()
+	return nil
+}
+
+func (mgc *memdbGarbageCollector) Now(_ context.Context) (time.Time, error) {
+	return time.Now().UTC(), nil
+}
+
+// TxIDBefore returns the newest revision snapshot that is older than the
+// provided time.
+func (mgc *memdbGarbageCollector) TxIDBefore(ctx context.Context, before time.Time) (datastore.Revision, error) {
+	mgc.mdb.RLock()
+	defer mgc.mdb.RUnlock()
+
+	if err := mgc.mdb.checkNotClosed(); err != nil {
+		return datastore.NoRevision, err
+	}
+
+	beforeRev := revisions.NewForTime(before)
+	index, _ := slices.BinarySearchFunc(mgc.mdb.revisions, beforeRev, func(snap snapshot, target revisions.TimestampRevision) int {
+		if snap.revision.LessThan(target) {
+			return -1
+		}
+		return 1
+	})
+
+	if index == 0 {
+		log.Ctx(ctx).Debug().Time("before", before).Msg("no stale revisions found in the datastore")
+		return datastore.NoRevision, nil
+	}
+
+	return mgc.mdb.revisions[index-1].revision, nil
+}
+
+// DeleteBeforeTx drops all revision snapshots older than the provided revision,
+// along with their changelog entries. The snapshot at the provided revision is
+// kept so that there is always at least one revision present.
+func (mgc *memdbGarbageCollector) DeleteBeforeTx(ctx context.Context, txID datastore.Revision) (common.DeletionCounts, error) {
+	var removed common.DeletionCounts
+	if txID == datastore.NoRevision {
+		return removed, nil
+	}
+
+	watermark := txID.(revisions.TimestampRevision)
+
+	mgc.mdb.Lock()
+	defer mgc.mdb.Unlock()
+
+	if err := mgc.mdb.checkNotClosed(); err != nil {
+		return removed, err
+	}
+
+	index, _ := slices.BinarySearchFunc(mgc.mdb.revisions, watermark, func(snap snapshot, target revisions.TimestampRevision) int {
+		return cmp.Compare(snap.revision.TimestampNanoSec(), target.TimestampNanoSec())
+	})
+
+	if index > 0 {
+		// Copy the retained snapshots so the pruned ones can be released.
+		mgc.mdb.revisions = slices.Clone(mgc.mdb.revisions[index:])
+	}
+	if watermark.GreaterThan(mgc.mdb.gcWatermark) {
+		mgc.mdb.gcWatermark = watermark
+	}
+
+	if mgc.mdb.activeWriteTxn != nil {
+		// memdb permits a single writer; the changelog will be pruned on the next run.
+		log.Ctx(ctx).Debug().Msg("skipping memdb changelog garbage collection due to an active write transaction")
+		removed.Transactions = int64(index)
+		return removed, nil
+	}
+
+	tx := mgc.mdb.db.Txn(true)
+	defer tx.Abort()
+
+	it, err := tx.Get(tableChangelog, indexRevision)
+	if err != nil {
+		return removed, err
+	}
+
+	var stale []*changelog
+	for found := it.Next(); found != nil; found = it.Next() {
+		entry := found.(*changelog)
+		if entry.revisionNanos >= watermark.TimestampNanoSec() {
+			break
+		}
+		stale = append(stale, entry)
+	}
+
+	for _, entry := range stale {
+		if err := tx.Delete(tableChangelog, entry); err != nil {
+			return removed, err
+		}
+	}
+	tx.Commit()
+
+	removed.Transactions = int64(max(index, len(stale)))
+	return removed, nil
+}
+
+// DeleteExpiredRels removes relationships that expired before the GC window
+// from the current state of the datastore. The removal is committed as a
+// transaction of its own, so that it is persisted along with all other writes.
+func (mgc *memdbGarbageCollector) DeleteExpiredRels(ctx context.Context) (int64, error) {
+	cutoff := time.Now().Add(-1 * mgc.gcWindow)
+
+	// Look for expired relationships ahead of the write, so that no revision is
+	// created when there are none.
+	mgc.mdb.RLock()
+	if err := mgc.mdb.checkNotClosed(); err != nil {
+		mgc.mdb.RUnlock()
+		return 0, err
+	}
+	readTx := mgc.mdb.db.Txn(false)
+	mgc.mdb.RUnlock()
+	defer readTx.Abort()
+
+	expired, err := expiredRelationships(readTx, cutoff)
+	if err != nil || len(expired) == 0 {
+		return 0, err
+	}
+
+	var deleted int64
+	if _, err := mgc.mdb.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		tx, err := rwt.(*memdbReadWriteTx).txSource()
+		if err != nil {
+			return err
+		}
+
+		expired, err := expiredRelationships(tx, cutoff)
+		if err != nil {
+			return err
+		}
+
+		for _, rel := range expired {
+			if err := tx.Delete(tableRelationship, rel); err != nil {
+				return err
+			}
+		}
+
+		deleted = int64(len(expired))
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+func expiredRelationships(tx *memdb.Txn, cutoff time.Time) ([]*relationship, error) {
+	it, err := tx.Get(tableRelationship, indexID)
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []*relationship
+	for found := it.Next(); found != nil; found = it.Next() {
+		rel := found.(*relationship)
+		if rel.expiration != nil && rel.expiration.Before(cutoff) {
+			expired = append(expired, rel)
+		}
+	}
+	return expired, nil
+}
@@ -0,0 +1,114 @@
+package memdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+func TestGarbageCollectionPrunesRevisions(t *testing.T) {
+	gcWindow := 200 * time.Millisecond
+	ds, err := NewMemdbDatastore(0, 0, gcWindow)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ds.Close() })
+
+	staleRev := writeTestData(t, ds, "document:first#viewer@user:tom")
+	writeTestData(t, ds, "document:second#viewer@user:tom")
+
+	time.Sleep(gcWindow)
+	headRev := writeTestData(t, ds, "document:third#viewer@user:tom")
+
+	mdb := ds.(*memdbDatastore)
+	require.Len(t, mdb.revisions, 4)
+
+	require.NoError(t, common.RunGarbageCollection(mdb, gcWindow, 10*time.Second))
+	require.True(t, mdb.HasGCRun())
+
+	// The newest revision outside the window is retained alongside the head.
+	require.Len(t, mdb.revisions, 2)
+	require.Equal(t, 3, countRelationships(t, ds, headRev))
+
+	var invalidErr datastore.InvalidRevisionError
+	require.ErrorAs(t, ds.CheckRevision(t.Context(), staleRev), &invalidErr)
+	require.Equal(t, datastore.RevisionStale, invalidErr.Reason())
+	require.NoError(t, ds.CheckRevision(t.Context(), headRev))
+
+	tx := mdb.db.Txn(false)
+	defer tx.Abort()
+	it, err := tx.Get(tableChangelog, indexRevision)
+	require.NoError(t, err)
+
+	remaining := 0
+	for found := it.Next(); found != nil; found = it.Next() {
+		require.GreaterOrEqual(t, found.(*changelog).revisionNanos, mdb.revisions[0].revision.TimestampNanoSec())
+		remaining++
+	}
+	require.Equal(t, 2, remaining)
+}
+
+func TestGarbageCollectionDeletesExpiredRelationships(t *testing.T) {
+	gcWindow := 100 * time.Millisecond
+	ds, err := NewMemdbDatastore(0, 0, gcWindow)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ds.Close() })
+
+	writeTestData(t, ds, "document:first#viewer@user:tom")
+	_, err = ds.ReadWriteTx(t.Context(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteRelationships(ctx, []tuple.RelationshipUpdate{
+			tuple.Touch(tuple.MustParse("document:second#viewer@user:tom[expiration:2020-01-01T00:00:00Z]")),
+		})
+	})
+	require.NoError(t, err)
+
+	gc, err := ds.(*memdbDatastore).BuildGarbageCollector(t.Context())
+	require.NoError(t, err)
+	defer gc.Close()
+
+	deleted, err := gc.DeleteExpiredRels(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	headRev := writeTestData(t, ds)
+	require.Equal(t, 1, countRelationships(t, ds, headRev))
+}
+
+func TestGarbageCollectionPersistsExpiredRelationshipDeletion(t *testing.T) {
+	dir := t.TempDir()
+	gcWindow := 100 * time.Millisecond
+	ds, err := NewMemdbDatastore(0, 0, gcWindow, PersistenceDirectory(dir), SnapshotInterval(0))
+	require.NoError(t, err)
+
+	writeTestData(t, ds, "document:first#viewer@user:tom")
+	_, err = ds.ReadWriteTx(t.Context(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteRelationships(ctx, []tuple.RelationshipUpdate{
+			tuple.Touch(tuple.MustParse("document:second#viewer@user:tom[expiration:2020-01-01T00:00:00Z]")),
+		})
+	})
+	require.NoError(t, err)
+
+	gc, err := ds.(*memdbDatastore).BuildGarbageCollector(t.Context())
+	require.NoError(t, err)
+	defer gc.Close()
+
+	deleted, err := gc.DeleteExpiredRels(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+
+	// Simulate a crash, so that the deletion is only found in the transaction log.
+	restored, err := NewMemdbDatastore(0, 0, gcWindow, PersistenceDirectory(dir))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = restored.Close() })
+
+	tx := restored.(*memdbDatastore).db.Txn(false)
+	defer tx.Abort()
+
+	stored, err := expiredRelationships(tx, time.Now())
+	require.NoError(t, err)
+	require.Empty(t, stored)
+}
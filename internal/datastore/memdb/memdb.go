@@ -7,6 +7,7 @@ import (
 	"math"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -43,7 +44,10 @@ func NewMemdbDatastore(
 	watchBufferLength uint16,
 	revisionQuantization,
 	gcWindow time.Duration,
+	options ...Option,
 ) (datastore.Datastore, error) {
+	config := generateConfig(options)
+
 	if revisionQuantization > gcWindow {
 		return nil, errors.New("gc window must be larger than quantization interval")
 	}
@@ -62,7 +66,7 @@ func NewMemdbDatastore(
 	}
 
 	uniqueID := uuid.NewString()
-	return &memdbDatastore{
+	mdb := &memdbDatastore{
 		CommonDecoder: revisions.CommonDecoder{
 			Kind: revisions.Timestamp,
 		},
@@ -79,7 +83,27 @@ func NewMemdbDatastore(
 		watchBufferLength:       watchBufferLength,
 		watchBufferWriteTimeout: 100 * time.Millisecond,
 		uniqueID:                uniqueID,
-	}, nil
+	}
+
+	if config.persistenceDirectory != "" {
+		p, err := newPersistence(config.persistenceDirectory, config.snapshotInterval, gcWindow)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := mdb.restore(p); err != nil {
+			return nil, fmt.Errorf("unable to restore memdb from %s: %w", config.persistenceDirectory, err)
+		}
+
+		mdb.persistence = p
+		p.start(mdb)
+	}
+
+	if config.gcInterval > 0 && gcWindow != DisableGC {
+		mdb.startGarbageCollector(config.gcInterval, gcWindow, config.gcMaxOperationTime)
+	}
+
+	return mdb, nil
 }
 
 type memdbDatastore struct {
@@ -96,6 +120,13 @@ type memdbDatastore struct {
 	watchBufferLength       uint16
 	watchBufferWriteTimeout time.Duration
 	uniqueID                string
+	persistence             *persistence
+
+	gcWatermark revisions.TimestampRevision // GUARDED_BY(RWMutex)
+	gcLock      sync.Mutex
+	gcHasRun    atomic.Bool
+	gcCancel    context.CancelFunc
+	gcDone      chan struct{}
 }
 
 type snapshot struct {
@@ -217,102 +248,29 @@ func (mdb *memdbDatastore) ReadWriteTx(
 		mdb.Lock()
 		defer mdb.Unlock()
 
-		tracked := common.NewChanges(revisions.TimestampIDKeyFunc, datastore.WatchRelationships|datastore.WatchSchema, 0)
 		if tx != nil {
+			var metadata map[string]any
 			if config.Metadata != nil && len(config.Metadata.GetFields()) > 0 {
-				if err := tracked.AddRevisionMetadata(ctx, newRevision, config.Metadata.AsMap()); err != nil {
-					return datastore.NoRevision, err
-				}
-			}
-
-			for _, change := range tx.Changes() {
-				switch change.Table {
-				case tableRelationship:
-					switch {
-					case change.After != nil:
-						rt, err := change.After.(*relationship).Relationship()
-						if err != nil {
-							return datastore.NoRevision, err
-						}
-
-						if err := tracked.AddRelationshipChange(ctx, newRevision, rt, tuple.UpdateOperationTouch); err != nil {
-							return datastore.NoRevision, err
-						}
-					case change.After == nil && change.Before != nil:
-						rt, err := change.Before.(*relationship).Relationship()
-						if err != nil {
-							return datastore.NoRevision, err
-						}
-
-						if err := tracked.AddRelationshipChange(ctx, newRevision, rt, tuple.UpdateOperationDelete); err != nil {
-							return datastore.NoRevision, err
-						}
-					default:
-						return datastore.NoRevision, spiceerrors.MustBugf("unexpected relationship change")
-					}
-				case tableNamespace:
-					switch {
-					case change.After != nil:
-						loaded := &corev1.NamespaceDefinition{}
-						if err := loaded.UnmarshalVT(change.After.(*namespace).configBytes); err != nil {
-							return datastore.NoRevision, err
-						}
-
-						err := tracked.AddChangedDefinition(ctx, newRevision, loaded)
-						if err != nil {
-							return datastore.NoRevision, err
-						}
-					case change.After == nil && change.Before != nil:
-						err := tracked.AddDeletedNamespace(ctx, newRevision, change.Before.(*namespace).name)
-						if err != nil {
-							return datastore.NoRevision, err
-						}
-					default:
-						return datastore.NoRevision, spiceerrors.MustBugf("unexpected namespace change")
-					}
-				case tableCaveats:
-					switch {
-					case change.After != nil:
-						loaded := &corev1.CaveatDefinition{}
-						if err := loaded.UnmarshalVT(change.After.(*caveat).definition); err != nil {
-							return datastore.NoRevision, err
-						}
-
-						err := tracked.AddChangedDefinition(ctx, newRevision, loaded)
-						if err != nil {
-							return datastore.NoRevision, err
-						}
-					case change.After == nil && change.Before != nil:
-						err := tracked.AddDeletedCaveat(ctx, newRevision, change.Before.(*caveat).name)
-						if err != nil {
-							return datastore.NoRevision, err
-						}
-					default:
-						return datastore.NoRevision, spiceerrors.MustBugf("unexpected namespace change")
-					}
-				}
+				metadata = config.Metadata.AsMap()
 			}
 
-			var rc datastore.RevisionChanges
-			changes, err := tracked.AsRevisionChanges(revisions.TimestampIDKeyLessThanFunc)
+			change, err := changelogForChanges(ctx, newRevision, tx.Changes(), metadata)
 			if err != nil {
 				return datastore.NoRevision, err
 			}
 
-			if len(changes) > 1 {
-				return datastore.NoRevision, spiceerrors.MustBugf("unexpected MemDB transaction with multiple revision changes")
-			} else if len(changes) == 1 {
-				rc = changes[0]
-			}
-
-			change := &changelog{
-				revisionNanos: newRevision.TimestampNanoSec(),
-				changes:       rc,
-			}
 			if err := tx.Insert(tableChangelog, change); err != nil {
 				return datastore.NoRevision, fmt.Errorf("error writing changelog: %w", err)
 			}
 
+			if mdb.persistence != nil {
+				if err := mdb.persistence.appendTxn(newRevision, tx.Changes(), metadata); err != nil {
+					tx.Abort()
+					mdb.activeWriteTxn = nil
+					return datastore.NoRevision, fmt.Errorf("error persisting transaction: %w", err)
+				}
+			}
+
 			tx.Commit()
 		}
 		mdb.activeWriteTxn = nil
@@ -330,6 +288,102 @@ func (mdb *memdbDatastore) ReadWriteTx(
 	return datastore.NoRevision, NewSerializationMaxRetriesReachedErr(errors.New("serialization max retries exceeded; please reduce your parallel writes"))
 }
 
+// changelogForChanges computes the changelog entry for the set of changes tracked
+// by a memdb write transaction committed at the given revision.
+func changelogForChanges(ctx context.Context, newRevision revisions.TimestampRevision, changes memdb.Changes, metadata map[string]any) (*changelog, error) {
+	tracked := common.NewChanges(revisions.TimestampIDKeyFunc, datastore.WatchRelationships|datastore.WatchSchema, 0)
+	if len(metadata) > 0 {
+		if err := tracked.AddRevisionMetadata(ctx, newRevision, metadata); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, change := range changes {
+		switch change.Table {
+		case tableRelationship:
+			switch {
+			case change.After != nil:
+				rt, err := change.After.(*relationship).Relationship()
+				if err != nil {
+					return nil, err
+				}
+
+				if err := tracked.AddRelationshipChange(ctx, newRevision, rt, tuple.UpdateOperationTouch); err != nil {
+					return nil, err
+				}
+			case change.After == nil && change.Before != nil:
+				rt, err := change.Before.(*relationship).Relationship()
+				if err != nil {
+					return nil, err
+				}
+
+				if err := tracked.AddRelationshipChange(ctx, newRevision, rt, tuple.UpdateOperationDelete); err != nil {
+					return nil, err
+				}
+			default:
+				return nil, spiceerrors.MustBugf("unexpected relationship change")
+			}
+		case tableNamespace:
+			switch {
+			case change.After != nil:
+				loaded := &corev1.NamespaceDefinition{}
+				if err := loaded.UnmarshalVT(change.After.(*namespace).configBytes); err != nil {
+					return nil, err
+				}
+
+				err := tracked.AddChangedDefinition(ctx, newRevision, loaded)
+				if err != nil {
+					return nil, err
+				}
+			case change.After == nil && change.Before != nil:
+				err := tracked.AddDeletedNamespace(ctx, newRevision, change.Before.(*namespace).name)
+				if err != nil {
+					return nil, err
+				}
+			default:
+				return nil, spiceerrors.MustBugf("unexpected namespace change")
+			}
+		case tableCaveats:
+			switch {
+			case change.After != nil:
+				loaded := &corev1.CaveatDefinition{}
+				if err := loaded.UnmarshalVT(change.After.(*caveat).definition); err != nil {
+					return nil, err
+				}
+
+				err := tracked.AddChangedDefinition(ctx, newRevision, loaded)
+				if err != nil {
+					return nil, err
+				}
+			case change.After == nil && change.Before != nil:
+				err := tracked.AddDeletedCaveat(ctx, newRevision, change.Before.(*caveat).name)
+				if err != nil {
+					return nil, err
+				}
+			default:
+				return nil, spiceerrors.MustBugf("unexpected namespace change")
+			}
+		}
+	}
+
+	var rc datastore.RevisionChanges
+	revChanges, err := tracked.AsRevisionChanges(revisions.TimestampIDKeyLessThanFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(revChanges) > 1 {
+		return nil, spiceerrors.MustBugf("unexpected MemDB transaction with multiple revision changes")
+	} else if len(revChanges) == 1 {
+		rc = revChanges[0]
+	}
+
+	return &changelog{
+		revisionNanos: newRevision.TimestampNanoSec(),
+		changes:       rc,
+	}, nil
+}
+
 func (mdb *memdbDatastore) ReadyState(_ context.Context) (datastore.ReadyState, error) {
 	mdb.RLock()
 	defer mdb.RUnlock()
@@ -362,6 +416,13 @@ func (mdb *memdbDatastore) Features(_ context.Context) (*datastore.Features, err
 }
 
 func (mdb *memdbDatastore) Close() error {
+	mdb.stopGarbageCollector()
+
+	var persistErr error
+	if mdb.persistence != nil {
+		persistErr = mdb.persistence.stop(mdb)
+	}
+
 	mdb.Lock()
 	defer mdb.Unlock()
 
@@ -378,7 +439,7 @@ func (mdb *memdbDatastore) Close() error {
 
 	mdb.db = nil
 
-	return nil
+	return persistErr
 }
 
 // This code assumes that the RWMutex has been acquired.
@@ -21,8 +21,8 @@ import (
 
 type memDBTest struct{}
 
-func (mdbt memDBTest) New(revisionQuantization, _, gcWindow time.Duration, watchBufferLength uint16) (datastore.Datastore, error) {
-	return NewMemdbDatastore(watchBufferLength, revisionQuantization, gcWindow)
+func (mdbt memDBTest) New(revisionQuantization, gcInterval, gcWindow time.Duration, watchBufferLength uint16) (datastore.Datastore, error) {
+	return NewMemdbDatastore(watchBufferLength, revisionQuantization, gcWindow, GCInterval(gcInterval))
 }
 
 func TestMemdbDatastore(t *testing.T) {
@@ -0,0 +1,77 @@
+package memdb
+
+import "time"
+
+const (
+	defaultSnapshotInterval   = 5 * time.Minute
+	defaultGCMaxOperationTime = 1 * time.Minute
+)
+
+type memdbOptions struct {
+	persistenceDirectory string
+	snapshotInterval     time.Duration
+	gcInterval           time.Duration
+	gcMaxOperationTime   time.Duration
+}
+
+// Option provides the facility to configure optional behavior of the memdb
+// datastore.
+type Option func(*memdbOptions)
+
+func generateConfig(options []Option) memdbOptions {
+	computed := memdbOptions{
+		snapshotInterval:   defaultSnapshotInterval,
+		gcMaxOperationTime: defaultGCMaxOperationTime,
+	}
+
+	for _, option := range options {
+		option(&computed)
+	}
+
+	return computed
+}
+
+// PersistenceDirectory enables durable storage for the datastore. Periodic
+// snapshots and an append-only log of every committed transaction are written
+// to the directory, and replayed when a datastore is created over it. Files
+// are retained for the GC window, so that revisions within it remain readable
+// after a restart.
+//
+// This value defaults to empty, which disables persistence.
+func PersistenceDirectory(directory string) Option {
+	return func(mo *memdbOptions) {
+		mo.persistenceDirectory = directory
+	}
+}
+
+// SnapshotInterval is the interval at which a full snapshot of the datastore
+// is written to the persistence directory, from which the datastore is
+// restored once its revision falls outside the GC window.
+//
+// This value defaults to 5 minutes. A value of zero disables periodic
+// snapshots; a snapshot is still written when the datastore is closed.
+func SnapshotInterval(interval time.Duration) Option {
+	return func(mo *memdbOptions) {
+		mo.snapshotInterval = interval
+	}
+}
+
+// GCInterval is the interval at which garbage collection runs in the
+// background, pruning revisions which have fallen outside of the GC window.
+//
+// This value defaults to zero, which disables background garbage collection.
+func GCInterval(interval time.Duration) Option {
+	return func(mo *memdbOptions) {
+		mo.gcInterval = interval
+	}
+}
+
+// GCMaxOperationTime is the maximum operation time of a garbage collection
+// pass before it times out.
+//
+// This value defaults to 1 minute.
+func GCMaxOperationTime(maxOperationTime time.Duration) Option {
+	return func(mo *memdbOptions) {
+		mo.gcMaxOperationTime = maxOperationTime
+	}
+}
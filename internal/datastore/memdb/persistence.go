@@ -0,0 +1,812 @@
+package memdb
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-memdb"
+
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/pkg/datastore"
+)
+
+const (
+	metadataFilename = "metadata.json"
+	snapshotPrefix   = "snapshot-"
+	snapshotSuffix   = ".jsonl"
+	changelogPrefix  = "changelog-"
+	changelogSuffix  = ".log"
+	tempSuffix       = ".tmp"
+)
+
+// persistedTables are the tables whose contents are written to snapshots and
+// the transaction log. The changelog table is rebuilt on replay.
+var persistedTables = []string{tableNamespace, tableCaveats, tableCounters, tableSchemaHistory, tableRelationship}
+
+// persistence writes the state of a memdb datastore to a local directory, as a
+// snapshot of all persisted tables plus an append-only log of every transaction
+// committed since that snapshot.
+//
+// Log files are named by the revision of the snapshot they follow, and are
+// rotated under the datastore lock whenever a new snapshot is taken, so that a
+// snapshot together with all log files present is always a complete picture of
+// the datastore, even if the process dies while the snapshot is being written.
+//
+// Snapshots and logs are retained for the GC window, so that the datastore can
+// be restored from the oldest retained snapshot and every revision since then
+// recreated by replaying the logs.
+type persistence struct {
+	directory        string
+	snapshotInterval time.Duration
+	retention        time.Duration
+
+	log              transactionLog              // GUARDED_BY(memdbDatastore.RWMutex)
+	logFailure       error                       // GUARDED_BY(memdbDatastore.RWMutex)
+	lastSnapshotted  revisions.TimestampRevision // GUARDED_BY(memdbDatastore.RWMutex)
+	snapshotInFlight sync.Mutex
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// transactionLog is the open file of the transaction log.
+type transactionLog interface {
+	io.WriteSeeker
+	io.Closer
+	Sync() error
+	Truncate(size int64) error
+}
+
+type persistedMetadata struct {
+	UniqueID string `json:"unique_id"`
+}
+
+type persistedSnapshotHeader struct {
+	RevisionNanos int64 `json:"revision"`
+}
+
+type persistedTxn struct {
+	RevisionNanos int64             `json:"revision"`
+	Metadata      map[string]any    `json:"metadata,omitempty"`
+	Records       []persistedRecord `json:"records"`
+}
+
+type persistedRecord struct {
+	Table         string                  `json:"table"`
+	Deleted       bool                    `json:"deleted,omitempty"`
+	Namespace     *persistedNamespace     `json:"namespace,omitempty"`
+	Caveat        *persistedCaveat        `json:"caveat,omitempty"`
+	Counter       *persistedCounter       `json:"counter,omitempty"`
+	SchemaHistory *persistedSchemaHistory `json:"schema_history,omitempty"`
+	Relationship  *persistedRelationship  `json:"relationship,omitempty"`
+}
+
+type persistedNamespace struct {
+	Name         string `json:"name"`
+	Config       []byte `json:"config"`
+	UpdatedNanos int64  `json:"updated"`
+}
+
+type persistedCaveat struct {
+	Name          string `json:"name"`
+	Definition    []byte `json:"definition"`
+	RevisionNanos int64  `json:"revision"`
+}
+
+type persistedCounter struct {
+	Name         string `json:"name"`
+	Filter       []byte `json:"filter"`
+	Count        int    `json:"count"`
+	UpdatedNanos int64  `json:"updated,omitempty"`
+}
+
+type persistedSchemaHistory struct {
+	RevisionNanos int64  `json:"revision"`
+	Entry         []byte `json:"entry"`
+}
+
+type persistedRelationship struct {
+	Namespace        string              `json:"namespace"`
+	ResourceID       string              `json:"resource_id"`
+	Relation         string              `json:"relation"`
+	SubjectNamespace string              `json:"subject_namespace"`
+	SubjectObjectID  string              `json:"subject_object_id"`
+	SubjectRelation  string              `json:"subject_relation"`
+	CaveatName       string              `json:"caveat_name,omitempty"`
+	CaveatContext    map[string]any      `json:"caveat_context,omitempty"`
+	Integrity        *persistedIntegrity `json:"integrity,omitempty"`
+	Expiration       *time.Time          `json:"expiration,omitempty"`
+}
+
+type persistedIntegrity struct {
+	KeyID     string    `json:"key_id"`
+	Hash      []byte    `json:"hash"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+func newPersistence(directory string, snapshotInterval, retention time.Duration) (*persistence, error) {
+	if err := os.MkdirAll(directory, 0o755); err != nil {
+		return nil, fmt.Errorf("unable to create persistence directory: %w", err)
+	}
+
+	return &persistence{
+		directory:        directory,
+		snapshotInterval: snapshotInterval,
+		retention:        retention,
+		done:             make(chan struct{}),
+	}, nil
+}
+
+// restore loads the oldest retained snapshot and replays the transaction logs
+// found in the persistence directory, recreating a revision snapshot for every
+// replayed transaction so that revisions issued before the restart remain
+// readable. Revisions older than the restored snapshot are treated as having
+// been garbage collected.
+func (mdb *memdbDatastore) restore(p *persistence) error {
+	mdb.Lock()
+	defer mdb.Unlock()
+
+	uniqueID, err := p.loadOrWriteMetadata(mdb.uniqueID)
+	if err != nil {
+		return err
+	}
+	mdb.uniqueID = uniqueID
+
+	snapshots, err := p.listFiles(snapshotPrefix, snapshotSuffix)
+	if err != nil {
+		return err
+	}
+
+	logs, err := p.listFiles(changelogPrefix, changelogSuffix)
+	if err != nil {
+		return err
+	}
+
+	base, found := restoreBase(snapshots, logs)
+	switch {
+	case found:
+		rev, err := p.loadSnapshot(mdb.db, base.name)
+		if err != nil {
+			return fmt.Errorf("unable to load snapshot %s: %w", base.name, err)
+		}
+
+		mdb.revisions = []snapshot{{revision: rev, db: mdb.db.Snapshot()}}
+		mdb.gcWatermark = rev
+		p.lastSnapshotted = revisions.NewForTimestamp(snapshots[len(snapshots)-1].revisionNanos)
+
+	case len(logs) > 0:
+		// The oldest log was started by an empty datastore, before any
+		// snapshot was written.
+		mdb.revisions = []snapshot{{revision: revisions.NewForTimestamp(logs[0].revisionNanos), db: mdb.db.Snapshot()}}
+	}
+
+	for index, logFile := range logs {
+		if found && logFile.revisionNanos < base.revisionNanos {
+			continue
+		}
+
+		if err := p.replayLog(mdb, logFile.name, index == len(logs)-1); err != nil {
+			return fmt.Errorf("unable to replay transaction log %s: %w", logFile.name, err)
+		}
+	}
+
+	return p.rotateLogCallerMustLock(mdb.headRevisionNoLock())
+}
+
+// restoreBase returns the snapshot from which the datastore is restored: the
+// oldest snapshot whose transaction log is present, so that every transaction
+// committed after it can be replayed. No snapshot is needed if the oldest log
+// precedes all snapshots, as it was then started by an empty datastore.
+func restoreBase(snapshots, logs []persistedFile) (persistedFile, bool) {
+	if len(snapshots) == 0 || (len(logs) > 0 && logs[0].revisionNanos < snapshots[0].revisionNanos) {
+		return persistedFile{}, false
+	}
+
+	for _, candidate := range snapshots {
+		if slices.ContainsFunc(logs, func(logFile persistedFile) bool {
+			return logFile.revisionNanos == candidate.revisionNanos
+		}) {
+			return candidate, true
+		}
+	}
+
+	return snapshots[len(snapshots)-1], true
+}
+
+func (p *persistence) loadOrWriteMetadata(uniqueID string) (string, error) {
+	path := filepath.Join(p.directory, metadataFilename)
+	contents, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		var metadata persistedMetadata
+		if err := json.Unmarshal(contents, &metadata); err != nil {
+			return "", fmt.Errorf("unable to decode persisted metadata: %w", err)
+		}
+		return metadata.UniqueID, nil
+
+	case errors.Is(err, os.ErrNotExist):
+		contents, err := json.Marshal(persistedMetadata{UniqueID: uniqueID})
+		if err != nil {
+			return "", err
+		}
+		return uniqueID, p.writeFileAtomically(metadataFilename, func(w io.Writer) error {
+			_, err := w.Write(contents)
+			return err
+		})
+
+	default:
+		return "", fmt.Errorf("unable to read persisted metadata: %w", err)
+	}
+}
+
+type persistedFile struct {
+	name          string
+	revisionNanos int64
+}
+
+// listFiles returns the files in the persistence directory with the given prefix
+// and suffix, sorted by the revision encoded in their names.
+func (p *persistence) listFiles(prefix, suffix string) ([]persistedFile, error) {
+	entries, err := os.ReadDir(p.directory)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list persistence directory: %w", err)
+	}
+
+	var files []persistedFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+			continue
+		}
+
+		revisionNanos, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(name, prefix), suffix), 10, 64)
+		if err != nil {
+			log.Warn().Str("file", name).Msg("ignoring unrecognized file in memdb persistence directory")
+			continue
+		}
+
+		files = append(files, persistedFile{name, revisionNanos})
+	}
+
+	slices.SortFunc(files, func(a, b persistedFile) int {
+		return cmp.Compare(a.revisionNanos, b.revisionNanos)
+	})
+	return files, nil
+}
+
+func (p *persistence) loadSnapshot(db *memdb.MemDB, name string) (revisions.TimestampRevision, error) {
+	f, err := os.Open(filepath.Join(p.directory, name))
+	if err != nil {
+		return revisions.TimestampRevision(0), err
+	}
+	defer f.Close()
+
+	decoder := json.NewDecoder(bufio.NewReader(f))
+
+	var header persistedSnapshotHeader
+	if err := decoder.Decode(&header); err != nil {
+		return revisions.TimestampRevision(0), fmt.Errorf("unable to decode snapshot header: %w", err)
+	}
+
+	tx := db.Txn(true)
+	defer tx.Abort()
+
+	for {
+		var record persistedRecord
+		if err := decoder.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return revisions.TimestampRevision(0), fmt.Errorf("unable to decode snapshot record: %w", err)
+		}
+
+		if err := record.apply(tx); err != nil {
+			return revisions.TimestampRevision(0), err
+		}
+	}
+
+	tx.Commit()
+	return revisions.NewForTimestamp(header.RevisionNanos), nil
+}
+
+// replayLog applies every transaction in the log file that is newer than the
+// current head revision. A torn final entry in the last log file, left behind
+// by a crash in the middle of an append, is discarded.
+func (p *persistence) replayLog(mdb *memdbDatastore, name string, isLast bool) error {
+	path := filepath.Join(p.directory, name)
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	var offset int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			if len(bytes.TrimSpace(line)) == 0 {
+				return nil
+			}
+
+			if !isLast {
+				return errors.New("transaction log is truncated")
+			}
+
+			log.Warn().Str("file", name).Msg("discarding incomplete trailing entry in memdb transaction log")
+			return os.Truncate(path, offset)
+		} else if err != nil {
+			return err
+		}
+		offset += int64(len(line))
+
+		var txn persistedTxn
+		if err := json.Unmarshal(line, &txn); err != nil {
+			return fmt.Errorf("unable to decode transaction log entry: %w", err)
+		}
+
+		if txn.RevisionNanos <= mdb.headRevisionNoLock().TimestampNanoSec() {
+			continue
+		}
+
+		if err := mdb.replayTxnCallerMustLock(txn); err != nil {
+			return err
+		}
+	}
+}
+
+func (mdb *memdbDatastore) replayTxnCallerMustLock(txn persistedTxn) error {
+	rev := revisions.NewForTimestamp(txn.RevisionNanos)
+
+	tx := mdb.db.Txn(true)
+	tx.TrackChanges()
+	defer tx.Abort()
+
+	for _, record := range txn.Records {
+		if err := record.apply(tx); err != nil {
+			return err
+		}
+	}
+
+	change, err := changelogForChanges(context.Background(), rev, tx.Changes(), txn.Metadata)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Insert(tableChangelog, change); err != nil {
+		return fmt.Errorf("error writing changelog: %w", err)
+	}
+
+	tx.Commit()
+	mdb.revisions = append(mdb.revisions, snapshot{rev, mdb.db.Snapshot()})
+	return nil
+}
+
+// appendTxn writes the persisted tables' changes of a transaction to the log.
+// The caller must hold the datastore's write lock.
+func (p *persistence) appendTxn(rev revisions.TimestampRevision, changes memdb.Changes, metadata map[string]any) error {
+	txn := persistedTxn{
+		RevisionNanos: rev.TimestampNanoSec(),
+		Metadata:      metadata,
+		Records:       make([]persistedRecord, 0, len(changes)),
+	}
+
+	for _, change := range changes {
+		if !slices.Contains(persistedTables, change.Table) {
+			continue
+		}
+
+		if change.Deleted() {
+			record, err := toPersistedRecord(change.Table, change.Before)
+			if err != nil {
+				return err
+			}
+			record.Deleted = true
+			txn.Records = append(txn.Records, record)
+			continue
+		}
+
+		record, err := toPersistedRecord(change.Table, change.After)
+		if err != nil {
+			return err
+		}
+		txn.Records = append(txn.Records, record)
+	}
+
+	line, err := json.Marshal(txn)
+	if err != nil {
+		return err
+	}
+
+	if p.logFailure != nil {
+		return fmt.Errorf("transaction log is unusable: %w", p.logFailure)
+	}
+
+	// Record the end of the log, so that the entry can be removed if it cannot be
+	// fully written, as it would otherwise be replayed on restore despite the
+	// transaction failing, or prevent the restore if torn.
+	offset, err := p.log.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	if _, err := p.log.Write(append(line, '\n')); err != nil {
+		return p.truncateLogCallerMustLock(offset, err)
+	}
+
+	if err := p.log.Sync(); err != nil {
+		return p.truncateLogCallerMustLock(offset, err)
+	}
+	return nil
+}
+
+// truncateLogCallerMustLock removes the entry being written at the offset from
+// the transaction log after the write failed with the given error. If the entry
+// cannot be removed, the log is marked as failed and all further writes are
+// rejected.
+func (p *persistence) truncateLogCallerMustLock(offset int64, writeErr error) error {
+	if err := p.log.Truncate(offset); err != nil {
+		p.logFailure = errors.Join(writeErr, err)
+		return p.logFailure
+	}
+
+	if _, err := p.log.Seek(offset, io.SeekStart); err != nil {
+		p.logFailure = errors.Join(writeErr, err)
+		return p.logFailure
+	}
+
+	return writeErr
+}
+
+// rotateLogCallerMustLock closes the current transaction log, if any, and opens
+// the log which will hold transactions committed after the given revision.
+func (p *persistence) rotateLogCallerMustLock(rev revisions.TimestampRevision) error {
+	if p.log != nil {
+		if err := p.log.Close(); err != nil {
+			return err
+		}
+		p.log = nil
+	}
+
+	name := fmt.Sprintf("%s%020d%s", changelogPrefix, rev.TimestampNanoSec(), changelogSuffix)
+	f, err := os.OpenFile(filepath.Join(p.directory, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("unable to open transaction log: %w", err)
+	}
+
+	p.log = f
+	return nil
+}
+
+// writeSnapshot writes a snapshot of the head revision of the datastore and
+// removes the snapshot and log files that it supersedes.
+func (p *persistence) writeSnapshot(mdb *memdbDatastore) error {
+	p.snapshotInFlight.Lock()
+	defer p.snapshotInFlight.Unlock()
+
+	mdb.Lock()
+	if err := mdb.checkNotClosed(); err != nil {
+		mdb.Unlock()
+		return err
+	}
+
+	head := mdb.headRevisionNoLock()
+	if head.Equal(p.lastSnapshotted) {
+		mdb.Unlock()
+		return nil
+	}
+
+	db := mdb.db.Snapshot()
+	if err := p.rotateLogCallerMustLock(head); err != nil {
+		mdb.Unlock()
+		return err
+	}
+	mdb.Unlock()
+
+	name := fmt.Sprintf("%s%020d%s", snapshotPrefix, head.TimestampNanoSec(), snapshotSuffix)
+	if err := p.writeFileAtomically(name, func(w io.Writer) error {
+		return encodeSnapshot(w, head, db)
+	}); err != nil {
+		return fmt.Errorf("unable to write snapshot: %w", err)
+	}
+
+	mdb.Lock()
+	if head.GreaterThan(p.lastSnapshotted) {
+		p.lastSnapshotted = head
+	}
+	mdb.Unlock()
+
+	return p.removeExpiredFiles()
+}
+
+func encodeSnapshot(w io.Writer, head revisions.TimestampRevision, db *memdb.MemDB) error {
+	encoder := json.NewEncoder(w)
+	if err := encoder.Encode(persistedSnapshotHeader{RevisionNanos: head.TimestampNanoSec()}); err != nil {
+		return err
+	}
+
+	tx := db.Txn(false)
+	defer tx.Abort()
+
+	for _, table := range persistedTables {
+		it, err := tx.Get(table, indexID)
+		if err != nil {
+			return err
+		}
+
+		for found := it.Next(); found != nil; found = it.Next() {
+			record, err := toPersistedRecord(table, found)
+			if err != nil {
+				return err
+			}
+
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// removeExpiredFiles removes the snapshots and logs which are only needed to
+// restore revisions older than the retention window, keeping the newest
+// snapshot taken before the window began as the base of those which remain.
+func (p *persistence) removeExpiredFiles() error {
+	snapshots, err := p.listFiles(snapshotPrefix, snapshotSuffix)
+	if err != nil {
+		return err
+	}
+
+	horizon := time.Now().UnixNano() - p.retention.Nanoseconds()
+	index := slices.IndexFunc(snapshots, func(candidate persistedFile) bool {
+		return candidate.revisionNanos > horizon
+	})
+	if index < 0 {
+		index = len(snapshots)
+	}
+	if index == 0 {
+		return nil
+	}
+	base := snapshots[index-1]
+
+	logs, err := p.listFiles(changelogPrefix, changelogSuffix)
+	if err != nil {
+		return err
+	}
+
+	// Logs are removed ahead of the snapshots they follow, so that a restore
+	// after an interrupted removal never mistakes them for the logs of an
+	// empty datastore.
+	for _, file := range slices.Concat(logs, snapshots[:index-1]) {
+		if file.revisionNanos >= base.revisionNanos {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(p.directory, file.name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeFileAtomically writes a file into the persistence directory by way of a
+// temporary file, so that readers never observe a partially written file.
+func (p *persistence) writeFileAtomically(name string, write func(w io.Writer) error) error {
+	path := filepath.Join(p.directory, name)
+	f, err := os.Create(path + tempSuffix)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(path + tempSuffix)
+	defer f.Close()
+
+	buffered := bufio.NewWriter(f)
+	if err := write(buffered); err != nil {
+		return err
+	}
+
+	if err := buffered.Flush(); err != nil {
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(path+tempSuffix, path); err != nil {
+		return err
+	}
+
+	dir, err := os.Open(p.directory)
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
+// start begins writing periodic snapshots in the background.
+func (p *persistence) start(mdb *memdbDatastore) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	go func() {
+		defer close(p.done)
+		if p.snapshotInterval <= 0 {
+			return
+		}
+
+		ticker := time.NewTicker(p.snapshotInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := p.writeSnapshot(mdb); err != nil {
+					log.Warn().Err(err).Msg("unable to write memdb snapshot")
+				}
+			}
+		}
+	}()
+}
+
+// stop halts periodic snapshots, writes a final snapshot and closes the
+// transaction log.
+func (p *persistence) stop(mdb *memdbDatastore) error {
+	if p.cancel == nil {
+		return nil
+	}
+	p.cancel()
+	p.cancel = nil
+	<-p.done
+
+	err := p.writeSnapshot(mdb)
+	if errors.Is(err, ErrMemDBIsClosed) {
+		err = nil
+	}
+
+	mdb.Lock()
+	defer mdb.Unlock()
+	if p.log != nil {
+		err = errors.Join(err, p.log.Close())
+		p.log = nil
+	}
+
+	return err
+}
+
+func revisionNanos(rev datastore.Revision) int64 {
+	if tr, ok := rev.(revisions.TimestampRevision); ok {
+		return tr.TimestampNanoSec()
+	}
+	return 0
+}
+
+func toPersistedRecord(table string, obj any) (persistedRecord, error) {
+	record := persistedRecord{Table: table}
+	switch table {
+	case tableNamespace:
+		ns := obj.(*namespace)
+		record.Namespace = &persistedNamespace{ns.name, ns.configBytes, revisionNanos(ns.updated)}
+
+	case tableCaveats:
+		c := obj.(*caveat)
+		record.Caveat = &persistedCaveat{c.name, c.definition, revisionNanos(c.revision)}
+
+	case tableCounters:
+		c := obj.(*counter)
+		record.Counter = &persistedCounter{c.name, c.filterBytes, c.count, revisionNanos(c.updated)}
+
+	case tableSchemaHistory:
+		e := obj.(*schemaHistoryEntry)
+		record.SchemaHistory = &persistedSchemaHistory{e.revisionNanos, e.entryBytes}
+
+	case tableRelationship:
+		r := obj.(*relationship)
+		pr := &persistedRelationship{
+			Namespace:        r.namespace,
+			ResourceID:       r.resourceID,
+			Relation:         r.relation,
+			SubjectNamespace: r.subjectNamespace,
+			SubjectObjectID:  r.subjectObjectID,
+			SubjectRelation:  r.subjectRelation,
+			Expiration:       r.expiration,
+		}
+		if r.caveat != nil {
+			pr.CaveatName = r.caveat.caveatName
+			pr.CaveatContext = r.caveat.context
+		}
+		if r.integrity != nil {
+			pr.Integrity = &persistedIntegrity{r.integrity.keyID, r.integrity.hash, r.integrity.timestamp}
+		}
+		record.Relationship = pr
+
+	default:
+		return record, fmt.Errorf("unsupported table for persistence: %s", table)
+	}
+
+	return record, nil
+}
+
+func (pr persistedRecord) object() (any, error) {
+	switch {
+	case pr.Table == tableNamespace && pr.Namespace != nil:
+		return &namespace{pr.Namespace.Name, pr.Namespace.Config, revisions.NewForTimestamp(pr.Namespace.UpdatedNanos)}, nil
+
+	case pr.Table == tableCaveats && pr.Caveat != nil:
+		return &caveat{pr.Caveat.Name, pr.Caveat.Definition, revisions.NewForTimestamp(pr.Caveat.RevisionNanos)}, nil
+
+	case pr.Table == tableCounters && pr.Counter != nil:
+		updated := datastore.NoRevision
+		if pr.Counter.UpdatedNanos != 0 {
+			updated = revisions.NewForTimestamp(pr.Counter.UpdatedNanos)
+		}
+		return &counter{pr.Counter.Name, pr.Counter.Filter, pr.Counter.Count, updated}, nil
+
+	case pr.Table == tableSchemaHistory && pr.SchemaHistory != nil:
+		return &schemaHistoryEntry{pr.SchemaHistory.RevisionNanos, pr.SchemaHistory.Entry}, nil
+
+	case pr.Table == tableRelationship && pr.Relationship != nil:
+		r := pr.Relationship
+		rel := &relationship{
+			namespace:        r.Namespace,
+			resourceID:       r.ResourceID,
+			relation:         r.Relation,
+			subjectNamespace: r.SubjectNamespace,
+			subjectObjectID:  r.SubjectObjectID,
+			subjectRelation:  r.SubjectRelation,
+			expiration:       r.Expiration,
+		}
+		if r.CaveatName != "" {
+			rel.caveat = &contextualizedCaveat{r.CaveatName, r.CaveatContext}
+		}
+		if r.Integrity != nil {
+			rel.integrity = &relationshipIntegrity{r.Integrity.KeyID, r.Integrity.Hash, r.Integrity.Timestamp}
+		}
+		return rel, nil
+
+	default:
+		return nil, fmt.Errorf("invalid persisted record for table %q", pr.Table)
+	}
+}
+
+func (pr persistedRecord) apply(tx *memdb.Txn) error {
+	obj, err := pr.object()
+	if err != nil {
+		return err
+	}
+
+	if pr.Deleted {
+		if err := tx.Delete(pr.Table, obj); err != nil && !errors.Is(err, memdb.ErrNotFound) {
+			return fmt.Errorf("error replaying delete from %s: %w", pr.Table, err)
+		}
+		return nil
+	}
+
+	if err := tx.Insert(pr.Table, obj); err != nil {
+		return fmt.Errorf("error replaying insert into %s: %w", pr.Table, err)
+	}
+	return nil
+}
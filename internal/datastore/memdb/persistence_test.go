@@ -0,0 +1,283 @@
+package memdb
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/pkg/datastore"
+	ns "github.com/authzed/spicedb/pkg/namespace"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+func writeTestData(t *testing.T, ds datastore.Datastore, rels ...string) datastore.Revision {
+	t.Helper()
+
+	updates := make([]tuple.RelationshipUpdate, 0, len(rels))
+	for _, rel := range rels {
+		updates = append(updates, tuple.Touch(tuple.MustParse(rel)))
+	}
+
+	rev, err := ds.ReadWriteTx(t.Context(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		if err := rwt.WriteNamespaces(ctx, ns.Namespace("user"), ns.Namespace("document", ns.MustRelation("viewer", nil))); err != nil {
+			return err
+		}
+		return rwt.WriteRelationships(ctx, updates)
+	})
+	require.NoError(t, err)
+	return rev
+}
+
+func countRelationships(t *testing.T, ds datastore.Datastore, rev datastore.Revision) int {
+	t.Helper()
+
+	iter, err := ds.SnapshotReader(rev).QueryRelationships(t.Context(), datastore.RelationshipsFilter{
+		OptionalResourceType: "document",
+	})
+	require.NoError(t, err)
+
+	count := 0
+	for _, err := range iter {
+		require.NoError(t, err)
+		count++
+	}
+	return count
+}
+
+func TestPersistenceRestoresSnapshot(t *testing.T) {
+	dir := t.TempDir()
+
+	ds, err := NewMemdbDatastore(0, 0, 1*time.Hour, PersistenceDirectory(dir))
+	require.NoError(t, err)
+
+	uniqueID, err := ds.UniqueID(t.Context())
+	require.NoError(t, err)
+
+	firstRev := writeTestData(t, ds, "document:first#viewer@user:tom")
+	secondRev := writeTestData(t, ds, "document:second#viewer@user:fred")
+	require.NoError(t, ds.Close())
+
+	snapshots, err := filepath.Glob(filepath.Join(dir, snapshotPrefix+"*"))
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+
+	restored, err := NewMemdbDatastore(0, 0, 1*time.Hour, PersistenceDirectory(dir))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = restored.Close() })
+
+	restoredID, err := restored.UniqueID(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, uniqueID, restoredID)
+
+	head, err := restored.HeadRevision(t.Context())
+	require.NoError(t, err)
+	require.True(t, head.Equal(secondRev))
+
+	parsed, err := restored.RevisionFromString(firstRev.String())
+	require.NoError(t, err)
+	require.NoError(t, restored.CheckRevision(t.Context(), parsed))
+	require.Equal(t, 1, countRelationships(t, restored, parsed))
+	require.Equal(t, 2, countRelationships(t, restored, head))
+
+	nsDefs, err := restored.SnapshotReader(head).ListAllNamespaces(t.Context())
+	require.NoError(t, err)
+	require.Len(t, nsDefs, 2)
+
+	thirdRev := writeTestData(t, restored, "document:third#viewer@user:sarah")
+	require.True(t, thirdRev.GreaterThan(secondRev))
+	require.Equal(t, 3, countRelationships(t, restored, thirdRev))
+}
+
+func TestPersistenceRemovesExpiredFiles(t *testing.T) {
+	dir := t.TempDir()
+	gcWindow := 50 * time.Millisecond
+
+	ds, err := NewMemdbDatastore(0, 0, gcWindow, PersistenceDirectory(dir), SnapshotInterval(0))
+	require.NoError(t, err)
+	firstRev := writeTestData(t, ds, "document:first#viewer@user:tom")
+	require.NoError(t, ds.Close())
+
+	time.Sleep(gcWindow)
+
+	// The snapshot written on close has since fallen outside of the GC window,
+	// so the log started by the empty datastore is removed on the next close.
+	ds, err = NewMemdbDatastore(0, 0, gcWindow, PersistenceDirectory(dir), SnapshotInterval(0))
+	require.NoError(t, err)
+	secondRev := writeTestData(t, ds, "document:second#viewer@user:fred")
+	require.NoError(t, ds.Close())
+
+	logs, err := filepath.Glob(filepath.Join(dir, changelogPrefix+"*"))
+	require.NoError(t, err)
+	require.Len(t, logs, 2)
+
+	restored, err := NewMemdbDatastore(0, 0, 1*time.Hour, PersistenceDirectory(dir))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = restored.Close() })
+
+	head, err := restored.HeadRevision(t.Context())
+	require.NoError(t, err)
+	require.True(t, head.Equal(secondRev))
+
+	// The restore begins from the snapshot at the first revision, whose history is retained,
+	// whereas anything older is no longer available.
+	require.Equal(t, 1, countRelationships(t, restored, firstRev))
+	require.Equal(t, 2, countRelationships(t, restored, secondRev))
+
+	olderRev, err := restored.RevisionFromString(strconv.FormatInt(firstRev.(revisions.TimestampRevision).TimestampNanoSec()-1, 10))
+	require.NoError(t, err)
+	require.ErrorAs(t, restored.CheckRevision(t.Context(), olderRev), &datastore.InvalidRevisionError{})
+}
+
+func TestPersistenceReplaysLogWithoutSnapshot(t *testing.T) {
+	dir := t.TempDir()
+
+	ds, err := NewMemdbDatastore(0, 0, 1*time.Hour, PersistenceDirectory(dir), SnapshotInterval(0))
+	require.NoError(t, err)
+
+	firstRev := writeTestData(t, ds, "document:first#viewer@user:tom", "document:second#viewer@user:fred")
+	secondRev, err := ds.ReadWriteTx(t.Context(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteRelationships(ctx, []tuple.RelationshipUpdate{
+			tuple.Delete(tuple.MustParse("document:first#viewer@user:tom")),
+		})
+	})
+	require.NoError(t, err)
+
+	// Simulate a crash by opening the directory again without closing the
+	// original datastore, after tearing the end of the log.
+	logs, err := filepath.Glob(filepath.Join(dir, changelogPrefix+"*"))
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+
+	f, err := os.OpenFile(logs[0], os.O_WRONLY|os.O_APPEND, 0o644)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"revision":`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	restored, err := NewMemdbDatastore(0, 0, 1*time.Hour, PersistenceDirectory(dir))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = restored.Close() })
+
+	head, err := restored.HeadRevision(t.Context())
+	require.NoError(t, err)
+	require.True(t, head.Equal(secondRev))
+
+	require.Equal(t, 2, countRelationships(t, restored, firstRev))
+	require.Equal(t, 1, countRelationships(t, restored, secondRev))
+
+	changes, errs := restored.Watch(t.Context(), firstRev, datastore.WatchJustRelationships())
+	select {
+	case change := <-changes:
+		require.True(t, change.Revision.Equal(secondRev))
+		require.Len(t, change.RelationshipChanges, 1)
+		require.Equal(t, tuple.UpdateOperationDelete, change.RelationshipChanges[0].Operation)
+	case err := <-errs:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		require.Fail(t, "expected a replayed change")
+	}
+}
+
+// failingLog is a transaction log whose writes are torn after half of the entry.
+type failingLog struct {
+	transactionLog
+	failTruncate bool
+}
+
+func (fl *failingLog) Write(p []byte) (int, error) {
+	n, err := fl.transactionLog.Write(p[:len(p)/2])
+	if err != nil {
+		return n, err
+	}
+	return n, errors.New("injected write failure")
+}
+
+func (fl *failingLog) Truncate(size int64) error {
+	if fl.failTruncate {
+		return errors.New("injected truncate failure")
+	}
+	return fl.transactionLog.Truncate(size)
+}
+
+func TestPersistenceRemovesFailedLogEntry(t *testing.T) {
+	dir := t.TempDir()
+
+	ds, err := NewMemdbDatastore(0, 0, 1*time.Hour, PersistenceDirectory(dir), SnapshotInterval(0))
+	require.NoError(t, err)
+	mdb := ds.(*memdbDatastore)
+
+	firstRev := writeTestData(t, ds, "document:first#viewer@user:tom")
+
+	mdb.Lock()
+	originalLog := mdb.persistence.log
+	mdb.persistence.log = &failingLog{transactionLog: originalLog}
+	mdb.Unlock()
+
+	_, err = ds.ReadWriteTx(t.Context(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteRelationships(ctx, []tuple.RelationshipUpdate{
+			tuple.Touch(tuple.MustParse("document:failed#viewer@user:fred")),
+		})
+	})
+	require.ErrorContains(t, err, "injected write failure")
+
+	mdb.Lock()
+	mdb.persistence.log = originalLog
+	mdb.Unlock()
+
+	secondRev := writeTestData(t, ds, "document:second#viewer@user:sarah")
+
+	// Simulate a crash by opening the directory again without closing the
+	// original datastore.
+	restored, err := NewMemdbDatastore(0, 0, 1*time.Hour, PersistenceDirectory(dir))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = restored.Close() })
+
+	head, err := restored.HeadRevision(t.Context())
+	require.NoError(t, err)
+	require.True(t, head.Equal(secondRev))
+
+	require.Equal(t, 1, countRelationships(t, restored, firstRev))
+	require.Equal(t, 2, countRelationships(t, restored, secondRev))
+}
+
+func TestPersistenceRejectsWritesAfterLogFailure(t *testing.T) {
+	dir := t.TempDir()
+
+	ds, err := NewMemdbDatastore(0, 0, 1*time.Hour, PersistenceDirectory(dir), SnapshotInterval(0))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ds.Close() })
+	mdb := ds.(*memdbDatastore)
+
+	writeTestData(t, ds, "document:first#viewer@user:tom")
+
+	mdb.Lock()
+	originalLog := mdb.persistence.log
+	mdb.persistence.log = &failingLog{transactionLog: originalLog, failTruncate: true}
+	mdb.Unlock()
+
+	_, err = ds.ReadWriteTx(t.Context(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteRelationships(ctx, []tuple.RelationshipUpdate{
+			tuple.Touch(tuple.MustParse("document:failed#viewer@user:fred")),
+		})
+	})
+	require.ErrorContains(t, err, "injected truncate failure")
+
+	// The torn entry remains in the log, so no further transactions may follow it.
+	mdb.Lock()
+	mdb.persistence.log = originalLog
+	mdb.Unlock()
+
+	_, err = ds.ReadWriteTx(t.Context(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteRelationships(ctx, []tuple.RelationshipUpdate{
+			tuple.Touch(tuple.MustParse("document:second#viewer@user:sarah")),
+		})
+	})
+	require.ErrorContains(t, err, "transaction log is unusable")
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/go-memdb"
 
 	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/revisions"
 	"github.com/authzed/spicedb/pkg/datastore"
 	"github.com/authzed/spicedb/pkg/datastore/options"
 	core "github.com/authzed/spicedb/pkg/proto/core/v1"
@@ -105,6 +106,54 @@ func (r *memdbReader) LookupCounters(ctx context.Context) ([]datastore.Relations
 	return counters, nil
 }
 
+func (r *memdbReader) ReadSchemaHistory(_ context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	if r.initErr != nil {
+		return nil, r.initErr
+	}
+
+	r.mustLock()
+	defer r.Unlock()
+
+	tx, err := r.txSource()
+	if err != nil {
+		return nil, err
+	}
+
+	var it memdb.ResultIterator
+	if beforeRevision == datastore.NoRevision {
+		it, err = tx.GetReverse(tableSchemaHistory, indexRevision)
+	} else {
+		before, ok := beforeRevision.(revisions.TimestampRevision)
+		if !ok {
+			return nil, spiceerrors.MustBugf("unexpected revision type for memdb: %T", beforeRevision)
+		}
+		it, err = tx.ReverseLowerBound(tableSchemaHistory, indexRevision, before.TimestampNanoSec()-1)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []datastore.SchemaHistoryEntry
+	for foundRaw := it.Next(); foundRaw != nil; foundRaw = it.Next() {
+		found := foundRaw.(*schemaHistoryEntry)
+
+		loaded := &core.SchemaHistoryEntry{}
+		if err := loaded.UnmarshalVT(found.entryBytes); err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, datastore.SchemaHistoryEntry{
+			Entry:           loaded,
+			WrittenRevision: revisions.NewForTimestamp(found.revisionNanos),
+		})
+		if limit > 0 && uint64(len(entries)) == limit {
+			break
+		}
+	}
+
+	return entries, nil
+}
+
 // QueryRelationships reads relationships starting from the resource side.
 func (r *memdbReader) QueryRelationships(
 	_ context.Context,
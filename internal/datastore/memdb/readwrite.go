@@ -12,6 +12,7 @@ import (
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
 
 	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/revisions"
 	"github.com/authzed/spicedb/pkg/datastore"
 	"github.com/authzed/spicedb/pkg/datastore/options"
 	core "github.com/authzed/spicedb/pkg/proto/core/v1"
@@ -265,11 +266,39 @@ func (rwt *memdbReadWriteTx) StoreCounterValue(ctx context.Context, name string,
 		return datastore.NewCounterNotRegisteredErr(name)
 	}
 
-	counter := foundRaw.(*counter)
-	counter.count = value
-	counter.updated = computedAtRevision
+	// Copy the counter, as the stored object is shared with older snapshots.
+	updated := *foundRaw.(*counter)
+	updated.count = value
+	updated.updated = computedAtRevision
 
-	return tx.Insert(tableCounters, counter)
+	return tx.Insert(tableCounters, &updated)
+}
+
+func (rwt *memdbReadWriteTx) WriteSchemaHistoryEntry(_ context.Context, entry *core.SchemaHistoryEntry) error {
+	rwt.mustLock()
+	defer rwt.Unlock()
+
+	tx, err := rwt.txSource()
+	if err != nil {
+		return err
+	}
+
+	revisionNanos := rwt.newRevision.(revisions.TimestampRevision).TimestampNanoSec()
+	foundRaw, err := tx.First(tableSchemaHistory, indexRevision, revisionNanos)
+	if err != nil {
+		return err
+	}
+
+	if foundRaw != nil {
+		return spiceerrors.MustBugf("schema history entry already written in transaction")
+	}
+
+	entryBytes, err := entry.MarshalVT()
+	if err != nil {
+		return err
+	}
+
+	return tx.Insert(tableSchemaHistory, &schemaHistoryEntry{revisionNanos, entryBytes})
 }
 
 func (rwt *memdbReadWriteTx) WriteNamespaces(_ context.Context, newConfigs ...*core.NamespaceDefinition) error {
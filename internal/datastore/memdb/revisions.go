@@ -31,8 +31,11 @@ func (mdb *memdbDatastore) newRevisionID() revisions.TimestampRevision {
 	// See: https://github.com/golang/go/issues/22037 which appeared to fix
 	// this in Go 1.9.2, but there appears to have been a reversion with either
 	// the new version of macOS or Go.
-	if created.Equal(existing) {
-		return revisions.NewForTimestamp(created.TimestampNanoSec() + 1)
+	//
+	// The same applies if the clock is behind the head revision, which can
+	// occur when the head revision was restored from persistent storage.
+	if !created.GreaterThan(existing) {
+		return revisions.NewForTimestamp(existing.TimestampNanoSec() + 1)
 	}
 
 	return created
@@ -85,9 +88,9 @@ func (mdb *memdbDatastore) CheckRevision(_ context.Context, dr datastore.Revisio
 func (mdb *memdbDatastore) checkRevisionLocalCallerMustLock(dr datastore.Revision) error {
 	now := nowRevision()
 
-	// Ensure the revision has not fallen outside of the GC window. If it has, it is considered
-	// invalid.
-	if mdb.revisionOutsideGCWindow(now, dr) {
+	// Ensure the revision has not fallen outside of the GC window, or been garbage collected.
+	// If it has, it is considered invalid.
+	if mdb.revisionOutsideGCWindow(now, dr) || mdb.gcWatermark.GreaterThan(dr) {
 		return datastore.NewInvalidRevisionErr(dr, datastore.RevisionStale)
 	}
 
@@ -24,6 +24,8 @@ const (
 
 	tableCounters = "counters"
 
+	tableSchemaHistory = "schemaHistory"
+
 	tableChangelog = "changelog"
 	indexRevision  = "id"
 )
@@ -45,6 +47,11 @@ type counter struct {
 	updated     datastore.Revision
 }
 
+type schemaHistoryEntry struct {
+	revisionNanos int64
+	entryBytes    []byte
+}
+
 type relationship struct {
 	namespace        string
 	resourceID       string
@@ -228,5 +235,15 @@ var schema = &memdb.DBSchema{
 				},
 			},
 		},
+		tableSchemaHistory: {
+			Name: tableSchemaHistory,
+			Indexes: map[string]*memdb.IndexSchema{
+				indexRevision: {
+					Name:    indexRevision,
+					Unique:  true,
+					Indexer: &memdb.IntFieldIndex{Field: "revisionNanos"},
+				},
+			},
+		},
 	},
 }
@@ -56,6 +56,8 @@ const (
 	colCounterCurrentCount      = "current_count"
 	colCounterUpdatedAtRevision = "count_updated_at_revision"
 
+	colSchemaHistoryEntry = "serialized_entry"
+
 	errUnableToInstantiate = "unable to instantiate datastore: %w"
 	liveDeletedTxnID       = uint64(math.MaxInt64)
 	batchDeleteSize        = 1000
@@ -8,6 +8,7 @@ const (
 	tableMetadataDefault      = "mysql_metadata"
 	tableCaveatDefault        = "caveat"
 	tableRelationshipCounters = "relationship_counters"
+	tableSchemaHistoryDefault = "schema_history"
 )
 
 type tables struct {
@@ -18,6 +19,7 @@ type tables struct {
 	tableMetadata             string
 	tableCaveat               string
 	tableRelationshipCounters string
+	tableSchemaHistory        string
 }
 
 func newTables(prefix string) *tables {
@@ -29,6 +31,7 @@ func newTables(prefix string) *tables {
 		tableMetadata:             prefix + tableMetadataDefault,
 		tableCaveat:               prefix + tableCaveatDefault,
 		tableRelationshipCounters: prefix + tableRelationshipCounters,
+		tableSchemaHistory:        prefix + tableSchemaHistoryDefault,
 	}
 }
 
@@ -62,3 +65,8 @@ func (tn *tables) Caveat() string {
 func (tn *tables) RelationshipCounters() string {
 	return tn.tableRelationshipCounters
 }
+
+// SchemaHistory returns the prefixed schema history table name.
+func (tn *tables) SchemaHistory() string {
+	return tn.tableSchemaHistory
+}
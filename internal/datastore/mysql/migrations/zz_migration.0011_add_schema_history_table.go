@@ -0,0 +1,23 @@
+package migrations
+
+import "fmt"
+
+// Entries of the schema history are never deleted, so the deleted transaction
+// is only present for the living object filter used by the readers. As at most
+// one entry is written per transaction, the creating transaction is the key.
+func addSchemaHistoryTable(t *tables) string {
+	return fmt.Sprintf(`CREATE TABLE %s (
+		created_transaction BIGINT NOT NULL PRIMARY KEY,
+		serialized_entry LONGBLOB NOT NULL,
+		deleted_transaction BIGINT NOT NULL DEFAULT '9223372036854775807') ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;`,
+		t.SchemaHistory(),
+	)
+}
+
+func init() {
+	mustRegisterMigration("add_schema_history_table", "add_expiration_to_relation_tuple", noNonatomicMigration,
+		newStatementBatch(
+			addSchemaHistoryTable,
+		).execute,
+	)
+}
@@ -22,6 +22,9 @@ type QueryBuilder struct {
 	DeleteCounterQuery sq.UpdateBuilder
 	UpdateCounterQuery sq.UpdateBuilder
 
+	ReadSchemaHistoryQuery   sq.SelectBuilder
+	InsertSchemaHistoryQuery sq.InsertBuilder
+
 	QueryRelsWithIdsQuery        sq.SelectBuilder
 	QueryRelsQuery               sq.SelectBuilder
 	DeleteRelsQuery              sq.UpdateBuilder
@@ -56,6 +59,10 @@ func NewQueryBuilder(driver *migrations.MySQLDriver) *QueryBuilder {
 	builder.DeleteCounterQuery = deleteCounter(driver.RelationshipCounters())
 	builder.UpdateCounterQuery = updateCounter(driver.RelationshipCounters())
 
+	// schema history builders
+	builder.ReadSchemaHistoryQuery = readSchemaHistory(driver.SchemaHistory())
+	builder.InsertSchemaHistoryQuery = insertSchemaHistory(driver.SchemaHistory())
+
 	// tuple builders
 	builder.QueryRelsWithIdsQuery = queryRelationshipsWithIds(driver.RelationTuple())
 	builder.DeleteNamespaceRelationshipsQuery = deleteNamespaceRelationships(driver.RelationTuple())
@@ -130,6 +137,17 @@ func updateCounter(tableRelationshipCounters string) sq.UpdateBuilder {
 	return sb.Update(tableRelationshipCounters).Where(sq.Eq{colDeletedTxn: liveDeletedTxnID})
 }
 
+func readSchemaHistory(tableSchemaHistory string) sq.SelectBuilder {
+	return sb.Select(colSchemaHistoryEntry, colCreatedTxn).From(tableSchemaHistory).OrderBy(colCreatedTxn + " DESC")
+}
+
+func insertSchemaHistory(tableSchemaHistory string) sq.InsertBuilder {
+	return sb.Insert(tableSchemaHistory).Columns(
+		colSchemaHistoryEntry,
+		colCreatedTxn,
+	)
+}
+
 func writeNamespace(tableNamespace string) sq.InsertBuilder {
 	return sb.Insert(tableNamespace).Columns(
 		colNamespace,
@@ -38,6 +38,7 @@ const (
 	errUnableToReadCounters      = "unable to read counters: %w"
 	errUnableToReadCounterFilter = "unable to read counter filter: %w"
 	errUnableToReadCount         = "unable to read count: %w"
+	errUnableToReadSchemaHistory = "unable to read schema history: %w"
 )
 
 func (mr *mysqlReader) CountRelationships(ctx context.Context, name string) (int, error) {
@@ -160,6 +161,57 @@ func (mr *mysqlReader) lookupCounters(ctx context.Context, optionalName string)
 	return counters, nil
 }
 
+func (mr *mysqlReader) ReadSchemaHistory(ctx context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	query := mr.aliveFilter(mr.ReadSchemaHistoryQuery)
+	if beforeRevision != datastore.NoRevision {
+		query = query.Where(sq.Lt{colCreatedTxn: beforeRevision.(revisions.TransactionIDRevision).TransactionID()})
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToReadSchemaHistory, err)
+	}
+
+	tx, txCleanup, err := mr.txSource(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToReadSchemaHistory, err)
+	}
+	defer common.LogOnError(ctx, txCleanup)
+
+	rows, err := tx.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToReadSchemaHistory, err)
+	}
+	defer common.LogOnError(ctx, rows.Close)
+
+	var entries []datastore.SchemaHistoryEntry
+	for rows.Next() {
+		var entryBytes []byte
+		var txID uint64
+		if err := rows.Scan(&entryBytes, &txID); err != nil {
+			return nil, fmt.Errorf(errUnableToReadSchemaHistory, err)
+		}
+
+		loaded := &core.SchemaHistoryEntry{}
+		if err := loaded.UnmarshalVT(entryBytes); err != nil {
+			return nil, fmt.Errorf(errUnableToReadSchemaHistory, err)
+		}
+
+		entries = append(entries, datastore.SchemaHistoryEntry{
+			Entry:           loaded,
+			WrittenRevision: revisions.NewForTransactionID(txID),
+		})
+	}
+	if rows.Err() != nil {
+		return nil, fmt.Errorf(errUnableToReadSchemaHistory, rows.Err())
+	}
+
+	return entries, nil
+}
+
 func (mr *mysqlReader) QueryRelationships(
 	ctx context.Context,
 	filter datastore.RelationshipsFilter,
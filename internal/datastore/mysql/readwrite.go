@@ -35,6 +35,7 @@ const (
 	errUnableToDeleteRelationships    = "unable to delete relationships: %w"
 	errUnableToWriteConfig            = "unable to write namespace config: %w"
 	errUnableToDeleteConfig           = "unable to delete namespace config: %w"
+	errUnableToWriteSchemaHistory     = "unable to write schema history: %w"
 
 	bulkInsertRowsLimit = 1_000
 )
@@ -176,6 +177,24 @@ func (rwt *mysqlReadWriteTXN) StoreCounterValue(ctx context.Context, name string
 	return nil
 }
 
+func (rwt *mysqlReadWriteTXN) WriteSchemaHistoryEntry(ctx context.Context, entry *core.SchemaHistoryEntry) error {
+	entryBytes, err := entry.MarshalVT()
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteSchemaHistory, err)
+	}
+
+	query, args, err := rwt.InsertSchemaHistoryQuery.Values(entryBytes, rwt.newTxnID).ToSql()
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteSchemaHistory, err)
+	}
+
+	if _, err := rwt.tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf(errUnableToWriteSchemaHistory, err)
+	}
+
+	return nil
+}
+
 // WriteRelationships takes a list of existing relationships that must exist, and a list of
 // tuple mutations and applies it to the datastore for the specified namespace.
 func (rwt *mysqlReadWriteTXN) WriteRelationships(ctx context.Context, mutations []tuple.RelationshipUpdate) error {
@@ -0,0 +1,28 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const createSchemaHistoryTable = `CREATE TABLE schema_history (
+	serialized_entry BYTEA NOT NULL,
+	created_xid xid8 NOT NULL DEFAULT (pg_current_xact_id()),
+	deleted_xid xid8 NOT NULL DEFAULT ('9223372036854775807'),
+	CONSTRAINT pk_schema_history PRIMARY KEY (created_xid)
+);`
+
+func init() {
+	if err := DatabaseMigrations.Register("create-schema-history-table", "add-index-for-transaction-gc",
+		func(ctx context.Context, conn *pgx.Conn) error {
+			if _, err := conn.Exec(ctx, createSchemaHistoryTable); err != nil {
+				return fmt.Errorf("failed to create schema history table: %w", err)
+			}
+			return nil
+		},
+		noTxMigration); err != nil {
+		panic("failed to register migration: " + err.Error())
+	}
+}
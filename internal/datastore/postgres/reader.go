@@ -36,12 +36,18 @@ var (
 	readCounters = psql.
 			Select(schema.ColCounterName, schema.ColCounterFilter, schema.ColCounterCurrentCount, schema.ColCounterSnapshot).
 			From(schema.TableRelationshipCounter)
+
+	readSchemaHistory = psql.
+				Select(schema.ColSchemaHistoryEntry, schema.ColCreatedXid).
+				From(schema.TableSchemaHistory).
+				OrderBy(schema.ColCreatedXid + " DESC")
 )
 
 const (
 	errUnableToReadConfig     = "unable to read namespace config: %w"
 	errUnableToReadFilter     = "unable to read relationship filter: %w"
 	errUnableToListNamespaces = "unable to list namespaces: %w"
+	errUnableToReadHistory    = "unable to read schema history: %w"
 )
 
 func (r *pgReader) CountRelationships(ctx context.Context, name string) (int, error) {
@@ -145,6 +151,55 @@ func (r *pgReader) lookupCounters(ctx context.Context, optionalName string) ([]d
 	return counters, nil
 }
 
+func (r *pgReader) ReadSchemaHistory(ctx context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	query := r.aliveFilter(readSchemaHistory)
+	if beforeRevision != datastore.NoRevision {
+		// Entries written before the revision are those visible in its snapshot, other than the
+		// entry written by the transaction of the revision itself.
+		before := beforeRevision.(postgresRevision)
+		query = query.Where(sq.Expr(fmt.Sprintf(snapshotAlive, schema.ColCreatedXid), before.snapshot, true))
+		if txID, ok := before.OptionalTransactionID(); ok {
+			query = query.Where(sq.NotEq{schema.ColCreatedXid: txID})
+		}
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToReadHistory, err)
+	}
+
+	var entries []datastore.SchemaHistoryEntry
+	err = r.query.QueryFunc(ctx, func(ctx context.Context, rows pgx.Rows) error {
+		for rows.Next() {
+			var entryBytes []byte
+			var version xid8
+
+			if err := rows.Scan(&entryBytes, &version); err != nil {
+				return err
+			}
+
+			loaded := &core.SchemaHistoryEntry{}
+			if err := loaded.UnmarshalVT(entryBytes); err != nil {
+				return err
+			}
+
+			revision := revisionForVersion(version)
+			revision.optionalTxID = version
+
+			entries = append(entries, datastore.SchemaHistoryEntry{Entry: loaded, WrittenRevision: revision})
+		}
+		return rows.Err()
+	}, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToReadHistory, err)
+	}
+
+	return entries, nil
+}
+
 func (r *pgReader) QueryRelationships(
 	ctx context.Context,
 	filter datastore.RelationshipsFilter,
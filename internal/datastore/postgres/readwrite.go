@@ -31,6 +31,7 @@ const (
 	errUnableToDeleteRelationships        = "unable to delete relationships: %w"
 	errUnableToWriteRelationshipsCounter  = "unable to write relationships counter: %w"
 	errUnableToDeleteRelationshipsCounter = "unable to delete relationships counter: %w"
+	errUnableToWriteSchemaHistory         = "unable to write schema history: %w"
 )
 
 var (
@@ -76,6 +77,8 @@ var (
 	updateRelationshipCounter = psql.Update(schema.TableRelationshipCounter).Where(sq.Eq{schema.ColDeletedXid: liveDeletedTxnID})
 
 	deleteRelationshipCounter = psql.Update(schema.TableRelationshipCounter).Where(sq.Eq{schema.ColDeletedXid: liveDeletedTxnID})
+
+	writeSchemaHistory = psql.Insert(schema.TableSchemaHistory).Columns(schema.ColSchemaHistoryEntry)
 )
 
 type pgReadWriteTXN struct {
@@ -760,6 +763,24 @@ func (rwt *pgReadWriteTXN) StoreCounterValue(ctx context.Context, name string, v
 	return nil
 }
 
+func (rwt *pgReadWriteTXN) WriteSchemaHistoryEntry(ctx context.Context, entry *core.SchemaHistoryEntry) error {
+	entryBytes, err := entry.MarshalVT()
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteSchemaHistory, err)
+	}
+
+	sql, args, err := writeSchemaHistory.Values(entryBytes).ToSql()
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteSchemaHistory, err)
+	}
+
+	if _, err := rwt.tx.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf(errUnableToWriteSchemaHistory, err)
+	}
+
+	return nil
+}
+
 var copyCols = []string{
 	schema.ColNamespace,
 	schema.ColObjectID,
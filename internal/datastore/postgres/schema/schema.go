@@ -12,6 +12,7 @@ const (
 	TableTuple               = "relation_tuple"
 	TableCaveat              = "caveat"
 	TableRelationshipCounter = "relationship_counter"
+	TableSchemaHistory       = "schema_history"
 
 	ColXID               = "xid"
 	ColTimestamp         = "timestamp"
@@ -36,6 +37,8 @@ const (
 	ColCounterFilter       = "serialized_filter"
 	ColCounterCurrentCount = "current_count"
 	ColCounterSnapshot     = "updated_revision_snapshot"
+
+	ColSchemaHistoryEntry = "serialized_entry"
 )
 
 func Schema(colOptimizationOpt common.ColumnOptimizationOption, expirationDisabled bool) *common.SchemaInformation {
@@ -241,6 +241,10 @@ func (fakeSnapshotReader) LookupCounters(ctx context.Context) ([]datastore.Relat
 	return nil, fmt.Errorf("not implemented")
 }
 
+func (fakeSnapshotReader) ReadSchemaHistory(ctx context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 func fakeIterator(fsr fakeSnapshotReader, explainCallback options.SQLExplainCallbackForTest) datastore.RelationshipIterator {
 	return func(yield func(tuple.Relationship, error) bool) {
 		if fsr.state == "primary" {
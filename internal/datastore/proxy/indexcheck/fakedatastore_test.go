@@ -155,6 +155,10 @@ func (fakeSnapshotReader) LookupCounters(ctx context.Context) ([]datastore.Relat
 	return nil, fmt.Errorf("not implemented")
 }
 
+func (fakeSnapshotReader) ReadSchemaHistory(ctx context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 func fakeIterator(fsr fakeSnapshotReader, explainCallback options.SQLExplainCallbackForTest) datastore.RelationshipIterator {
 	return func(yield func(tuple.Relationship, error) bool) {
 		if explainCallback != nil {
@@ -191,6 +195,10 @@ func (f *fakeRWT) StoreCounterValue(ctx context.Context, name string, value int,
 	return nil
 }
 
+func (f *fakeRWT) WriteSchemaHistoryEntry(ctx context.Context, entry *corev1.SchemaHistoryEntry) error {
+	return nil
+}
+
 func (f *fakeRWT) WriteCaveats(ctx context.Context, caveats []*corev1.CaveatDefinition) error {
 	return nil
 }
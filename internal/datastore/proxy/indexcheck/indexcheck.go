@@ -111,6 +111,10 @@ func (r *indexcheckingReader) LookupCounters(ctx context.Context) ([]datastore.R
 	return r.delegate.LookupCounters(ctx)
 }
 
+func (r *indexcheckingReader) ReadSchemaHistory(ctx context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	return r.delegate.ReadSchemaHistory(ctx, beforeRevision, limit)
+}
+
 func (r *indexcheckingReader) ReadCaveatByName(ctx context.Context, name string) (*core.CaveatDefinition, datastore.Revision, error) {
 	return r.delegate.ReadCaveatByName(ctx, name)
 }
@@ -198,6 +202,10 @@ func (rwt *indexcheckingRWT) StoreCounterValue(ctx context.Context, name string,
 	return rwt.delegate.StoreCounterValue(ctx, name, value, computedAtRevision)
 }
 
+func (rwt *indexcheckingRWT) WriteSchemaHistoryEntry(ctx context.Context, entry *core.SchemaHistoryEntry) error {
+	return rwt.delegate.WriteSchemaHistoryEntry(ctx, entry)
+}
+
 func (rwt *indexcheckingRWT) WriteCaveats(ctx context.Context, caveats []*core.CaveatDefinition) error {
 	return rwt.delegate.WriteCaveats(ctx, caveats)
 }
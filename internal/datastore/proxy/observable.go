@@ -2,6 +2,7 @@ package proxy
 
 import (
 	"context"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -62,13 +63,32 @@ func filterToAttributes(filter *v1.RelationshipFilter) []attribute.KeyValue {
 	return attrs
 }
 
+// ObservableOption configures the observable datastore proxy.
+type ObservableOption func(*observableProxy)
+
+// WithRelationshipQueryLatencyObserver sets a function invoked with the latency of each query for
+// relationships, measured until its first relationship is loaded or, if none are found, until the
+// query has completed.
+func WithRelationshipQueryLatencyObserver(observer func(latency time.Duration)) ObservableOption {
+	return func(p *observableProxy) {
+		p.latencyObserver = observer
+	}
+}
+
 // NewObservableDatastoreProxy creates a new datastore proxy which adds tracing
 // and metrics to the datastore.
-func NewObservableDatastoreProxy(d datastore.Datastore) datastore.Datastore {
-	return &observableProxy{delegate: d}
+func NewObservableDatastoreProxy(d datastore.Datastore, opts ...ObservableOption) datastore.Datastore {
+	p := &observableProxy{delegate: d}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
 }
 
-type observableProxy struct{ delegate datastore.Datastore }
+type observableProxy struct {
+	delegate        datastore.Datastore
+	latencyObserver func(latency time.Duration)
+}
 
 func (p *observableProxy) MetricsID() (string, error) {
 	return p.delegate.MetricsID()
@@ -80,7 +100,7 @@ func (p *observableProxy) UniqueID(ctx context.Context) (string, error) {
 
 func (p *observableProxy) SnapshotReader(rev datastore.Revision) datastore.Reader {
 	delegateReader := p.delegate.SnapshotReader(rev)
-	return &observableReader{delegateReader}
+	return &observableReader{delegateReader, p.latencyObserver}
 }
 
 func (p *observableProxy) ReadWriteTx(
@@ -89,7 +109,7 @@ func (p *observableProxy) ReadWriteTx(
 	opts ...options.RWTOptionsOption,
 ) (datastore.Revision, error) {
 	return p.delegate.ReadWriteTx(ctx, func(ctx context.Context, delegateRWT datastore.ReadWriteTransaction) error {
-		return f(ctx, &observableRWT{&observableReader{delegateRWT}, delegateRWT})
+		return f(ctx, &observableRWT{&observableReader{delegateRWT, p.latencyObserver}, delegateRWT})
 	}, opts...)
 }
 
@@ -155,7 +175,26 @@ func (p *observableProxy) ReadyState(ctx context.Context) (datastore.ReadyState,
 
 func (p *observableProxy) Close() error { return p.delegate.Close() }
 
-type observableReader struct{ delegate datastore.Reader }
+type observableReader struct {
+	delegate        datastore.Reader
+	latencyObserver func(latency time.Duration)
+}
+
+// observeLatency returns a function to be invoked with each relationship loaded by a query
+// started at the time given, which reports the latency of the query upon the first.
+func (r *observableReader) observeLatency(started time.Time) func() {
+	if r.latencyObserver == nil {
+		return func() {}
+	}
+
+	observed := false
+	return func() {
+		if !observed {
+			observed = true
+			r.latencyObserver(time.Since(started))
+		}
+	}
+}
 
 func (r *observableReader) CountRelationships(ctx context.Context, name string) (int, error) {
 	ctx, closer := observe(ctx, "CountRelationships", "", trace.WithAttributes(
@@ -173,6 +212,13 @@ func (r *observableReader) LookupCounters(ctx context.Context) ([]datastore.Rela
 	return r.delegate.LookupCounters(ctx)
 }
 
+func (r *observableReader) ReadSchemaHistory(ctx context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	ctx, closer := observe(ctx, "ReadSchemaHistory", "")
+	defer closer()
+
+	return r.delegate.ReadSchemaHistory(ctx, beforeRevision, limit)
+}
+
 func (r *observableReader) ReadCaveatByName(ctx context.Context, name string) (*core.CaveatDefinition, datastore.Revision, error) {
 	ctx, closer := observe(ctx, "ReadCaveatByName", "", trace.WithAttributes(
 		attribute.StringSlice(otelconv.AttrDatastoreNames, []string{name}),
@@ -225,6 +271,7 @@ func (r *observableReader) ReadNamespaceByName(ctx context.Context, nsName strin
 
 func (r *observableReader) QueryRelationships(ctx context.Context, filter datastore.RelationshipsFilter, opts ...options.QueryOptionsOption) (datastore.RelationshipIterator, error) {
 	queryOpts := options.NewQueryOptionsWithOptions(opts...)
+	observeLatency := r.observeLatency(time.Now())
 	ctx, closer := observe(ctx, "QueryRelationships", string(queryOpts.QueryShape), trace.WithAttributes(
 		attribute.String(otelconv.AttrDatastoreResourceType, filter.OptionalResourceType),
 		attribute.String(otelconv.AttrDatastoreResourceRelation, filter.OptionalResourceRelation),
@@ -242,17 +289,20 @@ func (r *observableReader) QueryRelationships(ctx context.Context, filter datast
 
 		var count uint64
 		for rel, err := range iterator {
+			observeLatency()
 			count++
 			if !yield(rel, err) {
 				break
 			}
 		}
+		observeLatency()
 		loadedRelationshipCount.Observe(float64(count))
 	}, nil
 }
 
 func (r *observableReader) ReverseQueryRelationships(ctx context.Context, subjectsFilter datastore.SubjectsFilter, opts ...options.ReverseQueryOptionsOption) (datastore.RelationshipIterator, error) {
 	queryOpts := options.NewReverseQueryOptionsWithOptions(opts...)
+	observeLatency := r.observeLatency(time.Now())
 	ctx, closer := observe(ctx, "ReverseQueryRelationships", string(queryOpts.QueryShapeForReverse), trace.WithAttributes(
 		attribute.String(otelconv.AttrDatastoreSubjectType, subjectsFilter.SubjectType),
 		attribute.String(otelconv.AttrDatastoreQueryShape, string(queryOpts.QueryShapeForReverse))))
@@ -268,11 +318,13 @@ func (r *observableReader) ReverseQueryRelationships(ctx context.Context, subjec
 
 		var count uint64
 		for rel, err := range iterator {
+			observeLatency()
 			count++
 			if !yield(rel, err) {
 				break
 			}
 		}
+		observeLatency()
 		loadedRelationshipCount.Observe(float64(count))
 	}, nil
 }
@@ -311,6 +363,13 @@ func (rwt *observableRWT) StoreCounterValue(ctx context.Context, name string, va
 	return rwt.delegate.StoreCounterValue(ctx, name, value, computedAtRevision)
 }
 
+func (rwt *observableRWT) WriteSchemaHistoryEntry(ctx context.Context, entry *core.SchemaHistoryEntry) error {
+	ctx, closer := observe(ctx, "WriteSchemaHistoryEntry", "")
+	defer closer()
+
+	return rwt.delegate.WriteSchemaHistoryEntry(ctx, entry)
+}
+
 func (rwt *observableRWT) WriteCaveats(ctx context.Context, caveats []*core.CaveatDefinition) error {
 	caveatNames := make([]string, 0, len(caveats))
 	for _, caveat := range caveats {
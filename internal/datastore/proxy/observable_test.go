@@ -1,13 +1,17 @@
 package proxy
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/authzed/spicedb/internal/datastore/dsfortesting"
 	"github.com/authzed/spicedb/internal/datastore/memdb"
 	"github.com/authzed/spicedb/pkg/datastore"
 	"github.com/authzed/spicedb/pkg/datastore/test"
+	"github.com/authzed/spicedb/pkg/tuple"
 )
 
 type observableTest struct{}
@@ -27,3 +31,33 @@ func TestObservableProxy(t *testing.T) {
 func (p *observableProxy) ExampleRetryableError() error {
 	return memdb.ErrSerialization
 }
+
+func TestObservableProxyLatencyObserver(t *testing.T) {
+	db, err := memdb.NewMemdbDatastore(0, 0, memdb.DisableGC)
+	require.NoError(t, err)
+
+	var latencies []time.Duration
+	ds := NewObservableDatastoreProxy(db, WithRelationshipQueryLatencyObserver(func(latency time.Duration) {
+		latencies = append(latencies, latency)
+	}))
+
+	revision, err := ds.ReadWriteTx(t.Context(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteRelationships(ctx, []tuple.RelationshipUpdate{
+			tuple.Touch(tuple.MustParse("document:first#viewer@user:tom")),
+			tuple.Touch(tuple.MustParse("document:second#viewer@user:tom")),
+		})
+	})
+	require.NoError(t, err)
+
+	reader := ds.SnapshotReader(revision)
+	for _, resourceType := range []string{"document", "folder"} {
+		iter, err := reader.QueryRelationships(t.Context(), datastore.RelationshipsFilter{OptionalResourceType: resourceType})
+		require.NoError(t, err)
+		for _, err := range iter {
+			require.NoError(t, err)
+		}
+	}
+
+	// The latency of each query is observed once, whether or not it found any relationships.
+	require.Len(t, latencies, 2)
+}
@@ -130,6 +130,11 @@ func (dm *MockReader) LookupCounters(ctx context.Context) ([]datastore.Relations
 	return args.Get(0).([]datastore.RelationshipCounter), args.Error(1)
 }
 
+func (dm *MockReader) ReadSchemaHistory(ctx context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	args := dm.Called(beforeRevision, limit)
+	return args.Get(0).([]datastore.SchemaHistoryEntry), args.Error(1)
+}
+
 func (dm *MockReader) QueryRelationships(
 	_ context.Context,
 	filter datastore.RelationshipsFilter,
@@ -215,6 +220,11 @@ func (dm *MockReadWriteTransaction) LookupCounters(ctx context.Context) ([]datas
 	return args.Get(0).([]datastore.RelationshipCounter), args.Error(1)
 }
 
+func (dm *MockReadWriteTransaction) ReadSchemaHistory(ctx context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	args := dm.Called(beforeRevision, limit)
+	return args.Get(0).([]datastore.SchemaHistoryEntry), args.Error(1)
+}
+
 func (dm *MockReadWriteTransaction) ReadNamespaceByName(
 	_ context.Context,
 	nsName string,
@@ -360,6 +370,11 @@ func (dm *MockReadWriteTransaction) StoreCounterValue(ctx context.Context, name
 	return args.Error(0)
 }
 
+func (dm *MockReadWriteTransaction) WriteSchemaHistoryEntry(ctx context.Context, entry *core.SchemaHistoryEntry) error {
+	args := dm.Called(entry)
+	return args.Error(0)
+}
+
 var (
 	_ datastore.Datastore            = &MockDatastore{}
 	_ datastore.Reader               = &MockReader{}
@@ -373,6 +373,10 @@ func (r relationshipIntegrityReader) LookupCounters(ctx context.Context) ([]data
 	return r.wrapped.LookupCounters(ctx)
 }
 
+func (r relationshipIntegrityReader) ReadSchemaHistory(ctx context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	return r.wrapped.ReadSchemaHistory(ctx, beforeRevision, limit)
+}
+
 func (r relationshipIntegrityReader) LookupNamespacesWithNames(ctx context.Context, nsNames []string) ([]datastore.RevisionedDefinition[*corev1.NamespaceDefinition], error) {
 	return r.wrapped.LookupNamespacesWithNames(ctx, nsNames)
 }
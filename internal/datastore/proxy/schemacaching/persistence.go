@@ -0,0 +1,138 @@
+package schemacaching
+
+import (
+	"context"
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/pkg/cache"
+	"github.com/authzed/spicedb/pkg/datastore"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+)
+
+// NewPersistenceCodec returns the codec used to persist the definitions cached by the caching
+// datastore proxy and to restore them. Definitions are only restored if loaded at a revision
+// still within the revision window, beyond which they are unlikely to be read again.
+func NewPersistenceCodec(ds datastore.Datastore, revisionWindow time.Duration) cache.Codec[cache.StringKey, CacheEntry] {
+	return &persistenceCodec{ds: ds, revisionWindow: revisionWindow, windowChecker: revisions.NewWindowChecker(ds, revisionWindow)}
+}
+
+type persistenceCodec struct {
+	ds             datastore.Datastore
+	revisionWindow time.Duration
+
+	// windowChecker checks the revisions at which the definitions decoded were loaded, each
+	// only once.
+	windowChecker *revisions.WindowChecker
+}
+
+// ForRestore returns a codec checking the revisions of the definitions of a single restore, each
+// against the datastore only once.
+func (pc *persistenceCodec) ForRestore() cache.Codec[cache.StringKey, CacheEntry] {
+	restoring := *pc
+	restoring.windowChecker = revisions.NewWindowChecker(pc.ds, pc.revisionWindow)
+	return &restoring
+}
+
+// Encode encodes an entry as its length-prefixed key and last written revision, followed by its
+// marshaled definition. Entries for definitions which were not found are not persisted.
+func (pc *persistenceCodec) Encode(key cache.StringKey, entry CacheEntry) ([]byte, bool) {
+	if entry.notFound != nil {
+		return nil, false
+	}
+
+	var (
+		definition []byte
+		err        error
+	)
+	switch t := entry.definition.(type) {
+	case *core.NamespaceDefinition:
+		definition, err = t.MarshalVT()
+	case *core.CaveatDefinition:
+		definition, err = t.MarshalVT()
+	default:
+		return nil, false
+	}
+	if err != nil {
+		return nil, false
+	}
+
+	updated := entry.updated.String()
+	encoded := make([]byte, 0, 2*binary.MaxVarintLen64+len(key)+len(updated)+len(definition))
+	encoded = binary.AppendUvarint(encoded, uint64(len(key)))
+	encoded = append(encoded, key...)
+	encoded = binary.AppendUvarint(encoded, uint64(len(updated)))
+	encoded = append(encoded, updated...)
+	encoded = append(encoded, definition...)
+	return encoded, true
+}
+
+func (pc *persistenceCodec) Decode(ctx context.Context, encoded []byte) (cache.StringKey, CacheEntry, int64, bool) {
+	key, encoded, ok := readLengthPrefixed(encoded)
+	if !ok {
+		return "", nil, 0, false
+	}
+
+	updated, encoded, ok := readLengthPrefixed(encoded)
+	if !ok {
+		return "", nil, 0, false
+	}
+
+	// Keys are of the form `prefix:name@revision`.
+	prefix, nameAndRevision, ok := strings.Cut(key, ":")
+	if !ok {
+		return "", nil, 0, false
+	}
+
+	separator := strings.LastIndex(nameAndRevision, "@")
+	if separator < 0 {
+		return "", nil, 0, false
+	}
+
+	inWindow, err := pc.windowChecker.InWindow(ctx, nameAndRevision[separator+1:])
+	if err != nil || !inWindow {
+		return "", nil, 0, false
+	}
+
+	updatedRevision, err := pc.ds.RevisionFromString(updated)
+	if err != nil {
+		return "", nil, 0, false
+	}
+
+	entry := &cacheEntry{updated: updatedRevision}
+	switch prefix {
+	case namespaceCacheKeyPrefix:
+		definition := &core.NamespaceDefinition{}
+		if err := definition.UnmarshalVT(encoded); err != nil {
+			return "", nil, 0, false
+		}
+		entry.definition = definition
+		entry.estimatedDefinitionSize = estimatedNamespaceDefinitionSize(definition.SizeVT())
+
+	case caveatCacheKeyPrefix:
+		definition := &core.CaveatDefinition{}
+		if err := definition.UnmarshalVT(encoded); err != nil {
+			return "", nil, 0, false
+		}
+		entry.definition = definition
+		entry.estimatedDefinitionSize = estimatedCaveatDefinitionSize(definition.SizeVT())
+
+	default:
+		return "", nil, 0, false
+	}
+
+	return cache.StringKey(key), entry, entry.Size(), true
+}
+
+func readLengthPrefixed(encoded []byte) (string, []byte, bool) {
+	length, read := binary.Uvarint(encoded)
+	if read <= 0 || length > uint64(len(encoded)-read) {
+		return "", nil, false
+	}
+	return string(encoded[read : read+int(length)]), encoded[read+int(length):], true
+}
+
+// Always verify that we implement the interface
+var _ cache.RestoreScopedCodec[cache.StringKey, CacheEntry] = &persistenceCodec{}
@@ -0,0 +1,60 @@
+package schemacaching
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	"github.com/authzed/spicedb/pkg/cache"
+	"github.com/authzed/spicedb/pkg/datastore"
+	ns "github.com/authzed/spicedb/pkg/namespace"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+)
+
+func TestPersistedDefinitions(t *testing.T) {
+	ds, err := memdb.NewMemdbDatastore(0, 0, memdb.DisableGC)
+	require.NoError(t, err)
+
+	revision, err := ds.ReadWriteTx(t.Context(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteNamespaces(ctx, ns.Namespace(nsA, ns.MustRelation("viewer", nil)))
+	})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "ns-cache")
+	codec := NewPersistenceCodec(ds, 1*time.Minute)
+
+	previousCache := cache.NewPersistentCache(DatastoreProxyTestCache(t), path, 0, codec)
+	previous := NewCachingDatastoreProxy(ds, previousCache, 0, JustInTimeCaching, 0)
+
+	// Repeatedly read the definitions, so that they are tracked as hot.
+	reader := previous.SnapshotReader(revision)
+	for range 16 {
+		def, _, err := reader.ReadNamespaceByName(t.Context(), nsA)
+		require.NoError(t, err)
+		require.Equal(t, nsA, def.Name)
+	}
+	for range 16 {
+		_, _, err = reader.ReadNamespaceByName(t.Context(), nsB)
+		require.ErrorAs(t, err, &datastore.NamespaceNotFoundError{})
+	}
+
+	// Definitions which were not found are not persisted.
+	written, err := previousCache.Persist(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 1, written)
+
+	currentCache := cache.NewPersistentCache(DatastoreProxyTestCache(t), path, 0, codec)
+	restored, err := currentCache.Restore(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 1, restored)
+
+	entry, found := currentCache.Get(cache.StringKey(namespaceCacheKeyPrefix + ":" + nsA + "@" + revision.String()))
+	require.True(t, found)
+	require.Equal(t, nsA, entry.definition.(*core.NamespaceDefinition).Name)
+	require.True(t, entry.updated.Equal(revision))
+	require.NoError(t, entry.notFound)
+}
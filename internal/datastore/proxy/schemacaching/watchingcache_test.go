@@ -606,6 +606,10 @@ func (fsr *fakeSnapshotReader) LookupCounters(ctx context.Context) ([]datastore.
 	return nil, fmt.Errorf("not implemented")
 }
 
+func (fsr *fakeSnapshotReader) ReadSchemaHistory(ctx context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 func (fsr *fakeSnapshotReader) LookupNamespacesWithNames(_ context.Context, nsNames []string) ([]datastore.RevisionedDefinition[*corev1.NamespaceDefinition], error) {
 	return fsr.fds.readNamespaces(nsNames, fsr.rev)
 }
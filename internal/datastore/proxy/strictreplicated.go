@@ -260,3 +260,13 @@ func (rr *strictReadReplicatedReader) LookupCounters(ctx context.Context) ([]dat
 	}
 	return counters, err
 }
+
+func (rr *strictReadReplicatedReader) ReadSchemaHistory(ctx context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	sr := rr.replica.SnapshotReader(rr.rev)
+	entries, err := sr.ReadSchemaHistory(ctx, beforeRevision, limit)
+	if err != nil && errors.As(err, &common.RevisionUnavailableError{}) {
+		log.Trace().Str("revision", rr.rev.String()).Msg("replica does not contain the requested revision, using primary")
+		return rr.primary.SnapshotReader(rr.rev).ReadSchemaHistory(ctx, beforeRevision, limit)
+	}
+	return entries, err
+}
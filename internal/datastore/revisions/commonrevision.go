@@ -2,6 +2,8 @@ package revisions
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/authzed/spicedb/pkg/datastore"
 	"github.com/authzed/spicedb/pkg/spiceerrors"
@@ -84,3 +86,55 @@ type WithTimestampRevision interface {
 	TimestampNanoSec() int64
 	ConstructForTimestamp(timestampNanoSec int64) WithTimestampRevision
 }
+
+// WindowChecker checks whether revisions, given in their string form, are within a window,
+// checking each distinct revision against the datastore only once. As revisions leave the window
+// over time, a checker should only be used for a bounded set of checks, such as those of the
+// entries of a single restore of a persisted cache.
+type WindowChecker struct {
+	ds     datastore.Datastore
+	window time.Duration
+
+	mu      sync.Mutex
+	checked map[string]checkedRevision
+}
+
+type checkedRevision struct {
+	revision datastore.Revision
+	valid    bool
+}
+
+// NewWindowChecker returns a new WindowChecker for revisions of the datastore.
+func NewWindowChecker(ds datastore.Datastore, window time.Duration) *WindowChecker {
+	return &WindowChecker{ds: ds, window: window, checked: make(map[string]checkedRevision)}
+}
+
+// InWindow parses the revision and returns whether it is still valid in the datastore and, if it
+// provides a timestamp, is no older than the window.
+func (wc *WindowChecker) InWindow(ctx context.Context, revisionString string) (bool, error) {
+	wc.mu.Lock()
+	checked, ok := wc.checked[revisionString]
+	wc.mu.Unlock()
+
+	if !ok {
+		revision, err := wc.ds.RevisionFromString(revisionString)
+		if err != nil {
+			return false, err
+		}
+
+		checked = checkedRevision{revision: revision, valid: wc.ds.CheckRevision(ctx, revision) == nil}
+		wc.mu.Lock()
+		wc.checked[revisionString] = checked
+		wc.mu.Unlock()
+	}
+
+	if !checked.valid {
+		return false, nil
+	}
+
+	timestamped, ok := checked.revision.(WithTimestampRevision)
+	if !ok {
+		return true, nil
+	}
+	return time.Since(time.Unix(0, timestamped.TimestampNanoSec())) <= wc.window, nil
+}
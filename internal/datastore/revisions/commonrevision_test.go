@@ -2,11 +2,16 @@ package revisions
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/pkg/datastore"
 )
 
 var kinds = map[RevisionKind]bool{Timestamp: false, TransactionID: false, HybridLogicalClock: true}
@@ -329,3 +334,55 @@ func TestRevisionByteSortable(t *testing.T) {
 		})
 	}
 }
+
+// checkCountingDatastore is a datastore of HLC revisions, which counts the revisions checked and
+// only considers valid those in validRevisions.
+type checkCountingDatastore struct {
+	datastore.Datastore
+
+	validRevisions []string
+	checked        int
+}
+
+func (ccd *checkCountingDatastore) RevisionFromString(s string) (datastore.Revision, error) {
+	return HLCRevisionFromString(s)
+}
+
+func (ccd *checkCountingDatastore) CheckRevision(_ context.Context, revision datastore.Revision) error {
+	ccd.checked++
+	for _, valid := range ccd.validRevisions {
+		if revision.String() == valid {
+			return nil
+		}
+	}
+	return errors.New("invalid revision")
+}
+
+func TestWindowChecker(t *testing.T) {
+	recent := NewHLCForTime(time.Now()).String()
+	old := NewHLCForTime(time.Now().Add(-time.Hour)).String()
+	gced := NewHLCForTime(time.Now().Add(-time.Minute)).String()
+
+	ds := &checkCountingDatastore{validRevisions: []string{recent, old}}
+	checker := NewWindowChecker(ds, 10*time.Minute)
+
+	for range 3 {
+		inWindow, err := checker.InWindow(t.Context(), recent)
+		require.NoError(t, err)
+		require.True(t, inWindow)
+
+		inWindow, err = checker.InWindow(t.Context(), old)
+		require.NoError(t, err)
+		require.False(t, inWindow)
+
+		inWindow, err = checker.InWindow(t.Context(), gced)
+		require.NoError(t, err)
+		require.False(t, inWindow)
+
+		_, err = checker.InWindow(t.Context(), "invalid")
+		require.Error(t, err)
+	}
+
+	// Each distinct revision is only checked against the datastore once.
+	require.Equal(t, 3, ds.checked)
+}
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"context"
+
+	"cloud.google.com/go/spanner/admin/database/apiv1/databasepb"
+)
+
+const (
+	addSchemaHistoryTable = `CREATE TABLE schema_history (
+			timestamp TIMESTAMP NOT NULL OPTIONS (allow_commit_timestamp=true),
+			serialized_entry BYTES(MAX) NOT NULL,
+		) PRIMARY KEY (timestamp DESC)
+	`
+)
+
+func init() {
+	if err := SpannerMigrations.Register("add-schema-history-table", "add-expiration-support", func(ctx context.Context, w Wrapper) error {
+		updateOp, err := w.adminClient.UpdateDatabaseDdl(ctx, &databasepb.UpdateDatabaseDdlRequest{
+			Database: w.client.DatabaseName(),
+			Statements: []string{
+				addSchemaHistoryTable,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		return updateOp.Wait(ctx)
+	}, nil); err != nil {
+		panic("failed to register migration: " + err.Error())
+	}
+}
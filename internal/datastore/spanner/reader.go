@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"cloud.google.com/go/spanner"
+	sq "github.com/Masterminds/squirrel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
@@ -131,6 +132,45 @@ func (sr spannerReader) lookupCounters(ctx context.Context, optionalFilterName s
 	return counters, nil
 }
 
+func (sr spannerReader) ReadSchemaHistory(ctx context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	query := querySchemaHistory
+	if beforeRevision != datastore.NoRevision {
+		query = query.Where(sq.Lt{colSchemaHistoryTS: beforeRevision.(revisions.TimestampRevision).Time()})
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToReadSchemaHistory, err)
+	}
+
+	var entries []datastore.SchemaHistoryEntry
+	if err := sr.txSource().Query(ctx, statementFromSQL(sql, args)).Do(func(row *spanner.Row) error {
+		var serialized []byte
+		var timestamp time.Time
+		if err := row.Columns(&serialized, &timestamp); err != nil {
+			return err
+		}
+
+		loaded := &core.SchemaHistoryEntry{}
+		if err := loaded.UnmarshalVT(serialized); err != nil {
+			return err
+		}
+
+		entries = append(entries, datastore.SchemaHistoryEntry{
+			Entry:           loaded,
+			WrittenRevision: revisions.NewForTime(timestamp),
+		})
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf(errUnableToReadSchemaHistory, err)
+	}
+
+	return entries, nil
+}
+
 func (sr spannerReader) QueryRelationships(
 	ctx context.Context,
 	filter datastore.RelationshipsFilter,
@@ -391,6 +431,10 @@ func readAllNamespaces(iter *spanner.RowIterator, span trace.Span) ([]datastore.
 
 var countRels = sql.Select("COUNT(*)").From(tableRelationship)
 
+var querySchemaHistory = sql.Select(colSchemaHistoryEntry, colSchemaHistoryTS).
+	From(tableSchemaHistory).
+	OrderBy(colSchemaHistoryTS + " DESC")
+
 var queryTuplesForDelete = sql.Select(
 	colNamespace,
 	colObjectID,
@@ -107,6 +107,24 @@ func (rwt spannerReadWriteTXN) StoreCounterValue(ctx context.Context, name strin
 	return nil
 }
 
+func (rwt spannerReadWriteTXN) WriteSchemaHistoryEntry(ctx context.Context, entry *core.SchemaHistoryEntry) error {
+	serialized, err := entry.MarshalVT()
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteSchemaHistory, err)
+	}
+
+	mutation := spanner.Insert(tableSchemaHistory,
+		[]string{colSchemaHistoryTS, colSchemaHistoryEntry},
+		[]any{spanner.CommitTimestamp, serialized},
+	)
+
+	if err := rwt.spannerRWT.BufferWrite([]*spanner.Mutation{mutation}); err != nil {
+		return fmt.Errorf(errUnableToWriteSchemaHistory, err)
+	}
+
+	return nil
+}
+
 func (rwt spannerReadWriteTXN) WriteRelationships(ctx context.Context, mutations []tuple.RelationshipUpdate) error {
 	var rowCountChange int64
 	for _, mutation := range mutations {
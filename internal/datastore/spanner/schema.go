@@ -32,6 +32,10 @@ const (
 	colCounterCurrentCount       = "current_count"
 	colCounterUpdatedAtTimestamp = "updated_at_timestamp"
 
+	tableSchemaHistory    = "schema_history"
+	colSchemaHistoryTS    = "timestamp"
+	colSchemaHistoryEntry = "serialized_entry"
+
 	tableTransactionMetadata = "transaction_metadata"
 	colTransactionTag        = "transaction_tag"
 	colMetadata              = "metadata"
@@ -67,6 +67,9 @@ const (
 	errUnableToDeleteCounter   = "unable to delete counter: %w"
 	errUnableToUpdateCounter   = "unable to update counter: %w"
 
+	errUnableToReadSchemaHistory  = "unable to read schema history: %w"
+	errUnableToWriteSchemaHistory = "unable to write schema history: %w"
+
 	// See https://cloud.google.com/spanner/docs/change-streams#data-retention
 	// See https://github.com/authzed/spicedb/issues/1457
 	defaultChangeStreamRetention = 24 * time.Hour
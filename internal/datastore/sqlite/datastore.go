@@ -0,0 +1,616 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/dlmiddlecote/sqlstats"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"golang.org/x/sync/errgroup"
+	sqlitedriver "modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	datastoreinternal "github.com/authzed/spicedb/internal/datastore"
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/internal/datastore/sqlite/migrations"
+	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/datastore/options"
+)
+
+const (
+	Engine = "sqlite"
+
+	colID               = "id"
+	colTimestamp        = "timestamp"
+	colMetadata         = "metadata"
+	colNamespace        = "namespace"
+	colConfig           = "serialized_config"
+	colCreatedTxn       = "created_transaction"
+	colDeletedTxn       = "deleted_transaction"
+	colObjectID         = "object_id"
+	colRelation         = "relation"
+	colUsersetNamespace = "userset_namespace"
+	colUsersetObjectID  = "userset_object_id"
+	colUsersetRelation  = "userset_relation"
+	colName             = "name"
+	colCaveatDefinition = "definition"
+	colCaveatName       = "caveat_name"
+	colCaveatContext    = "caveat_context"
+	colExpiration       = "expiration"
+
+	colCounterName              = "name"
+	colCounterSerializedFilter  = "serialized_filter"
+	colCounterCurrentCount      = "current_count"
+	colCounterUpdatedAtRevision = "count_updated_at_revision"
+
+	colSchemaHistoryEntry = "serialized_entry"
+
+	errUnableToInstantiate = "unable to instantiate datastore: %w"
+	liveDeletedTxnID       = uint64(math.MaxInt64)
+	batchDeleteSize        = 1000
+	seedingTimeout         = 10 * time.Second
+
+	// nowFunction is the name of the SQL function registered with the driver
+	// which returns the current time in the format used to store expirations.
+	nowFunction = "spicedb_now"
+
+	// expirationFormat matches the `_time_format=sqlite` format used by the
+	// driver when writing time values. Expirations are always written in UTC,
+	// which makes lexicographic comparisons consistent with time ordering.
+	expirationFormat = "2006-01-02 15:04:05.999999999-07:00"
+)
+
+var (
+	tracer = otel.Tracer("spicedb/internal/datastore/sqlite")
+
+	sb = sq.StatementBuilder.PlaceholderFormat(sq.Question)
+)
+
+func init() {
+	datastore.Engines = append(datastore.Engines, Engine)
+
+	// SQLite has no NOW() function, so one returning the current time in the
+	// expiration storage format is registered for every connection.
+	sqlitedriver.MustRegisterScalarFunction(nowFunction, 0, func(_ *sqlitedriver.FunctionContext, _ []driver.Value) (driver.Value, error) {
+		return time.Now().UTC().Format(expirationFormat), nil
+	})
+}
+
+type sqlFilter interface {
+	ToSql() (string, []any, error)
+}
+
+// NewSQLiteDatastore creates a new sqlite.Datastore value backed by the SQLite
+// database file at the given path. Supports customization via the various
+// options available in this package.
+//
+// The database must be migrated with `spicedb datastore migrate` before use.
+func NewSQLiteDatastore(ctx context.Context, path string, options ...Option) (datastore.Datastore, error) {
+	ds, err := newSQLiteDatastore(ctx, path, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return datastoreinternal.NewSeparatingContextDatastoreProxy(ds), nil
+}
+
+func newSQLiteDatastore(ctx context.Context, path string, options ...Option) (*Datastore, error) {
+	config, err := generateConfig(options)
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToInstantiate, err)
+	}
+
+	if path == "" {
+		return nil, fmt.Errorf(errUnableToInstantiate, errors.New("a path to the database file is required"))
+	}
+
+	db, collectors, err := openAndRegisterPrometheusCollectors(path, config)
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToInstantiate, err)
+	}
+
+	driver := migrations.NewSQLiteDriverFromDB(db)
+	queryBuilder := NewQueryBuilder(driver)
+
+	createTxn := sb.Insert(driver.RelationTupleTransaction()).Columns(colTimestamp, colMetadata)
+
+	// used for seeding the initial relation_tuple_transaction. using INSERT OR IGNORE on a known
+	// ID value makes this idempotent (i.e. safe to execute concurrently).
+	createBaseTxn := fmt.Sprintf("INSERT OR IGNORE INTO %s (id, timestamp) VALUES (1, 0)", driver.RelationTupleTransaction())
+
+	headMigration, err := migrations.Manager.HeadRevision()
+	if err != nil {
+		return nil, fmt.Errorf("invalid head migration found for sqlite: %w", err)
+	}
+
+	gcCtx, cancelGc := context.WithCancel(context.Background())
+
+	maxRevisionStaleness := time.Duration(float64(config.revisionQuantization.Nanoseconds())*
+		config.maxRevisionStalenessPercent) * time.Nanosecond
+
+	schema := common.NewSchemaInformationWithOptions(
+		common.WithRelationshipTableName(driver.RelationTuple()),
+		common.WithColNamespace(colNamespace),
+		common.WithColObjectID(colObjectID),
+		common.WithColRelation(colRelation),
+		common.WithColUsersetNamespace(colUsersetNamespace),
+		common.WithColUsersetObjectID(colUsersetObjectID),
+		common.WithColUsersetRelation(colUsersetRelation),
+		common.WithColCaveatName(colCaveatName),
+		common.WithColCaveatContext(colCaveatContext),
+		common.WithColExpiration(colExpiration),
+		common.WithPaginationFilterType(common.TupleComparison),
+		common.WithPlaceholderFormat(sq.Question),
+		common.WithNowFunction(nowFunction),
+		common.WithColumnOptimization(config.columnOptimizationOption),
+		common.WithExpirationDisabled(false),
+		common.WithExplicitLikeEscape(true),
+		common.SetIndexes(indexes),
+	)
+
+	store := &Datastore{
+		MigrationValidator:      common.NewMigrationValidator(headMigration, config.allowedMigrations),
+		db:                      db,
+		driver:                  driver,
+		collectors:              collectors,
+		path:                    path,
+		revisionQuantization:    config.revisionQuantization,
+		gcWindow:                config.gcWindow,
+		gcInterval:              config.gcInterval,
+		gcTimeout:               config.gcMaxOperationTime,
+		gcCtx:                   gcCtx,
+		cancelGc:                cancelGc,
+		watchEnabled:            !config.watchDisabled,
+		watchBufferLength:       config.watchBufferLength,
+		watchBufferWriteTimeout: config.watchBufferWriteTimeout,
+		createTxn:               createTxn,
+		createBaseTxn:           createBaseTxn,
+		QueryBuilder:            queryBuilder,
+		readTxOptions:           &sql.TxOptions{ReadOnly: true},
+		maxRetries:              config.maxRetries,
+		schema:                  *schema,
+		CachedOptimizedRevisions: revisions.NewCachedOptimizedRevisions(
+			maxRevisionStaleness,
+		),
+		CommonDecoder: revisions.CommonDecoder{
+			Kind: revisions.TransactionID,
+		},
+		filterMaximumIDCount: config.filterMaximumIDCount,
+	}
+
+	store.SetOptimizedRevisionFunc(store.optimizedRevisionFunc)
+
+	ctx, cancel := context.WithTimeout(ctx, seedingTimeout)
+	defer cancel()
+	err = store.seedDatabase(ctx)
+	if err != nil {
+		cancelGc()
+		return nil, errors.Join(err, store.closeDB())
+	}
+
+	// Start a goroutine for garbage collection.
+	if store.gcInterval > 0*time.Minute && config.gcEnabled {
+		store.gcGroup, store.gcCtx = errgroup.WithContext(store.gcCtx)
+		store.gcGroup.Go(func() error {
+			return common.StartGarbageCollector(
+				store.gcCtx,
+				store,
+				store.gcInterval,
+				store.gcWindow,
+				store.gcTimeout,
+			)
+		})
+	} else {
+		log.Warn().Msg("datastore background garbage collection disabled")
+	}
+
+	return store, nil
+}
+
+func (sds *Datastore) MetricsID() (string, error) {
+	return sds.path, nil
+}
+
+func (sds *Datastore) SnapshotReader(rev datastore.Revision) datastore.Reader {
+	createTxFunc := func(ctx context.Context) (*sql.Tx, txCleanupFunc, error) {
+		tx, err := sds.db.BeginTx(ctx, sds.readTxOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return tx, tx.Rollback, nil
+	}
+
+	executor := common.QueryRelationshipsExecutor{
+		Executor: newSQLiteExecutor(sds.db, sds),
+	}
+
+	return &sqliteReader{
+		sds.QueryBuilder,
+		createTxFunc,
+		executor,
+		buildLivingObjectFilterForRevision(rev),
+		sds.filterMaximumIDCount,
+		sds.schema,
+	}
+}
+
+func noCleanup() error { return nil }
+
+// ReadWriteTx starts a read/write transaction, which will be committed if no error is
+// returned and rolled back if an error is returned.
+func (sds *Datastore) ReadWriteTx(
+	ctx context.Context,
+	fn datastore.TxUserFunc,
+	opts ...options.RWTOptionsOption,
+) (datastore.Revision, error) {
+	config := options.NewRWTOptionsWithOptions(opts...)
+
+	var err error
+	for i := uint8(0); i <= sds.maxRetries; i++ {
+		var newTxnID uint64
+		if err = migrations.BeginTxFunc(ctx, sds.db, nil, func(tx *sql.Tx) error {
+			var metadata map[string]any
+			if config.Metadata != nil {
+				metadata = config.Metadata.AsMap()
+			}
+
+			longLivedTx := func(context.Context) (*sql.Tx, txCleanupFunc, error) {
+				return tx, noCleanup, nil
+			}
+
+			executor := common.QueryRelationshipsExecutor{
+				Executor: newSQLiteExecutor(tx, sds),
+			}
+
+			rwt := &sqliteReadWriteTXN{
+				sqliteReader: &sqliteReader{
+					sds.QueryBuilder,
+					longLivedTx,
+					executor,
+					currentlyLivingObjects,
+					sds.filterMaximumIDCount,
+					sds.schema,
+				},
+				tx: tx,
+				createTxn: func(ctx context.Context) (uint64, error) {
+					return sds.createNewTransaction(ctx, tx, metadata)
+				},
+			}
+
+			if err := fn(ctx, rwt); err != nil {
+				return err
+			}
+
+			// Transactions which did not write still produce a new revision.
+			newTxnID, err = rwt.transactionID(ctx)
+			return err
+		}); err != nil {
+			if !config.DisableRetries && isErrorRetryable(err) {
+				continue
+			}
+
+			return datastore.NoRevision, err
+		}
+
+		return revisions.NewForTransactionID(newTxnID), nil
+	}
+	if !config.DisableRetries {
+		err = fmt.Errorf("max retries exceeded: %w", err)
+	}
+
+	return datastore.NoRevision, err
+}
+
+// sqliteError is implemented by the errors returned by the SQLite driver.
+type sqliteError interface {
+	error
+	Code() int
+}
+
+var _ sqliteError = (*sqlitedriver.Error)(nil)
+
+// sqliteErrorCode returns the primary result code of a SQLite error, if any.
+func sqliteErrorCode(err error) (int, bool) {
+	var sqliteErr sqliteError
+	if !errors.As(err, &sqliteErr) {
+		return 0, false
+	}
+
+	// Extended result codes carry the primary result code in their lowest byte.
+	return sqliteErr.Code() & 0xff, true
+}
+
+func isUniqueConstraintError(err error) bool {
+	var sqliteErr sqliteError
+	return errors.As(err, &sqliteErr) && (sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE ||
+		sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY)
+}
+
+func isErrorRetryable(err error) bool {
+	code, ok := sqliteErrorCode(err)
+	if !ok {
+		log.Debug().Err(err).Msg("couldn't determine a sqlite error code")
+		return false
+	}
+
+	return code == sqlite3.SQLITE_BUSY || code == sqlite3.SQLITE_LOCKED
+}
+
+type querier interface {
+	QueryContext(context.Context, string, ...any) (*sql.Rows, error)
+}
+
+type asQueryableTx struct {
+	tx querier
+}
+
+func (aqt asQueryableTx) QueryFunc(ctx context.Context, f func(context.Context, common.Rows) error, sql string, args ...any) error {
+	rows, err := aqt.tx.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	return f(ctx, rows)
+}
+
+func newSQLiteExecutor(tx querier, explainable datastore.Explainable) common.ExecuteReadRelsQueryFunc {
+	// Reads outside of a read-write transaction run directly against the pool: in WAL mode
+	// each statement reads from a consistent snapshot of the database file without blocking
+	// the writer, and the revision filter applied to the query provides the MVCC view.
+	return func(ctx context.Context, builder common.RelationshipsQueryBuilder) (datastore.RelationshipIterator, error) {
+		return common.QueryRelationships[common.Rows, structpbWrapper](ctx, builder, asQueryableTx{tx}, explainable)
+	}
+}
+
+// Datastore is a SQLite-based implementation of the datastore.Datastore interface
+type Datastore struct {
+	*common.MigrationValidator
+	db            *sql.DB
+	driver        *migrations.SQLiteDriver
+	readTxOptions *sql.TxOptions
+	path          string
+	collectors    []prometheus.Collector
+
+	revisionQuantization    time.Duration
+	gcWindow                time.Duration
+	gcInterval              time.Duration
+	gcTimeout               time.Duration
+	watchBufferLength       uint16
+	watchBufferWriteTimeout time.Duration
+	watchEnabled            bool
+	maxRetries              uint8
+	filterMaximumIDCount    uint16
+	schema                  common.SchemaInformation
+
+	gcGroup  *errgroup.Group
+	gcCtx    context.Context
+	cancelGc context.CancelFunc
+	gcHasRun atomic.Bool
+	gcLock   sync.Mutex
+
+	createTxn     sq.InsertBuilder
+	createBaseTxn string
+
+	uniqueID atomic.Pointer[string]
+
+	*QueryBuilder
+	*revisions.CachedOptimizedRevisions
+	revisions.CommonDecoder
+}
+
+// Close closes the data store.
+func (sds *Datastore) Close() error {
+	sds.cancelGc()
+	if sds.gcGroup != nil {
+		if err := sds.gcGroup.Wait(); err != nil && !errors.Is(err, context.Canceled) {
+			log.Error().Err(err).Msg("error waiting for garbage collector to shutdown")
+		}
+	}
+	return sds.closeDB()
+}
+
+func (sds *Datastore) closeDB() error {
+	for _, collector := range sds.collectors {
+		_ = prometheus.Unregister(collector)
+	}
+	return sds.db.Close()
+}
+
+// ReadyState returns whether the datastore is ready to accept data. Datastores that require
+// database schema creation will return false until the migrations have been run to create
+// the necessary tables.
+func (sds *Datastore) ReadyState(ctx context.Context) (datastore.ReadyState, error) {
+	if err := sds.db.PingContext(ctx); err != nil {
+		return datastore.ReadyState{}, err
+	}
+
+	currentMigrationRevision, err := sds.driver.Version(ctx)
+	if err != nil {
+		return datastore.ReadyState{}, err
+	}
+
+	state := sds.MigrationReadyState(currentMigrationRevision)
+	if !state.IsReady {
+		return state, nil
+	}
+
+	isSeeded, err := sds.isSeeded(ctx)
+	if err != nil {
+		return datastore.ReadyState{}, err
+	}
+	if !isSeeded {
+		return datastore.ReadyState{
+			Message: "datastore is not properly seeded",
+			IsReady: false,
+		}, nil
+	}
+	return state, nil
+}
+
+func (sds *Datastore) Features(_ context.Context) (*datastore.Features, error) {
+	return sds.OfflineFeatures()
+}
+
+func (sds *Datastore) OfflineFeatures() (*datastore.Features, error) {
+	watchSupported := datastore.FeatureUnsupported
+	if sds.watchEnabled {
+		watchSupported = datastore.FeatureSupported
+	}
+
+	return &datastore.Features{
+		Watch: datastore.Feature{
+			Status: watchSupported,
+		},
+		IntegrityData: datastore.Feature{
+			Status: datastore.FeatureUnsupported,
+		},
+		ContinuousCheckpointing: datastore.Feature{
+			Status: datastore.FeatureUnsupported,
+		},
+		WatchEmitsImmediately: datastore.Feature{
+			Status: datastore.FeatureUnsupported,
+		},
+	}, nil
+}
+
+// isSeeded determines if the backing database has been seeded
+func (sds *Datastore) isSeeded(ctx context.Context) (bool, error) {
+	headRevision, err := sds.HeadRevision(ctx)
+	if err != nil {
+		return false, err
+	}
+	if headRevision == datastore.NoRevision {
+		return false, nil
+	}
+
+	_, err = sds.UniqueID(ctx)
+	if err != nil {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// seedDatabase initializes the first transaction revision and the unique ID
+// of the datastore if necessary.
+func (sds *Datastore) seedDatabase(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "seedDatabase")
+	defer span.End()
+
+	isSeeded, err := sds.isSeeded(ctx)
+	if err != nil {
+		return err
+	}
+	if isSeeded {
+		return nil
+	}
+
+	return migrations.BeginTxFunc(ctx, sds.db, nil, func(tx *sql.Tx) error {
+		// idempotent INSERT OR IGNORE transaction id=1. safe to be executed concurrently.
+		result, err := tx.ExecContext(ctx, sds.createBaseTxn)
+		if err != nil {
+			return fmt.Errorf("seedDatabase: %w", err)
+		}
+
+		seeded, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("seedDatabase: failed to get affected rows: %w", err)
+		}
+
+		if seeded > 0 {
+			log.Ctx(ctx).Info().Msg("seeded base datastore headRevision")
+		}
+
+		uuidSQL, uuidArgs, err := sb.
+			Insert(sds.driver.Metadata()).
+			Options("OR IGNORE").
+			Columns(metadataIDColumn, metadataUniqueIDColumn).
+			Values(0, uuid.NewString()).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("seedDatabase: failed to prepare SQL: %w", err)
+		}
+
+		insertUniqueResult, err := tx.ExecContext(ctx, uuidSQL, uuidArgs...)
+		if err != nil {
+			return fmt.Errorf("seedDatabase: failed to insert unique ID: %w", err)
+		}
+
+		seeded, err = insertUniqueResult.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("seedDatabase: failed to get affected rows: %w", err)
+		}
+
+		if seeded > 0 {
+			log.Ctx(ctx).Info().Msg("seeded base datastore unique ID")
+		}
+
+		return nil
+	})
+}
+
+func buildLivingObjectFilterForRevision(revision datastore.Revision) queryFilterer {
+	txID := revision.(revisions.TransactionIDRevision).TransactionID()
+	return func(original sq.SelectBuilder) sq.SelectBuilder {
+		return original.Where(sq.LtOrEq{colCreatedTxn: txID}).
+			Where(sq.Or{
+				sq.Eq{colDeletedTxn: liveDeletedTxnID},
+				sq.Gt{colDeletedTxn: txID},
+			})
+	}
+}
+
+func currentlyLivingObjects(original sq.SelectBuilder) sq.SelectBuilder {
+	return original.Where(sq.Eq{colDeletedTxn: liveDeletedTxnID})
+}
+
+func openAndRegisterPrometheusCollectors(path string, config sqliteOptions) (*sql.DB, []prometheus.Collector, error) {
+	db, err := sql.Open(migrations.DriverName, migrations.ConnectionString(path, config.busyTimeout))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	db.SetMaxOpenConns(config.maxOpenConns)
+	db.SetMaxIdleConns(config.maxOpenConns)
+
+	// Connections to a local file are cheap to keep around and closing them
+	// discards the page cache, so they are never expired.
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+
+	if !config.enablePrometheusStats {
+		return db, nil, nil
+	}
+
+	collector := sqlstats.NewStatsCollector("spicedb", db)
+	if err := prometheus.Register(collector); err != nil {
+		return nil, nil, errors.Join(err, db.Close())
+	}
+	collectors := []prometheus.Collector{collector}
+
+	gcMetrics, err := common.RegisterGCMetrics()
+	if err != nil {
+		_ = prometheus.Unregister(collector)
+		return nil, nil, errors.Join(err, db.Close())
+	}
+	collectors = append(collectors, gcMetrics...)
+
+	return db, collectors, nil
+}
@@ -0,0 +1,151 @@
+package sqlite
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	testdatastore "github.com/authzed/spicedb/internal/testserver/datastore"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/datastore/test"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+type busyError struct{}
+
+func (busyError) Error() string { return "database is locked (5) (SQLITE_BUSY)" }
+func (busyError) Code() int     { return sqlite3.SQLITE_BUSY }
+
+// Implement TestableDatastore interface
+func (sds *Datastore) ExampleRetryableError() error {
+	return busyError{}
+}
+
+type datastoreTester struct {
+	t *testing.T
+}
+
+func (dst datastoreTester) New(revisionQuantization, gcInterval, gcWindow time.Duration, watchBufferLength uint16) (datastore.Datastore, error) {
+	return newTestDatastore(dst.t,
+		RevisionQuantization(revisionQuantization),
+		// Writes to SQLite complete well within the allowed staleness, so a revision computed
+		// before them could otherwise still be returned once its quantization window expired.
+		MaxRevisionStalenessPercent(0),
+		GCWindow(gcWindow),
+		GCInterval(gcInterval),
+		WatchBufferLength(watchBufferLength),
+	), nil
+}
+
+func newTestDatastore(t *testing.T, options ...Option) datastore.Datastore {
+	engine := testdatastore.RunSQLiteForTesting(t)
+	return engine.NewDatastore(t, func(_, path string) datastore.Datastore {
+		ds, err := NewSQLiteDatastore(context.Background(), path, options...)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = ds.Close() })
+		return ds
+	})
+}
+
+func TestSQLiteDatastore(t *testing.T) {
+	t.Parallel()
+	test.AllWithExceptions(t, datastoreTester{t}, test.WithCategories(test.WatchSchemaCategory, test.WatchCheckpointsCategory), true)
+}
+
+func TestSQLiteReopenRetainsData(t *testing.T) {
+	t.Parallel()
+
+	var dbPath string
+	testdatastore.RunSQLiteForTesting(t).NewDatastore(t, func(_, path string) datastore.Datastore {
+		dbPath = path
+		return nil
+	})
+
+	ctx := t.Context()
+	ds, err := NewSQLiteDatastore(ctx, dbPath)
+	require.NoError(t, err)
+
+	uniqueID, err := ds.UniqueID(ctx)
+	require.NoError(t, err)
+
+	rev, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteRelationships(ctx, []tuple.RelationshipUpdate{
+			tuple.Create(tuple.MustParse("document:first#viewer@user:tom")),
+		})
+	})
+	require.NoError(t, err)
+	require.NoError(t, ds.Close())
+
+	reopened, err := NewSQLiteDatastore(ctx, dbPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = reopened.Close() })
+
+	reopenedID, err := reopened.UniqueID(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uniqueID, reopenedID)
+
+	headRev, err := reopened.HeadRevision(ctx)
+	require.NoError(t, err)
+	require.True(t, rev.Equal(headRev))
+
+	iter, err := reopened.SnapshotReader(headRev).QueryRelationships(ctx, datastore.RelationshipsFilter{
+		OptionalResourceType: "document",
+	})
+	require.NoError(t, err)
+
+	var found []string
+	for rel, err := range iter {
+		require.NoError(t, err)
+		found = append(found, tuple.MustString(rel))
+	}
+	require.Equal(t, []string{"document:first#viewer@user:tom"}, found)
+}
+
+func TestSQLiteGarbageCollection(t *testing.T) {
+	t.Parallel()
+
+	ds := newTestDatastore(t, RevisionQuantization(0), GCWindow(1*time.Millisecond), GCInterval(0))
+	ctx := t.Context()
+
+	_, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteRelationships(ctx, []tuple.RelationshipUpdate{
+			tuple.Create(tuple.MustParse("document:first#viewer@user:tom")),
+			tuple.Create(tuple.MustParse("document:second#viewer@user:tom[expiration:2020-01-01T00:00:00Z]")),
+		})
+	})
+	require.NoError(t, err)
+
+	_, err = ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		return rwt.WriteRelationships(ctx, []tuple.RelationshipUpdate{
+			tuple.Delete(tuple.MustParse("document:first#viewer@user:tom")),
+		})
+	})
+	require.NoError(t, err)
+
+	time.Sleep(5 * time.Millisecond)
+
+	gcds := ds.(datastore.UnwrappableDatastore).Unwrap().(common.GarbageCollectableDatastore)
+	gc, err := gcds.BuildGarbageCollector(ctx)
+	require.NoError(t, err)
+	defer gc.Close()
+
+	expired, err := gc.DeleteExpiredRels(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), expired)
+
+	now, err := gc.Now(ctx)
+	require.NoError(t, err)
+
+	watermark, err := gc.TxIDBefore(ctx, now)
+	require.NoError(t, err)
+	require.NotEqual(t, datastore.NoRevision, watermark)
+
+	removed, err := gc.DeleteBeforeTx(ctx, watermark)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), removed.Relationships)
+	require.Positive(t, removed.Transactions)
+}
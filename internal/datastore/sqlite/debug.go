@@ -0,0 +1,25 @@
+package sqlite
+
+import (
+	"errors"
+
+	"github.com/authzed/spicedb/pkg/datastore"
+)
+
+// errExplainUnsupported is returned by the explain methods, as the output of
+// SQLite's EXPLAIN QUERY PLAN is not parsed by this datastore.
+var errExplainUnsupported = errors.New("explain is unsupported in SQLite")
+
+func (sds *Datastore) PreExplainStatements() []string {
+	return nil
+}
+
+func (sds *Datastore) BuildExplainQuery(_ string, _ []any) (string, []any, error) {
+	return "", nil, errExplainUnsupported
+}
+
+func (sds *Datastore) ParseExplain(_ string) (datastore.ParsedExplain, error) {
+	return datastore.ParsedExplain{}, errExplainUnsupported
+}
+
+var _ datastore.Explainable = &Datastore{}
@@ -0,0 +1,183 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/ccoveille/go-safecast/v2"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/spiceerrors"
+)
+
+var (
+	_ common.GarbageCollectableDatastore = (*Datastore)(nil)
+	_ common.GarbageCollector            = (*sqliteGarbageCollector)(nil)
+)
+
+type sqliteGarbageCollector struct {
+	sds      *Datastore
+	isClosed bool
+}
+
+func (sds *Datastore) BuildGarbageCollector(_ context.Context) (common.GarbageCollector, error) {
+	return &sqliteGarbageCollector{sds: sds, isClosed: false}, nil
+}
+
+func (sds *Datastore) HasGCRun() bool {
+	return sds.gcHasRun.Load()
+}
+
+func (sds *Datastore) MarkGCCompleted() {
+	sds.gcHasRun.Store(true)
+}
+
+func (sds *Datastore) ResetGCCompleted() {
+	sds.gcHasRun.Store(false)
+}
+
+func (sgc *sqliteGarbageCollector) Close() {
+	sgc.isClosed = true
+}
+
+// LockForGCRun acquires an in-process lock, as a SQLite database file is only
+// ever served by a single SpiceDB process.
+func (sgc *sqliteGarbageCollector) LockForGCRun(_ context.Context) (bool, error) {
+	return sgc.sds.gcLock.TryLock(), nil
+}
+
+func (sgc *sqliteGarbageCollector) UnlockAfterGCRun() error {
+	sgc.sds.gcLock.Unlock()
+	return nil
+}
+
+func (sgc *sqliteGarbageCollector) Now(_ context.Context) (time.Time, error) {
+	if sgc.isClosed {
+		return time.Time{}, spiceerrors.MustBugf("sqliteGarbageCollector is closed")
+	}
+
+	// Transaction timestamps are written from the local clock, so it is used here
+	// as well.
+	return time.Now().UTC(), nil
+}
+
+func (sgc *sqliteGarbageCollector) TxIDBefore(ctx context.Context, before time.Time) (datastore.Revision, error) {
+	if sgc.isClosed {
+		return datastore.NoRevision, spiceerrors.MustBugf("sqliteGarbageCollector is closed")
+	}
+
+	// Find the highest transaction ID before the GC window.
+	query, args, err := sgc.sds.GetLastRevision.Where(sq.Lt{colTimestamp: before.UnixNano()}).ToSql()
+	if err != nil {
+		return datastore.NoRevision, err
+	}
+
+	var value sql.NullInt64
+	err = sgc.sds.db.QueryRowContext(ctx, query, args...).Scan(&value)
+	if err != nil {
+		return datastore.NoRevision, err
+	}
+
+	if !value.Valid {
+		log.Ctx(ctx).Debug().Time("before", before).Msg("no stale transactions found in the datastore")
+		return datastore.NoRevision, nil
+	}
+
+	uintValue, err := safecast.Convert[uint64](value.Int64)
+	if err != nil {
+		return datastore.NoRevision, spiceerrors.MustBugf("value could not be cast to uint64: %v", err)
+	}
+
+	return revisions.NewForTransactionID(uintValue), nil
+}
+
+func (sgc *sqliteGarbageCollector) DeleteBeforeTx(
+	ctx context.Context,
+	txID datastore.Revision,
+) (removed common.DeletionCounts, err error) {
+	if sgc.isClosed {
+		return removed, spiceerrors.MustBugf("sqliteGarbageCollector is closed")
+	}
+
+	revisionTx := txID.(revisions.TransactionIDRevision).TransactionID()
+
+	// Delete any relationship rows with deleted_transaction <= the transaction ID.
+	removed.Relationships, err = sgc.batchDelete(ctx, sgc.sds.driver.RelationTuple(), colID, sq.LtOrEq{colDeletedTxn: revisionTx})
+	if err != nil {
+		return removed, err
+	}
+
+	// Delete all transaction rows with ID < the transaction ID.
+	//
+	// We don't delete the transaction itself to ensure there is always at least
+	// one transaction present.
+	removed.Transactions, err = sgc.batchDelete(ctx, sgc.sds.driver.RelationTupleTransaction(), colID, sq.Lt{colID: revisionTx})
+	if err != nil {
+		return removed, err
+	}
+
+	// Delete any namespace rows with deleted_transaction <= the transaction ID.
+	removed.Namespaces, err = sgc.batchDelete(ctx, sgc.sds.driver.Namespace(), colID, sq.LtOrEq{colDeletedTxn: revisionTx})
+	return removed, err
+}
+
+func (sgc *sqliteGarbageCollector) DeleteExpiredRels(ctx context.Context) (int64, error) {
+	if sgc.sds.schema.ExpirationDisabled {
+		return 0, nil
+	}
+
+	now, err := sgc.Now(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return sgc.batchDelete(
+		ctx,
+		sgc.sds.driver.RelationTuple(),
+		colID,
+		sq.Lt{colExpiration: now.Add(-1 * sgc.sds.gcWindow)},
+	)
+}
+
+// batchDelete deletes the rows matching the filter in batches. DELETE ... LIMIT is an
+// optional SQLite compile time feature, so each batch is selected by a subquery on the
+// given key column instead.
+func (sgc *sqliteGarbageCollector) batchDelete(ctx context.Context, tableName string, keyColumn string, filter sqlFilter) (int64, error) {
+	if sgc.isClosed {
+		return -1, spiceerrors.MustBugf("sqliteGarbageCollector is closed")
+	}
+
+	batchSQL, batchArgs, err := sb.Select(keyColumn).From(tableName).Where(filter).Limit(batchDeleteSize).ToSql()
+	if err != nil {
+		return -1, err
+	}
+
+	query, args, err := sb.Delete(tableName).Where(keyColumn+" IN ("+batchSQL+")", batchArgs...).ToSql()
+	if err != nil {
+		return -1, err
+	}
+
+	var deletedCount int64
+	for {
+		cr, err := sgc.sds.db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return deletedCount, err
+		}
+
+		rowsDeleted, err := cr.RowsAffected()
+		if err != nil {
+			return deletedCount, err
+		}
+		deletedCount += rowsDeleted
+		if rowsDeleted < batchDeleteSize {
+			break
+		}
+	}
+
+	return deletedCount, nil
+}
@@ -0,0 +1,181 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	// Registers the pure-Go "sqlite" database/sql driver.
+	_ "modernc.org/sqlite"
+
+	"github.com/authzed/spicedb/pkg/migrate"
+)
+
+const (
+	errUnableToInstantiate = "unable to instantiate SQLiteDriver: %w"
+
+	// DriverName is the name under which the SQLite database/sql driver is registered.
+	DriverName = "sqlite"
+
+	// DefaultBusyTimeout is the default amount of time a connection waits for
+	// a lock on the database file to be released before failing.
+	DefaultBusyTimeout = 5 * time.Second
+
+	colVersion = "version"
+)
+
+var sb = sq.StatementBuilder.PlaceholderFormat(sq.Question)
+
+// SQLiteDriver is an implementation of migrate.Driver for SQLite
+type SQLiteDriver struct {
+	db *sql.DB
+	tables
+}
+
+// ConnectionString returns the DSN used to open the SQLite database file at
+// the given path. It configures write-ahead logging so that readers do not
+// block the writer, a busy timeout so that concurrent writers queue for the
+// write lock, case sensitive LIKE matching to agree with the other datastores,
+// and the time format expected by the datastore.
+func ConnectionString(path string, busyTimeout time.Duration) string {
+	params := url.Values{}
+	params.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", busyTimeout.Milliseconds()))
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "case_sensitive_like(1)")
+	params.Set("_time_format", "sqlite")
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	return path + separator + params.Encode()
+}
+
+// NewSQLiteDriverFromPath creates a new migration driver for the SQLite
+// database file at the given path.
+func NewSQLiteDriverFromPath(path string) (*SQLiteDriver, error) {
+	if path == "" {
+		return nil, fmt.Errorf(errUnableToInstantiate, errors.New("a path to the database file is required"))
+	}
+
+	db, err := sql.Open(DriverName, ConnectionString(path, DefaultBusyTimeout))
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToInstantiate, err)
+	}
+
+	return NewSQLiteDriverFromDB(db), nil
+}
+
+// NewSQLiteDriverFromDB creates a new migration driver with a connection pool specified upfront.
+func NewSQLiteDriverFromDB(db *sql.DB) *SQLiteDriver {
+	return &SQLiteDriver{db, tables{}}
+}
+
+// Version returns the version of the schema to which the connected database
+// has been migrated.
+func (driver *SQLiteDriver) Version(ctx context.Context) (string, error) {
+	var tableCount int
+	if err := driver.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?",
+		driver.migrationVersion(),
+	).Scan(&tableCount); err != nil {
+		return "", fmt.Errorf("unable to query revision table: %w", err)
+	}
+	if tableCount == 0 {
+		return "", nil
+	}
+
+	query, args, err := sb.Select(colVersion).From(driver.migrationVersion()).ToSql()
+	if err != nil {
+		return "", fmt.Errorf("unable to generate query for revision: %w", err)
+	}
+
+	var version string
+	if err := driver.db.QueryRowContext(ctx, query, args...).Scan(&version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("unable to query revision: %w", err)
+	}
+
+	return version, nil
+}
+
+func (driver *SQLiteDriver) Conn() Wrapper {
+	return Wrapper{db: driver.db, tables: driver.tables}
+}
+
+func (driver *SQLiteDriver) RunTx(ctx context.Context, f migrate.TxMigrationFunc[TxWrapper]) error {
+	return BeginTxFunc(ctx, driver.db, nil, func(tx *sql.Tx) error {
+		return f(ctx, TxWrapper{tx, driver.tables})
+	})
+}
+
+// BeginTxFunc is a polyfill for database/sql which implements a closure style transaction lifecycle.
+// The underlying transaction is aborted if the supplied function returns an error.
+// The underlying transaction is committed if the supplied function returns nil.
+func BeginTxFunc(ctx context.Context, db *sql.DB, txOptions *sql.TxOptions, f func(*sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, txOptions)
+	if err != nil {
+		return err
+	}
+
+	if err := f(tx); err != nil {
+		rerr := tx.Rollback()
+		if rerr != nil {
+			return errors.Join(err, rerr)
+		}
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// WriteVersion overwrites the single row of the version table, which records
+// the version of the database schema.
+func (driver *SQLiteDriver) WriteVersion(ctx context.Context, txWrapper TxWrapper, version, replaced string) error {
+	var (
+		stmt string
+		args []any
+		err  error
+	)
+	if replaced == "" {
+		stmt, args, err = sb.Insert(driver.migrationVersion()).Columns(colVersion).Values(version).ToSql()
+	} else {
+		stmt, args, err = sb.Update(driver.migrationVersion()).
+			Set(colVersion, version).
+			Where(sq.Eq{colVersion: replaced}).
+			ToSql()
+	}
+	if err != nil {
+		return fmt.Errorf("unable to write version: %w", err)
+	}
+
+	result, err := txWrapper.tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return fmt.Errorf("unable to write version: %w", err)
+	}
+
+	written, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("unable to write version: %w", err)
+	}
+	if written != 1 {
+		return fmt.Errorf("unable to write version: expected to replace version %q", replaced)
+	}
+
+	return nil
+}
+
+func (driver *SQLiteDriver) Close(_ context.Context) error {
+	return driver.db.Close()
+}
+
+var _ migrate.Driver[Wrapper, TxWrapper] = &SQLiteDriver{}
@@ -0,0 +1,32 @@
+package migrations
+
+import (
+	"database/sql"
+
+	"github.com/authzed/spicedb/pkg/migrate"
+)
+
+var (
+	noNonatomicMigration migrate.MigrationFunc[Wrapper]
+
+	// Manager is the singleton migration manager instance for SQLite
+	Manager = migrate.NewManager[*SQLiteDriver, Wrapper, TxWrapper]()
+)
+
+// Wrapper makes it possible to forward the table schema needed for SQLite MigrationFunc to run
+type Wrapper struct {
+	db     *sql.DB
+	tables tables
+}
+
+// TxWrapper makes it possible to forward the table schema to a transactional migration func.
+type TxWrapper struct {
+	tx     *sql.Tx
+	tables tables
+}
+
+func mustRegisterMigration(version, replaces string, up migrate.MigrationFunc[Wrapper], upTx migrate.TxMigrationFunc[TxWrapper]) {
+	if err := Manager.Register(version, replaces, up, upTx); err != nil {
+		panic("failed to register migration  " + err.Error())
+	}
+}
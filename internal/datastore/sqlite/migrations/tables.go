@@ -0,0 +1,53 @@
+package migrations
+
+const (
+	tableNamespace            = "namespace_config"
+	tableTransaction          = "relation_tuple_transaction"
+	tableTuple                = "relation_tuple"
+	tableMigrationVersion     = "spicedb_migration_version"
+	tableMetadata             = "spicedb_metadata"
+	tableCaveat               = "caveat"
+	tableRelationshipCounters = "relationship_counters"
+	tableSchemaHistory        = "schema_history"
+)
+
+type tables struct{}
+
+func (tables) migrationVersion() string {
+	return tableMigrationVersion
+}
+
+// RelationTupleTransaction returns the transaction table name.
+func (tables) RelationTupleTransaction() string {
+	return tableTransaction
+}
+
+// RelationTuple returns the relationship tuple table name.
+func (tables) RelationTuple() string {
+	return tableTuple
+}
+
+// Namespace returns the namespace table name.
+func (tables) Namespace() string {
+	return tableNamespace
+}
+
+// Metadata returns the datastore metadata table name.
+func (tables) Metadata() string {
+	return tableMetadata
+}
+
+// Caveat returns the caveat table name.
+func (tables) Caveat() string {
+	return tableCaveat
+}
+
+// RelationshipCounters returns the relationship counters table name.
+func (tables) RelationshipCounters() string {
+	return tableRelationshipCounters
+}
+
+// SchemaHistory returns the schema history table name.
+func (tables) SchemaHistory() string {
+	return tableSchemaHistory
+}
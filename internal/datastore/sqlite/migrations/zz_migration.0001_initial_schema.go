@@ -0,0 +1,151 @@
+package migrations
+
+import "fmt"
+
+// Table names prefixed with `sqlite_` are reserved by SQLite, so the version
+// and metadata tables are prefixed with `spicedb_` instead.
+func createMigrationVersion(t tables) string {
+	return fmt.Sprintf(`CREATE TABLE %s (
+		version TEXT NOT NULL);`,
+		t.migrationVersion(),
+	)
+}
+
+func createMetadataTable(t tables) string {
+	return fmt.Sprintf(`CREATE TABLE %s (
+		id INTEGER NOT NULL PRIMARY KEY,
+		unique_id TEXT NOT NULL);`,
+		t.Metadata(),
+	)
+}
+
+// The transaction timestamp is stored as nanoseconds since the unix epoch, so
+// that revision selection and garbage collection can compare it numerically.
+// AUTOINCREMENT guarantees that transaction IDs are never reused, even after
+// garbage collection.
+func createRelationTupleTransaction(t tables) string {
+	return fmt.Sprintf(`CREATE TABLE %s (
+		id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+		timestamp INTEGER NOT NULL,
+		metadata BLOB NULL DEFAULT NULL);`,
+		t.RelationTupleTransaction(),
+	)
+}
+
+func createRelationTupleTransactionTimestampIndex(t tables) string {
+	return fmt.Sprintf(`CREATE INDEX ix_relation_tuple_transaction_by_timestamp ON %s (timestamp);`,
+		t.RelationTupleTransaction(),
+	)
+}
+
+func createNamespaceConfig(t tables) string {
+	return fmt.Sprintf(`CREATE TABLE %s (
+		id INTEGER NOT NULL PRIMARY KEY,
+		namespace TEXT NOT NULL,
+		serialized_config BLOB NOT NULL,
+		created_transaction INTEGER NOT NULL,
+		deleted_transaction INTEGER NOT NULL DEFAULT 9223372036854775807,
+		CONSTRAINT uq_namespace_living UNIQUE (namespace, deleted_transaction));`,
+		t.Namespace(),
+	)
+}
+
+// The expiration column is declared as DATETIME so that the driver converts it
+// back into a time.Time. It is always written in UTC using a fixed format,
+// which keeps lexicographic comparisons consistent with chronological order.
+func createRelationTuple(t tables) string {
+	return fmt.Sprintf(`CREATE TABLE %s (
+		id INTEGER NOT NULL PRIMARY KEY,
+		namespace TEXT NOT NULL,
+		object_id TEXT NOT NULL,
+		relation TEXT NOT NULL,
+		userset_namespace TEXT NOT NULL,
+		userset_object_id TEXT NOT NULL,
+		userset_relation TEXT NOT NULL,
+		caveat_name TEXT,
+		caveat_context BLOB,
+		expiration DATETIME NULL DEFAULT NULL,
+		created_transaction INTEGER NOT NULL,
+		deleted_transaction INTEGER NOT NULL DEFAULT 9223372036854775807,
+		CONSTRAINT uq_relation_tuple_namespace UNIQUE (namespace, object_id, relation, userset_namespace, userset_object_id, userset_relation, created_transaction, deleted_transaction),
+		CONSTRAINT uq_relation_tuple_living UNIQUE (namespace, object_id, relation, userset_namespace, userset_object_id, userset_relation, deleted_transaction));`,
+		t.RelationTuple(),
+	)
+}
+
+func createRelationTupleBySubjectIndex(t tables) string {
+	return fmt.Sprintf(`CREATE INDEX ix_relation_tuple_by_subject ON %s (userset_object_id, userset_namespace, userset_relation, namespace, relation);`,
+		t.RelationTuple(),
+	)
+}
+
+func createRelationTupleBySubjectRelationIndex(t tables) string {
+	return fmt.Sprintf(`CREATE INDEX ix_relation_tuple_by_subject_relation ON %s (userset_namespace, userset_relation, namespace, relation);`,
+		t.RelationTuple(),
+	)
+}
+
+func createRelationTupleByDeletedTransactionIndex(t tables) string {
+	return fmt.Sprintf(`CREATE INDEX ix_relation_tuple_by_deleted_transaction ON %s (deleted_transaction);`,
+		t.RelationTuple(),
+	)
+}
+
+func createRelationTupleWatchIndex(t tables) string {
+	return fmt.Sprintf(`CREATE INDEX ix_relation_tuple_watch ON %s (created_transaction, deleted_transaction DESC);`,
+		t.RelationTuple(),
+	)
+}
+
+// Used for cleaning up expired relationships.
+func createRelationTupleExpiredIndex(t tables) string {
+	return fmt.Sprintf(`CREATE INDEX ix_relation_tuple_expired ON %s (expiration);`,
+		t.RelationTuple(),
+	)
+}
+
+func createCaveatTable(t tables) string {
+	return fmt.Sprintf(`CREATE TABLE %s (
+		name TEXT NOT NULL,
+		definition BLOB NOT NULL,
+		created_transaction INTEGER NOT NULL,
+		deleted_transaction INTEGER NOT NULL DEFAULT 9223372036854775807,
+		CONSTRAINT pk_caveat PRIMARY KEY (name, deleted_transaction),
+		CONSTRAINT uq_caveat UNIQUE (name, created_transaction, deleted_transaction));`,
+		t.Caveat(),
+	)
+}
+
+func createRelationshipCountersTable(t tables) string {
+	return fmt.Sprintf(`CREATE TABLE %s (
+		id INTEGER NOT NULL PRIMARY KEY,
+		name TEXT NOT NULL,
+		serialized_filter BLOB NOT NULL,
+		current_count INTEGER NOT NULL DEFAULT 0,
+		count_updated_at_revision INTEGER NOT NULL DEFAULT 0,
+		created_transaction INTEGER NOT NULL,
+		deleted_transaction INTEGER NOT NULL DEFAULT 9223372036854775807,
+		CONSTRAINT uq_relationship_counter_living UNIQUE (name, deleted_transaction));`,
+		t.RelationshipCounters(),
+	)
+}
+
+func init() {
+	mustRegisterMigration("initial", "", noNonatomicMigration,
+		newStatementBatch(
+			createMigrationVersion,
+			createMetadataTable,
+			createRelationTupleTransaction,
+			createRelationTupleTransactionTimestampIndex,
+			createNamespaceConfig,
+			createRelationTuple,
+			createRelationTupleBySubjectIndex,
+			createRelationTupleBySubjectRelationIndex,
+			createRelationTupleByDeletedTransactionIndex,
+			createRelationTupleWatchIndex,
+			createRelationTupleExpiredIndex,
+			createCaveatTable,
+			createRelationshipCountersTable,
+		).execute,
+	)
+}
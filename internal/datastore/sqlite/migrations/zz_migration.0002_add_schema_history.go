@@ -0,0 +1,23 @@
+package migrations
+
+import "fmt"
+
+// Entries of the schema history are never deleted, so the deleted transaction
+// is only present for the living object filter used by the readers. As at most
+// one entry is written per transaction, the creating transaction is the key.
+func createSchemaHistoryTable(t tables) string {
+	return fmt.Sprintf(`CREATE TABLE %s (
+		created_transaction INTEGER NOT NULL PRIMARY KEY,
+		serialized_entry BLOB NOT NULL,
+		deleted_transaction INTEGER NOT NULL DEFAULT 9223372036854775807);`,
+		t.SchemaHistory(),
+	)
+}
+
+func init() {
+	mustRegisterMigration("add-schema-history", "initial", noNonatomicMigration,
+		newStatementBatch(
+			createSchemaHistoryTable,
+		).execute,
+	)
+}
@@ -0,0 +1,243 @@
+package sqlite
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/sqlite/migrations"
+	log "github.com/authzed/spicedb/internal/logging"
+)
+
+const (
+	errQuantizationTooLarge = "revision quantization interval (%s) must be less than GC window (%s)"
+
+	defaultGarbageCollectionWindow           = 24 * time.Hour
+	defaultGarbageCollectionInterval         = time.Minute * 3
+	defaultGarbageCollectionMaxOperationTime = time.Minute
+	defaultMaxOpenConns                      = 10
+	defaultBusyTimeout                       = migrations.DefaultBusyTimeout
+	defaultWatchBufferLength                 = 128
+	defaultWatchBufferWriteTimeout           = 1 * time.Second
+	defaultQuantization                      = 5 * time.Second
+	defaultMaxRevisionStalenessPercent       = 0.1
+	defaultEnablePrometheusStats             = false
+	defaultMaxRetries                        = 8
+	defaultGCEnabled                         = true
+	defaultFilterMaximumIDCount              = 100
+	defaultColumnOptimizationOption          = common.ColumnOptimizationOptionStaticValues
+	defaultWatchDisabled                     = false
+)
+
+type sqliteOptions struct {
+	revisionQuantization        time.Duration
+	gcWindow                    time.Duration
+	gcInterval                  time.Duration
+	gcMaxOperationTime          time.Duration
+	maxRevisionStalenessPercent float64
+	watchBufferLength           uint16
+	watchBufferWriteTimeout     time.Duration
+	enablePrometheusStats       bool
+	maxOpenConns                int
+	busyTimeout                 time.Duration
+	maxRetries                  uint8
+	gcEnabled                   bool
+	filterMaximumIDCount        uint16
+	allowedMigrations           []string
+	columnOptimizationOption    common.ColumnOptimizationOption
+	watchDisabled               bool
+}
+
+// Option provides the facility to configure how clients within the
+// SQLite datastore interact with the database file.
+type Option func(*sqliteOptions)
+
+func generateConfig(options []Option) (sqliteOptions, error) {
+	computed := sqliteOptions{
+		gcWindow:                    defaultGarbageCollectionWindow,
+		gcInterval:                  defaultGarbageCollectionInterval,
+		gcMaxOperationTime:          defaultGarbageCollectionMaxOperationTime,
+		watchBufferLength:           defaultWatchBufferLength,
+		watchBufferWriteTimeout:     defaultWatchBufferWriteTimeout,
+		maxOpenConns:                defaultMaxOpenConns,
+		busyTimeout:                 defaultBusyTimeout,
+		revisionQuantization:        defaultQuantization,
+		maxRevisionStalenessPercent: defaultMaxRevisionStalenessPercent,
+		enablePrometheusStats:       defaultEnablePrometheusStats,
+		maxRetries:                  defaultMaxRetries,
+		gcEnabled:                   defaultGCEnabled,
+		filterMaximumIDCount:        defaultFilterMaximumIDCount,
+		columnOptimizationOption:    defaultColumnOptimizationOption,
+		watchDisabled:               defaultWatchDisabled,
+	}
+
+	for _, option := range options {
+		option(&computed)
+	}
+
+	// Run any checks on the config that need to be done
+	if computed.revisionQuantization >= computed.gcWindow {
+		return computed, fmt.Errorf(
+			errQuantizationTooLarge,
+			computed.revisionQuantization,
+			computed.gcWindow,
+		)
+	}
+
+	if computed.filterMaximumIDCount == 0 {
+		computed.filterMaximumIDCount = 100
+		log.Warn().Msg("filterMaximumIDCount not set, defaulting to 100")
+	}
+
+	if computed.maxOpenConns < 1 {
+		computed.maxOpenConns = 1
+	}
+
+	return computed, nil
+}
+
+// WatchBufferLength is the number of entries that can be stored in the watch
+// buffer while awaiting read by the client.
+//
+// This value defaults to 128.
+func WatchBufferLength(watchBufferLength uint16) Option {
+	return func(so *sqliteOptions) {
+		so.watchBufferLength = watchBufferLength
+	}
+}
+
+// WatchBufferWriteTimeout is the maximum timeout for writing to the watch buffer,
+// after which the caller to the watch will be disconnected.
+func WatchBufferWriteTimeout(watchBufferWriteTimeout time.Duration) Option {
+	return func(so *sqliteOptions) { so.watchBufferWriteTimeout = watchBufferWriteTimeout }
+}
+
+// RevisionQuantization is the time bucket size to which advertised
+// revisions will be rounded.
+//
+// This value defaults to 5 seconds.
+func RevisionQuantization(quantization time.Duration) Option {
+	return func(so *sqliteOptions) {
+		so.revisionQuantization = quantization
+	}
+}
+
+// MaxRevisionStalenessPercent is the amount of time, expressed as a percentage of
+// the revision quantization window, that a previously computed rounded revision
+// can still be advertised after the next rounded revision would otherwise be ready.
+//
+// This value defaults to 0.1 (10%).
+func MaxRevisionStalenessPercent(stalenessPercent float64) Option {
+	return func(so *sqliteOptions) {
+		so.maxRevisionStalenessPercent = stalenessPercent
+	}
+}
+
+// GCWindow is the maximum age of a passed revision that will be considered
+// valid.
+//
+// This value defaults to 24 hours.
+func GCWindow(window time.Duration) Option {
+	return func(so *sqliteOptions) {
+		so.gcWindow = window
+	}
+}
+
+// GCInterval is the interval at which garbage collection will occur.
+//
+// This value defaults to 3 minutes.
+func GCInterval(interval time.Duration) Option {
+	return func(so *sqliteOptions) {
+		so.gcInterval = interval
+	}
+}
+
+// GCEnabled indicates whether garbage collection is enabled.
+//
+// GC is enabled by default.
+func GCEnabled(isGCEnabled bool) Option {
+	return func(so *sqliteOptions) {
+		so.gcEnabled = isGCEnabled
+	}
+}
+
+// GCMaxOperationTime is the maximum operation time of a garbage collection
+// pass before it times out.
+//
+// This value defaults to 1 minute.
+func GCMaxOperationTime(time time.Duration) Option {
+	return func(so *sqliteOptions) {
+		so.gcMaxOperationTime = time
+	}
+}
+
+// MaxRetries is the maximum number of times a retriable transaction will be
+// client-side retried.
+//
+// Default: 8
+func MaxRetries(maxRetries uint8) Option {
+	return func(so *sqliteOptions) {
+		so.maxRetries = maxRetries
+	}
+}
+
+// WithEnablePrometheusStats marks whether Prometheus metrics provided by Go's database/sql package
+// are enabled.
+//
+// Prometheus metrics are disabled by default.
+func WithEnablePrometheusStats(enablePrometheusStats bool) Option {
+	return func(so *sqliteOptions) {
+		so.enablePrometheusStats = enablePrometheusStats
+	}
+}
+
+// MaxOpenConns is the maximum number of connections opened to the database
+// file. SQLite permits a single writer at a time, so additional connections
+// only increase read concurrency.
+// See https://pkg.go.dev/database/sql#DB.SetMaxOpenConns
+//
+// This value defaults to 10.
+func MaxOpenConns(conns int) Option {
+	return func(so *sqliteOptions) {
+		so.maxOpenConns = conns
+	}
+}
+
+// BusyTimeout is the amount of time a connection waits for a lock held by
+// another connection on the database file before failing with SQLITE_BUSY.
+//
+// This value defaults to 5 seconds.
+func BusyTimeout(timeout time.Duration) Option {
+	return func(so *sqliteOptions) {
+		so.busyTimeout = timeout
+	}
+}
+
+// FilterMaximumIDCount is the maximum number of IDs that can be used to filter IDs in queries
+func FilterMaximumIDCount(filterMaximumIDCount uint16) Option {
+	return func(so *sqliteOptions) { so.filterMaximumIDCount = filterMaximumIDCount }
+}
+
+// AllowedMigrations configures a set of additional migrations that will pass
+// the health check (head migration is always allowed).
+func AllowedMigrations(allowedMigrations []string) Option {
+	return func(so *sqliteOptions) { so.allowedMigrations = allowedMigrations }
+}
+
+// WithColumnOptimization configures the column optimization strategy for the SQLite datastore.
+func WithColumnOptimization(isEnabled bool) Option {
+	return func(so *sqliteOptions) {
+		if isEnabled {
+			so.columnOptimizationOption = common.ColumnOptimizationOptionStaticValues
+		} else {
+			so.columnOptimizationOption = common.ColumnOptimizationOptionNone
+		}
+	}
+}
+
+// WithWatchDisabled disables the watch functionality in the SQLite datastore.
+func WithWatchDisabled(isDisabled bool) Option {
+	return func(so *sqliteOptions) {
+		so.watchDisabled = isDisabled
+	}
+}
@@ -0,0 +1,753 @@
+package sqlite
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/ccoveille/go-safecast/v2"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/datastore/options"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/spiceerrors"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+const (
+	errUnableToWriteRelationships     = "unable to write relationships: %w"
+	errUnableToBulkWriteRelationships = "unable to bulk write relationships: %w"
+	errUnableToDeleteRelationships    = "unable to delete relationships: %w"
+	errUnableToWriteConfig            = "unable to write namespace config: %w"
+	errUnableToDeleteConfig           = "unable to delete namespace config: %w"
+	errUnableToWriteSchemaHistory     = "unable to write schema history: %w"
+
+	// bulkInsertRowsLimit keeps the number of bound parameters of a single
+	// insert statement well below SQLite's limit of 32766.
+	bulkInsertRowsLimit = 1_000
+
+	// maxClausesPerQuery keeps the expression tree of a query matching many
+	// relationships below SQLite's maximum depth of 1000.
+	maxClausesPerQuery = 500
+)
+
+type sqliteReadWriteTXN struct {
+	*sqliteReader
+
+	tx        *sql.Tx
+	createTxn func(context.Context) (uint64, error)
+	newTxnID  uint64
+}
+
+// transactionID returns the ID of the transaction row for this read-write transaction,
+// creating it on first use. The row is written lazily so that the write lock on the
+// database file is only acquired once the transaction first writes, letting reads
+// proceed alongside another writer.
+func (rwt *sqliteReadWriteTXN) transactionID(ctx context.Context) (uint64, error) {
+	if rwt.newTxnID == 0 {
+		newTxnID, err := rwt.createTxn(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("unable to create new txn ID: %w", err)
+		}
+		rwt.newTxnID = newTxnID
+	}
+
+	return rwt.newTxnID, nil
+}
+
+// structpbWrapper is used to marshall maps into JSON encoded blobs
+type structpbWrapper map[string]any
+
+func (cc *structpbWrapper) Scan(val any) error {
+	if val == nil {
+		clear(*cc)
+		*cc = nil
+		return nil
+	}
+
+	var v []byte
+	switch typed := val.(type) {
+	case []byte:
+		v = typed
+	case string:
+		v = []byte(typed)
+	default:
+		return fmt.Errorf("unsupported type: %T", val)
+	}
+
+	clear(*cc)
+	return json.Unmarshal(v, cc)
+}
+
+func (cc *structpbWrapper) Value() (driver.Value, error) {
+	return json.Marshal(&cc)
+}
+
+// utcExpiration normalizes an expiration to UTC, which is required for stored
+// expirations to compare correctly.
+func utcExpiration(expiration *time.Time) *time.Time {
+	if expiration == nil {
+		return nil
+	}
+
+	utc := expiration.UTC()
+	return &utc
+}
+
+func (rwt *sqliteReadWriteTXN) RegisterCounter(ctx context.Context, name string, filter *core.RelationshipFilter) error {
+	newTxnID, err := rwt.transactionID(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Check if the counter already exists.
+	counters, err := rwt.lookupCounters(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if len(counters) > 0 {
+		return datastore.NewCounterAlreadyRegisteredErr(name, filter)
+	}
+
+	serializedFilter, err := filter.MarshalVT()
+	if err != nil {
+		return fmt.Errorf("unable to serialize filter: %w", err)
+	}
+
+	// Insert the counter.
+	query, args, err := rwt.InsertCounterQuery.
+		Values(
+			name,
+			serializedFilter,
+			0,
+			0,
+			newTxnID,
+		).ToSql()
+	if err != nil {
+		return fmt.Errorf("unable to register counter: %w", err)
+	}
+
+	_, err = rwt.tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isUniqueConstraintError(err) {
+			return datastore.NewCounterAlreadyRegisteredErr(name, filter)
+		}
+
+		return fmt.Errorf("unable to register counter: %w", err)
+	}
+
+	return nil
+}
+
+func (rwt *sqliteReadWriteTXN) UnregisterCounter(ctx context.Context, name string) error {
+	newTxnID, err := rwt.transactionID(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Ensure the counter exists.
+	counters, err := rwt.lookupCounters(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if len(counters) == 0 {
+		return datastore.NewCounterNotRegisteredErr(name)
+	}
+
+	// Delete the counter.
+	query, args, err := rwt.DeleteCounterQuery.
+		Where(sq.Eq{colName: name}).
+		Set(colDeletedTxn, newTxnID).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("unable to unregister counter: %w", err)
+	}
+
+	_, err = rwt.tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("unable to unregister counter: %w", err)
+	}
+
+	return nil
+}
+
+func (rwt *sqliteReadWriteTXN) StoreCounterValue(ctx context.Context, name string, value int, computedAtRevision datastore.Revision) error {
+	// Ensure the counter exists.
+	counters, err := rwt.lookupCounters(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if len(counters) == 0 {
+		return datastore.NewCounterNotRegisteredErr(name)
+	}
+
+	updateRevisionID := computedAtRevision.(revisions.TransactionIDRevision).TransactionID()
+
+	// Update the counter.
+	query, args, err := rwt.UpdateCounterQuery.
+		Where(sq.Eq{colName: name}).
+		Set(colCounterCurrentCount, value).
+		Set(colCounterUpdatedAtRevision, updateRevisionID).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("unable to store counter value: %w", err)
+	}
+
+	_, err = rwt.tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("unable to store counter value: %w", err)
+	}
+
+	return nil
+}
+
+func (rwt *sqliteReadWriteTXN) WriteSchemaHistoryEntry(ctx context.Context, entry *core.SchemaHistoryEntry) error {
+	newTxnID, err := rwt.transactionID(ctx)
+	if err != nil {
+		return err
+	}
+
+	entryBytes, err := entry.MarshalVT()
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteSchemaHistory, err)
+	}
+
+	query, args, err := rwt.InsertSchemaHistoryQuery.Values(entryBytes, newTxnID).ToSql()
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteSchemaHistory, err)
+	}
+
+	if _, err := rwt.tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf(errUnableToWriteSchemaHistory, err)
+	}
+
+	return nil
+}
+
+// WriteRelationships takes a list of existing relationships that must exist, and a list of
+// tuple mutations and applies it to the datastore for the specified namespace.
+func (rwt *sqliteReadWriteTXN) WriteRelationships(ctx context.Context, mutations []tuple.RelationshipUpdate) error {
+	newTxnID, err := rwt.transactionID(ctx)
+	if err != nil {
+		return err
+	}
+
+	clauses := sq.Or{}
+	createAndTouchMutationsByRel := make(map[string]tuple.RelationshipUpdate, len(mutations))
+	createClauses := sq.Or{}
+
+	// Collect all TOUCH and DELETE operations. CREATE is handled below.
+	for _, mut := range mutations {
+		rel := mut.Relationship
+		relString := tuple.StringWithoutCaveatOrExpiration(rel)
+
+		switch mut.Operation {
+		case tuple.UpdateOperationCreate:
+			createAndTouchMutationsByRel[relString] = mut
+			createClauses = append(createClauses, exactRelationshipClause(rel))
+
+		case tuple.UpdateOperationTouch:
+			createAndTouchMutationsByRel[relString] = mut
+			clauses = append(clauses, exactRelationshipClause(rel))
+
+		case tuple.UpdateOperationDelete:
+			clauses = append(clauses, exactRelationshipClause(rel))
+
+		default:
+			return spiceerrors.MustBugf("unknown mutation operation")
+		}
+	}
+
+	// SQLite limits the depth of expression trees, so the lookup is split into
+	// chunks of clauses.
+	for chunk := range slices.Chunk(clauses, maxClausesPerQuery) {
+		query, args, err := rwt.QueryRelsWithIdsQuery.
+			Where(sq.Or(chunk)).
+			Where(sq.GtOrEq{colDeletedTxn: newTxnID}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf(errUnableToWriteRelationships, err)
+		}
+
+		relIdsToDelete, err := rwt.collectRelationshipsToDelete(ctx, query, args, createAndTouchMutationsByRel)
+		if err != nil {
+			return err
+		}
+
+		if len(relIdsToDelete) > 0 {
+			query, args, err := rwt.
+				DeleteRelsQuery.
+				Where(sq.Eq{colID: relIdsToDelete}).
+				Set(colDeletedTxn, newTxnID).
+				ToSql()
+			if err != nil {
+				return fmt.Errorf(errUnableToWriteRelationships, err)
+			}
+			if _, err := rwt.tx.ExecContext(ctx, query, args...); err != nil {
+				return fmt.Errorf(errUnableToWriteRelationships, err)
+			}
+		}
+	}
+
+	toWrite := slices.Collect(maps.Values(createAndTouchMutationsByRel))
+	for chunk := range slices.Chunk(toWrite, bulkInsertRowsLimit) {
+		bulkWrite := rwt.WriteRelsQuery
+		for _, mut := range chunk {
+			rel := mut.Relationship
+
+			var caveatName string
+			var caveatContext structpbWrapper
+			if rel.OptionalCaveat != nil {
+				caveatName = rel.OptionalCaveat.CaveatName
+				caveatContext = rel.OptionalCaveat.Context.AsMap()
+			}
+			bulkWrite = bulkWrite.Values(
+				rel.Resource.ObjectType,
+				rel.Resource.ObjectID,
+				rel.Resource.Relation,
+				rel.Subject.ObjectType,
+				rel.Subject.ObjectID,
+				rel.Subject.Relation,
+				caveatName,
+				&caveatContext,
+				utcExpiration(rel.OptionalExpiration),
+				newTxnID,
+			)
+		}
+
+		query, args, err := bulkWrite.ToSql()
+		if err != nil {
+			return fmt.Errorf(errUnableToWriteRelationships, err)
+		}
+
+		_, err = rwt.tx.ExecContext(ctx, query, args...)
+		if err != nil {
+			if isUniqueConstraintError(err) {
+				return rwt.createRelationshipExistsError(ctx, createClauses)
+			}
+			return fmt.Errorf(errUnableToWriteRelationships, err)
+		}
+	}
+
+	return nil
+}
+
+// collectRelationshipsToDelete returns the IDs of the living relationships found by the query
+// which must be deleted. TOUCHed relationships which are unchanged are removed from
+// createAndTouchMutationsByRel rather than being deleted and rewritten.
+func (rwt *sqliteReadWriteTXN) collectRelationshipsToDelete(
+	ctx context.Context,
+	query string,
+	args []any,
+	createAndTouchMutationsByRel map[string]tuple.RelationshipUpdate,
+) ([]int64, error) {
+	rows, err := rwt.tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf(errUnableToWriteRelationships, err)
+	}
+	defer common.LogOnError(ctx, rows.Close)
+
+	var resourceObjectType string
+	var resourceObjectID string
+	var relation string
+	var subjectObjectType string
+	var subjectObjectID string
+	var subjectRelation string
+	var caveatName sql.NullString
+	var caveatContext structpbWrapper
+	var expiration *time.Time
+
+	var relIdsToDelete []int64
+	for rows.Next() {
+		var relationshipID int64
+		if err := rows.Scan(
+			&relationshipID,
+			&resourceObjectType,
+			&resourceObjectID,
+			&relation,
+			&subjectObjectType,
+			&subjectObjectID,
+			&subjectRelation,
+			&caveatName,
+			&caveatContext,
+			&expiration,
+		); err != nil {
+			return nil, fmt.Errorf(errUnableToWriteRelationships, err)
+		}
+
+		foundRel := tuple.Relationship{
+			RelationshipReference: tuple.RelationshipReference{
+				Resource: tuple.ObjectAndRelation{
+					ObjectType: resourceObjectType,
+					ObjectID:   resourceObjectID,
+					Relation:   relation,
+				},
+				Subject: tuple.ObjectAndRelation{
+					ObjectType: subjectObjectType,
+					ObjectID:   subjectObjectID,
+					Relation:   subjectRelation,
+				},
+			},
+			OptionalExpiration: utcExpiration(expiration),
+		}
+
+		// if the relationship to be deleted is for a TOUCH operation and the caveat
+		// name, context or expiration has not changed, then remove it from delete and create.
+		tplString := tuple.StringWithoutCaveatOrExpiration(foundRel)
+		if mut, ok := createAndTouchMutationsByRel[tplString]; ok {
+			foundRel.OptionalCaveat, err = common.ContextualizedCaveatFrom(caveatName.String, caveatContext)
+			if err != nil {
+				return nil, fmt.Errorf(errUnableToQueryTuples, err)
+			}
+
+			// Ensure the tuples are the same.
+			if tuple.Equal(mut.Relationship, foundRel) {
+				delete(createAndTouchMutationsByRel, tplString)
+				continue
+			}
+		}
+
+		relIdsToDelete = append(relIdsToDelete, relationshipID)
+	}
+
+	if rows.Err() != nil {
+		return nil, fmt.Errorf(errUnableToWriteRelationships, rows.Err())
+	}
+
+	return relIdsToDelete, nil
+}
+
+// createRelationshipExistsError builds the error returned when a CREATE conflicts with a
+// living relationship. SQLite does not report the conflicting values, so the first
+// conflicting relationship is looked up within the transaction.
+func (rwt *sqliteReadWriteTXN) createRelationshipExistsError(ctx context.Context, createClauses sq.Or) error {
+	for chunk := range slices.Chunk(createClauses, maxClausesPerQuery) {
+		query, args, err := rwt.QueryRelsQuery.
+			Where(sq.Or(chunk)).
+			Where(sq.Eq{colDeletedTxn: liveDeletedTxnID}).
+			Limit(1).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf(errUnableToWriteRelationships, err)
+		}
+
+		var found tuple.Relationship
+		var caveatName sql.NullString
+		var caveatContext structpbWrapper
+		var expiration *time.Time
+		err = rwt.tx.QueryRowContext(ctx, query, args...).Scan(
+			&found.Resource.ObjectType,
+			&found.Resource.ObjectID,
+			&found.Resource.Relation,
+			&found.Subject.ObjectType,
+			&found.Subject.ObjectID,
+			&found.Subject.Relation,
+			&caveatName,
+			&caveatContext,
+			&expiration,
+		)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf(errUnableToWriteRelationships, err)
+		}
+
+		return common.NewCreateRelationshipExistsError(&found)
+	}
+
+	return common.NewCreateRelationshipExistsError(nil)
+}
+
+func (rwt *sqliteReadWriteTXN) DeleteRelationships(ctx context.Context, filter *v1.RelationshipFilter, opts ...options.DeleteOptionsOption) (uint64, bool, error) {
+	newTxnID, err := rwt.transactionID(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+
+	// Add clauses for the ResourceFilter
+	clauses := sq.And{}
+	if filter.ResourceType != "" {
+		clauses = append(clauses, sq.Eq{colNamespace: filter.ResourceType})
+	}
+	if filter.OptionalResourceId != "" {
+		clauses = append(clauses, sq.Eq{colObjectID: filter.OptionalResourceId})
+	}
+	if filter.OptionalRelation != "" {
+		clauses = append(clauses, sq.Eq{colRelation: filter.OptionalRelation})
+	}
+	if filter.OptionalResourceIdPrefix != "" {
+		likeClause, err := common.BuildEscapedLikePrefixClause(colObjectID, filter.OptionalResourceIdPrefix)
+		if err != nil {
+			return 0, false, fmt.Errorf(errUnableToDeleteRelationships, err)
+		}
+		clauses = append(clauses, likeClause)
+	}
+
+	// Add clauses for the SubjectFilter
+	if subjectFilter := filter.OptionalSubjectFilter; subjectFilter != nil {
+		clauses = append(clauses, sq.Eq{colUsersetNamespace: subjectFilter.SubjectType})
+		if subjectFilter.OptionalSubjectId != "" {
+			clauses = append(clauses, sq.Eq{colUsersetObjectID: subjectFilter.OptionalSubjectId})
+		}
+		if relationFilter := subjectFilter.OptionalRelation; relationFilter != nil {
+			clauses = append(clauses, sq.Eq{colUsersetRelation: cmp.Or(relationFilter.Relation, datastore.Ellipsis)})
+		}
+	}
+
+	// Add the limit, if any.
+	delOpts := options.NewDeleteOptionsWithOptionsAndDefaults(opts...)
+	var delLimit uint64
+	if delOpts.DeleteLimit != nil && *delOpts.DeleteLimit > 0 {
+		delLimit = *delOpts.DeleteLimit
+	}
+
+	query := rwt.DeleteRelsQuery.Set(colDeletedTxn, newTxnID)
+	if delLimit > 0 {
+		// UPDATE ... LIMIT is an optional SQLite compile time feature, so the limit is
+		// applied to a subquery selecting the IDs of the relationships to delete instead.
+		limitedSQL, limitedArgs, err := rwt.QueryRelationshipExistsQuery.
+			Where(sq.Eq{colDeletedTxn: liveDeletedTxnID}).
+			Where(clauses).
+			Limit(delLimit).
+			ToSql()
+		if err != nil {
+			return 0, false, fmt.Errorf(errUnableToDeleteRelationships, err)
+		}
+		query = query.Where(colID+" IN ("+limitedSQL+")", limitedArgs...)
+	} else {
+		query = query.Where(clauses)
+	}
+
+	querySQL, args, err := query.ToSql()
+	if err != nil {
+		return 0, false, fmt.Errorf(errUnableToDeleteRelationships, err)
+	}
+
+	modified, err := rwt.tx.ExecContext(ctx, querySQL, args...)
+	if err != nil {
+		return 0, false, fmt.Errorf(errUnableToDeleteRelationships, err)
+	}
+
+	rowsAffected, err := modified.RowsAffected()
+	if err != nil {
+		return 0, false, fmt.Errorf(errUnableToDeleteRelationships, err)
+	}
+
+	uintRowsAffected, err := safecast.Convert[uint64](rowsAffected)
+	if err != nil {
+		return 0, false, spiceerrors.MustBugf("rowsAffected was negative: %v", err)
+	}
+
+	if delLimit > 0 && uintRowsAffected == delLimit {
+		return uintRowsAffected, true, nil
+	}
+
+	return uintRowsAffected, false, nil
+}
+
+func (rwt *sqliteReadWriteTXN) WriteNamespaces(ctx context.Context, newNamespaces ...*core.NamespaceDefinition) error {
+	newTxnID, err := rwt.transactionID(ctx)
+	if err != nil {
+		return err
+	}
+
+	deletedNamespaceClause := sq.Or{}
+	writeQuery := rwt.WriteNamespaceQuery
+
+	for _, newNamespace := range newNamespaces {
+		serialized, err := newNamespace.MarshalVT()
+		if err != nil {
+			return fmt.Errorf(errUnableToWriteConfig, err)
+		}
+
+		deletedNamespaceClause = append(deletedNamespaceClause, sq.Eq{colNamespace: newNamespace.Name})
+		writeQuery = writeQuery.Values(newNamespace.Name, serialized, newTxnID)
+	}
+
+	delSQL, delArgs, err := rwt.DeleteNamespaceQuery.
+		Set(colDeletedTxn, newTxnID).
+		Where(sq.And{sq.Eq{colDeletedTxn: liveDeletedTxnID}, deletedNamespaceClause}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteConfig, err)
+	}
+
+	_, err = rwt.tx.ExecContext(ctx, delSQL, delArgs...)
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteConfig, err)
+	}
+
+	query, args, err := writeQuery.ToSql()
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteConfig, err)
+	}
+
+	_, err = rwt.tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf(errUnableToWriteConfig, err)
+	}
+
+	return nil
+}
+
+func (rwt *sqliteReadWriteTXN) DeleteNamespaces(ctx context.Context, nsNames []string, delOption datastore.DeleteNamespacesRelationshipsOption) error {
+	newTxnID, err := rwt.transactionID(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(nsNames) == 0 {
+		return nil
+	}
+
+	// For each namespace, check they exist and collect predicates for the
+	// "WHERE" clause to delete the namespaces and (if requested) associated tuples.
+	nsClauses := make([]sq.Sqlizer, 0, len(nsNames))
+	tplClauses := make([]sq.Sqlizer, 0, len(nsNames))
+	for _, nsName := range nsNames {
+		baseQuery := rwt.ReadNamespaceQuery.Where(sq.Eq{colDeletedTxn: liveDeletedTxnID})
+		_, createdAt, err := loadNamespace(ctx, nsName, rwt.tx, baseQuery)
+		switch {
+		case errors.As(err, &datastore.NamespaceNotFoundError{}):
+			return err
+		case err == nil:
+			createdTxn := createdAt.(revisions.TransactionIDRevision).TransactionID()
+			nsClauses = append(nsClauses, sq.Eq{colNamespace: nsName, colCreatedTxn: createdTxn})
+			tplClauses = append(tplClauses, sq.Eq{colNamespace: nsName})
+		default:
+			return fmt.Errorf(errUnableToDeleteConfig, err)
+		}
+	}
+
+	delSQL, delArgs, err := rwt.DeleteNamespaceQuery.
+		Set(colDeletedTxn, newTxnID).
+		Where(sq.Or(nsClauses)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf(errUnableToDeleteConfig, err)
+	}
+
+	_, err = rwt.tx.ExecContext(ctx, delSQL, delArgs...)
+	if err != nil {
+		return fmt.Errorf(errUnableToDeleteConfig, err)
+	}
+
+	if delOption == datastore.DeleteNamespacesAndRelationships {
+		deleteTupleSQL, deleteTupleArgs, err := rwt.DeleteNamespaceRelationshipsQuery.
+			Set(colDeletedTxn, newTxnID).
+			Where(sq.Or(tplClauses)).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf(errUnableToDeleteConfig, err)
+		}
+
+		_, err = rwt.tx.ExecContext(ctx, deleteTupleSQL, deleteTupleArgs...)
+		if err != nil {
+			return fmt.Errorf(errUnableToDeleteConfig, err)
+		}
+	}
+
+	return nil
+}
+
+func (rwt *sqliteReadWriteTXN) BulkLoad(ctx context.Context, iter datastore.BulkWriteRelationshipSource) (uint64, error) {
+	newTxnID, err := rwt.transactionID(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var sqlStmt bytes.Buffer
+
+	sql, _, err := rwt.WriteRelsQuery.Values(1, 2, 3, 4, 5, 6, 7, 8, 9, 10).ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var numWritten uint64
+	var rel *tuple.Relationship
+
+	// Bootstrap the loop
+	rel, err = iter.Next(ctx)
+
+	for rel != nil && err == nil {
+		sqlStmt.Reset()
+		sqlStmt.WriteString(sql)
+		var args []any
+		var batchLen uint64
+
+		for ; rel != nil && err == nil && batchLen < bulkInsertRowsLimit; rel, err = iter.Next(ctx) {
+			if batchLen != 0 {
+				sqlStmt.WriteString(",(?,?,?,?,?,?,?,?,?,?)")
+			}
+
+			var caveatName string
+			var caveatContext structpbWrapper
+			if rel.OptionalCaveat != nil {
+				caveatName = rel.OptionalCaveat.CaveatName
+				caveatContext = rel.OptionalCaveat.Context.AsMap()
+			}
+			args = append(args,
+				rel.Resource.ObjectType,
+				rel.Resource.ObjectID,
+				rel.Resource.Relation,
+				rel.Subject.ObjectType,
+				rel.Subject.ObjectID,
+				rel.Subject.Relation,
+				caveatName,
+				&caveatContext,
+				utcExpiration(rel.OptionalExpiration),
+				newTxnID,
+			)
+			batchLen++
+		}
+		if err != nil {
+			return 0, fmt.Errorf(errUnableToBulkWriteRelationships, err)
+		}
+
+		if batchLen > 0 {
+			if _, err := rwt.tx.ExecContext(ctx, sqlStmt.String(), args...); err != nil {
+				if isUniqueConstraintError(err) {
+					return 0, common.NewCreateRelationshipExistsError(nil)
+				}
+				return 0, fmt.Errorf(errUnableToBulkWriteRelationships, fmt.Errorf("error writing batch: %w", err))
+			}
+		}
+
+		numWritten += batchLen
+	}
+	if err != nil {
+		return 0, fmt.Errorf(errUnableToBulkWriteRelationships, err)
+	}
+
+	return numWritten, nil
+}
+
+func exactRelationshipClause(r tuple.Relationship) sq.Eq {
+	return sq.Eq{
+		colNamespace:        r.Resource.ObjectType,
+		colObjectID:         r.Resource.ObjectID,
+		colRelation:         r.Resource.Relation,
+		colUsersetNamespace: r.Subject.ObjectType,
+		colUsersetObjectID:  r.Subject.ObjectID,
+		colUsersetRelation:  r.Subject.Relation,
+	}
+}
+
+var _ datastore.ReadWriteTransaction = &sqliteReadWriteTXN{}
@@ -0,0 +1,178 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ccoveille/go-safecast/v2"
+
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/spiceerrors"
+)
+
+var ParseRevisionString = revisions.RevisionParser(revisions.TransactionID)
+
+const (
+	errRevision      = "unable to find revision: %w"
+	errCheckRevision = "unable to check revision: %w"
+)
+
+func (sds *Datastore) optimizedRevisionFunc(ctx context.Context) (datastore.Revision, time.Duration, error) {
+	// SQLite has no sub-second clock functions, so the quantization window is
+	// computed here and the first transaction after it is selected. If there are
+	// no transactions newer than the quantization window, the latest transaction
+	// is picked instead.
+	quantization := max(sds.revisionQuantization.Nanoseconds(), 1)
+	now := time.Now().UnixNano()
+	quantizedNow := now - now%quantization
+	validForNanos := time.Duration(quantization - now%quantization)
+
+	query := fmt.Sprintf(
+		`SELECT COALESCE((SELECT MIN(%[1]s) FROM %[2]s WHERE %[3]s >= ?), (SELECT MAX(%[1]s) FROM %[2]s))`,
+		colID,
+		sds.driver.RelationTupleTransaction(),
+		colTimestamp,
+	)
+
+	var rev uint64
+	if err := sds.db.QueryRowContext(ctx, query, quantizedNow).Scan(&rev); err != nil {
+		return datastore.NoRevision, 0, fmt.Errorf(errRevision, err)
+	}
+	return revisions.NewForTransactionID(rev), validForNanos, nil
+}
+
+func (sds *Datastore) HeadRevision(ctx context.Context) (datastore.Revision, error) {
+	revision, err := sds.loadRevision(ctx)
+	if err != nil {
+		return datastore.NoRevision, err
+	}
+	if revision == 0 {
+		return datastore.NoRevision, nil
+	}
+
+	return revisions.NewForTransactionID(revision), nil
+}
+
+func (sds *Datastore) CheckRevision(ctx context.Context, revision datastore.Revision) error {
+	if revision == datastore.NoRevision {
+		return datastore.NewInvalidRevisionErr(revision, datastore.CouldNotDetermineRevision)
+	}
+
+	rev, ok := revision.(revisions.TransactionIDRevision)
+	if !ok {
+		return fmt.Errorf("expected transaction revision, got %T", revision)
+	}
+
+	revisionTx := rev.TransactionID()
+	freshEnough, unknown, err := sds.checkValidTransaction(ctx, revisionTx)
+	if err != nil {
+		return fmt.Errorf(errCheckRevision, err)
+	}
+
+	if !freshEnough {
+		return datastore.NewInvalidRevisionErr(revision, datastore.RevisionStale)
+	}
+	if unknown {
+		return datastore.NewInvalidRevisionErr(revision, datastore.CouldNotDetermineRevision)
+	}
+
+	return nil
+}
+
+func (sds *Datastore) loadRevision(ctx context.Context) (uint64, error) {
+	ctx, span := tracer.Start(ctx, "loadRevision")
+	defer span.End()
+
+	query, args, err := sds.GetLastRevision.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf(errRevision, err)
+	}
+
+	var revision *uint64
+	err = sds.db.QueryRowContext(ctx, query, args...).Scan(&revision)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf(errRevision, err)
+	}
+
+	if revision == nil {
+		return 0, nil
+	}
+
+	return *revision, nil
+}
+
+// checkValidTransaction returns whether the specified transaction ID is newer than the
+// garbage collection window, and whether it represents a transaction that will occur in
+// the future. The current head transaction is always treated as valid even if it falls
+// outside the GC window.
+func (sds *Datastore) checkValidTransaction(ctx context.Context, revisionTx uint64) (bool, bool, error) {
+	ctx, span := tracer.Start(ctx, "checkValidTransaction")
+	defer span.End()
+
+	query := fmt.Sprintf(`
+		SELECT ? >= COALESCE((
+			SELECT MIN(%[1]s)
+			FROM   %[2]s
+			WHERE  %[3]s >= ?
+		), (
+			SELECT MAX(%[1]s)
+			FROM   %[2]s
+		)) AS fresh, ? > (
+			SELECT MAX(%[1]s)
+			FROM   %[2]s
+		) AS unknown;`,
+		colID,
+		sds.driver.RelationTupleTransaction(),
+		colTimestamp,
+	)
+
+	gcWindowStart := time.Now().Add(-sds.gcWindow).UnixNano()
+
+	var freshEnough, unknown sql.NullBool
+	err := sds.db.QueryRowContext(ctx, query, revisionTx, gcWindowStart, revisionTx).
+		Scan(&freshEnough, &unknown)
+	if err != nil {
+		return false, false, fmt.Errorf(errCheckRevision, err)
+	}
+
+	return freshEnough.Bool, unknown.Bool, nil
+}
+
+func (sds *Datastore) createNewTransaction(ctx context.Context, tx *sql.Tx, metadata map[string]any) (newTxnID uint64, err error) {
+	ctx, span := tracer.Start(ctx, "createNewTransaction")
+	defer span.End()
+
+	var wrappedMetadata structpbWrapper
+	if len(metadata) > 0 {
+		wrappedMetadata = metadata
+	}
+
+	sql, args, err := sds.createTxn.Values(time.Now().UnixNano(), &wrappedMetadata).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("createNewTransaction: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("createNewTransaction: %w", err)
+	}
+
+	lastInsertID, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("createNewTransaction: failed to get last inserted id: %w", err)
+	}
+
+	uintLastInsertID, err := safecast.Convert[uint64](lastInsertID)
+	if err != nil {
+		return 0, spiceerrors.MustBugf("lastInsertID was negative: %v", err)
+	}
+
+	return uintLastInsertID, nil
+}
@@ -0,0 +1,101 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/ccoveille/go-safecast/v2"
+
+	"github.com/authzed/spicedb/internal/datastore/common"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/spiceerrors"
+)
+
+const (
+	metadataIDColumn       = "id"
+	metadataUniqueIDColumn = "unique_id"
+)
+
+func (sds *Datastore) Statistics(ctx context.Context) (datastore.Stats, error) {
+	uniqueID, err := sds.UniqueID(ctx)
+	if err != nil {
+		return datastore.Stats{}, err
+	}
+
+	// SQLite does not maintain row count estimates, so the living relationships
+	// are counted directly, grouped by their resource type.
+	query, args, err := sb.Select(colNamespace, "count(*)").
+		From(sds.driver.RelationTuple()).
+		Where(squirrel.Eq{colDeletedTxn: liveDeletedTxnID}).
+		GroupBy(colNamespace).
+		ToSql()
+	if err != nil {
+		return datastore.Stats{}, err
+	}
+
+	rows, err := sds.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return datastore.Stats{}, err
+	}
+	defer common.LogOnError(ctx, rows.Close)
+
+	var count uint64
+	countsByType := make(map[string]uint64)
+	for rows.Next() {
+		var objectType string
+		var typeCount int64
+		if err := rows.Scan(&objectType, &typeCount); err != nil {
+			return datastore.Stats{}, err
+		}
+
+		uintCount, err := safecast.Convert[uint64](typeCount)
+		if err != nil {
+			return datastore.Stats{}, spiceerrors.MustBugf("could not cast count to uint64: %v", err)
+		}
+
+		countsByType[objectType] = uintCount
+		count += uintCount
+	}
+	if err := rows.Err(); err != nil {
+		return datastore.Stats{}, err
+	}
+
+	nsQuery := sds.ReadNamespaceQuery.Where(squirrel.Eq{colDeletedTxn: liveDeletedTxnID})
+
+	tx, err := sds.db.BeginTx(ctx, sds.readTxOptions)
+	if err != nil {
+		return datastore.Stats{}, err
+	}
+	defer common.LogOnError(ctx, tx.Rollback)
+
+	nsDefs, err := loadAllNamespaces(ctx, tx, nsQuery)
+	if err != nil {
+		return datastore.Stats{}, fmt.Errorf("unable to load namespaces: %w", err)
+	}
+
+	return datastore.Stats{
+		UniqueID:                   uniqueID,
+		ObjectTypeStatistics:       datastore.ComputeObjectTypeStats(nsDefs),
+		EstimatedRelationshipCount: count,
+		RelationshipCountsByType:   countsByType,
+	}, nil
+}
+
+func (sds *Datastore) UniqueID(ctx context.Context) (string, error) {
+	if sds.uniqueID.Load() == nil {
+		sql, args, err := sb.Select(metadataUniqueIDColumn).From(sds.driver.Metadata()).ToSql()
+		if err != nil {
+			return "", fmt.Errorf("unable to generate query sql: %w", err)
+		}
+
+		var uniqueID string
+		if err := sds.db.QueryRowContext(ctx, sql, args...).Scan(&uniqueID); err != nil {
+			return "", fmt.Errorf("unable to query unique ID: %w", err)
+		}
+		sds.uniqueID.Store(&uniqueID)
+		return uniqueID, nil
+	}
+
+	return *sds.uniqueID.Load(), nil
+}
@@ -16,10 +16,14 @@ import (
 
 	"github.com/authzed/spicedb/internal/dispatch"
 	"github.com/authzed/spicedb/internal/dispatch/keys"
+	log "github.com/authzed/spicedb/internal/logging"
+	datastoremw "github.com/authzed/spicedb/internal/middleware/datastore"
 	"github.com/authzed/spicedb/internal/telemetry/otelconv"
 	"github.com/authzed/spicedb/pkg/cache"
+	"github.com/authzed/spicedb/pkg/datastore"
 	"github.com/authzed/spicedb/pkg/middleware/nodeid"
 	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/schema"
 )
 
 const (
@@ -34,8 +38,23 @@ type Dispatcher struct {
 	c          cache.Cache[keys.DispatchCacheKey, any]
 	keyHandler keys.Handler
 
+	// restorable is whether results are cached along with their requests, so that they can be
+	// restored from a persisted cache.
+	restorable bool
+
+	// invalidationTracker, if set, enables carrying check results forward to other revisions.
+	invalidationTracker *InvalidationTracker
+
+	// peerCache, if set, is consulted for results not found in the local cache.
+	peerCache PeerCache
+
+	// resumableLookups is whether lookups are cached such that they can be resumed.
+	resumableLookups bool
+
 	checkTotalCounter               prometheus.Counter
 	checkFromCacheCounter           prometheus.Counter
+	checkCarriedForwardCounter      prometheus.Counter
+	fromPeerCacheCounter            prometheus.Counter
 	lookupResourcesTotalCounter     prometheus.Counter
 	lookupResourcesFromCacheCounter prometheus.Counter
 	lookupSubjectsTotalCounter      prometheus.Counter
@@ -69,6 +88,18 @@ func NewCachingDispatcher(cacheInst cache.Cache[keys.DispatchCacheKey, any], met
 		Name:      "check_from_cache_total",
 	})
 
+	checkCarriedForwardCounter := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: prometheusNamespace,
+		Subsystem: prometheusSubsystem,
+		Name:      "check_carried_forward_total",
+	})
+
+	fromPeerCacheCounter := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: prometheusNamespace,
+		Subsystem: prometheusSubsystem,
+		Name:      "from_peer_cache_total",
+	})
+
 	lookupResourcesTotalCounter := prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: prometheusNamespace,
 		Subsystem: prometheusSubsystem,
@@ -100,6 +131,14 @@ func NewCachingDispatcher(cacheInst cache.Cache[keys.DispatchCacheKey, any], met
 		if err != nil {
 			return nil, fmt.Errorf(errCachingInitialization, err)
 		}
+		err = prometheus.Register(checkCarriedForwardCounter)
+		if err != nil {
+			return nil, fmt.Errorf(errCachingInitialization, err)
+		}
+		err = prometheus.Register(fromPeerCacheCounter)
+		if err != nil {
+			return nil, fmt.Errorf(errCachingInitialization, err)
+		}
 		err = prometheus.Register(lookupResourcesTotalCounter)
 		if err != nil {
 			return nil, fmt.Errorf(errCachingInitialization, err)
@@ -126,8 +165,11 @@ func NewCachingDispatcher(cacheInst cache.Cache[keys.DispatchCacheKey, any], met
 		d:                               fakeDelegate{},
 		c:                               cacheInst,
 		keyHandler:                      keyHandler,
+		restorable:                      cache.IsPersistent(cacheInst),
 		checkTotalCounter:               checkTotalCounter,
 		checkFromCacheCounter:           checkFromCacheCounter,
+		checkCarriedForwardCounter:      checkCarriedForwardCounter,
+		fromPeerCacheCounter:            fromPeerCacheCounter,
 		lookupResourcesTotalCounter:     lookupResourcesTotalCounter,
 		lookupResourcesFromCacheCounter: lookupResourcesFromCacheCounter,
 		lookupSubjectsTotalCounter:      lookupSubjectsTotalCounter,
@@ -140,6 +182,13 @@ func (cd *Dispatcher) SetDelegate(delegate dispatch.Dispatcher) {
 	cd.d = delegate
 }
 
+// SetInvalidationTracker enables carrying check results forward to other revisions, for as
+// long as the tracker reports that nothing they depend upon has changed between the revisions.
+// The tracker must be run for any result to be carried forward.
+func (cd *Dispatcher) SetInvalidationTracker(tracker *InvalidationTracker) {
+	cd.invalidationTracker = tracker
+}
+
 // DispatchCheck implements dispatch.Check interface
 func (cd *Dispatcher) DispatchCheck(ctx context.Context, req *v1.DispatchCheckRequest) (*v1.DispatchCheckResponse, error) {
 	cd.checkTotalCounter.Inc()
@@ -151,7 +200,7 @@ func (cd *Dispatcher) DispatchCheck(ctx context.Context, req *v1.DispatchCheckRe
 
 	// Disable caching when debugging is enabled.
 	span := trace.SpanFromContext(ctx)
-	if cachedResultRaw, found := cd.c.Get(requestKey); found {
+	if cachedResultRaw, found := cd.get(requestKey); found {
 		var response v1.DispatchCheckResponse
 		if err := response.UnmarshalVT(cachedResultRaw.([]byte)); err != nil {
 			return &v1.DispatchCheckResponse{Metadata: &v1.ResponseMeta{}}, err
@@ -159,23 +208,34 @@ func (cd *Dispatcher) DispatchCheck(ctx context.Context, req *v1.DispatchCheckRe
 
 		if req.Metadata.DepthRemaining >= response.Metadata.DepthRequired {
 			cd.checkFromCacheCounter.Inc()
-			// If debugging is requested, add the req and the response to the trace.
-			if req.Debug == v1.DispatchCheckRequest_ENABLE_BASIC_DEBUGGING {
-				nodeID := nodeid.Get()
-				response.Metadata.DebugInfo = &v1.DebugInformation{
-					Check: &v1.CheckDebugTrace{
-						Request:        req,
-						Results:        maps.Clone(response.ResultsByResourceId),
-						IsCachedResult: true,
-						SourceId:       nodeID,
-					},
-				}
-			}
+			span.SetAttributes(attribute.Bool(otelconv.AttrDispatchCached, true))
+			return cachedCheckResponse(req, &response), nil
+		}
+	}
+
+	if cd.invalidationTracker != nil {
+		response, found, err := cd.carriedCheckResponse(ctx, req, requestKey)
+		if err != nil {
+			return &v1.DispatchCheckResponse{Metadata: &v1.ResponseMeta{}}, err
+		}
 
+		if found && req.Metadata.DepthRemaining >= response.Metadata.DepthRequired {
+			cd.checkFromCacheCounter.Inc()
+			cd.checkCarriedForwardCounter.Inc()
 			span.SetAttributes(attribute.Bool(otelconv.AttrDispatchCached, true))
-			return &response, nil
+			return cachedCheckResponse(req, response), nil
 		}
 	}
+
+	if cd.peerCache != nil {
+		response, found := cd.peerCheckResponse(ctx, req, requestKey)
+		if found && req.Metadata.DepthRemaining >= response.Metadata.DepthRequired {
+			cd.checkFromCacheCounter.Inc()
+			span.SetAttributes(attribute.Bool(otelconv.AttrDispatchCached, true))
+			return cachedCheckResponse(req, response), nil
+		}
+	}
+
 	span.SetAttributes(attribute.Bool(otelconv.AttrDispatchCached, false))
 	computed, err := cd.d.DispatchCheck(ctx, req)
 
@@ -191,7 +251,14 @@ func (cd *Dispatcher) DispatchCheck(ctx context.Context, req *v1.DispatchCheckRe
 			return &v1.DispatchCheckResponse{Metadata: &v1.ResponseMeta{}}, err
 		}
 
-		cd.c.Set(requestKey, adjustedBytes, sliceSize(adjustedBytes))
+		cd.set(requestKey, adjustedBytes, sliceSize(adjustedBytes), checkLookupRequest(req))
+
+		if cd.invalidationTracker != nil {
+			// Failing to cache the result for carrying forward does not fail the check.
+			if err := cd.setCarriedCheckResult(ctx, req, adjustedBytes); err != nil {
+				log.Ctx(ctx).Debug().Err(err).Msg("unable to cache check result for carrying forward")
+			}
+		}
 	}
 
 	// Return both the computed and err in ALL cases: computed contains resolved
@@ -199,12 +266,113 @@ func (cd *Dispatcher) DispatchCheck(ctx context.Context, req *v1.DispatchCheckRe
 	return computed.CloneVT(), err
 }
 
+// cachedCheckResponse returns the response found in the cache for the request, adding the
+// request and response to the trace if debugging is requested.
+func cachedCheckResponse(req *v1.DispatchCheckRequest, response *v1.DispatchCheckResponse) *v1.DispatchCheckResponse {
+	if req.Debug == v1.DispatchCheckRequest_ENABLE_BASIC_DEBUGGING {
+		nodeID := nodeid.Get()
+		response.Metadata.DebugInfo = &v1.DebugInformation{
+			Check: &v1.CheckDebugTrace{
+				Request:        req,
+				Results:        maps.Clone(response.ResultsByResourceId),
+				IsCachedResult: true,
+				SourceId:       nodeID,
+			},
+		}
+	}
+	return response
+}
+
+// carriedCheckResult is the result of a check cached for carrying forward to other revisions.
+type carriedCheckResult struct {
+	// computedAt is the revision at which the result was computed.
+	computedAt datastore.Revision
+
+	// dependencies are the dependencies of the result, as returned by checkDependencies.
+	dependencies []string
+
+	// response is the marshaled response of the check.
+	response []byte
+}
+
+// carriedCheckResponse returns the result of the check computed at another revision, if one is
+// cached and none of its dependencies are known to have changed between the revisions. The
+// result is then also cached under the request key.
+func (cd *Dispatcher) carriedCheckResponse(ctx context.Context, req *v1.DispatchCheckRequest, requestKey keys.DispatchCacheKey) (*v1.DispatchCheckResponse, bool, error) {
+	carriedKey, err := cd.keyHandler.CheckCarriedCacheKey(ctx, req)
+	if err != nil {
+		return nil, false, err
+	}
+
+	carriedRaw, found := cd.get(carriedKey)
+	if !found {
+		return nil, false, nil
+	}
+	carried := carriedRaw.(*carriedCheckResult)
+
+	requestedAt, err := datastoremw.MustFromContext(ctx).RevisionFromString(req.Metadata.AtRevision)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !cd.invalidationTracker.CanCarryForward(carried.computedAt, requestedAt, carried.dependencies) {
+		return nil, false, nil
+	}
+
+	var response v1.DispatchCheckResponse
+	if err := response.UnmarshalVT(carried.response); err != nil {
+		return nil, false, err
+	}
+
+	cd.set(requestKey, carried.response, sliceSize(carried.response), checkLookupRequest(req))
+	return &response, true, nil
+}
+
+// setCarriedCheckResult caches the marshaled response of the check for carrying forward to
+// other revisions, along with its dependencies. Results which cannot be carried forward, such
+// as those depending on expiring relationships, are not cached.
+func (cd *Dispatcher) setCarriedCheckResult(ctx context.Context, req *v1.DispatchCheckRequest, response []byte) error {
+	ds := datastoremw.MustFromContext(ctx)
+	computedAt, err := ds.RevisionFromString(req.Metadata.AtRevision)
+	if err != nil {
+		return err
+	}
+
+	ts := schema.NewTypeSystem(schema.ResolverForDatastoreReader(ds.SnapshotReader(computedAt)))
+	dependencies, ok, err := checkDependencies(ctx, ts, req.ResourceRelation)
+	if err != nil || !ok {
+		return err
+	}
+
+	carriedKey, err := cd.keyHandler.CheckCarriedCacheKey(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	size := sliceSize(response) + int64(unsafe.Sizeof(carriedCheckResult{}))
+	for _, dependency := range dependencies {
+		size += int64(unsafe.Sizeof(dependency)) + int64(len(dependency))
+	}
+
+	cd.c.Set(carriedKey, &carriedCheckResult{
+		computedAt:   computedAt,
+		dependencies: dependencies,
+		response:     response,
+	}, size)
+	return nil
+}
+
 // DispatchExpand implements dispatch.Expand interface and does not do any caching yet.
 func (cd *Dispatcher) DispatchExpand(ctx context.Context, req *v1.DispatchExpandRequest) (*v1.DispatchExpandResponse, error) {
 	resp, err := cd.d.DispatchExpand(ctx, req)
 	return resp, err
 }
 
+func (cd *Dispatcher) DispatchQueryPlan(ctx context.Context, req *v1.DispatchQueryPlanRequest) (*v1.DispatchQueryPlanResponse, error) {
+	resp, err := cd.d.DispatchQueryPlan(ctx, req)
+	return resp, err
+}
+
 func sliceSize(xs []byte) int64 {
 	// Slice Header + Slice Contents
 	return int64(int(unsafe.Sizeof(xs)) + len(xs))
@@ -218,9 +386,12 @@ func (cd *Dispatcher) DispatchLookupResources2(req *v1.DispatchLookupResources2R
 		return err
 	}
 
-	if cachedResultRaw, found := cd.c.Get(requestKey); found {
+	peerRequest := &v1.DispatchPeerCacheLookupRequest{
+		Request: &v1.DispatchPeerCacheLookupRequest_LookupResources2{LookupResources2: req},
+	}
+	if cachedResults, found := cd.cachedStreamResults(stream.Context(), requestKey, peerRequest); found {
 		cd.lookupResourcesFromCacheCounter.Inc()
-		for _, slice := range cachedResultRaw.([][]byte) {
+		for _, slice := range cachedResults {
 			var response v1.DispatchLookupResources2Response
 			if err := response.UnmarshalVT(slice); err != nil {
 				return err
@@ -270,21 +441,28 @@ func (cd *Dispatcher) DispatchLookupResources2(req *v1.DispatchLookupResources2R
 		size += sliceSize(slice)
 	}
 
-	cd.c.Set(requestKey, toCacheResults, size)
+	cd.set(requestKey, toCacheResults, size, peerRequest)
 	return nil
 }
 
 func (cd *Dispatcher) DispatchLookupResources3(req *v1.DispatchLookupResources3Request, stream dispatch.LookupResources3Stream) error {
 	cd.lookupResourcesTotalCounter.Inc()
 
+	if cd.resumableLookups && req.OptionalLimit > 0 {
+		return cd.resumableLookupResources3(req, stream)
+	}
+
 	requestKey, err := cd.keyHandler.LookupResources3CacheKey(stream.Context(), req)
 	if err != nil {
 		return err
 	}
 
-	if cachedResultRaw, found := cd.c.Get(requestKey); found {
+	peerRequest := &v1.DispatchPeerCacheLookupRequest{
+		Request: &v1.DispatchPeerCacheLookupRequest_LookupResources3{LookupResources3: req},
+	}
+	if cachedResults, found := cd.cachedStreamResults(stream.Context(), requestKey, peerRequest); found {
 		cd.lookupResourcesFromCacheCounter.Inc()
-		for _, slice := range cachedResultRaw.([][]byte) {
+		for _, slice := range cachedResults {
 			var response v1.DispatchLookupResources3Response
 			if err := response.UnmarshalVT(slice); err != nil {
 				return err
@@ -329,7 +507,7 @@ func (cd *Dispatcher) DispatchLookupResources3(req *v1.DispatchLookupResources3R
 		size += sliceSize(slice)
 	}
 
-	cd.c.Set(requestKey, toCacheResults, size)
+	cd.set(requestKey, toCacheResults, size, peerRequest)
 	return nil
 }
 
@@ -342,9 +520,12 @@ func (cd *Dispatcher) DispatchLookupSubjects(req *v1.DispatchLookupSubjectsReque
 		return err
 	}
 
-	if cachedResultRaw, found := cd.c.Get(requestKey); found {
+	peerRequest := &v1.DispatchPeerCacheLookupRequest{
+		Request: &v1.DispatchPeerCacheLookupRequest_LookupSubjects{LookupSubjects: req},
+	}
+	if cachedResults, found := cd.cachedStreamResults(stream.Context(), requestKey, peerRequest); found {
 		cd.lookupSubjectsFromCacheCounter.Inc()
-		for _, slice := range cachedResultRaw.([][]byte) {
+		for _, slice := range cachedResults {
 			var response v1.DispatchLookupSubjectsResponse
 			if err := response.UnmarshalVT(slice); err != nil {
 				return err
@@ -359,6 +540,12 @@ func (cd *Dispatcher) DispatchLookupSubjects(req *v1.DispatchLookupSubjectsReque
 		return nil
 	}
 
+	// The lookup cannot be resumed should its stream be interrupted, as LookupSubjects does not
+	// support cursors, so the results of each of its resources are instead cached separately.
+	if cd.resumableLookups && len(req.ResourceIds) > 1 {
+		return cd.perResourceLookupSubjects(req, requestKey, stream)
+	}
+
 	var (
 		mu             sync.Mutex
 		toCacheResults [][]byte
@@ -394,13 +581,15 @@ func (cd *Dispatcher) DispatchLookupSubjects(req *v1.DispatchLookupSubjectsReque
 		size += sliceSize(slice)
 	}
 
-	cd.c.Set(requestKey, toCacheResults, size)
+	cd.set(requestKey, toCacheResults, size, peerRequest)
 	return nil
 }
 
 func (cd *Dispatcher) Close() error {
 	prometheus.Unregister(cd.checkTotalCounter)
 	prometheus.Unregister(cd.checkFromCacheCounter)
+	prometheus.Unregister(cd.checkCarriedForwardCounter)
+	prometheus.Unregister(cd.fromPeerCacheCounter)
 	prometheus.Unregister(cd.lookupResourcesTotalCounter)
 	prometheus.Unregister(cd.lookupResourcesFromCacheCounter)
 	prometheus.Unregister(cd.lookupSubjectsFromCacheCounter)
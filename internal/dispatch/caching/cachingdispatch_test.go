@@ -246,6 +246,10 @@ func (ddm delegateDispatchMock) DispatchLookupSubjects(_ *v1.DispatchLookupSubje
 	return nil
 }
 
+func (ddm delegateDispatchMock) DispatchQueryPlan(_ context.Context, _ *v1.DispatchQueryPlanRequest) (*v1.DispatchQueryPlanResponse, error) {
+	return &v1.DispatchQueryPlanResponse{}, nil
+}
+
 func (ddm delegateDispatchMock) Close() error {
 	return nil
 }
@@ -40,4 +40,8 @@ func (fd fakeDelegate) DispatchLookupSubjects(_ *v1.DispatchLookupSubjectsReques
 	return spiceerrors.MustBugf(errMessage)
 }
 
+func (fd fakeDelegate) DispatchQueryPlan(_ context.Context, _ *v1.DispatchQueryPlanRequest) (*v1.DispatchQueryPlanResponse, error) {
+	return &v1.DispatchQueryPlanResponse{}, spiceerrors.MustBugf(errMessage)
+}
+
 var _ dispatch.Dispatcher = fakeDelegate{}
@@ -0,0 +1,149 @@
+package caching
+
+import (
+	"context"
+
+	"github.com/authzed/spicedb/pkg/genutil/mapz"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schema"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// relationDependency returns the dependency on the relationships of the relation.
+func relationDependency(namespaceName string, relationName string) string {
+	return "r:" + namespaceName + "#" + relationName
+}
+
+// definitionDependency returns the dependency on the definition of the namespace.
+func definitionDependency(namespaceName string) string {
+	return "d:" + namespaceName
+}
+
+// caveatDependency returns the dependency on the definition of the caveat.
+func caveatDependency(caveatName string) string {
+	return "c:" + caveatName
+}
+
+// checkDependencies returns the dependencies of the result of checking the relation: the
+// relations whose relationships may be read, along with the definitions and caveats of the
+// schema consulted, in doing so.
+//
+// If the result may depend on relationships which expire, false is returned, as expiration is
+// not reported by the Watch API and so the result cannot be carried forward.
+func checkDependencies(ctx context.Context, ts *schema.TypeSystem, start *core.RelationReference) ([]string, bool, error) {
+	dependencies := mapz.NewSet[string]()
+	visited := mapz.NewSet[string]()
+	toVisit := []*core.RelationReference{start}
+
+	for len(toVisit) > 0 {
+		current := toVisit[len(toVisit)-1]
+		toVisit = toVisit[:len(toVisit)-1]
+
+		if !visited.Add(current.Namespace + "#" + current.Relation) {
+			continue
+		}
+
+		def, err := ts.GetDefinition(ctx, current.Namespace)
+		if err != nil {
+			return nil, false, err
+		}
+		dependencies.Insert(definitionDependency(current.Namespace))
+
+		// Arrows may walk to subject types without the computed relation, which are skipped.
+		relation, ok := def.GetRelation(current.Relation)
+		if !ok {
+			continue
+		}
+
+		if relation.UsersetRewrite == nil {
+			dependencies.Insert(relationDependency(current.Namespace, current.Relation))
+
+			for _, allowed := range relation.GetTypeInformation().GetAllowedDirectRelations() {
+				if allowed.GetRequiredExpiration() != nil {
+					return nil, false, nil
+				}
+
+				if caveat := allowed.GetRequiredCaveat(); caveat != nil {
+					dependencies.Insert(caveatDependency(caveat.CaveatName))
+				}
+
+				if subjectRelation := allowed.GetRelation(); subjectRelation != "" && subjectRelation != tuple.Ellipsis {
+					toVisit = append(toVisit, &core.RelationReference{Namespace: allowed.Namespace, Relation: subjectRelation})
+				}
+			}
+			continue
+		}
+
+		found, ok := rewriteRelations(def, current.Namespace, relation.UsersetRewrite)
+		if !ok {
+			return nil, false, nil
+		}
+		toVisit = append(toVisit, found...)
+	}
+
+	return dependencies.AsSlice(), true, nil
+}
+
+// rewriteRelations returns the relations referenced by the rewrite, including those reached by
+// its arrows, or false if the rewrite cannot be analyzed.
+func rewriteRelations(def *schema.Definition, namespaceName string, rewrite *core.UsersetRewrite) ([]*core.RelationReference, bool) {
+	var setOperation *core.SetOperation
+	switch t := rewrite.RewriteOperation.(type) {
+	case *core.UsersetRewrite_Union:
+		setOperation = t.Union
+	case *core.UsersetRewrite_Intersection:
+		setOperation = t.Intersection
+	case *core.UsersetRewrite_Exclusion:
+		setOperation = t.Exclusion
+	default:
+		return nil, false
+	}
+
+	found := make([]*core.RelationReference, 0, len(setOperation.Child))
+	arrow := func(tuplesetRelation string, computedRelation string) bool {
+		found = append(found, &core.RelationReference{Namespace: namespaceName, Relation: tuplesetRelation})
+
+		tupleset, ok := def.GetRelation(tuplesetRelation)
+		if !ok {
+			return false
+		}
+
+		for _, allowed := range tupleset.GetTypeInformation().GetAllowedDirectRelations() {
+			found = append(found, &core.RelationReference{Namespace: allowed.Namespace, Relation: computedRelation})
+		}
+		return true
+	}
+
+	for _, child := range setOperation.Child {
+		switch t := child.ChildType.(type) {
+		case *core.SetOperation_Child_ComputedUserset:
+			found = append(found, &core.RelationReference{Namespace: namespaceName, Relation: t.ComputedUserset.Relation})
+
+		case *core.SetOperation_Child_UsersetRewrite:
+			nested, ok := rewriteRelations(def, namespaceName, t.UsersetRewrite)
+			if !ok {
+				return nil, false
+			}
+			found = append(found, nested...)
+
+		case *core.SetOperation_Child_TupleToUserset:
+			if !arrow(t.TupleToUserset.Tupleset.Relation, t.TupleToUserset.ComputedUserset.Relation) {
+				return nil, false
+			}
+
+		case *core.SetOperation_Child_FunctionedTupleToUserset:
+			if !arrow(t.FunctionedTupleToUserset.Tupleset.Relation, t.FunctionedTupleToUserset.ComputedUserset.Relation) {
+				return nil, false
+			}
+
+		case *core.SetOperation_Child_XNil:
+			// Nil references nothing.
+
+		default:
+			// Legacy `_this` references, and any unknown children, are not analyzed.
+			return nil, false
+		}
+	}
+
+	return found, true
+}
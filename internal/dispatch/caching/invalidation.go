@@ -0,0 +1,183 @@
+package caching
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/pkg/datastore"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+)
+
+const defaultInvalidationRetryDelay = 5 * time.Second
+
+// InvalidationTracker tracks the revisions at which relations, definitions and caveats were
+// last changed, as reported by the Watch API of the datastore, so that dispatch results can be
+// carried forward to other revisions when nothing they depend upon has changed between them.
+type InvalidationTracker struct {
+	ds         datastore.Datastore
+	retryDelay time.Duration
+
+	mu sync.RWMutex
+
+	// startRevision is the revision at which the current watch was started, or nil if there is
+	// no running watch. Changes made at or before it are not known.
+	startRevision datastore.Revision
+
+	// completeRevision is the revision up to which all changes have been applied.
+	completeRevision datastore.Revision
+
+	// pendingRevision is the revision of the last changes applied, which may not yet be
+	// complete, as the changes of a single revision can be reported in several parts.
+	pendingRevision datastore.Revision
+
+	// lastChanged holds the revision of the last change of each dependency changed since the
+	// start of the watch.
+	lastChanged map[string]datastore.Revision
+}
+
+// NewInvalidationTracker creates a new InvalidationTracker over the given datastore. The tracker
+// does not report any results as valid until it is run.
+func NewInvalidationTracker(ds datastore.Datastore) *InvalidationTracker {
+	return &InvalidationTracker{
+		ds:         ds,
+		retryDelay: defaultInvalidationRetryDelay,
+	}
+}
+
+// Run watches the datastore for changes until the context is canceled. If the watch fails, all
+// tracked changes are forgotten, as changes may have been missed, and the watch is restarted
+// from the head revision after a delay.
+func (it *InvalidationTracker) Run(ctx context.Context) error {
+	for {
+		err := it.run(ctx)
+		it.reset(nil)
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		log.Ctx(ctx).Warn().Err(err).Stringer("retry-delay", it.retryDelay).Msg("dispatch cache invalidation watch failed, restarting")
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(it.retryDelay):
+		}
+	}
+}
+
+func (it *InvalidationTracker) run(ctx context.Context) error {
+	headRev, err := it.ds.HeadRevision(ctx)
+	if err != nil {
+		return err
+	}
+
+	changes, errs := it.ds.Watch(ctx, headRev, datastore.WatchOptions{
+		Content: datastore.WatchRelationships | datastore.WatchSchema | datastore.WatchCheckpoints,
+	})
+	it.reset(headRev)
+
+	log.Ctx(ctx).Debug().Str("revision", headRev.String()).Msg("started dispatch cache invalidation watch")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case change, ok := <-changes:
+			if !ok {
+				// The error, if any, is reported on the error channel.
+				changes = nil
+				continue
+			}
+
+			it.applyChanges(change)
+
+		case err := <-errs:
+			return err
+		}
+	}
+}
+
+// reset forgets all tracked changes, starting the tracking of changes at the given revision, if
+// any.
+func (it *InvalidationTracker) reset(startRevision datastore.Revision) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	it.startRevision = startRevision
+	it.completeRevision = startRevision
+	it.pendingRevision = nil
+	it.lastChanged = make(map[string]datastore.Revision)
+}
+
+func (it *InvalidationTracker) applyChanges(change datastore.RevisionChanges) {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	if it.startRevision == nil {
+		return
+	}
+
+	if change.IsCheckpoint {
+		it.completeRevision = change.Revision
+		it.pendingRevision = nil
+		return
+	}
+
+	// Changes are reported in order of revision, so once changes at a later revision are seen,
+	// all changes at the previous one have been applied.
+	if it.pendingRevision != nil && change.Revision.GreaterThan(it.pendingRevision) {
+		it.completeRevision = it.pendingRevision
+	}
+	it.pendingRevision = change.Revision
+
+	for _, update := range change.RelationshipChanges {
+		resource := update.Relationship.Resource
+		it.lastChanged[relationDependency(resource.ObjectType, resource.Relation)] = change.Revision
+	}
+
+	for _, definition := range change.ChangedDefinitions {
+		switch t := definition.(type) {
+		case *core.NamespaceDefinition:
+			it.lastChanged[definitionDependency(t.Name)] = change.Revision
+
+		case *core.CaveatDefinition:
+			it.lastChanged[caveatDependency(t.Name)] = change.Revision
+		}
+	}
+
+	for _, name := range change.DeletedNamespaces {
+		it.lastChanged[definitionDependency(name)] = change.Revision
+	}
+
+	for _, name := range change.DeletedCaveats {
+		it.lastChanged[caveatDependency(name)] = change.Revision
+	}
+}
+
+// CanCarryForward returns whether a result computed at the revision, with the given
+// dependencies, is also the result at the requested revision, as none of the dependencies is
+// known to have changed between the two revisions.
+func (it *InvalidationTracker) CanCarryForward(computedAt datastore.Revision, requestedAt datastore.Revision, dependencies []string) bool {
+	earlier, later := computedAt, requestedAt
+	if later.LessThan(earlier) {
+		earlier, later = later, earlier
+	}
+
+	it.mu.RLock()
+	defer it.mu.RUnlock()
+
+	if it.startRevision == nil || earlier.LessThan(it.startRevision) || later.GreaterThan(it.completeRevision) {
+		return false
+	}
+
+	for _, dependency := range dependencies {
+		// Only the last change of each dependency is tracked, so a dependency changed after
+		// the earlier revision may also have changed before the later one.
+		if changedAt, ok := it.lastChanged[dependency]; ok && changedAt.GreaterThan(earlier) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,219 @@
+package caching
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	datastoremw "github.com/authzed/spicedb/internal/middleware/datastore"
+	tf "github.com/authzed/spicedb/internal/testfixtures"
+	"github.com/authzed/spicedb/pkg/datastore"
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/schema"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+const carryForwardSchema = `
+	use expiration
+
+	definition user {}
+
+	caveat only_weekdays(weekday string) {
+		weekday != "saturday"
+	}
+
+	definition group {
+		relation member: user | group#member
+	}
+
+	definition folder {
+		relation viewer: user | user with only_weekdays
+		relation temporary: user with expiration
+		permission view = viewer
+		permission temporary_view = temporary
+	}
+
+	definition document {
+		relation parent: folder
+		relation viewer: user | group#member
+		relation banned: user
+		permission view = (viewer - banned) + parent->view
+		permission temporary_view = parent->temporary_view
+	}
+
+	definition unrelated {
+		relation viewer: user
+	}
+`
+
+func TestCheckDependencies(t *testing.T) {
+	compiled, err := compiler.Compile(compiler.InputSchema{
+		Source:       input.Source("schema"),
+		SchemaString: carryForwardSchema,
+	}, compiler.AllowUnprefixedObjectType())
+	require.NoError(t, err)
+
+	ts := schema.NewTypeSystem(schema.ResolverForSchema(*compiled))
+
+	tcs := []struct {
+		name         string
+		relation     string
+		expected     []string
+		carryForward bool
+	}{
+		{
+			name:     "relation",
+			relation: "unrelated#viewer",
+			expected: []string{
+				"d:unrelated",
+				"r:unrelated#viewer",
+			},
+			carryForward: true,
+		},
+		{
+			name:     "recursive subject relation",
+			relation: "group#member",
+			expected: []string{
+				"d:group",
+				"r:group#member",
+			},
+			carryForward: true,
+		},
+		{
+			name:     "permission with arrow and caveat",
+			relation: "document#view",
+			expected: []string{
+				"c:only_weekdays",
+				"d:document",
+				"d:folder",
+				"d:group",
+				"r:document#banned",
+				"r:document#parent",
+				"r:document#viewer",
+				"r:folder#viewer",
+				"r:group#member",
+			},
+			carryForward: true,
+		},
+		{
+			name:         "permission over expiring relationships",
+			relation:     "document#temporary_view",
+			carryForward: false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			namespaceName, relationName, _ := strings.Cut(tc.relation, "#")
+			dependencies, ok, err := checkDependencies(t.Context(), ts, RR(namespaceName, relationName))
+			require.NoError(t, err)
+			require.Equal(t, tc.carryForward, ok)
+			if !ok {
+				return
+			}
+
+			slices.Sort(dependencies)
+			require.Equal(t, tc.expected, dependencies)
+		})
+	}
+}
+
+func TestCarryForwardCheck(t *testing.T) {
+	rawDS, err := memdb.NewMemdbDatastore(0, 0, memdb.DisableGC)
+	require.NoError(t, err)
+
+	ds, startRevision := tf.DatastoreFromSchemaAndTestRelationships(rawDS, carryForwardSchema, []tuple.Relationship{
+		tuple.MustParse("document:doc1#viewer@group:eng#member"),
+		tuple.MustParse("group:eng#member@user:tom"),
+	}, require.New(t))
+
+	tracker := NewInvalidationTracker(ds)
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	go func() {
+		_ = tracker.Run(ctx)
+	}()
+
+	// Wait for the tracker to start watching and to process changes up to the revision.
+	waitForTracker := func(revision datastore.Revision) {
+		require.Eventually(t, func() bool {
+			return tracker.CanCarryForward(revision, revision, nil)
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+	waitForTracker(startRevision)
+
+	delegate := delegateDispatchMock{&mock.Mock{}}
+	delegate.On("DispatchCheck", mock.Anything).Return(&v1.DispatchCheckResponse{
+		ResultsByResourceId: map[string]*v1.ResourceCheckResult{
+			"doc1": {Membership: v1.ResourceCheckResult_MEMBER},
+		},
+		Metadata: &v1.ResponseMeta{DispatchCount: 1, DepthRequired: 1},
+	}, nil)
+
+	dispatcher, err := NewCachingDispatcher(DispatchTestCache(t), false, "", nil)
+	require.NoError(t, err)
+	dispatcher.SetDelegate(delegate)
+	dispatcher.SetInvalidationTracker(tracker)
+	t.Cleanup(func() {
+		_ = dispatcher.Close()
+	})
+
+	checkCtx := datastoremw.ContextWithDatastore(t.Context(), ds)
+	check := func(revision datastore.Revision) {
+		resp, err := dispatcher.DispatchCheck(checkCtx, &v1.DispatchCheckRequest{
+			ResourceRelation: RR("document", "view"),
+			ResourceIds:      []string{"doc1"},
+			Subject:          tuple.MustParseSubjectONR("user:tom").ToCoreONR(),
+			Metadata: &v1.ResolverMeta{
+				AtRevision:     revision.String(),
+				DepthRemaining: 50,
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, v1.ResourceCheckResult_MEMBER, resp.ResultsByResourceId["doc1"].Membership)
+
+		// Let the cache converge.
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	write := func(rel string) datastore.Revision {
+		revision, err := ds.ReadWriteTx(t.Context(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+			return rwt.WriteRelationships(ctx, []tuple.RelationshipUpdate{tuple.Touch(tuple.MustParse(rel))})
+		})
+		require.NoError(t, err)
+		waitForTracker(revision)
+		return revision
+	}
+
+	check(startRevision)
+	delegate.AssertNumberOfCalls(t, "DispatchCheck", 1)
+
+	// A change to a relation the result does not depend upon carries the result forward.
+	unrelatedRevision := write("unrelated:first#viewer@user:tom")
+	check(unrelatedRevision)
+	delegate.AssertNumberOfCalls(t, "DispatchCheck", 1)
+
+	// A change to a relation the result depends upon invalidates it.
+	relatedRevision := write("group:eng#member@user:fred")
+	check(relatedRevision)
+	delegate.AssertNumberOfCalls(t, "DispatchCheck", 2)
+
+	// The result computed after the change is carried forward again.
+	laterRevision := write("unrelated:second#viewer@user:tom")
+	check(laterRevision)
+	delegate.AssertNumberOfCalls(t, "DispatchCheck", 2)
+
+	// A result is not carried forward to revisions the tracker has not yet processed.
+	unprocessedRevision := revisions.NewForTimestamp(laterRevision.(revisions.TimestampRevision).TimestampNanoSec() + 1)
+	require.False(t, tracker.CanCarryForward(laterRevision, unprocessedRevision, nil))
+}
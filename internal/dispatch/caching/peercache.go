@@ -0,0 +1,111 @@
+package caching
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/authzed/spicedb/internal/dispatch"
+	"github.com/authzed/spicedb/internal/dispatch/keys"
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+)
+
+// PeerCache is a second tier of cache, shared by the peers of a node in the cluster, which is
+// consulted for results not found in the local cache.
+type PeerCache interface {
+	// Lookup returns the marshaled responses cached by a peer for the request, if any. Failures
+	// to reach a peer are reported as results not being found.
+	Lookup(ctx context.Context, req *v1.DispatchPeerCacheLookupRequest) ([][]byte, bool)
+}
+
+// SetPeerCache sets the cache of the peers of the node, consulted for results not found in the
+// local cache. Results found in the peer cache are then also cached locally.
+func (cd *Dispatcher) SetPeerCache(peerCache PeerCache) {
+	cd.peerCache = peerCache
+}
+
+// LookupPeerCache implements dispatch.PeerCacheLookup, returning the result cached locally for
+// the request, if any. The peer cache of the dispatcher itself is never consulted, to ensure
+// that lookups are not forwarded from peer to peer.
+func (cd *Dispatcher) LookupPeerCache(ctx context.Context, req *v1.DispatchPeerCacheLookupRequest) (*v1.DispatchPeerCacheLookupResponse, error) {
+	var (
+		requestKey keys.DispatchCacheKey
+		err        error
+	)
+	switch t := req.Request.(type) {
+	case *v1.DispatchPeerCacheLookupRequest_Check:
+		requestKey, err = cd.keyHandler.CheckCacheKey(ctx, t.Check)
+	case *v1.DispatchPeerCacheLookupRequest_LookupResources2:
+		requestKey, err = cd.keyHandler.LookupResources2CacheKey(ctx, t.LookupResources2)
+	case *v1.DispatchPeerCacheLookupRequest_LookupResources3:
+		requestKey, err = cd.keyHandler.LookupResources3CacheKey(ctx, t.LookupResources3)
+	case *v1.DispatchPeerCacheLookupRequest_LookupSubjects:
+		requestKey, err = cd.keyHandler.LookupSubjectsCacheKey(ctx, t.LookupSubjects)
+	default:
+		return nil, fmt.Errorf("unknown peer cache lookup request: %T", req.Request)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cachedResultRaw, found := cd.get(requestKey)
+	if !found {
+		return &v1.DispatchPeerCacheLookupResponse{}, nil
+	}
+
+	switch cached := cachedResultRaw.(type) {
+	case []byte:
+		return &v1.DispatchPeerCacheLookupResponse{Found: true, CachedResponses: [][]byte{cached}}, nil
+	case [][]byte:
+		return &v1.DispatchPeerCacheLookupResponse{Found: true, CachedResponses: cached}, nil
+	default:
+		return &v1.DispatchPeerCacheLookupResponse{}, nil
+	}
+}
+
+// peerCheckResponse returns the response cached by a peer for the check, if any, caching it
+// locally under the request key.
+func (cd *Dispatcher) peerCheckResponse(ctx context.Context, req *v1.DispatchCheckRequest, requestKey keys.DispatchCacheKey) (*v1.DispatchCheckResponse, bool) {
+	peerRequest := checkLookupRequest(req)
+	cached, found := cd.peerCache.Lookup(ctx, peerRequest)
+	if !found || len(cached) != 1 {
+		return nil, false
+	}
+
+	var response v1.DispatchCheckResponse
+	if err := response.UnmarshalVT(cached[0]); err != nil {
+		return nil, false
+	}
+
+	cd.fromPeerCacheCounter.Inc()
+	cd.set(requestKey, cached[0], sliceSize(cached[0]), peerRequest)
+	return &response, true
+}
+
+// cachedStreamResults returns the marshaled responses cached for a lookup, from the local cache
+// or, if not found locally, from the cache of a peer, in which case they are also cached locally.
+func (cd *Dispatcher) cachedStreamResults(ctx context.Context, requestKey keys.DispatchCacheKey, peerRequest *v1.DispatchPeerCacheLookupRequest) ([][]byte, bool) {
+	if cachedResultRaw, found := cd.get(requestKey); found {
+		return cachedResultRaw.([][]byte), true
+	}
+
+	if cd.peerCache == nil {
+		return nil, false
+	}
+
+	cached, found := cd.peerCache.Lookup(ctx, peerRequest)
+	if !found {
+		return nil, false
+	}
+
+	var size int64
+	for _, slice := range cached {
+		size += sliceSize(slice)
+	}
+
+	cd.fromPeerCacheCounter.Inc()
+	cd.set(requestKey, cached, size, peerRequest)
+	return cached, true
+}
+
+// Always verify that we implement the interface
+var _ dispatch.PeerCacheLookup = &Dispatcher{}
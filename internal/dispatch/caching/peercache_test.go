@@ -0,0 +1,106 @@
+package caching
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// localPeerCache is a PeerCache which looks up results directly in the cache of another
+// dispatcher.
+type localPeerCache struct {
+	peer    *Dispatcher
+	lookups int
+}
+
+func (lpc *localPeerCache) Lookup(ctx context.Context, req *v1.DispatchPeerCacheLookupRequest) ([][]byte, bool) {
+	lpc.lookups++
+	resp, err := lpc.peer.LookupPeerCache(ctx, req)
+	if err != nil {
+		return nil, false
+	}
+	return resp.CachedResponses, resp.Found
+}
+
+func TestPeerCacheCheck(t *testing.T) {
+	checkRequest := func(resourceID string) *v1.DispatchCheckRequest {
+		return &v1.DispatchCheckRequest{
+			ResourceRelation: RR("document", "view"),
+			ResourceIds:      []string{resourceID},
+			Subject:          tuple.MustParseSubjectONR("user:tom").ToCoreONR(),
+			Metadata: &v1.ResolverMeta{
+				AtRevision:     "1234",
+				DepthRemaining: 50,
+			},
+		}
+	}
+
+	newDispatcher := func(delegate delegateDispatchMock) *Dispatcher {
+		dispatcher, err := NewCachingDispatcher(DispatchTestCache(t), false, "", nil)
+		require.NoError(t, err)
+		dispatcher.SetDelegate(delegate)
+		t.Cleanup(func() {
+			_ = dispatcher.Close()
+		})
+		return dispatcher
+	}
+
+	previousDelegate := delegateDispatchMock{&mock.Mock{}}
+	previousDelegate.On("DispatchCheck", mock.Anything).Return(&v1.DispatchCheckResponse{
+		ResultsByResourceId: map[string]*v1.ResourceCheckResult{
+			"doc1": {Membership: v1.ResourceCheckResult_MEMBER},
+		},
+		Metadata: &v1.ResponseMeta{DispatchCount: 1, DepthRequired: 1},
+	}, nil)
+	previous := newDispatcher(previousDelegate)
+
+	// Nothing is found in the cache of the peer before the result is computed.
+	resp, err := previous.LookupPeerCache(t.Context(), &v1.DispatchPeerCacheLookupRequest{
+		Request: &v1.DispatchPeerCacheLookupRequest_Check{Check: checkRequest("doc1")},
+	})
+	require.NoError(t, err)
+	require.False(t, resp.Found)
+
+	_, err = previous.DispatchCheck(t.Context(), checkRequest("doc1"))
+	require.NoError(t, err)
+	previousDelegate.AssertNumberOfCalls(t, "DispatchCheck", 1)
+	previous.c.Wait()
+
+	currentDelegate := delegateDispatchMock{&mock.Mock{}}
+	currentDelegate.On("DispatchCheck", mock.Anything).Return(&v1.DispatchCheckResponse{
+		ResultsByResourceId: map[string]*v1.ResourceCheckResult{
+			"doc2": {Membership: v1.ResourceCheckResult_NOT_MEMBER},
+		},
+		Metadata: &v1.ResponseMeta{DispatchCount: 1, DepthRequired: 1},
+	}, nil)
+	current := newDispatcher(currentDelegate)
+
+	peerCache := &localPeerCache{peer: previous}
+	current.SetPeerCache(peerCache)
+
+	// The result cached by the peer is returned without being computed.
+	checkResp, err := current.DispatchCheck(t.Context(), checkRequest("doc1"))
+	require.NoError(t, err)
+	require.Equal(t, v1.ResourceCheckResult_MEMBER, checkResp.ResultsByResourceId["doc1"].Membership)
+	require.Equal(t, uint32(1), checkResp.Metadata.CachedDispatchCount)
+	currentDelegate.AssertNumberOfCalls(t, "DispatchCheck", 0)
+	require.Equal(t, 1, peerCache.lookups)
+	current.c.Wait()
+
+	// The result is then cached locally, so the peer is no longer consulted.
+	_, err = current.DispatchCheck(t.Context(), checkRequest("doc1"))
+	require.NoError(t, err)
+	require.Equal(t, 1, peerCache.lookups)
+
+	// Results not cached by the peer are computed.
+	checkResp, err = current.DispatchCheck(t.Context(), checkRequest("doc2"))
+	require.NoError(t, err)
+	require.Equal(t, v1.ResourceCheckResult_NOT_MEMBER, checkResp.ResultsByResourceId["doc2"].Membership)
+	currentDelegate.AssertNumberOfCalls(t, "DispatchCheck", 1)
+	require.Equal(t, 2, peerCache.lookups)
+}
@@ -0,0 +1,217 @@
+package caching
+
+import (
+	"context"
+	"encoding/binary"
+	"time"
+	"unsafe"
+
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/internal/dispatch/keys"
+	log "github.com/authzed/spicedb/internal/logging"
+	datastoremw "github.com/authzed/spicedb/internal/middleware/datastore"
+	"github.com/authzed/spicedb/pkg/cache"
+	"github.com/authzed/spicedb/pkg/datastore"
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+)
+
+// restorableResult is a result cached along with the request for which it was computed, so that
+// its cache key can be recomputed when it is restored by another process. Cache keys are
+// partially computed with a per-process seed, and so cannot themselves be persisted.
+type restorableResult struct {
+	// request is the marshaled DispatchPeerCacheLookupRequest holding the request.
+	request []byte
+
+	// value is the cached result: the marshaled response of a check, or the marshaled
+	// responses of a lookup.
+	value any
+}
+
+// get returns the result cached under the key, if any.
+func (cd *Dispatcher) get(key keys.DispatchCacheKey) (any, bool) {
+	cached, found := cd.c.Get(key)
+	if !found {
+		return nil, false
+	}
+
+	if restorable, ok := cached.(*restorableResult); ok {
+		return restorable.value, true
+	}
+	return cached, true
+}
+
+// set caches the result under the key. If the cache is persistent and the request is given, the
+// result is cached along with its request, so that it can be restored.
+func (cd *Dispatcher) set(key keys.DispatchCacheKey, value any, cost int64, request *v1.DispatchPeerCacheLookupRequest) {
+	if !cd.restorable || request == nil {
+		cd.c.Set(key, value, cost)
+		return
+	}
+
+	marshaled, err := request.MarshalVT()
+	if err != nil {
+		// The result is still cached, but will not be restored.
+		cd.c.Set(key, value, cost)
+		return
+	}
+
+	cost += sliceSize(marshaled) + int64(unsafe.Sizeof(restorableResult{}))
+	cd.c.Set(key, &restorableResult{request: marshaled, value: value}, cost)
+}
+
+func checkLookupRequest(req *v1.DispatchCheckRequest) *v1.DispatchPeerCacheLookupRequest {
+	return &v1.DispatchPeerCacheLookupRequest{
+		Request: &v1.DispatchPeerCacheLookupRequest_Check{Check: req},
+	}
+}
+
+// NewPersistenceCodec returns the codec used to persist the results cached by a dispatcher and to
+// restore them. Restored results are keyed using the key handler, which must match that of the
+// dispatcher, and are only restored if computed at a revision still within the revision window,
+// beyond which results are unlikely to be requested again.
+func NewPersistenceCodec(ds datastore.Datastore, keyHandler keys.Handler, revisionWindow time.Duration) cache.Codec[keys.DispatchCacheKey, any] {
+	if keyHandler == nil {
+		keyHandler = &keys.DirectKeyHandler{}
+	}
+	return &persistenceCodec{ds: ds, keyHandler: keyHandler, revisionWindow: revisionWindow, windowChecker: revisions.NewWindowChecker(ds, revisionWindow)}
+}
+
+type persistenceCodec struct {
+	ds             datastore.Datastore
+	keyHandler     keys.Handler
+	revisionWindow time.Duration
+
+	// windowChecker checks the revisions of the results decoded, each only once.
+	windowChecker *revisions.WindowChecker
+}
+
+// ForRestore returns a codec checking the revisions of the results of a single restore, each
+// against the datastore only once, as most results are computed at a handful of revisions.
+func (pc *persistenceCodec) ForRestore() cache.Codec[keys.DispatchCacheKey, any] {
+	restoring := *pc
+	restoring.windowChecker = revisions.NewWindowChecker(pc.ds, pc.revisionWindow)
+	return &restoring
+}
+
+// Encode encodes a result as its length-prefixed request, followed by a
+// DispatchPeerCacheLookupResponse holding the result. Results cached without their request, such
+// as those cached for carrying forward, are not persisted.
+func (pc *persistenceCodec) Encode(_ keys.DispatchCacheKey, value any) ([]byte, bool) {
+	restorable, ok := value.(*restorableResult)
+	if !ok {
+		return nil, false
+	}
+
+	response := &v1.DispatchPeerCacheLookupResponse{Found: true}
+	switch cached := restorable.value.(type) {
+	case []byte:
+		response.CachedResponses = [][]byte{cached}
+	case [][]byte:
+		response.CachedResponses = cached
+	default:
+		return nil, false
+	}
+
+	marshaledResponse, err := response.MarshalVT()
+	if err != nil {
+		return nil, false
+	}
+
+	encoded := make([]byte, 0, binary.MaxVarintLen64+len(restorable.request)+len(marshaledResponse))
+	encoded = binary.AppendUvarint(encoded, uint64(len(restorable.request)))
+	encoded = append(encoded, restorable.request...)
+	encoded = append(encoded, marshaledResponse...)
+	return encoded, true
+}
+
+func (pc *persistenceCodec) Decode(ctx context.Context, encoded []byte) (keys.DispatchCacheKey, any, int64, bool) {
+	requestLength, read := binary.Uvarint(encoded)
+	if read <= 0 || requestLength > uint64(len(encoded)-read) {
+		return keys.DispatchCacheKey{}, nil, 0, false
+	}
+
+	marshaledRequest := encoded[read : read+int(requestLength)]
+	var request v1.DispatchPeerCacheLookupRequest
+	if err := request.UnmarshalVT(marshaledRequest); err != nil {
+		return keys.DispatchCacheKey{}, nil, 0, false
+	}
+
+	var response v1.DispatchPeerCacheLookupResponse
+	if err := response.UnmarshalVT(encoded[read+int(requestLength):]); err != nil {
+		return keys.DispatchCacheKey{}, nil, 0, false
+	}
+
+	valid, err := pc.inRevisionWindow(ctx, &request)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("unable to determine revision of persisted dispatch result")
+		return keys.DispatchCacheKey{}, nil, 0, false
+	}
+	if !valid {
+		return keys.DispatchCacheKey{}, nil, 0, false
+	}
+
+	ctx = datastoremw.ContextWithDatastore(ctx, pc.ds)
+
+	var (
+		key    keys.DispatchCacheKey
+		keyErr error
+		value  any
+	)
+	switch t := request.Request.(type) {
+	case *v1.DispatchPeerCacheLookupRequest_Check:
+		if len(response.CachedResponses) != 1 {
+			return keys.DispatchCacheKey{}, nil, 0, false
+		}
+		key, keyErr = pc.keyHandler.CheckCacheKey(ctx, t.Check)
+		value = response.CachedResponses[0]
+	case *v1.DispatchPeerCacheLookupRequest_LookupResources2:
+		key, keyErr = pc.keyHandler.LookupResources2CacheKey(ctx, t.LookupResources2)
+		value = response.CachedResponses
+	case *v1.DispatchPeerCacheLookupRequest_LookupResources3:
+		key, keyErr = pc.keyHandler.LookupResources3CacheKey(ctx, t.LookupResources3)
+		value = response.CachedResponses
+	case *v1.DispatchPeerCacheLookupRequest_LookupSubjects:
+		key, keyErr = pc.keyHandler.LookupSubjectsCacheKey(ctx, t.LookupSubjects)
+		value = response.CachedResponses
+	default:
+		return keys.DispatchCacheKey{}, nil, 0, false
+	}
+	if keyErr != nil {
+		log.Ctx(ctx).Debug().Err(keyErr).Msg("unable to compute cache key of persisted dispatch result")
+		return keys.DispatchCacheKey{}, nil, 0, false
+	}
+
+	var cost int64
+	for _, slice := range response.CachedResponses {
+		cost += sliceSize(slice)
+	}
+	cost += sliceSize(marshaledRequest) + int64(unsafe.Sizeof(restorableResult{}))
+
+	// The request is copied, so that the restored result does not retain the whole of the
+	// encoded entry.
+	return key, &restorableResult{request: append([]byte(nil), marshaledRequest...), value: value}, cost, true
+}
+
+// inRevisionWindow returns whether the request is for a revision which is still valid in the
+// datastore and, for revisions with a timestamp, is no older than the revision window.
+func (pc *persistenceCodec) inRevisionWindow(ctx context.Context, request *v1.DispatchPeerCacheLookupRequest) (bool, error) {
+	var metadata *v1.ResolverMeta
+	switch t := request.Request.(type) {
+	case *v1.DispatchPeerCacheLookupRequest_Check:
+		metadata = t.Check.GetMetadata()
+	case *v1.DispatchPeerCacheLookupRequest_LookupResources2:
+		metadata = t.LookupResources2.GetMetadata()
+	case *v1.DispatchPeerCacheLookupRequest_LookupResources3:
+		metadata = t.LookupResources3.GetMetadata()
+	case *v1.DispatchPeerCacheLookupRequest_LookupSubjects:
+		metadata = t.LookupSubjects.GetMetadata()
+	}
+	if metadata == nil {
+		return false, nil
+	}
+
+	return pc.windowChecker.InWindow(ctx, metadata.AtRevision)
+}
+
+// Always verify that we implement the interface
+var _ cache.RestoreScopedCodec[keys.DispatchCacheKey, any] = &persistenceCodec{}
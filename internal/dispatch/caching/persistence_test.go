@@ -0,0 +1,99 @@
+package caching
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	"github.com/authzed/spicedb/internal/datastore/revisions"
+	"github.com/authzed/spicedb/internal/dispatch/keys"
+	datastoremw "github.com/authzed/spicedb/internal/middleware/datastore"
+	tf "github.com/authzed/spicedb/internal/testfixtures"
+	"github.com/authzed/spicedb/pkg/cache"
+	"github.com/authzed/spicedb/pkg/datastore"
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+func TestPersistedCheckResults(t *testing.T) {
+	rawDS, err := memdb.NewMemdbDatastore(0, 0, memdb.DisableGC)
+	require.NoError(t, err)
+
+	ds, currentRevision := tf.DatastoreFromSchemaAndTestRelationships(rawDS, carryForwardSchema, []tuple.Relationship{
+		tuple.MustParse("document:doc1#viewer@user:tom"),
+	}, require.New(t))
+	staleRevision := revisions.NewForTime(time.Now().Add(-1 * time.Hour))
+
+	path := filepath.Join(t.TempDir(), "dispatch-cache")
+	codec := NewPersistenceCodec(ds, &keys.DirectKeyHandler{}, 1*time.Minute)
+
+	newDispatcher := func() (*Dispatcher, *cache.PersistentCache[keys.DispatchCacheKey, any], delegateDispatchMock) {
+		persisted := cache.NewPersistentCache(DispatchTestCache(t), path, 0, codec)
+
+		delegate := delegateDispatchMock{&mock.Mock{}}
+		delegate.On("DispatchCheck", mock.Anything).Return(&v1.DispatchCheckResponse{
+			ResultsByResourceId: map[string]*v1.ResourceCheckResult{
+				"doc1": {Membership: v1.ResourceCheckResult_MEMBER},
+			},
+			Metadata: &v1.ResponseMeta{DispatchCount: 1, DepthRequired: 1},
+		}, nil)
+
+		dispatcher, err := NewCachingDispatcher(persisted, false, "", &keys.DirectKeyHandler{})
+		require.NoError(t, err)
+		require.True(t, dispatcher.restorable)
+		dispatcher.SetDelegate(delegate)
+		t.Cleanup(func() {
+			_ = dispatcher.Close()
+		})
+		return dispatcher, persisted, delegate
+	}
+
+	checkCtx := datastoremw.ContextWithDatastore(t.Context(), ds)
+	check := func(dispatcher *Dispatcher, revision datastore.Revision) {
+		resp, err := dispatcher.DispatchCheck(checkCtx, &v1.DispatchCheckRequest{
+			ResourceRelation: RR("document", "view"),
+			ResourceIds:      []string{"doc1"},
+			Subject:          tuple.MustParseSubjectONR("user:tom").ToCoreONR(),
+			Metadata: &v1.ResolverMeta{
+				AtRevision:     revision.String(),
+				DepthRemaining: 50,
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, v1.ResourceCheckResult_MEMBER, resp.ResultsByResourceId["doc1"].Membership)
+		dispatcher.c.Wait()
+	}
+
+	previous, previousCache, previousDelegate := newDispatcher()
+
+	// Repeatedly check at both revisions, so that the results are tracked as hot.
+	for range 16 {
+		check(previous, staleRevision)
+	}
+	for range 16 {
+		check(previous, currentRevision)
+	}
+	previousDelegate.AssertNumberOfCalls(t, "DispatchCheck", 2)
+
+	written, err := previousCache.Persist(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 2, written)
+
+	current, currentCache, currentDelegate := newDispatcher()
+	restored, err := currentCache.Restore(t.Context())
+	require.NoError(t, err)
+
+	// Only the result at the current revision is restored, as the stale revision is outside of
+	// the revision window.
+	require.Equal(t, 1, restored)
+
+	check(current, currentRevision)
+	currentDelegate.AssertNumberOfCalls(t, "DispatchCheck", 0)
+
+	check(current, staleRevision)
+	currentDelegate.AssertNumberOfCalls(t, "DispatchCheck", 1)
+}
@@ -0,0 +1,336 @@
+package caching
+
+import (
+	"context"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/authzed/spicedb/internal/dispatch"
+	"github.com/authzed/spicedb/internal/dispatch/keys"
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+)
+
+// resumableLookupBatchSize is the maximum number of items published in each response of a
+// lookup served from a cached prefix.
+const resumableLookupBatchSize = 100
+
+// EnableResumableLookups enables caching lookups such that they can be resumed:
+//
+//   - The results of limited LookupResources3 operations are cached as prefixes of the results
+//     found from their cursor onward, indexed by the cursor after each result. A lookup starting
+//     at any cached cursor is served from the prefix and, should the prefix not hold enough
+//     results, resumed from its end, extending it for later lookups. Such lookups are not
+//     otherwise cached, and are not found in the peer cache.
+//   - The results of LookupSubjects operations are also cached for each of their resources,
+//     including those for which no subjects were found, such that a lookup only dispatches for
+//     the resources whose results have not already been cached. Each such resource is
+//     dispatched separately, so that its results are cached once its lookup completes, even
+//     should that of another resource fail. As LookupSubjects operations have no cursor, their
+//     results are not cached as resumable prefixes.
+func (cd *Dispatcher) EnableResumableLookups() {
+	cd.resumableLookups = true
+}
+
+// lookupPrefix holds the marshaled items found by a limited LookupResources3 operation from a
+// cursor onward. A prefix is shared by all of the positions within it, and is extended as further
+// items are found, such that lookups resumed from any position reuse the items found after it.
+type lookupPrefix struct {
+	mu       sync.RWMutex
+	items    [][]byte
+	complete bool
+}
+
+// itemsFrom returns up to limit items from the offset, along with whether no further items exist
+// beyond those held by the prefix.
+func (lp *lookupPrefix) itemsFrom(offset int, limit int) ([][]byte, bool) {
+	lp.mu.RLock()
+	defer lp.mu.RUnlock()
+
+	if offset >= len(lp.items) {
+		return nil, lp.complete
+	}
+
+	end := min(offset+limit, len(lp.items))
+	return lp.items[offset:end], lp.complete && end == len(lp.items)
+}
+
+// extend appends the items found when resuming from the offset, returning false if the prefix
+// has since been extended by another lookup.
+func (lp *lookupPrefix) extend(offset int, items [][]byte, complete bool) bool {
+	lp.mu.Lock()
+	defer lp.mu.Unlock()
+
+	if offset != len(lp.items) || lp.complete {
+		return false
+	}
+
+	lp.items = append(lp.items, items...)
+	lp.complete = complete
+	return true
+}
+
+// lookupPosition is a position within a prefix, cached under the key of the cursor at which it
+// begins.
+type lookupPosition struct {
+	prefix *lookupPrefix
+	offset int
+}
+
+func withCursor(req *v1.DispatchLookupResources3Request, cursor []string, limit uint32) *v1.DispatchLookupResources3Request {
+	return &v1.DispatchLookupResources3Request{
+		Metadata:         req.Metadata,
+		ResourceRelation: req.ResourceRelation,
+		SubjectRelation:  req.SubjectRelation,
+		SubjectIds:       req.SubjectIds,
+		TerminalSubject:  req.TerminalSubject,
+		Context:          req.Context,
+		OptionalCursor:   cursor,
+		OptionalLimit:    limit,
+	}
+}
+
+// resumableLookupResources3 serves a limited lookup from the prefix cached for its cursor, if
+// any, resuming the lookup from the end of the prefix should it not hold enough items.
+func (cd *Dispatcher) resumableLookupResources3(req *v1.DispatchLookupResources3Request, stream dispatch.LookupResources3Stream) error {
+	ctx := stream.Context()
+	startKey, err := cd.keyHandler.LookupResources3CursorCacheKey(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	var position *lookupPosition
+	if cached, found := cd.c.Get(startKey); found {
+		position = cached.(*lookupPosition)
+	} else {
+		position = &lookupPosition{prefix: &lookupPrefix{}}
+		cd.c.Set(startKey, position, int64(unsafe.Sizeof(lookupPosition{})))
+	}
+
+	limit := int(req.OptionalLimit)
+	cachedItems, complete := position.prefix.itemsFrom(position.offset, limit)
+
+	cursor := req.OptionalCursor
+	batch := make([]*v1.LR3Item, 0, min(len(cachedItems), resumableLookupBatchSize))
+	for _, marshaled := range cachedItems {
+		item := &v1.LR3Item{}
+		if err := item.UnmarshalVT(marshaled); err != nil {
+			return err
+		}
+		cursor = item.AfterResponseCursorSections
+
+		batch = append(batch, item)
+		if len(batch) < resumableLookupBatchSize {
+			continue
+		}
+
+		if err := stream.Publish(&v1.DispatchLookupResources3Response{Items: batch}); err != nil {
+			return err
+		}
+		batch = make([]*v1.LR3Item, 0, resumableLookupBatchSize)
+	}
+	if len(batch) > 0 {
+		if err := stream.Publish(&v1.DispatchLookupResources3Response{Items: batch}); err != nil {
+			return err
+		}
+	}
+
+	remaining := limit - len(cachedItems)
+	if remaining == 0 || complete {
+		if len(cachedItems) > 0 {
+			cd.lookupResourcesFromCacheCounter.Inc()
+		}
+		return nil
+	}
+
+	// Resume the lookup from the end of the items found in the prefix.
+	resumeReq := withCursor(req, cursor, uint32(remaining)) // nolint:gosec
+
+	var (
+		mu         sync.Mutex
+		foundItems []*v1.LR3Item
+		marshaled  [][]byte
+	)
+	wrapped := &dispatch.WrappedDispatchStream[*v1.DispatchLookupResources3Response]{
+		Stream: stream,
+		Ctx:    ctx,
+		Processor: func(result *v1.DispatchLookupResources3Response) (*v1.DispatchLookupResources3Response, bool, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			for _, item := range result.Items {
+				bytes, err := item.MarshalVT()
+				if err != nil {
+					return &v1.DispatchLookupResources3Response{}, false, err
+				}
+				foundItems = append(foundItems, item.CloneVT())
+				marshaled = append(marshaled, bytes)
+			}
+			return result, true, nil
+		},
+	}
+
+	// Should the lookup fail, the items found before it failed are still cached, as the prefix
+	// can be resumed from its end.
+	lookupErr := cd.d.DispatchLookupResources3(resumeReq, wrapped)
+
+	// A lookup returning fewer items than its limit has found all items.
+	foundAll := lookupErr == nil && len(foundItems) < remaining
+	if position.prefix.extend(position.offset+len(cachedItems), marshaled, foundAll) {
+		cd.indexLookupPositions(ctx, req, position.prefix, position.offset+len(cachedItems), foundItems, marshaled)
+	}
+	return lookupErr
+}
+
+// indexLookupPositions caches the position within the prefix which follows each of the items
+// found, so that lookups starting at the cursor after any of the items are served from the
+// prefix.
+func (cd *Dispatcher) indexLookupPositions(ctx context.Context, req *v1.DispatchLookupResources3Request, prefix *lookupPrefix, offset int, items []*v1.LR3Item, marshaled [][]byte) {
+	for index, item := range items {
+		if len(item.AfterResponseCursorSections) == 0 {
+			continue
+		}
+
+		key, err := cd.keyHandler.LookupResources3CursorCacheKey(ctx, withCursor(req, item.AfterResponseCursorSections, 0))
+		if err != nil {
+			continue
+		}
+
+		cost := int64(unsafe.Sizeof(lookupPosition{})) + sliceSize(marshaled[index])
+		cd.c.Set(key, &lookupPosition{prefix: prefix, offset: offset + index + 1}, cost)
+	}
+}
+
+func forResource(req *v1.DispatchLookupSubjectsRequest, resourceID string) *v1.DispatchLookupSubjectsRequest {
+	return &v1.DispatchLookupSubjectsRequest{
+		Metadata:         req.Metadata,
+		ResourceRelation: req.ResourceRelation,
+		ResourceIds:      []string{resourceID},
+		SubjectRelation:  req.SubjectRelation,
+	}
+}
+
+// perResourceLookupSubjects serves the lookup from the results cached for each of its resources,
+// dispatching only for the resources whose results are not cached, and then caching their
+// results for each resource, as well as for the lookup as a whole.
+func (cd *Dispatcher) perResourceLookupSubjects(req *v1.DispatchLookupSubjectsRequest, requestKey keys.DispatchCacheKey, stream dispatch.LookupSubjectsStream) error {
+	ctx := stream.Context()
+
+	var (
+		cachedResults [][]byte
+		remainingIDs  []string
+	)
+	for _, resourceID := range req.ResourceIds {
+		resourceKey, err := cd.keyHandler.LookupSubjectsCacheKey(ctx, forResource(req, resourceID))
+		if err != nil {
+			return err
+		}
+
+		cached, found := cd.get(resourceKey)
+		if !found {
+			remainingIDs = append(remainingIDs, resourceID)
+			continue
+		}
+		cachedResults = append(cachedResults, cached.([][]byte)...)
+	}
+
+	for _, slice := range cachedResults {
+		var response v1.DispatchLookupSubjectsResponse
+		if err := response.UnmarshalVT(slice); err != nil {
+			return err
+		}
+		if err := stream.Publish(&response); err != nil {
+			return err
+		}
+	}
+
+	if len(remainingIDs) == 0 {
+		cd.lookupSubjectsFromCacheCounter.Inc()
+		return nil
+	}
+
+	// Each resource is dispatched separately, as the results of a resource may be published
+	// across several responses, so that the results of those resources whose lookups complete
+	// are cached even should the lookup of another fail.
+	var (
+		mu              sync.Mutex
+		computedResults [][]byte
+	)
+	g, gctx := errgroup.WithContext(ctx)
+	for _, resourceID := range remainingIDs {
+		g.Go(func() error {
+			results, err := cd.lookupSubjectsForResource(gctx, forResource(req, resourceID), stream)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			computedResults = append(computedResults, results...)
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	toCacheResults := append(cachedResults, computedResults...)
+	var size int64
+	for _, slice := range toCacheResults {
+		size += sliceSize(slice)
+	}
+	cd.set(requestKey, toCacheResults, size, &v1.DispatchPeerCacheLookupRequest{
+		Request: &v1.DispatchPeerCacheLookupRequest_LookupSubjects{LookupSubjects: req},
+	})
+	return nil
+}
+
+// lookupSubjectsForResource dispatches the lookup for a single resource, publishing its results
+// to the stream and caching them once the lookup completes.
+func (cd *Dispatcher) lookupSubjectsForResource(ctx context.Context, resourceReq *v1.DispatchLookupSubjectsRequest, stream dispatch.LookupSubjectsStream) ([][]byte, error) {
+	var (
+		mu      sync.Mutex
+		results = [][]byte{}
+	)
+	wrapped := &dispatch.WrappedDispatchStream[*v1.DispatchLookupSubjectsResponse]{
+		Stream: stream,
+		Ctx:    ctx,
+		Processor: func(result *v1.DispatchLookupSubjectsResponse) (*v1.DispatchLookupSubjectsResponse, bool, error) {
+			adjustedResult := result.CloneVT()
+			adjustedResult.Metadata.CachedDispatchCount = adjustedResult.Metadata.DispatchCount
+			adjustedResult.Metadata.DispatchCount = 0
+			adjustedResult.Metadata.DebugInfo = nil
+
+			adjustedBytes, err := adjustedResult.MarshalVT()
+			if err != nil {
+				return &v1.DispatchLookupSubjectsResponse{Metadata: &v1.ResponseMeta{}}, false, err
+			}
+
+			mu.Lock()
+			results = append(results, adjustedBytes)
+			mu.Unlock()
+
+			return result, true, nil
+		},
+	}
+
+	if err := cd.d.DispatchLookupSubjects(resourceReq, wrapped); err != nil {
+		return nil, err
+	}
+
+	resourceKey, err := cd.keyHandler.LookupSubjectsCacheKey(ctx, resourceReq)
+	if err != nil {
+		return nil, err
+	}
+
+	// Resources for which no subjects were found are cached with no results.
+	var size int64
+	for _, slice := range results {
+		size += sliceSize(slice)
+	}
+	cd.set(resourceKey, results, size, &v1.DispatchPeerCacheLookupRequest{
+		Request: &v1.DispatchPeerCacheLookupRequest_LookupSubjects{LookupSubjects: resourceReq},
+	})
+	return results, nil
+}
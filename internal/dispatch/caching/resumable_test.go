@@ -0,0 +1,319 @@
+package caching
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/dispatch"
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// errLookupInterrupted is returned by the lookupDelegate for interrupted lookups.
+var errLookupInterrupted = errors.New("lookup interrupted")
+
+// lookupDelegate is a delegate which finds a fixed set of resources and subjects, recording the
+// lookups dispatched to it.
+type lookupDelegate struct {
+	delegateDispatchMock
+
+	resourceIDs        []string
+	subjectsByResource map[string][]string
+
+	// interruptAfter, if positive, interrupts LookupResources3 lookups once that many items
+	// have been published.
+	interruptAfter int
+
+	// interruptedResources are the resources whose LookupSubjects lookups are interrupted.
+	interruptedResources []string
+
+	mu                sync.Mutex
+	lr3Requests       []*v1.DispatchLookupResources3Request
+	subjectsRequested [][]string
+}
+
+func (ld *lookupDelegate) DispatchLookupResources3(req *v1.DispatchLookupResources3Request, stream dispatch.LookupResources3Stream) error {
+	ld.mu.Lock()
+	ld.lr3Requests = append(ld.lr3Requests, req)
+	ld.mu.Unlock()
+
+	// Cursors are the index of the next resource.
+	start := 0
+	if len(req.OptionalCursor) > 0 {
+		parsed, err := strconv.Atoi(req.OptionalCursor[0])
+		if err != nil {
+			return err
+		}
+		start = parsed
+	}
+
+	items := make([]*v1.LR3Item, 0, req.OptionalLimit)
+	for index := start; index < len(ld.resourceIDs) && len(items) < int(req.OptionalLimit); index++ {
+		items = append(items, &v1.LR3Item{
+			ResourceId:                  ld.resourceIDs[index],
+			AfterResponseCursorSections: []string{strconv.Itoa(index + 1)},
+		})
+	}
+
+	if ld.interruptAfter > 0 && len(items) > ld.interruptAfter {
+		if err := stream.Publish(&v1.DispatchLookupResources3Response{Items: items[:ld.interruptAfter]}); err != nil {
+			return err
+		}
+		return errLookupInterrupted
+	}
+	return stream.Publish(&v1.DispatchLookupResources3Response{Items: items})
+}
+
+func (ld *lookupDelegate) DispatchLookupSubjects(req *v1.DispatchLookupSubjectsRequest, stream dispatch.LookupSubjectsStream) error {
+	ld.mu.Lock()
+	ld.subjectsRequested = append(ld.subjectsRequested, req.ResourceIds)
+	ld.mu.Unlock()
+
+	found := make(map[string]*v1.FoundSubjects)
+	for _, resourceID := range req.ResourceIds {
+		if slices.Contains(ld.interruptedResources, resourceID) {
+			return errLookupInterrupted
+		}
+
+		for _, subjectID := range ld.subjectsByResource[resourceID] {
+			if found[resourceID] == nil {
+				found[resourceID] = &v1.FoundSubjects{}
+			}
+			found[resourceID].FoundSubjects = append(found[resourceID].FoundSubjects, &v1.FoundSubject{SubjectId: subjectID})
+		}
+	}
+	return stream.Publish(&v1.DispatchLookupSubjectsResponse{
+		FoundSubjectsByResourceId: found,
+		Metadata:                  &v1.ResponseMeta{DispatchCount: 1},
+	})
+}
+
+func newResumableDispatcher(t *testing.T, delegate *lookupDelegate) *Dispatcher {
+	dispatcher, err := NewCachingDispatcher(DispatchTestCache(t), false, "", nil)
+	require.NoError(t, err)
+	dispatcher.EnableResumableLookups()
+	dispatcher.SetDelegate(delegate)
+	t.Cleanup(func() {
+		_ = dispatcher.Close()
+	})
+	return dispatcher
+}
+
+func TestResumableLookupResources3(t *testing.T) {
+	delegate := &lookupDelegate{delegateDispatchMock: delegateDispatchMock{&mock.Mock{}}}
+	for index := range 8 {
+		delegate.resourceIDs = append(delegate.resourceIDs, fmt.Sprintf("doc%d", index))
+	}
+	dispatcher := newResumableDispatcher(t, delegate)
+
+	lookup := func(cursor []string, limit uint32) []string {
+		stream := dispatch.NewCollectingDispatchStream[*v1.DispatchLookupResources3Response](t.Context())
+		err := dispatcher.DispatchLookupResources3(&v1.DispatchLookupResources3Request{
+			ResourceRelation: RR("document", "view"),
+			SubjectRelation:  RR("user", "..."),
+			SubjectIds:       []string{"tom"},
+			TerminalSubject:  tuple.MustParseSubjectONR("user:tom").ToCoreONR(),
+			Metadata: &v1.ResolverMeta{
+				AtRevision:     "1234",
+				DepthRemaining: 50,
+			},
+			OptionalCursor: cursor,
+			OptionalLimit:  limit,
+		}, stream)
+		require.NoError(t, err)
+		dispatcher.c.Wait()
+
+		var resourceIDs []string
+		for _, response := range stream.Results() {
+			for _, item := range response.Items {
+				resourceIDs = append(resourceIDs, item.ResourceId)
+			}
+		}
+		return resourceIDs
+	}
+
+	requireDispatched := func(expectedCursors ...[]string) {
+		t.Helper()
+		dispatched := make([][]string, 0, len(delegate.lr3Requests))
+		for _, req := range delegate.lr3Requests {
+			dispatched = append(dispatched, req.OptionalCursor)
+		}
+		require.Equal(t, expectedCursors, dispatched)
+	}
+
+	// The first page is dispatched and then cached.
+	require.Equal(t, []string{"doc0", "doc1", "doc2"}, lookup(nil, 3))
+	require.Equal(t, []string{"doc0", "doc1", "doc2"}, lookup(nil, 3))
+	requireDispatched(nil)
+
+	// The second page begins at the end of the prefix, so is dispatched from its cursor.
+	require.Equal(t, []string{"doc3", "doc4", "doc5"}, lookup([]string{"3"}, 3))
+	requireDispatched(nil, []string{"3"})
+
+	// Pages within the prefix, whatever their limit, are served from it.
+	require.Equal(t, []string{"doc0", "doc1", "doc2", "doc3", "doc4"}, lookup(nil, 5))
+	require.Equal(t, []string{"doc2", "doc3"}, lookup([]string{"2"}, 2))
+	requireDispatched(nil, []string{"3"})
+
+	// Pages extending beyond the prefix are resumed from its end, finding the remaining items.
+	require.Equal(t, []string{"doc4", "doc5", "doc6", "doc7"}, lookup([]string{"4"}, 10))
+	requireDispatched(nil, []string{"3"}, []string{"6"})
+	require.Equal(t, uint32(8), delegate.lr3Requests[2].OptionalLimit)
+
+	// Once all items have been found, no further lookups are dispatched.
+	require.Equal(t, delegate.resourceIDs, lookup(nil, 100))
+	require.Empty(t, lookup([]string{"8"}, 3))
+	requireDispatched(nil, []string{"3"}, []string{"6"})
+}
+
+func TestResumableLookupResources3Interrupted(t *testing.T) {
+	delegate := &lookupDelegate{delegateDispatchMock: delegateDispatchMock{&mock.Mock{}}, interruptAfter: 2}
+	for index := range 8 {
+		delegate.resourceIDs = append(delegate.resourceIDs, fmt.Sprintf("doc%d", index))
+	}
+	dispatcher := newResumableDispatcher(t, delegate)
+
+	lookup := func(limit uint32) ([]string, error) {
+		stream := dispatch.NewCollectingDispatchStream[*v1.DispatchLookupResources3Response](t.Context())
+		err := dispatcher.DispatchLookupResources3(&v1.DispatchLookupResources3Request{
+			ResourceRelation: RR("document", "view"),
+			SubjectRelation:  RR("user", "..."),
+			SubjectIds:       []string{"tom"},
+			TerminalSubject:  tuple.MustParseSubjectONR("user:tom").ToCoreONR(),
+			Metadata: &v1.ResolverMeta{
+				AtRevision:     "1234",
+				DepthRemaining: 50,
+			},
+			OptionalLimit: limit,
+		}, stream)
+		dispatcher.c.Wait()
+
+		var resourceIDs []string
+		for _, response := range stream.Results() {
+			for _, item := range response.Items {
+				resourceIDs = append(resourceIDs, item.ResourceId)
+			}
+		}
+		return resourceIDs, err
+	}
+
+	// The items found before the lookup was interrupted are published and cached.
+	found, err := lookup(5)
+	require.ErrorIs(t, err, errLookupInterrupted)
+	require.Equal(t, []string{"doc0", "doc1"}, found)
+
+	// The lookup is then resumed from the end of the items cached.
+	delegate.interruptAfter = 0
+	found, err = lookup(5)
+	require.NoError(t, err)
+	require.Equal(t, []string{"doc0", "doc1", "doc2", "doc3", "doc4"}, found)
+
+	require.Len(t, delegate.lr3Requests, 2)
+	require.Nil(t, delegate.lr3Requests[0].OptionalCursor)
+	require.Equal(t, []string{"2"}, delegate.lr3Requests[1].OptionalCursor)
+	require.Equal(t, uint32(3), delegate.lr3Requests[1].OptionalLimit)
+}
+
+func TestPerResourceLookupSubjects(t *testing.T) {
+	delegate := &lookupDelegate{
+		delegateDispatchMock: delegateDispatchMock{&mock.Mock{}},
+		subjectsByResource: map[string][]string{
+			"doc1": {"tom", "sarah"},
+			"doc3": {"fred"},
+		},
+	}
+	dispatcher := newResumableDispatcher(t, delegate)
+
+	lookup := func(resourceIDs ...string) map[string][]string {
+		found, err := lookupSubjects(t, dispatcher, resourceIDs...)
+		require.NoError(t, err)
+		return found
+	}
+
+	require.Equal(t, map[string][]string{"doc1": {"tom", "sarah"}}, lookup("doc1", "doc2"))
+	requireSubjectsDispatched(t, delegate, "doc1", "doc2")
+
+	// Only the resource whose results have not been cached is dispatched, including for the
+	// resource for which no subjects were found.
+	require.Equal(t, map[string][]string{"doc1": {"tom", "sarah"}, "doc3": {"fred"}}, lookup("doc1", "doc2", "doc3"))
+	requireSubjectsDispatched(t, delegate, "doc1", "doc2", "doc3")
+
+	// Lookups of any of the resources are then served from the cache.
+	require.Empty(t, lookup("doc2"))
+	require.Equal(t, map[string][]string{"doc1": {"tom", "sarah"}, "doc3": {"fred"}}, lookup("doc3", "doc1"))
+	require.Equal(t, map[string][]string{"doc1": {"tom", "sarah"}, "doc3": {"fred"}}, lookup("doc1", "doc2", "doc3"))
+	requireSubjectsDispatched(t, delegate, "doc1", "doc2", "doc3")
+}
+
+// lookupSubjects dispatches a LookupSubjects lookup for the resources, returning the subjects
+// found for each of them.
+func lookupSubjects(t *testing.T, dispatcher *Dispatcher, resourceIDs ...string) (map[string][]string, error) {
+	stream := dispatch.NewCollectingDispatchStream[*v1.DispatchLookupSubjectsResponse](t.Context())
+	err := dispatcher.DispatchLookupSubjects(&v1.DispatchLookupSubjectsRequest{
+		ResourceRelation: RR("document", "view"),
+		ResourceIds:      resourceIDs,
+		SubjectRelation:  RR("user", "..."),
+		Metadata: &v1.ResolverMeta{
+			AtRevision:     "1234",
+			DepthRemaining: 50,
+		},
+	}, stream)
+	dispatcher.c.Wait()
+
+	found := make(map[string][]string)
+	for _, response := range stream.Results() {
+		for resourceID, foundSubjects := range response.FoundSubjectsByResourceId {
+			for _, subject := range foundSubjects.FoundSubjects {
+				found[resourceID] = append(found[resourceID], subject.SubjectId)
+			}
+		}
+	}
+	return found, err
+}
+
+// requireSubjectsDispatched requires the resources dispatched to the delegate by LookupSubjects
+// lookups to be those expected, in any order.
+func requireSubjectsDispatched(t *testing.T, delegate *lookupDelegate, expected ...string) {
+	t.Helper()
+
+	delegate.mu.Lock()
+	defer delegate.mu.Unlock()
+
+	var dispatched []string
+	for _, resourceIDs := range delegate.subjectsRequested {
+		dispatched = append(dispatched, resourceIDs...)
+	}
+	slices.Sort(dispatched)
+	require.Equal(t, expected, dispatched)
+}
+
+func TestPerResourceLookupSubjectsInterrupted(t *testing.T) {
+	delegate := &lookupDelegate{
+		delegateDispatchMock: delegateDispatchMock{&mock.Mock{}},
+		subjectsByResource: map[string][]string{
+			"doc1": {"tom", "sarah"},
+			"doc3": {"fred"},
+		},
+		interruptedResources: []string{"doc2"},
+	}
+	dispatcher := newResumableDispatcher(t, delegate)
+
+	_, err := lookupSubjects(t, dispatcher, "doc1", "doc2", "doc3")
+	require.ErrorIs(t, err, errLookupInterrupted)
+	requireSubjectsDispatched(t, delegate, "doc1", "doc2", "doc3")
+
+	// The results of the resources whose lookups completed were cached, so only the resource
+	// whose lookup was interrupted is dispatched again.
+	delegate.interruptedResources = nil
+	found, err := lookupSubjects(t, dispatcher, "doc1", "doc2", "doc3")
+	require.NoError(t, err)
+	require.Equal(t, map[string][]string{"doc1": {"tom", "sarah"}, "doc3": {"fred"}}, found)
+	requireSubjectsDispatched(t, delegate, "doc1", "doc2", "doc2", "doc3")
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/authzed/spicedb/internal/dispatch/caching"
 	"github.com/authzed/spicedb/internal/dispatch/graph"
 	"github.com/authzed/spicedb/internal/dispatch/keys"
+	"github.com/authzed/spicedb/internal/dispatch/limiter"
+	"github.com/authzed/spicedb/internal/dispatch/remote"
 	log "github.com/authzed/spicedb/internal/logging"
 	"github.com/authzed/spicedb/pkg/cache"
 	caveattypes "github.com/authzed/spicedb/pkg/caveats/types"
@@ -21,11 +23,15 @@ type optionState struct {
 	prometheusSubsystem          string
 	cache                        cache.Cache[keys.DispatchCacheKey, any]
 	concurrencyLimits            graph.ConcurrencyLimits
+	concurrencyLimiter           *limiter.AdaptiveLimiter
 	remoteDispatchTimeout        time.Duration
 	dispatchChunkSize            uint16
 	caveatTypeSet                *caveattypes.TypeSet
 	relationshipChunkCacheConfig *cache.Config
 	relationshipChunkCache       cache.Cache[cache.StringKey, any]
+	invalidationTracker          *caching.InvalidationTracker
+	peerCache                    *remote.PeerCache
+	resumableLookups             bool
 }
 
 // MetricsEnabled enables issuing prometheus metrics
@@ -56,6 +62,14 @@ func ConcurrencyLimits(limits graph.ConcurrencyLimits) Option {
 	}
 }
 
+// ConcurrencyLimiter sets the limiter used to adaptively limit the concurrency of each operation,
+// in place of the concurrency limits. If not specified, the concurrency limits are used.
+func ConcurrencyLimiter(concurrencyLimiter *limiter.AdaptiveLimiter) Option {
+	return func(state *optionState) {
+		state.concurrencyLimiter = concurrencyLimiter
+	}
+}
+
 // DispatchChunkSize sets the maximum number of items to be dispatched in a single dispatch request
 func DispatchChunkSize(dispatchChunkSize uint16) Option {
 	return func(state *optionState) {
@@ -78,6 +92,31 @@ func RelationshipChunkCacheConfig(config *cache.Config) Option {
 	}
 }
 
+// InvalidationTracker sets the tracker of changes used to carry cached check results forward to
+// other revisions. If not specified, results are only cached for the revision at which they
+// were computed.
+func InvalidationTracker(tracker *caching.InvalidationTracker) Option {
+	return func(state *optionState) {
+		state.invalidationTracker = tracker
+	}
+}
+
+// PeerCache sets the cache of the peers of the node, consulted for results not found in the
+// cache of the cluster dispatcher. If not specified, only the local cache is consulted.
+func PeerCache(peerCache *remote.PeerCache) Option {
+	return func(state *optionState) {
+		state.peerCache = peerCache
+	}
+}
+
+// ResumableLookups enables caching lookups such that they can be resumed from the results
+// previously found, rather than only when they have completed.
+func ResumableLookups(enabled bool) Option {
+	return func(state *optionState) {
+		state.resumableLookups = enabled
+	}
+}
+
 // RelationshipChunkCache sets the cache for LR3 relationship chunks.
 func RelationshipChunkCache(cache cache.Cache[cache.StringKey, any]) Option {
 	return func(state *optionState) {
@@ -135,6 +174,7 @@ func NewClusterDispatcher(dispatch dispatch.Dispatcher, options ...Option) (disp
 
 	params := graph.DispatcherParameters{
 		ConcurrencyLimits:      opts.concurrencyLimits,
+		ConcurrencyLimiter:     opts.concurrencyLimiter,
 		TypeSet:                cts,
 		DispatchChunkSize:      opts.dispatchChunkSize,
 		RelationshipChunkCache: relationshipChunkCache,
@@ -152,6 +192,15 @@ func NewClusterDispatcher(dispatch dispatch.Dispatcher, options ...Option) (disp
 	if err != nil {
 		return nil, err
 	}
+	if opts.invalidationTracker != nil {
+		cachingClusterDispatch.SetInvalidationTracker(opts.invalidationTracker)
+	}
+	if opts.resumableLookups {
+		cachingClusterDispatch.EnableResumableLookups()
+	}
+	if opts.peerCache != nil {
+		cachingClusterDispatch.SetPeerCache(opts.peerCache)
+	}
 	cachingClusterDispatch.SetDelegate(clusterDispatch)
 	return cachingClusterDispatch, nil
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/authzed/spicedb/internal/dispatch/caching"
 	"github.com/authzed/spicedb/internal/dispatch/graph"
 	"github.com/authzed/spicedb/internal/dispatch/keys"
+	"github.com/authzed/spicedb/internal/dispatch/limiter"
 	"github.com/authzed/spicedb/internal/dispatch/remote"
 	"github.com/authzed/spicedb/internal/dispatch/singleflight"
 	"github.com/authzed/spicedb/internal/grpchelpers"
@@ -35,6 +36,7 @@ type optionState struct {
 	grpcDialOpts                                 []grpc.DialOption
 	cache                                        cache.Cache[keys.DispatchCacheKey, any]
 	concurrencyLimits                            graph.ConcurrencyLimits
+	concurrencyLimiter                           *limiter.AdaptiveLimiter
 	remoteDispatchTimeout                        time.Duration
 	secondaryUpstreamAddrs                       map[string]string
 	secondaryUpstreamExprs                       map[string]string
@@ -44,6 +46,9 @@ type optionState struct {
 	caveatTypeSet                                *caveattypes.TypeSet
 	relationshipChunkCacheConfig                 *cache.Config
 	relationshipChunkCache                       cache.Cache[cache.StringKey, any]
+	invalidationTracker                          *caching.InvalidationTracker
+	peerCache                                    *remote.PeerCache
+	resumableLookups                             bool
 }
 
 // MetricsEnabled enables issuing prometheus metrics
@@ -132,6 +137,14 @@ func ConcurrencyLimits(limits graph.ConcurrencyLimits) Option {
 	}
 }
 
+// ConcurrencyLimiter sets the limiter used to adaptively limit the concurrency of each operation,
+// in place of the concurrency limits. If not specified, the concurrency limits are used.
+func ConcurrencyLimiter(concurrencyLimiter *limiter.AdaptiveLimiter) Option {
+	return func(state *optionState) {
+		state.concurrencyLimiter = concurrencyLimiter
+	}
+}
+
 // DispatchChunkSize sets the maximum number of items to be dispatched in a single dispatch request
 func DispatchChunkSize(dispatchChunkSize uint16) Option {
 	return func(state *optionState) {
@@ -146,6 +159,31 @@ func RelationshipChunkCacheConfig(config *cache.Config) Option {
 	}
 }
 
+// InvalidationTracker sets the tracker of changes used to carry cached check results forward to
+// other revisions. If not specified, results are only cached for the revision at which they
+// were computed.
+func InvalidationTracker(tracker *caching.InvalidationTracker) Option {
+	return func(state *optionState) {
+		state.invalidationTracker = tracker
+	}
+}
+
+// PeerCache sets the peer cache to be bound to the connection to the upstream, for use by the
+// cluster dispatcher. It is only bound if an upstream is specified.
+func PeerCache(peerCache *remote.PeerCache) Option {
+	return func(state *optionState) {
+		state.peerCache = peerCache
+	}
+}
+
+// ResumableLookups enables caching lookups such that they can be resumed from the results
+// previously found, rather than only when they have completed.
+func ResumableLookups(enabled bool) Option {
+	return func(state *optionState) {
+		state.resumableLookups = enabled
+	}
+}
+
 // RelationshipChunkCache sets the cache for LR3 relationship chunks.
 func RelationshipChunkCache(cache cache.Cache[cache.StringKey, any]) Option {
 	return func(state *optionState) {
@@ -195,6 +233,12 @@ func NewDispatcher(options ...Option) (dispatch.Dispatcher, error) {
 	if err != nil {
 		return nil, err
 	}
+	if opts.invalidationTracker != nil {
+		cachingRedispatch.SetInvalidationTracker(opts.invalidationTracker)
+	}
+	if opts.resumableLookups {
+		cachingRedispatch.EnableResumableLookups()
+	}
 
 	chunkSize := opts.dispatchChunkSize
 	if chunkSize == 0 {
@@ -229,6 +273,7 @@ func NewDispatcher(options ...Option) (dispatch.Dispatcher, error) {
 
 	params := graph.DispatcherParameters{
 		ConcurrencyLimits:      opts.concurrencyLimits,
+		ConcurrencyLimiter:     opts.concurrencyLimiter,
 		TypeSet:                cts,
 		DispatchChunkSize:      chunkSize,
 		RelationshipChunkCache: relationshipChunkCache,
@@ -305,6 +350,10 @@ func NewDispatcher(options ...Option) (dispatch.Dispatcher, error) {
 			return nil, err
 		}
 		redispatch = singleflight.New(re, &keys.CanonicalKeyHandler{})
+
+		if opts.peerCache != nil {
+			opts.peerCache.SetClient(v1.NewDispatchServiceClient(conn), &keys.CanonicalKeyHandler{})
+		}
 	}
 
 	cachingRedispatch.SetDelegate(redispatch)
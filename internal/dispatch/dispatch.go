@@ -27,6 +27,7 @@ type Dispatcher interface {
 	LookupSubjects
 	LookupResources2
 	LookupResources3
+	QueryPlan
 
 	// Close closes the dispatcher.
 	Close() error
@@ -79,6 +80,19 @@ type LookupSubjects interface {
 	) error
 }
 
+// QueryPlan interface describes just the methods required to dispatch subtrees of query plans.
+type QueryPlan interface {
+	// DispatchQueryPlan evaluates a single serialized query plan subtree and returns the paths found.
+	DispatchQueryPlan(ctx context.Context, req *v1.DispatchQueryPlanRequest) (*v1.DispatchQueryPlanResponse, error)
+}
+
+// PeerCacheLookup interface describes the method used by peers to look up the results cached
+// by a dispatcher, without computing them if not found.
+type PeerCacheLookup interface {
+	// LookupPeerCache returns the result cached for the request, if any.
+	LookupPeerCache(ctx context.Context, req *v1.DispatchPeerCacheLookupRequest) (*v1.DispatchPeerCacheLookupResponse, error)
+}
+
 // DispatchableRequest is an interface for requests.
 type DispatchableRequest interface {
 	zerolog.LogObjectMarshaler
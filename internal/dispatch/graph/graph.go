@@ -14,6 +14,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/authzed/spicedb/internal/dispatch"
+	"github.com/authzed/spicedb/internal/dispatch/limiter"
 	"github.com/authzed/spicedb/internal/graph"
 	log "github.com/authzed/spicedb/internal/logging"
 	datastoremw "github.com/authzed/spicedb/internal/middleware/datastore"
@@ -85,6 +86,7 @@ func SharedConcurrencyLimits(concurrencyLimit uint16) ConcurrencyLimits {
 // DispatcherParameters are the parameters for a dispatcher.
 type DispatcherParameters struct {
 	ConcurrencyLimits      ConcurrencyLimits
+	ConcurrencyLimiter     *limiter.AdaptiveLimiter
 	DispatchChunkSize      uint16
 	TypeSet                *caveattypes.TypeSet
 	RelationshipChunkCache cache.Cache[cache.StringKey, any]
@@ -146,7 +148,7 @@ func NewLocalOnlyDispatcher(parameters DispatcherParameters) (dispatch.Dispatche
 		return nil, err
 	}
 
-	d := &localDispatcher{}
+	d := &localDispatcher{concurrencyLimiter: parameters.ConcurrencyLimiter}
 
 	typeSet := parameters.TypeSet
 	concurrencyLimits := limitsOrDefaults(parameters.ConcurrencyLimits, defaultConcurrencyLimit)
@@ -156,6 +158,7 @@ func NewLocalOnlyDispatcher(parameters DispatcherParameters) (dispatch.Dispatche
 	d.expander = graph.NewConcurrentExpander(d)
 	d.lookupSubjectsHandler = graph.NewConcurrentLookupSubjects(d, concurrencyLimits.LookupSubjects, chunkSize)
 	d.lookupResourcesHandler2 = graph.NewCursoredLookupResources2(d, d, typeSet, concurrencyLimits.LookupResources, chunkSize)
+	d.queryPlanEvaluator = graph.NewQueryPlanEvaluator(d, typeSet, concurrencyLimits.Check)
 
 	lr3, err := graph.NewCursoredLookupResources3(d, d, typeSet, concurrencyLimits.LookupResources, chunkSize, parameters.RelationshipChunkCache)
 	if err != nil {
@@ -181,6 +184,7 @@ func NewDispatcher(redispatcher dispatch.Dispatcher, parameters DispatcherParame
 	expander := graph.NewConcurrentExpander(redispatcher)
 	lookupSubjectsHandler := graph.NewConcurrentLookupSubjects(redispatcher, concurrencyLimits.LookupSubjects, chunkSize)
 	lookupResourcesHandler2 := graph.NewCursoredLookupResources2(redispatcher, redispatcher, typeSet, concurrencyLimits.LookupResources, chunkSize)
+	queryPlanEvaluator := graph.NewQueryPlanEvaluator(redispatcher, typeSet, concurrencyLimits.Check)
 	lr3, err := graph.NewCursoredLookupResources3(redispatcher, redispatcher, typeSet, concurrencyLimits.ReachableResources, chunkSize, parameters.RelationshipChunkCache)
 	if err != nil {
 		return nil, err
@@ -192,6 +196,8 @@ func NewDispatcher(redispatcher dispatch.Dispatcher, parameters DispatcherParame
 		lookupSubjectsHandler:   lookupSubjectsHandler,
 		lookupResourcesHandler2: lookupResourcesHandler2,
 		lookupResourcesHandler3: lr3,
+		queryPlanEvaluator:      queryPlanEvaluator,
+		concurrencyLimiter:      parameters.ConcurrencyLimiter,
 	}, nil
 }
 
@@ -201,6 +207,20 @@ type localDispatcher struct {
 	lookupSubjectsHandler   *graph.ConcurrentLookupSubjects
 	lookupResourcesHandler2 *graph.CursoredLookupResources2
 	lookupResourcesHandler3 *graph.CursoredLookupResources3
+	queryPlanEvaluator      *graph.QueryPlanEvaluator
+
+	// concurrencyLimiter, if set, adaptively limits the concurrency of the operations.
+	concurrencyLimiter *limiter.AdaptiveLimiter
+}
+
+// limitConcurrency returns the context under which an operation is performed, carrying its
+// concurrency limit if the concurrency of operations is adaptively limited, along with a function
+// to be invoked once the operation has completed.
+func (ld *localDispatcher) limitConcurrency(ctx context.Context) (context.Context, func()) {
+	if ld.concurrencyLimiter == nil {
+		return ctx, func() {}
+	}
+	return ld.concurrencyLimiter.Begin(ctx)
 }
 
 func (ld *localDispatcher) loadNamespace(ctx context.Context, nsName string, revision datastore.Revision) (*core.NamespaceDefinition, error) {
@@ -251,6 +271,9 @@ func (ld *localDispatcher) DispatchCheck(ctx context.Context, req *v1.DispatchCh
 	))
 	defer span.End()
 
+	ctx, done := ld.limitConcurrency(ctx)
+	defer done()
+
 	if err := dispatch.CheckDepth(ctx, req); err != nil {
 		if req.Debug != v1.DispatchCheckRequest_ENABLE_BASIC_DEBUGGING {
 			return &v1.DispatchCheckResponse{
@@ -375,6 +398,9 @@ func (ld *localDispatcher) DispatchLookupResources2(
 	))
 	defer span.End()
 
+	ctx, done := ld.limitConcurrency(ctx)
+	defer done()
+
 	if err := dispatch.CheckDepth(ctx, req); err != nil {
 		return err
 	}
@@ -408,6 +434,9 @@ func (ld *localDispatcher) DispatchLookupResources3(
 	))
 	defer span.End()
 
+	ctx, done := ld.limitConcurrency(ctx)
+	defer done()
+
 	if err := dispatch.CheckDepth(ctx, req); err != nil {
 		return err
 	}
@@ -445,6 +474,9 @@ func (ld *localDispatcher) DispatchLookupSubjects(
 	))
 	defer span.End()
 
+	ctx, done := ld.limitConcurrency(ctx)
+	defer done()
+
 	if err := dispatch.CheckDepth(ctx, req); err != nil {
 		return err
 	}
@@ -463,6 +495,38 @@ func (ld *localDispatcher) DispatchLookupSubjects(
 	)
 }
 
+// DispatchQueryPlan implements dispatch.QueryPlan interface
+func (ld *localDispatcher) DispatchQueryPlan(ctx context.Context, req *v1.DispatchQueryPlanRequest) (*v1.DispatchQueryPlanResponse, error) {
+	nodeID := nodeid.Get()
+	ctx, span := tracer.Start(ctx, "DispatchQueryPlan", trace.WithAttributes(
+		attribute.String(otelconv.AttrDispatchResourceType, req.ResourceType),
+		attribute.StringSlice(otelconv.AttrDispatchResourceIds, req.ResourceIds),
+		attribute.String(otelconv.AttrDispatchNodeID, nodeID),
+	))
+	defer span.End()
+
+	ctx, done := ld.limitConcurrency(ctx)
+	defer done()
+
+	if err := dispatch.CheckDepth(ctx, req); err != nil {
+		return &v1.DispatchQueryPlanResponse{Metadata: emptyMetadata}, rewriteError(ctx, err)
+	}
+
+	revision, err := ld.parseRevision(ctx, req.Metadata.AtRevision)
+	if err != nil {
+		return &v1.DispatchQueryPlanResponse{Metadata: emptyMetadata}, rewriteError(ctx, err)
+	}
+
+	resp, err := ld.queryPlanEvaluator.Evaluate(ctx, graph.ValidatedQueryPlanRequest{
+		DispatchQueryPlanRequest: req,
+		Revision:                 revision,
+	})
+	if err != nil {
+		return &v1.DispatchQueryPlanResponse{Metadata: emptyMetadata}, rewriteError(ctx, err)
+	}
+	return resp, nil
+}
+
 func (ld *localDispatcher) Close() error {
 	return nil
 }
@@ -1,6 +1,8 @@
 package graph
 
 import (
+	"context"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -9,7 +11,20 @@ import (
 
 	"github.com/authzed/grpcutil"
 
+	"github.com/authzed/spicedb/internal/datastore/dsfortesting"
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	"github.com/authzed/spicedb/internal/dispatch"
+	"github.com/authzed/spicedb/internal/dispatch/limiter"
 	"github.com/authzed/spicedb/internal/graph"
+	log "github.com/authzed/spicedb/internal/logging"
+	datastoremw "github.com/authzed/spicedb/internal/middleware/datastore"
+	"github.com/authzed/spicedb/internal/testfixtures"
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/query"
+	"github.com/authzed/spicedb/pkg/schema/v2"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+	"github.com/authzed/spicedb/pkg/tuple"
 )
 
 func TestUnwrapStatusError(t *testing.T) {
@@ -60,3 +75,218 @@ func TestSharedConcurrencyLimits(t *testing.T) {
 	require.Equal(t, uint16(42), withDefaults.LookupSubjects)
 	require.Equal(t, uint16(42), withDefaults.ReachableResources)
 }
+
+// limitRecordingDispatcher records the concurrency limits carried by the contexts of the
+// operations redispatched to it, before delegating them.
+type limitRecordingDispatcher struct {
+	dispatch.Dispatcher
+
+	mu                sync.Mutex
+	limits            []uint16
+	unlimited         int
+	queryPlanRequests []*v1.DispatchQueryPlanRequest
+}
+
+func (lrd *limitRecordingDispatcher) record(ctx context.Context) {
+	lrd.mu.Lock()
+	defer lrd.mu.Unlock()
+
+	if limit, ok := graph.ConcurrencyLimitFromContext(ctx); ok {
+		lrd.limits = append(lrd.limits, limit)
+	} else {
+		lrd.unlimited++
+	}
+}
+
+func (lrd *limitRecordingDispatcher) reset() {
+	lrd.mu.Lock()
+	defer lrd.mu.Unlock()
+
+	lrd.limits = nil
+	lrd.unlimited = 0
+	lrd.queryPlanRequests = nil
+}
+
+func (lrd *limitRecordingDispatcher) DispatchCheck(ctx context.Context, req *v1.DispatchCheckRequest) (*v1.DispatchCheckResponse, error) {
+	lrd.record(ctx)
+	return lrd.Dispatcher.DispatchCheck(ctx, req)
+}
+
+func (lrd *limitRecordingDispatcher) DispatchLookupResources2(req *v1.DispatchLookupResources2Request, stream dispatch.LookupResources2Stream) error {
+	lrd.record(stream.Context())
+	return lrd.Dispatcher.DispatchLookupResources2(req, stream)
+}
+
+func (lrd *limitRecordingDispatcher) DispatchLookupResources3(req *v1.DispatchLookupResources3Request, stream dispatch.LookupResources3Stream) error {
+	lrd.record(stream.Context())
+	return lrd.Dispatcher.DispatchLookupResources3(req, stream)
+}
+
+func (lrd *limitRecordingDispatcher) DispatchLookupSubjects(req *v1.DispatchLookupSubjectsRequest, stream dispatch.LookupSubjectsStream) error {
+	lrd.record(stream.Context())
+	return lrd.Dispatcher.DispatchLookupSubjects(req, stream)
+}
+
+func (lrd *limitRecordingDispatcher) DispatchQueryPlan(ctx context.Context, req *v1.DispatchQueryPlanRequest) (*v1.DispatchQueryPlanResponse, error) {
+	lrd.record(ctx)
+
+	lrd.mu.Lock()
+	lrd.queryPlanRequests = append(lrd.queryPlanRequests, req)
+	lrd.mu.Unlock()
+
+	return lrd.Dispatcher.DispatchQueryPlan(ctx, req)
+}
+
+func TestConcurrencyLimiterWiring(t *testing.T) {
+	t.Parallel()
+
+	schemaText := `
+		definition user {}
+
+		definition org {
+			relation member: user
+		}
+
+		definition folder {
+			relation org: org
+			permission view = org->member
+		}
+
+		definition document {
+			relation parent: folder
+			permission view = parent->view
+		}`
+
+	rawDS, err := dsfortesting.NewMemDBDatastoreForTesting(0, 0, memdb.DisableGC)
+	require.NoError(t, err)
+
+	ds, revision := testfixtures.DatastoreFromSchemaAndTestRelationships(rawDS, schemaText, []tuple.Relationship{
+		tuple.MustParse("org:acme#member@user:tom"),
+		tuple.MustParse("folder:plans#org@org:acme"),
+		tuple.MustParse("document:masterplan#parent@folder:plans"),
+	}, require.New(t))
+
+	// The limit of the limiter is far below the concurrency limits of the dispatcher, so that
+	// it applies to each of the operations dispatched.
+	concurrencyLimiter := limiter.NewAdaptiveLimiter(3, 1, 3)
+	params := MustNewDefaultDispatcherParametersForTesting()
+	params.ConcurrencyLimits = SharedConcurrencyLimits(50)
+	params.ConcurrencyLimiter = concurrencyLimiter
+
+	recording := &limitRecordingDispatcher{}
+	dispatcher, err := NewDispatcher(recording, params)
+	require.NoError(t, err)
+	recording.Dispatcher = dispatcher
+	t.Cleanup(func() { _ = dispatcher.Close() })
+
+	ctx := log.Logger.WithContext(datastoremw.ContextWithHandle(t.Context()))
+	require.NoError(t, datastoremw.SetInContext(ctx, ds))
+
+	metadata := &v1.ResolverMeta{
+		AtRevision:     revision.String(),
+		DepthRemaining: 50,
+	}
+
+	compiled, err := compiler.Compile(compiler.InputSchema{
+		Source:       input.Source("schema"),
+		SchemaString: schemaText,
+	}, compiler.AllowUnprefixedObjectType())
+	require.NoError(t, err)
+
+	dsSchema, err := schema.BuildSchemaFromDefinitions(compiled.ObjectDefinitions, nil)
+	require.NoError(t, err)
+
+	it, err := query.BuildIteratorFromSchema(dsSchema, "document", "view")
+	require.NoError(t, err)
+
+	plan, err := query.SerializeIterator(it)
+	require.NoError(t, err)
+
+	tcs := []struct {
+		name     string
+		dispatch func() error
+	}{
+		{
+			name: "check",
+			dispatch: func() error {
+				_, err := dispatcher.DispatchCheck(ctx, &v1.DispatchCheckRequest{
+					ResourceRelation: RR("document", "view").ToCoreRR(),
+					ResourceIds:      []string{"masterplan"},
+					Subject:          tuple.CoreONR("user", "tom", tuple.Ellipsis),
+					Metadata:         metadata,
+				})
+				return err
+			},
+		},
+		{
+			name: "lookup resources 2",
+			dispatch: func() error {
+				return dispatcher.DispatchLookupResources2(&v1.DispatchLookupResources2Request{
+					ResourceRelation: RR("document", "view").ToCoreRR(),
+					SubjectRelation:  RR("user", tuple.Ellipsis).ToCoreRR(),
+					SubjectIds:       []string{"tom"},
+					TerminalSubject:  tuple.CoreONR("user", "tom", tuple.Ellipsis),
+					Metadata:         metadata,
+				}, dispatch.NewCollectingDispatchStream[*v1.DispatchLookupResources2Response](ctx))
+			},
+		},
+		{
+			name: "lookup resources 3",
+			dispatch: func() error {
+				return dispatcher.DispatchLookupResources3(&v1.DispatchLookupResources3Request{
+					ResourceRelation: RR("document", "view").ToCoreRR(),
+					SubjectRelation:  RR("user", tuple.Ellipsis).ToCoreRR(),
+					SubjectIds:       []string{"tom"},
+					TerminalSubject:  tuple.CoreONR("user", "tom", tuple.Ellipsis),
+					Metadata:         metadata,
+				}, dispatch.NewCollectingDispatchStream[*v1.DispatchLookupResources3Response](ctx))
+			},
+		},
+		{
+			name: "lookup subjects",
+			dispatch: func() error {
+				return dispatcher.DispatchLookupSubjects(&v1.DispatchLookupSubjectsRequest{
+					ResourceRelation: RR("document", "view").ToCoreRR(),
+					ResourceIds:      []string{"masterplan"},
+					SubjectRelation:  RR("user", tuple.Ellipsis).ToCoreRR(),
+					Metadata:         metadata,
+				}, dispatch.NewCollectingDispatchStream[*v1.DispatchLookupSubjectsResponse](ctx))
+			},
+		},
+		{
+			name: "query plan",
+			dispatch: func() error {
+				_, err := dispatcher.DispatchQueryPlan(ctx, &v1.DispatchQueryPlanRequest{
+					Metadata:          metadata,
+					Plan:              plan,
+					Operation:         v1.DispatchQueryPlanRequest_CHECK,
+					ResourceType:      "document",
+					ResourceIds:       []string{"masterplan"},
+					Subject:           tuple.CoreONR("user", "tom", tuple.Ellipsis),
+					MaxRecursionDepth: 5,
+				})
+				return err
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			recording.reset()
+			require.NoError(t, tc.dispatch())
+
+			// Each operation redispatched was dispatched under the limit of the limiter.
+			require.NotEmpty(t, recording.limits)
+			require.Zero(t, recording.unlimited)
+			for _, limit := range recording.limits {
+				require.LessOrEqual(t, limit, concurrencyLimiter.Limit())
+			}
+
+			// Subtrees of query plans are evaluated within the limit of the limiter.
+			for _, req := range recording.queryPlanRequests {
+				require.NotNil(t, req.ConcurrencyLimit)
+				require.LessOrEqual(t, *req.ConcurrencyLimit, uint32(concurrencyLimiter.Limit()))
+			}
+		})
+	}
+}
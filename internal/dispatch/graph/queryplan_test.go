@@ -0,0 +1,116 @@
+package graph
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/testfixtures"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/query"
+	"github.com/authzed/spicedb/pkg/schema/v2"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+func TestDispatchQueryPlanMatchesCheck(t *testing.T) {
+	t.Parallel()
+
+	objectDefs := []*core.NamespaceDefinition{testfixtures.UserNS.CloneVT(), testfixtures.FolderNS.CloneVT(), testfixtures.DocumentNS.CloneVT()}
+	dsSchema, err := schema.BuildSchemaFromDefinitions(objectDefs, nil)
+	require.NoError(t, err)
+
+	resourceIDs := []string{"masterplan", "companyplan", "specialplan", "ownerplan", "healthplan"}
+	for _, permission := range []string{"view", "edit", "view_and_edit"} {
+		it, err := query.BuildIteratorFromSchema(dsSchema, "document", permission)
+		require.NoError(t, err)
+
+		plan, err := query.SerializeIterator(it)
+		require.NoError(t, err)
+
+		for _, userID := range []string{"product_manager", "chief_financial_officer", "multiroleguy", "owner", "villain"} {
+			t.Run(permission+"/"+userID, func(t *testing.T) {
+				t.Parallel()
+
+				ctx, dispatcher, revision := newLocalDispatcher(t)
+				subject := tuple.CoreONR("user", userID, tuple.Ellipsis)
+
+				checkResp, err := dispatcher.DispatchCheck(ctx, &v1.DispatchCheckRequest{
+					ResourceRelation: RR("document", permission).ToCoreRR(),
+					ResourceIds:      resourceIDs,
+					Subject:          subject,
+					Metadata: &v1.ResolverMeta{
+						AtRevision:     revision.String(),
+						DepthRemaining: 50,
+					},
+				})
+				require.NoError(t, err)
+
+				resp, err := dispatcher.DispatchQueryPlan(ctx, &v1.DispatchQueryPlanRequest{
+					Metadata: &v1.ResolverMeta{
+						AtRevision:     revision.String(),
+						DepthRemaining: 50,
+					},
+					Plan:              plan,
+					Operation:         v1.DispatchQueryPlanRequest_CHECK,
+					ResourceType:      "document",
+					ResourceIds:       resourceIDs,
+					Subject:           subject,
+					MaxRecursionDepth: 5,
+				})
+				require.NoError(t, err)
+				require.GreaterOrEqual(t, resp.Metadata.DispatchCount, uint32(1))
+				require.GreaterOrEqual(t, resp.Metadata.DepthRequired, uint32(1))
+
+				found := make(map[string]bool)
+				for _, path := range resp.Paths {
+					found[path.Resource.ObjectId] = true
+				}
+
+				for _, resourceID := range resourceIDs {
+					result, ok := checkResp.ResultsByResourceId[resourceID]
+					isMember := ok && result.Membership == v1.ResourceCheckResult_MEMBER
+					require.Equal(t, isMember, found[resourceID], "mismatch for document:%s#%s", resourceID, permission)
+				}
+			})
+		}
+	}
+}
+
+func TestDispatchQueryPlanMaxDepth(t *testing.T) {
+	t.Parallel()
+
+	objectDefs := []*core.NamespaceDefinition{testfixtures.UserNS.CloneVT(), testfixtures.FolderNS.CloneVT(), testfixtures.DocumentNS.CloneVT()}
+	dsSchema, err := schema.BuildSchemaFromDefinitions(objectDefs, nil)
+	require.NoError(t, err)
+
+	it, err := query.BuildIteratorFromSchema(dsSchema, "document", "view")
+	require.NoError(t, err)
+
+	plan, err := query.SerializeIterator(it)
+	require.NoError(t, err)
+
+	ctx, dispatcher, revision := newLocalDispatcher(t)
+	req := &v1.DispatchQueryPlanRequest{
+		Metadata: &v1.ResolverMeta{
+			AtRevision:     revision.String(),
+			DepthRemaining: 1,
+		},
+		Plan:              plan,
+		Operation:         v1.DispatchQueryPlanRequest_CHECK,
+		ResourceType:      "document",
+		ResourceIds:       []string{"masterplan"},
+		Subject:           tuple.CoreONR("user", "product_manager", tuple.Ellipsis),
+		MaxRecursionDepth: 5,
+	}
+
+	// With a single level of depth remaining, the plan is evaluated without further dispatch.
+	resp, err := dispatcher.DispatchQueryPlan(ctx, req)
+	require.NoError(t, err)
+	require.Len(t, resp.Paths, 1)
+	require.Equal(t, uint32(1), resp.Metadata.DispatchCount)
+
+	req.Metadata.DepthRemaining = 0
+	_, err = dispatcher.DispatchQueryPlan(ctx, req)
+	require.Error(t, err)
+}
@@ -1,6 +1,8 @@
 package keys
 
 import (
+	"google.golang.org/protobuf/proto"
+
 	"github.com/authzed/spicedb/pkg/caveats"
 	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
 	"github.com/authzed/spicedb/pkg/spiceerrors"
@@ -23,21 +25,36 @@ const (
 	checkViaRelationPrefix  cachePrefix = "cr"
 	checkViaCanonicalPrefix cachePrefix = "cc"
 	lookupPrefix            cachePrefix = "l"
+	lookupAtCursorPrefix    cachePrefix = "lc"
 	expandPrefix            cachePrefix = "e"
 	lookupSubjectsPrefix    cachePrefix = "ls"
+	queryPlanPrefix         cachePrefix = "qp"
 )
 
 var cachePrefixes = []cachePrefix{
 	checkViaRelationPrefix,
 	checkViaCanonicalPrefix,
 	lookupPrefix,
+	lookupAtCursorPrefix,
 	expandPrefix,
 	lookupSubjectsPrefix,
+	queryPlanPrefix,
 }
 
+// carriedRevision is used in place of the revision of a check request when computing the key
+// under which its result is cached for carrying forward to other revisions. It must not be the
+// string form of any revision.
+const carriedRevision = "carried"
+
 // checkRequestToKey converts a check request into a cache key based on the relation
 func checkRequestToKey(req *v1.DispatchCheckRequest, option dispatchCacheKeyHashComputeOption) DispatchCacheKey {
-	return dispatchCacheKeyHash(checkViaRelationPrefix, req.Metadata.AtRevision, option,
+	return checkRequestToKeyAtRevision(req, req.Metadata.AtRevision, option)
+}
+
+// checkRequestToKeyAtRevision converts a check request into a cache key based on the relation,
+// with the given revision in place of that of the request.
+func checkRequestToKeyAtRevision(req *v1.DispatchCheckRequest, atRevision string, option dispatchCacheKeyHashComputeOption) DispatchCacheKey {
+	return dispatchCacheKeyHash(checkViaRelationPrefix, atRevision, option,
 		hashableRelationReference{req.ResourceRelation},
 		hashableIds(req.ResourceIds),
 		hashableOnr{req.Subject},
@@ -48,8 +65,14 @@ func checkRequestToKey(req *v1.DispatchCheckRequest, option dispatchCacheKeyHash
 // checkRequestToKeyWithCanonical converts a check request into a cache key based
 // on the canonical key.
 func checkRequestToKeyWithCanonical(req *v1.DispatchCheckRequest, canonicalKey string) (DispatchCacheKey, error) {
+	return checkRequestToKeyWithCanonicalAtRevision(req, canonicalKey, req.Metadata.AtRevision)
+}
+
+// checkRequestToKeyWithCanonicalAtRevision converts a check request into a cache key based
+// on the canonical key, with the given revision in place of that of the request.
+func checkRequestToKeyWithCanonicalAtRevision(req *v1.DispatchCheckRequest, canonicalKey string, atRevision string) (DispatchCacheKey, error) {
 	// NOTE: canonical cache keys are only unique *within* a version of a namespace.
-	cacheKey := dispatchCacheKeyHash(checkViaCanonicalPrefix, req.Metadata.AtRevision, computeBothHashes,
+	cacheKey := dispatchCacheKeyHash(checkViaCanonicalPrefix, atRevision, computeBothHashes,
 		hashableString(req.ResourceRelation.Namespace),
 		hashableString(canonicalKey),
 		hashableIds(req.ResourceIds),
@@ -97,6 +120,19 @@ func lookupResourcesRequest3ToKey(req *v1.DispatchLookupResources3Request, optio
 	)
 }
 
+// lookupResourcesRequest3ToCursorKey converts a lookup request into a cache key for the results
+// found from its cursor onward, without regard to its limit
+func lookupResourcesRequest3ToCursorKey(req *v1.DispatchLookupResources3Request, option dispatchCacheKeyHashComputeOption) DispatchCacheKey {
+	return dispatchCacheKeyHash(lookupAtCursorPrefix, req.Metadata.AtRevision, option,
+		hashableRelationReference{req.ResourceRelation},
+		hashableRelationReference{req.SubjectRelation},
+		hashableIds(req.SubjectIds),
+		hashableOnr{req.TerminalSubject},
+		hashableContext{HashableContext: caveats.HashableContext{Struct: req.Context}},
+		hashableCursorSections{req.OptionalCursor},
+	)
+}
+
 // lookupSubjectsRequestToKey converts a lookup subjects request into a cache key
 func lookupSubjectsRequestToKey(req *v1.DispatchLookupSubjectsRequest, option dispatchCacheKeyHashComputeOption) DispatchCacheKey {
 	return dispatchCacheKeyHash(lookupSubjectsPrefix, req.Metadata.AtRevision, option,
@@ -105,3 +141,23 @@ func lookupSubjectsRequestToKey(req *v1.DispatchLookupSubjectsRequest, option di
 		hashableIds(req.ResourceIds),
 	)
 }
+
+// queryPlanRequestToKey converts a query plan request into a key based on the plan subtree
+func queryPlanRequestToKey(req *v1.DispatchQueryPlanRequest, option dispatchCacheKeyHashComputeOption) (DispatchCacheKey, error) {
+	// NOTE: deterministic marshaling is stable within a single build, which is all that is
+	// required for placing requests on the hashring of nodes running the same version.
+	plan, err := proto.MarshalOptions{Deterministic: true}.Marshal(req.Plan)
+	if err != nil {
+		return emptyDispatchCacheKey, err
+	}
+
+	return dispatchCacheKeyHash(queryPlanPrefix, req.Metadata.AtRevision, option,
+		hashableString(plan),
+		hashableString(req.Operation.String()),
+		hashableString(req.ResourceType),
+		hashableIds(req.ResourceIds),
+		hashableOptionalOnr{req.Subject},
+		hashableContext{HashableContext: caveats.HashableContext{Struct: req.CaveatContext}},
+		hashableLimit(req.MaxRecursionDepth),
+	), nil
+}
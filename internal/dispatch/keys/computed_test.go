@@ -490,6 +490,30 @@ var generatorFuncs = map[string]generatorFunc{
 			}
 	},
 
+	// Lookup resources at cursor.
+	string(lookupAtCursorPrefix): func(
+		resourceIds []string,
+		subjectIds []string,
+		resourceRelation *core.RelationReference,
+		subjectRelation *core.RelationReference,
+		metadata *v1.ResolverMeta,
+	) (DispatchCacheKey, []string) {
+		return lookupResourcesRequest3ToCursorKey(&v1.DispatchLookupResources3Request{
+				ResourceRelation: resourceRelation,
+				SubjectRelation:  subjectRelation,
+				SubjectIds:       subjectIds,
+				TerminalSubject:  ONR(subjectRelation.Namespace, subjectIds[0], subjectRelation.Relation),
+				Metadata:         metadata,
+				OptionalCursor:   resourceIds,
+			}, computeBothHashes), append([]string{
+				resourceRelation.Namespace,
+				resourceRelation.Relation,
+				subjectRelation.Namespace,
+				subjectIds[0],
+				subjectRelation.Relation,
+			}, resourceIds...)
+	},
+
 	// Expand.
 	string(expandPrefix): func(
 		resourceIds []string,
@@ -528,6 +552,35 @@ var generatorFuncs = map[string]generatorFunc{
 				subjectRelation.Relation,
 			}, resourceIds...)
 	},
+
+	// Query Plan.
+	string(queryPlanPrefix): func(
+		resourceIds []string,
+		subjectIds []string,
+		resourceRelation *core.RelationReference,
+		subjectRelation *core.RelationReference,
+		metadata *v1.ResolverMeta,
+	) (DispatchCacheKey, []string) {
+		key, _ := queryPlanRequestToKey(&v1.DispatchQueryPlanRequest{
+			Plan: &v1.QueryPlanNode{Node: &v1.QueryPlanNode_Relation{Relation: &v1.QueryPlanRelation{
+				DefinitionName:  resourceRelation.Namespace,
+				RelationName:    resourceRelation.Relation,
+				SubjectType:     subjectRelation.Namespace,
+				SubjectRelation: subjectRelation.Relation,
+			}}},
+			ResourceType: resourceRelation.Namespace,
+			ResourceIds:  resourceIds,
+			Subject:      ONR(subjectRelation.Namespace, subjectIds[0], subjectRelation.Relation),
+			Metadata:     metadata,
+		}, computeBothHashes)
+		return key, append([]string{
+			resourceRelation.Namespace,
+			resourceRelation.Relation,
+			subjectRelation.Namespace,
+			subjectIds[0],
+			subjectRelation.Relation,
+		}, resourceIds...)
+	},
 }
 
 func TestCacheKeyNoOverlap(t *testing.T) {
@@ -650,3 +703,28 @@ func TestComputeContextHash(t *testing.T) {
 
 	require.Equal(t, "81aab1c790f0be947d", hex.EncodeToString(result.StableSumAsBytes()))
 }
+
+func TestCheckCarriedCacheKey(t *testing.T) {
+	requestAtRevision := func(revision string) *v1.DispatchCheckRequest {
+		return &v1.DispatchCheckRequest{
+			ResourceRelation: RR("document", "view"),
+			ResourceIds:      []string{"foo", "bar"},
+			Subject:          ONR("user", "tom", "..."),
+			Metadata: &v1.ResolverMeta{
+				AtRevision: revision,
+			},
+		}
+	}
+
+	handler := &DirectKeyHandler{}
+	firstCarried, err := handler.CheckCarriedCacheKey(t.Context(), requestAtRevision("1234"))
+	require.NoError(t, err)
+
+	secondCarried, err := handler.CheckCarriedCacheKey(t.Context(), requestAtRevision("5678"))
+	require.NoError(t, err)
+	require.Equal(t, firstCarried, secondCarried)
+
+	exact, err := handler.CheckCacheKey(t.Context(), requestAtRevision("1234"))
+	require.NoError(t, err)
+	require.NotEqual(t, exact, firstCarried)
+}
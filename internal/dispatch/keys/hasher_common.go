@@ -59,6 +59,16 @@ func (hnr hashableOnr) AppendToHash(hasher hasherInterface) {
 	hasher.WriteString(hnr.Relation)
 }
 
+type hashableOptionalOnr struct {
+	*core.ObjectAndRelation
+}
+
+func (hnr hashableOptionalOnr) AppendToHash(hasher hasherInterface) {
+	if hnr.ObjectAndRelation != nil {
+		hashableOnr(hnr).AppendToHash(hasher)
+	}
+}
+
 type hashableString string
 
 func (hs hashableString) AppendToHash(hasher hasherInterface) {
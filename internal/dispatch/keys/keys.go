@@ -13,12 +13,21 @@ type Handler interface {
 	// CheckCacheKey computes the caching key for a Check operation.
 	CheckCacheKey(ctx context.Context, req *v1.DispatchCheckRequest) (DispatchCacheKey, error)
 
+	// CheckCarriedCacheKey computes the caching key for a Check operation without regard to the
+	// revision at which it is checked, under which its result is cached for carrying forward to
+	// other revisions.
+	CheckCarriedCacheKey(ctx context.Context, req *v1.DispatchCheckRequest) (DispatchCacheKey, error)
+
 	// LookupResources2CacheKey computes the caching key for a LookupResources2 operation.
 	LookupResources2CacheKey(ctx context.Context, req *v1.DispatchLookupResources2Request) (DispatchCacheKey, error)
 
 	// LookupResources3CacheKey computes the caching key for a LookupResources3 operation.
 	LookupResources3CacheKey(ctx context.Context, req *v1.DispatchLookupResources3Request) (DispatchCacheKey, error)
 
+	// LookupResources3CursorCacheKey computes the caching key for the results of a
+	// LookupResources3 operation found from its cursor onward, without regard to its limit.
+	LookupResources3CursorCacheKey(ctx context.Context, req *v1.DispatchLookupResources3Request) (DispatchCacheKey, error)
+
 	// LookupSubjectsCacheKey computes the caching key for a LookupSubjects operation.
 	LookupSubjectsCacheKey(ctx context.Context, req *v1.DispatchLookupSubjectsRequest) (DispatchCacheKey, error)
 
@@ -39,6 +48,9 @@ type Handler interface {
 
 	// ExpandDispatchKey computes the dispatch key for an Expand operation.
 	ExpandDispatchKey(ctx context.Context, req *v1.DispatchExpandRequest) ([]byte, error)
+
+	// QueryPlanDispatchKey computes the dispatch key for a QueryPlan operation.
+	QueryPlanDispatchKey(ctx context.Context, req *v1.DispatchQueryPlanRequest) ([]byte, error)
 }
 
 type baseKeyHandler struct{}
@@ -51,6 +63,10 @@ func (b baseKeyHandler) LookupResources3CacheKey(_ context.Context, req *v1.Disp
 	return lookupResourcesRequest3ToKey(req, computeBothHashes), nil
 }
 
+func (b baseKeyHandler) LookupResources3CursorCacheKey(_ context.Context, req *v1.DispatchLookupResources3Request) (DispatchCacheKey, error) {
+	return lookupResourcesRequest3ToCursorKey(req, computeBothHashes), nil
+}
+
 func (b baseKeyHandler) LookupSubjectsCacheKey(_ context.Context, req *v1.DispatchLookupSubjectsRequest) (DispatchCacheKey, error) {
 	return lookupSubjectsRequestToKey(req, computeBothHashes), nil
 }
@@ -79,6 +95,14 @@ func (b baseKeyHandler) ExpandDispatchKey(_ context.Context, req *v1.DispatchExp
 	return expandRequestToKey(req, computeOnlyStableHash).StableSumAsBytes(), nil
 }
 
+func (b baseKeyHandler) QueryPlanDispatchKey(_ context.Context, req *v1.DispatchQueryPlanRequest) ([]byte, error) {
+	key, err := queryPlanRequestToKey(req, computeOnlyStableHash)
+	if err != nil {
+		return nil, err
+	}
+	return key.StableSumAsBytes(), nil
+}
+
 // DirectKeyHandler is a key handler that uses the relation name itself as the key.
 type DirectKeyHandler struct {
 	baseKeyHandler
@@ -88,6 +112,10 @@ func (d *DirectKeyHandler) CheckCacheKey(_ context.Context, req *v1.DispatchChec
 	return checkRequestToKey(req, computeBothHashes), nil
 }
 
+func (d *DirectKeyHandler) CheckCarriedCacheKey(_ context.Context, req *v1.DispatchCheckRequest) (DispatchCacheKey, error) {
+	return checkRequestToKeyAtRevision(req, carriedRevision, computeBothHashes), nil
+}
+
 // CanonicalKeyHandler is a key handler which makes use of the canonical key for relations for
 // dispatching.
 type CanonicalKeyHandler struct {
@@ -95,6 +123,16 @@ type CanonicalKeyHandler struct {
 }
 
 func (c *CanonicalKeyHandler) CheckCacheKey(ctx context.Context, req *v1.DispatchCheckRequest) (DispatchCacheKey, error) {
+	return c.checkCacheKey(ctx, req, req.Metadata.AtRevision)
+}
+
+func (c *CanonicalKeyHandler) CheckCarriedCacheKey(ctx context.Context, req *v1.DispatchCheckRequest) (DispatchCacheKey, error) {
+	// NOTE: canonical cache keys are only unique within a version of a namespace, but a result
+	// is never carried forward across a change of the namespace of the resource.
+	return c.checkCacheKey(ctx, req, carriedRevision)
+}
+
+func (c *CanonicalKeyHandler) checkCacheKey(ctx context.Context, req *v1.DispatchCheckRequest, atRevision string) (DispatchCacheKey, error) {
 	// NOTE: We do not use the canonicalized cache key when checking within the same namespace, as
 	// we may get different results if the subject being checked matches the resource exactly, e.g.
 	// a check for `somenamespace:someobject#somerel@somenamespace:someobject#somerel`.
@@ -119,9 +157,9 @@ func (c *CanonicalKeyHandler) CheckCacheKey(ctx context.Context, req *v1.Dispatc
 		}
 
 		if relation.CanonicalCacheKey != "" {
-			return checkRequestToKeyWithCanonical(req, relation.CanonicalCacheKey)
+			return checkRequestToKeyWithCanonicalAtRevision(req, relation.CanonicalCacheKey, atRevision)
 		}
 	}
 
-	return checkRequestToKey(req, computeBothHashes), nil
+	return checkRequestToKeyAtRevision(req, atRevision, computeBothHashes), nil
 }
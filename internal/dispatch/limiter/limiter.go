@@ -0,0 +1,213 @@
+// Package limiter contains an adaptive limiter of the concurrency of dispatched operations.
+package limiter
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/rs/zerolog"
+
+	"github.com/authzed/spicedb/internal/graph"
+	"github.com/authzed/spicedb/pkg/middleware/requestid"
+)
+
+const (
+	// defaultUpdateInterval is the interval at which the limit is adjusted.
+	defaultUpdateInterval = 500 * time.Millisecond
+
+	// latencyTolerance is the ratio to the baseline latency of the datastore beyond which the limit
+	// is decreased.
+	latencyTolerance = 1.5
+
+	// minGradient is the smallest ratio by which the limit is decreased in a single adjustment.
+	minGradient = 0.5
+
+	// maxSchedulingLatency is the latency with which runnable goroutines are scheduled beyond
+	// which goroutines are considered to be queueing, and the limit is decreased.
+	maxSchedulingLatency = 5 * time.Millisecond
+
+	// queueingBackoff is the ratio by which the limit is decreased when goroutines are queueing.
+	queueingBackoff = 0.9
+
+	// smoothing is the weight given to each increase of the limit.
+	smoothing = 0.2
+
+	// baselineDrift is the weight given to each observed latency above the baseline, so that a
+	// lasting increase in the latency of the datastore eventually becomes its baseline.
+	baselineDrift = 0.01
+)
+
+var (
+	limitGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "spicedb",
+		Subsystem: "dispatch",
+		Name:      "adaptive_concurrency_limit",
+		Help:      "current adaptive limit of the concurrency of dispatched operations",
+	})
+
+	activeRequestsGauge = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "spicedb",
+		Subsystem: "dispatch",
+		Name:      "adaptive_concurrency_active_requests",
+		Help:      "number of requests with dispatched operations in flight between which the adaptive concurrency limit is shared",
+	})
+)
+
+// AdaptiveLimiter limits the concurrency of the operations dispatched by a node, adjusting the
+// limit to the observed latency of the datastore and the queueing of goroutines, and sharing it
+// fairly between the requests for which operations are in flight.
+//
+// The limit is adjusted using a gradient: it is decreased in proportion to the increase of the
+// latency of the datastore beyond its baseline, and whenever goroutines queue to be scheduled,
+// and is otherwise increased, so that an idle node makes use of its CPU.
+//
+// Each request is given an equal share of the limit, identified by its request ID. The concurrency
+// of each operation of a request is limited to the part of the share of the request not already
+// used by its other operations in flight, so that a request with a large fanout, such as a large
+// LookupResources, cannot monopolize the node.
+type AdaptiveLimiter struct {
+	minLimit       float64
+	maxLimit       float64
+	updateInterval time.Duration
+
+	// schedulingLatency returns the latency with which goroutines were scheduled since it was
+	// last called.
+	schedulingLatency func() time.Duration
+
+	mu              sync.Mutex
+	limit           float64        // GUARDED_BY(mu)
+	baselineLatency time.Duration  // GUARDED_BY(mu)
+	latencySum      time.Duration  // GUARDED_BY(mu)
+	latencyCount    int            // GUARDED_BY(mu)
+	inflight        map[string]int // GUARDED_BY(mu)
+}
+
+// NewAdaptiveLimiter creates a new limiter, starting at the initial limit and adjusting it
+// between the minimum and maximum limits. The limit is only adjusted while the limiter runs.
+func NewAdaptiveLimiter(initialLimit, minLimit, maxLimit uint16) *AdaptiveLimiter {
+	minLimit = max(minLimit, 1)
+	maxLimit = max(maxLimit, minLimit)
+	initialLimit = min(max(initialLimit, minLimit), maxLimit)
+
+	limitGauge.Set(float64(initialLimit))
+	return &AdaptiveLimiter{
+		minLimit:          float64(minLimit),
+		maxLimit:          float64(maxLimit),
+		updateInterval:    defaultUpdateInterval,
+		schedulingLatency: newSchedulingLatencySampler().sample,
+		limit:             float64(initialLimit),
+		inflight:          make(map[string]int),
+	}
+}
+
+// MarshalZerologObject implements zerolog.LogObjectMarshaler
+func (al *AdaptiveLimiter) MarshalZerologObject(e *zerolog.Event) {
+	e.Uint16("adaptive-concurrency-limit", al.Limit())
+	e.Float64("adaptive-concurrency-min-limit", al.minLimit)
+	e.Float64("adaptive-concurrency-max-limit", al.maxLimit)
+}
+
+// Limit returns the current limit.
+func (al *AdaptiveLimiter) Limit() uint16 {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+	return uint16(al.limit)
+}
+
+// ObserveLatency records the latency of a query to the datastore.
+func (al *AdaptiveLimiter) ObserveLatency(latency time.Duration) {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+	al.latencySum += latency
+	al.latencyCount++
+}
+
+// Begin marks the start of an operation dispatched for the request of the context, returning a
+// context carrying the concurrency limit of the operation, along with a function which must be
+// invoked once the operation has completed.
+func (al *AdaptiveLimiter) Begin(ctx context.Context) (context.Context, func()) {
+	// Operations of requests without an ID share a single share of the limit.
+	requestID, _ := requestid.FromContext(ctx)
+
+	limit, done := al.begin(requestID)
+	return graph.ContextWithConcurrencyLimit(ctx, limit), done
+}
+
+func (al *AdaptiveLimiter) begin(requestID string) (uint16, func()) {
+	al.mu.Lock()
+	inflight := al.inflight[requestID]
+	al.inflight[requestID] = inflight + 1
+	share := al.limit / float64(len(al.inflight))
+	activeRequestsGauge.Set(float64(len(al.inflight)))
+	al.mu.Unlock()
+
+	limit := min(max(share-float64(inflight), 1), math.MaxUint16)
+	return uint16(limit), func() {
+		al.mu.Lock()
+		defer al.mu.Unlock()
+
+		al.inflight[requestID]--
+		if al.inflight[requestID] <= 0 {
+			delete(al.inflight, requestID)
+		}
+		activeRequestsGauge.Set(float64(len(al.inflight)))
+	}
+}
+
+// Run adjusts the limit at each interval until the context is canceled.
+func (al *AdaptiveLimiter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(al.updateInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			al.update(al.schedulingLatency())
+		}
+	}
+}
+
+// update adjusts the limit to the latency of the datastore observed since the last adjustment and
+// the latency with which goroutines were scheduled.
+func (al *AdaptiveLimiter) update(schedulingLatency time.Duration) {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+
+	gradient := 1.0
+	if al.latencyCount > 0 {
+		latency := al.latencySum / time.Duration(al.latencyCount)
+		al.latencySum = 0
+		al.latencyCount = 0
+
+		switch {
+		case al.baselineLatency == 0 || latency < al.baselineLatency:
+			al.baselineLatency = latency
+		default:
+			al.baselineLatency += time.Duration(float64(latency-al.baselineLatency) * baselineDrift)
+		}
+
+		if latency > 0 {
+			gradient = min(max(latencyTolerance*float64(al.baselineLatency)/float64(latency), minGradient), 1)
+		}
+	}
+
+	if schedulingLatency > maxSchedulingLatency {
+		gradient = min(gradient, queueingBackoff)
+	}
+
+	// The limit is only increased when neither the datastore nor the scheduler shows signs of
+	// load, and then gradually, whereas it is decreased immediately.
+	limit := al.limit * gradient
+	if gradient == 1 {
+		limit += math.Sqrt(al.limit) * smoothing
+	}
+
+	al.limit = min(max(limit, al.minLimit), al.maxLimit)
+	limitGauge.Set(al.limit)
+}
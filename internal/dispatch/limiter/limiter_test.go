@@ -0,0 +1,156 @@
+package limiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/authzed/authzed-go/pkg/requestmeta"
+)
+
+func TestAdaptiveLimiterUpdate(t *testing.T) {
+	tcs := []struct {
+		name              string
+		latencies         []time.Duration
+		schedulingLatency time.Duration
+		expectIncrease    bool
+	}{
+		{
+			name:           "idle",
+			expectIncrease: true,
+		},
+		{
+			name:           "latency at baseline",
+			latencies:      []time.Duration{10 * time.Millisecond, 10 * time.Millisecond},
+			expectIncrease: true,
+		},
+		{
+			name:           "latency within tolerance",
+			latencies:      []time.Duration{10 * time.Millisecond, 14 * time.Millisecond},
+			expectIncrease: true,
+		},
+		{
+			name:           "latency beyond tolerance",
+			latencies:      []time.Duration{10 * time.Millisecond, 40 * time.Millisecond},
+			expectIncrease: false,
+		},
+		{
+			name:              "goroutines queueing",
+			schedulingLatency: 20 * time.Millisecond,
+			expectIncrease:    false,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			al := NewAdaptiveLimiter(100, 1, 1000)
+
+			// Each latency is observed within its own interval, so that the first sets the baseline.
+			for _, latency := range tc.latencies[:max(len(tc.latencies)-1, 0)] {
+				al.ObserveLatency(latency)
+				al.update(0)
+			}
+
+			before := al.Limit()
+			if len(tc.latencies) > 0 {
+				al.ObserveLatency(tc.latencies[len(tc.latencies)-1])
+			}
+			al.update(tc.schedulingLatency)
+
+			if tc.expectIncrease {
+				require.Greater(t, al.Limit(), before)
+			} else {
+				require.Less(t, al.Limit(), before)
+			}
+		})
+	}
+}
+
+func TestAdaptiveLimiterBounds(t *testing.T) {
+	al := NewAdaptiveLimiter(10, 5, 20)
+	for range 100 {
+		al.update(0)
+	}
+	require.Equal(t, uint16(20), al.Limit())
+
+	for range 100 {
+		al.update(time.Second)
+	}
+	require.Equal(t, uint16(5), al.Limit())
+}
+
+func TestAdaptiveLimiterFairness(t *testing.T) {
+	al := NewAdaptiveLimiter(100, 1, 1000)
+
+	// A single request is given the whole of the limit, less its operations in flight.
+	limit, doneLarge := al.begin("large")
+	require.Equal(t, uint16(100), limit)
+
+	var doneLargeOperations []func()
+	for range 60 {
+		_, done := al.begin("large")
+		doneLargeOperations = append(doneLargeOperations, done)
+	}
+
+	limit, doneLargeLast := al.begin("large")
+	require.Equal(t, uint16(39), limit)
+
+	// Another request is given its share of the limit, while the request with many operations
+	// in flight is limited to a concurrency of one.
+	limit, doneSmall := al.begin("small")
+	require.Equal(t, uint16(50), limit)
+
+	limit, doneLargeAgain := al.begin("large")
+	require.Equal(t, uint16(1), limit)
+
+	doneSmall()
+	doneLargeAgain()
+	doneLargeLast()
+	for _, done := range doneLargeOperations {
+		done()
+	}
+	doneLarge()
+	require.Empty(t, al.inflight)
+}
+
+func TestAdaptiveLimiterBeginByRequestID(t *testing.T) {
+	al := NewAdaptiveLimiter(100, 1, 1000)
+
+	requestCtx := func(requestID string) context.Context {
+		return metadata.NewIncomingContext(t.Context(), metadata.Pairs(string(requestmeta.RequestIDKey), requestID))
+	}
+
+	_, doneFirst := al.Begin(requestCtx("first"))
+	_, doneSecond := al.Begin(requestCtx("second"))
+	_, doneAnonymous := al.Begin(t.Context())
+	require.Len(t, al.inflight, 3)
+
+	doneFirst()
+	doneSecond()
+	doneAnonymous()
+	require.Empty(t, al.inflight)
+}
+
+func TestLatencyQuantile(t *testing.T) {
+	buckets := []float64{0, 0.001, 0.01, 0.1, 1}
+
+	// No goroutines were scheduled since the previous sample.
+	require.Equal(t, time.Duration(0), latencyQuantile(buckets, []uint64{5, 5, 5, 5}, []uint64{5, 5, 5, 5}, 0.99))
+
+	// All goroutines were scheduled within a millisecond.
+	require.Equal(t, time.Millisecond, latencyQuantile(buckets, []uint64{105, 5, 5, 5}, []uint64{5, 5, 5, 5}, 0.99))
+
+	// Some goroutines took at least ten milliseconds to be scheduled.
+	require.Equal(t, 100*time.Millisecond, latencyQuantile(buckets, []uint64{95, 0, 5, 0}, []uint64{0, 0, 0, 0}, 0.99))
+}
+
+func TestSchedulingLatencySampler(t *testing.T) {
+	sampler := newSchedulingLatencySampler()
+
+	// The first sample establishes the counts from which later samples are computed.
+	require.Equal(t, time.Duration(0), sampler.sample())
+	require.GreaterOrEqual(t, sampler.sample(), time.Duration(0))
+}
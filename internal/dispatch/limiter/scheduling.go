@@ -0,0 +1,75 @@
+package limiter
+
+import (
+	"math"
+	"runtime/metrics"
+	"time"
+)
+
+const (
+	schedulingLatencyMetric = "/sched/latencies:seconds"
+
+	// schedulingLatencyQuantile is the quantile of the latencies with which goroutines were
+	// scheduled used to determine whether goroutines are queueing.
+	schedulingLatencyQuantile = 0.99
+)
+
+// schedulingLatencySampler samples the latency with which runnable goroutines are scheduled, as
+// reported by the runtime.
+type schedulingLatencySampler struct {
+	samples  []metrics.Sample
+	previous []uint64
+}
+
+func newSchedulingLatencySampler() *schedulingLatencySampler {
+	return &schedulingLatencySampler{
+		samples: []metrics.Sample{{Name: schedulingLatencyMetric}},
+	}
+}
+
+// sample returns the quantile of the latencies with which goroutines were scheduled since the
+// last sample, or zero if no goroutines were scheduled.
+func (s *schedulingLatencySampler) sample() time.Duration {
+	metrics.Read(s.samples)
+	if s.samples[0].Value.Kind() != metrics.KindFloat64Histogram {
+		return 0
+	}
+
+	histogram := s.samples[0].Value.Float64Histogram()
+	previous := s.previous
+	s.previous = append(s.previous[:0:0], histogram.Counts...)
+	if len(previous) != len(histogram.Counts) {
+		return 0
+	}
+
+	return latencyQuantile(histogram.Buckets, histogram.Counts, previous, schedulingLatencyQuantile)
+}
+
+// latencyQuantile returns the quantile of the latencies counted by the histogram since the
+// previous counts, as the upper bound of the bucket in which it falls.
+func latencyQuantile(buckets []float64, counts []uint64, previous []uint64, quantile float64) time.Duration {
+	var total uint64
+	for index := range counts {
+		total += counts[index] - previous[index]
+	}
+	if total == 0 {
+		return 0
+	}
+
+	threshold := uint64(math.Ceil(float64(total) * quantile))
+	var cumulative uint64
+	for index := range counts {
+		cumulative += counts[index] - previous[index]
+		if cumulative < threshold {
+			continue
+		}
+
+		// Buckets hold one more boundary than counts; the last may be unbounded.
+		upper := buckets[index+1]
+		if math.IsInf(upper, 1) {
+			upper = buckets[index]
+		}
+		return time.Duration(upper * float64(time.Second))
+	}
+	return 0
+}
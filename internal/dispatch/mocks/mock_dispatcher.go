@@ -129,6 +129,21 @@ func (mr *MockDispatcherMockRecorder) DispatchLookupSubjects(req, stream any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchLookupSubjects", reflect.TypeOf((*MockDispatcher)(nil).DispatchLookupSubjects), req, stream)
 }
 
+// DispatchQueryPlan mocks base method.
+func (m *MockDispatcher) DispatchQueryPlan(ctx context.Context, req *dispatchv1.DispatchQueryPlanRequest) (*dispatchv1.DispatchQueryPlanResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DispatchQueryPlan", ctx, req)
+	ret0, _ := ret[0].(*dispatchv1.DispatchQueryPlanResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DispatchQueryPlan indicates an expected call of DispatchQueryPlan.
+func (mr *MockDispatcherMockRecorder) DispatchQueryPlan(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchQueryPlan", reflect.TypeOf((*MockDispatcher)(nil).DispatchQueryPlan), ctx, req)
+}
+
 // ReadyState mocks base method.
 func (m *MockDispatcher) ReadyState() dispatch.ReadyState {
 	m.ctrl.T.Helper()
@@ -335,6 +350,84 @@ func (mr *MockLookupSubjectsMockRecorder) DispatchLookupSubjects(req, stream any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchLookupSubjects", reflect.TypeOf((*MockLookupSubjects)(nil).DispatchLookupSubjects), req, stream)
 }
 
+// MockQueryPlan is a mock of QueryPlan interface.
+type MockQueryPlan struct {
+	ctrl     *gomock.Controller
+	recorder *MockQueryPlanMockRecorder
+	isgomock struct{}
+}
+
+// MockQueryPlanMockRecorder is the mock recorder for MockQueryPlan.
+type MockQueryPlanMockRecorder struct {
+	mock *MockQueryPlan
+}
+
+// NewMockQueryPlan creates a new mock instance.
+func NewMockQueryPlan(ctrl *gomock.Controller) *MockQueryPlan {
+	mock := &MockQueryPlan{ctrl: ctrl}
+	mock.recorder = &MockQueryPlanMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockQueryPlan) EXPECT() *MockQueryPlanMockRecorder {
+	return m.recorder
+}
+
+// DispatchQueryPlan mocks base method.
+func (m *MockQueryPlan) DispatchQueryPlan(ctx context.Context, req *dispatchv1.DispatchQueryPlanRequest) (*dispatchv1.DispatchQueryPlanResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DispatchQueryPlan", ctx, req)
+	ret0, _ := ret[0].(*dispatchv1.DispatchQueryPlanResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DispatchQueryPlan indicates an expected call of DispatchQueryPlan.
+func (mr *MockQueryPlanMockRecorder) DispatchQueryPlan(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchQueryPlan", reflect.TypeOf((*MockQueryPlan)(nil).DispatchQueryPlan), ctx, req)
+}
+
+// MockPeerCacheLookup is a mock of PeerCacheLookup interface.
+type MockPeerCacheLookup struct {
+	ctrl     *gomock.Controller
+	recorder *MockPeerCacheLookupMockRecorder
+	isgomock struct{}
+}
+
+// MockPeerCacheLookupMockRecorder is the mock recorder for MockPeerCacheLookup.
+type MockPeerCacheLookupMockRecorder struct {
+	mock *MockPeerCacheLookup
+}
+
+// NewMockPeerCacheLookup creates a new mock instance.
+func NewMockPeerCacheLookup(ctrl *gomock.Controller) *MockPeerCacheLookup {
+	mock := &MockPeerCacheLookup{ctrl: ctrl}
+	mock.recorder = &MockPeerCacheLookupMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPeerCacheLookup) EXPECT() *MockPeerCacheLookupMockRecorder {
+	return m.recorder
+}
+
+// LookupPeerCache mocks base method.
+func (m *MockPeerCacheLookup) LookupPeerCache(ctx context.Context, req *dispatchv1.DispatchPeerCacheLookupRequest) (*dispatchv1.DispatchPeerCacheLookupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupPeerCache", ctx, req)
+	ret0, _ := ret[0].(*dispatchv1.DispatchPeerCacheLookupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LookupPeerCache indicates an expected call of LookupPeerCache.
+func (mr *MockPeerCacheLookupMockRecorder) LookupPeerCache(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupPeerCache", reflect.TypeOf((*MockPeerCacheLookup)(nil).LookupPeerCache), ctx, req)
+}
+
 // MockDispatchableRequest is a mock of DispatchableRequest interface.
 type MockDispatchableRequest struct {
 	ctrl     *gomock.Controller
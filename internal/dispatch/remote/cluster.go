@@ -90,6 +90,7 @@ type ClusterClient interface {
 	DispatchLookupResources2(ctx context.Context, in *v1.DispatchLookupResources2Request, opts ...grpc.CallOption) (v1.DispatchService_DispatchLookupResources2Client, error)
 	DispatchLookupResources3(ctx context.Context, in *v1.DispatchLookupResources3Request, opts ...grpc.CallOption) (v1.DispatchService_DispatchLookupResources3Client, error)
 	DispatchLookupSubjects(ctx context.Context, in *v1.DispatchLookupSubjectsRequest, opts ...grpc.CallOption) (v1.DispatchService_DispatchLookupSubjectsClient, error)
+	DispatchQueryPlan(ctx context.Context, req *v1.DispatchQueryPlanRequest, opts ...grpc.CallOption) (*v1.DispatchQueryPlanResponse, error)
 }
 
 type ClusterDispatcherConfig struct {
@@ -769,6 +770,33 @@ func (cr *clusterDispatcher) DispatchExpand(ctx context.Context, req *v1.Dispatc
 	return resp, err
 }
 
+// DispatchQueryPlan dispatches a query plan subtree to the node owning it on the hashring,
+// which is chosen by the subtree and its arguments so that repeated evaluations of the same
+// subtree are placed on the same node.
+func (cr *clusterDispatcher) DispatchQueryPlan(ctx context.Context, req *v1.DispatchQueryPlanRequest) (*v1.DispatchQueryPlanResponse, error) {
+	if err := dispatch.CheckDepth(ctx, req); err != nil {
+		return &v1.DispatchQueryPlanResponse{Metadata: emptyMetadata}, err
+	}
+
+	requestKey, err := cr.keyHandler.QueryPlanDispatchKey(ctx, req)
+	if err != nil {
+		return &v1.DispatchQueryPlanResponse{Metadata: emptyMetadata}, err
+	}
+
+	ctx = context.WithValue(ctx, consistent.CtxKey, requestKey)
+
+	withTimeout, cancelFn := context.WithTimeout(ctx, cr.dispatchOverallTimeout)
+	defer cancelFn()
+
+	resp, err := cr.clusterClient.DispatchQueryPlan(withTimeout, req)
+	if err != nil {
+		return &v1.DispatchQueryPlanResponse{Metadata: requestFailureMetadata}, err
+	}
+
+	err = adjustMetadataForDispatch(resp.Metadata)
+	return resp, err
+}
+
 func (cr *clusterDispatcher) DispatchLookupResources2(
 	req *v1.DispatchLookupResources2Request,
 	stream dispatch.LookupResources2Stream,
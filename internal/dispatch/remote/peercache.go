@@ -0,0 +1,105 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/authzed/consistent"
+
+	"github.com/authzed/spicedb/internal/dispatch/caching"
+	"github.com/authzed/spicedb/internal/dispatch/keys"
+	log "github.com/authzed/spicedb/internal/logging"
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+)
+
+// defaultPeerCacheLookupTimeout is the maximum duration of a lookup of the cache of a peer,
+// beyond which the result is computed instead.
+const defaultPeerCacheLookupTimeout = 50 * time.Millisecond
+
+// PeerCacheClient is the client used to look up the results cached by peers.
+type PeerCacheClient interface {
+	DispatchPeerCacheLookup(ctx context.Context, req *v1.DispatchPeerCacheLookupRequest, opts ...grpc.CallOption) (*v1.DispatchPeerCacheLookupResponse, error)
+}
+
+// PeerCache is a caching.PeerCache which looks up results in the cluster dispatch caches of the
+// previous owners of the requests on the dispatch hashring. When the members of the hashring
+// change, such as during a deploy, the new owner of a request can then fetch the result
+// from the node which previously computed it, rather than recomputing it.
+//
+// Lookups are routed over the dispatch connection, which must use a balancer built by the
+// builder returned from NewPreviousOwnerBalancerBuilder.
+type PeerCache struct {
+	lookupTimeout time.Duration
+	bound         atomic.Pointer[boundPeerCache]
+}
+
+type boundPeerCache struct {
+	client     PeerCacheClient
+	keyHandler keys.Handler
+}
+
+// NewPeerCache creates a new PeerCache, which does not find any results until a client is set.
+// If the lookup timeout is zero, a default is used.
+func NewPeerCache(lookupTimeout time.Duration) *PeerCache {
+	if lookupTimeout <= 0 {
+		lookupTimeout = defaultPeerCacheLookupTimeout
+	}
+	return &PeerCache{lookupTimeout: lookupTimeout}
+}
+
+// SetClient sets the client used to look up results, along with the key handler used to
+// compute the dispatch keys of requests, which must match that used to dispatch them.
+func (pc *PeerCache) SetClient(client PeerCacheClient, keyHandler keys.Handler) {
+	pc.bound.Store(&boundPeerCache{client: client, keyHandler: keyHandler})
+}
+
+// Lookup implements caching.PeerCache.
+func (pc *PeerCache) Lookup(ctx context.Context, req *v1.DispatchPeerCacheLookupRequest) ([][]byte, bool) {
+	bound := pc.bound.Load()
+	if bound == nil {
+		return nil, false
+	}
+
+	dispatchKey, err := peerCacheDispatchKey(ctx, bound.keyHandler, req)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("unable to compute dispatch key for peer cache lookup")
+		return nil, false
+	}
+
+	// The consistent hashring key is also set, should the balancer not support routing to
+	// previous owners.
+	ctx = context.WithValue(ctx, consistent.CtxKey, dispatchKey)
+	ctx = context.WithValue(ctx, PreviousOwnerCtxKey, dispatchKey)
+
+	ctx, cancel := context.WithTimeout(ctx, pc.lookupTimeout)
+	defer cancel()
+
+	resp, err := bound.client.DispatchPeerCacheLookup(ctx, req)
+	if err != nil {
+		log.Ctx(ctx).Trace().Err(err).Msg("peer cache lookup failed")
+		return nil, false
+	}
+	return resp.CachedResponses, resp.Found
+}
+
+func peerCacheDispatchKey(ctx context.Context, keyHandler keys.Handler, req *v1.DispatchPeerCacheLookupRequest) ([]byte, error) {
+	switch t := req.Request.(type) {
+	case *v1.DispatchPeerCacheLookupRequest_Check:
+		return keyHandler.CheckDispatchKey(ctx, t.Check)
+	case *v1.DispatchPeerCacheLookupRequest_LookupResources2:
+		return keyHandler.LookupResources2DispatchKey(ctx, t.LookupResources2)
+	case *v1.DispatchPeerCacheLookupRequest_LookupResources3:
+		return keyHandler.LookupResources3DispatchKey(ctx, t.LookupResources3)
+	case *v1.DispatchPeerCacheLookupRequest_LookupSubjects:
+		return keyHandler.LookupSubjectsDispatchKey(ctx, t.LookupSubjects)
+	default:
+		return nil, fmt.Errorf("unknown peer cache lookup request: %T", req.Request)
+	}
+}
+
+// Always verify that we implement the interface
+var _ caching.PeerCache = &PeerCache{}
@@ -0,0 +1,198 @@
+package remote
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/status"
+
+	"github.com/authzed/consistent"
+	"github.com/authzed/consistent/hashring"
+)
+
+type previousOwnerCtxKey string
+
+// PreviousOwnerCtxKey is the key of the context value holding the dispatch key of a request to
+// be routed to the node which owned the key on the hashring before its members last changed,
+// rather than to its current owner. It is only honored by balancers built by the builder
+// returned from NewPreviousOwnerBalancerBuilder.
+const PreviousOwnerCtxKey previousOwnerCtxKey = "previousOwnerKey"
+
+// previousOwnerRetention is the duration for which the previous members of the hashring are
+// retained after its members change. Dispatch results are cached for recent revisions, so
+// results cached by previous owners are only of use for a short while.
+const previousOwnerRetention = 1 * time.Minute
+
+var errNoPreviousOwner = status.Error(codes.Unavailable, "no previous owner of the dispatch key on the hashring")
+
+// NewPreviousOwnerBalancerBuilder wraps the builder of the consistent hashring balancer so that,
+// in addition to being routed to the current owner of their key, requests can be routed to
+// the previous owner of their key, by setting the PreviousOwnerCtxKey in their context.
+func NewPreviousOwnerBalancerBuilder(builder consistent.Builder, hashfn hashring.HashFunc) consistent.Builder {
+	return &previousOwnerBuilder{Builder: builder, hashfn: hashfn}
+}
+
+type previousOwnerBuilder struct {
+	consistent.Builder
+	hashfn hashring.HashFunc
+}
+
+func (b *previousOwnerBuilder) Build(cc balancer.ClientConn, opts balancer.BuildOptions) balancer.Balancer {
+	pob := &previousOwnerBalancer{
+		hashfn:   b.hashfn,
+		subConns: make(map[string]balancer.SubConn),
+	}
+	pob.Balancer = b.Builder.Build(&previousOwnerClientConn{ClientConn: cc, balancer: pob}, opts)
+	return pob
+}
+
+// previousOwnerBalancer tracks the members of the hashring of the wrapped balancer, retaining
+// those from before the last change of its members.
+type previousOwnerBalancer struct {
+	balancer.Balancer
+	hashfn hashring.HashFunc
+
+	// subConns holds the subconnection of each member, by key. It is only accessed from calls
+	// to the balancer, which are serialized by gRPC.
+	subConns          map[string]balancer.SubConn
+	replicationFactor uint16
+	memberKeys        []string
+
+	mu              sync.RWMutex
+	current         *hashring.Ring
+	currentSubConns map[string]balancer.SubConn
+	previous        *hashring.Ring
+	changedAt       time.Time
+}
+
+type ringMember struct {
+	key string
+}
+
+func (m ringMember) Key() string { return m.key }
+
+// memberKey returns the key of the member for the address, as used by the consistent hashring
+// balancer.
+func memberKey(addr resolver.Address) string {
+	return addr.ServerName + addr.Addr
+}
+
+func (b *previousOwnerBalancer) UpdateClientConnState(s balancer.ClientConnState) error {
+	err := b.Balancer.UpdateClientConnState(s)
+
+	if config, ok := s.BalancerConfig.(*consistent.BalancerConfig); ok {
+		b.replicationFactor = config.ReplicationFactor
+	}
+	if b.replicationFactor == 0 {
+		return err
+	}
+
+	memberKeys := make([]string, 0, len(s.ResolverState.Addresses))
+	subConns := make(map[string]balancer.SubConn, len(s.ResolverState.Addresses))
+	for _, addr := range s.ResolverState.Addresses {
+		key := memberKey(addr)
+		if sc, ok := b.subConns[key]; ok {
+			memberKeys = append(memberKeys, key)
+			subConns[key] = sc
+		}
+	}
+	slices.Sort(memberKeys)
+	b.subConns = subConns
+
+	if slices.Equal(memberKeys, b.memberKeys) {
+		return err
+	}
+	b.memberKeys = memberKeys
+
+	ring, ringErr := hashring.New(b.hashfn, b.replicationFactor)
+	if ringErr != nil {
+		return ringErr
+	}
+	for _, key := range memberKeys {
+		if ringErr := ring.Add(ringMember{key}); ringErr != nil {
+			return ringErr
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.previous = b.current
+	b.current = ring
+	b.currentSubConns = subConns
+	b.changedAt = time.Now()
+	return err
+}
+
+// previousOwner returns the subconnection of the previous owner of the key, if it differs from
+// the current owner and is still a member of the hashring.
+func (b *previousOwnerBalancer) previousOwner(key []byte) (balancer.SubConn, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.previous == nil || time.Since(b.changedAt) > previousOwnerRetention {
+		return nil, errNoPreviousOwner
+	}
+
+	previous, err := b.previous.FindN(key, 1)
+	if err != nil {
+		return nil, errNoPreviousOwner
+	}
+
+	current, err := b.current.FindN(key, 1)
+	if err != nil {
+		return nil, errNoPreviousOwner
+	}
+
+	if previous[0].Key() == current[0].Key() {
+		return nil, errNoPreviousOwner
+	}
+
+	sc, ok := b.currentSubConns[previous[0].Key()]
+	if !ok {
+		return nil, errNoPreviousOwner
+	}
+	return sc, nil
+}
+
+// previousOwnerClientConn records the subconnections created by the wrapped balancer, and wraps
+// its pickers to route requests to the previous owners of their keys, when requested.
+type previousOwnerClientConn struct {
+	balancer.ClientConn
+	balancer *previousOwnerBalancer
+}
+
+func (cc *previousOwnerClientConn) NewSubConn(addrs []resolver.Address, opts balancer.NewSubConnOptions) (balancer.SubConn, error) {
+	sc, err := cc.ClientConn.NewSubConn(addrs, opts)
+	if err == nil && len(addrs) == 1 {
+		cc.balancer.subConns[memberKey(addrs[0])] = sc
+	}
+	return sc, err
+}
+
+func (cc *previousOwnerClientConn) UpdateState(state balancer.State) {
+	state.Picker = &previousOwnerPicker{Picker: state.Picker, balancer: cc.balancer}
+	cc.ClientConn.UpdateState(state)
+}
+
+type previousOwnerPicker struct {
+	balancer.Picker
+	balancer *previousOwnerBalancer
+}
+
+func (p *previousOwnerPicker) Pick(info balancer.PickInfo) (balancer.PickResult, error) {
+	key, ok := info.Ctx.Value(PreviousOwnerCtxKey).([]byte)
+	if !ok {
+		return p.Picker.Pick(info)
+	}
+
+	sc, err := p.balancer.previousOwner(key)
+	if err != nil {
+		return balancer.PickResult{}, err
+	}
+	return balancer.PickResult{SubConn: sc}, nil
+}
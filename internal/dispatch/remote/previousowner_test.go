@@ -0,0 +1,113 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/balancer"
+	"google.golang.org/grpc/resolver"
+
+	"github.com/authzed/consistent"
+)
+
+type fakeSubConn struct {
+	balancer.SubConn
+	addr string
+}
+
+func (sc *fakeSubConn) Connect() {}
+
+type fakeClientConn struct {
+	balancer.ClientConn
+	picker balancer.Picker
+}
+
+func (cc *fakeClientConn) NewSubConn(addrs []resolver.Address, _ balancer.NewSubConnOptions) (balancer.SubConn, error) {
+	return &fakeSubConn{addr: addrs[0].Addr}, nil
+}
+
+func (cc *fakeClientConn) RemoveSubConn(balancer.SubConn) {}
+
+func (cc *fakeClientConn) UpdateState(state balancer.State) {
+	cc.picker = state.Picker
+}
+
+func TestPreviousOwnerBalancer(t *testing.T) {
+	builder := NewPreviousOwnerBalancerBuilder(consistent.NewBuilder(xxhash.Sum64), xxhash.Sum64)
+
+	config, err := builder.ParseConfig(json.RawMessage(`{"replicationFactor": 100, "spread": 1}`))
+	require.NoError(t, err)
+
+	cc := &fakeClientConn{}
+	bal := builder.Build(cc, balancer.BuildOptions{})
+
+	updateAddrs := func(addrs ...string) {
+		resolverAddrs := make([]resolver.Address, 0, len(addrs))
+		for _, addr := range addrs {
+			resolverAddrs = append(resolverAddrs, resolver.Address{Addr: addr})
+		}
+
+		require.NoError(t, bal.UpdateClientConnState(balancer.ClientConnState{
+			ResolverState:  resolver.State{Addresses: resolverAddrs},
+			BalancerConfig: config,
+		}))
+	}
+
+	pick := func(ctxKey any, key []byte) (string, error) {
+		result, err := cc.picker.Pick(balancer.PickInfo{Ctx: context.WithValue(t.Context(), ctxKey, key)})
+		if err != nil {
+			return "", err
+		}
+		return result.SubConn.(*fakeSubConn).addr, nil
+	}
+
+	keys := make([][]byte, 0, 100)
+	for i := range 100 {
+		keys = append(keys, []byte(fmt.Sprintf("key%d", i)))
+	}
+
+	updateAddrs("node1", "node2")
+
+	// Without a previous change of members, there is no previous owner.
+	_, err = pick(PreviousOwnerCtxKey, keys[0])
+	require.ErrorIs(t, err, errNoPreviousOwner)
+
+	previousOwners := make(map[string]string, len(keys))
+	for _, key := range keys {
+		owner, err := pick(consistent.CtxKey, key)
+		require.NoError(t, err)
+		previousOwners[string(key)] = owner
+	}
+
+	updateAddrs("node1", "node2", "node3")
+
+	moved := 0
+	for _, key := range keys {
+		owner, err := pick(consistent.CtxKey, key)
+		require.NoError(t, err)
+
+		previousOwner, err := pick(PreviousOwnerCtxKey, key)
+		if owner == previousOwners[string(key)] {
+			// Keys which did not move have no previous owner.
+			require.ErrorIs(t, err, errNoPreviousOwner)
+			continue
+		}
+
+		moved++
+		require.NoError(t, err)
+		require.Equal(t, "node3", owner)
+		require.Equal(t, previousOwners[string(key)], previousOwner)
+	}
+	require.Positive(t, moved)
+
+	// Once the previous owner of a key is no longer a member, it cannot be picked.
+	updateAddrs("node3")
+	for _, key := range keys {
+		_, err := pick(PreviousOwnerCtxKey, key)
+		require.ErrorIs(t, err, errNoPreviousOwner)
+	}
+}
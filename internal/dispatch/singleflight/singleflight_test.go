@@ -392,6 +392,10 @@ func (m mockDispatcher) DispatchLookupSubjects(_ *v1.DispatchLookupSubjectsReque
 	return nil
 }
 
+func (m mockDispatcher) DispatchQueryPlan(_ context.Context, _ *v1.DispatchQueryPlanRequest) (*v1.DispatchQueryPlanResponse, error) {
+	return &v1.DispatchQueryPlanResponse{}, nil
+}
+
 func (m mockDispatcher) Close() error {
 	return nil
 }
@@ -524,7 +524,7 @@ func (cc *ConcurrentChecker) checkDirect(ctx context.Context, crc currentRequest
 		}
 
 		return mapFoundResources(childResult, dd.resourceType, checksToDispatch)
-	}, cc.concurrencyLimit)
+	}, concurrencyLimitFor(ctx, cc.concurrencyLimit))
 
 	return combineResultWithFoundResources(result, foundResources)
 }
@@ -559,15 +559,15 @@ func (cc *ConcurrentChecker) checkUsersetRewrite(ctx context.Context, crc curren
 			ctx, span = tracer.Start(ctx, "+")
 			defer span.End()
 		}
-		return union(ctx, crc, rw.Union.Child, cc.runSetOperation, cc.concurrencyLimit)
+		return union(ctx, crc, rw.Union.Child, cc.runSetOperation, concurrencyLimitFor(ctx, cc.concurrencyLimit))
 	case *core.UsersetRewrite_Intersection:
 		ctx, span := tracer.Start(ctx, "&")
 		defer span.End()
-		return all(ctx, crc, rw.Intersection.Child, cc.runSetOperation, cc.concurrencyLimit)
+		return all(ctx, crc, rw.Intersection.Child, cc.runSetOperation, concurrencyLimitFor(ctx, cc.concurrencyLimit))
 	case *core.UsersetRewrite_Exclusion:
 		ctx, span := tracer.Start(ctx, "-")
 		defer span.End()
-		return difference(ctx, crc, rw.Exclusion.Child, cc.runSetOperation, cc.concurrencyLimit)
+		return difference(ctx, crc, rw.Exclusion.Child, cc.runSetOperation, concurrencyLimitFor(ctx, cc.concurrencyLimit))
 	default:
 		return checkResultError(spiceerrors.MustBugf("unknown userset rewrite operator"), emptyMetadata)
 	}
@@ -839,7 +839,7 @@ func checkIntersectionTupleToUserset(
 				relationType: dd.resourceType,
 			}
 		},
-		cc.concurrencyLimit,
+		concurrencyLimitFor(ctx, cc.concurrencyLimit),
 	)
 	if err != nil {
 		return checkResultError(err, emptyMetadata)
@@ -994,7 +994,7 @@ func checkTupleToUserset[T relation](
 
 			return mapFoundResources(childResult, dd.resourceType, checksToDispatch)
 		},
-		cc.concurrencyLimit,
+		concurrencyLimitFor(ctx, cc.concurrencyLimit),
 	), hintsToReturn)
 }
 
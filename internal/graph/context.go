@@ -29,5 +29,33 @@ func branchContext(ctx context.Context) (context.Context, func(cancelErr error))
 
 	detachedContext = requestid.PropagateIfExists(ctx, detachedContext)
 
+	// Add the concurrency limit to the context.
+	if limit, ok := ConcurrencyLimitFromContext(ctx); ok {
+		detachedContext = ContextWithConcurrencyLimit(detachedContext, limit)
+	}
+
 	return context.WithCancelCause(detachedContext)
 }
+
+type concurrencyLimitKey struct{}
+
+// ContextWithConcurrencyLimit returns a context carrying the concurrency limit of the operations
+// performed under it, overriding the limit with which their handlers were created.
+func ContextWithConcurrencyLimit(ctx context.Context, limit uint16) context.Context {
+	return context.WithValue(ctx, concurrencyLimitKey{}, limit)
+}
+
+// ConcurrencyLimitFromContext returns the concurrency limit carried by the context, if any.
+func ConcurrencyLimitFromContext(ctx context.Context) (uint16, bool) {
+	limit, ok := ctx.Value(concurrencyLimitKey{}).(uint16)
+	return limit, ok
+}
+
+// concurrencyLimitFor returns the concurrency limit carried by the context, if any, or the
+// default limit otherwise.
+func concurrencyLimitFor(ctx context.Context, defaultLimit uint16) uint16 {
+	if limit, ok := ConcurrencyLimitFromContext(ctx); ok && limit > 0 {
+		return limit
+	}
+	return defaultLimit
+}
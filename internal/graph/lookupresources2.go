@@ -144,7 +144,7 @@ func (crr *CursoredLookupResources2) afterSameType(
 	}
 
 	// For each entrypoint, load the necessary data and re-dispatch if a subproblem was found.
-	return withParallelizedStreamingIterableInCursor(ctx, ci, entrypoints, parentStream, crr.concurrencyLimit,
+	return withParallelizedStreamingIterableInCursor(ctx, ci, entrypoints, parentStream, concurrencyLimitFor(ctx, crr.concurrencyLimit),
 		func(ctx context.Context, ci cursorInformation, entrypoint schema.ReachabilityEntrypoint, stream dispatch.LookupResources2Stream) error {
 			ds, err := entrypoint.DebugString()
 			spiceerrors.DebugAssertf(func() bool {
@@ -263,7 +263,7 @@ func (crr *CursoredLookupResources2) lookupRelationEntrypoint(
 			foundResourceType:  relationReference,
 			entrypoint:         entrypoint,
 			rg:                 rg,
-			concurrencyLimit:   crr.concurrencyLimit,
+			concurrencyLimit:   concurrencyLimitFor(ctx, crr.concurrencyLimit),
 			parentStream:       stream,
 			parentRequest:      req,
 			dispatched:         dispatched,
@@ -690,7 +690,7 @@ func (crr *CursoredLookupResources2) redispatchOrReport(
 				crr.dl,
 				crr.dc,
 				crr.caveatTypeSet,
-				crr.concurrencyLimit,
+				concurrencyLimitFor(ctx, crr.concurrencyLimit),
 				crr.dispatchChunkSize,
 			)
 		})
@@ -270,7 +270,7 @@ func (crr *CursoredLookupResources3) LookupResources3(req ValidatedLookupResourc
 		reader:           reader,
 		ts:               ts,
 		caveatRunner:     caveatRunner,
-		concurrencyLimit: crr.concurrencyLimit,
+		concurrencyLimit: concurrencyLimitFor(ctx, crr.concurrencyLimit),
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
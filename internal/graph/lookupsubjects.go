@@ -276,7 +276,7 @@ func lookupViaIntersectionTupleToUserset(
 	// For each found tuple, dispatch a lookup subjects request and collect its results.
 	// We need to intersect between *all* the found subjects for each resource ID.
 	var ttuCaveat *core.CaveatExpression
-	taskrunner := taskrunner.NewPreloadedTaskRunner(cancelCtx, cl.concurrencyLimit, 1)
+	taskrunner := taskrunner.NewPreloadedTaskRunner(cancelCtx, concurrencyLimitFor(ctx, cl.concurrencyLimit), 1)
 	for rel, err := range it {
 		if err != nil {
 			return err
@@ -511,7 +511,7 @@ func (cl *ConcurrentLookupSubjects) lookupSetOperation(
 	defer checkCancel()
 
 	g, subCtx := errgroup.WithContext(cancelCtx)
-	g.SetLimit(int(cl.concurrencyLimit))
+	g.SetLimit(int(concurrencyLimitFor(ctx, cl.concurrencyLimit)))
 
 	for index, childOneof := range so.Child {
 		stream := reducer.ForIndex(subCtx, index)
@@ -583,7 +583,7 @@ func (cl *ConcurrentLookupSubjects) dispatchTo(
 	defer checkCancel()
 
 	g, subCtx := errgroup.WithContext(cancelCtx)
-	g.SetLimit(int(cl.concurrencyLimit))
+	g.SetLimit(int(concurrencyLimitFor(ctx, cl.concurrencyLimit)))
 
 	toDispatchByType.ForEachType(func(resourceType *core.RelationReference, foundSubjects datasets.SubjectSet) {
 		slice := foundSubjects.AsSlice()
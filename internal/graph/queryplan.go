@@ -0,0 +1,137 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/authzed/spicedb/internal/caveats"
+	"github.com/authzed/spicedb/internal/dispatch"
+	log "github.com/authzed/spicedb/internal/logging"
+	datastoremw "github.com/authzed/spicedb/internal/middleware/datastore"
+	caveattypes "github.com/authzed/spicedb/pkg/caveats/types"
+	"github.com/authzed/spicedb/pkg/datastore"
+	v1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/query"
+	"github.com/authzed/spicedb/pkg/schema/v2"
+	"github.com/authzed/spicedb/pkg/spiceerrors"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// NewQueryPlanEvaluator creates an instance of QueryPlanEvaluator.
+func NewQueryPlanEvaluator(d dispatch.QueryPlan, typeSet *caveattypes.TypeSet, concurrencyLimit uint16) *QueryPlanEvaluator {
+	return &QueryPlanEvaluator{d, typeSet, concurrencyLimit}
+}
+
+// QueryPlanEvaluator exposes a method to evaluate dispatched query plan subtrees, and
+// delegates the subtrees of the relations they reach to the provided dispatch.QueryPlan instance.
+type QueryPlanEvaluator struct {
+	d                dispatch.QueryPlan
+	typeSet          *caveattypes.TypeSet
+	concurrencyLimit uint16
+}
+
+// ValidatedQueryPlanRequest represents a request after it has been validated and parsed for internal
+// consumption.
+type ValidatedQueryPlanRequest struct {
+	*v1.DispatchQueryPlanRequest
+	Revision datastore.Revision
+}
+
+// Evaluate evaluates the query plan subtree of the request at the request's revision.
+func (qe *QueryPlanEvaluator) Evaluate(ctx context.Context, req ValidatedQueryPlanRequest) (*v1.DispatchQueryPlanResponse, error) {
+	log.Ctx(ctx).Trace().Object("queryplan", req).Send()
+
+	if req.Subject == nil && req.Operation != v1.DispatchQueryPlanRequest_ITER_SUBJECTS {
+		return nil, fmt.Errorf("a subject is required for query plan operation %v", req.Operation)
+	}
+
+	reader := datastoremw.MustFromContext(ctx).SnapshotReader(req.Revision)
+
+	// Only the definitions whose relations are referenced by the subtree are needed to
+	// rebuild it.
+	namespaces, err := reader.LookupNamespacesWithNames(ctx, query.ReferencedDefinitionNames(req.Plan))
+	if err != nil {
+		return nil, err
+	}
+
+	partialSchema, err := schema.BuildSchemaFromDefinitions(datastore.DefinitionsOf(namespaces), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	it, err := query.DeserializeIterator(req.Plan, partialSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	// The subtree is evaluated within the concurrency limit of the query which dispatched
+	// it, bounded by the limit of this node, or that carried by the context, if any.
+	concurrencyLimit := concurrencyLimitFor(ctx, qe.concurrencyLimit)
+	if req.ConcurrencyLimit != nil {
+		concurrencyLimit = uint16(min(uint32(concurrencyLimit), *req.ConcurrencyLimit))
+	}
+
+	executor := query.NewRemoteExecutor(qe.d, req.Revision, req.Metadata.DepthRemaining-1, query.NewParallelExecutor(concurrencyLimit))
+	qctx := &query.Context{
+		Context:           ctx,
+		Executor:          executor,
+		Reader:            reader,
+		CaveatContext:     req.CaveatContext.AsMap(),
+		CaveatRunner:      caveats.NewCaveatRunner(qe.typeSet),
+		MaxRecursionDepth: int(req.MaxRecursionDepth),
+	}
+	if req.Analyze {
+		qctx.Analyzer = query.NewAnalyzer()
+	}
+
+	// The root of the subtree is evaluated here, rather than through the executor, as it is
+	// the subtree which was dispatched.
+	var pathSeq query.PathSeq
+	switch req.Operation {
+	case v1.DispatchQueryPlanRequest_CHECK:
+		resources := make([]query.Object, 0, len(req.ResourceIds))
+		for _, resourceID := range req.ResourceIds {
+			resources = append(resources, query.NewObject(req.ResourceType, resourceID))
+		}
+		pathSeq, err = it.CheckImpl(qctx, resources, tuple.FromCoreObjectAndRelation(req.Subject))
+
+	case v1.DispatchQueryPlanRequest_ITER_SUBJECTS:
+		if len(req.ResourceIds) != 1 {
+			return nil, fmt.Errorf("expected a single resource to iterate subjects, found %d", len(req.ResourceIds))
+		}
+		pathSeq, err = it.IterSubjectsImpl(qctx, query.NewObject(req.ResourceType, req.ResourceIds[0]))
+
+	case v1.DispatchQueryPlanRequest_ITER_RESOURCES:
+		pathSeq, err = it.IterResourcesImpl(qctx, tuple.FromCoreObjectAndRelation(req.Subject))
+
+	default:
+		return nil, spiceerrors.MustBugf("unknown query plan operation: %v", req.Operation)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := query.CollectAll(pathSeq)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &v1.DispatchQueryPlanResponse{
+		Metadata: addCallToResponseMetadata(&v1.ResponseMeta{
+			DispatchCount: executor.DispatchCount(),
+			DepthRequired: executor.DepthRequired(),
+		}),
+		Paths: make([]*v1.QueryPlanPath, 0, len(paths)),
+	}
+	if qctx.Analyzer != nil {
+		// The root of the subtree is analyzed by the caller; the analysis returned is that of
+		// the subiterators it evaluated.
+		resp.Metadata.DebugInfo = &v1.DebugInformation{
+			QueryPlanAnalysis: qctx.Analyzer.Analysis(),
+		}
+	}
+	for _, path := range paths {
+		resp.Paths = append(resp.Paths, query.PathToProto(path))
+	}
+	return resp, nil
+}
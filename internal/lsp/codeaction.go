@@ -0,0 +1,128 @@
+package lsp
+
+import (
+	"context"
+
+	"github.com/jzelinskie/persistent"
+	"github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/jsonrpc2"
+
+	"github.com/authzed/spicedb/pkg/development"
+	developerv1 "github.com/authzed/spicedb/pkg/proto/developer/v1"
+)
+
+func (s *Server) textDocCodeAction(ctx context.Context, r *jsonrpc2.Request) ([]CodeAction, error) {
+	params, err := unmarshalParams[lsp.CodeActionParams](r)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]CodeAction, 0)
+	err = s.withFiles(func(files *persistent.Map[lsp.DocumentURI, trackedFile]) error {
+		file, ok := files.Get(params.TextDocument.URI)
+		if !ok {
+			return &jsonrpc2.Error{Code: jsonrpc2.CodeInternalError, Message: "file not found"}
+		}
+
+		if isValidationFile(params.TextDocument.URI) {
+			found, err := validationFileCodeActions(ctx, params.TextDocument.URI, file.contents)
+			if err != nil {
+				return err
+			}
+
+			actions = append(actions, found...)
+			return nil
+		}
+
+		found, err := schemaCodeActions(ctx, params.TextDocument.URI, file.contents, params.Range)
+		if err != nil {
+			return err
+		}
+
+		actions = append(actions, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return actions, nil
+}
+
+// schemaCodeActions returns the quick fixes suggested for the warnings found on the lines of the
+// range in the schema. No fixes are returned if the schema has errors.
+func schemaCodeActions(ctx context.Context, uri lsp.DocumentURI, contents string, actionRange lsp.Range) ([]CodeAction, error) {
+	devCtx, devErrs, err := development.NewDevContext(ctx, &developerv1.RequestContext{
+		Schema: contents,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if devErrs != nil {
+		return nil, nil
+	}
+	defer devCtx.Dispose()
+
+	warnings, err := development.GetWarnings(ctx, devCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []CodeAction
+	for _, devWarning := range warnings {
+		diagnostic := warningDiagnostic(devWarning)
+		if diagnostic.Range.Start.Line < actionRange.Start.Line || diagnostic.Range.Start.Line > actionRange.End.Line {
+			continue
+		}
+
+		for _, fix := range devWarning.SuggestedFixes {
+			edits := make([]lsp.TextEdit, 0, len(fix.Edits))
+			for _, edit := range fix.Edits {
+				edits = append(edits, lsp.TextEdit{
+					Range: lsp.Range{
+						Start: lsp.Position{Line: int(edit.Line) - 1, Character: int(edit.Column) - 1},
+						End:   lsp.Position{Line: int(edit.EndLine) - 1, Character: int(edit.EndColumn) - 1},
+					},
+					NewText: edit.NewText,
+				})
+			}
+
+			actions = append(actions, CodeAction{
+				Title:       fix.Description,
+				Kind:        lsp.CAKQuickFix,
+				Diagnostics: []lsp.Diagnostic{diagnostic},
+				Edit: &lsp.WorkspaceEdit{
+					Changes: map[string][]lsp.TextEdit{
+						string(uri): edits,
+					},
+				},
+			})
+		}
+	}
+
+	return actions, nil
+}
+
+var warningSeverities = map[developerv1.DeveloperWarning_Severity]lsp.DiagnosticSeverity{
+	developerv1.DeveloperWarning_WARNING: lsp.Warning,
+	developerv1.DeveloperWarning_ERROR:   lsp.Error,
+	developerv1.DeveloperWarning_INFO:    lsp.Information,
+}
+
+// warningDiagnostic returns the diagnostic reporting the warning, with its configured severity.
+func warningDiagnostic(devWarning *developerv1.DeveloperWarning) lsp.Diagnostic {
+	severity, ok := warningSeverities[devWarning.Severity]
+	if !ok {
+		severity = lsp.Warning
+	}
+
+	return lsp.Diagnostic{
+		Severity: severity,
+		Range: lsp.Range{
+			Start: lsp.Position{Line: int(devWarning.Line) - 1, Character: int(devWarning.Column) - 1},
+			End:   lsp.Position{Line: int(devWarning.Line) - 1, Character: int(devWarning.Column) - 1},
+		},
+		Message: devWarning.Message,
+	}
+}
@@ -0,0 +1,71 @@
+package lsp
+
+import (
+	"context"
+
+	"github.com/jzelinskie/persistent"
+	"github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/jsonrpc2"
+
+	"github.com/authzed/spicedb/pkg/development"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+)
+
+// completionTriggerCharacters are the characters after which a name is expected in a schema.
+var completionTriggerCharacters = []string{":", "#", ">", "(", "|", " "}
+
+var completionKinds = map[development.ReferenceType]lsp.CompletionItemKind{
+	development.ReferenceTypeDefinition: lsp.CIKClass,
+	development.ReferenceTypeCaveat:     lsp.CIKFunction,
+	development.ReferenceTypeRelation:   lsp.CIKField,
+	development.ReferenceTypePermission: lsp.CIKMethod,
+}
+
+func (s *Server) textDocCompletion(_ context.Context, r *jsonrpc2.Request) (*lsp.CompletionList, error) {
+	params, err := unmarshalParams[lsp.CompletionParams](r)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]lsp.CompletionItem, 0)
+	if isValidationFile(params.TextDocument.URI) {
+		return &lsp.CompletionList{Items: items}, nil
+	}
+
+	err = s.withFiles(func(files *persistent.Map[lsp.DocumentURI, trackedFile]) error {
+		// The contents are used directly, as they are rarely valid while being written.
+		file, ok := files.Get(params.TextDocument.URI)
+		if !ok {
+			return &jsonrpc2.Error{Code: jsonrpc2.CodeInternalError, Message: "file not found"}
+		}
+
+		position := input.Position{
+			LineNumber:     params.Position.Line,
+			ColumnPosition: params.Position.Character,
+		}
+
+		candidates, err := development.CompletionsAtPosition(file.contents, sourceFolderOf(params.TextDocument.URI), position)
+		if err != nil {
+			return invalidParams(err)
+		}
+
+		for _, candidate := range candidates {
+			detail := symbolDetails[candidate.ReferenceType]
+			if candidate.DefinitionName != "" {
+				detail += " in " + candidate.DefinitionName
+			}
+
+			items = append(items, lsp.CompletionItem{
+				Label:  candidate.Name,
+				Kind:   completionKinds[candidate.ReferenceType],
+				Detail: detail,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &lsp.CompletionList{Items: items}, nil
+}
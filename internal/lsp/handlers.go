@@ -57,6 +57,16 @@ func (s *Server) computeDiagnostics(ctx context.Context, uri lsp.DocumentURI) ([
 			return &jsonrpc2.Error{Code: jsonrpc2.CodeInternalError, Message: "file not found"}
 		}
 
+		if isValidationFile(uri) {
+			validationDiagnostics, err := validationFileDiagnostics(ctx, file.contents)
+			if err != nil {
+				return err
+			}
+
+			diagnostics = append(diagnostics, validationDiagnostics...)
+			return nil
+		}
+
 		devCtx, devErrs, err := development.NewDevContext(ctx, &developerv1.RequestContext{
 			Schema:        file.contents,
 			Relationships: nil,
@@ -85,14 +95,7 @@ func (s *Server) computeDiagnostics(ctx context.Context, uri lsp.DocumentURI) ([
 			}
 
 			for _, devWarning := range warnings {
-				diagnostics = append(diagnostics, lsp.Diagnostic{
-					Severity: lsp.Warning,
-					Range: lsp.Range{
-						Start: lsp.Position{Line: int(devWarning.Line) - 1, Character: int(devWarning.Column) - 1},
-						End:   lsp.Position{Line: int(devWarning.Line) - 1, Character: int(devWarning.Column) - 1},
-					},
-					Message: devWarning.Message,
-				})
+				diagnostics = append(diagnostics, warningDiagnostic(devWarning))
 			}
 		}
 
@@ -232,7 +235,7 @@ func (s *Server) textDocHover(_ context.Context, r *jsonrpc2.Request) (*Hover, e
 			ColumnPosition: params.Position.Character,
 		}
 
-		resolved, err := resolver.ReferenceAtPosition(input.Source("schema"), position)
+		resolved, err := resolver.ReferenceAtPosition(schemaSource, position)
 		if err != nil {
 			return err
 		}
@@ -288,6 +291,11 @@ func (s *Server) textDocFormat(_ context.Context, r *jsonrpc2.Request) ([]lsp.Te
 		return nil, err
 	}
 
+	// Validation files are not formatted.
+	if isValidationFile(params.TextDocument.URI) {
+		return nil, nil
+	}
+
 	var formatted string
 	err = s.withFiles(func(files *persistent.Map[lsp.DocumentURI, trackedFile]) error {
 		compiled, err := s.getCompiledContents(params.TextDocument.URI, files)
@@ -349,10 +357,15 @@ func (s *Server) initialize(_ context.Context, r *jsonrpc2.Request) (any, error)
 	return InitializeResult{
 		Capabilities: ServerCapabilities{
 			TextDocumentSync:           &lsp.TextDocumentSyncOptionsOrKind{Kind: &syncKind},
-			CompletionProvider:         &lsp.CompletionOptions{TriggerCharacters: []string{"."}},
+			CompletionProvider:         &lsp.CompletionOptions{TriggerCharacters: completionTriggerCharacters},
 			DocumentFormattingProvider: true,
 			DiagnosticProvider:         &DiagnosticOptions{Identifier: "spicedb", InterFileDependencies: false, WorkspaceDiagnostics: false},
 			HoverProvider:              true,
+			DefinitionProvider:         true,
+			ReferencesProvider:         true,
+			RenameProvider:             true,
+			DocumentSymbolProvider:     true,
+			CodeActionProvider:         true,
 		},
 	}, nil
 }
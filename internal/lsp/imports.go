@@ -0,0 +1,218 @@
+package lsp
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/jzelinskie/persistent"
+	"github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/jsonrpc2"
+
+	composablecompiler "github.com/authzed/spicedb/pkg/composableschemadsl/compiler"
+	composableinput "github.com/authzed/spicedb/pkg/composableschemadsl/input"
+	"github.com/authzed/spicedb/pkg/development"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+)
+
+// schemaFile is a file of the import graph of a schema.
+type schemaFile struct {
+	uri lsp.DocumentURI
+
+	// contents are the contents of the file as compiled, with its import statements blanked
+	// out, so that positions within them match those within the file.
+	contents string
+
+	compiled *compiler.CompiledSchema
+
+	// occurrences are the occurrences of the symbols found within the file, resolved against
+	// the definitions and caveats of the whole import graph.
+	occurrences []development.SymbolOccurrence
+}
+
+// importedFile is a file read while walking the imports of a schema.
+type importedFile struct {
+	contents string
+	imports  []composablecompiler.ImportStatement
+}
+
+// importGraph walks the imports of schema files, reading each from the open documents if found
+// there, or from the filesystem otherwise.
+type importGraph struct {
+	files *persistent.Map[lsp.DocumentURI, trackedFile]
+
+	// openURIs maps the path of each open document to its URI.
+	openURIs map[string]lsp.DocumentURI
+
+	read map[lsp.DocumentURI]*importedFile
+}
+
+func newImportGraph(files *persistent.Map[lsp.DocumentURI, trackedFile]) *importGraph {
+	openURIs := make(map[string]lsp.DocumentURI)
+	files.Range(func(uri lsp.DocumentURI, _ trackedFile) {
+		if path, ok := pathOf(uri); ok {
+			openURIs[path] = uri
+		}
+	})
+
+	return &importGraph{files: files, openURIs: openURIs, read: make(map[lsp.DocumentURI]*importedFile)}
+}
+
+// schemaFilesOf returns the compiled files of the import graph of the document, with the
+// document first. The graph is made of the files imported by the document, directly or
+// transitively, along with any open document whose imports share a file with them.
+func (ig *importGraph) schemaFilesOf(uri lsp.DocumentURI) ([]*schemaFile, error) {
+	connected, err := ig.closureOf(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	var closures [][]lsp.DocumentURI
+	ig.files.Range(func(openURI lsp.DocumentURI, _ trackedFile) {
+		if isValidationFile(openURI) || slices.Contains(connected, openURI) {
+			return
+		}
+
+		// Open documents whose imports cannot be read are not part of the graph.
+		if closure, err := ig.closureOf(openURI); err == nil {
+			closures = append(closures, closure)
+		}
+	})
+
+	for added := true; added; {
+		added = false
+		for index, closure := range closures {
+			if closure == nil || !slices.ContainsFunc(closure, func(uri lsp.DocumentURI) bool { return slices.Contains(connected, uri) }) {
+				continue
+			}
+
+			for _, closureURI := range closure {
+				if !slices.Contains(connected, closureURI) {
+					connected = append(connected, closureURI)
+				}
+			}
+			closures[index] = nil
+			added = true
+		}
+	}
+	slices.Sort(connected[1:])
+
+	schemaFiles := make([]*schemaFile, 0, len(connected))
+	for _, connectedURI := range connected {
+		schemaFile, err := ig.compile(connectedURI)
+		if err != nil {
+			return nil, err
+		}
+		schemaFiles = append(schemaFiles, schemaFile)
+	}
+
+	compiledSchemas := make([]*compiler.CompiledSchema, 0, len(schemaFiles))
+	for _, schemaFile := range schemaFiles {
+		compiledSchemas = append(compiledSchemas, schemaFile.compiled)
+	}
+
+	for index, schemaFile := range schemaFiles {
+		others := slices.Delete(slices.Clone(compiledSchemas), index, index+1)
+		resolver, err := development.NewResolverWithImports(schemaFile.compiled, others)
+		if err != nil {
+			return nil, err
+		}
+
+		schemaFile.occurrences, err = resolver.SymbolOccurrences(schemaSource)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return schemaFiles, nil
+}
+
+// closureOf returns the document and the files it imports, directly or transitively.
+func (ig *importGraph) closureOf(uri lsp.DocumentURI) ([]lsp.DocumentURI, error) {
+	closure := []lsp.DocumentURI{uri}
+	for index := 0; index < len(closure); index++ {
+		file, err := ig.readFile(closure[index])
+		if err != nil {
+			return nil, err
+		}
+
+		sourceFolder := sourceFolderOf(closure[index])
+		for _, statement := range file.imports {
+			importedURI := ig.uriOf(filepath.Join(sourceFolder, statement.Path))
+			if !slices.Contains(closure, importedURI) {
+				closure = append(closure, importedURI)
+			}
+		}
+	}
+	return closure, nil
+}
+
+// readFile returns the contents and import statements of the file.
+func (ig *importGraph) readFile(uri lsp.DocumentURI) (*importedFile, error) {
+	if file, ok := ig.read[uri]; ok {
+		return file, nil
+	}
+
+	var contents string
+	if tracked, ok := ig.files.Get(uri); ok {
+		contents = tracked.contents
+	} else {
+		path, ok := pathOf(uri)
+		if !ok {
+			return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInternalError, Message: "file not found"}
+		}
+
+		read, err := os.ReadFile(path)
+		if err != nil {
+			return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInternalError, Message: "failed to read imported file: " + err.Error()}
+		}
+		contents = string(read)
+	}
+
+	imports, err := composablecompiler.ImportStatements(composableinput.Source(uri), contents)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &importedFile{contents: contents, imports: imports}
+	ig.read[uri] = file
+	return file, nil
+}
+
+// compile compiles the file, with its import statements blanked out.
+func (ig *importGraph) compile(uri lsp.DocumentURI) (*schemaFile, error) {
+	file, err := ig.readFile(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	runes := []rune(file.contents)
+	for _, statement := range file.imports {
+		for index := statement.StartRune; index <= statement.EndRune && index < len(runes); index++ {
+			if runes[index] != '\n' {
+				runes[index] = ' '
+			}
+		}
+	}
+	contents := string(runes)
+
+	compiled, derr, err := development.CompileSchema(contents)
+	if err != nil {
+		return nil, err
+	}
+	if derr != nil {
+		return nil, &jsonrpc2.Error{Code: jsonrpc2.CodeInternalError, Message: derr.String()}
+	}
+
+	return &schemaFile{uri: uri, contents: contents, compiled: compiled}, nil
+}
+
+// uriOf returns the URI of the file found at the path, which is that of the open document for
+// the path, if any.
+func (ig *importGraph) uriOf(path string) lsp.DocumentURI {
+	if uri, ok := ig.openURIs[path]; ok {
+		return uri
+	}
+	return lsp.DocumentURI((&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String())
+}
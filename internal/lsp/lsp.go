@@ -96,6 +96,18 @@ func (s *Server) handle(ctx context.Context, conn *jsonrpc2.Conn, r *jsonrpc2.Re
 		result, err = s.textDocFormat(ctx, r)
 	case "textDocument/hover":
 		result, err = s.textDocHover(ctx, r)
+	case "textDocument/completion":
+		result, err = s.textDocCompletion(ctx, r)
+	case "textDocument/definition":
+		result, err = s.textDocDefinition(ctx, r)
+	case "textDocument/references":
+		result, err = s.textDocReferences(ctx, r)
+	case "textDocument/rename":
+		result, err = s.textDocRename(ctx, r)
+	case "textDocument/documentSymbol":
+		result, err = s.textDocDocumentSymbol(ctx, r)
+	case "textDocument/codeAction":
+		result, err = s.textDocCodeAction(ctx, r)
 	default:
 		log.Ctx(ctx).Warn().
 			Str("method", r.Method).
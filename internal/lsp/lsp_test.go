@@ -3,6 +3,9 @@ package lsp
 import (
 	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/sourcegraph/go-lsp"
@@ -415,3 +418,496 @@ func TestGetCompiledContentsFileNotFound(t *testing.T) {
 	require.Error(t, err)
 	require.Equal(t, int64(jsonrpc2.CodeInternalError), err.Code)
 }
+
+const navigationTestSchema = `definition user {}
+
+definition group {
+	relation member: user | group#member
+}
+
+definition document {
+	relation viewer: user | group#member
+	relation parent: group
+	permission view = viewer + parent->member
+}
+`
+
+func openNavigationTestSchema(tester *lspTester) {
+	sendAndReceive[any](tester, "textDocument/didOpen", lsp.DidOpenTextDocumentParams{
+		TextDocument: lsp.TextDocumentItem{
+			URI:        lsp.DocumentURI("file:///test"),
+			LanguageID: "test",
+			Version:    1,
+			Text:       navigationTestSchema,
+		},
+	})
+}
+
+func TestDocumentDefinition(t *testing.T) {
+	tester := newLSPTester(t)
+	tester.initialize()
+	openNavigationTestSchema(tester)
+
+	// The arrow target `member` is defined in `group`.
+	resp, _ := sendAndReceive[[]lsp.Location](tester, "textDocument/definition", lsp.TextDocumentPositionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: "file:///test"},
+		Position:     lsp.Position{Line: 9, Character: 37},
+	})
+	require.Equal(t, []lsp.Location{
+		{
+			URI: "file:///test",
+			Range: lsp.Range{
+				Start: lsp.Position{Line: 3, Character: 10},
+				End:   lsp.Position{Line: 3, Character: 16},
+			},
+		},
+	}, resp)
+
+	// No definition is found for a keyword.
+	resp, _ = sendAndReceive[[]lsp.Location](tester, "textDocument/definition", lsp.TextDocumentPositionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: "file:///test"},
+		Position:     lsp.Position{Line: 9, Character: 2},
+	})
+	require.Empty(t, resp)
+}
+
+func TestDocumentReferences(t *testing.T) {
+	tester := newLSPTester(t)
+	tester.initialize()
+	openNavigationTestSchema(tester)
+
+	position := lsp.TextDocumentPositionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: "file:///test"},
+		Position:     lsp.Position{Line: 3, Character: 12},
+	}
+
+	resp, _ := sendAndReceive[[]lsp.Location](tester, "textDocument/references", lsp.ReferenceParams{
+		TextDocumentPositionParams: position,
+	})
+	require.Equal(t, []lsp.Location{
+		{URI: "file:///test", Range: lsp.Range{Start: lsp.Position{Line: 3, Character: 31}, End: lsp.Position{Line: 3, Character: 37}}},
+		{URI: "file:///test", Range: lsp.Range{Start: lsp.Position{Line: 7, Character: 31}, End: lsp.Position{Line: 7, Character: 37}}},
+		{URI: "file:///test", Range: lsp.Range{Start: lsp.Position{Line: 9, Character: 36}, End: lsp.Position{Line: 9, Character: 42}}},
+	}, resp)
+
+	resp, _ = sendAndReceive[[]lsp.Location](tester, "textDocument/references", lsp.ReferenceParams{
+		TextDocumentPositionParams: position,
+		Context:                    lsp.ReferenceContext{IncludeDeclaration: true},
+	})
+	require.Len(t, resp, 4)
+	require.Equal(t, lsp.Position{Line: 3, Character: 10}, resp[0].Range.Start)
+}
+
+func TestDocumentRename(t *testing.T) {
+	tester := newLSPTester(t)
+	tester.initialize()
+	openNavigationTestSchema(tester)
+
+	resp, _ := sendAndReceive[lsp.WorkspaceEdit](tester, "textDocument/rename", lsp.RenameParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: "file:///test"},
+		Position:     lsp.Position{Line: 2, Character: 13},
+		NewName:      "team",
+	})
+	require.Equal(t, []lsp.TextEdit{
+		{Range: lsp.Range{Start: lsp.Position{Line: 2, Character: 11}, End: lsp.Position{Line: 2, Character: 16}}, NewText: "team"},
+		{Range: lsp.Range{Start: lsp.Position{Line: 3, Character: 25}, End: lsp.Position{Line: 3, Character: 30}}, NewText: "team"},
+		{Range: lsp.Range{Start: lsp.Position{Line: 7, Character: 25}, End: lsp.Position{Line: 7, Character: 30}}, NewText: "team"},
+		{Range: lsp.Range{Start: lsp.Position{Line: 8, Character: 18}, End: lsp.Position{Line: 8, Character: 23}}, NewText: "team"},
+	}, resp.Changes["file:///test"])
+
+	// Renaming to a name already in use is rejected.
+	lerr, _ := sendAndExpectError(tester, "textDocument/rename", lsp.RenameParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: "file:///test"},
+		Position:     lsp.Position{Line: 7, Character: 12},
+		NewName:      "parent",
+	})
+	require.Equal(t, int64(jsonrpc2.CodeInvalidParams), lerr.Code)
+
+	// Renaming to an invalid name is rejected.
+	lerr, _ = sendAndExpectError(tester, "textDocument/rename", lsp.RenameParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: "file:///test"},
+		Position:     lsp.Position{Line: 7, Character: 12},
+		NewName:      "not valid",
+	})
+	require.Equal(t, int64(jsonrpc2.CodeInvalidParams), lerr.Code)
+
+	// Renaming at a position without a symbol is rejected.
+	lerr, _ = sendAndExpectError(tester, "textDocument/rename", lsp.RenameParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: "file:///test"},
+		Position:     lsp.Position{Line: 7, Character: 2},
+		NewName:      "something",
+	})
+	require.Equal(t, int64(jsonrpc2.CodeInvalidRequest), lerr.Code)
+}
+
+func TestDocumentRenameAmbiguousArrow(t *testing.T) {
+	tester := newLSPTester(t)
+	tester.initialize()
+
+	tester.setFileContents("file:///test", `definition user {}
+
+definition folder {
+	relation viewer: user
+}
+
+definition organization {
+	relation viewer: user
+}
+
+definition document {
+	relation parent: folder | organization
+	permission view = parent->viewer
+}
+`)
+
+	lerr, _ := sendAndExpectError(tester, "textDocument/rename", lsp.RenameParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: "file:///test"},
+		Position:     lsp.Position{Line: 3, Character: 12},
+		NewName:      "reader",
+	})
+	require.Equal(t, int64(jsonrpc2.CodeInvalidRequest), lerr.Code)
+	require.Contains(t, lerr.Message, "referenced through an arrow along with `organization#viewer`")
+}
+
+func TestDocumentNavigationAcrossImports(t *testing.T) {
+	tester := newLSPTester(t)
+	tester.initialize()
+
+	dir := t.TempDir()
+	groupsContents := `definition user {}
+
+definition group {
+	relation member: user
+}
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "groups.zed"), []byte(groupsContents), 0o600))
+
+	groupsURI := lsp.DocumentURI("file://" + filepath.ToSlash(dir) + "/groups.zed")
+	documentURI := lsp.DocumentURI("file://" + filepath.ToSlash(dir) + "/document.zed")
+	sendAndReceive[any](tester, "textDocument/didOpen", lsp.DidOpenTextDocumentParams{
+		TextDocument: lsp.TextDocumentItem{
+			URI:        documentURI,
+			LanguageID: "test",
+			Version:    1,
+			Text: `import "groups.zed"
+
+definition document {
+	relation viewer: group#member
+	relation parent: group
+	permission view = viewer + parent->member
+}
+`,
+		},
+	})
+
+	memberDeclaration := lsp.Range{Start: lsp.Position{Line: 3, Character: 10}, End: lsp.Position{Line: 3, Character: 16}}
+
+	// The definition of `member` is found in the imported file, read from the filesystem.
+	locations, _ := sendAndReceive[[]lsp.Location](tester, "textDocument/definition", lsp.TextDocumentPositionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: documentURI},
+		Position:     lsp.Position{Line: 5, Character: 37},
+	})
+	require.Equal(t, []lsp.Location{{URI: groupsURI, Range: memberDeclaration}}, locations)
+
+	// Once opened, the references to `member` within the imported file are found in the
+	// documents importing it.
+	sendAndReceive[any](tester, "textDocument/didOpen", lsp.DidOpenTextDocumentParams{
+		TextDocument: lsp.TextDocumentItem{
+			URI:        groupsURI,
+			LanguageID: "test",
+			Version:    1,
+			Text:       groupsContents,
+		},
+	})
+
+	locations, _ = sendAndReceive[[]lsp.Location](tester, "textDocument/references", lsp.ReferenceParams{
+		TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+			TextDocument: lsp.TextDocumentIdentifier{URI: groupsURI},
+			Position:     lsp.Position{Line: 3, Character: 12},
+		},
+		Context: lsp.ReferenceContext{IncludeDeclaration: true},
+	})
+	require.Equal(t, []lsp.Location{
+		{URI: groupsURI, Range: memberDeclaration},
+		{URI: documentURI, Range: lsp.Range{Start: lsp.Position{Line: 3, Character: 24}, End: lsp.Position{Line: 3, Character: 30}}},
+		{URI: documentURI, Range: lsp.Range{Start: lsp.Position{Line: 5, Character: 36}, End: lsp.Position{Line: 5, Character: 42}}},
+	}, locations)
+
+	// Renaming `member` edits both files.
+	edit, _ := sendAndReceive[lsp.WorkspaceEdit](tester, "textDocument/rename", lsp.RenameParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: documentURI},
+		Position:     lsp.Position{Line: 5, Character: 37},
+		NewName:      "participant",
+	})
+	require.Equal(t, map[string][]lsp.TextEdit{
+		string(groupsURI): {
+			{Range: memberDeclaration, NewText: "participant"},
+		},
+		string(documentURI): {
+			{Range: lsp.Range{Start: lsp.Position{Line: 3, Character: 24}, End: lsp.Position{Line: 3, Character: 30}}, NewText: "participant"},
+			{Range: lsp.Range{Start: lsp.Position{Line: 5, Character: 36}, End: lsp.Position{Line: 5, Character: 42}}, NewText: "participant"},
+		},
+	}, edit.Changes)
+
+	// Renaming to a name already in use in the imported file is rejected.
+	lerr, _ := sendAndExpectError(tester, "textDocument/rename", lsp.RenameParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: documentURI},
+		Position:     lsp.Position{Line: 4, Character: 19},
+		NewName:      "user",
+	})
+	require.Equal(t, int64(jsonrpc2.CodeInvalidParams), lerr.Code)
+}
+
+func TestDocumentSymbols(t *testing.T) {
+	tester := newLSPTester(t)
+	tester.initialize()
+	openNavigationTestSchema(tester)
+
+	resp, _ := sendAndReceive[[]DocumentSymbol](tester, "textDocument/documentSymbol", lsp.DocumentSymbolParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: "file:///test"},
+	})
+	require.Len(t, resp, 3)
+
+	names := make([]string, 0, len(resp))
+	for _, symbol := range resp {
+		names = append(names, symbol.Name)
+		require.Equal(t, lsp.SKClass, symbol.Kind)
+	}
+	require.Equal(t, []string{"user", "group", "document"}, names)
+
+	document := resp[2]
+	require.Equal(t, lsp.Range{
+		Start: lsp.Position{Line: 6, Character: 0},
+		End:   lsp.Position{Line: 10, Character: 1},
+	}, document.Range)
+	require.Equal(t, lsp.Range{
+		Start: lsp.Position{Line: 6, Character: 11},
+		End:   lsp.Position{Line: 6, Character: 19},
+	}, document.SelectionRange)
+
+	require.Len(t, document.Children, 3)
+	require.Equal(t, "viewer", document.Children[0].Name)
+	require.Equal(t, lsp.SKField, document.Children[0].Kind)
+	require.Equal(t, "view", document.Children[2].Name)
+	require.Equal(t, lsp.SKMethod, document.Children[2].Kind)
+	require.Equal(t, "permission", document.Children[2].Detail)
+}
+
+func TestDocumentCompletion(t *testing.T) {
+	tester := newLSPTester(t)
+	tester.initialize()
+
+	sendAndReceive[any](tester, "textDocument/didOpen", lsp.DidOpenTextDocumentParams{
+		TextDocument: lsp.TextDocumentItem{
+			URI:        lsp.DocumentURI("file:///test"),
+			LanguageID: "test",
+			Version:    1,
+			Text: `definition user {}
+
+definition group {
+	relation member: user
+}
+
+definition document {
+	relation parent: group
+	permission view = parent->
+}`,
+		},
+	})
+
+	// The relations of the subject types of `parent` are completed after the arrow.
+	resp, _ := sendAndReceive[lsp.CompletionList](tester, "textDocument/completion", lsp.CompletionParams{
+		TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+			TextDocument: lsp.TextDocumentIdentifier{URI: "file:///test"},
+			Position:     lsp.Position{Line: 8, Character: 27},
+		},
+	})
+	require.Equal(t, []lsp.CompletionItem{
+		{Label: "member", Kind: lsp.CIKField, Detail: "relation in group"},
+	}, resp.Items)
+
+	// Definitions are completed for the type of a relation.
+	sendAndReceive[any](tester, "textDocument/didOpen", lsp.DidOpenTextDocumentParams{
+		TextDocument: lsp.TextDocumentItem{
+			URI:        lsp.DocumentURI("file:///other"),
+			LanguageID: "test",
+			Version:    1,
+			Text: `definition user {}
+
+definition group {
+	relation member: 
+}`,
+		},
+	})
+
+	resp, _ = sendAndReceive[lsp.CompletionList](tester, "textDocument/completion", lsp.CompletionParams{
+		TextDocumentPositionParams: lsp.TextDocumentPositionParams{
+			TextDocument: lsp.TextDocumentIdentifier{URI: "file:///other"},
+			Position:     lsp.Position{Line: 3, Character: 18},
+		},
+	})
+
+	labels := make([]string, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		labels = append(labels, item.Label)
+		require.Equal(t, lsp.CIKClass, item.Kind)
+	}
+	require.Equal(t, []string{"group", "user"}, labels)
+}
+
+const validationFileTestContents = `schema: |-
+  definition user {}
+
+  definition document {
+    relation viewer: user
+    permission view = viewer
+  }
+relationships: |-
+  document:firstdoc#viewer@user:tom
+assertions:
+  assertTrue:
+    - document:firstdoc#view@user:fred
+validation:
+  document:firstdoc#view:
+    - "[user:fred] is <document:firstdoc#viewer>"
+`
+
+func TestValidationFileDiagnostics(t *testing.T) {
+	tester := newLSPTester(t)
+	tester.initialize()
+
+	tester.setFileContents("file:///test.yaml", validationFileTestContents)
+
+	resp, _ := sendAndReceive[FullDocumentDiagnosticReport](tester, "textDocument/diagnostic",
+		TextDocumentDiagnosticParams{
+			TextDocument: TextDocument{URI: "file:///test.yaml"},
+		})
+	require.Equal(t, "full", resp.Kind)
+	require.Len(t, resp.Items, 3)
+
+	// The failing assertion is reported at its line.
+	require.Equal(t, lsp.Error, resp.Items[0].Severity)
+	require.Equal(t, "Expected relation or permission document:firstdoc#view@user:fred to exist", resp.Items[0].Message)
+	require.Equal(t, lsp.Range{
+		Start: lsp.Position{Line: 11, Character: 6},
+		End:   lsp.Position{Line: 11, Character: 38},
+	}, resp.Items[0].Range)
+
+	// Invalid relationships are reported at their line.
+	tester.setFileContents("file:///test.yaml", `schema: |-
+  definition user {}
+
+  definition document {
+    relation viewer: user
+  }
+relationships: |-
+  document:firstdoc#viewer@user:tom
+  document:firstdoc#editor@user:tom
+`)
+
+	resp, _ = sendAndReceive[FullDocumentDiagnosticReport](tester, "textDocument/diagnostic",
+		TextDocumentDiagnosticParams{
+			TextDocument: TextDocument{URI: "file:///test.yaml"},
+		})
+	require.Len(t, resp.Items, 1)
+	require.Equal(t, "relation/permission `editor` not found under definition `document`", resp.Items[0].Message)
+	require.Equal(t, lsp.Range{
+		Start: lsp.Position{Line: 8, Character: 2},
+		End:   lsp.Position{Line: 8, Character: 35},
+	}, resp.Items[0].Range)
+}
+
+func TestValidationFileCodeAction(t *testing.T) {
+	tester := newLSPTester(t)
+	tester.initialize()
+
+	tester.setFileContents("file:///test.yaml", validationFileTestContents)
+
+	resp, _ := sendAndReceive[[]CodeAction](tester, "textDocument/codeAction", lsp.CodeActionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: "file:///test.yaml"},
+	})
+	require.Len(t, resp, 1)
+	require.Equal(t, regenerateValidationTitle, resp[0].Title)
+	require.Equal(t, map[string][]lsp.TextEdit{
+		"file:///test.yaml": {
+			{
+				Range: lsp.Range{
+					Start: lsp.Position{Line: 12, Character: 0},
+					End:   lsp.Position{Line: 15, Character: 0},
+				},
+				NewText: "validation:\n  document:firstdoc#view:\n  - '[user:tom] is <document:firstdoc#viewer>'\n",
+			},
+		},
+	}, resp[0].Edit.Changes)
+
+	// No action is returned once the block is up to date.
+	tester.setFileContents("file:///test.yaml", strings.Replace(validationFileTestContents,
+		`    - "[user:fred] is <document:firstdoc#viewer>"`,
+		`  - '[user:tom] is <document:firstdoc#viewer>'`, 1))
+
+	resp, _ = sendAndReceive[[]CodeAction](tester, "textDocument/codeAction", lsp.CodeActionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: "file:///test.yaml"},
+	})
+	require.Empty(t, resp)
+
+	// No action is returned for schemas without warnings.
+	openNavigationTestSchema(tester)
+	resp, _ = sendAndReceive[[]CodeAction](tester, "textDocument/codeAction", lsp.CodeActionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: "file:///test"},
+	})
+	require.Empty(t, resp)
+}
+
+func TestSchemaQuickFix(t *testing.T) {
+	tester := newLSPTester(t)
+	tester.initialize()
+
+	tester.setFileContents("file:///test", `definition user {}
+
+definition document {
+	relation viewer: user
+	permission view = viewer + view
+}`)
+
+	// No fixes are returned for lines without warnings.
+	resp, _ := sendAndReceive[[]CodeAction](tester, "textDocument/codeAction", lsp.CodeActionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: "file:///test"},
+		Range:        lsp.Range{Start: lsp.Position{Line: 3}, End: lsp.Position{Line: 3}},
+	})
+	require.Empty(t, resp)
+
+	resp, _ = sendAndReceive[[]CodeAction](tester, "textDocument/codeAction", lsp.CodeActionParams{
+		TextDocument: lsp.TextDocumentIdentifier{URI: "file:///test"},
+		Range:        lsp.Range{Start: lsp.Position{Line: 4, Character: 29}, End: lsp.Position{Line: 4, Character: 29}},
+	})
+	require.Len(t, resp, 2)
+
+	require.Equal(t, `Remove reference to "view"`, resp[0].Title)
+	require.Equal(t, lsp.CAKQuickFix, resp[0].Kind)
+	require.Len(t, resp[0].Diagnostics, 1)
+	require.Equal(t, lsp.DiagnosticSeverity(lsp.Warning), resp[0].Diagnostics[0].Severity)
+	require.Equal(t, map[string][]lsp.TextEdit{
+		"file:///test": {
+			{
+				Range: lsp.Range{
+					Start: lsp.Position{Line: 4, Character: 19},
+					End:   lsp.Position{Line: 4, Character: 32},
+				},
+				NewText: "viewer",
+			},
+		},
+	}, resp[0].Edit.Changes)
+
+	require.Equal(t, `Ignore warning "permission-references-itself" for "view"`, resp[1].Title)
+	require.Equal(t, map[string][]lsp.TextEdit{
+		"file:///test": {
+			{
+				Range: lsp.Range{
+					Start: lsp.Position{Line: 4, Character: 1},
+					End:   lsp.Position{Line: 4, Character: 1},
+				},
+				NewText: "// spicedb-ignore-warning: permission-references-itself\n\t",
+			},
+		},
+	}, resp[1].Edit.Changes)
+}
@@ -14,6 +14,11 @@ type ServerCapabilities struct {
 	DocumentFormattingProvider bool                                   `json:"documentFormattingProvider,omitempty"`
 	DiagnosticProvider         *DiagnosticOptions                     `json:"diagnosticProvider,omitempty"`
 	HoverProvider              bool                                   `json:"hoverProvider,omitempty"`
+	DefinitionProvider         bool                                   `json:"definitionProvider,omitempty"`
+	ReferencesProvider         bool                                   `json:"referencesProvider,omitempty"`
+	RenameProvider             bool                                   `json:"renameProvider,omitempty"`
+	DocumentSymbolProvider     bool                                   `json:"documentSymbolProvider,omitempty"`
+	CodeActionProvider         bool                                   `json:"codeActionProvider,omitempty"`
 }
 
 type DiagnosticOptions struct {
@@ -72,3 +77,19 @@ type MarkupContent struct {
 	Language string `json:"language,omitempty"`
 	Value    string `json:"value"`
 }
+
+type DocumentSymbol struct {
+	Name           string             `json:"name"`
+	Detail         string             `json:"detail,omitempty"`
+	Kind           baselsp.SymbolKind `json:"kind"`
+	Range          baselsp.Range      `json:"range"`
+	SelectionRange baselsp.Range      `json:"selectionRange"`
+	Children       []DocumentSymbol   `json:"children,omitempty"`
+}
+
+type CodeAction struct {
+	Title       string                 `json:"title"`
+	Kind        baselsp.CodeActionKind `json:"kind,omitempty"`
+	Diagnostics []baselsp.Diagnostic   `json:"diagnostics,omitempty"`
+	Edit        *baselsp.WorkspaceEdit `json:"edit,omitempty"`
+}
@@ -0,0 +1,291 @@
+package lsp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jzelinskie/persistent"
+	"github.com/sourcegraph/go-lsp"
+	"github.com/sourcegraph/jsonrpc2"
+
+	"github.com/authzed/spicedb/pkg/development"
+	developerv1 "github.com/authzed/spicedb/pkg/proto/developer/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+)
+
+// schemaSource is the source under which the contents of files are compiled.
+const schemaSource = input.Source("schema")
+
+var symbolKinds = map[development.ReferenceType]lsp.SymbolKind{
+	development.ReferenceTypeDefinition: lsp.SKClass,
+	development.ReferenceTypeCaveat:     lsp.SKFunction,
+	development.ReferenceTypeRelation:   lsp.SKField,
+	development.ReferenceTypePermission: lsp.SKMethod,
+}
+
+var symbolDetails = map[development.ReferenceType]string{
+	development.ReferenceTypeDefinition: "definition",
+	development.ReferenceTypeCaveat:     "caveat",
+	development.ReferenceTypeRelation:   "relation",
+	development.ReferenceTypePermission: "permission",
+}
+
+func (s *Server) textDocDefinition(_ context.Context, r *jsonrpc2.Request) ([]lsp.Location, error) {
+	params, err := unmarshalParams[lsp.TextDocumentPositionParams](r)
+	if err != nil {
+		return nil, err
+	}
+
+	var locations []lsp.Location
+	err = s.withSymbolsAtPosition(params.TextDocument.URI, params.Position, func(schemaFiles []*schemaFile, atPosition []development.SchemaSymbol) error {
+		for _, schemaFile := range schemaFiles {
+			for _, occurrence := range schemaFile.occurrences {
+				if occurrence.IsDeclaration && slices.Contains(atPosition, occurrence.Symbol) {
+					locations = append(locations, lsp.Location{
+						URI:   schemaFile.uri,
+						Range: toLSPRange(occurrence.NameRange),
+					})
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+func (s *Server) textDocReferences(_ context.Context, r *jsonrpc2.Request) ([]lsp.Location, error) {
+	params, err := unmarshalParams[lsp.ReferenceParams](r)
+	if err != nil {
+		return nil, err
+	}
+
+	var locations []lsp.Location
+	err = s.withSymbolsAtPosition(params.TextDocument.URI, params.Position, func(schemaFiles []*schemaFile, atPosition []development.SchemaSymbol) error {
+		for _, schemaFile := range schemaFiles {
+			for _, occurrence := range schemaFile.occurrences {
+				if occurrence.IsDeclaration && !params.Context.IncludeDeclaration {
+					continue
+				}
+
+				if !slices.Contains(atPosition, occurrence.Symbol) {
+					continue
+				}
+
+				// A reference through an arrow may be an occurrence of more than one of the symbols.
+				location := lsp.Location{URI: schemaFile.uri, Range: toLSPRange(occurrence.NameRange)}
+				if !slices.Contains(locations, location) {
+					locations = append(locations, location)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return locations, nil
+}
+
+func (s *Server) textDocRename(ctx context.Context, r *jsonrpc2.Request) (*lsp.WorkspaceEdit, error) {
+	params, err := unmarshalParams[lsp.RenameParams](r)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string][]lsp.TextEdit)
+	err = s.withSymbolsAtPosition(params.TextDocument.URI, params.Position, func(schemaFiles []*schemaFile, atPosition []development.SchemaSymbol) error {
+		if len(atPosition) == 0 {
+			return invalidRequest(errors.New("no definition, caveat, relation or permission found to rename"))
+		}
+
+		if len(atPosition) > 1 {
+			return invalidRequest(fmt.Errorf("cannot rename `%s`, as it refers to more than one relation or permission", atPosition[0].Name()))
+		}
+
+		symbol := atPosition[0]
+		renamed := make(map[lsp.DocumentURI][]development.SourceRange, len(schemaFiles))
+		for _, schemaFile := range schemaFiles {
+			for _, occurrence := range schemaFile.occurrences {
+				if occurrence.Symbol == symbol {
+					renamed[schemaFile.uri] = append(renamed[schemaFile.uri], occurrence.NameRange)
+				}
+			}
+		}
+
+		// Renaming a reference through an arrow which also refers to another symbol would
+		// change the meaning of the schema.
+		for _, schemaFile := range schemaFiles {
+			for _, occurrence := range schemaFile.occurrences {
+				if occurrence.Symbol != symbol && slices.Contains(renamed[schemaFile.uri], occurrence.NameRange) {
+					return invalidRequest(fmt.Errorf("cannot rename `%s`, as it is referenced through an arrow along with `%s#%s`", symbol.Name(), occurrence.Symbol.DefinitionName, occurrence.Symbol.RelationName))
+				}
+			}
+		}
+
+		// Ensure the schema, made of all the files of the import graph, remains valid once renamed.
+		updated := make([]string, 0, len(schemaFiles))
+		for _, schemaFile := range schemaFiles {
+			updatedContents, err := applyRenames(schemaFile.contents, schemaFile.compiled, renamed[schemaFile.uri], params.NewName)
+			if err != nil {
+				return err
+			}
+			updated = append(updated, updatedContents)
+		}
+
+		_, devErrs, err := development.NewDevContext(ctx, &developerv1.RequestContext{Schema: strings.Join(updated, "\n\n")})
+		if err != nil {
+			return err
+		}
+		if inputErrs := devErrs.GetInputErrors(); len(inputErrs) > 0 {
+			return invalidParams(fmt.Errorf("cannot rename `%s` to `%s`: %s", symbol.Name(), params.NewName, inputErrs[0].Message))
+		}
+
+		for _, schemaFile := range schemaFiles {
+			for _, renamedRange := range renamed[schemaFile.uri] {
+				changes[string(schemaFile.uri)] = append(changes[string(schemaFile.uri)], lsp.TextEdit{Range: toLSPRange(renamedRange), NewText: params.NewName})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &lsp.WorkspaceEdit{Changes: changes}, nil
+}
+
+func (s *Server) textDocDocumentSymbol(_ context.Context, r *jsonrpc2.Request) ([]DocumentSymbol, error) {
+	params, err := unmarshalParams[lsp.DocumentSymbolParams](r)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols := make([]DocumentSymbol, 0)
+	err = s.withFiles(func(files *persistent.Map[lsp.DocumentURI, trackedFile]) error {
+		if _, ok := files.Get(params.TextDocument.URI); !ok {
+			return &jsonrpc2.Error{Code: jsonrpc2.CodeInternalError, Message: "file not found"}
+		}
+
+		// Import statements are blanked out, as only the declarations of the document are needed.
+		schemaFile, err := newImportGraph(files).compile(params.TextDocument.URI)
+		if err != nil {
+			return err
+		}
+
+		resolver, err := development.NewResolver(schemaFile.compiled)
+		if err != nil {
+			return err
+		}
+
+		occurrences, err := resolver.SymbolOccurrences(schemaSource)
+		if err != nil {
+			return err
+		}
+
+		for _, occurrence := range occurrences {
+			if !occurrence.IsDeclaration {
+				continue
+			}
+
+			documentSymbol := DocumentSymbol{
+				Name:           occurrence.Symbol.Name(),
+				Detail:         symbolDetails[occurrence.Symbol.ReferenceType],
+				Kind:           symbolKinds[occurrence.Symbol.ReferenceType],
+				Range:          toLSPRange(occurrence.DeclarationRange),
+				SelectionRange: toLSPRange(occurrence.NameRange),
+			}
+
+			// Relations and permissions are found under their definition, which is always
+			// declared before them.
+			if occurrence.Symbol.RelationName != "" && len(symbols) > 0 {
+				parent := &symbols[len(symbols)-1]
+				parent.Children = append(parent.Children, documentSymbol)
+				continue
+			}
+
+			symbols = append(symbols, documentSymbol)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return symbols, nil
+}
+
+// withSymbolsAtPosition invokes the function with the files of the import graph of the document,
+// the document first, and the symbols found at the position within the document.
+func (s *Server) withSymbolsAtPosition(uri lsp.DocumentURI, position lsp.Position, fn func(schemaFiles []*schemaFile, atPosition []development.SchemaSymbol) error) error {
+	return s.withFiles(func(files *persistent.Map[lsp.DocumentURI, trackedFile]) error {
+		if _, ok := files.Get(uri); !ok {
+			return &jsonrpc2.Error{Code: jsonrpc2.CodeInternalError, Message: "file not found"}
+		}
+
+		schemaFiles, err := newImportGraph(files).schemaFilesOf(uri)
+		if err != nil {
+			return err
+		}
+
+		inputPosition := input.Position{LineNumber: position.Line, ColumnPosition: position.Character}
+		var atPosition []development.SchemaSymbol
+		for _, occurrence := range schemaFiles[0].occurrences {
+			if occurrence.NameRange.Contains(inputPosition) && !slices.Contains(atPosition, occurrence.Symbol) {
+				atPosition = append(atPosition, occurrence.Symbol)
+			}
+		}
+
+		return fn(schemaFiles, atPosition)
+	})
+}
+
+// applyRenames returns the contents with the name found at each of the ranges replaced by the
+// new name.
+func applyRenames(contents string, compiled *compiler.CompiledSchema, ranges []development.SourceRange, newName string) (string, error) {
+	runes := []rune(contents)
+	updated := make([]rune, 0, len(runes))
+	lastRune := 0
+
+	sorted := slices.Clone(ranges)
+	slices.SortFunc(sorted, func(a, b development.SourceRange) int {
+		if a.Start.LineNumber != b.Start.LineNumber {
+			return a.Start.LineNumber - b.Start.LineNumber
+		}
+		return a.Start.ColumnPosition - b.Start.ColumnPosition
+	})
+
+	for _, nameRange := range sorted {
+		startRune, err := compiled.SourcePositionToRunePosition(schemaSource, nameRange.Start)
+		if err != nil {
+			return "", err
+		}
+
+		endRune := startRune + nameRange.End.ColumnPosition - nameRange.Start.ColumnPosition
+		if startRune < lastRune || endRune > len(runes) {
+			return "", &jsonrpc2.Error{Code: jsonrpc2.CodeInternalError, Message: "invalid rename range"}
+		}
+
+		updated = append(updated, runes[lastRune:startRune]...)
+		updated = append(updated, []rune(newName)...)
+		lastRune = endRune
+	}
+
+	updated = append(updated, runes[lastRune:]...)
+	return string(updated), nil
+}
+
+func toLSPRange(sourceRange development.SourceRange) lsp.Range {
+	return lsp.Range{
+		Start: lsp.Position{Line: sourceRange.Start.LineNumber, Character: sourceRange.Start.ColumnPosition},
+		End:   lsp.Position{Line: sourceRange.End.LineNumber, Character: sourceRange.End.ColumnPosition},
+	}
+}
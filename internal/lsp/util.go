@@ -4,8 +4,11 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"net/url"
 	"os"
+	"path/filepath"
 
+	"github.com/sourcegraph/go-lsp"
 	"github.com/sourcegraph/jsonrpc2"
 )
 
@@ -38,6 +41,24 @@ func invalidRequest(err error) *jsonrpc2.Error {
 	}
 }
 
+// pathOf returns the path of the document on the filesystem, if it is a file.
+func pathOf(uri lsp.DocumentURI) (string, bool) {
+	parsed, err := url.Parse(string(uri))
+	if err != nil || parsed.Scheme != "file" {
+		return "", false
+	}
+	return filepath.FromSlash(parsed.Path), true
+}
+
+// sourceFolderOf returns the folder of the document, against which its imports are resolved.
+func sourceFolderOf(uri lsp.DocumentURI) string {
+	path, ok := pathOf(uri)
+	if !ok {
+		return ""
+	}
+	return filepath.Dir(path)
+}
+
 type stdrwc struct{}
 
 var _ io.ReadWriteCloser = (*stdrwc)(nil)
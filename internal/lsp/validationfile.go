@@ -0,0 +1,151 @@
+package lsp
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/sourcegraph/go-lsp"
+	yamlv3 "gopkg.in/yaml.v3"
+
+	"github.com/authzed/spicedb/pkg/development"
+	devinterface "github.com/authzed/spicedb/pkg/proto/developer/v1"
+)
+
+// regenerateValidationTitle is the title of the code action regenerating the `validation`
+// block of a validation file.
+const regenerateValidationTitle = "Regenerate validation block"
+
+// isValidationFile returns whether the file is a validation file, rather than a schema.
+func isValidationFile(uri lsp.DocumentURI) bool {
+	switch strings.ToLower(path.Ext(string(uri))) {
+	case ".yaml", ".yml":
+		return true
+
+	default:
+		return false
+	}
+}
+
+// validationFileDiagnostics returns the diagnostics for the input errors, failing assertions
+// and failing expected relations of the validation file.
+func validationFileDiagnostics(ctx context.Context, contents string) ([]lsp.Diagnostic, error) {
+	result, err := development.RunValidationFile(ctx, []byte(contents))
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(contents, "\n")
+	diagnostics := make([]lsp.Diagnostic, 0)
+	for _, devErrs := range [][]*devinterface.DeveloperError{result.InputErrors, result.AssertionErrors, result.ValidationErrors} {
+		for _, devErr := range devErrs {
+			diagnostics = append(diagnostics, lsp.Diagnostic{
+				Severity: lsp.Error,
+				Range:    validationFileRange(lines, devErr),
+				Message:  devErr.Message,
+			})
+		}
+	}
+	return diagnostics, nil
+}
+
+// validationFileRange returns the range of the error within the validation file, which spans
+// from its position to the end of its line.
+func validationFileRange(lines []string, devErr *devinterface.DeveloperError) lsp.Range {
+	line := max(int(devErr.Line)-1, 0)
+	start := max(int(devErr.Column)-1, 0)
+
+	end := start
+	if line < len(lines) {
+		end = max(len(strings.TrimRight(lines[line], " \t\r")), start)
+	}
+
+	return lsp.Range{
+		Start: lsp.Position{Line: line, Character: start},
+		End:   lsp.Position{Line: line, Character: end},
+	}
+}
+
+// validationFileCodeActions returns the code actions for the validation file, which regenerate
+// its `validation` block if it is out of date.
+func validationFileCodeActions(ctx context.Context, uri lsp.DocumentURI, contents string) ([]CodeAction, error) {
+	result, err := development.RunValidationFile(ctx, []byte(contents))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result.InputErrors) > 0 {
+		return nil, nil
+	}
+
+	edit, ok := validationBlockEdit(contents, result.UpdatedValidationYaml)
+	if !ok {
+		return nil, nil
+	}
+
+	return []CodeAction{{
+		Title: regenerateValidationTitle,
+		Kind:  lsp.CAKSource,
+		Edit: &lsp.WorkspaceEdit{
+			Changes: map[string][]lsp.TextEdit{
+				string(uri): {edit},
+			},
+		},
+	}}, nil
+}
+
+// validationBlockEdit returns the edit replacing the `validation` block of the validation file
+// with the generated block, if the file has such a block and it differs from that generated.
+func validationBlockEdit(contents string, generated string) (lsp.TextEdit, bool) {
+	if strings.TrimSpace(generated) == "{}" {
+		return lsp.TextEdit{}, false
+	}
+
+	var root yamlv3.Node
+	if err := yamlv3.Unmarshal([]byte(contents), &root); err != nil || len(root.Content) == 0 {
+		return lsp.TextEdit{}, false
+	}
+
+	mapping := root.Content[0]
+	if mapping.Kind != yamlv3.MappingNode {
+		return lsp.TextEdit{}, false
+	}
+
+	lines := strings.Split(contents, "\n")
+	for index := 0; index+1 < len(mapping.Content); index += 2 {
+		if mapping.Content[index].Value != "validation" {
+			continue
+		}
+
+		var updated strings.Builder
+		updated.WriteString("validation:\n")
+		for _, line := range strings.Split(strings.TrimRight(generated, "\n"), "\n") {
+			updated.WriteString("  " + line + "\n")
+		}
+
+		// The block extends to the next key of the file, if any, which remains separated from it
+		// by a blank line.
+		start := lsp.Position{Line: mapping.Content[index].Line - 1}
+		end := lsp.Position{Line: len(lines) - 1, Character: len(lines[len(lines)-1])}
+		if index+2 < len(mapping.Content) {
+			end = lsp.Position{Line: mapping.Content[index+2].Line - 1}
+			updated.WriteString("\n")
+		}
+
+		existing := strings.Join(lines[start.Line:end.Line], "\n")
+		if end.Line > start.Line {
+			existing += "\n"
+		}
+		existing += lines[end.Line][:end.Character]
+		if existing == updated.String() {
+			return lsp.TextEdit{}, false
+		}
+
+		return lsp.TextEdit{
+			Range:   lsp.Range{Start: start, End: end},
+			NewText: updated.String(),
+		}, true
+	}
+
+	return lsp.TextEdit{}, false
+}
@@ -0,0 +1,200 @@
+package tokenpolicy
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"golang.org/x/time/rate"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// FileConfig is the contents of a token policy file, such as:
+//
+//	tokens:
+//	  - token_sha256: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+//	    methods: ["CheckPermission", "CheckBulkPermissions"]
+//	    rate_limit:
+//	      requests_per_second: 100
+//	      burst: 200
+//	  - token: "some-admin-key"
+//	default:
+//	  methods: ["authzed.api.v1.PermissionsService/*"]
+type FileConfig struct {
+	// Tokens are the policies for specific tokens.
+	Tokens []TokenPolicy `yaml:"tokens"`
+
+	// Default, if specified, is the policy for tokens which have no policy of
+	// their own; its rate limit is shared by all such tokens. If unspecified,
+	// such tokens may not call any method.
+	Default *Policy `yaml:"default"`
+}
+
+// TokenPolicy is the policy for a single token, which is identified by either
+// its value or the hex-encoded SHA-256 hash of its value.
+type TokenPolicy struct {
+	Token       string `yaml:"token"`
+	TokenSHA256 string `yaml:"token_sha256"`
+	Policy      `yaml:",inline"`
+}
+
+// Policy is the set of methods a token may call and the rate at which it may
+// call them.
+type Policy struct {
+	// Methods are the gRPC methods which may be called, as either full gRPC
+	// method names, method names alone, `package.Service/*` or `*`. If empty,
+	// all methods may be called.
+	Methods []string `yaml:"methods"`
+
+	// RateLimit, if specified, limits the rate of calls made with the token.
+	RateLimit *RateLimit `yaml:"rate_limit"`
+}
+
+// RateLimit is a token bucket rate limit.
+type RateLimit struct {
+	// RequestsPerSecond is the rate at which the bucket is refilled.
+	RequestsPerSecond float64 `yaml:"requests_per_second"`
+
+	// Burst is the size of the bucket. Defaults to the requests per second,
+	// rounded up.
+	Burst int `yaml:"burst"`
+}
+
+// policies is a parsed policy file, with tokens keyed by their hash.
+type policies struct {
+	byTokenHash map[string]*compiledPolicy
+	fallback    *compiledPolicy
+}
+
+type compiledPolicy struct {
+	methods []string
+	limit   rate.Limit
+	burst   int
+	limiter *rate.Limiter
+}
+
+// ParseConfig parses and validates the contents of a token policy file.
+func ParseConfig(r io.Reader) (FileConfig, error) {
+	decoder := yamlv3.NewDecoder(r)
+	decoder.KnownFields(true)
+
+	var config FileConfig
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return FileConfig{}, fmt.Errorf("failed to parse token policy file: %w", err)
+	}
+
+	for index, tokenPolicy := range config.Tokens {
+		switch {
+		case tokenPolicy.Token == "" && tokenPolicy.TokenSHA256 == "":
+			return FileConfig{}, fmt.Errorf("token policy #%d must specify either token or token_sha256", index+1)
+		case tokenPolicy.Token != "" && tokenPolicy.TokenSHA256 != "":
+			return FileConfig{}, fmt.Errorf("token policy #%d must specify only one of token or token_sha256", index+1)
+		case tokenPolicy.TokenSHA256 != "":
+			if decoded, err := hex.DecodeString(tokenPolicy.TokenSHA256); err != nil || len(decoded) != sha256.Size {
+				return FileConfig{}, fmt.Errorf("token policy #%d has an invalid token_sha256", index+1)
+			}
+		}
+
+		if err := tokenPolicy.validate(); err != nil {
+			return FileConfig{}, fmt.Errorf("token policy #%d: %w", index+1, err)
+		}
+	}
+
+	if config.Default != nil {
+		if err := config.Default.validate(); err != nil {
+			return FileConfig{}, fmt.Errorf("default policy: %w", err)
+		}
+	}
+
+	return config, nil
+}
+
+func (p Policy) validate() error {
+	for _, method := range p.Methods {
+		if method == "" {
+			return errors.New("methods must be non-empty")
+		}
+	}
+
+	if p.RateLimit != nil {
+		if p.RateLimit.RequestsPerSecond <= 0 {
+			return errors.New("rate_limit.requests_per_second must be greater than zero")
+		}
+		if p.RateLimit.Burst < 0 {
+			return errors.New("rate_limit.burst must not be negative")
+		}
+	}
+
+	return nil
+}
+
+// compile converts the file config into policies. Rate limiters are carried
+// over from the previous policies for tokens whose rate limit is unchanged, so
+// that reloading the file does not refill their buckets.
+func (fc FileConfig) compile(previous *policies) (*policies, error) {
+	compiled := &policies{byTokenHash: make(map[string]*compiledPolicy, len(fc.Tokens))}
+	for _, tokenPolicy := range fc.Tokens {
+		tokenHash := strings.ToLower(tokenPolicy.TokenSHA256)
+		if tokenPolicy.Token != "" {
+			tokenHash = hashToken(tokenPolicy.Token)
+		}
+
+		if _, ok := compiled.byTokenHash[tokenHash]; ok {
+			return nil, errors.New("token policy file contains duplicate tokens")
+		}
+
+		var existing *compiledPolicy
+		if previous != nil {
+			existing = previous.byTokenHash[tokenHash]
+		}
+		compiled.byTokenHash[tokenHash] = tokenPolicy.compile(existing)
+	}
+
+	if fc.Default != nil {
+		var existing *compiledPolicy
+		if previous != nil {
+			existing = previous.fallback
+		}
+		compiled.fallback = fc.Default.compile(existing)
+	}
+
+	return compiled, nil
+}
+
+func (p Policy) compile(existing *compiledPolicy) *compiledPolicy {
+	compiled := &compiledPolicy{methods: p.Methods}
+	if p.RateLimit == nil {
+		return compiled
+	}
+
+	compiled.limit = rate.Limit(p.RateLimit.RequestsPerSecond)
+	compiled.burst = p.RateLimit.Burst
+	if compiled.burst == 0 {
+		compiled.burst = int(math.Ceil(p.RateLimit.RequestsPerSecond))
+	}
+
+	if existing != nil && existing.limiter != nil && existing.limit == compiled.limit && existing.burst == compiled.burst {
+		compiled.limiter = existing.limiter
+	} else {
+		compiled.limiter = rate.NewLimiter(compiled.limit, compiled.burst)
+	}
+	return compiled
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func parsePolicies(contents []byte, previous *policies) (*policies, error) {
+	config, err := ParseConfig(bytes.NewReader(contents))
+	if err != nil {
+		return nil, err
+	}
+	return config.compile(previous)
+}
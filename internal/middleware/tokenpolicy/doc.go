@@ -0,0 +1,3 @@
+// Package tokenpolicy defines middleware that restricts the gRPC methods which each bearer token may call,
+// and rate limits the calls made with each token, as configured by a hot-reloaded policy file.
+package tokenpolicy
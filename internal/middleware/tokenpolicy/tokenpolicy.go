@@ -0,0 +1,144 @@
+package tokenpolicy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+
+	grpcauth "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/authzed/spicedb/internal/auth"
+	log "github.com/authzed/spicedb/internal/logging"
+)
+
+const (
+	errMethodNotAllowed = "token is not permitted to call %s"
+	errRateLimited      = "rate limit exceeded for token"
+)
+
+// Middleware enforces the policies of a token policy file, which is reloaded
+// whenever its contents change.
+type Middleware struct {
+	path           string
+	reloadInterval time.Duration
+
+	current      atomic.Pointer[policies]
+	lastContents []byte
+}
+
+// NewMiddleware returns a new token policy middleware which loads its policies
+// from the file at the given path. Run must be called for changes to the file
+// to be picked up.
+func NewMiddleware(path string, reloadInterval time.Duration) (*Middleware, error) {
+	m := &Middleware{path: path, reloadInterval: reloadInterval}
+	if _, err := m.reload(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Run checks the policy file for changes at the reload interval, until the
+// context is canceled. If the changed file is invalid, the error is logged and
+// the previously loaded policies remain in effect.
+func (m *Middleware) Run(ctx context.Context) error {
+	ticker := time.NewTicker(m.reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-ticker.C:
+			changed, err := m.reload()
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Str("path", m.path).Msg("failed to reload token policy file; keeping previous policies")
+				continue
+			}
+
+			if changed {
+				log.Ctx(ctx).Info().Str("path", m.path).Msg("reloaded token policy file")
+			}
+		}
+	}
+}
+
+// reload loads the policy file if its contents have changed, returning whether
+// they have.
+func (m *Middleware) reload() (bool, error) {
+	contents, err := os.ReadFile(m.path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read token policy file: %w", err)
+	}
+
+	if m.lastContents != nil && bytes.Equal(contents, m.lastContents) {
+		return false, nil
+	}
+
+	loaded, err := parsePolicies(contents, m.current.Load())
+	if err != nil {
+		return false, err
+	}
+
+	m.current.Store(loaded)
+	m.lastContents = contents
+	return true, nil
+}
+
+// authorize returns an error if the token in the context may not call the
+// method, or has exceeded its rate limit.
+func (m *Middleware) authorize(ctx context.Context, fullMethod string) error {
+	current := m.current.Load()
+
+	// The token is not required to be present, as the auth middleware may allow
+	// requests without one; such requests are subject to the default policy.
+	token, _ := grpcauth.AuthFromMD(ctx, "bearer")
+	policy, ok := current.byTokenHash[hashToken(token)]
+	if !ok {
+		policy = current.fallback
+	}
+
+	if policy == nil || (len(policy.methods) > 0 && !auth.MethodAllowed(policy.methods, fullMethod)) {
+		return status.Errorf(codes.PermissionDenied, errMethodNotAllowed, fullMethod)
+	}
+
+	if policy.limiter != nil && !policy.limiter.Allow() {
+		return status.Error(codes.ResourceExhausted, errRateLimited)
+	}
+
+	return nil
+}
+
+// UnaryServerInterceptor returns a new unary server interceptor that enforces the token policies
+func (m *Middleware) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		// Services which override authentication, such as health checks, are
+		// not subject to token policies.
+		if _, ok := info.Server.(grpcauth.ServiceAuthFuncOverride); !ok {
+			if err := m.authorize(ctx, info.FullMethod); err != nil {
+				return nil, err
+			}
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a new stream server interceptor that enforces the token policies
+func (m *Middleware) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if _, ok := srv.(grpcauth.ServiceAuthFuncOverride); !ok {
+			if err := m.authorize(stream.Context(), info.FullMethod); err != nil {
+				return err
+			}
+		}
+
+		return handler(srv, stream)
+	}
+}
@@ -0,0 +1,227 @@
+package tokenpolicy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/authzed/grpcutil"
+)
+
+const (
+	checkPermissionMethod = "/authzed.api.v1.PermissionsService/CheckPermission"
+	writeSchemaMethod     = "/authzed.api.v1.SchemaService/WriteSchema"
+)
+
+func TestTokenPolicies(t *testing.T) {
+	path := writePolicyFile(t, `
+tokens:
+  - token: check
+    methods: ["CheckPermission"]
+  - token_sha256: "`+strings.ToUpper(hashToken("limited"))+`"
+    rate_limit:
+      requests_per_second: 0.001
+      burst: 2
+  - token: admin
+default:
+  methods: ["authzed.api.v1.PermissionsService/*"]
+`)
+
+	m, err := NewMiddleware(path, time.Hour)
+	require.NoError(t, err)
+
+	testcases := []struct {
+		name           string
+		token          string
+		method         string
+		expectedStatus codes.Code
+	}{
+		{"token may call allowed method", "check", checkPermissionMethod, codes.OK},
+		{"token may not call other method", "check", writeSchemaMethod, codes.PermissionDenied},
+		{"token without methods may call any method", "admin", writeSchemaMethod, codes.OK},
+		{"unlisted token may call default methods", "other", checkPermissionMethod, codes.OK},
+		{"unlisted token may not call other methods", "other", writeSchemaMethod, codes.PermissionDenied},
+		{"missing token uses default policy", "", checkPermissionMethod, codes.OK},
+	}
+
+	for _, testcase := range testcases {
+		t.Run(testcase.name, func(t *testing.T) {
+			err := callUnary(t, m, testcase.token, testcase.method)
+			if testcase.expectedStatus != codes.OK {
+				grpcutil.RequireStatus(t, testcase.expectedStatus, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("rate limit", func(t *testing.T) {
+		require.NoError(t, callUnary(t, m, "limited", writeSchemaMethod))
+		require.NoError(t, callStream(t, m, "limited", writeSchemaMethod))
+		grpcutil.RequireStatus(t, codes.ResourceExhausted, callUnary(t, m, "limited", writeSchemaMethod))
+		grpcutil.RequireStatus(t, codes.ResourceExhausted, callStream(t, m, "limited", writeSchemaMethod))
+
+		// Other tokens are not affected.
+		require.NoError(t, callUnary(t, m, "admin", writeSchemaMethod))
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		require.NoError(t, callStream(t, m, "check", checkPermissionMethod))
+		grpcutil.RequireStatus(t, codes.PermissionDenied, callStream(t, m, "check", writeSchemaMethod))
+	})
+
+	t.Run("auth override", func(t *testing.T) {
+		info := &grpc.UnaryServerInfo{Server: authOverrideServer{}, FullMethod: "/grpc.health.v1.Health/Check"}
+		_, err := m.UnaryServerInterceptor()(withToken(t.Context(), "check"), nil, info, okHandler)
+		require.NoError(t, err)
+	})
+}
+
+func TestNoDefaultPolicy(t *testing.T) {
+	m, err := NewMiddleware(writePolicyFile(t, "tokens:\n  - token: admin\n"), time.Hour)
+	require.NoError(t, err)
+
+	require.NoError(t, callUnary(t, m, "admin", writeSchemaMethod))
+	grpcutil.RequireStatus(t, codes.PermissionDenied, callUnary(t, m, "other", checkPermissionMethod))
+}
+
+func TestReload(t *testing.T) {
+	path := writePolicyFile(t, `
+tokens:
+  - token: edge
+    methods: ["CheckPermission"]
+    rate_limit:
+      requests_per_second: 0.001
+      burst: 2
+`)
+
+	m, err := NewMiddleware(path, 10*time.Millisecond)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error, 1)
+	go func() {
+		done <- m.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+
+	require.NoError(t, callUnary(t, m, "edge", checkPermissionMethod))
+	grpcutil.RequireStatus(t, codes.PermissionDenied, callUnary(t, m, "edge", writeSchemaMethod))
+
+	// Allow another method, keeping the rate limit unchanged.
+	require.NoError(t, os.WriteFile(path, []byte(`
+tokens:
+  - token: edge
+    methods: ["CheckPermission", "WriteSchema"]
+    rate_limit:
+      requests_per_second: 0.001
+      burst: 2
+`), 0o600))
+
+	require.Eventually(t, func() bool {
+		return len(m.current.Load().byTokenHash[hashToken("edge")].methods) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The bucket is carried over from before the reload, so only one call remains.
+	require.NoError(t, callUnary(t, m, "edge", writeSchemaMethod))
+	grpcutil.RequireStatus(t, codes.ResourceExhausted, callUnary(t, m, "edge", checkPermissionMethod))
+
+	// An invalid file is ignored.
+	require.NoError(t, os.WriteFile(path, []byte("tokens: [{}]"), 0o600))
+	time.Sleep(50 * time.Millisecond)
+	require.Len(t, m.current.Load().byTokenHash, 1)
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	testcases := map[string]string{
+		"unknown field":     "tokens:\n  - token: a\n    unknown: true\n",
+		"missing token":     "tokens:\n  - methods: [\"CheckPermission\"]\n",
+		"both tokens":       "tokens:\n  - token: a\n    token_sha256: \"" + hashToken("a") + "\"\n",
+		"invalid hash":      "tokens:\n  - token_sha256: abc\n",
+		"empty method":      "tokens:\n  - token: a\n    methods: [\"\"]\n",
+		"zero rate":         "tokens:\n  - token: a\n    rate_limit:\n      requests_per_second: 0\n",
+		"negative burst":    "tokens:\n  - token: a\n    rate_limit:\n      requests_per_second: 1\n      burst: -1\n",
+		"invalid default":   "default:\n  methods: [\"\"]\n",
+		"not a policy file": "- a\n- b\n",
+	}
+
+	for name, contents := range testcases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseConfig(strings.NewReader(contents))
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("duplicate tokens", func(t *testing.T) {
+		_, err := NewMiddleware(writePolicyFile(t, "tokens:\n  - token: a\n  - token_sha256: \""+hashToken("a")+"\"\n"), time.Hour)
+		require.ErrorContains(t, err, "duplicate tokens")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := NewMiddleware(filepath.Join(t.TempDir(), "missing.yaml"), time.Hour)
+		require.ErrorContains(t, err, "failed to read token policy file")
+	})
+}
+
+func TestDefaultBurst(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader("tokens:\n  - token: a\n    rate_limit:\n      requests_per_second: 2.5\n"))
+	require.NoError(t, err)
+
+	compiled, err := config.compile(nil)
+	require.NoError(t, err)
+	require.Equal(t, 3, compiled.byTokenHash[hashToken("a")].burst)
+}
+
+func writePolicyFile(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+	return path
+}
+
+func withToken(ctx context.Context, token string) context.Context {
+	if token == "" {
+		return ctx
+	}
+	return metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "bearer "+token))
+}
+
+func okHandler(_ context.Context, _ any) (any, error) {
+	return nil, nil
+}
+
+func callUnary(t *testing.T, m *Middleware, token, method string) error {
+	_, err := m.UnaryServerInterceptor()(withToken(t.Context(), token), nil, &grpc.UnaryServerInfo{FullMethod: method}, okHandler)
+	return err
+}
+
+func callStream(t *testing.T, m *Middleware, token, method string) error {
+	stream := &contextStream{ctx: withToken(t.Context(), token)}
+	return m.StreamServerInterceptor()(nil, stream, &grpc.StreamServerInfo{FullMethod: method}, func(any, grpc.ServerStream) error {
+		return nil
+	})
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context { return s.ctx }
+
+type authOverrideServer struct{}
+
+func (authOverrideServer) AuthFuncOverride(ctx context.Context, _ string) (context.Context, error) {
+	return ctx, nil
+}
@@ -69,6 +69,21 @@ func (ds *dispatchServer) DispatchLookupSubjects(
 	return ds.localDispatch.DispatchLookupSubjects(req, dispatch.WrapGRPCStream(resp))
 }
 
+func (ds *dispatchServer) DispatchQueryPlan(ctx context.Context, req *dispatchv1.DispatchQueryPlanRequest) (*dispatchv1.DispatchQueryPlanResponse, error) {
+	resp, err := ds.localDispatch.DispatchQueryPlan(ctx, req)
+	return resp, rewriteGraphError(ctx, err)
+}
+
+func (ds *dispatchServer) DispatchPeerCacheLookup(ctx context.Context, req *dispatchv1.DispatchPeerCacheLookupRequest) (*dispatchv1.DispatchPeerCacheLookupResponse, error) {
+	lookup, ok := ds.localDispatch.(dispatch.PeerCacheLookup)
+	if !ok {
+		return &dispatchv1.DispatchPeerCacheLookupResponse{}, nil
+	}
+
+	resp, err := lookup.LookupPeerCache(ctx, req)
+	return resp, rewriteGraphError(ctx, err)
+}
+
 func (ds *dispatchServer) Close() error {
 	return nil
 }
@@ -103,13 +103,15 @@ func runQueryPlanAssertions(t *testing.T, handle *queryPlanConsistencyHandle) {
 				t.Run(entry.name, func(t *testing.T) {
 					for _, assertion := range entry.assertions {
 						t.Run(assertion.RelationshipWithContextString, func(t *testing.T) {
-							// Run both unoptimized and optimized versions
+							// Run both unoptimized and optimized versions, and with the parallel executor
 							for _, optimizationMode := range []struct {
 								name     string
 								optimize bool
+								parallel bool
 							}{
-								{"unoptimized", false},
-								{"optimized", true},
+								{"unoptimized", false, false},
+								{"optimized", true, false},
+								{"parallel", true, true},
 							} {
 								t.Run(optimizationMode.name, func(t *testing.T) {
 									require := require.New(t)
@@ -125,6 +127,11 @@ func runQueryPlanAssertions(t *testing.T, handle *queryPlanConsistencyHandle) {
 									}
 
 									qctx := handle.buildContext(t)
+									if optimizationMode.parallel {
+										// Traced queries are evaluated sequentially.
+										qctx.Executor = query.NewParallelExecutor(4)
+										qctx.TraceLogger = nil
+									}
 
 									// Add caveat context from assertion if available
 									if len(assertion.CaveatContext) > 0 {
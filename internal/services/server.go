@@ -13,6 +13,9 @@ import (
 	"github.com/authzed/spicedb/internal/dispatch"
 	"github.com/authzed/spicedb/internal/services/health"
 	v1svc "github.com/authzed/spicedb/internal/services/v1"
+	countersv1 "github.com/authzed/spicedb/pkg/proto/counters/v1"
+	schemahistoryv1 "github.com/authzed/spicedb/pkg/proto/schemahistory/v1"
+	schemamigrationv1 "github.com/authzed/spicedb/pkg/proto/schemamigration/v1"
 )
 
 // SchemaServiceOption defines the options for enabling or disabling the V1 Schema service.
@@ -61,6 +64,7 @@ func RegisterGrpcServices(
 
 	v1.RegisterPermissionsServiceServer(srv, v1svc.NewPermissionsServer(dispatch, permSysConfig))
 	v1.RegisterExperimentalServiceServer(srv, v1svc.NewExperimentalServer(dispatch, permSysConfig))
+	countersv1.RegisterRelationshipCounterServiceServer(srv, v1svc.NewRelationshipCounterServer(permSysConfig))
 	healthManager.RegisterReportedService(v1.PermissionsService_ServiceDesc.ServiceName)
 
 	if watchServiceOption == WatchServiceEnabled {
@@ -77,6 +81,12 @@ func RegisterGrpcServices(
 		}
 		v1.RegisterSchemaServiceServer(srv, v1svc.NewSchemaServer(schemaConfig))
 		healthManager.RegisterReportedService(v1.SchemaService_ServiceDesc.ServiceName)
+
+		schemamigrationv1.RegisterSchemaMigrationServiceServer(srv, v1svc.NewSchemaMigrationServer(schemaConfig))
+		healthManager.RegisterReportedService(schemamigrationv1.SchemaMigrationService_ServiceDesc.ServiceName)
+
+		schemahistoryv1.RegisterSchemaHistoryServiceServer(srv, v1svc.NewSchemaHistoryServer(schemaConfig))
+		healthManager.RegisterReportedService(schemahistoryv1.SchemaHistoryService_ServiceDesc.ServiceName)
 	}
 
 	healthpb.RegisterHealthServer(srv, healthManager.HealthSvc())
@@ -3,6 +3,7 @@ package shared
 import (
 	"context"
 	"maps"
+	"slices"
 
 	log "github.com/authzed/spicedb/internal/logging"
 	"github.com/authzed/spicedb/internal/namespace"
@@ -26,9 +27,29 @@ type ValidatedSchemaChanges struct {
 	validatedTypeSystems map[string]*schema.ValidatedDefinition
 	newCaveatDefNames    *mapz.Set[string]
 	newObjectDefNames    *mapz.Set[string]
+	relationRenames      map[string]nsdiff.RelationRenames
+	schemaText           string
 	additiveOnly         bool
 }
 
+// WithSchemaText returns the validated schema changes with the schema text from which they were
+// compiled, which is recorded in the schema history when the changes are applied. Without it, the
+// text generated for the compiled definitions is recorded instead.
+func (vsc *ValidatedSchemaChanges) WithSchemaText(schemaText string) *ValidatedSchemaChanges {
+	updated := *vsc
+	updated.schemaText = schemaText
+	return &updated
+}
+
+// WithRelationRenames returns the validated schema changes with the relations renamed by them,
+// keyed by the name of their object definition. When the changes are applied, the relationships
+// of a renamed relation, and those with subjects of it, are rewritten to its new name.
+func (vsc *ValidatedSchemaChanges) WithRelationRenames(renames map[string]nsdiff.RelationRenames) *ValidatedSchemaChanges {
+	updated := *vsc
+	updated.relationRenames = renames
+	return &updated
+}
+
 // ValidateSchemaChanges validates the schema found in the compiled schema and returns a
 // ValidatedSchemaChanges, if fully validated.
 func ValidateSchemaChanges(ctx context.Context, compiled *compiler.CompiledSchema, caveatTypeSet *caveattypes.TypeSet, additiveOnly bool) (*ValidatedSchemaChanges, error) {
@@ -90,7 +111,7 @@ type AppliedSchemaChanges struct {
 }
 
 // ApplySchemaChanges applies schema changes found in the validated changes struct, via the specified
-// ReadWriteTransaction.
+// ReadWriteTransaction, and records their write in the schema history.
 func ApplySchemaChanges(ctx context.Context, rwt datastore.ReadWriteTransaction, caveatTypeSet *caveattypes.TypeSet, validated *ValidatedSchemaChanges) (*AppliedSchemaChanges, error) {
 	existingCaveats, err := rwt.ListAllCaveats(ctx)
 	if err != nil {
@@ -106,7 +127,7 @@ func ApplySchemaChanges(ctx context.Context, rwt datastore.ReadWriteTransaction,
 }
 
 // ApplySchemaChangesOverExisting applies schema changes found in the validated changes struct, against
-// existing caveat and object definitions given.
+// existing caveat and object definitions given, and records their write in the schema history.
 func ApplySchemaChangesOverExisting(
 	ctx context.Context,
 	rwt datastore.ReadWriteTransaction,
@@ -147,11 +168,17 @@ func ApplySchemaChangesOverExisting(
 		existingObjectDefNames.Insert(existingDef.Name)
 	}
 
+	// Rewrite the relationships of the renamed relations, if any, so that they are not found
+	// under the previous names of the relations by the checks below.
+	if err := rewriteRenamedRelations(ctx, rwt, validated, existingObjectDefMap); err != nil {
+		return nil, err
+	}
+
 	// For each definition, perform a diff and ensure the changes will not result in any
 	// breaking changes.
 	objectDefsWithChanges := make([]*core.NamespaceDefinition, 0, len(validated.compiled.ObjectDefinitions))
 	for _, nsdef := range validated.compiled.ObjectDefinitions {
-		diff, err := sanityCheckNamespaceChanges(ctx, rwt, nsdef, existingObjectDefMap)
+		diff, err := sanityCheckNamespaceChanges(ctx, rwt, nsdef, existingObjectDefMap, validated.relationRenames[nsdef.Name])
 		if err != nil {
 			return nil, err
 		}
@@ -230,6 +257,10 @@ func ApplySchemaChangesOverExisting(
 		Object("removedCaveatDefinitions", removedCaveatDefNames).
 		Msg("completed schema update")
 
+	if err := recordSchemaChanges(ctx, rwt, caveatTypeSet, validated, existingCaveats, existingObjectDefs); err != nil {
+		return nil, err
+	}
+
 	return &AppliedSchemaChanges{
 		TotalOperationCount:   len(validated.compiled.ObjectDefinitions) + len(validated.compiled.CaveatDefinitions) + removedObjectDefNames.Len() + removedCaveatDefNames.Len(),
 		NewObjectDefNames:     validated.newObjectDefNames.Subtract(existingObjectDefNames).AsSlice(),
@@ -239,6 +270,39 @@ func ApplySchemaChangesOverExisting(
 	}, nil
 }
 
+// rewriteRenamedRelations validates the relation renames of the schema changes, and rewrites
+// the relationships of each renamed relation, and those with subjects of it, to its new name.
+func rewriteRenamedRelations(
+	ctx context.Context,
+	rwt datastore.ReadWriteTransaction,
+	validated *ValidatedSchemaChanges,
+	existingDefs map[string]*core.NamespaceDefinition,
+) error {
+	if len(validated.relationRenames) == 0 {
+		return nil
+	}
+
+	nsdefs := make(map[string]*core.NamespaceDefinition, len(validated.compiled.ObjectDefinitions))
+	for _, nsdef := range validated.compiled.ObjectDefinitions {
+		nsdefs[nsdef.Name] = nsdef
+	}
+
+	nsdefNames := slices.Sorted(maps.Keys(validated.relationRenames))
+	steps := make([]SchemaMigrationStep, 0, len(nsdefNames)*2)
+	for _, nsdefName := range nsdefNames {
+		renames := validated.relationRenames[nsdefName]
+		if _, err := nsdiff.DiffNamespacesWithRenames(existingDefs[nsdefName], nsdefs[nsdefName], renames); err != nil {
+			return err
+		}
+
+		for _, previousName := range slices.Sorted(maps.Keys(renames)) {
+			steps = append(steps, relationRewriteSteps(nsdefName, previousName, renames[previousName])...)
+		}
+	}
+
+	return executeSchemaMigrationSteps(ctx, rwt, steps)
+}
+
 // sanityCheckCaveatChanges ensures that a caveat definition being written does not break
 // the types of the parameters that may already exist on relationships.
 func sanityCheckCaveatChanges(
@@ -307,10 +371,11 @@ func sanityCheckNamespaceChanges(
 	rwt datastore.ReadWriteTransaction,
 	nsdef *core.NamespaceDefinition,
 	existingDefs map[string]*core.NamespaceDefinition,
+	renames nsdiff.RelationRenames,
 ) (*nsdiff.Diff, error) {
 	// Ensure that the updated namespace does not break the existing tuple data.
 	existing := existingDefs[nsdef.Name]
-	diff, err := nsdiff.DiffNamespaces(existing, nsdef)
+	diff, err := nsdiff.DiffNamespacesWithRenames(existing, nsdef, renames)
 	if err != nil {
 		return nil, err
 	}
@@ -402,38 +467,9 @@ func sanityCheckNamespaceChanges(
 			}
 
 		case nsdiff.RelationAllowedTypeRemoved:
-			var optionalSubjectIds []string
-			var optionalCaveatNameFilter datastore.CaveatNameFilter
-			if delta.AllowedType.GetPublicWildcard() != nil {
-				optionalSubjectIds = []string{tuple.PublicWildcard}
-			}
-
-			if delta.AllowedType.GetRequiredCaveat() != nil && delta.AllowedType.GetRequiredCaveat().CaveatName != "" {
-				optionalCaveatNameFilter = datastore.WithCaveatName(delta.AllowedType.GetRequiredCaveat().CaveatName)
-			} else {
-				optionalCaveatNameFilter = datastore.WithNoCaveat()
-			}
-
-			expirationOption := datastore.ExpirationFilterOptionNoExpiration
-			if delta.AllowedType.RequiredExpiration != nil {
-				expirationOption = datastore.ExpirationFilterOptionHasExpiration
-			}
-
 			qyr, qyrErr := rwt.QueryRelationships(
 				ctx,
-				datastore.RelationshipsFilter{
-					OptionalResourceType:     nsdef.Name,
-					OptionalResourceRelation: delta.RelationName,
-					OptionalSubjectsSelectors: []datastore.SubjectsSelector{
-						{
-							OptionalSubjectType: delta.AllowedType.Namespace,
-							OptionalSubjectIds:  optionalSubjectIds,
-							RelationFilter:      subjectRelationFilterForAllowedType(delta.AllowedType),
-						},
-					},
-					OptionalCaveatNameFilter: optionalCaveatNameFilter,
-					OptionalExpirationOption: expirationOption,
-				},
+				relationshipsFilterForRemovedAllowedType(nsdef.Name, delta),
 				options.WithLimit(options.LimitOne),
 				options.WithQueryShape(queryshape.FindResourceRelationForSubjectRelation),
 			)
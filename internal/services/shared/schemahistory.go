@@ -0,0 +1,128 @@
+package shared
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/authzed/spicedb/internal/auth"
+	caveattypes "github.com/authzed/spicedb/pkg/caveats/types"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/diff"
+	caveatdiff "github.com/authzed/spicedb/pkg/diff/caveats"
+	nsdiff "github.com/authzed/spicedb/pkg/diff/namespace"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schema"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/generator"
+)
+
+// WriteSchemaHistoryEntry records the write of a schema in the schema history, along with the
+// changes made by it to the existing schema and the caller which made them. If the schema text is
+// empty, the text generated for the updated schema is recorded in its place.
+//
+// Schema writes made through ApplySchemaChanges are recorded by it; other writers of definitions
+// must call this within the same transaction. The only writers exempt are those of ephemeral
+// datastores, such as the development package and the test fixtures.
+func WriteSchemaHistoryEntry(ctx context.Context, rwt datastore.ReadWriteTransaction, caveatTypeSet *caveattypes.TypeSet, schemaText string, existing diff.DiffableSchema, updated diff.DiffableSchema) error {
+	return writeSchemaHistoryEntry(ctx, rwt, caveatTypeSet, schemaText, existing, updated, nil, false)
+}
+
+// recordSchemaChanges records the write of the validated schema changes over the existing
+// definitions in the schema history.
+func recordSchemaChanges(ctx context.Context, rwt datastore.ReadWriteTransaction, caveatTypeSet *caveattypes.TypeSet, validated *ValidatedSchemaChanges, existingCaveats []*core.CaveatDefinition, existingObjectDefs []*core.NamespaceDefinition) error {
+	existing := diff.DiffableSchema{
+		ObjectDefinitions: existingObjectDefs,
+		CaveatDefinitions: existingCaveats,
+	}
+	return writeSchemaHistoryEntry(ctx, rwt, caveatTypeSet, validated.schemaText, existing, diff.NewDiffableSchemaFromCompiledSchema(validated.compiled), validated.relationRenames, validated.additiveOnly)
+}
+
+func writeSchemaHistoryEntry(ctx context.Context, rwt datastore.ReadWriteTransaction, caveatTypeSet *caveattypes.TypeSet, schemaText string, existing diff.DiffableSchema, updated diff.DiffableSchema, renames map[string]nsdiff.RelationRenames, additiveOnly bool) error {
+	entry, err := schemaHistoryEntry(ctx, caveatTypeSet, schemaText, existing, updated, renames, additiveOnly)
+	if err != nil {
+		return err
+	}
+
+	return rwt.WriteSchemaHistoryEntry(ctx, entry)
+}
+
+// schemaHistoryEntry returns the entry of the schema history for the write of the updated schema
+// over the existing schema.
+func schemaHistoryEntry(ctx context.Context, caveatTypeSet *caveattypes.TypeSet, schemaText string, existing diff.DiffableSchema, updated diff.DiffableSchema, renames map[string]nsdiff.RelationRenames, additiveOnly bool) (*core.SchemaHistoryEntry, error) {
+	schemaDiff, err := diff.DiffSchemasWithRenames(existing, updated, caveatTypeSet, renames)
+	if err != nil {
+		return nil, err
+	}
+
+	classified := schemaDiff.ClassifiedDeltas()
+	deltas := make([]*core.SchemaHistoryDelta, 0, len(classified))
+	for _, delta := range classified {
+		// Definitions are never removed from a schema which is additive only.
+		if additiveOnly && isDefinitionRemoval(delta) {
+			continue
+		}
+
+		deltas = append(deltas, schemaHistoryDelta(delta))
+	}
+
+	if schemaText == "" {
+		definitions := make([]compiler.SchemaDefinition, 0, len(updated.CaveatDefinitions)+len(updated.ObjectDefinitions))
+		for _, caveatDef := range updated.CaveatDefinitions {
+			definitions = append(definitions, caveatDef)
+		}
+		for _, objectDef := range updated.ObjectDefinitions {
+			definitions = append(definitions, objectDef)
+		}
+
+		schemaText, _, err = generator.GenerateSchemaWithCaveatTypeSet(definitions, caveatTypeSet)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var caller string
+	if found, ok := auth.CallerFromContext(ctx); ok {
+		caller = found.String()
+	}
+
+	return &core.SchemaHistoryEntry{
+		SchemaText: schemaText,
+		Deltas:     deltas,
+		Caller:     caller,
+		WrittenAt:  timestamppb.Now(),
+	}, nil
+}
+
+func isDefinitionRemoval(delta diff.ClassifiedDelta) bool {
+	if delta.NamespaceDelta != nil {
+		return delta.NamespaceDelta.Type == nsdiff.NamespaceRemoved
+	}
+	return delta.CaveatDelta.Type == caveatdiff.CaveatRemoved
+}
+
+func schemaHistoryDelta(delta diff.ClassifiedDelta) *core.SchemaHistoryDelta {
+	if delta.NamespaceDelta != nil {
+		historyDelta := &core.SchemaHistoryDelta{
+			DefinitionKind:  core.SchemaHistoryDelta_DEFINITION_KIND_OBJECT,
+			DefinitionName:  delta.NamespaceName,
+			DeltaType:       string(delta.NamespaceDelta.Type),
+			Subject:         delta.NamespaceDelta.RelationName,
+			PreviousSubject: delta.NamespaceDelta.PreviousRelationName,
+			ChangeClass:     string(delta.Class),
+			AccessChange:    string(delta.NamespaceDelta.AccessChange),
+		}
+		if delta.NamespaceDelta.AllowedType != nil {
+			historyDelta.AllowedType = schema.SourceForAllowedRelation(delta.NamespaceDelta.AllowedType)
+		}
+		return historyDelta
+	}
+
+	return &core.SchemaHistoryDelta{
+		DefinitionKind: core.SchemaHistoryDelta_DEFINITION_KIND_CAVEAT,
+		DefinitionName: delta.CaveatName,
+		DeltaType:      string(delta.CaveatDelta.Type),
+		Subject:        delta.CaveatDelta.ParameterName,
+		ChangeClass:    string(delta.Class),
+	}
+}
@@ -0,0 +1,149 @@
+package shared
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/auth"
+	"github.com/authzed/spicedb/internal/datastore/dsfortesting"
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	caveattypes "github.com/authzed/spicedb/pkg/caveats/types"
+	"github.com/authzed/spicedb/pkg/datastore"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+)
+
+func TestApplySchemaChangesRecordsHistory(t *testing.T) {
+	ds, err := dsfortesting.NewMemDBDatastoreForTesting(0, 0, memdb.DisableGC)
+	require.NoError(t, err)
+
+	writeSchema := func(ctx context.Context, schemaText string, additiveOnly bool) datastore.Revision {
+		compiled, err := compiler.Compile(compiler.InputSchema{
+			Source:       input.Source("schema"),
+			SchemaString: schemaText,
+		}, compiler.AllowUnprefixedObjectType())
+		require.NoError(t, err)
+
+		validated, err := ValidateSchemaChanges(ctx, compiled, caveattypes.Default.TypeSet, additiveOnly)
+		require.NoError(t, err)
+
+		rev, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+			_, err := ApplySchemaChanges(ctx, rwt, caveattypes.Default.TypeSet, validated.WithSchemaText(schemaText))
+			return err
+		})
+		require.NoError(t, err)
+		return rev
+	}
+
+	firstSchema := `definition user {}
+
+	definition document {
+		relation viewer: user
+		permission view = viewer
+	}`
+	writeSchema(t.Context(), firstSchema, false)
+
+	secondSchema := `definition user {}
+
+	definition group {}
+
+	definition document {
+		relation viewer: user
+		relation editor: user
+		permission view = viewer + editor
+	}`
+	callerCtx := auth.ContextWithCaller(t.Context(), auth.Caller{Method: auth.CallerMethodJWT, ID: "deployer"})
+	writeSchema(callerCtx, secondSchema, false)
+
+	// Definitions are not removed by a schema which is additive only, so their removal is not
+	// recorded.
+	thirdSchema := `definition document {
+		relation viewer: user
+		relation editor: user
+		permission view = viewer + editor
+	}
+
+	definition user {}
+
+	definition folder {}`
+	rev := writeSchema(t.Context(), thirdSchema+"\n", true)
+
+	entries, err := ds.SnapshotReader(rev).ReadSchemaHistory(t.Context(), datastore.NoRevision, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	require.Equal(t, thirdSchema+"\n", entries[0].Entry.SchemaText)
+	require.Empty(t, entries[0].Entry.Caller)
+	require.Equal(t, []*core.SchemaHistoryDelta{
+		{
+			DefinitionKind: core.SchemaHistoryDelta_DEFINITION_KIND_OBJECT,
+			DefinitionName: "folder",
+			DeltaType:      "namespace-added",
+			ChangeClass:    "additive",
+		},
+	}, entries[0].Entry.Deltas)
+
+	require.Equal(t, secondSchema, entries[1].Entry.SchemaText)
+	require.Equal(t, "jwt:deployer", entries[1].Entry.Caller)
+	require.NotNil(t, entries[1].Entry.WrittenAt)
+	require.Equal(t, []*core.SchemaHistoryDelta{
+		{
+			DefinitionKind: core.SchemaHistoryDelta_DEFINITION_KIND_OBJECT,
+			DefinitionName: "document",
+			DeltaType:      "added-relation",
+			Subject:        "editor",
+			ChangeClass:    "additive",
+		},
+		{
+			DefinitionKind: core.SchemaHistoryDelta_DEFINITION_KIND_OBJECT,
+			DefinitionName: "document",
+			DeltaType:      "changed-permission-implementation",
+			Subject:        "view",
+			ChangeClass:    "behavior-changing",
+			AccessChange:   "widens",
+		},
+		{
+			DefinitionKind: core.SchemaHistoryDelta_DEFINITION_KIND_OBJECT,
+			DefinitionName: "group",
+			DeltaType:      "namespace-added",
+			ChangeClass:    "additive",
+		},
+	}, entries[1].Entry.Deltas)
+
+	require.Equal(t, firstSchema, entries[2].Entry.SchemaText)
+	require.Len(t, entries[2].Entry.Deltas, 2)
+}
+
+func TestApplySchemaChangesRecordsGeneratedHistoryText(t *testing.T) {
+	ds, err := dsfortesting.NewMemDBDatastoreForTesting(0, 0, memdb.DisableGC)
+	require.NoError(t, err)
+
+	compiled, err := compiler.Compile(compiler.InputSchema{
+		Source: input.Source("schema"),
+		SchemaString: `definition user {}
+
+		// some comment
+		definition document {
+			relation viewer: user
+		}`,
+	}, compiler.AllowUnprefixedObjectType())
+	require.NoError(t, err)
+
+	validated, err := ValidateSchemaChanges(t.Context(), compiled, caveattypes.Default.TypeSet, false)
+	require.NoError(t, err)
+
+	rev, err := ds.ReadWriteTx(t.Context(), func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		_, err := ApplySchemaChanges(ctx, rwt, caveattypes.Default.TypeSet, validated)
+		return err
+	})
+	require.NoError(t, err)
+
+	entries, err := ds.SnapshotReader(rev).ReadSchemaHistory(t.Context(), datastore.NoRevision, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "definition user {}\n\n// some comment\ndefinition document {\n\trelation viewer: user\n}", entries[0].Entry.SchemaText)
+	require.Len(t, entries[0].Entry.Deltas, 2)
+}
@@ -0,0 +1,565 @@
+package shared
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	caveattypes "github.com/authzed/spicedb/pkg/caveats/types"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/datastore/options"
+	"github.com/authzed/spicedb/pkg/datastore/queryshape"
+	"github.com/authzed/spicedb/pkg/diff"
+	caveatdiff "github.com/authzed/spicedb/pkg/diff/caveats"
+	nsdiff "github.com/authzed/spicedb/pkg/diff/namespace"
+	"github.com/authzed/spicedb/pkg/genutil/mapz"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schema"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// SchemaMigrationStepKind defines the kind of change made by a step of a schema migration.
+type SchemaMigrationStepKind int
+
+const (
+	// DeleteRelationshipsStep deletes the relationships matched by the step.
+	DeleteRelationshipsStep SchemaMigrationStepKind = iota
+
+	// RewriteResourceRelationStep rewrites the relation of the relationships matched by
+	// the step.
+	RewriteResourceRelationStep
+
+	// RewriteSubjectRelationStep rewrites the relation of the subject of the relationships
+	// matched by the step.
+	RewriteSubjectRelationStep
+
+	// RemoveCaveatParameterStep removes a parameter from the caveat context of the
+	// relationships matched by the step.
+	RemoveCaveatParameterStep
+)
+
+// RelationRewrite asks for the relationships of a relation removed by a schema to be
+// rewritten to another relation of the same definition, rather than deleted.
+type RelationRewrite struct {
+	DefinitionName string
+	FromRelation   string
+	ToRelation     string
+}
+
+// SchemaMigrationStep is a single change to the stored relationships required before a
+// schema can be written.
+type SchemaMigrationStep struct {
+	// Kind is the kind of change made by the step.
+	Kind SchemaMigrationStepKind
+
+	// Description describes the step and the relationships it changes.
+	Description string
+
+	// Filter matches the relationships changed by the step.
+	Filter datastore.RelationshipsFilter
+
+	// Relation is the relation to which relationships are rewritten, for rewrite steps.
+	Relation string
+
+	// CaveatParameter is the parameter removed, for RemoveCaveatParameterStep steps.
+	CaveatParameter string
+}
+
+// SchemaMigrationPlan holds the steps required to migrate the stored relationships to a
+// schema, in the order in which they are to be executed.
+type SchemaMigrationPlan struct {
+	// Steps are the steps of the migration. If empty, the schema can be written without
+	// any migration.
+	Steps []SchemaMigrationStep
+
+	// BlockingChanges describe the changes of the schema which cannot be migrated, and
+	// which will cause its write to fail if data exists for them.
+	BlockingChanges []string
+}
+
+// Hash returns a hash of the steps of the plan, which identifies the plan across calls.
+func (p *SchemaMigrationPlan) Hash() string {
+	hasher := sha256.New()
+	for _, step := range p.Steps {
+		_, _ = fmt.Fprintf(hasher, "%d:%s\n", step.Kind, step.Description)
+	}
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+// PlanSchemaMigration computes the steps required to migrate the relationships stored in the
+// datastore to the validated schema, using the deltas between the existing schema and it.
+// Relationships of removed relations are deleted, unless a rewrite to another relation is
+// given for them.
+func PlanSchemaMigration(ctx context.Context, reader datastore.Reader, caveatTypeSet *caveattypes.TypeSet, validated *ValidatedSchemaChanges, rewrites []RelationRewrite) (*SchemaMigrationPlan, error) {
+	existingCaveats, err := reader.ListAllCaveats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existingObjectDefs, err := reader.ListAllNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := diff.DiffableSchema{
+		ObjectDefinitions: datastore.DefinitionsOf(existingObjectDefs),
+		CaveatDefinitions: datastore.DefinitionsOf(existingCaveats),
+	}
+	target := diff.NewDiffableSchemaFromCompiledSchema(validated.compiled)
+
+	schemaDiff, err := diff.DiffSchemas(existing, target, caveatTypeSet)
+	if err != nil {
+		return nil, err
+	}
+
+	rewritesByRelation := make(map[string]RelationRewrite, len(rewrites))
+	rewrittenRelations := mapz.NewSet[string]()
+	for _, rewrite := range rewrites {
+		relRef := tuple.JoinRelRef(rewrite.DefinitionName, rewrite.FromRelation)
+		rewritesByRelation[relRef] = rewrite
+		rewrittenRelations.Add(relRef)
+	}
+
+	for _, rewrite := range rewrites {
+		if err := validateRelationRewrite(existing, target, rewrite, rewritesByRelation); err != nil {
+			return nil, err
+		}
+	}
+
+	plan := &SchemaMigrationPlan{}
+
+	// Relationships of removed definitions are deleted, unless the schema is additive only,
+	// in which case the definitions are not removed. Relationships with them as subjects
+	// are handled by the removal of the allowed types referencing them.
+	if !validated.additiveOnly {
+		removedObjectDefNames := slices.Clone(schemaDiff.RemovedNamespaces)
+		slices.Sort(removedObjectDefNames)
+		for _, nsdefName := range removedObjectDefNames {
+			plan.Steps = append(plan.Steps, SchemaMigrationStep{
+				Kind:        DeleteRelationshipsStep,
+				Description: fmt.Sprintf("delete relationships of object definition `%s`", nsdefName),
+				Filter:      datastore.RelationshipsFilter{OptionalResourceType: nsdefName},
+			})
+		}
+	}
+
+	changedObjectDefNames := make([]string, 0, len(schemaDiff.ChangedNamespaces))
+	for nsdefName := range schemaDiff.ChangedNamespaces {
+		changedObjectDefNames = append(changedObjectDefNames, nsdefName)
+	}
+	slices.Sort(changedObjectDefNames)
+
+	for _, nsdefName := range changedObjectDefNames {
+		for _, delta := range sortedNamespaceDeltas(schemaDiff.ChangedNamespaces[nsdefName]) {
+			switch delta.Type {
+			case nsdiff.RemovedRelation:
+				relRef := tuple.JoinRelRef(nsdefName, delta.RelationName)
+				resourceFilter := datastore.RelationshipsFilter{
+					OptionalResourceType:     nsdefName,
+					OptionalResourceRelation: delta.RelationName,
+				}
+				subjectFilter := datastore.RelationshipsFilter{
+					OptionalSubjectsSelectors: []datastore.SubjectsSelector{
+						{
+							OptionalSubjectType: nsdefName,
+							RelationFilter:      datastore.SubjectRelationFilter{}.WithRelation(delta.RelationName),
+						},
+					},
+				}
+
+				rewrite, ok := rewritesByRelation[relRef]
+				if !ok {
+					plan.Steps = append(plan.Steps,
+						SchemaMigrationStep{
+							Kind:        DeleteRelationshipsStep,
+							Description: fmt.Sprintf("delete relationships of relation `%s`", relRef),
+							Filter:      resourceFilter,
+						},
+						SchemaMigrationStep{
+							Kind:        DeleteRelationshipsStep,
+							Description: fmt.Sprintf("delete relationships with subjects of relation `%s`", relRef),
+							Filter:      subjectFilter,
+						},
+					)
+					continue
+				}
+
+				delete(rewritesByRelation, relRef)
+				plan.Steps = append(plan.Steps, relationRewriteSteps(nsdefName, delta.RelationName, rewrite.ToRelation)...)
+
+			case nsdiff.RelationAllowedTypeRemoved:
+				// Subjects of a rewritten relation are rewritten, rather than deleted.
+				if rewrittenRelations.Has(tuple.JoinRelRef(delta.AllowedType.Namespace, delta.AllowedType.GetRelation())) {
+					continue
+				}
+
+				plan.Steps = append(plan.Steps, SchemaMigrationStep{
+					Kind: DeleteRelationshipsStep,
+					Description: fmt.Sprintf("delete relationships of relation `%s` with subjects of allowed type `%s`",
+						tuple.JoinRelRef(nsdefName, delta.RelationName), schema.SourceForAllowedRelation(delta.AllowedType)),
+					Filter: relationshipsFilterForRemovedAllowedType(nsdefName, delta),
+				})
+			}
+		}
+	}
+
+	// Rewrites must each apply to a relation removed by the schema.
+	for relRef := range rewritesByRelation {
+		return nil, NewSchemaWriteDataValidationError("relation `%s` is not removed by the schema, so cannot be rewritten", []any{relRef}, map[string]string{
+			"relation":  relRef,
+			"operation": "rewrite_relation",
+		})
+	}
+
+	changedCaveatNames := make([]string, 0, len(schemaDiff.ChangedCaveats))
+	for caveatName := range schemaDiff.ChangedCaveats {
+		changedCaveatNames = append(changedCaveatNames, caveatName)
+	}
+	slices.Sort(changedCaveatNames)
+
+	for _, caveatName := range changedCaveatNames {
+		for _, delta := range schemaDiff.ChangedCaveats[caveatName].Deltas() {
+			switch delta.Type {
+			case caveatdiff.RemovedParameter:
+				plan.Steps = append(plan.Steps, SchemaMigrationStep{
+					Kind:            RemoveCaveatParameterStep,
+					Description:     fmt.Sprintf("remove parameter `%s` from the context of relationships with caveat `%s`", delta.ParameterName, caveatName),
+					Filter:          datastore.RelationshipsFilter{OptionalCaveatNameFilter: datastore.WithCaveatName(caveatName)},
+					CaveatParameter: delta.ParameterName,
+				})
+
+			case caveatdiff.ParameterTypeChanged:
+				plan.BlockingChanges = append(plan.BlockingChanges,
+					fmt.Sprintf("the type of parameter `%s` on caveat `%s` is changed", delta.ParameterName, caveatName))
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// relationRewriteSteps returns the steps rewriting the relationships of the relation, and
+// those with subjects of the relation, to the relation to which it is rewritten.
+func relationRewriteSteps(nsdefName string, fromRelation string, toRelation string) []SchemaMigrationStep {
+	relRef := tuple.JoinRelRef(nsdefName, fromRelation)
+	toRelRef := tuple.JoinRelRef(nsdefName, toRelation)
+	return []SchemaMigrationStep{
+		{
+			Kind:        RewriteResourceRelationStep,
+			Description: fmt.Sprintf("rewrite relationships of relation `%s` to relation `%s`", relRef, toRelRef),
+			Filter: datastore.RelationshipsFilter{
+				OptionalResourceType:     nsdefName,
+				OptionalResourceRelation: fromRelation,
+			},
+			Relation: toRelation,
+		},
+		{
+			Kind:        RewriteSubjectRelationStep,
+			Description: fmt.Sprintf("rewrite subjects of relation `%s` to relation `%s`", relRef, toRelRef),
+			Filter: datastore.RelationshipsFilter{
+				OptionalSubjectsSelectors: []datastore.SubjectsSelector{
+					{
+						OptionalSubjectType: nsdefName,
+						RelationFilter:      datastore.SubjectRelationFilter{}.WithRelation(fromRelation),
+					},
+				},
+			},
+			Relation: toRelation,
+		},
+	}
+}
+
+// sortedNamespaceDeltas returns the deltas of the diff in a stable order, so that the plans
+// computed for a schema are identical across calls.
+func sortedNamespaceDeltas(nsDiff nsdiff.Diff) []nsdiff.Delta {
+	deltas := slices.Clone(nsDiff.Deltas())
+	slices.SortStableFunc(deltas, func(a, b nsdiff.Delta) int {
+		return cmp.Or(
+			cmp.Compare(a.RelationName, b.RelationName),
+			cmp.Compare(a.Type, b.Type),
+			cmp.Compare(allowedTypeSource(a.AllowedType), allowedTypeSource(b.AllowedType)),
+		)
+	})
+	return deltas
+}
+
+func allowedTypeSource(allowedType *core.AllowedRelation) string {
+	if allowedType == nil {
+		return ""
+	}
+	return schema.SourceForAllowedRelation(allowedType)
+}
+
+// validateRelationRewrite ensures that the relation to which relationships are rewritten is
+// a relation of the definition in the target schema, and that WriteRelationships would accept
+// the rewritten relationships: every type allowed on the relation rewritten, and every allowed
+// type referencing it as a subject relation, must be allowed in the target schema once
+// rewritten, with the same wildcard, caveat and expiration.
+func validateRelationRewrite(existing diff.DiffableSchema, target diff.DiffableSchema, rewrite RelationRewrite, rewritesByRelation map[string]RelationRewrite) error {
+	relRef := tuple.JoinRelRef(rewrite.DefinitionName, rewrite.FromRelation)
+	toRelRef := tuple.JoinRelRef(rewrite.DefinitionName, rewrite.ToRelation)
+	toRelation, ok := target.GetRelation(rewrite.DefinitionName, rewrite.ToRelation)
+	if !ok || toRelation.UsersetRewrite != nil {
+		return NewSchemaWriteDataValidationError("cannot rewrite relationships to `%s`, as it is not a relation in the schema", []any{toRelRef}, map[string]string{
+			"relation":  toRelRef,
+			"operation": "rewrite_relation",
+		})
+	}
+
+	// A relation which does not exist has no relationships to rewrite; the rewrite is
+	// rejected by the plan as not being of a removed relation.
+	fromRelation, ok := existing.GetRelation(rewrite.DefinitionName, rewrite.FromRelation)
+	if !ok {
+		return nil
+	}
+
+	for _, allowedType := range fromRelation.GetTypeInformation().GetAllowedDirectRelations() {
+		if !allowsType(toRelation, allowedType) {
+			return NewSchemaWriteDataValidationError("cannot rewrite relationships of `%s` to `%s`, as it does not allow subjects of type `%s`", []any{relRef, toRelRef, schema.SourceForAllowedRelation(allowedType)}, map[string]string{
+				"relation":     relRef,
+				"to_relation":  toRelRef,
+				"subject_type": schema.SourceForAllowedRelation(allowedType),
+				"operation":    "rewrite_relation",
+			})
+		}
+	}
+
+	// Relationships with subjects of the relation have their subject relation rewritten, so
+	// the relations allowing them must allow the rewritten subject relation.
+	for _, nsDef := range existing.ObjectDefinitions {
+		for _, relation := range nsDef.Relation {
+			for _, allowedType := range relation.GetTypeInformation().GetAllowedDirectRelations() {
+				if allowedType.Namespace != rewrite.DefinitionName || allowedType.GetRelation() != rewrite.FromRelation {
+					continue
+				}
+
+				// Relationships of relations removed by the schema are deleted, unless they
+				// are themselves rewritten.
+				targetRelationName := relation.Name
+				if relationRewrite, ok := rewritesByRelation[tuple.JoinRelRef(nsDef.Name, relation.Name)]; ok {
+					targetRelationName = relationRewrite.ToRelation
+				}
+
+				targetRelation, ok := target.GetRelation(nsDef.Name, targetRelationName)
+				if !ok {
+					continue
+				}
+
+				rewrittenType := allowedType.CloneVT()
+				rewrittenType.RelationOrWildcard = &core.AllowedRelation_Relation{Relation: rewrite.ToRelation}
+				if !allowsType(targetRelation, rewrittenType) {
+					targetRelRef := tuple.JoinRelRef(nsDef.Name, targetRelationName)
+					return NewSchemaWriteDataValidationError("cannot rewrite subjects of `%s` to `%s`, as `%s` does not allow subjects of type `%s`", []any{relRef, toRelRef, targetRelRef, schema.SourceForAllowedRelation(rewrittenType)}, map[string]string{
+						"relation":     targetRelRef,
+						"to_relation":  toRelRef,
+						"subject_type": schema.SourceForAllowedRelation(rewrittenType),
+						"operation":    "rewrite_relation",
+					})
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// allowsType returns whether the relation allows the type, comparing its subject type,
+// subject relation, wildcard, caveat and expiration as HasAllowedRelation does when
+// validating relationships written.
+func allowsType(relation *core.Relation, allowedType *core.AllowedRelation) bool {
+	source := schema.SourceForAllowedRelation(allowedType)
+	return slices.ContainsFunc(relation.GetTypeInformation().GetAllowedDirectRelations(), func(candidate *core.AllowedRelation) bool {
+		return schema.SourceForAllowedRelation(candidate) == source
+	})
+}
+
+// relationshipsFilterForRemovedAllowedType returns the filter for the relationships of the
+// relation of the delta with subjects of the allowed type removed by it.
+func relationshipsFilterForRemovedAllowedType(nsdefName string, delta nsdiff.Delta) datastore.RelationshipsFilter {
+	var optionalSubjectIds []string
+	var optionalCaveatNameFilter datastore.CaveatNameFilter
+	if delta.AllowedType.GetPublicWildcard() != nil {
+		optionalSubjectIds = []string{tuple.PublicWildcard}
+	}
+
+	if delta.AllowedType.GetRequiredCaveat() != nil && delta.AllowedType.GetRequiredCaveat().CaveatName != "" {
+		optionalCaveatNameFilter = datastore.WithCaveatName(delta.AllowedType.GetRequiredCaveat().CaveatName)
+	} else {
+		optionalCaveatNameFilter = datastore.WithNoCaveat()
+	}
+
+	expirationOption := datastore.ExpirationFilterOptionNoExpiration
+	if delta.AllowedType.RequiredExpiration != nil {
+		expirationOption = datastore.ExpirationFilterOptionHasExpiration
+	}
+
+	return datastore.RelationshipsFilter{
+		OptionalResourceType:     nsdefName,
+		OptionalResourceRelation: delta.RelationName,
+		OptionalSubjectsSelectors: []datastore.SubjectsSelector{
+			{
+				OptionalSubjectType: delta.AllowedType.Namespace,
+				OptionalSubjectIds:  optionalSubjectIds,
+				RelationFilter:      subjectRelationFilterForAllowedType(delta.AllowedType),
+			},
+		},
+		OptionalCaveatNameFilter: optionalCaveatNameFilter,
+		OptionalExpirationOption: expirationOption,
+	}
+}
+
+// CountSchemaMigrationStep returns the number of relationships currently matched by the step.
+func CountSchemaMigrationStep(ctx context.Context, reader datastore.Reader, step SchemaMigrationStep) (uint64, error) {
+	it, err := reader.QueryRelationships(ctx, step.Filter, options.WithQueryShape(queryshape.Varying))
+	if err != nil {
+		return 0, err
+	}
+
+	var count uint64
+	for rel, err := range it {
+		if err != nil {
+			return 0, err
+		}
+
+		if step.Kind == RemoveCaveatParameterStep && !hasCaveatParameter(rel, step.CaveatParameter) {
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+// SchemaMigrationPosition is the position of the execution of a schema migration plan.
+type SchemaMigrationPosition struct {
+	// StepIndex is the index of the step being executed.
+	StepIndex int
+
+	// After is the last relationship processed by the step, if any.
+	After *tuple.Relationship
+}
+
+// SchemaMigrationBatchResult holds the changes made by a batch of a schema migration.
+type SchemaMigrationBatchResult struct {
+	// RelationshipsDeleted is the number of relationships deleted.
+	RelationshipsDeleted uint64
+
+	// RelationshipsRewritten is the number of relationships rewritten.
+	RelationshipsRewritten uint64
+
+	// Next is the position from which to execute the next batch.
+	Next SchemaMigrationPosition
+
+	// Completed is true once every step of the plan has been executed.
+	Completed bool
+}
+
+// ExecuteSchemaMigrationBatch executes the steps of the plan from the position, changing at
+// most batchSize relationships via the specified ReadWriteTransaction. As relationships
+// are processed in order, the batches of a plan can be resumed from the position returned.
+func ExecuteSchemaMigrationBatch(ctx context.Context, rwt datastore.ReadWriteTransaction, plan *SchemaMigrationPlan, position SchemaMigrationPosition, batchSize uint64) (*SchemaMigrationBatchResult, error) {
+	result := &SchemaMigrationBatchResult{Next: position}
+	remaining := batchSize
+
+	for result.Next.StepIndex < len(plan.Steps) && remaining > 0 {
+		step := plan.Steps[result.Next.StepIndex]
+
+		queryOpts := []options.QueryOptionsOption{
+			options.WithLimit(&remaining),
+			options.WithSort(options.ByResource),
+			options.WithQueryShape(queryshape.Varying),
+		}
+		if result.Next.After != nil {
+			queryOpts = append(queryOpts, options.WithAfter(result.Next.After))
+		}
+
+		it, err := rwt.QueryRelationships(ctx, step.Filter, queryOpts...)
+		if err != nil {
+			return nil, err
+		}
+
+		rels, err := datastore.IteratorToSlice(it)
+		if err != nil {
+			return nil, err
+		}
+
+		mutations := make([]tuple.RelationshipUpdate, 0, len(rels))
+		for _, rel := range rels {
+			switch step.Kind {
+			case DeleteRelationshipsStep:
+				mutations = append(mutations, tuple.Delete(rel))
+				result.RelationshipsDeleted++
+
+			case RewriteResourceRelationStep:
+				rewritten := rel
+				rewritten.Resource.Relation = step.Relation
+				mutations = append(mutations, tuple.Delete(rel), tuple.Touch(rewritten))
+				result.RelationshipsRewritten++
+
+			case RewriteSubjectRelationStep:
+				rewritten := rel
+				rewritten.Subject.Relation = step.Relation
+				mutations = append(mutations, tuple.Delete(rel), tuple.Touch(rewritten))
+				result.RelationshipsRewritten++
+
+			case RemoveCaveatParameterStep:
+				if !hasCaveatParameter(rel, step.CaveatParameter) {
+					continue
+				}
+
+				rewritten := rel
+				rewritten.OptionalCaveat = rel.OptionalCaveat.CloneVT()
+				delete(rewritten.OptionalCaveat.Context.Fields, step.CaveatParameter)
+				mutations = append(mutations, tuple.Touch(rewritten))
+				result.RelationshipsRewritten++
+			}
+		}
+
+		if len(mutations) > 0 {
+			if err := rwt.WriteRelationships(ctx, mutations); err != nil {
+				return nil, err
+			}
+		}
+
+		if uint64(len(rels)) < remaining {
+			result.Next = SchemaMigrationPosition{StepIndex: result.Next.StepIndex + 1}
+		} else {
+			last := rels[len(rels)-1]
+			result.Next.After = &last
+		}
+		remaining -= uint64(len(rels))
+	}
+
+	result.Completed = result.Next.StepIndex >= len(plan.Steps)
+	return result, nil
+}
+
+// executeSchemaMigrationSteps executes the steps in full, in batches, via the specified
+// ReadWriteTransaction.
+func executeSchemaMigrationSteps(ctx context.Context, rwt datastore.ReadWriteTransaction, steps []SchemaMigrationStep) error {
+	plan := &SchemaMigrationPlan{Steps: steps}
+	position := SchemaMigrationPosition{}
+	for {
+		result, err := ExecuteSchemaMigrationBatch(ctx, rwt, plan, position, schemaMigrationStepsBatchSize)
+		if err != nil {
+			return err
+		}
+
+		if result.Completed {
+			return nil
+		}
+		position = result.Next
+	}
+}
+
+const schemaMigrationStepsBatchSize = 1000
+
+func hasCaveatParameter(rel tuple.Relationship, parameterName string) bool {
+	if rel.OptionalCaveat == nil || rel.OptionalCaveat.Context == nil {
+		return false
+	}
+
+	_, ok := rel.OptionalCaveat.Context.Fields[parameterName]
+	return ok
+}
@@ -0,0 +1,68 @@
+package v1
+
+import (
+	"context"
+	"errors"
+
+	grpcvalidate "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/validator"
+	"google.golang.org/grpc/codes"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+
+	"github.com/authzed/spicedb/internal/middleware"
+	datastoremw "github.com/authzed/spicedb/internal/middleware/datastore"
+	"github.com/authzed/spicedb/internal/middleware/perfinsights"
+	"github.com/authzed/spicedb/internal/middleware/usagemetrics"
+	"github.com/authzed/spicedb/internal/services/shared"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	countersv1 "github.com/authzed/spicedb/pkg/proto/counters/v1"
+	"github.com/authzed/spicedb/pkg/spiceerrors"
+)
+
+var counterExpirationFilters = map[countersv1.ExpirationScope]core.RelationshipFilter_ExpirationFilter{
+	countersv1.ExpirationScope_EXPIRATION_SCOPE_UNSPECIFIED:  core.RelationshipFilter_EXPIRATION_FILTER_UNSPECIFIED,
+	countersv1.ExpirationScope_EXPIRATION_SCOPE_EXPIRING:     core.RelationshipFilter_EXPIRATION_FILTER_HAS_EXPIRATION,
+	countersv1.ExpirationScope_EXPIRATION_SCOPE_NON_EXPIRING: core.RelationshipFilter_EXPIRATION_FILTER_NO_EXPIRATION,
+}
+
+// NewRelationshipCounterServer creates a RelationshipCounterServiceServer instance.
+func NewRelationshipCounterServer(config PermissionsServerConfig) countersv1.RelationshipCounterServiceServer {
+	return &relationshipCounterServer{
+		WithServiceSpecificInterceptors: shared.WithServiceSpecificInterceptors{
+			Unary: middleware.ChainUnaryServer(
+				grpcvalidate.UnaryServerInterceptor(),
+				usagemetrics.UnaryServerInterceptor(),
+				perfinsights.UnaryServerInterceptor(config.PerformanceInsightMetricsEnabled),
+			),
+			Stream: middleware.ChainStreamServer(
+				grpcvalidate.StreamServerInterceptor(),
+				usagemetrics.StreamServerInterceptor(),
+				perfinsights.StreamServerInterceptor(config.PerformanceInsightMetricsEnabled),
+			),
+		},
+	}
+}
+
+type relationshipCounterServer struct {
+	countersv1.UnimplementedRelationshipCounterServiceServer
+	shared.WithServiceSpecificInterceptors
+}
+
+func (rcs *relationshipCounterServer) RegisterScopedRelationshipCounter(ctx context.Context, req *countersv1.RegisterScopedRelationshipCounterRequest) (*countersv1.RegisterScopedRelationshipCounterResponse, error) {
+	perfinsights.SetInContext(ctx, func() perfinsights.APIShapeLabels {
+		return perfinsights.APIShapeLabels{
+			perfinsights.NameLabel: req.Name,
+		}
+	})
+
+	if req.Name == "" {
+		return nil, shared.RewriteErrorWithoutConfig(ctx, spiceerrors.WithCodeAndReason(errors.New("name must be provided"), codes.InvalidArgument, v1.ErrorReason_ERROR_REASON_UNSPECIFIED))
+	}
+
+	ds := datastoremw.MustFromContext(ctx)
+	if err := registerRelationshipCounter(ctx, ds, req.Name, req.RelationshipFilter, req.OptionalCaveatName, counterExpirationFilters[req.OptionalExpirationScope]); err != nil {
+		return nil, shared.RewriteErrorWithoutConfig(ctx, err)
+	}
+
+	return &countersv1.RegisterScopedRelationshipCounterResponse{}, nil
+}
@@ -109,8 +109,9 @@ func NewExperimentalServer(dispatch dispatch.Dispatcher, permServerConfig Permis
 				perfinsights.StreamServerInterceptor(permServerConfig.PerformanceInsightMetricsEnabled),
 			),
 		},
-		maxBatchSize:  uint64(config.MaxExportBatchSize),
-		caveatTypeSet: caveattypes.TypeSetOrDefault(permServerConfig.CaveatTypeSet),
+		maxBatchSize:          uint64(config.MaxExportBatchSize),
+		caveatTypeSet:         caveattypes.TypeSetOrDefault(permServerConfig.CaveatTypeSet),
+		useMaintainedCounters: permServerConfig.RelationshipCounterMaintenanceEnabled,
 		bulkChecker: &bulkChecker{
 			maxAPIDepth:          permServerConfig.MaximumAPIDepth,
 			maxCaveatContextSize: permServerConfig.MaxCaveatContextSize,
@@ -130,6 +131,11 @@ type experimentalServer struct {
 
 	bulkChecker   *bulkChecker
 	caveatTypeSet *caveattypes.TypeSet
+
+	// useMaintainedCounters, if true, indicates that relationship counter values are
+	// maintained from the Watch API and read from the datastore, rather than computed
+	// on demand.
+	useMaintainedCounters bool
 }
 
 type bulkLoadAdapter struct {
@@ -746,19 +752,41 @@ func (es *experimentalServer) ExperimentalRegisterRelationshipCounter(ctx contex
 		return nil, shared.RewriteErrorWithoutConfig(ctx, spiceerrors.WithCodeAndReason(errors.New("name must be provided"), codes.InvalidArgument, v1.ErrorReason_ERROR_REASON_UNSPECIFIED))
 	}
 
+	if err := registerRelationshipCounter(ctx, ds, req.Name, req.RelationshipFilter, "", core.RelationshipFilter_EXPIRATION_FILTER_UNSPECIFIED); err != nil {
+		return nil, shared.RewriteErrorWithoutConfig(ctx, err)
+	}
+
+	return &v1.ExperimentalRegisterRelationshipCounterResponse{}, nil
+}
+
+// registerRelationshipCounter registers a counter of the relationships matching the filter,
+// optionally scoped to those with the named caveat, and those with or without an expiration.
+func registerRelationshipCounter(
+	ctx context.Context,
+	ds datastore.Datastore,
+	name string,
+	filter *v1.RelationshipFilter,
+	caveatName string,
+	expirationFilter core.RelationshipFilter_ExpirationFilter,
+) error {
 	_, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
-		if err := validateRelationshipsFilter(ctx, req.RelationshipFilter, rwt); err != nil {
+		if err := validateRelationshipsFilter(ctx, filter, rwt); err != nil {
 			return err
 		}
 
-		coreFilter := datastore.CoreFilterFromRelationshipFilter(req.RelationshipFilter)
-		return rwt.RegisterCounter(ctx, req.Name, coreFilter)
-	})
-	if err != nil {
-		return nil, shared.RewriteErrorWithoutConfig(ctx, err)
-	}
+		coreFilter := datastore.CoreFilterFromRelationshipFilter(filter)
+		if caveatName != "" {
+			if _, _, err := rwt.ReadCaveatByName(ctx, caveatName); err != nil {
+				return err
+			}
+			coreFilter.OptionalCaveatName = caveatName
+		}
 
-	return &v1.ExperimentalRegisterRelationshipCounterResponse{}, nil
+		coreFilter.OptionalExpirationFilter = expirationFilter
+
+		return rwt.RegisterCounter(ctx, name, coreFilter)
+	})
+	return err
 }
 
 func (es *experimentalServer) ExperimentalUnregisterRelationshipCounter(ctx context.Context, req *v1.ExperimentalUnregisterRelationshipCounterRequest) (*v1.ExperimentalUnregisterRelationshipCounterResponse, error) {
@@ -802,9 +830,29 @@ func (es *experimentalServer) ExperimentalCountRelationships(ctx context.Context
 	}
 
 	snapshotReader := ds.SnapshotReader(headRev)
-	count, err := snapshotReader.CountRelationships(ctx, req.Name)
-	if err != nil {
-		return nil, shared.RewriteErrorWithoutConfig(ctx, err)
+
+	var count int
+	if es.useMaintainedCounters {
+		counter, err := lookupCounter(ctx, snapshotReader, req.Name)
+		if err != nil {
+			return nil, shared.RewriteErrorWithoutConfig(ctx, err)
+		}
+
+		if counter.ComputedAtRevision == datastore.NoRevision {
+			return &v1.ExperimentalCountRelationshipsResponse{
+				CounterResult: &v1.ExperimentalCountRelationshipsResponse_CounterStillCalculating{
+					CounterStillCalculating: true,
+				},
+			}, nil
+		}
+
+		count = counter.Count
+		headRev = counter.ComputedAtRevision
+	} else {
+		count, err = snapshotReader.CountRelationships(ctx, req.Name)
+		if err != nil {
+			return nil, shared.RewriteErrorWithoutConfig(ctx, err)
+		}
 	}
 
 	uintCount, err := safecast.Convert[uint64](count)
@@ -827,6 +875,21 @@ func (es *experimentalServer) ExperimentalCountRelationships(ctx context.Context
 	}, nil
 }
 
+func lookupCounter(ctx context.Context, reader datastore.Reader, name string) (datastore.RelationshipCounter, error) {
+	counters, err := reader.LookupCounters(ctx)
+	if err != nil {
+		return datastore.RelationshipCounter{}, err
+	}
+
+	for _, counter := range counters {
+		if counter.Name == name {
+			return counter, nil
+		}
+	}
+
+	return datastore.RelationshipCounter{}, datastore.NewCounterNotRegisteredErr(name)
+}
+
 func queryForEach(
 	ctx context.Context,
 	reader datastore.Reader,
@@ -9,9 +9,11 @@ import (
 	"math/rand"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/ccoveille/go-safecast/v2"
 	"github.com/jzelinskie/stringz"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
 	"google.golang.org/grpc"
@@ -29,6 +31,7 @@ import (
 	"github.com/authzed/spicedb/internal/testserver"
 	"github.com/authzed/spicedb/pkg/datastore"
 	"github.com/authzed/spicedb/pkg/genutil/mapz"
+	countersv1 "github.com/authzed/spicedb/pkg/proto/counters/v1"
 	"github.com/authzed/spicedb/pkg/testutil"
 	"github.com/authzed/spicedb/pkg/tuple"
 )
@@ -1737,3 +1740,145 @@ func TestExperimentalCountRelationships(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, uint64(2), actual.GetReadCounterValue().RelationshipCount)
 }
+
+const scopedCounterSchema = `use expiration
+
+caveat somecaveat(somecondition int) {
+	somecondition == 42
+}
+
+definition user {}
+
+definition document {
+	relation viewer: user | user with somecaveat | user with expiration
+}`
+
+var scopedCounterRelationships = []string{
+	"document:doc1#viewer@user:alice",
+	"document:doc1#viewer@user:bob[somecaveat]",
+	"document:doc2#viewer@user:bob[somecaveat]",
+	"document:doc2#viewer@user:charlie[expiration:2320-01-01T00:00:00Z]",
+	"document:doc3#viewer@user:charlie[expiration:2020-01-01T00:00:00Z]",
+}
+
+func writeScopedCounterData(t *testing.T, conn *grpc.ClientConn) {
+	_, err := v1.NewSchemaServiceClient(conn).WriteSchema(t.Context(), &v1.WriteSchemaRequest{
+		Schema: scopedCounterSchema,
+	})
+	require.NoError(t, err)
+
+	relUpdates := make([]tuple.RelationshipUpdate, 0, len(scopedCounterRelationships))
+	for _, rel := range scopedCounterRelationships {
+		relUpdates = append(relUpdates, tuple.Create(tuple.MustParse(rel)))
+	}
+
+	updates, err := tuple.UpdatesToV1RelationshipUpdates(relUpdates)
+	require.NoError(t, err)
+
+	_, err = v1.NewPermissionsServiceClient(conn).WriteRelationships(t.Context(), &v1.WriteRelationshipsRequest{
+		Updates: updates,
+	})
+	require.NoError(t, err)
+}
+
+func registerScopedCounters(t *testing.T, conn *grpc.ClientConn) {
+	client := countersv1.NewRelationshipCounterServiceClient(conn)
+	for _, req := range []*countersv1.RegisterScopedRelationshipCounterRequest{
+		{Name: "all"},
+		{Name: "caveated", OptionalCaveatName: "somecaveat"},
+		{Name: "expiring", OptionalExpirationScope: countersv1.ExpirationScope_EXPIRATION_SCOPE_EXPIRING},
+		{Name: "nonexpiring", OptionalExpirationScope: countersv1.ExpirationScope_EXPIRATION_SCOPE_NON_EXPIRING},
+	} {
+		req.RelationshipFilter = &v1.RelationshipFilter{ResourceType: "document"}
+		_, err := client.RegisterScopedRelationshipCounter(t.Context(), req)
+		require.NoError(t, err)
+	}
+}
+
+var expectedScopedCounts = map[string]uint64{
+	"all":         4,
+	"caveated":    2,
+	"expiring":    1,
+	"nonexpiring": 3,
+}
+
+func TestExperimentalCountRelationshipsScoped(t *testing.T) {
+	conn, cleanup, _, _ := testserver.NewTestServer(require.New(t), 0, memdb.DisableGC, true, tf.EmptyDatastore)
+	expClient := v1.NewExperimentalServiceClient(conn)
+	defer cleanup()
+
+	writeScopedCounterData(t, conn)
+	registerScopedCounters(t, conn)
+
+	for name, expected := range expectedScopedCounts {
+		actual, err := expClient.ExperimentalCountRelationships(t.Context(), &v1.ExperimentalCountRelationshipsRequest{
+			Name: name,
+		})
+		require.NoError(t, err)
+		require.Equal(t, expected, actual.GetReadCounterValue().RelationshipCount, "mismatch for counter %s", name)
+	}
+
+	// Unknown caveats and expiration scopes are rejected.
+	client := countersv1.NewRelationshipCounterServiceClient(conn)
+	_, err := client.RegisterScopedRelationshipCounter(t.Context(), &countersv1.RegisterScopedRelationshipCounterRequest{
+		Name:               "unknowncaveat",
+		RelationshipFilter: &v1.RelationshipFilter{ResourceType: "document"},
+		OptionalCaveatName: "unknowncaveat",
+	})
+	require.ErrorContains(t, err, "unknowncaveat")
+
+	_, err = client.RegisterScopedRelationshipCounter(t.Context(), &countersv1.RegisterScopedRelationshipCounterRequest{
+		Name:                    "unknownscope",
+		RelationshipFilter:      &v1.RelationshipFilter{ResourceType: "document"},
+		OptionalExpirationScope: countersv1.ExpirationScope(42),
+	})
+	grpcutil.RequireStatus(t, codes.InvalidArgument, err)
+}
+
+func TestExperimentalCountRelationshipsMaintained(t *testing.T) {
+	config := testserver.DefaultTestServerConfig
+	config.EnableRelationshipCounterMaintenance = true
+
+	conn, cleanup, _, _ := testserver.NewTestServerWithConfig(require.New(t), 0, memdb.DisableGC, true, config, tf.EmptyDatastore)
+	expClient := v1.NewExperimentalServiceClient(conn)
+	defer cleanup()
+
+	writeScopedCounterData(t, conn)
+	registerScopedCounters(t, conn)
+
+	requireCounts(t, expClient, expectedScopedCounts)
+
+	// Remove a caveated relationship, which is reflected in the maintained counts.
+	_, err := v1.NewPermissionsServiceClient(conn).DeleteRelationships(t.Context(), &v1.DeleteRelationshipsRequest{
+		RelationshipFilter: &v1.RelationshipFilter{
+			ResourceType:       "document",
+			OptionalResourceId: "doc2",
+			OptionalSubjectFilter: &v1.SubjectFilter{
+				SubjectType:       "user",
+				OptionalSubjectId: "bob",
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	requireCounts(t, expClient, map[string]uint64{
+		"all":         3,
+		"caveated":    1,
+		"expiring":    1,
+		"nonexpiring": 2,
+	})
+}
+
+func requireCounts(t *testing.T, expClient v1.ExperimentalServiceClient, expectedCounts map[string]uint64) {
+	require.EventuallyWithT(t, func(c *assert.CollectT) {
+		for name, expected := range expectedCounts {
+			actual, err := expClient.ExperimentalCountRelationships(t.Context(), &v1.ExperimentalCountRelationshipsRequest{
+				Name: name,
+			})
+			require.NoError(c, err)
+			require.False(c, actual.GetCounterStillCalculating(), "counter %s is still calculating", name)
+			require.Equal(c, expected, actual.GetReadCounterValue().RelationshipCount, "mismatch for counter %s", name)
+			require.NotNil(c, actual.GetReadCounterValue().ReadAt)
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+}
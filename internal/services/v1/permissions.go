@@ -464,6 +464,12 @@ func (ps *permissionServer) LookupResources(req *v1.LookupResourcesRequest, resp
 		}
 	}
 
+	// The query plan does not yet support cursors or limits, so lookups are only evaluated
+	// by it when they are to be analyzed.
+	if ps.config.ExperimentalQueryPlan && req.OptionalCursor == nil && req.OptionalLimit == 0 && isExplainAnalyzeRequested(resp.Context()) {
+		return ps.lookupResourcesWithQueryPlan(req, resp)
+	}
+
 	if ps.config.EnableExperimentalLookupResources3 {
 		return ps.lookupResources3(req, resp)
 	}
@@ -2,25 +2,141 @@ package v1
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/authzed/authzed-go/pkg/requestmeta"
+	"github.com/authzed/authzed-go/pkg/responsemeta"
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
 
 	caveatsimpl "github.com/authzed/spicedb/internal/caveats"
 	datastoremw "github.com/authzed/spicedb/internal/middleware/datastore"
 	"github.com/authzed/spicedb/pkg/datastore"
 	"github.com/authzed/spicedb/pkg/middleware/consistency"
+	dispatch "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
 	"github.com/authzed/spicedb/pkg/query"
 	"github.com/authzed/spicedb/pkg/schema/v2"
+	"github.com/authzed/spicedb/pkg/spiceerrors"
 )
 
-// checkPermissionWithQueryPlan executes a permission check using the query plan API.
-// This builds an iterator tree from the schema and executes it against the datastore.
-func (ps *permissionServer) checkPermissionWithQueryPlan(ctx context.Context, req *v1.CheckPermissionRequest) (*v1.CheckPermissionResponse, error) {
-	atRevision, checkedAt, err := consistency.RevisionFromContext(ctx)
+const (
+	// ExplainAnalyzeHeader, if specified in the request header of a CheckPermission or
+	// LookupResources call evaluated by the experimental query plan, asks SpiceDB to return
+	// the statistics of the evaluation of each iterator of the plan in the
+	// ExplainAnalyzeTrailer of the response.
+	//
+	// LookupResources calls are only evaluated by the query plan when this header is
+	// specified, and neither a cursor nor a limit is given.
+	ExplainAnalyzeHeader requestmeta.BoolRequestMetadataHeaderKey = "io.spicedb.requestexplainanalyze"
+
+	// ExplainAnalyzeTrailer is the key in the response trailer metadata for the JSON-encoded
+	// debug information holding the analysis of the evaluation of the query plan.
+	ExplainAnalyzeTrailer responsemeta.ResponseMetadataTrailerKey = "io.spicedb.respmeta.explainanalyze"
+
+	// QueryPlanConcurrencyLimitHeader, if specified in the request header of a call evaluated
+	// by the experimental query plan, is the maximum number of subtrees of the plan to be
+	// evaluated concurrently for the request, with zero evaluating them sequentially. The
+	// limit configured for the server is used if it is lower, or if the header is absent.
+	QueryPlanConcurrencyLimitHeader requestmeta.RequestMetadataHeaderKey = "io.spicedb.queryplanconcurrencylimit"
+)
+
+// queryPlanStatisticsRefreshInterval is how long the datastore statistics used by the
+// cost-based optimizer are reused before being read again.
+const queryPlanStatisticsRefreshInterval = time.Minute
+
+// queryPlanStatistics caches the statistics of the datastore used by the cost-based
+// optimizer, as reading them may query the datastore several times.
+type queryPlanStatistics struct {
+	lock      sync.Mutex
+	stats     query.Statistics
+	fetchedAt time.Time
+}
+
+// get returns the cached statistics, reading them from the datastore if they are older
+// than the refresh interval.
+func (qs *queryPlanStatistics) get(ctx context.Context, ds datastore.ReadOnlyDatastore) (query.Statistics, error) {
+	qs.lock.Lock()
+	defer qs.lock.Unlock()
+
+	if !qs.fetchedAt.IsZero() && time.Since(qs.fetchedAt) < queryPlanStatisticsRefreshInterval {
+		return qs.stats, nil
+	}
+
+	stats, err := ds.Statistics(ctx)
 	if err != nil {
-		return nil, ps.rewriteError(ctx, err)
+		return query.Statistics{}, err
+	}
+
+	qs.stats = query.StatisticsFromDatastore(stats)
+	qs.fetchedAt = time.Now()
+	return qs.stats, nil
+}
+
+// isExplainAnalyzeRequested returns whether the request asks for its evaluation by the
+// query plan to be analyzed.
+func isExplainAnalyzeRequested(ctx context.Context) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+
+	_, isRequested := md[string(ExplainAnalyzeHeader)]
+	return isRequested
+}
+
+// queryPlanConcurrencyLimit returns the concurrency limit with which the query plan of the
+// request is evaluated: that requested, if any, bounded by the limit of the server.
+func (ps *permissionServer) queryPlanConcurrencyLimit(ctx context.Context) (uint16, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ps.config.QueryPlanConcurrencyLimit, nil
+	}
+
+	values := md.Get(string(QueryPlanConcurrencyLimitHeader))
+	if len(values) == 0 {
+		return ps.config.QueryPlanConcurrencyLimit, nil
+	}
+
+	requested, err := strconv.ParseUint(values[0], 10, 16)
+	if err != nil {
+		return 0, spiceerrors.WithCodeAndReason(
+			fmt.Errorf("invalid value for %s: %w", QueryPlanConcurrencyLimitHeader, err),
+			codes.InvalidArgument,
+			v1.ErrorReason_ERROR_REASON_UNSPECIFIED,
+		)
+	}
+	return min(uint16(requested), ps.config.QueryPlanConcurrencyLimit), nil
+}
+
+// setExplainAnalyzeTrailer returns the analysis recorded by the analyzer, if any, in the
+// trailer of the response.
+func setExplainAnalyzeTrailer(ctx context.Context, analyzer *query.Analyzer) error {
+	if analyzer == nil {
+		return nil
+	}
+
+	encoded, err := protojson.Marshal(&dispatch.DebugInformation{
+		QueryPlanAnalysis: analyzer.Analysis(),
+	})
+	if err != nil {
+		return err
 	}
 
+	return responsemeta.SetResponseTrailerMetadata(ctx, map[responsemeta.ResponseMetadataTrailerKey]string{
+		ExplainAnalyzeTrailer: string(encoded),
+	})
+}
+
+// newQueryPlanContext builds the iterator tree for the relation of the definition, from the
+// schema at the revision, along with the query context with which to evaluate it. The tree
+// is ordered by the cost-based optimizer, using the statistics of the datastore.
+func (ps *permissionServer) newQueryPlanContext(ctx context.Context, atRevision datastore.Revision, definitionName string, relationName string, caveatContext map[string]any) (*query.Context, query.Iterator, error) {
 	ds := datastoremw.MustFromContext(ctx)
 	reader := ds.SnapshotReader(atRevision)
 
@@ -28,12 +144,12 @@ func (ps *permissionServer) checkPermissionWithQueryPlan(ctx context.Context, re
 	// TODO: Better schema caching
 	namespaces, err := reader.ListAllNamespaces(ctx)
 	if err != nil {
-		return nil, ps.rewriteError(ctx, err)
+		return nil, nil, err
 	}
 
 	caveats, err := reader.ListAllCaveats(ctx)
 	if err != nil {
-		return nil, ps.rewriteError(ctx, err)
+		return nil, nil, err
 	}
 
 	// Build schema from definitions
@@ -42,30 +158,66 @@ func (ps *permissionServer) checkPermissionWithQueryPlan(ctx context.Context, re
 		datastore.DefinitionsOf(caveats),
 	)
 	if err != nil {
-		return nil, ps.rewriteError(ctx, err)
+		return nil, nil, err
 	}
 
 	// Build iterator tree from schema
 	// TODO: Better iterator caching
-	it, err := query.BuildIteratorFromSchema(fullSchema, req.Resource.ObjectType, req.Permission)
+	it, err := query.BuildIteratorFromSchema(fullSchema, definitionName, relationName)
 	if err != nil {
-		return nil, ps.rewriteError(ctx, err)
+		return nil, nil, err
 	}
 
-	// Parse caveat context if provided
-	caveatContext, err := GetCaveatContext(ctx, req.Context, ps.config.MaxCaveatContextSize)
+	stats, err := ps.queryPlanStatistics.get(ctx, ds)
 	if err != nil {
-		return nil, ps.rewriteError(ctx, err)
+		return nil, nil, err
+	}
+
+	it, _, err = query.ApplyOptimizations(it, query.CostBasedOptimizations(stats))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	concurrencyLimit, err := ps.queryPlanConcurrencyLimit(ctx)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Create query context with optional tracing
+	// Create query context with optional tracing. The executor is created for each
+	// request, so that the concurrency limit applies to the request as a whole, and
+	// dispatches subtrees reaching other relations over the cluster.
 	qctx := &query.Context{
 		Context:       ctx,
-		Executor:      query.LocalExecutor{},
+		Executor:      query.NewRemoteExecutor(ps.dispatch, atRevision, ps.config.MaximumAPIDepth, query.NewParallelExecutor(concurrencyLimit)),
 		Reader:        reader,
 		CaveatContext: caveatContext,
 		CaveatRunner:  caveatsimpl.NewCaveatRunner(ps.config.CaveatTypeSet),
 	}
+	if isExplainAnalyzeRequested(ctx) {
+		qctx.Analyzer = query.NewAnalyzerWithStatistics(stats)
+	}
+
+	return qctx, it, nil
+}
+
+// checkPermissionWithQueryPlan executes a permission check using the query plan API.
+// This builds an iterator tree from the schema and executes it against the datastore.
+func (ps *permissionServer) checkPermissionWithQueryPlan(ctx context.Context, req *v1.CheckPermissionRequest) (*v1.CheckPermissionResponse, error) {
+	atRevision, checkedAt, err := consistency.RevisionFromContext(ctx)
+	if err != nil {
+		return nil, ps.rewriteError(ctx, err)
+	}
+
+	// Parse caveat context if provided
+	caveatContext, err := GetCaveatContext(ctx, req.Context, ps.config.MaxCaveatContextSize)
+	if err != nil {
+		return nil, ps.rewriteError(ctx, err)
+	}
+
+	qctx, it, err := ps.newQueryPlanContext(ctx, atRevision, req.Resource.ObjectType, req.Permission, caveatContext)
+	if err != nil {
+		return nil, ps.rewriteError(ctx, err)
+	}
 
 	// Execute the check
 	resource := query.Object{
@@ -90,6 +242,10 @@ func (ps *permissionServer) checkPermissionWithQueryPlan(ctx context.Context, re
 		return nil, ps.rewriteError(ctx, err)
 	}
 
+	if err := setExplainAnalyzeTrailer(ctx, qctx.Analyzer); err != nil {
+		return nil, ps.rewriteError(ctx, err)
+	}
+
 	resp := &v1.CheckPermissionResponse{
 		CheckedAt:         checkedAt,
 		Permissionship:    permissionship,
@@ -123,3 +279,79 @@ func convertPathsToPermissionship(pathSeq query.PathSeq) (v1.CheckPermissionResp
 	// No paths found - no permission
 	return v1.CheckPermissionResponse_PERMISSIONSHIP_NO_PERMISSION, nil, nil
 }
+
+// lookupResourcesWithQueryPlan executes a lookup of resources using the query plan API, by
+// iterating the resources of the iterator tree built for the permission.
+func (ps *permissionServer) lookupResourcesWithQueryPlan(req *v1.LookupResourcesRequest, resp v1.PermissionsService_LookupResourcesServer) error {
+	ctx := resp.Context()
+
+	atRevision, lookedUpAt, err := consistency.RevisionFromContext(ctx)
+	if err != nil {
+		return ps.rewriteError(ctx, err)
+	}
+
+	caveatContext, err := GetCaveatContext(ctx, req.Context, ps.config.MaxCaveatContextSize)
+	if err != nil {
+		return ps.rewriteError(ctx, err)
+	}
+
+	qctx, it, err := ps.newQueryPlanContext(ctx, atRevision, req.ResourceObjectType, req.Permission, caveatContext)
+	if err != nil {
+		return ps.rewriteError(ctx, err)
+	}
+
+	subject := query.ObjectAndRelation{
+		ObjectType: req.Subject.Object.ObjectType,
+		ObjectID:   req.Subject.Object.ObjectId,
+		Relation:   normalizeSubjectRelation(req.Subject),
+	}
+
+	pathSeq, err := qctx.IterResources(it, subject)
+	if err != nil {
+		return ps.rewriteError(ctx, err)
+	}
+
+	// A resource may be found by several paths; it has the permission if any of them
+	// is without a caveat.
+	var resourceIDs []string
+	isConditional := make(map[string]bool)
+	for path, err := range pathSeq {
+		if err != nil {
+			return ps.rewriteError(ctx, err)
+		}
+
+		conditional, ok := isConditional[path.Resource.ObjectID]
+		if !ok {
+			resourceIDs = append(resourceIDs, path.Resource.ObjectID)
+			conditional = true
+		}
+		isConditional[path.Resource.ObjectID] = conditional && path.Caveat != nil
+	}
+
+	if err := setExplainAnalyzeTrailer(ctx, qctx.Analyzer); err != nil {
+		return ps.rewriteError(ctx, err)
+	}
+
+	for _, resourceID := range resourceIDs {
+		permissionship := v1.LookupPermissionship_LOOKUP_PERMISSIONSHIP_HAS_PERMISSION
+		var partial *v1.PartialCaveatInfo
+		if isConditional[resourceID] {
+			// TODO: Extract missing required context from caveat expression
+			permissionship = v1.LookupPermissionship_LOOKUP_PERMISSIONSHIP_CONDITIONAL_PERMISSION
+			partial = &v1.PartialCaveatInfo{
+				MissingRequiredContext: []string{},
+			}
+		}
+
+		if err := resp.Send(&v1.LookupResourcesResponse{
+			LookedUpAt:        lookedUpAt,
+			ResourceObjectId:  resourceID,
+			Permissionship:    permissionship,
+			PartialCaveatInfo: partial,
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
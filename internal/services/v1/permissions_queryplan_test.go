@@ -0,0 +1,240 @@
+package v1_test
+
+import (
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/authzed/authzed-go/pkg/requestmeta"
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/authzed/grpcutil"
+
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	v1svc "github.com/authzed/spicedb/internal/services/v1"
+	tf "github.com/authzed/spicedb/internal/testfixtures"
+	"github.com/authzed/spicedb/internal/testserver"
+	"github.com/authzed/spicedb/pkg/datastore"
+	dispatch "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+	"github.com/authzed/spicedb/pkg/zedtoken"
+)
+
+// explainAnalyzeSchema avoids recursive permissions, whose query plans are evaluated to the
+// default maximum recursion depth.
+const explainAnalyzeSchema = `
+	definition user {}
+
+	definition organization {
+		relation admin: user
+	}
+
+	definition document {
+		relation org: organization
+		relation viewer: user
+		relation editor: user
+		permission edit = editor
+		permission view = viewer + edit + org->admin
+	}
+`
+
+func explainAnalyzeDatastore(emptyDS datastore.Datastore, require *require.Assertions) (datastore.Datastore, datastore.Revision) {
+	return tf.DatastoreFromSchemaAndTestRelationships(emptyDS, explainAnalyzeSchema, []tuple.Relationship{
+		tuple.MustParse("document:firstdoc#org@organization:someorg"),
+		tuple.MustParse("document:firstdoc#viewer@user:tom"),
+		tuple.MustParse("document:seconddoc#editor@user:tom"),
+		tuple.MustParse("document:thirddoc#editor@user:sarah"),
+		tuple.MustParse("organization:someorg#admin@user:sarah"),
+	}, require)
+}
+
+func explainAnalyzeFromTrailer(t *testing.T, trailer metadata.MD) *dispatch.QueryPlanAnalysis {
+	t.Helper()
+
+	values := trailer.Get(string(v1svc.ExplainAnalyzeTrailer))
+	require.Len(t, values, 1)
+
+	var debugInfo dispatch.DebugInformation
+	require.NoError(t, protojson.Unmarshal([]byte(values[0]), &debugInfo))
+	require.Len(t, debugInfo.QueryPlanAnalysis, 1)
+	return debugInfo.QueryPlanAnalysis[0]
+}
+
+func totalDatastoreStats(analysis *dispatch.QueryPlanAnalysis) (queries uint64, rows uint64) {
+	queries, rows = analysis.DatastoreQueries, analysis.RowsRead
+	for _, child := range analysis.Children {
+		childQueries, childRows := totalDatastoreStats(child)
+		queries += childQueries
+		rows += childRows
+	}
+	return queries, rows
+}
+
+func hasDispatched(analysis *dispatch.QueryPlanAnalysis) bool {
+	if analysis.Dispatched {
+		return true
+	}
+	for _, child := range analysis.Children {
+		if hasDispatched(child) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCheckPermissionExplainAnalyze(t *testing.T) {
+	config := testserver.DefaultTestServerConfig
+	config.EnableExperimentalQueryPlan = true
+
+	conn, cleanup, _, revision := testserver.NewTestServerWithConfig(require.New(t), 0, memdb.DisableGC, true, config, explainAnalyzeDatastore)
+	t.Cleanup(cleanup)
+
+	client := v1.NewPermissionsServiceClient(conn)
+	req := &v1.CheckPermissionRequest{
+		Consistency: &v1.Consistency{
+			Requirement: &v1.Consistency_AtLeastAsFresh{
+				AtLeastAsFresh: zedtoken.MustNewFromRevisionForTesting(revision),
+			},
+		},
+		Resource:   obj("document", "firstdoc"),
+		Permission: "view",
+		Subject:    sub("user", "sarah", ""),
+	}
+
+	t.Run("requested", func(t *testing.T) {
+		var trailer metadata.MD
+		ctx := requestmeta.AddRequestHeaders(t.Context(), v1svc.ExplainAnalyzeHeader)
+		resp, err := client.CheckPermission(ctx, req, grpc.Trailer(&trailer))
+		require.NoError(t, err)
+		require.Equal(t, v1.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION, resp.Permissionship)
+
+		analysis := explainAnalyzeFromTrailer(t, trailer)
+		require.Equal(t, "Alias(view)", analysis.Iterator)
+		require.Equal(t, uint32(1), analysis.Calls)
+		require.Positive(t, analysis.Results)
+		require.Positive(t, analysis.Duration.AsDuration())
+		require.NotEmpty(t, analysis.Children)
+
+		// The plan is ordered by the cost-based optimizer, whose estimates are returned.
+		require.NotNil(t, analysis.Estimate)
+		require.Positive(t, analysis.Estimate.Cost)
+
+		// The permission is found through the organization, so the arrow to it is
+		// dispatched and analyzed by the dispatcher.
+		require.True(t, hasDispatched(analysis))
+
+		queries, rows := totalDatastoreStats(analysis)
+		require.Positive(t, queries)
+		require.Positive(t, rows)
+	})
+
+	t.Run("not requested", func(t *testing.T) {
+		var trailer metadata.MD
+		_, err := client.CheckPermission(t.Context(), req, grpc.Trailer(&trailer))
+		require.NoError(t, err)
+		require.Empty(t, trailer.Get(string(v1svc.ExplainAnalyzeTrailer)))
+	})
+}
+
+func TestLookupResourcesExplainAnalyze(t *testing.T) {
+	config := testserver.DefaultTestServerConfig
+	config.EnableExperimentalQueryPlan = true
+
+	conn, cleanup, _, revision := testserver.NewTestServerWithConfig(require.New(t), 0, memdb.DisableGC, true, config, explainAnalyzeDatastore)
+	t.Cleanup(cleanup)
+
+	client := v1.NewPermissionsServiceClient(conn)
+
+	for _, tc := range []struct {
+		permission          string
+		subjectID           string
+		expectedResourceIDs []string
+		expectedRowsRead    uint64
+	}{
+		{"edit", "tom", []string{"seconddoc"}, 1},
+
+		// `view` reaches sarah through the admins of the organization of firstdoc, as well
+		// as through the editors of thirddoc.
+		{"view", "sarah", []string{"firstdoc", "thirddoc"}, 3},
+	} {
+		t.Run(tc.permission, func(t *testing.T) {
+			ctx := requestmeta.AddRequestHeaders(t.Context(), v1svc.ExplainAnalyzeHeader)
+			stream, err := client.LookupResources(ctx, &v1.LookupResourcesRequest{
+				Consistency: &v1.Consistency{
+					Requirement: &v1.Consistency_AtLeastAsFresh{
+						AtLeastAsFresh: zedtoken.MustNewFromRevisionForTesting(revision),
+					},
+				},
+				ResourceObjectType: "document",
+				Permission:         tc.permission,
+				Subject:            sub("user", tc.subjectID, ""),
+			})
+			require.NoError(t, err)
+
+			var resourceIDs []string
+			for {
+				resp, err := stream.Recv()
+				if err == io.EOF {
+					break
+				}
+				require.NoError(t, err)
+				require.Equal(t, v1.LookupPermissionship_LOOKUP_PERMISSIONSHIP_HAS_PERMISSION, resp.Permissionship)
+				resourceIDs = append(resourceIDs, resp.ResourceObjectId)
+			}
+			slices.Sort(resourceIDs)
+			require.Equal(t, tc.expectedResourceIDs, resourceIDs)
+
+			analysis := explainAnalyzeFromTrailer(t, stream.Trailer())
+			require.Equal(t, "Alias("+tc.permission+")", analysis.Iterator)
+			require.Equal(t, uint64(len(tc.expectedResourceIDs)), analysis.Results)
+
+			queries, rows := totalDatastoreStats(analysis)
+			require.Positive(t, queries)
+			require.Equal(t, tc.expectedRowsRead, rows)
+		})
+	}
+}
+
+func TestQueryPlanConcurrencyLimitHeader(t *testing.T) {
+	config := testserver.DefaultTestServerConfig
+	config.EnableExperimentalQueryPlan = true
+
+	conn, cleanup, _, revision := testserver.NewTestServerWithConfig(require.New(t), 0, memdb.DisableGC, true, config, explainAnalyzeDatastore)
+	t.Cleanup(cleanup)
+
+	client := v1.NewPermissionsServiceClient(conn)
+	req := &v1.CheckPermissionRequest{
+		Consistency: &v1.Consistency{
+			Requirement: &v1.Consistency_AtLeastAsFresh{
+				AtLeastAsFresh: zedtoken.MustNewFromRevisionForTesting(revision),
+			},
+		},
+		Resource:   obj("document", "firstdoc"),
+		Permission: "view",
+		Subject:    sub("user", "sarah", ""),
+	}
+
+	for _, limit := range []string{"0", "2", "65535"} {
+		t.Run(limit, func(t *testing.T) {
+			ctx := requestmeta.SetRequestHeaders(t.Context(), map[requestmeta.RequestMetadataHeaderKey]string{
+				v1svc.QueryPlanConcurrencyLimitHeader: limit,
+			})
+			resp, err := client.CheckPermission(ctx, req)
+			require.NoError(t, err)
+			require.Equal(t, v1.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION, resp.Permissionship)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		ctx := requestmeta.SetRequestHeaders(t.Context(), map[requestmeta.RequestMetadataHeaderKey]string{
+			v1svc.QueryPlanConcurrencyLimitHeader: "many",
+		})
+		_, err := client.CheckPermission(ctx, req)
+		grpcutil.RequireStatus(t, codes.InvalidArgument, err)
+	})
+}
@@ -118,6 +118,15 @@ type PermissionsServerConfig struct {
 
 	// ExperimentalQueryPlan enables the experimental query plan for API calls.
 	ExperimentalQueryPlan bool
+
+	// QueryPlanConcurrencyLimit defines the maximum number of subtrees of a query plan
+	// that are evaluated concurrently for a single request. Requests may ask for a lower
+	// limit with the QueryPlanConcurrencyLimitHeader.
+	QueryPlanConcurrencyLimit uint16
+
+	// RelationshipCounterMaintenanceEnabled defines whether relationship counters are
+	// maintained from the Watch API, in which case their stored values are returned.
+	RelationshipCounterMaintenanceEnabled bool
 }
 
 // NewPermissionsServer creates a PermissionsServiceServer instance.
@@ -144,6 +153,7 @@ func NewPermissionsServer(
 		PerformanceInsightMetricsEnabled:   config.PerformanceInsightMetricsEnabled,
 		EnableExperimentalLookupResources3: config.EnableExperimentalLookupResources3,
 		ExperimentalQueryPlan:              config.ExperimentalQueryPlan,
+		QueryPlanConcurrencyLimit:          defaultIfZero(config.QueryPlanConcurrencyLimit, 50),
 	}
 
 	return &permissionServer{
@@ -164,6 +174,7 @@ func NewPermissionsServer(
 				perfinsights.StreamServerInterceptor(configWithDefaults.PerformanceInsightMetricsEnabled),
 			),
 		},
+		queryPlanStatistics: &queryPlanStatistics{},
 		bulkChecker: &bulkChecker{
 			maxAPIDepth:          configWithDefaults.MaximumAPIDepth,
 			maxCaveatContextSize: configWithDefaults.MaxCaveatContextSize,
@@ -183,6 +194,8 @@ type permissionServer struct {
 	config   PermissionsServerConfig
 
 	bulkChecker *bulkChecker
+
+	queryPlanStatistics *queryPlanStatistics
 }
 
 func (ps *permissionServer) ReadRelationships(req *v1.ReadRelationshipsRequest, resp v1.PermissionsService_ReadRelationshipsServer) error {
@@ -172,7 +172,7 @@ func (ss *schemaServer) WriteSchema(ctx context.Context, in *v1.WriteSchemaReque
 
 	// Update the schema.
 	revision, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
-		applied, err := shared.ApplySchemaChanges(ctx, rwt, ss.caveatTypeSet, validated)
+		applied, err := shared.ApplySchemaChanges(ctx, rwt, ss.caveatTypeSet, validated.WithSchemaText(in.GetSchema()))
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,201 @@
+package v1
+
+import (
+	"context"
+
+	grpcvalidate "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+
+	"github.com/authzed/spicedb/internal/middleware"
+	datastoremw "github.com/authzed/spicedb/internal/middleware/datastore"
+	"github.com/authzed/spicedb/internal/middleware/perfinsights"
+	"github.com/authzed/spicedb/internal/middleware/usagemetrics"
+	"github.com/authzed/spicedb/internal/services/shared"
+	"github.com/authzed/spicedb/pkg/cursor"
+	"github.com/authzed/spicedb/pkg/datastore"
+	schemahistoryv1 "github.com/authzed/spicedb/pkg/proto/schemahistory/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/generator"
+	"github.com/authzed/spicedb/pkg/zedtoken"
+)
+
+// defaultSchemaHistoryLimit is the number of entries returned by a call to ReadSchemaHistory,
+// if no limit is given.
+const defaultSchemaHistoryLimit = 100
+
+// schemaHistoryCursorHash is the call hash of the cursors of ReadSchemaHistory, which only
+// hold the revision of the last entry returned.
+const schemaHistoryCursorHash = "schemahistory"
+
+// NewSchemaHistoryServer creates a SchemaHistoryServiceServer instance.
+func NewSchemaHistoryServer(config SchemaServerConfig) schemahistoryv1.SchemaHistoryServiceServer {
+	return &schemaHistoryServer{
+		WithServiceSpecificInterceptors: shared.WithServiceSpecificInterceptors{
+			Unary: middleware.ChainUnaryServer(
+				grpcvalidate.UnaryServerInterceptor(),
+				usagemetrics.UnaryServerInterceptor(),
+				perfinsights.UnaryServerInterceptor(config.PerformanceInsightMetricsEnabled),
+			),
+			Stream: middleware.ChainStreamServer(
+				grpcvalidate.StreamServerInterceptor(),
+				usagemetrics.StreamServerInterceptor(),
+				perfinsights.StreamServerInterceptor(config.PerformanceInsightMetricsEnabled),
+			),
+		},
+	}
+}
+
+type schemaHistoryServer struct {
+	schemahistoryv1.UnimplementedSchemaHistoryServiceServer
+	shared.WithServiceSpecificInterceptors
+}
+
+func (shs *schemaHistoryServer) rewriteError(ctx context.Context, err error) error {
+	return shared.RewriteError(ctx, err, nil)
+}
+
+func (shs *schemaHistoryServer) ReadSchemaHistory(ctx context.Context, req *schemahistoryv1.ReadSchemaHistoryRequest) (*schemahistoryv1.ReadSchemaHistoryResponse, error) {
+	perfinsights.SetInContext(ctx, perfinsights.NoLabels)
+
+	ds := datastoremw.MustFromContext(ctx)
+
+	var atRevision datastore.Revision
+	if req.OptionalAt != "" {
+		decoded, tokenStatus, err := zedtoken.DecodeRevision(&v1.ZedToken{Token: req.OptionalAt}, ds)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to decode revision: %s", err)
+		}
+
+		if tokenStatus == zedtoken.StatusMismatchedDatastoreID {
+			return nil, status.Errorf(codes.InvalidArgument, "revision was generated by a different datastore instance")
+		}
+
+		// The schema can only be read at revisions which have not yet been garbage collected.
+		if err := ds.CheckRevision(ctx, decoded); err != nil {
+			return nil, shs.rewriteError(ctx, err)
+		}
+		atRevision = decoded
+	} else {
+		headRevision, err := ds.HeadRevision(ctx)
+		if err != nil {
+			return nil, shs.rewriteError(ctx, err)
+		}
+		atRevision = headRevision
+	}
+
+	beforeRevision := datastore.NoRevision
+	if req.OptionalCursor != "" {
+		decoded, err := decodeSchemaHistoryCursor(ctx, req.OptionalCursor, ds)
+		if err != nil {
+			return nil, shs.rewriteError(ctx, err)
+		}
+		beforeRevision = decoded
+	}
+
+	limit := uint64(req.OptionalLimit)
+	if limit == 0 {
+		limit = defaultSchemaHistoryLimit
+	}
+
+	reader := ds.SnapshotReader(atRevision)
+	schemaText, err := schemaTextAtRevision(ctx, reader)
+	if err != nil {
+		return nil, shs.rewriteError(ctx, err)
+	}
+
+	// Read one more entry than the limit, to determine whether there are more to return.
+	found, err := reader.ReadSchemaHistory(ctx, beforeRevision, limit+1)
+	if err != nil {
+		return nil, shs.rewriteError(ctx, err)
+	}
+
+	hasMore := uint64(len(found)) > limit
+	if hasMore {
+		found = found[:limit]
+	}
+
+	entries := make([]*schemahistoryv1.SchemaHistoryEntry, 0, len(found))
+	for _, entry := range found {
+		writtenAt, err := zedtoken.NewFromRevision(ctx, entry.WrittenRevision, ds)
+		if err != nil {
+			return nil, shs.rewriteError(ctx, err)
+		}
+
+		entries = append(entries, &schemahistoryv1.SchemaHistoryEntry{
+			WrittenAt: writtenAt.Token,
+			Entry:     entry.Entry,
+		})
+	}
+
+	var afterResultCursor string
+	if hasMore {
+		encoded, err := cursor.EncodeFromDispatchCursorSections(nil, schemaHistoryCursorHash, found[len(found)-1].WrittenRevision, nil)
+		if err != nil {
+			return nil, shs.rewriteError(ctx, err)
+		}
+		afterResultCursor = encoded.Token
+	}
+
+	readAt, err := zedtoken.NewFromRevision(ctx, atRevision, ds)
+	if err != nil {
+		return nil, shs.rewriteError(ctx, err)
+	}
+
+	return &schemahistoryv1.ReadSchemaHistoryResponse{
+		SchemaText:        schemaText,
+		ReadAt:            readAt.Token,
+		Entries:           entries,
+		AfterResultCursor: afterResultCursor,
+	}, nil
+}
+
+// schemaTextAtRevision generates the schema from the definitions at the revision of the reader,
+// returning an empty string if none are defined.
+func schemaTextAtRevision(ctx context.Context, reader datastore.Reader) (string, error) {
+	nsDefs, err := reader.ListAllNamespaces(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	caveatDefs, err := reader.ListAllCaveats(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if len(nsDefs) == 0 && len(caveatDefs) == 0 {
+		return "", nil
+	}
+
+	schemaDefinitions := make([]compiler.SchemaDefinition, 0, len(nsDefs)+len(caveatDefs))
+	for _, caveatDef := range caveatDefs {
+		schemaDefinitions = append(schemaDefinitions, caveatDef.Definition)
+	}
+
+	for _, nsDef := range nsDefs {
+		schemaDefinitions = append(schemaDefinitions, nsDef.Definition)
+	}
+
+	schemaText, _, err := generator.GenerateSchema(schemaDefinitions)
+	return schemaText, err
+}
+
+// decodeSchemaHistoryCursor decodes the revision of the last entry returned from the cursor.
+func decodeSchemaHistoryCursor(ctx context.Context, token string, ds datastore.Datastore) (datastore.Revision, error) {
+	encoded := &v1.Cursor{Token: token}
+	if _, _, err := cursor.DecodeToDispatchCursor(encoded, schemaHistoryCursorHash); err != nil {
+		return datastore.NoRevision, err
+	}
+
+	revision, tokenStatus, err := cursor.DecodeToDispatchRevision(ctx, encoded, ds)
+	if err != nil {
+		return datastore.NoRevision, cursor.NewInvalidCursorErr(err)
+	}
+
+	if tokenStatus == zedtoken.StatusMismatchedDatastoreID {
+		return datastore.NoRevision, status.Errorf(codes.InvalidArgument, "cursor was generated by a different datastore instance")
+	}
+	return revision, nil
+}
@@ -0,0 +1,89 @@
+package v1_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/authzed/grpcutil"
+
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	tf "github.com/authzed/spicedb/internal/testfixtures"
+	"github.com/authzed/spicedb/internal/testserver"
+	schemahistoryv1 "github.com/authzed/spicedb/pkg/proto/schemahistory/v1"
+)
+
+func TestReadSchemaHistory(t *testing.T) {
+	conn, cleanup, _, _ := testserver.NewTestServer(require.New(t), 0, memdb.DisableGC, true, tf.EmptyDatastore)
+	t.Cleanup(cleanup)
+
+	schemas := []string{
+		"definition user {}",
+		"definition document {\n\trelation viewer: user\n}\n\ndefinition user {}",
+		"definition document {\n\trelation viewer: user\n\trelation editor: user\n}\n\ndefinition user {}",
+	}
+
+	schemaClient := v1.NewSchemaServiceClient(conn)
+	writtenAt := make([]string, 0, len(schemas))
+	for _, schemaText := range schemas {
+		resp, err := schemaClient.WriteSchema(t.Context(), &v1.WriteSchemaRequest{Schema: schemaText})
+		require.NoError(t, err)
+		writtenAt = append(writtenAt, resp.WrittenAt.Token)
+	}
+
+	client := schemahistoryv1.NewSchemaHistoryServiceClient(conn)
+
+	// Read the whole history at the head revision.
+	resp, err := client.ReadSchemaHistory(t.Context(), &schemahistoryv1.ReadSchemaHistoryRequest{})
+	require.NoError(t, err)
+	require.Equal(t, schemas[2], resp.SchemaText)
+	require.NotEmpty(t, resp.ReadAt)
+	require.Empty(t, resp.AfterResultCursor)
+	require.Len(t, resp.Entries, 3)
+	for index, entry := range resp.Entries {
+		require.Equal(t, schemas[len(schemas)-1-index], entry.Entry.SchemaText)
+		require.NotNil(t, entry.Entry.WrittenAt)
+		require.NotEmpty(t, entry.WrittenAt)
+	}
+
+	deltas := resp.Entries[0].Entry.Deltas
+	require.Len(t, deltas, 1)
+	require.Equal(t, "document", deltas[0].DefinitionName)
+	require.Equal(t, "added-relation", deltas[0].DeltaType)
+	require.Equal(t, "editor", deltas[0].Subject)
+	require.Equal(t, "additive", deltas[0].ChangeClass)
+
+	// Read the history as of the second write.
+	resp, err = client.ReadSchemaHistory(t.Context(), &schemahistoryv1.ReadSchemaHistoryRequest{
+		OptionalAt: writtenAt[1],
+	})
+	require.NoError(t, err)
+	require.Equal(t, schemas[1], resp.SchemaText)
+	require.Len(t, resp.Entries, 2)
+	require.Equal(t, schemas[1], resp.Entries[0].Entry.SchemaText)
+	require.Equal(t, schemas[0], resp.Entries[1].Entry.SchemaText)
+
+	// Page through the history, one entry at a time.
+	found := make([]string, 0, len(schemas))
+	req := &schemahistoryv1.ReadSchemaHistoryRequest{OptionalLimit: 1}
+	for {
+		resp, err := client.ReadSchemaHistory(t.Context(), req)
+		require.NoError(t, err)
+		for _, entry := range resp.Entries {
+			found = append(found, entry.Entry.SchemaText)
+		}
+
+		if resp.AfterResultCursor == "" {
+			break
+		}
+		req.OptionalCursor = resp.AfterResultCursor
+	}
+	require.Equal(t, []string{schemas[2], schemas[1], schemas[0]}, found)
+
+	_, err = client.ReadSchemaHistory(t.Context(), &schemahistoryv1.ReadSchemaHistoryRequest{
+		OptionalCursor: "invalid",
+	})
+	grpcutil.RequireStatus(t, codes.InvalidArgument, err)
+}
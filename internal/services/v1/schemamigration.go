@@ -0,0 +1,327 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	grpcvalidate "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+
+	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/internal/middleware"
+	datastoremw "github.com/authzed/spicedb/internal/middleware/datastore"
+	"github.com/authzed/spicedb/internal/middleware/perfinsights"
+	"github.com/authzed/spicedb/internal/middleware/usagemetrics"
+	"github.com/authzed/spicedb/internal/services/shared"
+	caveattypes "github.com/authzed/spicedb/pkg/caveats/types"
+	"github.com/authzed/spicedb/pkg/cursor"
+	"github.com/authzed/spicedb/pkg/datastore"
+	nsdiff "github.com/authzed/spicedb/pkg/diff/namespace"
+	"github.com/authzed/spicedb/pkg/genutil"
+	dispatchv1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	schemamigrationv1 "github.com/authzed/spicedb/pkg/proto/schemamigration/v1"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+	"github.com/authzed/spicedb/pkg/tuple"
+	"github.com/authzed/spicedb/pkg/zedtoken"
+)
+
+// defaultSchemaMigrationBatchSize is the number of relationships changed by a call to
+// ExecuteSchemaMigration, if no batch size is given.
+const defaultSchemaMigrationBatchSize = 1000
+
+// NewSchemaMigrationServer creates a SchemaMigrationServiceServer instance.
+func NewSchemaMigrationServer(config SchemaServerConfig) schemamigrationv1.SchemaMigrationServiceServer {
+	return &schemaMigrationServer{
+		WithServiceSpecificInterceptors: shared.WithServiceSpecificInterceptors{
+			Unary: middleware.ChainUnaryServer(
+				grpcvalidate.UnaryServerInterceptor(),
+				usagemetrics.UnaryServerInterceptor(),
+				perfinsights.UnaryServerInterceptor(config.PerformanceInsightMetricsEnabled),
+			),
+			Stream: middleware.ChainStreamServer(
+				grpcvalidate.StreamServerInterceptor(),
+				usagemetrics.StreamServerInterceptor(),
+				perfinsights.StreamServerInterceptor(config.PerformanceInsightMetricsEnabled),
+			),
+		},
+		additiveOnly:        config.AdditiveOnly,
+		expiringRelsEnabled: config.ExpiringRelsEnabled,
+		caveatTypeSet:       caveattypes.TypeSetOrDefault(config.CaveatTypeSet),
+	}
+}
+
+type schemaMigrationServer struct {
+	schemamigrationv1.UnimplementedSchemaMigrationServiceServer
+	shared.WithServiceSpecificInterceptors
+
+	caveatTypeSet       *caveattypes.TypeSet
+	additiveOnly        bool
+	expiringRelsEnabled bool
+}
+
+func (sms *schemaMigrationServer) rewriteError(ctx context.Context, err error) error {
+	return shared.RewriteError(ctx, err, nil)
+}
+
+// validateTargetSchema compiles and validates the target schema.
+func (sms *schemaMigrationServer) validateTargetSchema(ctx context.Context, schemaText string) (*shared.ValidatedSchemaChanges, error) {
+	opts := make([]compiler.Option, 0, 2)
+	if !sms.expiringRelsEnabled {
+		opts = append(opts, compiler.DisallowExpirationFlag())
+	}
+	opts = append(opts, compiler.CaveatTypeSet(sms.caveatTypeSet))
+
+	compiled, err := compiler.Compile(compiler.InputSchema{
+		Source:       input.Source("schema"),
+		SchemaString: schemaText,
+	}, compiler.AllowUnprefixedObjectType(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return shared.ValidateSchemaChanges(ctx, compiled, sms.caveatTypeSet, sms.additiveOnly)
+}
+
+// planMigration compiles and validates the target schema, and plans the migration to it from
+// the schema at the revision of the reader.
+func (sms *schemaMigrationServer) planMigration(ctx context.Context, reader datastore.Reader, schemaText string, rewrites []*schemamigrationv1.RelationRewrite) (*shared.SchemaMigrationPlan, error) {
+	validated, err := sms.validateTargetSchema(ctx, schemaText)
+	if err != nil {
+		return nil, err
+	}
+
+	relationRewrites := make([]shared.RelationRewrite, 0, len(rewrites))
+	for _, rewrite := range rewrites {
+		relationRewrites = append(relationRewrites, shared.RelationRewrite{
+			DefinitionName: rewrite.DefinitionName,
+			FromRelation:   rewrite.FromRelation,
+			ToRelation:     rewrite.ToRelation,
+		})
+	}
+
+	return shared.PlanSchemaMigration(ctx, reader, sms.caveatTypeSet, validated, relationRewrites)
+}
+
+func (sms *schemaMigrationServer) PlanSchemaMigration(ctx context.Context, req *schemamigrationv1.PlanSchemaMigrationRequest) (*schemamigrationv1.PlanSchemaMigrationResponse, error) {
+	perfinsights.SetInContext(ctx, perfinsights.NoLabels)
+
+	// Migrations are always planned against the head revision, as is the schema written.
+	ds := datastoremw.MustFromContext(ctx)
+	headRevision, err := ds.HeadRevision(ctx)
+	if err != nil {
+		return nil, sms.rewriteError(ctx, err)
+	}
+
+	reader := ds.SnapshotReader(headRevision)
+	plan, err := sms.planMigration(ctx, reader, req.Schema, req.RelationRewrites)
+	if err != nil {
+		return nil, sms.rewriteError(ctx, err)
+	}
+
+	steps := make([]*schemamigrationv1.SchemaMigrationStep, 0, len(plan.Steps))
+	for _, step := range plan.Steps {
+		var count uint64
+		if req.CountAffectedRelationships {
+			count, err = shared.CountSchemaMigrationStep(ctx, reader, step)
+			if err != nil {
+				return nil, sms.rewriteError(ctx, err)
+			}
+		}
+
+		steps = append(steps, &schemamigrationv1.SchemaMigrationStep{
+			Kind:                      schemaMigrationStepKinds[step.Kind],
+			Description:               step.Description,
+			AffectedRelationshipCount: count,
+		})
+	}
+
+	plannedAt, err := zedtoken.NewFromRevision(ctx, headRevision, ds)
+	if err != nil {
+		return nil, sms.rewriteError(ctx, err)
+	}
+
+	return &schemamigrationv1.PlanSchemaMigrationResponse{
+		Steps:           steps,
+		BlockingChanges: plan.BlockingChanges,
+		PlannedAt:       plannedAt.Token,
+	}, nil
+}
+
+func (sms *schemaMigrationServer) ExecuteSchemaMigration(ctx context.Context, req *schemamigrationv1.ExecuteSchemaMigrationRequest) (*schemamigrationv1.ExecuteSchemaMigrationResponse, error) {
+	perfinsights.SetInContext(ctx, perfinsights.NoLabels)
+
+	batchSize := uint64(req.BatchSize)
+	if batchSize == 0 {
+		batchSize = defaultSchemaMigrationBatchSize
+	}
+
+	ds := datastoremw.MustFromContext(ctx)
+
+	var result *shared.SchemaMigrationBatchResult
+	var planHash string
+	revision, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		// The plan is recomputed within the transaction, so that it is always executed
+		// against the current schema.
+		plan, err := sms.planMigration(ctx, rwt, req.Schema, req.RelationRewrites)
+		if err != nil {
+			return err
+		}
+		planHash = plan.Hash()
+
+		position, err := decodeSchemaMigrationCursor(req.OptionalCursor, planHash)
+		if err != nil {
+			return err
+		}
+
+		result, err = shared.ExecuteSchemaMigrationBatch(ctx, rwt, plan, position, batchSize)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, sms.rewriteError(ctx, err)
+	}
+
+	log.Ctx(ctx).Debug().
+		Uint64("deleted", result.RelationshipsDeleted).
+		Uint64("rewritten", result.RelationshipsRewritten).
+		Bool("completed", result.Completed).
+		Msg("executed schema migration batch")
+
+	var afterResultCursor string
+	if !result.Completed {
+		afterResultCursor, err = encodeSchemaMigrationCursor(result.Next, planHash, revision)
+		if err != nil {
+			return nil, sms.rewriteError(ctx, err)
+		}
+	}
+
+	writtenAt, err := zedtoken.NewFromRevision(ctx, revision, ds)
+	if err != nil {
+		return nil, sms.rewriteError(ctx, err)
+	}
+
+	return &schemamigrationv1.ExecuteSchemaMigrationResponse{
+		RelationshipsDeleted:   result.RelationshipsDeleted,
+		RelationshipsRewritten: result.RelationshipsRewritten,
+		Completed:              result.Completed,
+		AfterResultCursor:      afterResultCursor,
+		WrittenAt:              writtenAt.Token,
+	}, nil
+}
+
+func (sms *schemaMigrationServer) WriteSchemaWithRelationRenames(ctx context.Context, req *schemamigrationv1.WriteSchemaWithRelationRenamesRequest) (*schemamigrationv1.WriteSchemaWithRelationRenamesResponse, error) {
+	perfinsights.SetInContext(ctx, perfinsights.NoLabels)
+
+	renames := make(map[string]nsdiff.RelationRenames)
+	for _, rename := range req.RelationRenames {
+		if renames[rename.DefinitionName] == nil {
+			renames[rename.DefinitionName] = make(nsdiff.RelationRenames)
+		}
+		if _, ok := renames[rename.DefinitionName][rename.PreviousName]; ok {
+			return nil, status.Errorf(codes.InvalidArgument, "relation `%s#%s` is renamed more than once", rename.DefinitionName, rename.PreviousName)
+		}
+		renames[rename.DefinitionName][rename.PreviousName] = rename.NewName
+	}
+
+	validated, err := sms.validateTargetSchema(ctx, req.Schema)
+	if err != nil {
+		return nil, sms.rewriteError(ctx, err)
+	}
+	validated = validated.WithRelationRenames(renames)
+
+	ds := datastoremw.MustFromContext(ctx)
+	revision, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		applied, err := shared.ApplySchemaChanges(ctx, rwt, sms.caveatTypeSet, validated.WithSchemaText(req.Schema))
+		if err != nil {
+			return err
+		}
+
+		dispatchCount, err := genutil.EnsureUInt32(applied.TotalOperationCount)
+		if err != nil {
+			return err
+		}
+
+		usagemetrics.SetInContext(ctx, &dispatchv1.ResponseMeta{
+			DispatchCount: dispatchCount,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, sms.rewriteError(ctx, err)
+	}
+
+	writtenAt, err := zedtoken.NewFromRevision(ctx, revision, ds)
+	if err != nil {
+		return nil, sms.rewriteError(ctx, err)
+	}
+
+	return &schemamigrationv1.WriteSchemaWithRelationRenamesResponse{
+		WrittenAt: writtenAt.Token,
+	}, nil
+}
+
+var schemaMigrationStepKinds = map[shared.SchemaMigrationStepKind]schemamigrationv1.SchemaMigrationStep_Kind{
+	shared.DeleteRelationshipsStep:     schemamigrationv1.SchemaMigrationStep_DELETE_RELATIONSHIPS,
+	shared.RewriteResourceRelationStep: schemamigrationv1.SchemaMigrationStep_REWRITE_RESOURCE_RELATION,
+	shared.RewriteSubjectRelationStep:  schemamigrationv1.SchemaMigrationStep_REWRITE_SUBJECT_RELATION,
+	shared.RemoveCaveatParameterStep:   schemamigrationv1.SchemaMigrationStep_REMOVE_CAVEAT_PARAMETER,
+}
+
+// encodeSchemaMigrationCursor encodes the position of the execution of the plan with the
+// hash into a cursor, whose sections are the index of the step and the last relationship
+// processed by it, if any.
+func encodeSchemaMigrationCursor(position shared.SchemaMigrationPosition, planHash string, revision datastore.Revision) (string, error) {
+	after := ""
+	if position.After != nil {
+		encoded, err := tuple.String(*position.After)
+		if err != nil {
+			return "", err
+		}
+		after = encoded
+	}
+
+	encoded, err := cursor.EncodeFromDispatchCursorSections([]string{strconv.Itoa(position.StepIndex), after}, planHash, revision, nil)
+	if err != nil {
+		return "", err
+	}
+	return encoded.Token, nil
+}
+
+// decodeSchemaMigrationCursor decodes the position of the execution of the plan with the
+// hash from the cursor, if any.
+func decodeSchemaMigrationCursor(token string, planHash string) (shared.SchemaMigrationPosition, error) {
+	if token == "" {
+		return shared.SchemaMigrationPosition{}, nil
+	}
+
+	decoded, _, err := cursor.DecodeToDispatchCursor(&v1.Cursor{Token: token}, planHash)
+	if err != nil {
+		return shared.SchemaMigrationPosition{}, err
+	}
+
+	if len(decoded.Sections) != 2 {
+		return shared.SchemaMigrationPosition{}, cursor.NewInvalidCursorErr(errors.New("expected two sections in schema migration cursor"))
+	}
+
+	stepIndex, err := strconv.Atoi(decoded.Sections[0])
+	if err != nil {
+		return shared.SchemaMigrationPosition{}, cursor.NewInvalidCursorErr(err)
+	}
+
+	position := shared.SchemaMigrationPosition{StepIndex: stepIndex}
+	if decoded.Sections[1] != "" {
+		after, err := tuple.Parse(decoded.Sections[1])
+		if err != nil {
+			return shared.SchemaMigrationPosition{}, cursor.NewInvalidCursorErr(err)
+		}
+		position.After = &after
+	}
+	return position, nil
+}
@@ -0,0 +1,597 @@
+package v1_test
+
+import (
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	"github.com/authzed/grpcutil"
+
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	tf "github.com/authzed/spicedb/internal/testfixtures"
+	"github.com/authzed/spicedb/internal/testserver"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	schemamigrationv1 "github.com/authzed/spicedb/pkg/proto/schemamigration/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+func writeSchemaAndRelationshipsForMigration(t *testing.T, conn *grpc.ClientConn, schemaText string, rels ...tuple.Relationship) {
+	t.Helper()
+
+	_, err := v1.NewSchemaServiceClient(conn).WriteSchema(t.Context(), &v1.WriteSchemaRequest{Schema: schemaText})
+	require.NoError(t, err)
+
+	updates := make([]*v1.RelationshipUpdate, 0, len(rels))
+	for _, rel := range rels {
+		updates = append(updates, tuple.MustUpdateToV1RelationshipUpdate(tuple.Create(rel)))
+	}
+
+	_, err = v1.NewPermissionsServiceClient(conn).WriteRelationships(t.Context(), &v1.WriteRelationshipsRequest{Updates: updates})
+	require.NoError(t, err)
+}
+
+// executeMigration executes the migration to the schema in batches of the size until completed,
+// returning the number of calls made.
+func executeMigration(t *testing.T, client schemamigrationv1.SchemaMigrationServiceClient, req *schemamigrationv1.ExecuteSchemaMigrationRequest) (calls int, deleted uint64, rewritten uint64) {
+	t.Helper()
+
+	for {
+		resp, err := client.ExecuteSchemaMigration(t.Context(), req)
+		require.NoError(t, err)
+		require.NotEmpty(t, resp.WrittenAt)
+
+		calls++
+		deleted += resp.RelationshipsDeleted
+		rewritten += resp.RelationshipsRewritten
+		if resp.Completed {
+			require.Empty(t, resp.AfterResultCursor)
+			return calls, deleted, rewritten
+		}
+
+		require.NotEmpty(t, resp.AfterResultCursor)
+		req.OptionalCursor = resp.AfterResultCursor
+	}
+}
+
+func readAllRelationships(t *testing.T, conn *grpc.ClientConn, resourceType string) []string {
+	t.Helper()
+
+	stream, err := v1.NewPermissionsServiceClient(conn).ReadRelationships(t.Context(), &v1.ReadRelationshipsRequest{
+		Consistency:        &v1.Consistency{Requirement: &v1.Consistency_FullyConsistent{FullyConsistent: true}},
+		RelationshipFilter: &v1.RelationshipFilter{ResourceType: resourceType},
+	})
+	require.NoError(t, err)
+
+	var found []string
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		found = append(found, tuple.MustV1RelString(resp.Relationship))
+	}
+	slices.Sort(found)
+	return found
+}
+
+func TestSchemaMigrationDeletesRelationships(t *testing.T) {
+	conn, cleanup, _, _ := testserver.NewTestServer(require.New(t), 0, memdb.DisableGC, true, tf.EmptyDatastore)
+	t.Cleanup(cleanup)
+
+	writeSchemaAndRelationshipsForMigration(t, conn, `definition user {}
+
+		definition team {
+			relation member: user
+		}
+
+		definition document {
+			relation viewer: user | user:* | team#member
+			relation editor: user
+		}`,
+		tuple.MustParse("document:first#viewer@user:tom"),
+		tuple.MustParse("document:first#viewer@user:*"),
+		tuple.MustParse("document:first#editor@user:tom"),
+		tuple.MustParse("document:second#editor@user:sarah"),
+		tuple.MustParse("document:third#editor@user:fred"),
+		tuple.MustParse("document:first#viewer@team:engineering#member"),
+		tuple.MustParse("team:engineering#member@user:sarah"),
+	)
+
+	// The target schema removes `editor`, the wildcard and the team.
+	targetSchema := `definition user {}
+
+		definition document {
+			relation viewer: user
+		}`
+
+	client := schemamigrationv1.NewSchemaMigrationServiceClient(conn)
+	plan, err := client.PlanSchemaMigration(t.Context(), &schemamigrationv1.PlanSchemaMigrationRequest{
+		Schema:                     targetSchema,
+		CountAffectedRelationships: true,
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, plan.PlannedAt)
+	require.Empty(t, plan.BlockingChanges)
+
+	counts := make(map[string]uint64, len(plan.Steps))
+	for _, step := range plan.Steps {
+		require.Equal(t, schemamigrationv1.SchemaMigrationStep_DELETE_RELATIONSHIPS, step.Kind)
+		counts[step.Description] = step.AffectedRelationshipCount
+	}
+	require.Equal(t, map[string]uint64{
+		"delete relationships of object definition `team`":                                               1,
+		"delete relationships of relation `document#editor`":                                             3,
+		"delete relationships with subjects of relation `document#editor`":                               0,
+		"delete relationships of relation `document#viewer` with subjects of allowed type `user:*`":      1,
+		"delete relationships of relation `document#viewer` with subjects of allowed type `team#member`": 1,
+	}, counts)
+
+	// Planning changes no relationships, so the schema cannot yet be written.
+	schemaClient := v1.NewSchemaServiceClient(conn)
+	_, err = schemaClient.WriteSchema(t.Context(), &v1.WriteSchemaRequest{Schema: targetSchema})
+	grpcutil.RequireStatus(t, codes.InvalidArgument, err)
+
+	calls, deleted, rewritten := executeMigration(t, client, &schemamigrationv1.ExecuteSchemaMigrationRequest{
+		Schema:    targetSchema,
+		BatchSize: 2,
+	})
+	// The last batch is full, so its completion is only found by the call after it.
+	require.Equal(t, 4, calls)
+	require.Equal(t, uint64(6), deleted)
+	require.Zero(t, rewritten)
+
+	require.Equal(t, []string{"document:first#viewer@user:tom"}, readAllRelationships(t, conn, "document"))
+
+	_, err = schemaClient.WriteSchema(t.Context(), &v1.WriteSchemaRequest{Schema: targetSchema})
+	require.NoError(t, err)
+
+	// Once written, the schema requires no further migration.
+	plan, err = client.PlanSchemaMigration(t.Context(), &schemamigrationv1.PlanSchemaMigrationRequest{Schema: targetSchema})
+	require.NoError(t, err)
+	require.Empty(t, plan.Steps)
+}
+
+func TestSchemaMigrationRewritesRelation(t *testing.T) {
+	conn, cleanup, _, _ := testserver.NewTestServer(require.New(t), 0, memdb.DisableGC, true, tf.EmptyDatastore)
+	t.Cleanup(cleanup)
+
+	writeSchemaAndRelationshipsForMigration(t, conn, `definition user {}
+
+		definition group {
+			relation participant: user
+			relation member: user
+		}
+
+		definition document {
+			relation viewer: user | group#participant | group#member
+		}`,
+		tuple.MustParse("group:eng#participant@user:tom"),
+		tuple.MustParse("group:eng#participant@user:sarah"),
+		tuple.MustParse("document:first#viewer@group:eng#participant"),
+	)
+
+	// The target schema renames `participant` to `member`.
+	targetSchema := `definition user {}
+
+		definition group {
+			relation member: user
+		}
+
+		definition document {
+			relation viewer: user | group#member
+		}`
+	rewrites := []*schemamigrationv1.RelationRewrite{
+		{DefinitionName: "group", FromRelation: "participant", ToRelation: "member"},
+	}
+
+	client := schemamigrationv1.NewSchemaMigrationServiceClient(conn)
+	plan, err := client.PlanSchemaMigration(t.Context(), &schemamigrationv1.PlanSchemaMigrationRequest{
+		Schema:                     targetSchema,
+		RelationRewrites:           rewrites,
+		CountAffectedRelationships: true,
+	})
+	require.NoError(t, err)
+
+	kinds := make([]schemamigrationv1.SchemaMigrationStep_Kind, 0, len(plan.Steps))
+	for _, step := range plan.Steps {
+		kinds = append(kinds, step.Kind)
+	}
+	require.Equal(t, []schemamigrationv1.SchemaMigrationStep_Kind{
+		schemamigrationv1.SchemaMigrationStep_REWRITE_RESOURCE_RELATION,
+		schemamigrationv1.SchemaMigrationStep_REWRITE_SUBJECT_RELATION,
+	}, kinds)
+	require.Equal(t, uint64(2), plan.Steps[0].AffectedRelationshipCount)
+	require.Equal(t, uint64(1), plan.Steps[1].AffectedRelationshipCount)
+
+	_, deleted, rewritten := executeMigration(t, client, &schemamigrationv1.ExecuteSchemaMigrationRequest{
+		Schema:           targetSchema,
+		RelationRewrites: rewrites,
+		BatchSize:        1,
+	})
+	require.Zero(t, deleted)
+	require.Equal(t, uint64(3), rewritten)
+
+	require.Equal(t, []string{
+		"group:eng#member@user:sarah",
+		"group:eng#member@user:tom",
+	}, readAllRelationships(t, conn, "group"))
+	require.Equal(t, []string{
+		"document:first#viewer@group:eng#member",
+	}, readAllRelationships(t, conn, "document"))
+
+	_, err = v1.NewSchemaServiceClient(conn).WriteSchema(t.Context(), &v1.WriteSchemaRequest{Schema: targetSchema})
+	require.NoError(t, err)
+}
+
+func TestSchemaMigrationRemovesCaveatParameter(t *testing.T) {
+	conn, cleanup, _, _ := testserver.NewTestServer(require.New(t), 0, memdb.DisableGC, true, tf.EmptyDatastore)
+	t.Cleanup(cleanup)
+
+	withCaveat := func(relString string, caveatContext map[string]any) tuple.Relationship {
+		caveatCtx, err := structpb.NewStruct(caveatContext)
+		require.NoError(t, err)
+
+		rel := tuple.MustParse(relString)
+		rel.OptionalCaveat = &core.ContextualizedCaveat{CaveatName: "somecaveat", Context: caveatCtx}
+		return rel
+	}
+
+	writeSchemaAndRelationshipsForMigration(t, conn, `definition user {}
+
+		caveat somecaveat(a int, b int) {
+			a == 42 || b == 1
+		}
+
+		definition document {
+			relation viewer: user with somecaveat
+		}`,
+		withCaveat("document:first#viewer@user:tom", map[string]any{"a": 42, "b": 1}),
+		withCaveat("document:second#viewer@user:tom", map[string]any{"a": 42}),
+		withCaveat("document:third#viewer@user:tom", map[string]any{"b": 2}),
+	)
+
+	targetSchema := `definition user {}
+
+		caveat somecaveat(a int) {
+			a == 42
+		}
+
+		definition document {
+			relation viewer: user with somecaveat
+		}`
+
+	client := schemamigrationv1.NewSchemaMigrationServiceClient(conn)
+	plan, err := client.PlanSchemaMigration(t.Context(), &schemamigrationv1.PlanSchemaMigrationRequest{
+		Schema:                     targetSchema,
+		CountAffectedRelationships: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Steps, 1)
+	require.Equal(t, schemamigrationv1.SchemaMigrationStep_REMOVE_CAVEAT_PARAMETER, plan.Steps[0].Kind)
+	require.Equal(t, uint64(2), plan.Steps[0].AffectedRelationshipCount)
+
+	// Relationships without the parameter are left unchanged, so the batches progress past
+	// them rather than revisiting them.
+	calls, _, rewritten := executeMigration(t, client, &schemamigrationv1.ExecuteSchemaMigrationRequest{
+		Schema:    targetSchema,
+		BatchSize: 1,
+	})
+	require.Equal(t, 4, calls)
+	require.Equal(t, uint64(2), rewritten)
+
+	require.Equal(t, []string{
+		"document:first#viewer@user:tom[somecaveat:{\"a\":42}]",
+		"document:second#viewer@user:tom[somecaveat:{\"a\":42}]",
+		"document:third#viewer@user:tom[somecaveat]",
+	}, readAllRelationships(t, conn, "document"))
+}
+
+func TestSchemaMigrationBlockingChanges(t *testing.T) {
+	conn, cleanup, _, _ := testserver.NewTestServer(require.New(t), 0, memdb.DisableGC, true, tf.EmptyDatastore)
+	t.Cleanup(cleanup)
+
+	writeSchemaAndRelationshipsForMigration(t, conn, `definition user {}
+
+		caveat somecaveat(a int) {
+			a == 42
+		}
+
+		definition document {
+			relation viewer: user with somecaveat
+		}`)
+
+	client := schemamigrationv1.NewSchemaMigrationServiceClient(conn)
+	plan, err := client.PlanSchemaMigration(t.Context(), &schemamigrationv1.PlanSchemaMigrationRequest{
+		Schema: `definition user {}
+
+		caveat somecaveat(a string) {
+			a == "42"
+		}
+
+		definition document {
+			relation viewer: user with somecaveat
+		}`,
+	})
+	require.NoError(t, err)
+	require.Empty(t, plan.Steps)
+	require.Equal(t, []string{"the type of parameter `a` on caveat `somecaveat` is changed"}, plan.BlockingChanges)
+}
+
+func TestSchemaMigrationInvalidRequests(t *testing.T) {
+	conn, cleanup, _, _ := testserver.NewTestServer(require.New(t), 0, memdb.DisableGC, true, tf.EmptyDatastore)
+	t.Cleanup(cleanup)
+
+	writeSchemaAndRelationshipsForMigration(t, conn, `definition user {}
+
+		definition document {
+			relation reader: user
+			relation viewer: user
+			permission view = viewer
+		}`,
+		tuple.MustParse("document:first#reader@user:tom"),
+		tuple.MustParse("document:second#reader@user:tom"),
+	)
+
+	targetSchema := `definition user {}
+
+		definition document {
+			relation viewer: user
+			permission view = viewer
+		}`
+	client := schemamigrationv1.NewSchemaMigrationServiceClient(conn)
+
+	t.Run("rewrite to permission", func(t *testing.T) {
+		_, err := client.PlanSchemaMigration(t.Context(), &schemamigrationv1.PlanSchemaMigrationRequest{
+			Schema: targetSchema,
+			RelationRewrites: []*schemamigrationv1.RelationRewrite{
+				{DefinitionName: "document", FromRelation: "reader", ToRelation: "view"},
+			},
+		})
+		grpcutil.RequireStatus(t, codes.InvalidArgument, err)
+	})
+
+	t.Run("rewrite of relation not removed", func(t *testing.T) {
+		_, err := client.PlanSchemaMigration(t.Context(), &schemamigrationv1.PlanSchemaMigrationRequest{
+			Schema: targetSchema,
+			RelationRewrites: []*schemamigrationv1.RelationRewrite{
+				{DefinitionName: "document", FromRelation: "viewer", ToRelation: "viewer"},
+			},
+		})
+		grpcutil.RequireStatus(t, codes.InvalidArgument, err)
+	})
+
+	t.Run("cursor of another plan", func(t *testing.T) {
+		resp, err := client.ExecuteSchemaMigration(t.Context(), &schemamigrationv1.ExecuteSchemaMigrationRequest{
+			Schema:    targetSchema,
+			BatchSize: 1,
+		})
+		require.NoError(t, err)
+		require.False(t, resp.Completed)
+
+		_, err = client.ExecuteSchemaMigration(t.Context(), &schemamigrationv1.ExecuteSchemaMigrationRequest{
+			Schema: targetSchema,
+			RelationRewrites: []*schemamigrationv1.RelationRewrite{
+				{DefinitionName: "document", FromRelation: "reader", ToRelation: "viewer"},
+			},
+			OptionalCursor: resp.AfterResultCursor,
+		})
+		grpcutil.RequireStatus(t, codes.InvalidArgument, err)
+	})
+}
+
+func TestSchemaMigrationRejectsIncompatibleRewrites(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		existingSchema string
+		targetSchema   string
+		rewrite        *schemamigrationv1.RelationRewrite
+	}{
+		{
+			name: "subject type not allowed",
+			existingSchema: `definition user {}
+				definition team {}
+
+				definition document {
+					relation reader: user | team
+					relation viewer: user
+				}`,
+			targetSchema: `definition user {}
+				definition team {}
+
+				definition document {
+					relation viewer: user
+				}`,
+			rewrite: &schemamigrationv1.RelationRewrite{DefinitionName: "document", FromRelation: "reader", ToRelation: "viewer"},
+		},
+		{
+			name: "wildcard not allowed",
+			existingSchema: `definition user {}
+
+				definition document {
+					relation reader: user | user:*
+					relation viewer: user
+				}`,
+			targetSchema: `definition user {}
+
+				definition document {
+					relation viewer: user
+				}`,
+			rewrite: &schemamigrationv1.RelationRewrite{DefinitionName: "document", FromRelation: "reader", ToRelation: "viewer"},
+		},
+		{
+			name: "caveat not allowed",
+			existingSchema: `definition user {}
+
+				caveat only_on_tuesday(day string) {
+					day == "tuesday"
+				}
+
+				definition document {
+					relation reader: user with only_on_tuesday
+					relation viewer: user
+				}`,
+			targetSchema: `definition user {}
+
+				caveat only_on_tuesday(day string) {
+					day == "tuesday"
+				}
+
+				definition document {
+					relation viewer: user
+				}`,
+			rewrite: &schemamigrationv1.RelationRewrite{DefinitionName: "document", FromRelation: "reader", ToRelation: "viewer"},
+		},
+		{
+			name: "expiration not allowed",
+			existingSchema: `use expiration
+
+				definition user {}
+
+				definition document {
+					relation reader: user with expiration
+					relation viewer: user
+				}`,
+			targetSchema: `use expiration
+
+				definition user {}
+
+				definition document {
+					relation viewer: user
+				}`,
+			rewrite: &schemamigrationv1.RelationRewrite{DefinitionName: "document", FromRelation: "reader", ToRelation: "viewer"},
+		},
+		{
+			name: "rewritten subject relation not allowed",
+			existingSchema: `definition user {}
+
+				definition group {
+					relation participant: user
+					relation member: user
+				}
+
+				definition document {
+					relation viewer: user | group#participant
+				}`,
+			targetSchema: `definition user {}
+
+				definition group {
+					relation member: user
+				}
+
+				definition document {
+					relation viewer: user
+				}`,
+			rewrite: &schemamigrationv1.RelationRewrite{DefinitionName: "group", FromRelation: "participant", ToRelation: "member"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			conn, cleanup, _, _ := testserver.NewTestServer(require.New(t), 0, memdb.DisableGC, true, tf.EmptyDatastore)
+			t.Cleanup(cleanup)
+
+			writeSchemaAndRelationshipsForMigration(t, conn, tc.existingSchema)
+
+			_, err := schemamigrationv1.NewSchemaMigrationServiceClient(conn).PlanSchemaMigration(t.Context(), &schemamigrationv1.PlanSchemaMigrationRequest{
+				Schema:           tc.targetSchema,
+				RelationRewrites: []*schemamigrationv1.RelationRewrite{tc.rewrite},
+			})
+			grpcutil.RequireStatus(t, codes.InvalidArgument, err)
+		})
+	}
+}
+
+func TestSchemaMigrationWriteSchemaWithRelationRenames(t *testing.T) {
+	conn, cleanup, _, _ := testserver.NewTestServer(require.New(t), 0, memdb.DisableGC, true, tf.EmptyDatastore)
+	t.Cleanup(cleanup)
+	client := schemamigrationv1.NewSchemaMigrationServiceClient(conn)
+
+	writeSchemaAndRelationshipsForMigration(t, conn, `definition user {}
+
+		definition group {
+			relation participant: user | group#participant
+		}
+
+		definition document {
+			relation reader: user | group#participant
+			permission view = reader
+		}`,
+		tuple.MustParse("group:eng#participant@user:tom"),
+		tuple.MustParse("group:all#participant@group:eng#participant"),
+		tuple.MustParse("document:firstdoc#reader@user:sarah"),
+		tuple.MustParse("document:firstdoc#reader@group:all#participant"),
+	)
+
+	renamedSchema := `definition user {}
+
+		definition group {
+			relation member: user | group#member
+		}
+
+		definition document {
+			relation viewer: user | group#member
+			permission view = viewer
+		}`
+
+	// Without the renames, the relations cannot be removed, as relationships exist for them.
+	_, err := v1.NewSchemaServiceClient(conn).WriteSchema(t.Context(), &v1.WriteSchemaRequest{Schema: renamedSchema})
+	grpcutil.RequireStatus(t, codes.InvalidArgument, err)
+
+	// An invalid rename is rejected.
+	_, err = client.WriteSchemaWithRelationRenames(t.Context(), &schemamigrationv1.WriteSchemaWithRelationRenamesRequest{
+		Schema: renamedSchema,
+		RelationRenames: []*schemamigrationv1.RelationRename{
+			{DefinitionName: "group", PreviousName: "participant", NewName: "unknown"},
+		},
+	})
+	grpcutil.RequireStatus(t, codes.InvalidArgument, err)
+	require.ErrorContains(t, err, "`unknown` is not a relation in the updated definition")
+
+	_, err = client.WriteSchemaWithRelationRenames(t.Context(), &schemamigrationv1.WriteSchemaWithRelationRenamesRequest{
+		Schema: renamedSchema,
+		RelationRenames: []*schemamigrationv1.RelationRename{
+			{DefinitionName: "group", PreviousName: "participant", NewName: "member"},
+			{DefinitionName: "group", PreviousName: "participant", NewName: "other"},
+		},
+	})
+	grpcutil.RequireStatus(t, codes.InvalidArgument, err)
+
+	_, err = client.WriteSchemaWithRelationRenames(t.Context(), &schemamigrationv1.WriteSchemaWithRelationRenamesRequest{
+		Schema: renamedSchema,
+	})
+	grpcutil.RequireStatus(t, codes.InvalidArgument, err)
+
+	// With the renames, the relationships are rewritten along with the schema.
+	resp, err := client.WriteSchemaWithRelationRenames(t.Context(), &schemamigrationv1.WriteSchemaWithRelationRenamesRequest{
+		Schema: renamedSchema,
+		RelationRenames: []*schemamigrationv1.RelationRename{
+			{DefinitionName: "group", PreviousName: "participant", NewName: "member"},
+			{DefinitionName: "document", PreviousName: "reader", NewName: "viewer"},
+		},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.WrittenAt)
+
+	require.Equal(t, []string{
+		"group:all#member@group:eng#member",
+		"group:eng#member@user:tom",
+	}, readAllRelationships(t, conn, "group"))
+	require.Equal(t, []string{
+		"document:firstdoc#viewer@group:all#member",
+		"document:firstdoc#viewer@user:sarah",
+	}, readAllRelationships(t, conn, "document"))
+
+	checkResp, err := v1.NewPermissionsServiceClient(conn).CheckPermission(t.Context(), &v1.CheckPermissionRequest{
+		Consistency: &v1.Consistency{Requirement: &v1.Consistency_FullyConsistent{FullyConsistent: true}},
+		Resource:    &v1.ObjectReference{ObjectType: "document", ObjectId: "firstdoc"},
+		Permission:  "view",
+		Subject:     &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "user", ObjectId: "tom"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, v1.CheckPermissionResponse_PERMISSIONSHIP_HAS_PERMISSION, checkResp.Permissionship)
+}
@@ -97,6 +97,10 @@ func (vsr validatingSnapshotReader) LookupCounters(ctx context.Context) ([]datas
 	return vsr.delegate.LookupCounters(ctx)
 }
 
+func (vsr validatingSnapshotReader) ReadSchemaHistory(ctx context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	return vsr.delegate.ReadSchemaHistory(ctx, beforeRevision, limit)
+}
+
 func (vsr validatingSnapshotReader) QueryRelationships(ctx context.Context,
 	filter datastore.RelationshipsFilter,
 	opts ...options.QueryOptionsOption,
@@ -206,6 +210,13 @@ func (vrwt validatingReadWriteTransaction) StoreCounterValue(ctx context.Context
 	return vrwt.delegate.StoreCounterValue(ctx, name, value, computedAtRevision)
 }
 
+func (vrwt validatingReadWriteTransaction) WriteSchemaHistoryEntry(ctx context.Context, entry *core.SchemaHistoryEntry) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+	return vrwt.delegate.WriteSchemaHistoryEntry(ctx, entry)
+}
+
 func (vrwt validatingReadWriteTransaction) WriteNamespaces(ctx context.Context, newConfigs ...*core.NamespaceDefinition) error {
 	for _, newConfig := range newConfigs {
 		if err := newConfig.Validate(); err != nil {
@@ -21,6 +21,7 @@ import (
 	"github.com/authzed/consistent"
 
 	combineddispatch "github.com/authzed/spicedb/internal/dispatch/combined"
+	"github.com/authzed/spicedb/internal/dispatch/remote"
 	"github.com/authzed/spicedb/pkg/cmd/server"
 	"github.com/authzed/spicedb/pkg/cmd/util"
 	"github.com/authzed/spicedb/pkg/datastore"
@@ -60,7 +61,7 @@ var testResolverBuilder = &SafeManualResolverBuilder{}
 
 func init() {
 	// register hashring balancer
-	balancer.Register(consistent.NewBuilder(xxhash.Sum64))
+	balancer.Register(remote.NewPreviousOwnerBalancerBuilder(consistent.NewBuilder(xxhash.Sum64), xxhash.Sum64))
 
 	// Register a manual resolver.Builder  that we can feed addresses for tests
 	// Registration is not thread safe, so we register a single resolver.Builder
@@ -75,6 +75,9 @@ func RunDatastoreEngineWithBridge(t testing.TB, engine string, bridgeNetworkName
 		return RunMySQLForTesting(t, bridgeNetworkName)
 	case "spanner":
 		return RunSpannerForTesting(t, bridgeNetworkName, migrate.Head)
+	case "sqlite":
+		require.Equal(t, "", bridgeNetworkName, "sqlite datastore does not support bridge networking")
+		return RunSQLiteForTesting(t)
 	default:
 		t.Fatalf("found missing engine for RunDatastoreEngine: %s", engine)
 		return nil
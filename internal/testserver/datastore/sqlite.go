@@ -0,0 +1,38 @@
+package datastore
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/datastore/sqlite/migrations"
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/migrate"
+)
+
+type sqliteTester struct{}
+
+// RunSQLiteForTesting returns a RunningEngineForTest for the SQLite driver.
+// Each database is a new file in a temporary directory owned by the test.
+func RunSQLiteForTesting(_ testing.TB) RunningEngineForTest {
+	return &sqliteTester{}
+}
+
+func (b *sqliteTester) NewDatabase(t testing.TB) string {
+	return filepath.Join(t.TempDir(), "spicedb.db")
+}
+
+func (b *sqliteTester) NewDatastore(t testing.TB, initFunc InitFunc) datastore.Datastore {
+	path := b.NewDatabase(t)
+
+	driver, err := migrations.NewSQLiteDriverFromPath(path)
+	require.NoError(t, err, "failed to create sqlite driver")
+	defer driver.Close(context.Background())
+
+	err = migrations.Manager.Run(context.Background(), driver, migrate.Head, migrate.LiveRun)
+	require.NoError(t, err, "failed to run migrations")
+
+	return initFunc("sqlite", path)
+}
@@ -21,12 +21,14 @@ import (
 
 // ServerConfig is configuration for the test server.
 type ServerConfig struct {
-	MaxUpdatesPerWrite                 uint16
-	MaxPreconditionsCount              uint16
-	MaxRelationshipContextSize         int
-	StreamingAPITimeout                time.Duration
-	CaveatTypeSet                      *caveattypes.TypeSet
-	EnableExperimentalLookupResources3 bool
+	MaxUpdatesPerWrite                   uint16
+	MaxPreconditionsCount                uint16
+	MaxRelationshipContextSize           int
+	StreamingAPITimeout                  time.Duration
+	CaveatTypeSet                        *caveattypes.TypeSet
+	EnableExperimentalLookupResources3   bool
+	EnableRelationshipCounterMaintenance bool
+	EnableExperimentalQueryPlan          bool
 }
 
 var DefaultTestServerConfig = ServerConfig{
@@ -82,6 +84,11 @@ func NewTestServerWithConfigAndDatastore(require *require.Assertions,
 		lrver = "lr3"
 	}
 
+	queryPlan := ""
+	if config.EnableExperimentalQueryPlan {
+		queryPlan = "check"
+	}
+
 	params, err := graph.NewDefaultDispatcherParametersForTesting()
 	require.NoError(err)
 
@@ -100,6 +107,9 @@ func NewTestServerWithConfigAndDatastore(require *require.Assertions,
 		server.WithMaxCaveatContextSize(4096),
 		server.WithMaxRelationshipContextSize(config.MaxRelationshipContextSize),
 		server.WithExperimentalLookupResourcesVersion(lrver),
+		server.WithExperimentalQueryPlan(queryPlan),
+		server.WithEnableExperimentalRelationshipCounterMaintenance(config.EnableRelationshipCounterMaintenance),
+		server.WithRelationshipCounterFlushInterval(10*time.Millisecond),
 		server.WithGRPCServer(util.GRPCServerConfig{
 			Network: util.BufferedNetwork,
 			Enabled: true,
@@ -0,0 +1,276 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	// defaultPersistenceMaxEntries is the default maximum number of entries persisted.
+	defaultPersistenceMaxEntries = 10_000
+
+	// accessSampleRate is the rate at which accesses to a persistent cache are sampled to track
+	// its hot entries: one in every accessSampleRate accesses is recorded.
+	accessSampleRate = 8
+)
+
+// persistenceHeader is written at the start of every persisted cache file, and identifies both
+// the file and the version of its format.
+var persistenceHeader = []byte("spicedb-cache\x01")
+
+// Codec encodes and decodes the entries of a cache, so that they can be persisted.
+type Codec[K KeyString, V any] interface {
+	// Encode returns the encoded form of the entry, or false if the entry cannot be persisted.
+	Encode(key K, value V) ([]byte, bool)
+
+	// Decode returns the entry for its encoded form, along with its cost, or false if the entry
+	// is no longer valid and should not be restored.
+	Decode(ctx context.Context, encoded []byte) (K, V, int64, bool)
+}
+
+// RestoreScopedCodec is a Codec which decodes the entries of each restore with a codec of its
+// own, so that state can be shared between the entries of a single restore, such as whether the
+// revisions at which they were computed are still valid.
+type RestoreScopedCodec[K KeyString, V any] interface {
+	Codec[K, V]
+
+	// ForRestore returns the codec with which to decode the entries of a single restore.
+	ForRestore() Codec[K, V]
+}
+
+// PersistentCache is a Cache which tracks its most frequently accessed entries, so that they can
+// be persisted to a local file and restored from it by a later process, such as after a restart.
+//
+// Hot entries are tracked by sampling accesses into a bounded ring of keys, which works with any
+// underlying cache, including those which cannot be iterated.
+type PersistentCache[K KeyString, V any] struct {
+	Cache[K, V]
+
+	path  string
+	codec Codec[K, V]
+
+	accesses atomic.Uint64
+
+	mu      sync.Mutex
+	hotKeys []K
+	next    int
+}
+
+var _ Cache[StringKey, any] = (*PersistentCache[StringKey, any])(nil)
+
+// NewPersistentCache wraps the cache so that up to maxEntries of its hot entries can be
+// persisted to, and restored from, the file at the given path. If maxEntries is not positive,
+// a default is used.
+func NewPersistentCache[K KeyString, V any](c Cache[K, V], path string, maxEntries int, codec Codec[K, V]) *PersistentCache[K, V] {
+	if maxEntries <= 0 {
+		maxEntries = defaultPersistenceMaxEntries
+	}
+
+	return &PersistentCache[K, V]{
+		Cache:   c,
+		path:    path,
+		codec:   codec,
+		hotKeys: make([]K, 0, maxEntries),
+	}
+}
+
+// IsPersistent returns whether the cache persists its hot entries.
+func IsPersistent[K KeyString, V any](c Cache[K, V]) bool {
+	_, ok := c.(*PersistentCache[K, V])
+	return ok
+}
+
+func (pc *PersistentCache[K, V]) Get(key K) (V, bool) {
+	value, found := pc.Cache.Get(key)
+	if found {
+		pc.recordAccess(key)
+	}
+	return value, found
+}
+
+func (pc *PersistentCache[K, V]) Set(key K, entry V, cost int64) bool {
+	added := pc.Cache.Set(key, entry, cost)
+	if added {
+		pc.recordAccess(key)
+	}
+	return added
+}
+
+func (pc *PersistentCache[K, V]) MarshalZerologObject(e *zerolog.Event) {
+	pc.Cache.MarshalZerologObject(e)
+	e.Str("persistencePath", pc.path)
+}
+
+// recordAccess records a sample of the accesses to the cache in the ring of hot keys, such that
+// the most frequently accessed keys are the most likely to be found within it.
+func (pc *PersistentCache[K, V]) recordAccess(key K) {
+	if pc.accesses.Add(1)%accessSampleRate != 0 {
+		return
+	}
+
+	pc.mu.Lock()
+	defer pc.mu.Unlock()
+
+	if len(pc.hotKeys) < cap(pc.hotKeys) {
+		pc.hotKeys = append(pc.hotKeys, key)
+		return
+	}
+
+	pc.hotKeys[pc.next] = key
+	pc.next = (pc.next + 1) % len(pc.hotKeys)
+}
+
+// Persist writes the hot entries still found in the cache to the file, replacing any previous
+// contents atomically, and returns the number of entries written.
+func (pc *PersistentCache[K, V]) Persist(ctx context.Context) (int, error) {
+	pc.mu.Lock()
+	hotKeys := make([]K, len(pc.hotKeys))
+	copy(hotKeys, pc.hotKeys)
+	pc.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(pc.path), 0o700); err != nil {
+		return 0, fmt.Errorf("unable to create directory for persisted cache: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(pc.path), filepath.Base(pc.path)+".tmp*")
+	if err != nil {
+		return 0, fmt.Errorf("unable to create persisted cache file: %w", err)
+	}
+	defer func() {
+		// Removing the temporary file fails once it has been renamed, which is expected.
+		_ = os.Remove(file.Name())
+	}()
+
+	count, err := pc.writeEntries(ctx, file, hotKeys)
+	if err != nil {
+		_ = file.Close()
+		return 0, err
+	}
+
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return 0, fmt.Errorf("unable to sync persisted cache file: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return 0, fmt.Errorf("unable to close persisted cache file: %w", err)
+	}
+
+	if err := os.Rename(file.Name(), pc.path); err != nil {
+		return 0, fmt.Errorf("unable to replace persisted cache file: %w", err)
+	}
+
+	return count, nil
+}
+
+func (pc *PersistentCache[K, V]) writeEntries(ctx context.Context, w io.Writer, hotKeys []K) (int, error) {
+	bw := bufio.NewWriter(w)
+	if _, err := bw.Write(persistenceHeader); err != nil {
+		return 0, fmt.Errorf("unable to write persisted cache file: %w", err)
+	}
+
+	count := 0
+	written := make(map[K]struct{}, len(hotKeys))
+	lengthBuf := make([]byte, 0, binary.MaxVarintLen64)
+	for _, key := range hotKeys {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+
+		if _, ok := written[key]; ok {
+			continue
+		}
+		written[key] = struct{}{}
+
+		// The underlying cache is read directly, so that persisting does not count as an access.
+		value, found := pc.Cache.Get(key)
+		if !found {
+			continue
+		}
+
+		encoded, ok := pc.codec.Encode(key, value)
+		if !ok {
+			continue
+		}
+
+		lengthBuf = binary.AppendUvarint(lengthBuf[:0], uint64(len(encoded)))
+		if _, err := bw.Write(lengthBuf); err != nil {
+			return 0, fmt.Errorf("unable to write persisted cache file: %w", err)
+		}
+		if _, err := bw.Write(encoded); err != nil {
+			return 0, fmt.Errorf("unable to write persisted cache file: %w", err)
+		}
+		count++
+	}
+
+	if err := bw.Flush(); err != nil {
+		return 0, fmt.Errorf("unable to write persisted cache file: %w", err)
+	}
+
+	return count, nil
+}
+
+// Restore reads the entries from the file, if it exists, adding those which are still valid to
+// the cache, and returns the number of entries restored. The file is removed once read, so that
+// entries are never restored more than once.
+func (pc *PersistentCache[K, V]) Restore(ctx context.Context) (int, error) {
+	contents, err := os.ReadFile(pc.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("unable to read persisted cache file: %w", err)
+	}
+
+	if err := os.Remove(pc.path); err != nil {
+		return 0, fmt.Errorf("unable to remove persisted cache file: %w", err)
+	}
+
+	if !bytes.HasPrefix(contents, persistenceHeader) {
+		return 0, errors.New("persisted cache file has an unknown format")
+	}
+	contents = contents[len(persistenceHeader):]
+
+	codec := pc.codec
+	if scoped, ok := codec.(RestoreScopedCodec[K, V]); ok {
+		codec = scoped.ForRestore()
+	}
+
+	restored := 0
+	for len(contents) > 0 {
+		if err := ctx.Err(); err != nil {
+			return restored, err
+		}
+
+		length, read := binary.Uvarint(contents)
+		if read <= 0 || length > uint64(len(contents)-read) {
+			return restored, errors.New("persisted cache file is truncated")
+		}
+
+		encoded := contents[read : read+int(length)]
+		contents = contents[read+int(length):]
+
+		key, value, cost, ok := codec.Decode(ctx, encoded)
+		if !ok {
+			continue
+		}
+
+		if pc.Cache.Set(key, value, cost) {
+			restored++
+		}
+	}
+
+	pc.Cache.Wait()
+	return restored, nil
+}
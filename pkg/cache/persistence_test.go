@@ -0,0 +1,186 @@
+//go:build !wasm
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// stringCodec persists string values, skipping those with an "invalid" prefix when restoring.
+type stringCodec struct{}
+
+func (stringCodec) Encode(key StringKey, value string) ([]byte, bool) {
+	return []byte(string(key) + "=" + value), true
+}
+
+func (stringCodec) Decode(_ context.Context, encoded []byte) (StringKey, string, int64, bool) {
+	key, value, ok := strings.Cut(string(encoded), "=")
+	if !ok || strings.HasPrefix(value, "invalid") {
+		return "", "", 0, false
+	}
+	return StringKey(key), value, int64(len(value)), true
+}
+
+func TestPersistentCache(t *testing.T) {
+	for _, kind := range []string{"ristretto", "otter", "theine"} {
+		t.Run(kind, func(t *testing.T) {
+			newCache := func() Cache[StringKey, string] {
+				config := &Config{NumCounters: 1000, MaxCost: 1 << 20}
+
+				var (
+					c   Cache[StringKey, string]
+					err error
+				)
+				switch kind {
+				case "ristretto":
+					c, err = NewRistrettoCache[StringKey, string](config)
+				case "otter":
+					c, err = NewOtterCache[StringKey, string](config)
+				case "theine":
+					c, err = NewTheineCache[StringKey, string](config)
+				}
+				require.NoError(t, err)
+				t.Cleanup(c.Close)
+				return c
+			}
+
+			path := filepath.Join(t.TempDir(), "cache")
+
+			persisted := NewPersistentCache(newCache(), path, 100, stringCodec{})
+			require.True(t, IsPersistent[StringKey, string](persisted))
+
+			for i := range 10 {
+				persisted.Set(StringKey(fmt.Sprintf("key%d", i)), fmt.Sprintf("value%d", i), 1)
+			}
+			persisted.Set("stale", "invalid", 1)
+			persisted.Wait()
+
+			// Access the entries enough that all of them are sampled as hot.
+			for range accessSampleRate {
+				for i := range 10 {
+					_, found := persisted.Get(StringKey(fmt.Sprintf("key%d", i)))
+					require.True(t, found)
+				}
+				_, found := persisted.Get("stale")
+				require.True(t, found)
+			}
+
+			written, err := persisted.Persist(t.Context())
+			require.NoError(t, err)
+			require.Equal(t, 11, written)
+
+			restored := NewPersistentCache(newCache(), path, 100, stringCodec{})
+			count, err := restored.Restore(t.Context())
+			require.NoError(t, err)
+			require.Equal(t, 10, count)
+
+			for i := range 10 {
+				value, found := restored.Get(StringKey(fmt.Sprintf("key%d", i)))
+				require.True(t, found)
+				require.Equal(t, fmt.Sprintf("value%d", i), value)
+			}
+
+			// Entries which are no longer valid are not restored.
+			_, found := restored.Get("stale")
+			require.False(t, found)
+
+			// The file is removed once restored.
+			_, err = os.Stat(path)
+			require.ErrorIs(t, err, os.ErrNotExist)
+
+			count, err = restored.Restore(t.Context())
+			require.NoError(t, err)
+			require.Zero(t, count)
+		})
+	}
+}
+
+func TestPersistentCacheBoundsHotEntries(t *testing.T) {
+	c, err := NewOtterCache[StringKey, string](&Config{MaxCost: 1 << 20})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+
+	persisted := NewPersistentCache(c, filepath.Join(t.TempDir(), "cache"), 5, stringCodec{})
+	for i := range 100 * accessSampleRate {
+		persisted.Set(StringKey(fmt.Sprintf("key%d", i)), "value", 1)
+	}
+
+	written, err := persisted.Persist(t.Context())
+	require.NoError(t, err)
+	require.LessOrEqual(t, written, 5)
+	require.Positive(t, written)
+}
+
+func TestPersistentCacheRestoreUnknownFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache")
+	require.NoError(t, os.WriteFile(path, []byte("not a cache"), 0o600))
+
+	persisted := NewPersistentCache(NoopCache[StringKey, string](), path, 0, stringCodec{})
+	_, err := persisted.Restore(t.Context())
+	require.ErrorContains(t, err, "unknown format")
+}
+
+// restoreScopedCodec counts the entries decoded by the codec of each restore.
+type restoreScopedCodec struct {
+	stringCodec
+
+	decodedPerRestore []*int
+}
+
+type countingCodec struct {
+	stringCodec
+
+	decoded *int
+}
+
+func (cc countingCodec) Decode(ctx context.Context, encoded []byte) (StringKey, string, int64, bool) {
+	*cc.decoded++
+	return cc.stringCodec.Decode(ctx, encoded)
+}
+
+func (rsc *restoreScopedCodec) ForRestore() Codec[StringKey, string] {
+	decoded := new(int)
+	rsc.decodedPerRestore = append(rsc.decodedPerRestore, decoded)
+	return countingCodec{decoded: decoded}
+}
+
+func TestPersistentCacheRestoreScopedCodec(t *testing.T) {
+	c, err := NewOtterCache[StringKey, string](&Config{MaxCost: 1 << 20})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+
+	path := filepath.Join(t.TempDir(), "cache")
+	codec := &restoreScopedCodec{}
+	persisted := NewPersistentCache(c, path, 100, codec)
+	for round := range 2 {
+		for i := range 3 {
+			persisted.Set(StringKey(fmt.Sprintf("key%d", i)), "value", 1)
+		}
+		persisted.Wait()
+
+		for range accessSampleRate {
+			for i := range 3 {
+				_, found := persisted.Get(StringKey(fmt.Sprintf("key%d", i)))
+				require.True(t, found)
+			}
+		}
+
+		_, err = persisted.Persist(t.Context())
+		require.NoError(t, err)
+
+		count, err := persisted.Restore(t.Context())
+		require.NoError(t, err)
+		require.Equal(t, 3, count)
+
+		// Each restore decodes its entries with a codec of its own.
+		require.Len(t, codec.decodedPerRestore, round+1)
+		require.Equal(t, 3, *codec.decodedPerRestore[round])
+	}
+}
@@ -76,6 +76,8 @@ func (c *Config) ToOption() ConfigOption {
 		to.SpannerMaxSessions = c.SpannerMaxSessions
 		to.SpannerDatastoreMetricsOption = c.SpannerDatastoreMetricsOption
 		to.TablePrefix = c.TablePrefix
+		to.MemoryPersistenceDirectory = c.MemoryPersistenceDirectory
+		to.MemorySnapshotInterval = c.MemorySnapshotInterval
 		to.RelationshipIntegrityEnabled = c.RelationshipIntegrityEnabled
 		to.RelationshipIntegrityCurrentKey = c.RelationshipIntegrityCurrentKey
 		to.RelationshipIntegrityExpiredKeys = c.RelationshipIntegrityExpiredKeys
@@ -134,6 +136,8 @@ func (c Config) DebugMap() map[string]any {
 	debugMap["SpannerMaxSessions"] = helpers.DebugValue(c.SpannerMaxSessions, false)
 	debugMap["SpannerDatastoreMetricsOption"] = helpers.DebugValue(c.SpannerDatastoreMetricsOption, false)
 	debugMap["TablePrefix"] = helpers.DebugValue(c.TablePrefix, false)
+	debugMap["MemoryPersistenceDirectory"] = helpers.DebugValue(c.MemoryPersistenceDirectory, false)
+	debugMap["MemorySnapshotInterval"] = helpers.DebugValue(c.MemorySnapshotInterval, false)
 	debugMap["RelationshipIntegrityEnabled"] = helpers.DebugValue(c.RelationshipIntegrityEnabled, false)
 	debugMap["RelationshipIntegrityCurrentKey"] = helpers.DebugValue(c.RelationshipIntegrityCurrentKey, false)
 	debugMap["RelationshipIntegrityExpiredKeys"] = helpers.DebugValue(c.RelationshipIntegrityExpiredKeys, false)
@@ -499,6 +503,20 @@ func WithTablePrefix(tablePrefix string) ConfigOption {
 	}
 }
 
+// WithMemoryPersistenceDirectory returns an option that can set MemoryPersistenceDirectory on a Config
+func WithMemoryPersistenceDirectory(memoryPersistenceDirectory string) ConfigOption {
+	return func(c *Config) {
+		c.MemoryPersistenceDirectory = memoryPersistenceDirectory
+	}
+}
+
+// WithMemorySnapshotInterval returns an option that can set MemorySnapshotInterval on a Config
+func WithMemorySnapshotInterval(memorySnapshotInterval time.Duration) ConfigOption {
+	return func(c *Config) {
+		c.MemorySnapshotInterval = memorySnapshotInterval
+	}
+}
+
 // WithRelationshipIntegrityEnabled returns an option that can set RelationshipIntegrityEnabled on a Config
 func WithRelationshipIntegrityEnabled(relationshipIntegrityEnabled bool) ConfigOption {
 	return func(c *Config) {
@@ -106,6 +106,9 @@ func BuildRootCommand() (*cobra.Command, error) {
 	}
 	rootCmd.AddCommand(lspCmd)
 
+	schemaCmd := NewSchemaCommand(rootCmd.Use, new(SchemaLintConfig))
+	rootCmd.AddCommand(schemaCmd)
+
 	var testServerConfig testserver.Config
 	testingCmd := NewTestingCommand(rootCmd.Use, &testServerConfig)
 	RegisterTestingFlags(testingCmd, &testServerConfig)
@@ -0,0 +1,440 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-logr/zerologr"
+	"github.com/jzelinskie/cobrautil/v2"
+	"github.com/jzelinskie/cobrautil/v2/cobrazerolog"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+
+	"github.com/authzed/spicedb/internal/logging"
+	caveattypes "github.com/authzed/spicedb/pkg/caveats/types"
+	"github.com/authzed/spicedb/pkg/cmd/termination"
+	"github.com/authzed/spicedb/pkg/development"
+	"github.com/authzed/spicedb/pkg/diff"
+	devinterface "github.com/authzed/spicedb/pkg/proto/developer/v1"
+	"github.com/authzed/spicedb/pkg/schema"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/generator"
+)
+
+// SchemaLintConfig is the configuration for the schema lint command.
+type SchemaLintConfig struct {
+	// ConfigPath is the path to the lint configuration file, if any.
+	ConfigPath string
+
+	// Rules are the lint rules run over the schemas, to which programs embedding the command
+	// may add custom rules. Defaults to the built-in rules.
+	Rules *development.LintRules
+}
+
+var lintSeverityNames = map[devinterface.DeveloperWarning_Severity]string{
+	devinterface.DeveloperWarning_INFO:    "info",
+	devinterface.DeveloperWarning_WARNING: "warning",
+	devinterface.DeveloperWarning_ERROR:   "error",
+}
+
+func RegisterSchemaLintFlags(cmd *cobra.Command, config *SchemaLintConfig) {
+	cmd.Flags().StringVar(&config.ConfigPath, "config", "", "path to the lint configuration file setting the severity of each rule")
+}
+
+func RegisterSchemaFmtFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolP("write", "w", false, "write the formatted schemas to their files rather than printing them")
+	cmd.Flags().Bool("check", false, "fail if any of the schemas is not formatted, rather than printing them")
+}
+
+func RegisterSchemaDiffFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("fail-on-breaking", false, "fail if any of the changes between the schemas breaks relationships which may be stored")
+	cmd.Flags().Bool("fail-on-widening", false, "fail if any of the permissions changed between the schemas may grant access to more subjects")
+}
+
+func NewSchemaCommand(programName string, lintConfig *SchemaLintConfig) *cobra.Command {
+	schemaCmd := &cobra.Command{
+		Use:   "schema",
+		Short: "schema operations",
+		Long:  "Operations against schema files, run without a server",
+	}
+
+	schemaCmd.AddCommand(NewSchemaCompileCommand(programName))
+
+	fmtCmd := NewSchemaFmtCommand(programName)
+	RegisterSchemaFmtFlags(fmtCmd)
+	schemaCmd.AddCommand(fmtCmd)
+
+	diffCmd := NewSchemaDiffCommand(programName)
+	RegisterSchemaDiffFlags(diffCmd)
+	schemaCmd.AddCommand(diffCmd)
+
+	lintCmd := NewSchemaLintCommand(programName, lintConfig)
+	RegisterSchemaLintFlags(lintCmd, lintConfig)
+	schemaCmd.AddCommand(lintCmd)
+
+	schemaCmd.AddCommand(NewSchemaValidateCommand(programName))
+
+	return schemaCmd
+}
+
+func NewSchemaCompileCommand(programName string) *cobra.Command {
+	return &cobra.Command{
+		Use:     "compile <schema file>...",
+		Short:   "compile schema files",
+		Long:    "Compiles and validates the schema files, reporting any errors found.",
+		PreRunE: schemaPreRunE(programName),
+		RunE: termination.PublishError(func(cmd *cobra.Command, args []string) error {
+			return compileSchemas(cmd, args)
+		}),
+		Args: cobra.MinimumNArgs(1),
+	}
+}
+
+func NewSchemaFmtCommand(programName string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "fmt <schema file>...",
+		Short: "format schema files",
+		Long:  "Formats the schema files, printing the formatted schema unless either --write or --check is specified.",
+		Example: fmt.Sprintf(`	%[1]s schema fmt schema.zed
+	%[1]s schema fmt --write schemas/*.zed
+	%[1]s schema fmt --check schemas/*.zed`, programName),
+		PreRunE: schemaPreRunE(programName),
+		RunE: termination.PublishError(func(cmd *cobra.Command, args []string) error {
+			return formatSchemas(cmd, args, cobrautil.MustGetBool(cmd, "write"), cobrautil.MustGetBool(cmd, "check"))
+		}),
+		Args: cobra.MinimumNArgs(1),
+	}
+}
+
+func NewSchemaDiffCommand(programName string) *cobra.Command {
+	return &cobra.Command{
+		Use:     "diff <old schema file> <new schema file>",
+		Short:   "diff schema files",
+		Long:    "Reports the changes made to the definitions and caveats of the old schema by the new schema, classifying each as additive, behavior-changing or data-breaking. Changed permissions are reported as widening, narrowing or changing access in an unknown way.",
+		PreRunE: schemaPreRunE(programName),
+		RunE: termination.PublishError(func(cmd *cobra.Command, args []string) error {
+			return diffSchemas(cmd, args[0], args[1], cobrautil.MustGetBool(cmd, "fail-on-breaking"), cobrautil.MustGetBool(cmd, "fail-on-widening"))
+		}),
+		Args: cobra.ExactArgs(2),
+	}
+}
+
+func NewSchemaValidateCommand(programName string) *cobra.Command {
+	return &cobra.Command{
+		Use:     "validate <validation file>...",
+		Short:   "validate validation files",
+		Long:    "Loads the schema and relationships of the validation files, and runs their assertions and expected relations, reporting any failures.",
+		PreRunE: schemaPreRunE(programName),
+		RunE: termination.PublishError(func(cmd *cobra.Command, args []string) error {
+			return validateFiles(cmd, args)
+		}),
+		Args: cobra.MinimumNArgs(1),
+	}
+}
+
+func NewSchemaLintCommand(programName string, config *SchemaLintConfig) *cobra.Command {
+	return &cobra.Command{
+		Use:   "lint <schema file>...",
+		Short: "lint schema files",
+		Long:  "Runs the lint rules over the schema files, reporting the warnings raised by each. Fails if any schema is invalid or any rule configured as an error raises a warning.",
+		Example: fmt.Sprintf(`	%[1]s schema lint schema.zed
+	%[1]s schema lint --config lint.yaml schemas/*.zed`, programName),
+		PreRunE: schemaPreRunE(programName),
+		RunE: termination.PublishError(func(cmd *cobra.Command, args []string) error {
+			return lintSchemas(cmd, config, args)
+		}),
+		Args: cobra.MinimumNArgs(1),
+	}
+}
+
+// schemaPreRunE sets up logging for the schema commands, which run locally and so need none of
+// the setup of the server.
+func schemaPreRunE(programName string) cobrautil.CobraRunFunc {
+	return cobrautil.CommandStack(
+		cobrautil.SyncViperDotEnvPreRunE(programName, "spicedb.env", zerologr.New(&logging.Logger)),
+		cobrazerolog.New(
+			cobrazerolog.WithTarget(func(logger zerolog.Logger) {
+				logging.SetGlobalLogger(logger)
+			}),
+		).RunE(),
+	)
+}
+
+func lintSchemas(cmd *cobra.Command, config *SchemaLintConfig, paths []string) error {
+	rules := config.Rules
+	if rules == nil {
+		rules = development.DefaultLintRules()
+	}
+
+	if config.ConfigPath != "" {
+		configFile, err := os.Open(config.ConfigPath)
+		if err != nil {
+			return fmt.Errorf("failed to open lint configuration file: %w", err)
+		}
+		defer configFile.Close()
+
+		lintConfig, err := development.ParseLintConfig(configFile)
+		if err != nil {
+			return err
+		}
+
+		if err := rules.Configure(lintConfig); err != nil {
+			return err
+		}
+	}
+
+	errorCount := 0
+	for _, path := range paths {
+		found, err := lintSchema(cmd, rules, path)
+		if err != nil {
+			return err
+		}
+		errorCount += found
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("found %d lint error(s)", errorCount)
+	}
+	return nil
+}
+
+// lintSchema reports the input errors and warnings of the schema file, returning the number of
+// errors found.
+func lintSchema(cmd *cobra.Command, rules *development.LintRules, path string) (int, error) {
+	devCtx, errorCount, err := loadSchemaFile(cmd, path)
+	if err != nil || devCtx == nil {
+		return errorCount, err
+	}
+	defer devCtx.Dispose()
+
+	warnings, err := rules.Warnings(cmd.Context(), devCtx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, warning := range warnings {
+		if warning.Severity == devinterface.DeveloperWarning_ERROR {
+			errorCount++
+		}
+		printSchemaResult(cmd.OutOrStdout(), path, warning.Line, warning.Column, lintSeverityNames[warning.Severity], warning.Message)
+	}
+	return errorCount, nil
+}
+
+func compileSchemas(cmd *cobra.Command, paths []string) error {
+	errorCount := 0
+	for _, path := range paths {
+		devCtx, found, err := loadSchemaFile(cmd, path)
+		if err != nil {
+			return err
+		}
+
+		errorCount += found
+		if devCtx == nil {
+			continue
+		}
+
+		fmt.Fprintf(cmd.OutOrStdout(), "%s: compiled %d definition(s) and %d caveat(s)\n", path, len(devCtx.CompiledSchema.ObjectDefinitions), len(devCtx.CompiledSchema.CaveatDefinitions))
+		devCtx.Dispose()
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("found %d schema error(s)", errorCount)
+	}
+	return nil
+}
+
+func formatSchemas(cmd *cobra.Command, paths []string, write bool, check bool) error {
+	if write && check {
+		return errors.New("only one of --write or --check may be specified")
+	}
+
+	out := cmd.OutOrStdout()
+	unformattedCount := 0
+	for _, path := range paths {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read schema file: %w", err)
+		}
+
+		compiled, devErr, err := development.CompileSchema(string(contents))
+		if err != nil {
+			return err
+		}
+
+		if devErr != nil {
+			printSchemaResult(out, path, devErr.Line, devErr.Column, "error", devErr.Message)
+			return fmt.Errorf("cannot format invalid schema file %s", path)
+		}
+
+		generated, _, err := generator.GenerateSchema(compiled.OrderedDefinitions)
+		if err != nil {
+			return err
+		}
+
+		formatted := strings.TrimSpace(generated) + "\n"
+		switch {
+		case check:
+			if formatted != string(contents) {
+				fmt.Fprintln(out, path)
+				unformattedCount++
+			}
+
+		case write:
+			if formatted == string(contents) {
+				continue
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				return err
+			}
+
+			if err := os.WriteFile(path, []byte(formatted), info.Mode().Perm()); err != nil {
+				return fmt.Errorf("failed to write schema file: %w", err)
+			}
+
+		default:
+			fmt.Fprint(out, formatted)
+		}
+	}
+
+	if unformattedCount > 0 {
+		return fmt.Errorf("found %d unformatted schema file(s)", unformattedCount)
+	}
+	return nil
+}
+
+func diffSchemas(cmd *cobra.Command, oldPath string, newPath string, failOnBreaking bool, failOnWidening bool) error {
+	var compiled [2]*compiler.CompiledSchema
+	for index, path := range []string{oldPath, newPath} {
+		devCtx, errorCount, err := loadSchemaFile(cmd, path)
+		if err != nil {
+			return err
+		}
+
+		if errorCount > 0 {
+			return fmt.Errorf("cannot diff invalid schema file %s", path)
+		}
+
+		compiled[index] = devCtx.CompiledSchema
+		devCtx.Dispose()
+	}
+
+	schemaDiff, err := diff.DiffSchemas(
+		diff.NewDiffableSchemaFromCompiledSchema(compiled[0]),
+		diff.NewDiffableSchemaFromCompiledSchema(compiled[1]),
+		caveattypes.Default.TypeSet,
+	)
+	if err != nil {
+		return err
+	}
+
+	breakingCount := 0
+	wideningCount := 0
+	for _, delta := range schemaDiff.ClassifiedDeltas() {
+		if delta.Class == diff.ChangeDataBreaking {
+			breakingCount++
+		}
+		if delta.MayWidenAccess() {
+			wideningCount++
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), describeDelta(delta))
+	}
+
+	if failOnBreaking && breakingCount > 0 {
+		return fmt.Errorf("found %d breaking change(s)", breakingCount)
+	}
+	if failOnWidening && wideningCount > 0 {
+		return fmt.Errorf("found %d permission change(s) which may widen access", wideningCount)
+	}
+	return nil
+}
+
+// describeDelta returns the line describing the delta in the output of the diff command.
+func describeDelta(delta diff.ClassifiedDelta) string {
+	if delta.CaveatDelta != nil {
+		description := fmt.Sprintf("caveat %s: %s", delta.CaveatName, delta.CaveatDelta.Type)
+		if delta.CaveatDelta.ParameterName != "" {
+			description += " " + delta.CaveatDelta.ParameterName
+		}
+		return fmt.Sprintf("%s (%s)", description, delta.Class)
+	}
+
+	nsDelta := delta.NamespaceDelta
+	description := fmt.Sprintf("definition %s: %s", delta.NamespaceName, nsDelta.Type)
+	switch {
+	case nsDelta.PreviousRelationName != "":
+		description += fmt.Sprintf(" %s -> %s", nsDelta.PreviousRelationName, nsDelta.RelationName)
+
+	case nsDelta.AllowedType != nil:
+		description += fmt.Sprintf(" %s: %s", nsDelta.RelationName, schema.SourceForAllowedRelation(nsDelta.AllowedType))
+
+	case nsDelta.RelationName != "":
+		description += " " + nsDelta.RelationName
+	}
+
+	if nsDelta.AccessChange != "" {
+		return fmt.Sprintf("%s (%s, access %s)", description, delta.Class, nsDelta.AccessChange)
+	}
+	return fmt.Sprintf("%s (%s)", description, delta.Class)
+}
+
+func validateFiles(cmd *cobra.Command, paths []string) error {
+	errorCount := 0
+	for _, path := range paths {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read validation file: %w", err)
+		}
+
+		result, err := development.RunValidationFile(cmd.Context(), contents)
+		if err != nil {
+			return err
+		}
+
+		for _, devErrs := range [][]*devinterface.DeveloperError{result.InputErrors, result.AssertionErrors, result.ValidationErrors} {
+			for _, devErr := range devErrs {
+				printSchemaResult(cmd.OutOrStdout(), path, devErr.Line, devErr.Column, "error", devErr.Message)
+				errorCount++
+			}
+		}
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("found %d validation error(s)", errorCount)
+	}
+	return nil
+}
+
+// loadSchemaFile loads the schema file into a development context, reporting its input errors,
+// if any. If any are found, their number is returned instead of a context.
+func loadSchemaFile(cmd *cobra.Command, path string) (*development.DevContext, int, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read schema file: %w", err)
+	}
+
+	devCtx, devErrs, err := development.NewDevContext(cmd.Context(), &devinterface.RequestContext{
+		Schema: string(contents),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if devErrs != nil {
+		for _, devErr := range devErrs.InputErrors {
+			printSchemaResult(cmd.OutOrStdout(), path, devErr.Line, devErr.Column, "error", devErr.Message)
+		}
+		return nil, len(devErrs.InputErrors), nil
+	}
+
+	return devCtx, 0, nil
+}
+
+func printSchemaResult(out io.Writer, path string, line uint32, column uint32, severity string, message string) {
+	fmt.Fprintf(out, "%s:%d:%d: %s: %s\n", path, line, column, severity, strings.TrimSpace(message))
+}
@@ -0,0 +1,435 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaLint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		schema         string
+		config         string
+		expectedOutput string
+		expectedError  string
+	}{
+		{
+			name: "no warnings",
+			schema: `definition user {}
+
+definition document {
+	relation viewer: user
+	permission view = viewer
+}`,
+			expectedOutput: "",
+		},
+		{
+			name: "warnings",
+			schema: `definition user {}
+
+definition document {
+	relation viewer: user
+	permission view_document = viewer
+}`,
+			expectedOutput: "schema.zed:5:2: warning: Permission \"view_document\" references parent type \"document\" in its name; it is recommended to drop the suffix (relation-name-references-parent)\n",
+		},
+		{
+			name: "rule disabled",
+			schema: `definition user {}
+
+definition document {
+	relation viewer: user
+	permission view_document = viewer
+}`,
+			config: `rules:
+  relation-name-references-parent: "off"
+`,
+			expectedOutput: "",
+		},
+		{
+			name: "rule configured as error",
+			schema: `definition user {}
+
+definition document {
+	relation viewer: user
+	permission view_document = viewer
+}`,
+			config: `rules:
+  relation-name-references-parent: error
+`,
+			expectedOutput: "schema.zed:5:2: error: Permission \"view_document\" references parent type \"document\" in its name; it is recommended to drop the suffix (relation-name-references-parent)\n",
+			expectedError:  "found 1 lint error(s)",
+		},
+		{
+			name:   "unknown rule configured",
+			schema: `definition user {}`,
+			config: `rules:
+  unknown-rule: error
+`,
+			expectedError: "unknown lint rule `unknown-rule`",
+		},
+		{
+			name: "invalid schema",
+			schema: `definition document {
+	relation viewer: user
+}`,
+			expectedOutput: "schema.zed:2:19: error: could not lookup definition `user` for relation `viewer`: object definition `user` not found\n",
+			expectedError:  "found 1 lint error(s)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "schema.zed"), []byte(tt.schema), 0o600))
+
+			config := &SchemaLintConfig{}
+			if tt.config != "" {
+				config.ConfigPath = filepath.Join(dir, "lint.yaml")
+				require.NoError(t, os.WriteFile(config.ConfigPath, []byte(tt.config), 0o600))
+			}
+
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+			cmd.SetContext(t.Context())
+
+			err := lintSchemas(cmd, config, []string{filepath.Join(dir, "schema.zed")})
+			if tt.expectedError != "" {
+				require.ErrorContains(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.expectedOutput, strings.ReplaceAll(out.String(), dir+string(filepath.Separator), ""))
+		})
+	}
+}
+
+func newSchemaTestCommand(t *testing.T) (*cobra.Command, *bytes.Buffer) {
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	cmd.SetContext(t.Context())
+	return cmd, &out
+}
+
+func TestSchemaCompile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	validPath := filepath.Join(dir, "valid.zed")
+	require.NoError(t, os.WriteFile(validPath, []byte(`definition user {}
+
+definition document {
+	relation viewer: user with only_weekdays
+	permission view = viewer
+}
+
+caveat only_weekdays(weekday string) {
+	weekday != "saturday" && weekday != "sunday"
+}`), 0o600))
+
+	invalidPath := filepath.Join(dir, "invalid.zed")
+	require.NoError(t, os.WriteFile(invalidPath, []byte(`definition document {
+	relation viewer: user
+}`), 0o600))
+
+	cmd, out := newSchemaTestCommand(t)
+	require.NoError(t, compileSchemas(cmd, []string{validPath}))
+	require.Equal(t, "valid.zed: compiled 2 definition(s) and 1 caveat(s)\n", strings.ReplaceAll(out.String(), dir+string(filepath.Separator), ""))
+
+	cmd, out = newSchemaTestCommand(t)
+	require.ErrorContains(t, compileSchemas(cmd, []string{validPath, invalidPath}), "found 1 schema error(s)")
+	require.Equal(t, "valid.zed: compiled 2 definition(s) and 1 caveat(s)\ninvalid.zed:2:19: error: could not lookup definition `user` for relation `viewer`: object definition `user` not found\n", strings.ReplaceAll(out.String(), dir+string(filepath.Separator), ""))
+}
+
+func TestSchemaFmt(t *testing.T) {
+	t.Parallel()
+
+	unformatted := `definition user {}
+definition document {
+    relation viewer: user
+  permission view = viewer+viewer
+}`
+	formatted := `definition user {}
+
+definition document {
+	relation viewer: user
+	permission view = viewer + viewer
+}
+`
+
+	tests := []struct {
+		name           string
+		schema         string
+		write          bool
+		check          bool
+		expectedOutput string
+		expectedSchema string
+		expectedError  string
+	}{
+		{
+			name:           "print",
+			schema:         unformatted,
+			expectedOutput: formatted,
+			expectedSchema: unformatted,
+		},
+		{
+			name:           "write",
+			schema:         unformatted,
+			write:          true,
+			expectedSchema: formatted,
+		},
+		{
+			name:           "check unformatted",
+			schema:         unformatted,
+			check:          true,
+			expectedOutput: "schema.zed\n",
+			expectedSchema: unformatted,
+			expectedError:  "found 1 unformatted schema file(s)",
+		},
+		{
+			name:           "check formatted",
+			schema:         formatted,
+			check:          true,
+			expectedSchema: formatted,
+		},
+		{
+			name:           "write and check",
+			schema:         unformatted,
+			write:          true,
+			check:          true,
+			expectedSchema: unformatted,
+			expectedError:  "only one of --write or --check may be specified",
+		},
+		{
+			name:           "invalid schema",
+			schema:         "definition user {",
+			write:          true,
+			expectedOutput: "schema.zed:1:1: error: Expected end of statement or definition, found: TokenTypeError\n",
+			expectedSchema: "definition user {",
+			expectedError:  "cannot format invalid schema file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			path := filepath.Join(dir, "schema.zed")
+			require.NoError(t, os.WriteFile(path, []byte(tt.schema), 0o600))
+
+			cmd, out := newSchemaTestCommand(t)
+			err := formatSchemas(cmd, []string{path}, tt.write, tt.check)
+			if tt.expectedError != "" {
+				require.ErrorContains(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.expectedOutput, strings.ReplaceAll(out.String(), dir+string(filepath.Separator), ""))
+
+			contents, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedSchema, string(contents))
+		})
+	}
+}
+
+func TestSchemaDiff(t *testing.T) {
+	t.Parallel()
+
+	oldSchema := `definition user {}
+
+definition team {
+	relation member: user
+}
+
+definition document {
+	relation viewer: user | team#member
+	relation editor: user
+	permission view = viewer + editor
+}
+
+caveat only_weekdays(weekday string, timezone string) {
+	weekday != "saturday" && timezone != ""
+}`
+
+	tests := []struct {
+		name           string
+		newSchema      string
+		failOnBreaking bool
+		failOnWidening bool
+		expectedOutput string
+		expectedError  string
+	}{
+		{
+			name:           "unchanged",
+			newSchema:      oldSchema,
+			failOnBreaking: true,
+			expectedOutput: "",
+		},
+		{
+			name: "additive",
+			newSchema: `definition user {}
+
+definition team {
+	relation member: user
+}
+
+definition document {
+	relation viewer: user | team#member | user:*
+	relation editor: user
+	relation owner: user
+	permission view = viewer + editor
+	permission edit = editor + owner
+}
+
+definition folder {}
+
+caveat only_weekdays(weekday string, timezone string) {
+	weekday != "saturday" && timezone != ""
+}`,
+			failOnBreaking: true,
+			failOnWidening: true,
+			expectedOutput: `definition document: added-permission edit (additive)
+definition document: added-relation owner (additive)
+definition document: relation-allowed-type-added viewer: user:* (additive)
+definition folder: namespace-added (additive)
+`,
+		},
+		{
+			name: "breaking",
+			newSchema: `definition user {}
+
+definition document {
+	relation viewer: user
+	permission view = viewer
+}
+
+caveat only_weekdays(weekday int) {
+	weekday != 6
+}`,
+			expectedOutput: `definition document: changed-permission-implementation view (behavior-changing, access narrows)
+definition document: relation-allowed-type-removed viewer: team#member (data-breaking)
+definition document: removed-relation editor (data-breaking)
+definition team: namespace-removed (data-breaking)
+caveat only_weekdays: expression-has-changed (behavior-changing)
+caveat only_weekdays: parameter-type-changed weekday (data-breaking)
+caveat only_weekdays: removed-parameter timezone (data-breaking)
+`,
+		},
+		{
+			name: "fail on breaking",
+			newSchema: `definition user {}
+
+definition document {
+	relation viewer: user | team#member
+	relation editor: user
+	permission view = viewer + editor
+}
+
+definition team {
+	relation member: user
+}`,
+			failOnBreaking: true,
+			expectedOutput: "caveat only_weekdays: caveat-removed (data-breaking)\n",
+			expectedError:  "found 1 breaking change(s)",
+		},
+		{
+			name: "fail on widening",
+			newSchema: `definition user {}
+
+definition team {
+	relation member: user
+}
+
+definition document {
+	relation viewer: user | team#member
+	relation editor: user
+	relation owner: user
+	permission view = viewer + editor + owner
+}
+
+caveat only_weekdays(weekday string, timezone string) {
+	weekday != "saturday" && timezone != ""
+}`,
+			failOnBreaking: true,
+			failOnWidening: true,
+			expectedOutput: `definition document: added-relation owner (additive)
+definition document: changed-permission-implementation view (behavior-changing, access widens)
+`,
+			expectedError: "found 1 permission change(s) which may widen access",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			oldPath := filepath.Join(dir, "old.zed")
+			newPath := filepath.Join(dir, "new.zed")
+			require.NoError(t, os.WriteFile(oldPath, []byte(oldSchema), 0o600))
+			require.NoError(t, os.WriteFile(newPath, []byte(tt.newSchema), 0o600))
+
+			cmd, out := newSchemaTestCommand(t)
+			err := diffSchemas(cmd, oldPath, newPath, tt.failOnBreaking, tt.failOnWidening)
+			if tt.expectedError != "" {
+				require.ErrorContains(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			require.Equal(t, tt.expectedOutput, out.String())
+		})
+	}
+}
+
+func TestSchemaValidate(t *testing.T) {
+	t.Parallel()
+
+	validationFile := `schema: |-
+  definition user {}
+
+  definition document {
+    relation viewer: user
+    permission view = viewer
+  }
+relationships: |-
+  document:readme#viewer@user:tom
+assertions:
+  assertTrue:
+    - document:readme#view@user:tom
+  assertFalse:
+    - document:readme#view@user:%s
+`
+
+	dir := t.TempDir()
+	validPath := filepath.Join(dir, "valid.yaml")
+	require.NoError(t, os.WriteFile(validPath, []byte(fmt.Sprintf(validationFile, "sarah")), 0o600))
+
+	invalidPath := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalidPath, []byte(fmt.Sprintf(validationFile, "tom")), 0o600))
+
+	cmd, out := newSchemaTestCommand(t)
+	require.NoError(t, validateFiles(cmd, []string{validPath}))
+	require.Empty(t, out.String())
+
+	cmd, out = newSchemaTestCommand(t)
+	require.ErrorContains(t, validateFiles(cmd, []string{validPath, invalidPath}), "found 1 validation error(s)")
+	require.Equal(t, "invalid.yaml:14:7: error: Expected relation or permission document:readme#view@user:tom to not exist\n", strings.ReplaceAll(out.String(), dir+string(filepath.Separator), ""))
+}
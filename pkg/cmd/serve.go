@@ -22,30 +22,33 @@ const PresharedKeyFlag = "grpc-preshared-key"
 
 var (
 	namespaceCacheDefaults = &server.CacheConfig{
-		Name:                "namespace",
-		Enabled:             true,
-		Metrics:             true,
-		NumCounters:         1_000,
-		MaxCost:             "32MiB",
-		CacheKindForTesting: "",
+		Name:                  "namespace",
+		Enabled:               true,
+		Metrics:               true,
+		NumCounters:           1_000,
+		MaxCost:               "32MiB",
+		CacheKindForTesting:   "",
+		PersistenceMaxEntries: 1_000,
 	}
 
 	dispatchCacheDefaults = &server.CacheConfig{
-		Name:                "dispatch",
-		Enabled:             true,
-		Metrics:             true,
-		NumCounters:         10_000,
-		MaxCost:             "30%",
-		CacheKindForTesting: "",
+		Name:                  "dispatch",
+		Enabled:               true,
+		Metrics:               true,
+		NumCounters:           10_000,
+		MaxCost:               "30%",
+		CacheKindForTesting:   "",
+		PersistenceMaxEntries: 10_000,
 	}
 
 	dispatchClusterCacheDefaults = &server.CacheConfig{
-		Name:                "cluster_dispatch",
-		Enabled:             true,
-		Metrics:             true,
-		NumCounters:         100_000,
-		MaxCost:             "70%",
-		CacheKindForTesting: "",
+		Name:                  "cluster_dispatch",
+		Enabled:               true,
+		Metrics:               true,
+		NumCounters:           100_000,
+		MaxCost:               "70%",
+		CacheKindForTesting:   "",
+		PersistenceMaxEntries: 10_000,
 	}
 
 	lr3ChunkCacheDefaults = &server.CacheConfig{
@@ -73,11 +76,15 @@ func RegisterServeFlags(cmd *cobra.Command, config *server.Config) error {
 
 	// Flags for the gRPC API server
 	util.RegisterGRPCServerFlags(grpcFlagSet, &config.GRPCServer, "grpc", "gRPC", ":50051", true)
-	grpcFlagSet.StringSliceVar(&config.PresharedSecureKey, PresharedKeyFlag, []string{}, "(required) preshared key(s) that must be provided by clients to authenticate requests")
+	grpcFlagSet.StringSliceVar(&config.PresharedSecureKey, PresharedKeyFlag, []string{}, "preshared key(s) that must be provided by clients to authenticate requests (required with the preshared-key auth backend, and for dispatch)")
 	grpcFlagSet.DurationVar(&config.ShutdownGracePeriod, "grpc-shutdown-grace-period", 0*time.Second, "amount of time after receiving sigint to continue serving")
-	if err := cobra.MarkFlagRequired(grpcFlagSet, PresharedKeyFlag); err != nil {
-		return fmt.Errorf("failed to mark flag as required: %w", err)
-	}
+	grpcFlagSet.StringVar(&config.GRPCAuthBackend, "grpc-auth-backend", server.GRPCAuthBackendPresharedKey, `backend used to authenticate API requests ("preshared-key" or "jwt")`)
+	grpcFlagSet.StringVar(&config.JWTJWKSPath, "grpc-jwt-jwks-path", "", "path to a JWKS file holding the public keys which sign bearer tokens, for the jwt auth backend")
+	grpcFlagSet.StringVar(&config.JWTIssuer, "grpc-jwt-issuer", "", "if set, the issuer which bearer tokens must have, for the jwt auth backend")
+	grpcFlagSet.StringVar(&config.JWTAudience, "grpc-jwt-audience", "", "if set, an audience which bearer tokens must have, for the jwt auth backend")
+	grpcFlagSet.StringArrayVar(&config.JWTKeyScopes, "grpc-jwt-key-scopes", []string{}, "methods which tokens signed by a key may call, as \"keyid=Method1,Method2\", for the jwt auth backend; methods may be full gRPC method names, method names alone, \"package.Service/*\" or \"*\", and keys without scopes may call all methods")
+	grpcFlagSet.StringVar(&config.GRPCTokenPolicyFile, "grpc-token-policy-file", "", "path to a YAML file which restricts the methods each bearer token may call and rate limits each token; the file is reloaded when it changes")
+	grpcFlagSet.DurationVar(&config.GRPCTokenPolicyReloadInterval, "grpc-token-policy-reload-interval", 10*time.Second, "interval at which the token policy file is checked for changes")
 
 	// Flags for HTTP gateway
 	httpFlags := nfs.FlagSet(BoldBlue("HTTP"))
@@ -136,12 +143,15 @@ func RegisterServeFlags(cmd *cobra.Command, config *server.Config) error {
 	}
 	namespaceCacheFlags.DurationVar(&config.SchemaWatchHeartbeat, "datastore-schema-watch-heartbeat", 1*time.Second, "heartbeat time on the schema watch in the datastore (if supported). 0 means to default to the datastore's minimum.")
 	server.MustRegisterCacheFlags(namespaceCacheFlags, "ns-cache", &config.NamespaceCacheConfig, namespaceCacheDefaults)
+	server.MustRegisterCachePersistenceFlags(namespaceCacheFlags, "ns-cache", &config.NamespaceCacheConfig, namespaceCacheDefaults)
 
 	dispatchFlags := nfs.FlagSet(BoldBlue("Dispatch"))
 	// Flags for configuring the dispatch server
 	util.RegisterGRPCServerFlags(dispatchFlags, &config.DispatchServer, "dispatch-cluster", "dispatch", ":50053", false)
 	server.MustRegisterCacheFlags(dispatchFlags, "dispatch-cache", &config.DispatchCacheConfig, dispatchCacheDefaults)
 	server.MustRegisterCacheFlags(dispatchFlags, "dispatch-cluster-cache", &config.ClusterDispatchCacheConfig, dispatchClusterCacheDefaults)
+	server.MustRegisterCachePersistenceFlags(dispatchFlags, "dispatch-cache", &config.DispatchCacheConfig, dispatchCacheDefaults)
+	server.MustRegisterCachePersistenceFlags(dispatchFlags, "dispatch-cluster-cache", &config.ClusterDispatchCacheConfig, dispatchClusterCacheDefaults)
 
 	// Flags for configuring dispatch requests
 	dispatchFlags.Uint16Var(&config.DispatchChunkSize, "dispatch-chunk-size", 100, "maximum number of object IDs in a dispatched request")
@@ -156,6 +166,7 @@ func RegisterServeFlags(cmd *cobra.Command, config *server.Config) error {
 	dispatchFlags.Uint16Var(&config.DispatchConcurrencyLimits.LookupResources, "dispatch-lookup-resources-concurrency-limit", 0, "maximum number of parallel goroutines to create for each lookup resources request or subrequest. defaults to --dispatch-concurrency-limit")
 	dispatchFlags.Uint16Var(&config.DispatchConcurrencyLimits.LookupSubjects, "dispatch-lookup-subjects-concurrency-limit", 0, "maximum number of parallel goroutines to create for each lookup subjects request or subrequest. defaults to --dispatch-concurrency-limit")
 	dispatchFlags.Uint16Var(&config.DispatchConcurrencyLimits.ReachableResources, "dispatch-reachable-resources-concurrency-limit", 0, "maximum number of parallel goroutines to create for each reachable resources request or subrequest. defaults to --dispatch-concurrency-limit")
+	dispatchFlags.Uint16Var(&config.DispatchAdaptiveMaxConcurrency, "dispatch-adaptive-max-concurrency", 1000, "maximum number of parallel goroutines to create for each request across its operations on a node, when adaptive dispatch concurrency is enabled")
 
 	dispatchFlags.Uint16Var(&config.DispatchHashringReplicationFactor, "dispatch-hashring-replication-factor", 100, "set the replication factor of the consistent hasher used for the dispatcher")
 	dispatchFlags.Uint8Var(&config.DispatchHashringSpread, "dispatch-hashring-spread", 1, "set the spread of the consistent hasher used for the dispatcher")
@@ -180,6 +191,12 @@ func RegisterServeFlags(cmd *cobra.Command, config *server.Config) error {
 		return fmt.Errorf("failed to mark flag as deprecated: %w", err)
 	}
 	experimentalFlags.BoolVar(&config.EnableExperimentalWatchableSchemaCache, "enable-experimental-watchable-schema-cache", false, "enables the experimental schema cache, which uses the Watch API to keep the schema up to date")
+	experimentalFlags.BoolVar(&config.EnableExperimentalRelationshipCounterMaintenance, "enable-experimental-relationship-counter-maintenance", false, "enables maintaining relationship counters from the Watch API, rather than counting the relationships on each read")
+	experimentalFlags.DurationVar(&config.RelationshipCounterFlushInterval, "experimental-relationship-counter-flush-interval", 1*time.Second, "interval at which maintained relationship counter values are written to the datastore")
+	experimentalFlags.BoolVar(&config.EnableExperimentalDispatchCacheCarryForward, "enable-experimental-dispatch-cache-carry-forward", false, "enables carrying cached check results forward to newer revisions, unless the Watch API reports a change to the relationships or schema they depend upon")
+	experimentalFlags.BoolVar(&config.EnableExperimentalDispatchPeerCache, "enable-experimental-dispatch-peer-cache", false, "enables fetching dispatch results not found in the cluster dispatch cache from the node which previously owned them on the dispatch hashring")
+	experimentalFlags.BoolVar(&config.EnableExperimentalResumableLookupCache, "enable-experimental-resumable-lookup-cache", false, "enables caching the results of paginated LookupResources and of LookupSubjects for each resource, such that later pages and lookups reuse the results already found")
+	experimentalFlags.BoolVar(&config.EnableExperimentalAdaptiveDispatchConcurrency, "enable-experimental-adaptive-dispatch-concurrency", false, "enables adapting the concurrency of dispatched operations to the latency of the datastore and the load of the node, shared fairly between requests, in place of the static dispatch concurrency limits")
 	// TODO: these two could reasonably be put in either the Dispatch group or the Experimental group. Is there a preference?
 	experimentalFlags.StringToStringVar(&config.DispatchSecondaryUpstreamAddrs, "experimental-dispatch-secondary-upstream-addrs", nil, "secondary upstream addresses for dispatches, each with a name")
 	experimentalFlags.StringToStringVar(&config.DispatchSecondaryUpstreamExprs, "experimental-dispatch-secondary-upstream-exprs", nil, "map from request type to its associated CEL expression, which returns the secondary upstream(s) to be used for the request")
@@ -2,11 +2,16 @@ package cmd
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/go-jose/go-jose/v4"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
 
@@ -106,6 +111,37 @@ func TestFlagsAreParsed(t *testing.T) {
 	})
 }
 
+func TestJWTAuthFlagsAreParsed(t *testing.T) {
+	keySet := jose.JSONWebKeySet{}
+	for _, keyID := range []string{"check", "schema"} {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		keySet.Keys = append(keySet.Keys, jose.JSONWebKey{Key: key.Public(), KeyID: keyID, Algorithm: string(jose.ES256), Use: "sig"})
+	}
+	contents, err := json.Marshal(keySet)
+	require.NoError(t, err)
+	jwksPath := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksPath, contents, 0o600))
+
+	flags := []string{
+		"--grpc-addr", "127.0.0.1:13051",
+		"--grpc-auth-backend", "jwt",
+		"--grpc-jwt-jwks-path", jwksPath,
+		"--grpc-jwt-issuer", "https://issuer.example.com",
+		"--grpc-jwt-audience", "spicedb",
+		"--grpc-jwt-key-scopes", "check=CheckPermission,CheckBulkPermissions",
+		"--grpc-jwt-key-scopes", "schema=authzed.api.v1.SchemaService/*",
+	}
+	RunServeTest(t, flags, func(t *testing.T, mergedConfig *server.Config) {
+		require.Equal(t, server.GRPCAuthBackendJWT, mergedConfig.GRPCAuthBackend)
+		require.Empty(t, mergedConfig.PresharedSecureKey)
+		require.Equal(t, jwksPath, mergedConfig.JWTJWKSPath)
+		require.Equal(t, "https://issuer.example.com", mergedConfig.JWTIssuer)
+		require.Equal(t, "spicedb", mergedConfig.JWTAudience)
+		require.Equal(t, []string{"check=CheckPermission,CheckBulkPermissions", "schema=authzed.api.v1.SchemaService/*"}, mergedConfig.JWTKeyScopes)
+	})
+}
+
 func TestEnvVarsAreParsed(t *testing.T) {
 	t.Setenv("SPICEDB_GRPC_PRESHARED_KEY", "some_key")
 	t.Setenv("SPICEDB_GRPC_ADDR", "127.0.0.1:10051")
@@ -2,6 +2,7 @@ package server
 
 import (
 	"cmp"
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -13,7 +14,9 @@ import (
 	"github.com/pbnjay/memory"
 	"github.com/spf13/pflag"
 
+	log "github.com/authzed/spicedb/internal/logging"
 	"github.com/authzed/spicedb/pkg/cache"
+	"github.com/authzed/spicedb/pkg/cmd/termination"
 )
 
 // Factor by which we will extend the maximum amount of expected needed TTL
@@ -41,6 +44,11 @@ type CacheConfig struct {
 	Enabled             bool          `debugmap:"visible"`
 	defaultTTL          time.Duration `debugmap:"visible"`
 	CacheKindForTesting string        `debugmap:"visible"`
+
+	// PersistencePath is the local path to which the hot entries of the cache are persisted upon
+	// termination, and from which they are restored at startup. Persistence is disabled if empty.
+	PersistencePath       string `debugmap:"visible"`
+	PersistenceMaxEntries int    `debugmap:"visible"`
 }
 
 // WithRevisionParameters configures a cache such that all entries are given a TTL
@@ -50,14 +58,28 @@ func (cc *CacheConfig) WithRevisionParameters(
 	followerReadDelay time.Duration,
 	maxStalenessPercent float64,
 ) *CacheConfig {
-	maxExpectedLifetime := float64(quantizationInterval.Nanoseconds())*(1+maxStalenessPercent) + float64(followerReadDelay.Nanoseconds())
-	cc.defaultTTL = time.Duration(maxExpectedLifetime*ttlExtensionFactor) * time.Nanosecond
+	cc.defaultTTL = revisionWindow(quantizationInterval, followerReadDelay, maxStalenessPercent)
 	return cc
 }
 
+// revisionWindow returns the duration for which results computed at a revision may be requested,
+// extended to expire safely outside of the quantization window.
+func revisionWindow(
+	quantizationInterval time.Duration,
+	followerReadDelay time.Duration,
+	maxStalenessPercent float64,
+) time.Duration {
+	maxExpectedLifetime := float64(quantizationInterval.Nanoseconds())*(1+maxStalenessPercent) + float64(followerReadDelay.Nanoseconds())
+	return time.Duration(maxExpectedLifetime*ttlExtensionFactor) * time.Nanosecond
+}
+
+func (cc *CacheConfig) disabled() bool {
+	return !cc.Enabled || cc.MaxCost == "" || cc.MaxCost == "0%" || cc.NumCounters == 0
+}
+
 // CompleteCache translates the CLI cache config into a cache config.
 func CompleteCache[K cache.KeyString, V any](cc *CacheConfig) (cache.Cache[K, V], error) {
-	if !cc.Enabled || cc.MaxCost == "" || cc.MaxCost == "0%" || cc.NumCounters == 0 {
+	if cc.disabled() {
 		return cache.NoopCache[K, V](), nil
 	}
 
@@ -115,6 +137,37 @@ func CompleteCache[K cache.KeyString, V any](cc *CacheConfig) (cache.Cache[K, V]
 	})
 }
 
+// completeCachePersistence wraps the cache, if persistence is configured, restoring its hot
+// entries from the persistence path and registering a termination handler which persists them.
+func completeCachePersistence[K cache.KeyString, V any](ctx context.Context, cc *CacheConfig, c cache.Cache[K, V], codec cache.Codec[K, V], closeables *closeableStack) cache.Cache[K, V] {
+	if cc.PersistencePath == "" || cc.disabled() {
+		return c
+	}
+
+	persistent := cache.NewPersistentCache(c, cc.PersistencePath, cc.PersistenceMaxEntries, codec)
+
+	// Failing to restore the cache does not fail startup, as the cache is simply left cold.
+	restored, err := persistent.Restore(ctx)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("cache", cc.Name).Msg("unable to restore persisted cache entries")
+	} else {
+		log.Ctx(ctx).Info().Str("cache", cc.Name).Int("entries", restored).Msg("restored persisted cache entries")
+	}
+
+	unregister := termination.RegisterHandler(func(ctx context.Context) error {
+		persisted, err := persistent.Persist(ctx)
+		if err != nil {
+			return fmt.Errorf("unable to persist %s cache entries: %w", cc.Name, err)
+		}
+
+		log.Ctx(ctx).Info().Str("cache", cc.Name).Int("entries", persisted).Msg("persisted cache entries")
+		return nil
+	})
+	closeables.AddWithoutError(unregister)
+
+	return persistent
+}
+
 func parsePercent(str string, freeMem uint64) (uint64, error) {
 	percent := strings.TrimSuffix(str, "%")
 	parsedPercent, err := strconv.ParseUint(percent, 10, 64)
@@ -145,3 +198,11 @@ func MustRegisterCacheFlags(flags *pflag.FlagSet, flagPrefix string, config, def
 		panic(err)
 	}
 }
+
+// MustRegisterCachePersistenceFlags registers flags used to configure the
+// persistence of SpiceDB's caches across restarts.
+func MustRegisterCachePersistenceFlags(flags *pflag.FlagSet, flagPrefix string, config, defaults *CacheConfig) {
+	flagPrefix = cmp.Or(flagPrefix, "cache")
+	flags.StringVar(&config.PersistencePath, flagPrefix+"-persistence-path", defaults.PersistencePath, "local path to which the most frequently accessed cache entries are persisted on termination, and from which those still valid are restored at startup (disabled if empty)")
+	flags.IntVar(&config.PersistenceMaxEntries, flagPrefix+"-persistence-max-entries", defaults.PersistenceMaxEntries, "maximum number of cache entries to persist on termination")
+}
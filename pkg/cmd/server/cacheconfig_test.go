@@ -1,9 +1,14 @@
 package server
 
 import (
+	"context"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/pkg/cache"
+	"github.com/authzed/spicedb/pkg/cmd/termination"
 )
 
 func TestParsePercent(t *testing.T) {
@@ -30,3 +35,55 @@ func TestParsePercent(t *testing.T) {
 		require.Equal(t, tt.expected, v)
 	}
 }
+
+type stringCodec struct{}
+
+func (stringCodec) Encode(_ cache.StringKey, value string) ([]byte, bool) {
+	return []byte(value), true
+}
+
+func (stringCodec) Decode(_ context.Context, encoded []byte) (cache.StringKey, string, int64, bool) {
+	return cache.StringKey(encoded), string(encoded), 1, true
+}
+
+func TestCompleteCachePersistence(t *testing.T) {
+	config := &CacheConfig{
+		Name:            "test",
+		MaxCost:         "1MiB",
+		NumCounters:     1_000,
+		Enabled:         true,
+		PersistencePath: filepath.Join(t.TempDir(), "cache"),
+	}
+
+	closeables := closeableStack{}
+	t.Cleanup(func() {
+		require.NoError(t, closeables.Close())
+	})
+
+	newCache := func() cache.Cache[cache.StringKey, string] {
+		c, err := CompleteCache[cache.StringKey, string](config)
+		require.NoError(t, err)
+		closeables.AddWithoutError(c.Close)
+		return completeCachePersistence(t.Context(), config, c, stringCodec{}, &closeables)
+	}
+
+	previous := newCache()
+	require.True(t, cache.IsPersistent(previous))
+	for range 16 {
+		previous.Set("value", "value", 1)
+		previous.Wait()
+	}
+
+	// The entries are persisted when the termination handlers run.
+	termination.RunHandlers(t.Context())
+	require.FileExists(t, config.PersistencePath)
+
+	current := newCache()
+	value, found := current.Get("value")
+	require.True(t, found)
+	require.Equal(t, "value", value)
+
+	// Caches without a persistence path are not persistent.
+	config.PersistencePath = ""
+	require.False(t, cache.IsPersistent(newCache()))
+}
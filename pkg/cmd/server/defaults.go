@@ -173,6 +173,7 @@ const (
 	DefaultMiddlewareGRPCProm         = "grpcprom"
 	DefaultMiddlewareServerVersion    = "serverversion"
 	DefaultMiddlewareMemoryProtection = "memoryprotection"
+	DefaultMiddlewareTokenPolicy      = "tokenpolicy"
 
 	DefaultInternalMiddlewareDispatch       = "dispatch"
 	DefaultInternalMiddlewareDatastore      = "datastore"
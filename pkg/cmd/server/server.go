@@ -28,16 +28,21 @@ import (
 	"github.com/authzed/grpcutil"
 
 	"github.com/authzed/spicedb/internal/auth"
+	"github.com/authzed/spicedb/internal/datastore/counters"
 	"github.com/authzed/spicedb/internal/datastore/proxy"
 	"github.com/authzed/spicedb/internal/datastore/proxy/schemacaching"
 	"github.com/authzed/spicedb/internal/dispatch"
+	"github.com/authzed/spicedb/internal/dispatch/caching"
 	clusterdispatch "github.com/authzed/spicedb/internal/dispatch/cluster"
 	combineddispatch "github.com/authzed/spicedb/internal/dispatch/combined"
 	"github.com/authzed/spicedb/internal/dispatch/graph"
 	"github.com/authzed/spicedb/internal/dispatch/keys"
+	"github.com/authzed/spicedb/internal/dispatch/limiter"
+	"github.com/authzed/spicedb/internal/dispatch/remote"
 	"github.com/authzed/spicedb/internal/gateway"
 	log "github.com/authzed/spicedb/internal/logging"
 	"github.com/authzed/spicedb/internal/middleware/memoryprotection"
+	"github.com/authzed/spicedb/internal/middleware/tokenpolicy"
 	"github.com/authzed/spicedb/internal/services"
 	dispatchSvc "github.com/authzed/spicedb/internal/services/dispatch"
 	"github.com/authzed/spicedb/internal/services/health"
@@ -53,11 +58,23 @@ import (
 )
 
 // ConsistentHashringBuilder is a balancer Builder that uses xxhash as the
-// underlying hash for the ConsistentHashringBalancers it creates.
-var ConsistentHashringBuilder = consistent.NewBuilder(xxhash.Sum64)
+// underlying hash for the ConsistentHashringBalancers it creates. The balancers
+// can also route requests to the previous owners of their keys, for lookups of
+// the dispatch peer cache.
+var ConsistentHashringBuilder = remote.NewPreviousOwnerBalancerBuilder(consistent.NewBuilder(xxhash.Sum64), xxhash.Sum64)
 
 var DefaultMemoryUsageProvider memoryprotection.MemoryUsageProvider
 
+const (
+	// GRPCAuthBackendPresharedKey authenticates API requests with one of the
+	// configured preshared keys.
+	GRPCAuthBackendPresharedKey = "preshared-key"
+
+	// GRPCAuthBackendJWT authenticates API requests with a JWT signed by one
+	// of the keys in the configured JWKS file.
+	GRPCAuthBackendJWT = "jwt"
+)
+
 //go:generate go run github.com/ecordell/optgen -output zz_generated.options.go . Config
 type Config struct {
 	// API config
@@ -68,6 +85,17 @@ type Config struct {
 	DisableVersionResponse bool                  `debugmap:"visible"`
 	ServerName             string                `debugmap:"visible"`
 
+	// API auth backends
+	GRPCAuthBackend string   `debugmap:"visible" default:"preshared-key"`
+	JWTJWKSPath     string   `debugmap:"visible"`
+	JWTIssuer       string   `debugmap:"visible"`
+	JWTAudience     string   `debugmap:"visible"`
+	JWTKeyScopes    []string `debugmap:"visible-format"`
+
+	// Token policies
+	GRPCTokenPolicyFile           string        `debugmap:"visible"`
+	GRPCTokenPolicyReloadInterval time.Duration `debugmap:"visible" default:"10s"`
+
 	// GRPC Gateway config
 	HTTPGateway                    util.HTTPServerConfig `debugmap:"visible"`
 	HTTPGatewayUpstreamAddr        string                `debugmap:"visible"`
@@ -96,6 +124,7 @@ type Config struct {
 	DispatchMaxDepth                  uint32                  `debugmap:"visible"`
 	GlobalDispatchConcurrencyLimit    uint16                  `debugmap:"visible"`
 	DispatchConcurrencyLimits         graph.ConcurrencyLimits `debugmap:"visible"`
+	DispatchAdaptiveMaxConcurrency    uint16                  `debugmap:"visible" default:"1000"`
 	DispatchUpstreamAddr              string                  `debugmap:"visible"`
 	DispatchUpstreamCAPath            string                  `debugmap:"visible"`
 	DispatchUpstreamTimeout           time.Duration           `debugmap:"visible"`
@@ -117,6 +146,11 @@ type Config struct {
 	ClusterDispatchCacheConfig  CacheConfig `debugmap:"visible"`
 	LR3ResourceChunkCacheConfig CacheConfig `debugmap:"visible"`
 
+	EnableExperimentalDispatchCacheCarryForward   bool `debugmap:"visible"`
+	EnableExperimentalDispatchPeerCache           bool `debugmap:"visible"`
+	EnableExperimentalResumableLookupCache        bool `debugmap:"visible"`
+	EnableExperimentalAdaptiveDispatchConcurrency bool `debugmap:"visible"`
+
 	// API Behavior
 	DisableV1SchemaAPI                 bool          `debugmap:"visible"`
 	V1SchemaAdditiveOnly               bool          `debugmap:"visible"`
@@ -137,6 +171,10 @@ type Config struct {
 	EnablePerformanceInsightMetrics    bool          `debugmap:"visible"`
 	MismatchZedTokenBehavior           string        `debugmap:"visible"`
 
+	// Relationship counters
+	EnableExperimentalRelationshipCounterMaintenance bool          `debugmap:"visible"`
+	RelationshipCounterFlushInterval                 time.Duration `debugmap:"visible" default:"1s"`
+
 	// Additional Services
 	MetricsAPI util.HTTPServerConfig `debugmap:"visible"`
 
@@ -217,21 +255,54 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 		}
 	}()
 
-	if len(c.PresharedSecureKey) < 1 && c.GRPCAuthFunc == nil {
-		return nil, errors.New("a preshared key must be provided to authenticate API requests")
-	}
-
 	if c.GRPCAuthFunc == nil {
-		log.Ctx(ctx).Trace().Int("preshared-keys-count", len(c.PresharedSecureKey)).Msg("using gRPC auth with preshared key(s)")
-		for index, presharedKey := range c.PresharedSecureKey {
-			if len(presharedKey) == 0 {
-				return nil, fmt.Errorf("preshared key #%d is empty", index+1)
+		switch c.GRPCAuthBackend {
+		case "", GRPCAuthBackendPresharedKey:
+			if len(c.PresharedSecureKey) < 1 {
+				return nil, errors.New("a preshared key must be provided to authenticate API requests")
 			}
 
-			log.Ctx(ctx).Trace().Int("preshared-key-"+strconv.Itoa(index+1)+"-length", len(presharedKey)).Msg("preshared key configured")
-		}
+			log.Ctx(ctx).Trace().Int("preshared-keys-count", len(c.PresharedSecureKey)).Msg("using gRPC auth with preshared key(s)")
+			if err := validatePresharedKeys(ctx, c.PresharedSecureKey); err != nil {
+				return nil, err
+			}
+
+			c.GRPCAuthFunc = auth.MustRequirePresharedKey(c.PresharedSecureKey)
+
+		case GRPCAuthBackendJWT:
+			if c.JWTJWKSPath == "" {
+				return nil, errors.New("a JWKS file must be provided to authenticate API requests with JWTs")
+			}
+
+			// Dispatch between SpiceDB nodes remains authenticated by preshared key.
+			if c.DispatchServer.Enabled || c.DispatchUpstreamAddr != "" {
+				if len(c.PresharedSecureKey) < 1 {
+					return nil, errors.New("a preshared key must be provided to authenticate dispatch requests")
+				}
+				if err := validatePresharedKeys(ctx, c.PresharedSecureKey); err != nil {
+					return nil, err
+				}
+			}
+
+			keyScopes, err := auth.ParseKeyScopes(c.JWTKeyScopes)
+			if err != nil {
+				return nil, err
+			}
 
-		c.GRPCAuthFunc = auth.MustRequirePresharedKey(c.PresharedSecureKey)
+			log.Ctx(ctx).Trace().Str("jwks-path", c.JWTJWKSPath).Int("scoped-keys-count", len(keyScopes)).Msg("using gRPC auth with JWTs")
+			c.GRPCAuthFunc, err = auth.RequireJWT(auth.JWTConfig{
+				JWKSPath:  c.JWTJWKSPath,
+				Issuer:    c.JWTIssuer,
+				Audience:  c.JWTAudience,
+				KeyScopes: keyScopes,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to configure JWT auth: %w", err)
+			}
+
+		default:
+			return nil, fmt.Errorf("unknown gRPC auth backend %q", c.GRPCAuthBackend)
+		}
 	} else {
 		log.Ctx(ctx).Trace().Msg("using preconfigured auth function")
 	}
@@ -255,10 +326,19 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 	}
 	closeables.AddWithError(ds.Close)
 
+	// Persisted cache entries are only restored if computed at revisions which may still be
+	// requested.
+	cacheRevisionWindow := revisionWindow(
+		c.DatastoreConfig.RevisionQuantization,
+		c.DatastoreConfig.FollowerReadDelay,
+		c.DatastoreConfig.MaxRevisionStalenessPercent,
+	)
+
 	nscc, err := CompleteCache[cache.StringKey, schemacaching.CacheEntry](&c.NamespaceCacheConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create namespace cache: %w", err)
 	}
+	nscc = completeCachePersistence(ctx, &c.NamespaceCacheConfig, nscc, schemacaching.NewPersistenceCodec(ds, cacheRevisionWindow), &closeables)
 	log.Ctx(ctx).Info().EmbedObject(nscc).Msg("configured namespace cache")
 
 	cachingMode := schemacaching.JustInTimeCaching
@@ -266,7 +346,19 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 		cachingMode = schemacaching.WatchIfSupported
 	}
 
-	ds = proxy.NewObservableDatastoreProxy(ds)
+	var (
+		concurrencyLimiter *limiter.AdaptiveLimiter
+		observableOpts     []proxy.ObservableOption
+	)
+	if c.EnableExperimentalAdaptiveDispatchConcurrency {
+		// The limit starts from its maximum, so that an idle node makes full use of its CPU, and
+		// is decreased as load is observed.
+		concurrencyLimiter = limiter.NewAdaptiveLimiter(c.DispatchAdaptiveMaxConcurrency, 1, c.DispatchAdaptiveMaxConcurrency)
+		observableOpts = append(observableOpts, proxy.WithRelationshipQueryLatencyObserver(concurrencyLimiter.ObserveLatency))
+		log.Ctx(ctx).Info().EmbedObject(concurrencyLimiter).Msg("configured adaptive dispatch concurrency")
+	}
+
+	ds = proxy.NewObservableDatastoreProxy(ds, observableOpts...)
 	ds = proxy.NewSingleflightDatastoreProxy(ds)
 	ds = schemacaching.NewCachingDatastoreProxy(ds, nscc, c.DatastoreConfig.GCWindow, cachingMode, c.SchemaWatchHeartbeat)
 	closeables.AddWithError(ds.Close)
@@ -282,6 +374,29 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 	closeables.AddWithoutError(lr3ChunkCache.Close)
 	log.Ctx(ctx).Info().EmbedObject(lr3ChunkCache).Msg("configured LR3 resource chunk cache")
 
+	var invalidationTracker *caching.InvalidationTracker
+	if c.EnableExperimentalDispatchCacheCarryForward {
+		features, err := ds.Features(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error determining datastore features: %w", err)
+		}
+
+		if features.Watch.Status == datastore.FeatureSupported {
+			invalidationTracker = caching.NewInvalidationTracker(ds)
+		} else {
+			log.Ctx(ctx).Warn().Str("reason", features.Watch.Reason).Msg("dispatch cache carry forward disabled; underlying datastore does not support watch")
+		}
+	}
+
+	var peerCache *remote.PeerCache
+	if c.EnableExperimentalDispatchPeerCache {
+		if c.DispatchUpstreamAddr != "" && c.DispatchServer.Enabled {
+			peerCache = remote.NewPeerCache(0)
+		} else {
+			log.Ctx(ctx).Warn().Msg("dispatch peer cache disabled; it requires both a dispatch upstream and the dispatch server")
+		}
+	}
+
 	dispatcher := c.Dispatcher
 	if dispatcher == nil {
 		cc, err := CompleteCache[keys.DispatchCacheKey, any](c.DispatchCacheConfig.WithRevisionParameters(
@@ -293,6 +408,7 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 			return nil, fmt.Errorf("failed to create dispatcher: %w", err)
 		}
 		closeables.AddWithoutError(cc.Close)
+		cc = completeCachePersistence(ctx, &c.DispatchCacheConfig, cc, caching.NewPersistenceCodec(ds, &keys.CanonicalKeyHandler{}, cacheRevisionWindow), &closeables)
 		log.Ctx(ctx).Info().EmbedObject(cc).Msg("configured dispatch cache")
 
 		dispatchPresharedKey := ""
@@ -329,9 +445,13 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 			combineddispatch.PrometheusSubsystem(c.DispatchClientMetricsPrefix),
 			combineddispatch.Cache(cc),
 			combineddispatch.ConcurrencyLimits(concurrencyLimits),
+			combineddispatch.ConcurrencyLimiter(concurrencyLimiter),
 			combineddispatch.DispatchChunkSize(c.DispatchChunkSize),
 			combineddispatch.RelationshipChunkCache(lr3ChunkCache),
 			combineddispatch.StartingPrimaryHedgingDelay(c.DispatchPrimaryDelayForTesting),
+			combineddispatch.InvalidationTracker(invalidationTracker),
+			combineddispatch.PeerCache(peerCache),
+			combineddispatch.ResumableLookups(c.EnableExperimentalResumableLookupCache),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create dispatcher: %w", err)
@@ -351,8 +471,9 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to configure cluster dispatch: %w", err)
 		}
-		log.Ctx(ctx).Info().EmbedObject(cdcc).Msg("configured cluster dispatch cache")
 		closeables.AddWithoutError(cdcc.Close)
+		cdcc = completeCachePersistence(ctx, &c.ClusterDispatchCacheConfig, cdcc, caching.NewPersistenceCodec(ds, &keys.CanonicalKeyHandler{}, cacheRevisionWindow), &closeables)
+		log.Ctx(ctx).Info().EmbedObject(cdcc).Msg("configured cluster dispatch cache")
 
 		cachingClusterDispatch, err = clusterdispatch.NewClusterDispatcher(
 			dispatcher,
@@ -361,8 +482,12 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 			clusterdispatch.Cache(cdcc),
 			clusterdispatch.RemoteDispatchTimeout(c.DispatchUpstreamTimeout),
 			clusterdispatch.ConcurrencyLimits(concurrencyLimits),
+			clusterdispatch.ConcurrencyLimiter(concurrencyLimiter),
 			clusterdispatch.DispatchChunkSize(c.DispatchChunkSize),
 			clusterdispatch.RelationshipChunkCache(lr3ChunkCache),
+			clusterdispatch.InvalidationTracker(invalidationTracker),
+			clusterdispatch.PeerCache(peerCache),
+			clusterdispatch.ResumableLookups(c.EnableExperimentalResumableLookupCache),
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to configure cluster dispatch: %w", err)
@@ -456,6 +581,18 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 		)
 	}
 
+	var tokenPolicyMiddleware *tokenpolicy.Middleware
+	if c.GRPCTokenPolicyFile != "" {
+		tokenPolicyMiddleware, err = tokenpolicy.NewMiddleware(c.GRPCTokenPolicyFile, c.GRPCTokenPolicyReloadInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load token policy file: %w", err)
+		}
+
+		if err := addTokenPolicyMiddleware(defaultUnaryMiddlewareChain, defaultStreamingMiddlewareChain, tokenPolicyMiddleware); err != nil {
+			return nil, fmt.Errorf("error adding token policy middleware: %w", err)
+		}
+	}
+
 	unaryMiddleware, err := c.buildUnaryMiddleware(defaultUnaryMiddlewareChain)
 	if err != nil {
 		return nil, fmt.Errorf("error building unary middlewares: %w", err)
@@ -491,6 +628,9 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 		PerformanceInsightMetricsEnabled:   c.EnablePerformanceInsightMetrics,
 		EnableExperimentalLookupResources3: c.ExperimentalLookupResourcesVersion == "lr3",
 		ExperimentalQueryPlan:              c.ExperimentalQueryPlan == "check",
+		QueryPlanConcurrencyLimit:          concurrencyLimits.Check,
+
+		RelationshipCounterMaintenanceEnabled: c.EnableExperimentalRelationshipCounterMaintenance,
 	}
 
 	healthManager := health.NewHealthManager(dispatcher, ds)
@@ -562,6 +702,11 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 
 	log.Ctx(ctx).Info().Fields(helpers.Flatten(c.DebugMap())).Msg("configuration")
 
+	var counterMaintainer *counters.Maintainer
+	if c.EnableExperimentalRelationshipCounterMaintenance {
+		counterMaintainer = counters.NewMaintainer(ds, counters.FlushInterval(c.RelationshipCounterFlushInterval))
+	}
+
 	return &completedServerConfig{
 		ds:                  ds,
 		gRPCServer:          grpcServer,
@@ -575,6 +720,10 @@ func (c *Config) Complete(ctx context.Context) (RunnableServer, error) {
 		healthManager:       healthManager,
 		statsHandler:        otelgrpc.NewServerHandler(statsHandlerOpts...),
 		closeFunc:           closeables.Close,
+		counterMaintainer:   counterMaintainer,
+		tokenPolicy:         tokenPolicyMiddleware,
+		invalidationTracker: invalidationTracker,
+		concurrencyLimiter:  concurrencyLimiter,
 	}, nil
 }
 
@@ -590,9 +739,22 @@ func (c *Config) BuildMemoryUsageProvider() memoryprotection.MemoryUsageProvider
 	return &memoryprotection.HarcodedMemoryLimitProvider{AcceptAllRequests: true}
 }
 
+func validatePresharedKeys(ctx context.Context, presharedKeys []string) error {
+	for index, presharedKey := range presharedKeys {
+		if len(presharedKey) == 0 {
+			return fmt.Errorf("preshared key #%d is empty", index+1)
+		}
+
+		log.Ctx(ctx).Trace().Int("preshared-key-"+strconv.Itoa(index+1)+"-length", len(presharedKey)).Msg("preshared key configured")
+	}
+	return nil
+}
+
 func (c *Config) buildDispatchServer(memoryUsageProvider memoryprotection.MemoryUsageProvider, ds datastore.Datastore, cachingClusterDispatch dispatch.Dispatcher, closeables *closeableStack, otelOpts []otelgrpc.Option) (util.RunnableGRPCServer, error) {
 	if len(c.DispatchUnaryMiddleware) == 0 && len(c.DispatchStreamingMiddleware) == 0 {
-		if c.GRPCAuthFunc == nil {
+		// Peers always authenticate dispatch requests with a preshared key, regardless
+		// of the backend used to authenticate API requests.
+		if c.GRPCAuthFunc == nil || (c.GRPCAuthBackend == GRPCAuthBackendJWT && len(c.PresharedSecureKey) > 0) {
 			c.DispatchUnaryMiddleware, c.DispatchStreamingMiddleware = DefaultDispatchMiddleware(log.Logger, auth.MustRequirePresharedKey(c.PresharedSecureKey), ds, c.DisableGRPCLatencyHistogram, memoryUsageProvider)
 		} else {
 			c.DispatchUnaryMiddleware, c.DispatchStreamingMiddleware = DefaultDispatchMiddleware(log.Logger, c.GRPCAuthFunc, ds, c.DisableGRPCLatencyHistogram, memoryUsageProvider)
@@ -668,6 +830,34 @@ func (c *Config) buildUnaryMiddleware(defaultMiddleware *MiddlewareChain[grpc.Un
 	return chain.ToGRPCInterceptors(), nil
 }
 
+// addTokenPolicyMiddleware adds the token policy middleware to the default
+// chains, directly after the auth middleware.
+func addTokenPolicyMiddleware(unary *MiddlewareChain[grpc.UnaryServerInterceptor], streaming *MiddlewareChain[grpc.StreamServerInterceptor], mw *tokenpolicy.Middleware) error {
+	if err := unary.modify(MiddlewareModification[grpc.UnaryServerInterceptor]{
+		DependencyMiddlewareName: DefaultMiddlewareGRPCAuth,
+		Operation:                OperationAppend,
+		Middlewares: []ReferenceableMiddleware[grpc.UnaryServerInterceptor]{
+			NewUnaryMiddleware().
+				WithName(DefaultMiddlewareTokenPolicy).
+				WithInterceptor(mw.UnaryServerInterceptor()).
+				Done(),
+		},
+	}); err != nil {
+		return err
+	}
+
+	return streaming.modify(MiddlewareModification[grpc.StreamServerInterceptor]{
+		DependencyMiddlewareName: DefaultMiddlewareGRPCAuth,
+		Operation:                OperationAppend,
+		Middlewares: []ReferenceableMiddleware[grpc.StreamServerInterceptor]{
+			NewStreamMiddleware().
+				WithName(DefaultMiddlewareTokenPolicy).
+				WithInterceptor(mw.StreamServerInterceptor()).
+				Done(),
+		},
+	})
+}
+
 func (c *Config) buildStreamingMiddleware(defaultMiddleware *MiddlewareChain[grpc.StreamServerInterceptor]) ([]grpc.StreamServerInterceptor, error) {
 	chain := MiddlewareChain[grpc.StreamServerInterceptor]{}
 	if defaultMiddleware != nil {
@@ -758,6 +948,10 @@ type completedServerConfig struct {
 	presharedKeys       []string
 	statsHandler        stats.Handler
 	closeFunc           func() error
+	counterMaintainer   *counters.Maintainer
+	tokenPolicy         *tokenpolicy.Middleware
+	invalidationTracker *caching.InvalidationTracker
+	concurrencyLimiter  *limiter.AdaptiveLimiter
 }
 
 func (c *completedServerConfig) GRPCDialContext(ctx context.Context, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
@@ -807,6 +1001,22 @@ func (c *completedServerConfig) Run(ctx context.Context) error {
 	g.Go(c.metricsServer.ListenAndServe)
 	g.Go(func() error { return c.telemetryReporter(ctx) })
 
+	if c.counterMaintainer != nil {
+		g.Go(func() error { return c.counterMaintainer.Run(ctx) })
+	}
+
+	if c.tokenPolicy != nil {
+		g.Go(func() error { return c.tokenPolicy.Run(ctx) })
+	}
+
+	if c.invalidationTracker != nil {
+		g.Go(func() error { return c.invalidationTracker.Run(ctx) })
+	}
+
+	if c.concurrencyLimiter != nil {
+		g.Go(func() error { return c.concurrencyLimiter.Run(ctx) })
+	}
+
 	g.Go(stopOnCancelWithErr(c.closeFunc))
 
 	if err := g.Wait(); err != nil {
@@ -2,11 +2,22 @@ package server
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-jose/go-jose/v4"
+	"github.com/go-jose/go-jose/v4/jwt"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/sdk/trace"
@@ -633,3 +644,215 @@ func TestBuildDispatchServer(t *testing.T) {
 		})
 	}
 }
+
+func TestJWTAuthBackend(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	checkKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	adminKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	jwks, err := json.Marshal(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+		{Key: checkKey.Public(), KeyID: "check", Algorithm: string(jose.ES256), Use: "sig"},
+		{Key: adminKey.Public(), KeyID: "admin", Algorithm: string(jose.ES256), Use: "sig"},
+	}})
+	require.NoError(t, err)
+	jwksPath := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(jwksPath, jwks, 0o600))
+
+	ds, err := dsfortesting.NewMemDBDatastoreForTesting(0, 1*time.Second, 10*time.Second)
+	require.NoError(t, err)
+
+	grpcAddr, httpAddr := freeAddress(t), freeAddress(t)
+	srv, err := NewConfigWithOptionsAndDefaults(
+		WithGRPCAuthBackend(GRPCAuthBackendJWT),
+		WithJWTJWKSPath(jwksPath),
+		WithJWTAudience("spicedb"),
+		WithJWTKeyScopes("check=CheckPermission"),
+		WithDatastore(ds),
+		WithGRPCServer(util.GRPCServerConfig{Network: "tcp", Address: grpcAddr, Enabled: true}),
+		WithHTTPGateway(util.HTTPServerConfig{HTTPEnabled: true, HTTPAddress: httpAddr}),
+		WithMetricsAPI(util.HTTPServerConfig{HTTPEnabled: false}),
+		WithDispatchCacheConfig(CacheConfig{Enabled: false}),
+		WithNamespaceCacheConfig(CacheConfig{Enabled: false}),
+		WithClusterDispatchCacheConfig(CacheConfig{Enabled: false}),
+		WithMemoryProtectionEnabled(false),
+	).Complete(ctx)
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	conn, err := srv.GRPCDialContext(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	checkToken := signTestJWT(t, checkKey, "check")
+	adminToken := signTestJWT(t, adminKey, "admin")
+	withToken := func(token string) context.Context {
+		return metadata.AppendToOutgoingContext(ctx, "authorization", "bearer "+token)
+	}
+
+	schemaClient := v1.NewSchemaServiceClient(conn)
+	require.Eventually(t, func() bool {
+		_, err := schemaClient.WriteSchema(withToken(adminToken), &v1.WriteSchemaRequest{
+			Schema: `definition user {}
+definition document {
+	relation viewer: user
+	permission view = viewer
+}`,
+		})
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond)
+
+	_, err = schemaClient.WriteSchema(withToken(checkToken), &v1.WriteSchemaRequest{Schema: `definition user {}`})
+	grpcutil.RequireStatus(t, codes.PermissionDenied, err)
+
+	_, err = schemaClient.WriteSchema(ctx, &v1.WriteSchemaRequest{Schema: `definition user {}`})
+	grpcutil.RequireStatus(t, codes.Unauthenticated, err)
+
+	checkRequest := &v1.CheckPermissionRequest{
+		Consistency: &v1.Consistency{Requirement: &v1.Consistency_FullyConsistent{FullyConsistent: true}},
+		Resource:    &v1.ObjectReference{ObjectType: "document", ObjectId: "doc"},
+		Permission:  "view",
+		Subject:     &v1.SubjectReference{Object: &v1.ObjectReference{ObjectType: "user", ObjectId: "tom"}},
+	}
+	_, err = v1.NewPermissionsServiceClient(conn).CheckPermission(withToken(checkToken), checkRequest)
+	require.NoError(t, err)
+
+	// The same scopes are enforced for requests made through the HTTP gateway.
+	httpRequest := func(path, token, body string) int {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+httpAddr+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+
+		var resp *http.Response
+		require.Eventually(t, func() bool {
+			resp, err = http.DefaultClient.Do(req)
+			return err == nil
+		}, 5*time.Second, 50*time.Millisecond)
+		defer resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	checkBody := `{"consistency": {"fullyConsistent": true}, "resource": {"objectType": "document", "objectId": "doc"}, "permission": "view", "subject": {"object": {"objectType": "user", "objectId": "tom"}}}`
+	require.Equal(t, http.StatusOK, httpRequest("/v1/permissions/check", checkToken, checkBody))
+	require.Equal(t, http.StatusForbidden, httpRequest("/v1/schema/write", checkToken, `{"schema": "definition user {}"}`))
+	require.Equal(t, http.StatusUnauthorized, httpRequest("/v1/permissions/check", "invalid", checkBody))
+}
+
+func TestJWTAuthBackendRequiresPresharedKeyForDispatch(t *testing.T) {
+	_, err := NewConfigWithOptionsAndDefaults(
+		WithGRPCAuthBackend(GRPCAuthBackendJWT),
+		WithJWTJWKSPath(filepath.Join(t.TempDir(), "jwks.json")),
+		WithDispatchServer(util.GRPCServerConfig{Enabled: true}),
+	).Complete(t.Context())
+	require.ErrorContains(t, err, "a preshared key must be provided to authenticate dispatch requests")
+}
+
+func freeAddress(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, l.Close())
+	return l.Addr().String()
+}
+
+func signTestJWT(t *testing.T, key *ecdsa.PrivateKey, keyID string) string {
+	t.Helper()
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.ES256, Key: key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader(jose.HeaderKey("kid"), keyID),
+	)
+	require.NoError(t, err)
+
+	token, err := jwt.Signed(signer).Claims(jwt.Claims{
+		Audience: jwt.Audience{"spicedb"},
+		Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).Serialize()
+	require.NoError(t, err)
+	return token
+}
+
+func TestTokenPolicyMiddleware(t *testing.T) {
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	policyPath := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(policyPath, []byte(`
+tokens:
+  - token: readonly
+    methods: ["ReadSchema"]
+  - token: admin
+`), 0o600))
+
+	ds, err := dsfortesting.NewMemDBDatastoreForTesting(0, 1*time.Second, 10*time.Second)
+	require.NoError(t, err)
+
+	srv, err := NewConfigWithOptionsAndDefaults(
+		SetPresharedSecureKey([]string{"readonly", "admin"}),
+		WithGRPCTokenPolicyFile(policyPath),
+		WithDatastore(ds),
+		WithGRPCServer(util.GRPCServerConfig{Network: util.BufferedNetwork, Enabled: true}),
+		WithHTTPGateway(util.HTTPServerConfig{HTTPEnabled: false}),
+		WithMetricsAPI(util.HTTPServerConfig{HTTPEnabled: false}),
+		WithDispatchCacheConfig(CacheConfig{Enabled: false}),
+		WithNamespaceCacheConfig(CacheConfig{Enabled: false}),
+		WithClusterDispatchCacheConfig(CacheConfig{Enabled: false}),
+		WithMemoryProtectionEnabled(false),
+	).Complete(ctx)
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- srv.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	// The connection authenticates with the first preshared key.
+	conn, err := srv.GRPCDialContext(ctx)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	healthClient := healthpb.NewHealthClient(conn)
+	require.Eventually(t, func() bool {
+		_, err := healthClient.Check(ctx, &healthpb.HealthCheckRequest{Service: ""})
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond)
+
+	schemaClient := v1.NewSchemaServiceClient(conn)
+	_, err = schemaClient.ReadSchema(ctx, &v1.ReadSchemaRequest{})
+	grpcutil.RequireStatus(t, codes.NotFound, err)
+
+	_, err = schemaClient.WriteSchema(ctx, &v1.WriteSchemaRequest{Schema: `definition user {}`})
+	grpcutil.RequireStatus(t, codes.PermissionDenied, err)
+}
+
+func TestTokenPolicyMiddlewareInvalidFile(t *testing.T) {
+	policyPath := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(policyPath, []byte("tokens: [{}]"), 0o600))
+
+	ds, err := dsfortesting.NewMemDBDatastoreForTesting(0, 1*time.Second, 10*time.Second)
+	require.NoError(t, err)
+
+	_, err = NewConfigWithOptionsAndDefaults(
+		WithPresharedSecureKey("psk"),
+		WithGRPCTokenPolicyFile(policyPath),
+		WithDatastore(ds),
+		WithGRPCServer(util.GRPCServerConfig{Network: util.BufferedNetwork, Enabled: true}),
+		WithMetricsAPI(util.HTTPServerConfig{HTTPEnabled: false}),
+		WithMemoryProtectionEnabled(false),
+	).Complete(t.Context())
+	require.ErrorContains(t, err, "failed to load token policy file")
+}
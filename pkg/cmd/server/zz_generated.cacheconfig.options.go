@@ -37,6 +37,8 @@ func (c *CacheConfig) ToOption() CacheConfigOption {
 		to.Enabled = c.Enabled
 		to.defaultTTL = c.defaultTTL
 		to.CacheKindForTesting = c.CacheKindForTesting
+		to.PersistencePath = c.PersistencePath
+		to.PersistenceMaxEntries = c.PersistenceMaxEntries
 	}
 }
 
@@ -49,6 +51,8 @@ func (c CacheConfig) DebugMap() map[string]any {
 	debugMap["Metrics"] = helpers.DebugValue(c.Metrics, false)
 	debugMap["Enabled"] = helpers.DebugValue(c.Enabled, false)
 	debugMap["CacheKindForTesting"] = helpers.DebugValue(c.CacheKindForTesting, false)
+	debugMap["PersistencePath"] = helpers.DebugValue(c.PersistencePath, false)
+	debugMap["PersistenceMaxEntries"] = helpers.DebugValue(c.PersistenceMaxEntries, false)
 	return debugMap
 }
 
@@ -109,3 +113,17 @@ func WithCacheKindForTesting(cacheKindForTesting string) CacheConfigOption {
 		c.CacheKindForTesting = cacheKindForTesting
 	}
 }
+
+// WithPersistencePath returns an option that can set PersistencePath on a CacheConfig
+func WithPersistencePath(persistencePath string) CacheConfigOption {
+	return func(c *CacheConfig) {
+		c.PersistencePath = persistencePath
+	}
+}
+
+// WithPersistenceMaxEntries returns an option that can set PersistenceMaxEntries on a CacheConfig
+func WithPersistenceMaxEntries(persistenceMaxEntries int) CacheConfigOption {
+	return func(c *CacheConfig) {
+		c.PersistenceMaxEntries = persistenceMaxEntries
+	}
+}
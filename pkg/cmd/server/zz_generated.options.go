@@ -44,6 +44,13 @@ func (c *Config) ToOption() ConfigOption {
 		to.ShutdownGracePeriod = c.ShutdownGracePeriod
 		to.DisableVersionResponse = c.DisableVersionResponse
 		to.ServerName = c.ServerName
+		to.GRPCAuthBackend = c.GRPCAuthBackend
+		to.JWTJWKSPath = c.JWTJWKSPath
+		to.JWTIssuer = c.JWTIssuer
+		to.JWTAudience = c.JWTAudience
+		to.JWTKeyScopes = c.JWTKeyScopes
+		to.GRPCTokenPolicyFile = c.GRPCTokenPolicyFile
+		to.GRPCTokenPolicyReloadInterval = c.GRPCTokenPolicyReloadInterval
 		to.HTTPGateway = c.HTTPGateway
 		to.HTTPGatewayUpstreamAddr = c.HTTPGatewayUpstreamAddr
 		to.HTTPGatewayUpstreamTLSCertPath = c.HTTPGatewayUpstreamTLSCertPath
@@ -61,6 +68,7 @@ func (c *Config) ToOption() ConfigOption {
 		to.DispatchMaxDepth = c.DispatchMaxDepth
 		to.GlobalDispatchConcurrencyLimit = c.GlobalDispatchConcurrencyLimit
 		to.DispatchConcurrencyLimits = c.DispatchConcurrencyLimits
+		to.DispatchAdaptiveMaxConcurrency = c.DispatchAdaptiveMaxConcurrency
 		to.DispatchUpstreamAddr = c.DispatchUpstreamAddr
 		to.DispatchUpstreamCAPath = c.DispatchUpstreamCAPath
 		to.DispatchUpstreamTimeout = c.DispatchUpstreamTimeout
@@ -79,6 +87,10 @@ func (c *Config) ToOption() ConfigOption {
 		to.DispatchCacheConfig = c.DispatchCacheConfig
 		to.ClusterDispatchCacheConfig = c.ClusterDispatchCacheConfig
 		to.LR3ResourceChunkCacheConfig = c.LR3ResourceChunkCacheConfig
+		to.EnableExperimentalDispatchCacheCarryForward = c.EnableExperimentalDispatchCacheCarryForward
+		to.EnableExperimentalDispatchPeerCache = c.EnableExperimentalDispatchPeerCache
+		to.EnableExperimentalResumableLookupCache = c.EnableExperimentalResumableLookupCache
+		to.EnableExperimentalAdaptiveDispatchConcurrency = c.EnableExperimentalAdaptiveDispatchConcurrency
 		to.DisableV1SchemaAPI = c.DisableV1SchemaAPI
 		to.V1SchemaAdditiveOnly = c.V1SchemaAdditiveOnly
 		to.MaximumUpdatesPerWrite = c.MaximumUpdatesPerWrite
@@ -97,6 +109,8 @@ func (c *Config) ToOption() ConfigOption {
 		to.EnableRevisionHeartbeat = c.EnableRevisionHeartbeat
 		to.EnablePerformanceInsightMetrics = c.EnablePerformanceInsightMetrics
 		to.MismatchZedTokenBehavior = c.MismatchZedTokenBehavior
+		to.EnableExperimentalRelationshipCounterMaintenance = c.EnableExperimentalRelationshipCounterMaintenance
+		to.RelationshipCounterFlushInterval = c.RelationshipCounterFlushInterval
 		to.MetricsAPI = c.MetricsAPI
 		to.UnaryMiddlewareModification = c.UnaryMiddlewareModification
 		to.StreamingMiddlewareModification = c.StreamingMiddlewareModification
@@ -122,6 +136,13 @@ func (c Config) DebugMap() map[string]any {
 	debugMap["ShutdownGracePeriod"] = helpers.DebugValue(c.ShutdownGracePeriod, false)
 	debugMap["DisableVersionResponse"] = helpers.DebugValue(c.DisableVersionResponse, false)
 	debugMap["ServerName"] = helpers.DebugValue(c.ServerName, false)
+	debugMap["GRPCAuthBackend"] = helpers.DebugValue(c.GRPCAuthBackend, false)
+	debugMap["JWTJWKSPath"] = helpers.DebugValue(c.JWTJWKSPath, false)
+	debugMap["JWTIssuer"] = helpers.DebugValue(c.JWTIssuer, false)
+	debugMap["JWTAudience"] = helpers.DebugValue(c.JWTAudience, false)
+	debugMap["JWTKeyScopes"] = helpers.DebugValue(c.JWTKeyScopes, true)
+	debugMap["GRPCTokenPolicyFile"] = helpers.DebugValue(c.GRPCTokenPolicyFile, false)
+	debugMap["GRPCTokenPolicyReloadInterval"] = helpers.DebugValue(c.GRPCTokenPolicyReloadInterval, false)
 	debugMap["HTTPGateway"] = helpers.DebugValue(c.HTTPGateway, false)
 	debugMap["HTTPGatewayUpstreamAddr"] = helpers.DebugValue(c.HTTPGatewayUpstreamAddr, false)
 	debugMap["HTTPGatewayUpstreamTLSCertPath"] = helpers.DebugValue(c.HTTPGatewayUpstreamTLSCertPath, false)
@@ -139,6 +160,7 @@ func (c Config) DebugMap() map[string]any {
 	debugMap["DispatchMaxDepth"] = helpers.DebugValue(c.DispatchMaxDepth, false)
 	debugMap["GlobalDispatchConcurrencyLimit"] = helpers.DebugValue(c.GlobalDispatchConcurrencyLimit, false)
 	debugMap["DispatchConcurrencyLimits"] = helpers.DebugValue(c.DispatchConcurrencyLimits, false)
+	debugMap["DispatchAdaptiveMaxConcurrency"] = helpers.DebugValue(c.DispatchAdaptiveMaxConcurrency, false)
 	debugMap["DispatchUpstreamAddr"] = helpers.DebugValue(c.DispatchUpstreamAddr, false)
 	debugMap["DispatchUpstreamCAPath"] = helpers.DebugValue(c.DispatchUpstreamCAPath, false)
 	debugMap["DispatchUpstreamTimeout"] = helpers.DebugValue(c.DispatchUpstreamTimeout, false)
@@ -156,6 +178,10 @@ func (c Config) DebugMap() map[string]any {
 	debugMap["DispatchCacheConfig"] = helpers.DebugValue(c.DispatchCacheConfig, false)
 	debugMap["ClusterDispatchCacheConfig"] = helpers.DebugValue(c.ClusterDispatchCacheConfig, false)
 	debugMap["LR3ResourceChunkCacheConfig"] = helpers.DebugValue(c.LR3ResourceChunkCacheConfig, false)
+	debugMap["EnableExperimentalDispatchCacheCarryForward"] = helpers.DebugValue(c.EnableExperimentalDispatchCacheCarryForward, false)
+	debugMap["EnableExperimentalDispatchPeerCache"] = helpers.DebugValue(c.EnableExperimentalDispatchPeerCache, false)
+	debugMap["EnableExperimentalResumableLookupCache"] = helpers.DebugValue(c.EnableExperimentalResumableLookupCache, false)
+	debugMap["EnableExperimentalAdaptiveDispatchConcurrency"] = helpers.DebugValue(c.EnableExperimentalAdaptiveDispatchConcurrency, false)
 	debugMap["DisableV1SchemaAPI"] = helpers.DebugValue(c.DisableV1SchemaAPI, false)
 	debugMap["V1SchemaAdditiveOnly"] = helpers.DebugValue(c.V1SchemaAdditiveOnly, false)
 	debugMap["MaximumUpdatesPerWrite"] = helpers.DebugValue(c.MaximumUpdatesPerWrite, false)
@@ -174,6 +200,8 @@ func (c Config) DebugMap() map[string]any {
 	debugMap["EnableRevisionHeartbeat"] = helpers.DebugValue(c.EnableRevisionHeartbeat, false)
 	debugMap["EnablePerformanceInsightMetrics"] = helpers.DebugValue(c.EnablePerformanceInsightMetrics, false)
 	debugMap["MismatchZedTokenBehavior"] = helpers.DebugValue(c.MismatchZedTokenBehavior, false)
+	debugMap["EnableExperimentalRelationshipCounterMaintenance"] = helpers.DebugValue(c.EnableExperimentalRelationshipCounterMaintenance, false)
+	debugMap["RelationshipCounterFlushInterval"] = helpers.DebugValue(c.RelationshipCounterFlushInterval, false)
 	debugMap["MetricsAPI"] = helpers.DebugValue(c.MetricsAPI, false)
 	debugMap["MemoryProtectionEnabled"] = helpers.DebugValue(c.MemoryProtectionEnabled, false)
 	debugMap["SilentlyDisableTelemetry"] = helpers.DebugValue(c.SilentlyDisableTelemetry, false)
@@ -251,6 +279,62 @@ func WithServerName(serverName string) ConfigOption {
 	}
 }
 
+// WithGRPCAuthBackend returns an option that can set GRPCAuthBackend on a Config
+func WithGRPCAuthBackend(gRPCAuthBackend string) ConfigOption {
+	return func(c *Config) {
+		c.GRPCAuthBackend = gRPCAuthBackend
+	}
+}
+
+// WithJWTJWKSPath returns an option that can set JWTJWKSPath on a Config
+func WithJWTJWKSPath(jWTJWKSPath string) ConfigOption {
+	return func(c *Config) {
+		c.JWTJWKSPath = jWTJWKSPath
+	}
+}
+
+// WithJWTIssuer returns an option that can set JWTIssuer on a Config
+func WithJWTIssuer(jWTIssuer string) ConfigOption {
+	return func(c *Config) {
+		c.JWTIssuer = jWTIssuer
+	}
+}
+
+// WithJWTAudience returns an option that can set JWTAudience on a Config
+func WithJWTAudience(jWTAudience string) ConfigOption {
+	return func(c *Config) {
+		c.JWTAudience = jWTAudience
+	}
+}
+
+// WithJWTKeyScopes returns an option that can append JWTKeyScopess to Config.JWTKeyScopes
+func WithJWTKeyScopes(jWTKeyScopes string) ConfigOption {
+	return func(c *Config) {
+		c.JWTKeyScopes = append(c.JWTKeyScopes, jWTKeyScopes)
+	}
+}
+
+// SetJWTKeyScopes returns an option that can set JWTKeyScopes on a Config
+func SetJWTKeyScopes(jWTKeyScopes []string) ConfigOption {
+	return func(c *Config) {
+		c.JWTKeyScopes = jWTKeyScopes
+	}
+}
+
+// WithGRPCTokenPolicyFile returns an option that can set GRPCTokenPolicyFile on a Config
+func WithGRPCTokenPolicyFile(gRPCTokenPolicyFile string) ConfigOption {
+	return func(c *Config) {
+		c.GRPCTokenPolicyFile = gRPCTokenPolicyFile
+	}
+}
+
+// WithGRPCTokenPolicyReloadInterval returns an option that can set GRPCTokenPolicyReloadInterval on a Config
+func WithGRPCTokenPolicyReloadInterval(gRPCTokenPolicyReloadInterval time.Duration) ConfigOption {
+	return func(c *Config) {
+		c.GRPCTokenPolicyReloadInterval = gRPCTokenPolicyReloadInterval
+	}
+}
+
 // WithHTTPGateway returns an option that can set HTTPGateway on a Config
 func WithHTTPGateway(hTTPGateway util.HTTPServerConfig) ConfigOption {
 	return func(c *Config) {
@@ -377,6 +461,13 @@ func WithDispatchConcurrencyLimits(dispatchConcurrencyLimits graph.ConcurrencyLi
 	}
 }
 
+// WithDispatchAdaptiveMaxConcurrency returns an option that can set DispatchAdaptiveMaxConcurrency on a Config
+func WithDispatchAdaptiveMaxConcurrency(dispatchAdaptiveMaxConcurrency uint16) ConfigOption {
+	return func(c *Config) {
+		c.DispatchAdaptiveMaxConcurrency = dispatchAdaptiveMaxConcurrency
+	}
+}
+
 // WithDispatchUpstreamAddr returns an option that can set DispatchUpstreamAddr on a Config
 func WithDispatchUpstreamAddr(dispatchUpstreamAddr string) ConfigOption {
 	return func(c *Config) {
@@ -524,6 +615,34 @@ func WithLR3ResourceChunkCacheConfig(lR3ResourceChunkCacheConfig CacheConfig) Co
 	}
 }
 
+// WithEnableExperimentalDispatchCacheCarryForward returns an option that can set EnableExperimentalDispatchCacheCarryForward on a Config
+func WithEnableExperimentalDispatchCacheCarryForward(enableExperimentalDispatchCacheCarryForward bool) ConfigOption {
+	return func(c *Config) {
+		c.EnableExperimentalDispatchCacheCarryForward = enableExperimentalDispatchCacheCarryForward
+	}
+}
+
+// WithEnableExperimentalDispatchPeerCache returns an option that can set EnableExperimentalDispatchPeerCache on a Config
+func WithEnableExperimentalDispatchPeerCache(enableExperimentalDispatchPeerCache bool) ConfigOption {
+	return func(c *Config) {
+		c.EnableExperimentalDispatchPeerCache = enableExperimentalDispatchPeerCache
+	}
+}
+
+// WithEnableExperimentalResumableLookupCache returns an option that can set EnableExperimentalResumableLookupCache on a Config
+func WithEnableExperimentalResumableLookupCache(enableExperimentalResumableLookupCache bool) ConfigOption {
+	return func(c *Config) {
+		c.EnableExperimentalResumableLookupCache = enableExperimentalResumableLookupCache
+	}
+}
+
+// WithEnableExperimentalAdaptiveDispatchConcurrency returns an option that can set EnableExperimentalAdaptiveDispatchConcurrency on a Config
+func WithEnableExperimentalAdaptiveDispatchConcurrency(enableExperimentalAdaptiveDispatchConcurrency bool) ConfigOption {
+	return func(c *Config) {
+		c.EnableExperimentalAdaptiveDispatchConcurrency = enableExperimentalAdaptiveDispatchConcurrency
+	}
+}
+
 // WithDisableV1SchemaAPI returns an option that can set DisableV1SchemaAPI on a Config
 func WithDisableV1SchemaAPI(disableV1SchemaAPI bool) ConfigOption {
 	return func(c *Config) {
@@ -650,6 +769,20 @@ func WithMismatchZedTokenBehavior(mismatchZedTokenBehavior string) ConfigOption
 	}
 }
 
+// WithEnableExperimentalRelationshipCounterMaintenance returns an option that can set EnableExperimentalRelationshipCounterMaintenance on a Config
+func WithEnableExperimentalRelationshipCounterMaintenance(enableExperimentalRelationshipCounterMaintenance bool) ConfigOption {
+	return func(c *Config) {
+		c.EnableExperimentalRelationshipCounterMaintenance = enableExperimentalRelationshipCounterMaintenance
+	}
+}
+
+// WithRelationshipCounterFlushInterval returns an option that can set RelationshipCounterFlushInterval on a Config
+func WithRelationshipCounterFlushInterval(relationshipCounterFlushInterval time.Duration) ConfigOption {
+	return func(c *Config) {
+		c.RelationshipCounterFlushInterval = relationshipCounterFlushInterval
+	}
+}
+
 // WithMetricsAPI returns an option that can set MetricsAPI on a Config
 func WithMetricsAPI(metricsAPI util.HTTPServerConfig) ConfigOption {
 	return func(c *Config) {
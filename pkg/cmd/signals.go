@@ -8,11 +8,13 @@ import (
 	"time"
 
 	log "github.com/authzed/spicedb/internal/logging"
+	"github.com/authzed/spicedb/pkg/cmd/termination"
 )
 
 // SignalContextWithGracePeriod creates a new context that will be cancelled
 // when an interrupt/SIGTERM signal is received and the provided grace period
-// subsequently finishes.
+// subsequently finishes. Termination handlers are run upon receiving the
+// signal, before the grace period begins.
 func SignalContextWithGracePeriod(ctx context.Context, gracePeriod time.Duration) context.Context {
 	newCtx, cancelfn := context.WithCancel(ctx)
 	go func() {
@@ -20,6 +22,10 @@ func SignalContextWithGracePeriod(ctx context.Context, gracePeriod time.Duration
 		<-signalctx.Done()
 		log.Ctx(ctx).Info().Msg("received interrupt")
 
+		if newCtx.Err() == nil {
+			termination.RunHandlers(newCtx)
+		}
+
 		if gracePeriod > 0 {
 			interruptGrace, _ := signal.NotifyContext(context.Background(), os.Interrupt)
 			graceTimer := time.NewTimer(gracePeriod)
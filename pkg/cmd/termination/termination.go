@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"sync"
 
 	"github.com/jzelinskie/cobrautil/v2"
 	"github.com/spf13/cobra"
@@ -73,3 +74,50 @@ func RegisterFlags(flagset *flag.FlagSet) {
 		"local path to the termination log file, which contains a JSON payload to surface as reason for termination",
 	)
 }
+
+// Handler is a function run when the process receives a termination signal, before its shutdown
+// grace period begins.
+type Handler func(ctx context.Context) error
+
+var (
+	handlersMu sync.Mutex
+	handlers   = map[*Handler]struct{}{}
+)
+
+// RegisterHandler registers a handler to be run when the process receives a termination signal,
+// returning a function which unregisters it.
+func RegisterHandler(handler Handler) (unregister func()) {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+
+	registered := &handler
+	handlers[registered] = struct{}{}
+	return func() {
+		handlersMu.Lock()
+		defer handlersMu.Unlock()
+		delete(handlers, registered)
+	}
+}
+
+// RunHandlers runs all registered handlers concurrently, waiting for them to complete. Errors
+// returned by handlers are logged, as termination proceeds regardless.
+func RunHandlers(ctx context.Context) {
+	handlersMu.Lock()
+	toRun := make([]Handler, 0, len(handlers))
+	for handler := range handlers {
+		toRun = append(toRun, *handler)
+	}
+	handlersMu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, handler := range toRun {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := handler(ctx); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("termination handler failed")
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -1,10 +1,12 @@
 package termination
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -91,3 +93,26 @@ func (s *testValue) Type() string {
 func (s *testValue) String() string {
 	return string(*s)
 }
+
+func TestRunHandlers(t *testing.T) {
+	var first, second atomic.Int32
+	unregisterFirst := RegisterHandler(func(context.Context) error {
+		first.Add(1)
+		return nil
+	})
+	unregisterSecond := RegisterHandler(func(context.Context) error {
+		second.Add(1)
+		return errors.New("failed")
+	})
+	t.Cleanup(unregisterSecond)
+
+	RunHandlers(t.Context())
+	require.Equal(t, int32(1), first.Load())
+	require.Equal(t, int32(1), second.Load())
+
+	// Unregistered handlers are no longer run.
+	unregisterFirst()
+	RunHandlers(t.Context())
+	require.Equal(t, int32(1), first.Load())
+	require.Equal(t, int32(2), second.Load())
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/authzed/spicedb/pkg/composableschemadsl/dslshape"
 	"github.com/authzed/spicedb/pkg/composableschemadsl/input"
+	"github.com/authzed/spicedb/pkg/composableschemadsl/parser"
 )
 
 // DSLNode is a node in the DSL AST.
@@ -142,3 +143,95 @@ func (w wrapper) Lookup(predicateName string) (DSLNode, error) {
 
 	return wrapper{found}, nil
 }
+
+// ErrorNodeChain parses the given schema, which may be only partially written, and returns the
+// chain of the error node at which parsing failed, and its parents, if the schema could not be
+// fully parsed. Imports are not followed.
+func ErrorNodeChain(source input.Source, schemaString string) (*NodeChain, error) {
+	root := parser.Parse(createAstNode, source, schemaString).(*dslNode)
+	found, err := errorNodeChain(root)
+	if err != nil {
+		return nil, err
+	}
+
+	if found == nil {
+		return nil, nil
+	}
+
+	startRune, err := found[0].GetInt(dslshape.NodePredicateStartRune)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NodeChain{nodes: found, runePosition: startRune}, nil
+}
+
+// errorNodeChain returns the chain of the most deeply nested error node under the node, which
+// is that closest to the point at which parsing failed.
+func errorNodeChain(node *dslNode) ([]DSLNode, error) {
+	if node.GetType() == dslshape.NodeTypeError {
+		return []DSLNode{wrapper{node}}, nil
+	}
+
+	var deepest []DSLNode
+	for _, child := range node.AllSubNodes() {
+		childChain, err := errorNodeChain(child)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(childChain) > len(deepest) {
+			deepest = childChain
+		}
+	}
+
+	if deepest == nil {
+		return nil, nil
+	}
+
+	return append(deepest, wrapper{node}), nil
+}
+
+// ImportStatement is an import statement found in a schema.
+type ImportStatement struct {
+	// Path is the path of the imported file, relative to the folder of the schema.
+	Path string
+
+	// StartRune is the position of the first rune of the statement.
+	StartRune int
+
+	// EndRune is the position of the last rune of the statement.
+	EndRune int
+}
+
+// ImportStatements parses the given schema, which may be only partially written, and returns the
+// import statements found within it, in the order in which they are found. Imports are not
+// followed.
+func ImportStatements(source input.Source, schemaString string) ([]ImportStatement, error) {
+	root := parser.Parse(createAstNode, source, schemaString).(*dslNode)
+
+	var statements []ImportStatement
+	for _, node := range root.GetChildren() {
+		if node.GetType() != dslshape.NodeTypeImport {
+			continue
+		}
+
+		importPath, err := node.GetString(dslshape.NodeImportPredicatePath)
+		if err != nil {
+			continue
+		}
+
+		startRune, err := node.GetInt(dslshape.NodePredicateStartRune)
+		if err != nil {
+			return nil, err
+		}
+
+		endRune, err := node.GetInt(dslshape.NodePredicateEndRune)
+		if err != nil {
+			return nil, err
+		}
+
+		statements = append(statements, ImportStatement{Path: importPath, StartRune: startRune, EndRune: endRune})
+	}
+	return statements, nil
+}
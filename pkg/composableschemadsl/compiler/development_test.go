@@ -411,3 +411,21 @@ func TestNodeChainString(t *testing.T) {
 		})
 	}
 }
+
+func TestImportStatements(t *testing.T) {
+	t.Parallel()
+
+	schemaString := `import "users.zed"
+import "groups/groups.zed"
+
+definition document {
+	relation viewer: user
+}`
+
+	statements, err := ImportStatements(input.Source("test"), schemaString)
+	require.NoError(t, err)
+	require.Equal(t, []ImportStatement{
+		{Path: "users.zed", StartRune: 0, EndRune: 17},
+		{Path: "groups/groups.zed", StartRune: 19, EndRune: 44},
+	}, statements)
+}
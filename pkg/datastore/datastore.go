@@ -190,12 +190,9 @@ func (rf RelationshipsFilter) Test(relationship tuple.Relationship) bool {
 		return false
 	}
 
-	if len(rf.OptionalSubjectsSelectors) > 0 {
-		for _, selector := range rf.OptionalSubjectsSelectors {
-			if selector.Test(relationship.Subject) {
-				return true
-			}
-		}
+	if len(rf.OptionalSubjectsSelectors) > 0 && !slices.ContainsFunc(rf.OptionalSubjectsSelectors, func(selector SubjectsSelector) bool {
+		return selector.Test(relationship.Subject)
+	}) {
 		return false
 	}
 
@@ -317,16 +314,35 @@ func RelationshipsFilterFromCoreFilter(filter *core.RelationshipFilter) (Relatio
 		return RelationshipsFilter{}, errors.New("cannot specify both OptionalResourceId and OptionalResourceIDPrefix")
 	}
 
-	if filter.ResourceType == "" && filter.OptionalRelation == "" && len(resourceIds) == 0 && filter.OptionalResourceIdPrefix == "" && len(subjectsSelectors) == 0 {
+	if filter.ResourceType == "" && filter.OptionalRelation == "" && len(resourceIds) == 0 && filter.OptionalResourceIdPrefix == "" && len(subjectsSelectors) == 0 && filter.OptionalCaveatName == "" {
 		return RelationshipsFilter{}, errors.New("at least one filter field must be set")
 	}
 
+	var caveatNameFilter CaveatNameFilter
+	if filter.OptionalCaveatName != "" {
+		caveatNameFilter = WithCaveatName(filter.OptionalCaveatName)
+	}
+
+	var expirationOption ExpirationFilterOption
+	switch filter.OptionalExpirationFilter {
+	case core.RelationshipFilter_EXPIRATION_FILTER_UNSPECIFIED:
+		expirationOption = ExpirationFilterOptionNone
+	case core.RelationshipFilter_EXPIRATION_FILTER_HAS_EXPIRATION:
+		expirationOption = ExpirationFilterOptionHasExpiration
+	case core.RelationshipFilter_EXPIRATION_FILTER_NO_EXPIRATION:
+		expirationOption = ExpirationFilterOptionNoExpiration
+	default:
+		return RelationshipsFilter{}, fmt.Errorf("unknown expiration filter: %v", filter.OptionalExpirationFilter)
+	}
+
 	return RelationshipsFilter{
 		OptionalResourceType:      filter.ResourceType,
 		OptionalResourceIds:       resourceIds,
 		OptionalResourceIDPrefix:  filter.OptionalResourceIdPrefix,
 		OptionalResourceRelation:  filter.OptionalRelation,
 		OptionalSubjectsSelectors: subjectsSelectors,
+		OptionalCaveatNameFilter:  caveatNameFilter,
+		OptionalExpirationOption:  expirationOption,
 	}, nil
 }
 
@@ -519,6 +535,7 @@ type RevisionedNamespace = RevisionedDefinition[*core.NamespaceDefinition]
 type Reader interface {
 	CaveatReader
 	CounterReader
+	SchemaHistoryReader
 
 	// QueryRelationships reads relationships, starting from the resource side.
 	QueryRelationships(
@@ -562,6 +579,7 @@ type ReadWriteTransaction interface {
 	Reader
 	CaveatStorer
 	CounterRegisterer
+	SchemaHistoryWriter
 
 	// WriteRelationships takes a list of tuple mutations and applies them to the datastore.
 	WriteRelationships(ctx context.Context, mutations []tuple.RelationshipUpdate) error
@@ -932,6 +950,12 @@ type Stats struct {
 	// table statistics.
 	EstimatedRelationshipCount uint64
 
+	// RelationshipCountsByType, if non-nil, is a best-guess estimate of the number of
+	// relationships for each resource object type (namespace) in the datastore. Datastores
+	// which cannot compute it cheaply leave it nil; currently, only the memdb and SQLite
+	// datastores compute it.
+	RelationshipCountsByType map[string]uint64
+
 	// ObjectTypeStatistics returns a slice element for each object type (namespace)
 	// stored in the datastore.
 	ObjectTypeStatistics []ObjectTypeStat
@@ -9,6 +9,7 @@ import (
 	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
 
 	"github.com/authzed/spicedb/pkg/datastore/options"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
 	"github.com/authzed/spicedb/pkg/tuple"
 )
 
@@ -567,6 +568,28 @@ func TestRelationshipsFilterTest(t *testing.T) {
 			relationshipString: "foo:something#viewer@user:fred",
 			expected:           true,
 		},
+		{
+			name: "subject filter match with caveat mismatch",
+			filter: RelationshipsFilter{
+				OptionalSubjectsSelectors: []SubjectsSelector{
+					{OptionalSubjectType: "user"},
+				},
+				OptionalCaveatNameFilter: WithCaveatName("somecaveat"),
+			},
+			relationshipString: "foo:something#viewer@user:fred[othercaveat]",
+			expected:           false,
+		},
+		{
+			name: "subject filter match with expiration mismatch",
+			filter: RelationshipsFilter{
+				OptionalSubjectsSelectors: []SubjectsSelector{
+					{OptionalSubjectType: "user"},
+				},
+				OptionalExpirationOption: ExpirationFilterOptionHasExpiration,
+			},
+			relationshipString: "foo:something#viewer@user:fred",
+			expected:           false,
+		},
 	}
 
 	for _, tc := range tcs {
@@ -578,6 +601,71 @@ func TestRelationshipsFilterTest(t *testing.T) {
 	}
 }
 
+func TestRelationshipsFilterFromCoreFilter(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         *core.RelationshipFilter
+		expected      RelationshipsFilter
+		expectedError string
+	}{
+		{
+			"empty",
+			&core.RelationshipFilter{},
+			RelationshipsFilter{},
+			"at least one filter field must be set",
+		},
+		{
+			"only expiration",
+			&core.RelationshipFilter{OptionalExpirationFilter: core.RelationshipFilter_EXPIRATION_FILTER_HAS_EXPIRATION},
+			RelationshipsFilter{},
+			"at least one filter field must be set",
+		},
+		{
+			"resource type",
+			&core.RelationshipFilter{ResourceType: "doc"},
+			RelationshipsFilter{OptionalResourceType: "doc"},
+			"",
+		},
+		{
+			"caveat name",
+			&core.RelationshipFilter{OptionalCaveatName: "somecaveat"},
+			RelationshipsFilter{OptionalCaveatNameFilter: WithCaveatName("somecaveat")},
+			"",
+		},
+		{
+			"has expiration",
+			&core.RelationshipFilter{ResourceType: "doc", OptionalExpirationFilter: core.RelationshipFilter_EXPIRATION_FILTER_HAS_EXPIRATION},
+			RelationshipsFilter{OptionalResourceType: "doc", OptionalExpirationOption: ExpirationFilterOptionHasExpiration},
+			"",
+		},
+		{
+			"no expiration",
+			&core.RelationshipFilter{ResourceType: "doc", OptionalExpirationFilter: core.RelationshipFilter_EXPIRATION_FILTER_NO_EXPIRATION},
+			RelationshipsFilter{OptionalResourceType: "doc", OptionalExpirationOption: ExpirationFilterOptionNoExpiration},
+			"",
+		},
+		{
+			"unknown expiration",
+			&core.RelationshipFilter{ResourceType: "doc", OptionalExpirationFilter: 42},
+			RelationshipsFilter{},
+			"unknown expiration filter",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			computed, err := RelationshipsFilterFromCoreFilter(test.input)
+			if test.expectedError != "" {
+				require.ErrorContains(t, err, test.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, test.expected, computed)
+		})
+	}
+}
+
 func TestUnwrapAs(t *testing.T) {
 	result := UnwrapAs[error](nil)
 	require.NoError(t, result)
@@ -239,6 +239,21 @@ func (mr *MockReaderMockRecorder) ReadNamespaceByName(ctx, nsName any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadNamespaceByName", reflect.TypeOf((*MockReader)(nil).ReadNamespaceByName), ctx, nsName)
 }
 
+// ReadSchemaHistory mocks base method.
+func (m *MockReader) ReadSchemaHistory(ctx context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSchemaHistory", ctx, beforeRevision, limit)
+	ret0, _ := ret[0].([]datastore.SchemaHistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSchemaHistory indicates an expected call of ReadSchemaHistory.
+func (mr *MockReaderMockRecorder) ReadSchemaHistory(ctx, beforeRevision, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSchemaHistory", reflect.TypeOf((*MockReader)(nil).ReadSchemaHistory), ctx, beforeRevision, limit)
+}
+
 // ReverseQueryRelationships mocks base method.
 func (m *MockReader) ReverseQueryRelationships(ctx context.Context, subjectsFilter datastore.SubjectsFilter, arg2 ...options.ReverseQueryOptionsOption) (datastore.RelationshipIterator, error) {
 	m.ctrl.T.Helper()
@@ -489,6 +504,21 @@ func (mr *MockReadWriteTransactionMockRecorder) ReadNamespaceByName(ctx, nsName
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadNamespaceByName", reflect.TypeOf((*MockReadWriteTransaction)(nil).ReadNamespaceByName), ctx, nsName)
 }
 
+// ReadSchemaHistory mocks base method.
+func (m *MockReadWriteTransaction) ReadSchemaHistory(ctx context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadSchemaHistory", ctx, beforeRevision, limit)
+	ret0, _ := ret[0].([]datastore.SchemaHistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadSchemaHistory indicates an expected call of ReadSchemaHistory.
+func (mr *MockReadWriteTransactionMockRecorder) ReadSchemaHistory(ctx, beforeRevision, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadSchemaHistory", reflect.TypeOf((*MockReadWriteTransaction)(nil).ReadSchemaHistory), ctx, beforeRevision, limit)
+}
+
 // RegisterCounter mocks base method.
 func (m *MockReadWriteTransaction) RegisterCounter(ctx context.Context, name string, filter *corev1.RelationshipFilter) error {
 	m.ctrl.T.Helper()
@@ -598,6 +628,20 @@ func (mr *MockReadWriteTransactionMockRecorder) WriteRelationships(ctx, mutation
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteRelationships", reflect.TypeOf((*MockReadWriteTransaction)(nil).WriteRelationships), ctx, mutations)
 }
 
+// WriteSchemaHistoryEntry mocks base method.
+func (m *MockReadWriteTransaction) WriteSchemaHistoryEntry(ctx context.Context, entry *corev1.SchemaHistoryEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteSchemaHistoryEntry", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WriteSchemaHistoryEntry indicates an expected call of WriteSchemaHistoryEntry.
+func (mr *MockReadWriteTransactionMockRecorder) WriteSchemaHistoryEntry(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteSchemaHistoryEntry", reflect.TypeOf((*MockReadWriteTransaction)(nil).WriteSchemaHistoryEntry), ctx, entry)
+}
+
 // MockBulkWriteRelationshipSource is a mock of BulkWriteRelationshipSource interface.
 type MockBulkWriteRelationshipSource struct {
 	ctrl     *gomock.Controller
@@ -143,6 +143,10 @@ func (m *mockedReader) LookupCounters(ctx context.Context) ([]datastore.Relation
 	panic("not implemented")
 }
 
+func (m *mockedReader) ReadSchemaHistory(ctx context.Context, beforeRevision datastore.Revision, limit uint64) ([]datastore.SchemaHistoryEntry, error) {
+	panic("not implemented")
+}
+
 func (m *mockedReader) ReadCaveatByName(_ context.Context, _ string) (caveat *core.CaveatDefinition, lastWritten datastore.Revision, err error) {
 	panic("not implemented")
 }
@@ -0,0 +1,35 @@
+package datastore
+
+import (
+	"context"
+
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+)
+
+// SchemaHistoryEntry is an entry in the log of the schema writes made to the datastore.
+type SchemaHistoryEntry struct {
+	// Entry holds the schema written, the changes made by it and the caller which wrote it.
+	Entry *core.SchemaHistoryEntry
+
+	// WrittenRevision is the revision of the transaction in which the entry was written.
+	WrittenRevision Revision
+}
+
+// SchemaHistoryReader is an interface for reading the log of schema writes.
+type SchemaHistoryReader interface {
+	// ReadSchemaHistory returns the entries of the schema history written at or before the
+	// revision of the reader, newest first. If beforeRevision is not NoRevision, only the
+	// entries written before it are returned. If limit is not zero, at most limit entries are
+	// returned.
+	//
+	// Unlike the definitions of the schema, the entries of the schema history are never
+	// garbage collected.
+	ReadSchemaHistory(ctx context.Context, beforeRevision Revision, limit uint64) ([]SchemaHistoryEntry, error)
+}
+
+// SchemaHistoryWriter is an interface for appending to the log of schema writes.
+type SchemaHistoryWriter interface {
+	// WriteSchemaHistoryEntry appends the entry to the schema history, at the revision of the
+	// transaction. At most one entry may be written per transaction.
+	WriteSchemaHistoryEntry(ctx context.Context, entry *core.SchemaHistoryEntry) error
+}
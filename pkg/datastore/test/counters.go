@@ -68,6 +68,74 @@ func RelationshipCounterOverExpiredTest(t *testing.T, tester DatastoreTester) {
 	require.Equal(t, expectedCount, count)
 }
 
+func RelationshipCounterWithCaveatAndExpirationFilterTest(t *testing.T, tester DatastoreTester) {
+	rawDS, err := tester.New(0, veryLargeGCInterval, veryLargeGCWindow, 1)
+	require.NoError(t, err)
+
+	ds, _ := testfixtures.StandardDatastoreWithData(rawDS, require.New(t))
+
+	filters := map[string]*core.RelationshipFilter{
+		"caveated": {
+			ResourceType:       testfixtures.DocumentNS.Name,
+			OptionalCaveatName: "test",
+		},
+		"expiring": {
+			ResourceType:             testfixtures.DocumentNS.Name,
+			OptionalExpirationFilter: core.RelationshipFilter_EXPIRATION_FILTER_HAS_EXPIRATION,
+		},
+		"nonexpiring": {
+			ResourceType:             testfixtures.DocumentNS.Name,
+			OptionalExpirationFilter: core.RelationshipFilter_EXPIRATION_FILTER_NO_EXPIRATION,
+		},
+	}
+
+	_, err = ds.ReadWriteTx(t.Context(), func(ctx context.Context, tx datastore.ReadWriteTransaction) error {
+		for name, filter := range filters {
+			if err := tx.RegisterCounter(ctx, name, filter); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	updatedRev, err := ds.ReadWriteTx(t.Context(), func(ctx context.Context, tx datastore.ReadWriteTransaction) error {
+		return tx.WriteRelationships(ctx, []tuple.RelationshipUpdate{
+			tuple.Touch(tuple.MustParse("document:somedoc#expiring_viewer@user:tom[expiration:2020-01-01T00:00:00Z]")),
+			tuple.Touch(tuple.MustParse("document:somedoc#expiring_viewer@user:fred[expiration:2320-01-01T00:00:00Z]")),
+			tuple.Touch(tuple.MustParse("document:somedoc#caveated_viewer@user:fred[test]")),
+		})
+	})
+	require.NoError(t, err)
+
+	reader := ds.SnapshotReader(updatedRev)
+	iter, err := reader.QueryRelationships(t.Context(), datastore.RelationshipsFilter{
+		OptionalResourceType: testfixtures.DocumentNS.Name,
+	}, options.WithQueryShape(queryshape.FindResourceOfType))
+	require.NoError(t, err)
+
+	expectedCounts := map[string]int{}
+	for rel, err := range iter {
+		require.NoError(t, err)
+		for name, filter := range filters {
+			relFilter, err := datastore.RelationshipsFilterFromCoreFilter(filter)
+			require.NoError(t, err)
+			if relFilter.Test(rel) {
+				expectedCounts[name]++
+			}
+		}
+	}
+
+	require.Equal(t, 1, expectedCounts["expiring"])
+	require.Positive(t, expectedCounts["caveated"])
+
+	for name := range filters {
+		count, err := reader.CountRelationships(t.Context(), name)
+		require.NoError(t, err)
+		require.Equal(t, expectedCounts[name], count, "mismatch for counter %s", name)
+	}
+}
+
 func RegisterRelationshipCountersInParallelTest(t *testing.T, tester DatastoreTester) {
 	rawDS, err := tester.New(0, veryLargeGCInterval, veryLargeGCWindow, 1)
 	require.NoError(t, err)
@@ -0,0 +1,91 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/pkg/datastore"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+)
+
+func SchemaHistoryTest(t *testing.T, tester DatastoreTester) {
+	ds, err := tester.New(0, veryLargeGCInterval, veryLargeGCWindow, 1)
+	require.NoError(t, err)
+
+	ctx := t.Context()
+
+	// Ensure the history is initially empty.
+	headRev, err := ds.HeadRevision(ctx)
+	require.NoError(t, err)
+
+	entries, err := ds.SnapshotReader(headRev).ReadSchemaHistory(ctx, datastore.NoRevision, 0)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	schemas := []string{
+		"definition user {}",
+		"definition user {}\n\ndefinition document {}",
+		"definition user {}\n\ndefinition folder {}",
+	}
+
+	writtenRevs := make([]datastore.Revision, 0, len(schemas))
+	for _, schemaText := range schemas {
+		rev, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+			return rwt.WriteSchemaHistoryEntry(ctx, &core.SchemaHistoryEntry{
+				SchemaText: schemaText,
+				Caller:     "tester",
+				Deltas: []*core.SchemaHistoryDelta{
+					{
+						DefinitionKind: core.SchemaHistoryDelta_DEFINITION_KIND_OBJECT,
+						DefinitionName: "user",
+						DeltaType:      "namespace-added",
+						ChangeClass:    "additive",
+					},
+				},
+			})
+		})
+		require.NoError(t, err)
+		writtenRevs = append(writtenRevs, rev)
+	}
+
+	schemaTexts := func(entries []datastore.SchemaHistoryEntry) []string {
+		texts := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			texts = append(texts, entry.Entry.SchemaText)
+		}
+		return texts
+	}
+
+	// Read the full history, newest first.
+	reader := ds.SnapshotReader(writtenRevs[2])
+	entries, err = reader.ReadSchemaHistory(ctx, datastore.NoRevision, 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{schemas[2], schemas[1], schemas[0]}, schemaTexts(entries))
+	require.Equal(t, "tester", entries[0].Entry.Caller)
+	require.Len(t, entries[0].Entry.Deltas, 1)
+	require.Equal(t, "user", entries[0].Entry.Deltas[0].DefinitionName)
+
+	// Read the history as of an earlier revision.
+	entries, err = ds.SnapshotReader(writtenRevs[1]).ReadSchemaHistory(ctx, datastore.NoRevision, 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{schemas[1], schemas[0]}, schemaTexts(entries))
+
+	// Page through the history.
+	entries, err = reader.ReadSchemaHistory(ctx, datastore.NoRevision, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{schemas[2]}, schemaTexts(entries))
+
+	entries, err = reader.ReadSchemaHistory(ctx, entries[0].WrittenRevision, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{schemas[1]}, schemaTexts(entries))
+
+	entries, err = reader.ReadSchemaHistory(ctx, entries[0].WrittenRevision, 5)
+	require.NoError(t, err)
+	require.Equal(t, []string{schemas[0]}, schemaTexts(entries))
+
+	entries, err = reader.ReadSchemaHistory(ctx, entries[0].WrittenRevision, 5)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+}
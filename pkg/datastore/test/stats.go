@@ -44,6 +44,11 @@ func StatsTest(t *testing.T, tester DatastoreTester) {
 		require.Len(stats.ObjectTypeStatistics, 3, "must report object stats")
 		require.Positive(stats.EstimatedRelationshipCount, "must report some relationships")
 
+		if stats.RelationshipCountsByType != nil {
+			require.Positive(stats.RelationshipCountsByType["document"], "must report relationships by type")
+			require.NotContains(stats.RelationshipCountsByType, "user", "must not report types without relationships")
+		}
+
 		newStats, err := ds.Statistics(ctx)
 		require.NoError(err)
 		require.Equal(newStats.UniqueID, stats.UniqueID, "unique ID must be stable")
@@ -0,0 +1,217 @@
+package development
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/authzed/spicedb/pkg/composableschemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/composableschemadsl/dslshape"
+	composableinput "github.com/authzed/spicedb/pkg/composableschemadsl/input"
+	"github.com/authzed/spicedb/pkg/schema"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+)
+
+// CompletionCandidate is a candidate name for completion at a position in a schema.
+type CompletionCandidate struct {
+	// Name is the name of the definition, caveat, relation or permission.
+	Name string
+
+	// ReferenceType is the type of the candidate, which is one of definition, caveat,
+	// relation or permission.
+	ReferenceType ReferenceType
+
+	// DefinitionName is the name of the definition under which the relation or permission
+	// is found, if any.
+	DefinitionName string
+}
+
+var (
+	// partialNamePattern matches the partially written name found before a position, if any.
+	partialNamePattern = regexp.MustCompile(`[a-z0-9_/]*$`)
+
+	// arrowPattern matches an arrow, or a functioned arrow, and the relation on its left side.
+	arrowPattern = regexp.MustCompile(`([a-z][a-z0-9_]*)\s*(->|\.any\(|\.all\()$`)
+)
+
+// CompletionsAtPosition returns the candidates for completion at the given position in the
+// schema, which may be only partially written. Candidates are returned for the types of a
+// relation, the relations of a subject type, the caveat of a type, and the relations and
+// permissions referenced by the expression of a permission, including those on the right side
+// of an arrow. Candidates are filtered by the name partially written at the position, if any.
+//
+// The schema is compiled as a composable schema, with its imports resolved relative to the
+// source folder, so that the definitions and caveats imported are also candidates.
+func CompletionsAtPosition(schemaString string, sourceFolder string, position input.Position) ([]CompletionCandidate, error) {
+	mapper := input.CreateSourcePositionMapper([]byte(schemaString))
+	runePosition, err := mapper.LineAndColToRunePosition(position.LineNumber, position.ColumnPosition)
+	if err != nil {
+		return nil, err
+	}
+
+	runes := []rune(schemaString)
+	runePosition = min(runePosition, len(runes))
+
+	// Determine the context of the position from the tree parsed up to the name being written,
+	// at which parsing is expected to fail.
+	beforePosition := string(runes[:runePosition])
+	partialName := partialNamePattern.FindString(beforePosition)
+	beforeName := strings.TrimSuffix(beforePosition, partialName)
+
+	nodeChain, err := compiler.ErrorNodeChain(composableinput.Source(schemaSource), beforeName)
+	if err != nil {
+		return nil, err
+	}
+
+	if nodeChain == nil {
+		return nil, nil
+	}
+
+	compiled := compileForCompletion(schemaString, sourceFolder, position.LineNumber)
+	if compiled == nil {
+		return nil, nil
+	}
+
+	ts := schema.NewTypeSystem(schema.ResolverForPredefinedDefinitions(schema.PredefinedElements{
+		Definitions: compiled.ObjectDefinitions,
+		Caveats:     compiled.CaveatDefinitions,
+	}))
+	trimmed := strings.TrimRightFunc(beforeName, unicode.IsSpace)
+
+	var candidates []CompletionCandidate
+	switch {
+	case nodeChain.FindNodeOfType(dslshape.NodeTypeCaveatReference) != nil:
+		for _, caveatDef := range compiled.CaveatDefinitions {
+			candidates = append(candidates, CompletionCandidate{Name: caveatDef.Name, ReferenceType: ReferenceTypeCaveat})
+		}
+
+	case nodeChain.FindNodeOfType(dslshape.NodeTypeSpecificTypeReference) != nil && strings.HasSuffix(trimmed, "#"):
+		defName, err := nodeChain.FindNodeOfType(dslshape.NodeTypeSpecificTypeReference).GetString(dslshape.NodeSpecificReferencePredicateType)
+		if err != nil {
+			return nil, nil
+		}
+
+		candidates = relationCandidates(ts, defName, "")
+
+	case nodeChain.FindNodeOfType(dslshape.NodeTypeTypeReference) != nil:
+		for _, def := range compiled.ObjectDefinitions {
+			candidates = append(candidates, CompletionCandidate{Name: def.Name, ReferenceType: ReferenceTypeDefinition})
+		}
+
+	case nodeChain.FindNodeOfType(dslshape.NodeTypePermission) != nil:
+		defNode := nodeChain.FindNodeOfType(dslshape.NodeTypeDefinition)
+		if defNode == nil {
+			return nil, nil
+		}
+
+		defName, err := defNode.GetString(dslshape.NodeDefinitionPredicateName)
+		if err != nil {
+			return nil, nil
+		}
+
+		if matches := arrowPattern.FindStringSubmatch(trimmed); matches != nil {
+			candidates = arrowCandidates(ts, defName, matches[1])
+			break
+		}
+
+		// A permission cannot reference itself.
+		permissionName, _ := nodeChain.FindNodeOfType(dslshape.NodeTypePermission).GetString(dslshape.NodePredicateName)
+		candidates = relationCandidates(ts, defName, permissionName)
+	}
+
+	filtered := make([]CompletionCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate.Name, partialName) {
+			filtered = append(filtered, candidate)
+		}
+	}
+
+	slices.SortStableFunc(filtered, func(a, b CompletionCandidate) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return filtered, nil
+}
+
+// compileForCompletion compiles the schema for the resolution of completion candidates, with its
+// imports resolved relative to the source folder. As the statement being written is rarely valid,
+// the schema is compiled without the line found at the position if it cannot be compiled in full.
+func compileForCompletion(schemaString string, sourceFolder string, lineNumber int) *compiler.CompiledSchema {
+	compile := func(schemaString string) *compiler.CompiledSchema {
+		compiled, err := compiler.Compile(compiler.InputSchema{
+			Source:       composableinput.Source(schemaSource),
+			SchemaString: schemaString,
+		}, compiler.AllowUnprefixedObjectType(), compiler.SkipValidation(), compiler.SourceFolder(sourceFolder))
+		if err != nil {
+			return nil
+		}
+		return compiled
+	}
+
+	if compiled := compile(schemaString); compiled != nil {
+		return compiled
+	}
+
+	lines := strings.Split(schemaString, "\n")
+	if lineNumber >= len(lines) {
+		return nil
+	}
+
+	lines[lineNumber] = ""
+	return compile(strings.Join(lines, "\n"))
+}
+
+// relationCandidates returns the relations and permissions of the definition as candidates,
+// other than that excluded, if any.
+func relationCandidates(ts *schema.TypeSystem, defName string, excludedName string) []CompletionCandidate {
+	def, err := ts.GetDefinition(context.Background(), defName)
+	if err != nil {
+		return nil
+	}
+
+	candidates := make([]CompletionCandidate, 0, len(def.Namespace().Relation))
+	for _, relation := range def.Namespace().Relation {
+		if relation.Name == excludedName {
+			continue
+		}
+
+		referenceType := ReferenceTypeRelation
+		if def.IsPermission(relation.Name) {
+			referenceType = ReferenceTypePermission
+		}
+
+		candidates = append(candidates, CompletionCandidate{
+			Name:           relation.Name,
+			ReferenceType:  referenceType,
+			DefinitionName: defName,
+		})
+	}
+	return candidates
+}
+
+// arrowCandidates returns the relations and permissions of the subject types of the relation on
+// the left side of an arrow as candidates for its right side.
+func arrowCandidates(ts *schema.TypeSystem, defName string, relationName string) []CompletionCandidate {
+	def, err := ts.GetDefinition(context.Background(), defName)
+	if err != nil {
+		return nil
+	}
+
+	relation, ok := def.GetRelation(relationName)
+	if !ok {
+		return nil
+	}
+
+	var candidates []CompletionCandidate
+	for _, allowed := range relation.GetTypeInformation().GetAllowedDirectRelations() {
+		for _, candidate := range relationCandidates(ts, allowed.Namespace, "") {
+			if !slices.ContainsFunc(candidates, func(existing CompletionCandidate) bool {
+				return existing.Name == candidate.Name
+			}) {
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,129 @@
+package development
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+)
+
+const completionTestSchema = `caveat somecaveat(someparam int) {
+	someparam == 42
+}
+
+caveat othercaveat(someparam int) {
+	someparam == 43
+}
+
+definition user {}
+
+definition organization {
+	relation admin: user
+	permission administer = admin
+}
+
+definition group {
+	relation member: user
+}
+
+definition document {
+	relation parent: group | organization
+	relation editor: user
+	%s
+}`
+
+var completionReferenceTypes = map[ReferenceType]string{
+	ReferenceTypeDefinition: "definition",
+	ReferenceTypeCaveat:     "caveat",
+	ReferenceTypeRelation:   "relation",
+	ReferenceTypePermission: "permission",
+}
+
+func TestCompletionsAtPosition(t *testing.T) {
+	tcs := []struct {
+		name     string
+		line     string
+		expected []string
+	}{
+		{"type of relation", "relation viewer: ", []string{"definition document", "definition group", "definition organization", "definition user"}},
+		{"partial type of relation", "relation viewer: gr", []string{"definition group"}},
+		{"type of relation after union", "relation viewer: user | ", []string{"definition document", "definition group", "definition organization", "definition user"}},
+		{"relation of subject type", "relation viewer: group#", []string{"relation group#member"}},
+		{"caveat of type", "relation viewer: user with ", []string{"caveat othercaveat", "caveat somecaveat"}},
+		{"partial caveat of type", "relation viewer: user with so", []string{"caveat somecaveat"}},
+		{"permission expression", "permission view = ", []string{"relation document#editor", "relation document#parent"}},
+		{"partial permission expression", "permission view = editor + pa", []string{"relation document#parent"}},
+		{"arrow", "permission view = parent->", []string{"relation organization#admin", "permission organization#administer", "relation group#member"}},
+		{"partial arrow", "permission view = parent->adm", []string{"relation organization#admin", "permission organization#administer"}},
+		{"functioned arrow", "permission view = parent.any(", []string{"relation organization#admin", "permission organization#administer", "relation group#member"}},
+		{"arrow of unknown relation", "permission view = unknown->", nil},
+		{"relation name", "relation vie", nil},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			schemaString := strings.Replace(completionTestSchema, "%s", tc.line, 1)
+			position := input.Position{LineNumber: 22, ColumnPosition: len(tc.line) + 1}
+
+			candidates, err := CompletionsAtPosition(schemaString, "", position)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, describeCandidates(candidates))
+		})
+	}
+}
+
+func describeCandidates(candidates []CompletionCandidate) []string {
+	var found []string
+	for _, candidate := range candidates {
+		name := candidate.Name
+		if candidate.DefinitionName != "" {
+			name = candidate.DefinitionName + "#" + name
+		}
+		found = append(found, completionReferenceTypes[candidate.ReferenceType]+" "+name)
+	}
+	return found
+}
+
+func TestCompletionsAtPositionOfImportedNames(t *testing.T) {
+	sourceFolder := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(sourceFolder, "groups.zed"), []byte(`definition user {}
+
+definition group {
+	relation member: user
+}`), 0o600))
+
+	schemaString := `import "groups.zed"
+
+definition document {
+	relation parent: group
+	%s
+}`
+
+	tcs := []struct {
+		name     string
+		line     string
+		expected []string
+	}{
+		{"imported type of relation", "relation viewer: gr", []string{"definition group"}},
+		{"relation of imported subject type", "relation viewer: group#", []string{"relation group#member"}},
+		{"arrow to imported definition", "permission view = parent->", []string{"relation group#member"}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			position := input.Position{LineNumber: 4, ColumnPosition: len(tc.line) + 1}
+			candidates, err := CompletionsAtPosition(strings.Replace(schemaString, "%s", tc.line, 1), sourceFolder, position)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, describeCandidates(candidates))
+		})
+	}
+}
+
+func TestCompletionsAtPositionInvalidPosition(t *testing.T) {
+	_, err := CompletionsAtPosition(completionTestSchema, "", input.Position{LineNumber: 100, ColumnPosition: 0})
+	require.Error(t, err)
+}
@@ -0,0 +1,366 @@
+package development
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/ccoveille/go-safecast/v2"
+
+	log "github.com/authzed/spicedb/internal/logging"
+	corev1 "github.com/authzed/spicedb/pkg/proto/core/v1"
+	devinterface "github.com/authzed/spicedb/pkg/proto/developer/v1"
+	"github.com/authzed/spicedb/pkg/schema"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/dslshape"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+)
+
+// schemaSource is the source under which schemas are compiled for development.
+const schemaSource = input.Source("schema")
+
+var fixerKey = contextKey("fixer")
+
+// schemaFixer computes the fixes suggested for the warnings found in a compiled schema.
+type schemaFixer struct {
+	compiled    *compiler.CompiledSchema
+	occurrences []SymbolOccurrence
+}
+
+func newSchemaFixer(compiled *compiler.CompiledSchema) (*schemaFixer, error) {
+	resolver, err := NewResolver(compiled)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences, err := resolver.SymbolOccurrences(schemaSource)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schemaFixer{compiled: compiled, occurrences: occurrences}, nil
+}
+
+// withFixes returns the warning with the fixes suggested for it, ignoring those which are nil.
+func withFixes(warning *devinterface.DeveloperWarning, fixes ...*devinterface.SuggestedFix) *devinterface.DeveloperWarning {
+	for _, fix := range fixes {
+		if fix != nil {
+			warning.SuggestedFixes = append(warning.SuggestedFixes, fix)
+		}
+	}
+	return warning
+}
+
+// withIgnoreFix returns the warning with the fix disabling the named check for the relation or
+// definition being checked appended to its fixes.
+func withIgnoreFix(ctx context.Context, warning *devinterface.DeveloperWarning, warningName string) *devinterface.DeveloperWarning {
+	fixer, ok := ctx.Value(fixerKey).(*schemaFixer)
+	if !ok {
+		return warning
+	}
+
+	var target interface {
+		GetName() string
+		GetSourcePosition() *corev1.SourcePosition
+	}
+	if relation, ok := ctx.Value(relationKey).(*corev1.Relation); ok {
+		target = relation
+	} else if nsDef, ok := ctx.Value(definitionKey).(*corev1.NamespaceDefinition); ok {
+		target = nsDef
+	} else {
+		return warning
+	}
+
+	ignoreFix, err := fixer.ignoreWarningFix(target.GetSourcePosition(), target.GetName(), warningName)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("warning", warningName).Msg("could not compute fix to ignore warning")
+		return warning
+	}
+	return withFixes(warning, ignoreFix)
+}
+
+// suggestRename returns the fix renaming the relation or permission of the definition, if the
+// fixer found in the context can compute one.
+func suggestRename(ctx context.Context, def *schema.Definition, relationName string, newName string) *devinterface.SuggestedFix {
+	fixer, ok := ctx.Value(fixerKey).(*schemaFixer)
+	if !ok {
+		return nil
+	}
+
+	fix, err := fixer.renameFix(def, relationName, newName)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("relation", relationName).Msg("could not compute fix to rename relation")
+		return nil
+	}
+	return fix
+}
+
+// suggestOperandRemoval returns the fix removing the operand found at the source position from
+// its permission, if the fixer found in the context can compute one.
+func suggestOperandRemoval(ctx context.Context, sourcePosition *corev1.SourcePosition, isArrow bool, description string) *devinterface.SuggestedFix {
+	fixer, ok := ctx.Value(fixerKey).(*schemaFixer)
+	if !ok {
+		return nil
+	}
+
+	fix, err := fixer.removeOperandFix(sourcePosition, isArrow, description)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("could not compute fix to remove operand")
+		return nil
+	}
+	return fix
+}
+
+// ignoreWarningFix returns the fix adding the comment disabling the warning above the
+// named relation, permission or definition declared at the source position.
+func (sf *schemaFixer) ignoreWarningFix(sourcePosition *corev1.SourcePosition, name string, warningName string) (*devinterface.SuggestedFix, error) {
+	if sourcePosition == nil {
+		return nil, nil
+	}
+
+	position, err := toInputPosition(sourcePosition)
+	if err != nil {
+		return nil, err
+	}
+
+	line, err := sf.compiled.TextForLine(schemaSource, position.LineNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// The comment is indented to match the relation, permission or definition.
+	indentation := []rune(line)[:min(position.ColumnPosition, len([]rune(line)))]
+	if strings.TrimSpace(string(indentation)) != "" {
+		return nil, nil
+	}
+
+	edit, err := toSchemaEdit(position, position, "// spicedb-ignore-warning: "+warningName+"\n"+string(indentation))
+	if err != nil {
+		return nil, err
+	}
+
+	return &devinterface.SuggestedFix{
+		Description: fmt.Sprintf("Ignore warning %q for %q", warningName, name),
+		Edits:       []*devinterface.SchemaEdit{edit},
+	}, nil
+}
+
+// renameFix returns the fix renaming the relation or permission of the definition, and all of
+// its references, if the new name is valid and unused and all references can be renamed
+// unambiguously.
+func (sf *schemaFixer) renameFix(def *schema.Definition, relationName string, newName string) (*devinterface.SuggestedFix, error) {
+	if err := (&corev1.Relation{Name: newName}).Validate(); err != nil {
+		return nil, nil
+	}
+
+	if _, ok := def.GetRelation(newName); ok {
+		return nil, nil
+	}
+
+	symbol := SchemaSymbol{ReferenceType: ReferenceTypeRelation, DefinitionName: def.Namespace().Name, RelationName: relationName}
+	if def.IsPermission(relationName) {
+		symbol.ReferenceType = ReferenceTypePermission
+	}
+
+	var renamed []SourceRange
+	for _, occurrence := range sf.occurrences {
+		if occurrence.Symbol == symbol {
+			renamed = append(renamed, occurrence.NameRange)
+		}
+	}
+
+	// An arrow referencing the relation may also reference relations of other definitions,
+	// which would no longer be referenced once renamed.
+	for _, occurrence := range sf.occurrences {
+		if occurrence.Symbol != symbol && containsRange(renamed, occurrence.NameRange) {
+			return nil, nil
+		}
+	}
+
+	edits := make([]*devinterface.SchemaEdit, 0, len(renamed))
+	for _, nameRange := range renamed {
+		edit, err := toSchemaEdit(nameRange.Start, nameRange.End, newName)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, edit)
+	}
+
+	if len(edits) == 0 {
+		return nil, nil
+	}
+
+	return &devinterface.SuggestedFix{
+		Description: fmt.Sprintf("Rename %q to %q", relationName, newName),
+		Edits:       edits,
+	}, nil
+}
+
+// removeOperandFix returns the fix removing the operand of the permission found at the source
+// position, if the operand is found under a union or is excluded, and so can be removed
+// without otherwise changing the permission. The operand is the arrow found at the position if
+// isArrow is true, and the relation or permission found there otherwise.
+func (sf *schemaFixer) removeOperandFix(sourcePosition *corev1.SourcePosition, isArrow bool, description string) (*devinterface.SuggestedFix, error) {
+	if sourcePosition == nil {
+		return nil, nil
+	}
+
+	position, err := toInputPosition(sourcePosition)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeChain, err := compiler.PositionToAstNodeChain(sf.compiled, schemaSource, position)
+	if err != nil || nodeChain == nil {
+		return nil, err
+	}
+
+	operandType := dslshape.NodeTypeIdentifier
+	if isArrow {
+		operandType = dslshape.NodeTypeArrowExpression
+	}
+
+	operand := nodeChain.FindNodeOfType(operandType)
+	expression := nodeChain.ParentOfType(operandType)
+	if operand == nil || expression == nil {
+		return nil, nil
+	}
+
+	operandStart, operandEnd, err := nodeRunes(operand)
+	if err != nil {
+		return nil, err
+	}
+
+	expressionStart, expressionEnd, err := nodeRunes(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	expressionText, err := compiler.NodeText(sf.compiled, expression)
+	if err != nil {
+		return nil, err
+	}
+
+	// The expression is replaced by the text of the other operand, found by skipping the
+	// operator and any parentheses surrounding the removed operand.
+	runes := []rune(expressionText)
+	var remaining string
+	switch {
+	case expression.GetType() == dslshape.NodeTypeUnionExpression && operandStart == expressionStart:
+		index := skipRunes(runes, operandEnd-expressionStart+1, 1, " \t\n)")
+		if index < 0 || index >= len(runes) || runes[index] != '+' {
+			return nil, nil
+		}
+		remaining = strings.TrimLeftFunc(string(runes[index+1:]), unicode.IsSpace)
+
+	case (expression.GetType() == dslshape.NodeTypeUnionExpression || expression.GetType() == dslshape.NodeTypeExclusionExpression) && operandEnd == expressionEnd:
+		index := skipRunes(runes, operandStart-expressionStart-1, -1, " \t\n(")
+		if index < 0 || (runes[index] != '+' && runes[index] != '-') {
+			return nil, nil
+		}
+		remaining = strings.TrimRightFunc(string(runes[:index]), unicode.IsSpace)
+
+	default:
+		return nil, nil
+	}
+
+	start, err := sf.compiled.RunePositionToSourcePosition(schemaSource, expressionStart)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := sf.compiled.RunePositionToSourcePosition(schemaSource, expressionEnd+1)
+	if err != nil {
+		return nil, err
+	}
+
+	edit, err := toSchemaEdit(start, end, remaining)
+	if err != nil {
+		return nil, err
+	}
+
+	return &devinterface.SuggestedFix{
+		Description: description,
+		Edits:       []*devinterface.SchemaEdit{edit},
+	}, nil
+}
+
+// skipRunes returns the index of the first rune found from the index in the given direction
+// which is not one of those skipped, or -1 if none.
+func skipRunes(runes []rune, index int, direction int, skipped string) int {
+	for ; index >= 0 && index < len(runes); index += direction {
+		if !strings.ContainsRune(skipped, runes[index]) {
+			return index
+		}
+	}
+	return -1
+}
+
+func nodeRunes(node compiler.DSLNode) (int, int, error) {
+	start, err := node.GetInt(dslshape.NodePredicateStartRune)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	end, err := node.GetInt(dslshape.NodePredicateEndRune)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return start, end, nil
+}
+
+func containsRange(ranges []SourceRange, sourceRange SourceRange) bool {
+	for _, existing := range ranges {
+		if existing == sourceRange {
+			return true
+		}
+	}
+	return false
+}
+
+func toInputPosition(sourcePosition *corev1.SourcePosition) (input.Position, error) {
+	lineNumber, err := safecast.Convert[int](sourcePosition.ZeroIndexedLineNumber)
+	if err != nil {
+		return input.Position{}, err
+	}
+
+	columnPosition, err := safecast.Convert[int](sourcePosition.ZeroIndexedColumnPosition)
+	if err != nil {
+		return input.Position{}, err
+	}
+
+	return input.Position{LineNumber: lineNumber, ColumnPosition: columnPosition}, nil
+}
+
+// toSchemaEdit returns the edit replacing the text between the zero-indexed positions.
+func toSchemaEdit(start input.Position, end input.Position, newText string) (*devinterface.SchemaEdit, error) {
+	line, err := safecast.Convert[uint32](start.LineNumber + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	column, err := safecast.Convert[uint32](start.ColumnPosition + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	endLine, err := safecast.Convert[uint32](end.LineNumber + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	endColumn, err := safecast.Convert[uint32](end.ColumnPosition + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &devinterface.SchemaEdit{
+		Line:      line,
+		Column:    column,
+		EndLine:   endLine,
+		EndColumn: endColumn,
+		NewText:   newText,
+	}, nil
+}
@@ -0,0 +1,221 @@
+package development
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	developerv1 "github.com/authzed/spicedb/pkg/proto/developer/v1"
+)
+
+func TestSuggestedFixes(t *testing.T) {
+	tcs := []struct {
+		name                string
+		schema              string
+		expectedDescription string
+		expectedSchema      string
+	}{
+		{
+			name: "remove self reference on left of union",
+			schema: `definition test {
+	relation viewer: test
+	permission view = view + viewer
+}`,
+			expectedDescription: `Remove reference to "view"`,
+			expectedSchema: `definition test {
+	relation viewer: test
+	permission view = viewer
+}`,
+		},
+		{
+			name: "remove self reference on right of union",
+			schema: `definition test {
+	relation viewer: test
+	relation editor: test
+	permission view = viewer + (editor & viewer) + view
+}`,
+			expectedDescription: `Remove reference to "view"`,
+			expectedSchema: `definition test {
+	relation viewer: test
+	relation editor: test
+	permission view = viewer + (editor & viewer)
+}`,
+		},
+		{
+			name: "remove excluded self reference",
+			schema: `definition test {
+	relation viewer: test
+	permission view = viewer - view
+}`,
+			expectedDescription: `Remove reference to "view"`,
+			expectedSchema: `definition test {
+	relation viewer: test
+	permission view = viewer
+}`,
+		},
+		{
+			name: "remove unreachable arrow",
+			schema: `definition user {}
+
+definition group {}
+
+definition document {
+	relation viewer: user
+	relation group: group
+	permission view = viewer + group->member
+}`,
+			expectedDescription: "Remove arrow `group->member`",
+			expectedSchema: `definition user {}
+
+definition group {}
+
+definition document {
+	relation viewer: user
+	relation group: group
+	permission view = viewer
+}`,
+		},
+		{
+			name: "remove unreachable functioned arrow",
+			schema: `definition user {}
+
+definition group {}
+
+definition document {
+	relation viewer: user
+	relation group: group
+	permission view = group.any(member) + viewer
+}`,
+			expectedDescription: "Remove arrow `group.any(member)`",
+			expectedSchema: `definition user {}
+
+definition group {}
+
+definition document {
+	relation viewer: user
+	relation group: group
+	permission view = viewer
+}`,
+		},
+		{
+			name: "rename permission referencing parent with its references",
+			schema: `definition user {}
+
+definition document {
+	relation reader: user
+	permission viewer_document = reader
+	permission view = viewer_document
+}
+
+definition folder {
+	relation doc: document
+	permission view = doc->viewer_document
+}`,
+			expectedDescription: `Rename "viewer_document" to "viewer"`,
+			expectedSchema: `definition user {}
+
+definition document {
+	relation reader: user
+	permission viewer = reader
+	permission view = viewer
+}
+
+definition folder {
+	relation doc: document
+	permission view = doc->viewer
+}`,
+		},
+		{
+			name: "ignore warning when the operand cannot be removed",
+			schema: `definition test {
+	relation viewer: test
+	permission view = viewer & view
+}`,
+			expectedDescription: `Ignore warning "permission-references-itself" for "view"`,
+			expectedSchema: `definition test {
+	relation viewer: test
+	// spicedb-ignore-warning: permission-references-itself
+	permission view = viewer & view
+}`,
+		},
+		{
+			name: "ignore warning when the renamed relation already exists",
+			schema: `definition user {}
+
+definition document {
+	relation view: user
+	permission view_document = view
+}`,
+			expectedDescription: `Ignore warning "relation-name-references-parent" for "view_document"`,
+			expectedSchema: `definition user {}
+
+definition document {
+	relation view: user
+	// spicedb-ignore-warning: relation-name-references-parent
+	permission view_document = view
+}`,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			devCtx, devErr, err := NewDevContext(t.Context(), &developerv1.RequestContext{
+				Schema: tc.schema,
+			})
+			require.NoError(t, err)
+			require.Empty(t, devErr)
+
+			warnings, err := GetWarnings(t.Context(), devCtx)
+			require.NoError(t, err)
+			require.Len(t, warnings, 1, "expected exactly one warning")
+			require.NotEmpty(t, warnings[0].SuggestedFixes)
+
+			fix := warnings[0].SuggestedFixes[0]
+			require.Equal(t, tc.expectedDescription, fix.Description)
+
+			updated := applySchemaEdits(t, tc.schema, fix.Edits)
+			require.Equal(t, tc.expectedSchema, updated)
+
+			// The fix must resolve the warning.
+			updatedCtx, devErr, err := NewDevContext(t.Context(), &developerv1.RequestContext{
+				Schema: updated,
+			})
+			require.NoError(t, err)
+			require.Empty(t, devErr)
+
+			updatedWarnings, err := GetWarnings(t.Context(), updatedCtx)
+			require.NoError(t, err)
+			require.Empty(t, updatedWarnings)
+		})
+	}
+}
+
+// applySchemaEdits returns the schema with the edits applied.
+func applySchemaEdits(t *testing.T, schema string, edits []*developerv1.SchemaEdit) string {
+	lines := strings.Split(schema, "\n")
+	offset := func(line uint32, column uint32) int {
+		require.LessOrEqual(t, int(line), len(lines))
+
+		runeOffset := 0
+		for _, previous := range lines[:line-1] {
+			runeOffset += len([]rune(previous)) + 1
+		}
+		return runeOffset + int(column) - 1
+	}
+
+	// Edits are applied from the end of the schema, so that the offsets of those remaining are
+	// unchanged.
+	sorted := slices.Clone(edits)
+	slices.SortFunc(sorted, func(a, b *developerv1.SchemaEdit) int {
+		return offset(b.Line, b.Column) - offset(a.Line, a.Column)
+	})
+
+	runes := []rune(schema)
+	for _, edit := range sorted {
+		start, end := offset(edit.Line, edit.Column), offset(edit.EndLine, edit.EndColumn)
+		runes = slices.Concat(runes[:start], []rune(edit.NewText), runes[end:])
+	}
+	return string(runes)
+}
@@ -0,0 +1,211 @@
+package development
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	yamlv3 "gopkg.in/yaml.v3"
+
+	corev1 "github.com/authzed/spicedb/pkg/proto/core/v1"
+	devinterface "github.com/authzed/spicedb/pkg/proto/developer/v1"
+	"github.com/authzed/spicedb/pkg/schema"
+)
+
+// LintSeverity is the severity with which the warnings raised by a lint rule are reported.
+type LintSeverity string
+
+const (
+	// LintSeverityOff disables the rule.
+	LintSeverityOff LintSeverity = "off"
+
+	// LintSeverityInfo reports the warnings raised by the rule as informational.
+	LintSeverityInfo LintSeverity = "info"
+
+	// LintSeverityWarning reports the warnings raised by the rule as warnings. This is the
+	// default severity of all rules.
+	LintSeverityWarning LintSeverity = "warning"
+
+	// LintSeverityError reports the warnings raised by the rule as errors.
+	LintSeverityError LintSeverity = "error"
+)
+
+var lintSeverities = map[LintSeverity]devinterface.DeveloperWarning_Severity{
+	LintSeverityInfo:    devinterface.DeveloperWarning_INFO,
+	LintSeverityWarning: devinterface.DeveloperWarning_WARNING,
+	LintSeverityError:   devinterface.DeveloperWarning_ERROR,
+}
+
+// LintConfig is the contents of a lint configuration file, such as:
+//
+//	rules:
+//	  relation-name-references-parent: "off"
+//	  arrow-references-relation: error
+type LintConfig struct {
+	// Rules is the severity of each rule, by name. Rules which are not found are reported with
+	// their default severity.
+	Rules map[string]LintSeverity `yaml:"rules"`
+}
+
+// ParseLintConfig parses a lint configuration file.
+func ParseLintConfig(r io.Reader) (LintConfig, error) {
+	decoder := yamlv3.NewDecoder(r)
+	decoder.KnownFields(true)
+
+	var config LintConfig
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return LintConfig{}, fmt.Errorf("failed to parse lint configuration file: %w", err)
+	}
+
+	for name, severity := range config.Rules {
+		if _, ok := lintSeverities[severity]; !ok && severity != LintSeverityOff {
+			return LintConfig{}, fmt.Errorf("rule `%s` has unknown severity `%s`; must be one of `off`, `info`, `warning` or `error`", name, severity)
+		}
+	}
+
+	return config, nil
+}
+
+// LintRules is a set of lint rules run over schemas, along with the severity with which the
+// warnings raised by each are reported.
+type LintRules struct {
+	checks     checks
+	severities map[string]LintSeverity
+}
+
+// DefaultLintRules returns the built-in lint rules, each reported as a warning.
+func DefaultLintRules() *LintRules {
+	return &LintRules{
+		checks: checks{
+			definitionChecks:      slices.Clone(allChecks.definitionChecks),
+			relationChecks:        slices.Clone(allChecks.relationChecks),
+			computedUsersetChecks: slices.Clone(allChecks.computedUsersetChecks),
+			ttuChecks:             slices.Clone(allChecks.ttuChecks),
+		},
+		severities: map[string]LintSeverity{},
+	}
+}
+
+// AddDefinitionCheck adds a custom rule run over each definition.
+func (lr *LintRules) AddDefinitionCheck(check DefinitionCheck) error {
+	if err := lr.ensureUnregistered(check.name); err != nil {
+		return err
+	}
+
+	lr.checks.definitionChecks = append(lr.checks.definitionChecks, check)
+	return nil
+}
+
+// AddRelationCheck adds a custom rule run over each relation and permission.
+func (lr *LintRules) AddRelationCheck(check RelationCheck) error {
+	if err := lr.ensureUnregistered(check.name); err != nil {
+		return err
+	}
+
+	lr.checks.relationChecks = append(lr.checks.relationChecks, check)
+	return nil
+}
+
+// AddComputedUsersetCheck adds a custom rule run over each relation or permission referenced by
+// a permission.
+func (lr *LintRules) AddComputedUsersetCheck(check ComputedUsersetCheck) error {
+	if err := lr.ensureUnregistered(check.name); err != nil {
+		return err
+	}
+
+	lr.checks.computedUsersetChecks = append(lr.checks.computedUsersetChecks, check)
+	return nil
+}
+
+// AddTTUCheck adds a custom rule run over each arrow found under a permission.
+func (lr *LintRules) AddTTUCheck(check TTUCheck) error {
+	if err := lr.ensureUnregistered(check.name); err != nil {
+		return err
+	}
+
+	lr.checks.ttuChecks = append(lr.checks.ttuChecks, check)
+	return nil
+}
+
+// RuleNames returns the names of the rules, sorted.
+func (lr *LintRules) RuleNames() []string {
+	names := make([]string, 0)
+	for _, check := range lr.checks.definitionChecks {
+		names = append(names, check.name)
+	}
+	for _, check := range lr.checks.relationChecks {
+		names = append(names, check.name)
+	}
+	for _, check := range lr.checks.computedUsersetChecks {
+		names = append(names, check.name)
+	}
+	for _, check := range lr.checks.ttuChecks {
+		names = append(names, check.name)
+	}
+
+	slices.Sort(names)
+	return names
+}
+
+// Configure sets the severity of the rules found in the configuration. It returns an error if
+// the configuration references a rule which does not exist.
+func (lr *LintRules) Configure(config LintConfig) error {
+	names := lr.RuleNames()
+	for _, name := range slices.Sorted(maps.Keys(config.Rules)) {
+		if !slices.Contains(names, name) {
+			return fmt.Errorf("unknown lint rule `%s`", name)
+		}
+
+		lr.severities[name] = config.Rules[name]
+	}
+	return nil
+}
+
+// Warnings returns the warnings raised by the rules for the schema of the developer context,
+// other than those of disabled rules.
+func (lr *LintRules) Warnings(ctx context.Context, devCtx *DevContext) ([]*devinterface.DeveloperWarning, error) {
+	warnings := []*devinterface.DeveloperWarning{}
+	res := schema.ResolverForCompiledSchema(*devCtx.CompiledSchema)
+	ts := schema.NewTypeSystem(res)
+
+	fixer, err := newSchemaFixer(devCtx.CompiledSchema)
+	if err != nil {
+		return nil, err
+	}
+	ctx = context.WithValue(ctx, fixerKey, fixer)
+
+	for _, def := range devCtx.CompiledSchema.ObjectDefinitions {
+		found, err := addDefinitionWarnings(ctx, def, ts, lr)
+		if err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, found...)
+	}
+
+	return warnings, nil
+}
+
+func (lr *LintRules) ensureUnregistered(name string) error {
+	if slices.Contains(lr.RuleNames(), name) {
+		return fmt.Errorf("lint rule `%s` is already registered", name)
+	}
+	return nil
+}
+
+// shouldSkipCheck returns whether the named check is disabled, either by its configuration or
+// by a comment on the relation or definition being checked.
+func (lr *LintRules) shouldSkipCheck(metadata *corev1.Metadata, name string) bool {
+	return lr.severities[name] == LintSeverityOff || shouldSkipCheck(metadata, name)
+}
+
+// report returns the warning raised by the named check, with the severity configured for the
+// check and the fix disabling it.
+func (lr *LintRules) report(ctx context.Context, name string, warning *devinterface.DeveloperWarning) *devinterface.DeveloperWarning {
+	if severity, ok := lintSeverities[lr.severities[name]]; ok {
+		warning.Severity = severity
+	}
+	return withIgnoreFix(ctx, warning, name)
+}
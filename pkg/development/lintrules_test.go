@@ -0,0 +1,258 @@
+package development
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corev1 "github.com/authzed/spicedb/pkg/proto/core/v1"
+	developerv1 "github.com/authzed/spicedb/pkg/proto/developer/v1"
+	"github.com/authzed/spicedb/pkg/schema"
+)
+
+var lintRequireViewPermission = NewDefinitionCheck(
+	"definition-requires-view",
+	func(ctx context.Context, def *schema.Definition) (*developerv1.DeveloperWarning, error) {
+		if len(def.Namespace().Relation) == 0 || def.IsPermission("view") {
+			return nil, nil
+		}
+
+		return WarningForMetadata(
+			"definition-requires-view",
+			fmt.Sprintf("Definition %q has no `view` permission", def.Namespace().Name),
+			def.Namespace().Name,
+			def.Namespace(),
+		), nil
+	},
+)
+
+var lintNoCaveatedAdmin = NewRelationCheck(
+	"no-caveated-admin",
+	func(ctx context.Context, relation *corev1.Relation, def *schema.Definition) (*developerv1.DeveloperWarning, error) {
+		if relation.Name != "admin" {
+			return nil, nil
+		}
+
+		for _, allowed := range relation.GetTypeInformation().GetAllowedDirectRelations() {
+			if allowed.RequiredCaveat != nil {
+				return WarningForMetadata(
+					"no-caveated-admin",
+					fmt.Sprintf("Relation %q allows caveated subjects", relation.Name),
+					relation.Name,
+					relation,
+				), nil
+			}
+		}
+
+		return nil, nil
+	},
+)
+
+func TestParseLintConfig(t *testing.T) {
+	tcs := []struct {
+		name           string
+		contents       string
+		expectedConfig LintConfig
+		expectedError  string
+	}{
+		{
+			name:           "empty",
+			contents:       "",
+			expectedConfig: LintConfig{},
+		},
+		{
+			name: "severities",
+			contents: `rules:
+  relation-name-references-parent: "off"
+  arrow-references-relation: error
+  arrow-walks-subject-relation: info
+`,
+			expectedConfig: LintConfig{
+				Rules: map[string]LintSeverity{
+					"relation-name-references-parent": LintSeverityOff,
+					"arrow-references-relation":       LintSeverityError,
+					"arrow-walks-subject-relation":    LintSeverityInfo,
+				},
+			},
+		},
+		{
+			name: "unknown severity",
+			contents: `rules:
+  arrow-references-relation: fatal
+`,
+			expectedError: "rule `arrow-references-relation` has unknown severity `fatal`",
+		},
+		{
+			name: "unknown field",
+			contents: `checks:
+  arrow-references-relation: error
+`,
+			expectedError: "field checks not found",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			config, err := ParseLintConfig(strings.NewReader(tc.contents))
+			if tc.expectedError != "" {
+				require.ErrorContains(t, err, tc.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedConfig, config)
+		})
+	}
+}
+
+func TestLintRules(t *testing.T) {
+	schemaString := `definition user {}
+
+definition organization {
+	relation admin: user with is_admin
+	relation member: user
+}
+
+definition document {
+	relation org: organization
+	relation viewer: user
+	permission view = viewer + org->member
+	permission view_document = view
+}
+
+caveat is_admin(admin bool) {
+	admin
+}`
+
+	tcs := []struct {
+		name             string
+		config           LintConfig
+		expectedWarnings []string
+		expectedSeverity map[string]developerv1.DeveloperWarning_Severity
+	}{
+		{
+			name: "defaults",
+			expectedWarnings: []string{
+				"definition-requires-view",
+				"no-caveated-admin",
+				"arrow-references-relation",
+				"relation-name-references-parent",
+			},
+		},
+		{
+			name: "disabled and configured severities",
+			config: LintConfig{
+				Rules: map[string]LintSeverity{
+					"relation-name-references-parent": LintSeverityOff,
+					"definition-requires-view":        LintSeverityError,
+					"arrow-references-relation":       LintSeverityInfo,
+					"no-caveated-admin":               LintSeverityWarning,
+				},
+			},
+			expectedWarnings: []string{
+				"definition-requires-view",
+				"no-caveated-admin",
+				"arrow-references-relation",
+			},
+			expectedSeverity: map[string]developerv1.DeveloperWarning_Severity{
+				"definition-requires-view":  developerv1.DeveloperWarning_ERROR,
+				"no-caveated-admin":         developerv1.DeveloperWarning_WARNING,
+				"arrow-references-relation": developerv1.DeveloperWarning_INFO,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			rules := DefaultLintRules()
+			require.NoError(t, rules.AddDefinitionCheck(lintRequireViewPermission))
+			require.NoError(t, rules.AddRelationCheck(lintNoCaveatedAdmin))
+			require.NoError(t, rules.Configure(tc.config))
+
+			devCtx, devErr, err := NewDevContext(t.Context(), &developerv1.RequestContext{
+				Schema: schemaString,
+			})
+			require.NoError(t, err)
+			require.Empty(t, devErr)
+
+			warnings, err := rules.Warnings(t.Context(), devCtx)
+			require.NoError(t, err)
+
+			found := make([]string, 0, len(warnings))
+			for _, warning := range warnings {
+				name := warning.Message[strings.LastIndex(warning.Message, "(")+1 : len(warning.Message)-1]
+				found = append(found, name)
+
+				expectedSeverity := tc.expectedSeverity[name]
+				require.Equal(t, expectedSeverity, warning.Severity, "unexpected severity for %s", name)
+			}
+			require.Equal(t, tc.expectedWarnings, found)
+		})
+	}
+}
+
+func TestLintRulesRegistration(t *testing.T) {
+	rules := DefaultLintRules()
+	require.NoError(t, rules.AddDefinitionCheck(lintRequireViewPermission))
+	require.ErrorContains(t, rules.AddDefinitionCheck(lintRequireViewPermission), "lint rule `definition-requires-view` is already registered")
+	require.ErrorContains(t, rules.AddTTUCheck(lintArrowReferencingRelation), "lint rule `arrow-references-relation` is already registered")
+
+	require.Equal(t, []string{
+		"arrow-references-relation",
+		"arrow-references-unreachable-relation",
+		"arrow-walks-subject-relation",
+		"definition-requires-view",
+		"permission-references-itself",
+		"relation-name-references-parent",
+	}, rules.RuleNames())
+
+	// Custom rules are not added to the default rules.
+	require.NotContains(t, DefaultLintRules().RuleNames(), "definition-requires-view")
+
+	err := rules.Configure(LintConfig{Rules: map[string]LintSeverity{"unknown-rule": LintSeverityOff}})
+	require.ErrorContains(t, err, "unknown lint rule `unknown-rule`")
+}
+
+func TestDefinitionCheckIgnoreFix(t *testing.T) {
+	rules := DefaultLintRules()
+	require.NoError(t, rules.AddDefinitionCheck(lintRequireViewPermission))
+
+	schemaString := `definition user {}
+
+definition document {
+	relation viewer: user
+}`
+
+	devCtx, devErr, err := NewDevContext(t.Context(), &developerv1.RequestContext{
+		Schema: schemaString,
+	})
+	require.NoError(t, err)
+	require.Empty(t, devErr)
+
+	warnings, err := rules.Warnings(t.Context(), devCtx)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	require.Len(t, warnings[0].SuggestedFixes, 1)
+
+	updated := applySchemaEdits(t, schemaString, warnings[0].SuggestedFixes[0].Edits)
+	require.Equal(t, `definition user {}
+
+// spicedb-ignore-warning: definition-requires-view
+definition document {
+	relation viewer: user
+}`, updated)
+
+	// The comment disables the check for the definition.
+	devCtx, devErr, err = NewDevContext(t.Context(), &developerv1.RequestContext{
+		Schema: updated,
+	})
+	require.NoError(t, err)
+	require.Empty(t, devErr)
+
+	warnings, err = rules.Warnings(t.Context(), devCtx)
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+}
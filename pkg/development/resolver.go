@@ -3,6 +3,7 @@ package development
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/ccoveille/go-safecast/v2"
@@ -66,13 +67,33 @@ type SchemaReference struct {
 type Resolver struct {
 	schema     *compiler.CompiledSchema
 	typeSystem *schema.TypeSystem
+	caveats    []*core.CaveatDefinition
 }
 
 // NewResolver creates a new resolver for the given schema.
 func NewResolver(compiledSchema *compiler.CompiledSchema) (*Resolver, error) {
 	resolver := schema.ResolverForCompiledSchema(*compiledSchema)
 	ts := schema.NewTypeSystem(resolver)
-	return &Resolver{schema: compiledSchema, typeSystem: ts}, nil
+	return &Resolver{schema: compiledSchema, typeSystem: ts, caveats: compiledSchema.CaveatDefinitions}, nil
+}
+
+// NewResolverWithImports creates a new resolver for the given schema, which also resolves
+// references to the definitions and caveats of the other schemas given, such as those of the
+// other files of its import graph.
+func NewResolverWithImports(compiledSchema *compiler.CompiledSchema, others []*compiler.CompiledSchema) (*Resolver, error) {
+	definitions := slices.Clone(compiledSchema.ObjectDefinitions)
+	caveats := slices.Clone(compiledSchema.CaveatDefinitions)
+	for _, other := range others {
+		definitions = append(definitions, other.ObjectDefinitions...)
+		caveats = append(caveats, other.CaveatDefinitions...)
+	}
+
+	resolver := schema.ResolverForPredefinedDefinitions(schema.PredefinedElements{
+		Definitions: definitions,
+		Caveats:     caveats,
+	})
+	ts := schema.NewTypeSystem(resolver)
+	return &Resolver{schema: compiledSchema, typeSystem: ts, caveats: caveats}, nil
 }
 
 // ReferenceAtPosition returns the reference to the schema node at the given position in the source, if any.
@@ -257,7 +278,7 @@ func (r *Resolver) ReferenceAtPosition(source input.Source, position input.Posit
 }
 
 func (r *Resolver) lookupCaveat(caveatName string) (*core.CaveatDefinition, bool) {
-	for _, caveatDef := range r.schema.CaveatDefinitions {
+	for _, caveatDef := range r.caveats {
 		if caveatDef.Name == caveatName {
 			return caveatDef, true
 		}
@@ -0,0 +1,413 @@
+package development
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/dslshape"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+)
+
+// SchemaSymbol identifies a definition, caveat, relation or permission of a schema.
+type SchemaSymbol struct {
+	// ReferenceType is the type of the symbol, which is one of definition, caveat, relation
+	// or permission.
+	ReferenceType ReferenceType
+
+	// DefinitionName is the name of the definition or caveat, or the name of the definition
+	// under which the relation or permission is found.
+	DefinitionName string
+
+	// RelationName is the name of the relation or permission, if any.
+	RelationName string
+}
+
+// Name returns the name of the symbol, as written in the schema.
+func (ss SchemaSymbol) Name() string {
+	if ss.RelationName != "" {
+		return ss.RelationName
+	}
+	return ss.DefinitionName
+}
+
+// SourceRange is a range in a source, from its start position to its end position, exclusive.
+type SourceRange struct {
+	Start input.Position
+	End   input.Position
+}
+
+// Contains returns true if the position is found within the range, or at its end.
+func (sr SourceRange) Contains(position input.Position) bool {
+	if position.LineNumber < sr.Start.LineNumber || position.LineNumber > sr.End.LineNumber {
+		return false
+	}
+
+	if position.LineNumber == sr.Start.LineNumber && position.ColumnPosition < sr.Start.ColumnPosition {
+		return false
+	}
+
+	if position.LineNumber == sr.End.LineNumber && position.ColumnPosition > sr.End.ColumnPosition {
+		return false
+	}
+
+	return true
+}
+
+// SymbolOccurrence is the declaration of, or a reference to, a symbol in a schema.
+type SymbolOccurrence struct {
+	// Symbol is the symbol declared or referenced.
+	Symbol SchemaSymbol
+
+	// NameRange is the range of the name of the symbol at the occurrence.
+	NameRange SourceRange
+
+	// IsDeclaration indicates whether the occurrence is the declaration of the symbol.
+	IsDeclaration bool
+
+	// DeclarationRange is the range of the full declaration of the symbol, if the
+	// occurrence is its declaration.
+	DeclarationRange SourceRange
+}
+
+// SymbolOccurrences returns the declarations of, and references to, the definitions, caveats,
+// relations and permissions found in the source, in the order in which they are found.
+//
+// NOTE: the relation or permission on the right side of an arrow is resolved against every
+// subject type of the relation on the left side, so a single reference may be returned as an
+// occurrence of more than one symbol.
+func (r *Resolver) SymbolOccurrences(source input.Source) ([]SymbolOccurrence, error) {
+	var occurrences []SymbolOccurrence
+	err := compiler.WalkNodeChains(r.schema, source, func(nodeChain *compiler.NodeChain) error {
+		found, err := r.symbolOccurrencesForNode(source, nodeChain)
+		if err != nil {
+			return err
+		}
+
+		occurrences = append(occurrences, found...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(occurrences, func(a, b SymbolOccurrence) int {
+		if a.NameRange.Start.LineNumber != b.NameRange.Start.LineNumber {
+			return a.NameRange.Start.LineNumber - b.NameRange.Start.LineNumber
+		}
+		return a.NameRange.Start.ColumnPosition - b.NameRange.Start.ColumnPosition
+	})
+	return occurrences, nil
+}
+
+// SymbolsAtPosition returns the occurrences of the symbols declared or referenced at the given
+// position in the source, if any.
+func (r *Resolver) SymbolsAtPosition(source input.Source, position input.Position) ([]SymbolOccurrence, error) {
+	occurrences, err := r.SymbolOccurrences(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []SymbolOccurrence
+	for _, occurrence := range occurrences {
+		if occurrence.NameRange.Contains(position) {
+			found = append(found, occurrence)
+		}
+	}
+	return found, nil
+}
+
+func (r *Resolver) symbolOccurrencesForNode(source input.Source, nodeChain *compiler.NodeChain) ([]SymbolOccurrence, error) {
+	head := nodeChain.Head()
+	switch head.GetType() {
+	case dslshape.NodeTypeDefinition:
+		return r.declarationOccurrence(source, head, "definition", SchemaSymbol{ReferenceType: ReferenceTypeDefinition}, dslshape.NodeDefinitionPredicateName)
+
+	case dslshape.NodeTypeCaveatDefinition:
+		return r.declarationOccurrence(source, head, "caveat", SchemaSymbol{ReferenceType: ReferenceTypeCaveat}, dslshape.NodeCaveatDefinitionPredicateName)
+
+	case dslshape.NodeTypeRelation, dslshape.NodeTypePermission:
+		defName, ok := parentDefinitionName(nodeChain)
+		if !ok {
+			return nil, nil
+		}
+
+		keyword := "relation"
+		symbol := SchemaSymbol{ReferenceType: ReferenceTypeRelation, DefinitionName: defName}
+		if head.GetType() == dslshape.NodeTypePermission {
+			keyword = "permission"
+			symbol.ReferenceType = ReferenceTypePermission
+		}
+		return r.declarationOccurrence(source, head, keyword, symbol, dslshape.NodePredicateName)
+
+	case dslshape.NodeTypeSpecificTypeReference:
+		return r.typeReferenceOccurrences(source, head)
+
+	case dslshape.NodeTypeCaveatReference:
+		caveatName, err := head.GetString(dslshape.NodeCaveatPredicateCaveat)
+		if err != nil {
+			return nil, nil
+		}
+
+		if _, ok := r.lookupCaveat(caveatName); !ok {
+			return nil, nil
+		}
+
+		occurrence, err := r.referenceOccurrence(source, head, 0, SchemaSymbol{ReferenceType: ReferenceTypeCaveat, DefinitionName: caveatName})
+		if err != nil {
+			return nil, err
+		}
+		return []SymbolOccurrence{occurrence}, nil
+
+	case dslshape.NodeTypeIdentifier:
+		return r.identifierOccurrences(source, nodeChain)
+
+	default:
+		return nil, nil
+	}
+}
+
+// declarationOccurrence returns the occurrence of the declaration of the definition, caveat,
+// relation or permission by the node, whose name is found after the keyword.
+func (r *Resolver) declarationOccurrence(source input.Source, node compiler.DSLNode, keyword string, symbol SchemaSymbol, namePredicate string) ([]SymbolOccurrence, error) {
+	name, err := node.GetString(namePredicate)
+	if err != nil {
+		return nil, nil
+	}
+
+	text, err := compiler.NodeText(r.schema, node)
+	if err != nil {
+		return nil, err
+	}
+
+	nameOffset := runeOffsetOf(text, len(keyword), name)
+	if nameOffset < 0 {
+		return nil, nil
+	}
+
+	if symbol.ReferenceType == ReferenceTypeRelation || symbol.ReferenceType == ReferenceTypePermission {
+		symbol.RelationName = name
+	} else {
+		symbol.DefinitionName = name
+	}
+
+	occurrence, err := r.referenceOccurrence(source, node, nameOffset, symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	declarationRange, err := r.nodeRange(source, node)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrence.IsDeclaration = true
+	occurrence.DeclarationRange = declarationRange
+	return []SymbolOccurrence{occurrence}, nil
+}
+
+// typeReferenceOccurrences returns the occurrences of the definition, and the relation, if any,
+// referenced by a type reference, such as `group#member`.
+func (r *Resolver) typeReferenceOccurrences(source input.Source, node compiler.DSLNode) ([]SymbolOccurrence, error) {
+	defName, err := node.GetString(dslshape.NodeSpecificReferencePredicateType)
+	if err != nil {
+		return nil, nil
+	}
+
+	if _, err := r.typeSystem.GetDefinition(context.Background(), defName); err != nil {
+		return nil, nil
+	}
+
+	defOccurrence, err := r.referenceOccurrence(source, node, 0, SchemaSymbol{ReferenceType: ReferenceTypeDefinition, DefinitionName: defName})
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences := []SymbolOccurrence{defOccurrence}
+
+	relationName, err := node.GetString(dslshape.NodeSpecificReferencePredicateRelation)
+	if err != nil || relationName == "" {
+		return occurrences, nil
+	}
+
+	relation, def, ok := r.lookupRelation(defName, relationName)
+	if !ok {
+		return occurrences, nil
+	}
+
+	text, err := compiler.NodeText(r.schema, node)
+	if err != nil {
+		return nil, err
+	}
+
+	relationOffset := runeOffsetOf(text, len(defName), "#"+relationName)
+	if relationOffset < 0 {
+		return occurrences, nil
+	}
+
+	relationOccurrence, err := r.referenceOccurrence(source, node, relationOffset+1, relationSymbol(defName, relation.Name, def.IsPermission(relation.Name)))
+	if err != nil {
+		return nil, err
+	}
+
+	return append(occurrences, relationOccurrence), nil
+}
+
+// identifierOccurrences returns the occurrences of the relations or permissions referenced by an
+// identifier in the expression of a permission.
+func (r *Resolver) identifierOccurrences(source input.Source, nodeChain *compiler.NodeChain) ([]SymbolOccurrence, error) {
+	head := nodeChain.Head()
+	name, err := head.GetString(dslshape.NodeIdentiferPredicateValue)
+	if err != nil {
+		return nil, nil
+	}
+
+	defName, ok := parentDefinitionName(nodeChain)
+	if !ok {
+		return nil, nil
+	}
+
+	// The right side of an arrow refers to a relation or permission of the subject types of
+	// the relation on its left side.
+	targetDefNames := []string{defName}
+	if parent := nodeChain.Parent(); parent != nil && parent.GetType() == dslshape.NodeTypeArrowExpression {
+		rightExpr, err := parent.Lookup(dslshape.NodeExpressionPredicateRightExpr)
+		if err == nil && rightExpr == head {
+			targetDefNames, err = r.arrowSubjectDefinitionNames(defName, parent)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	var occurrences []SymbolOccurrence
+	for _, targetDefName := range targetDefNames {
+		relation, def, ok := r.lookupRelation(targetDefName, name)
+		if !ok {
+			continue
+		}
+
+		occurrence, err := r.referenceOccurrence(source, head, 0, relationSymbol(targetDefName, relation.Name, def.IsPermission(relation.Name)))
+		if err != nil {
+			return nil, err
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+	return occurrences, nil
+}
+
+// arrowSubjectDefinitionNames returns the names of the subject types of the relation on the
+// left side of the arrow.
+func (r *Resolver) arrowSubjectDefinitionNames(defName string, arrowExpr compiler.DSLNode) ([]string, error) {
+	leftExpr, err := arrowExpr.Lookup(dslshape.NodeExpressionPredicateLeftExpr)
+	if err != nil {
+		return nil, nil
+	}
+
+	leftName, err := leftExpr.GetString(dslshape.NodeIdentiferPredicateValue)
+	if err != nil {
+		return nil, nil
+	}
+
+	relation, _, ok := r.lookupRelation(defName, leftName)
+	if !ok {
+		return nil, nil
+	}
+
+	var subjectDefNames []string
+	for _, allowed := range relation.GetTypeInformation().GetAllowedDirectRelations() {
+		if !slices.Contains(subjectDefNames, allowed.Namespace) {
+			subjectDefNames = append(subjectDefNames, allowed.Namespace)
+		}
+	}
+	return subjectDefNames, nil
+}
+
+// referenceOccurrence returns the occurrence of the symbol, whose name is found at the given
+// rune offset from the start of the node.
+func (r *Resolver) referenceOccurrence(source input.Source, node compiler.DSLNode, nameOffset int, symbol SchemaSymbol) (SymbolOccurrence, error) {
+	startRune, err := node.GetInt(dslshape.NodePredicateStartRune)
+	if err != nil {
+		return SymbolOccurrence{}, err
+	}
+
+	start, err := r.schema.RunePositionToSourcePosition(source, startRune+nameOffset)
+	if err != nil {
+		return SymbolOccurrence{}, err
+	}
+
+	end := start
+	end.ColumnPosition += utf8.RuneCountInString(symbol.Name())
+
+	return SymbolOccurrence{
+		Symbol:    symbol,
+		NameRange: SourceRange{Start: start, End: end},
+	}, nil
+}
+
+// nodeRange returns the range of the full source of the node.
+func (r *Resolver) nodeRange(source input.Source, node compiler.DSLNode) (SourceRange, error) {
+	startRune, err := node.GetInt(dslshape.NodePredicateStartRune)
+	if err != nil {
+		return SourceRange{}, err
+	}
+
+	endRune, err := node.GetInt(dslshape.NodePredicateEndRune)
+	if err != nil {
+		return SourceRange{}, err
+	}
+
+	start, err := r.schema.RunePositionToSourcePosition(source, startRune)
+	if err != nil {
+		return SourceRange{}, err
+	}
+
+	end, err := r.schema.RunePositionToSourcePosition(source, endRune)
+	if err != nil {
+		return SourceRange{}, err
+	}
+
+	// The end rune is inclusive.
+	end.ColumnPosition++
+	return SourceRange{Start: start, End: end}, nil
+}
+
+func relationSymbol(defName string, relationName string, isPermission bool) SchemaSymbol {
+	referenceType := ReferenceTypeRelation
+	if isPermission {
+		referenceType = ReferenceTypePermission
+	}
+
+	return SchemaSymbol{ReferenceType: referenceType, DefinitionName: defName, RelationName: relationName}
+}
+
+func parentDefinitionName(nodeChain *compiler.NodeChain) (string, bool) {
+	parentDefNode := nodeChain.FindNodeOfType(dslshape.NodeTypeDefinition)
+	if parentDefNode == nil {
+		return "", false
+	}
+
+	defName, err := parentDefNode.GetString(dslshape.NodeDefinitionPredicateName)
+	if err != nil {
+		return "", false
+	}
+
+	return defName, true
+}
+
+// runeOffsetOf returns the offset, in runes, of the first instance of the name in the text
+// found at or after the byte offset given, or -1 if none.
+func runeOffsetOf(text string, fromByteOffset int, name string) int {
+	if fromByteOffset > len(text) {
+		return -1
+	}
+
+	index := strings.Index(text[fromByteOffset:], name)
+	if index < 0 {
+		return -1
+	}
+
+	return utf8.RuneCountInString(text[:fromByteOffset+index])
+}
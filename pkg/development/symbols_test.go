@@ -0,0 +1,169 @@
+package development
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+)
+
+const symbolsTestSchema = `caveat somecaveat(someparam int) {
+	someparam == 42
+}
+
+definition user {}
+
+definition organization {
+	relation admin: user
+}
+
+definition group {
+	relation member: user | group#member with somecaveat
+	relation org: organization
+	permission manage = org->admin
+}
+
+definition document {
+	relation viewer: user | group#member
+	relation parent: group | organization
+	permission view = viewer + parent->admin + parent.any(member)
+}`
+
+func newSymbolsTestResolver(t *testing.T) *Resolver {
+	compiled, err := compiler.Compile(compiler.InputSchema{
+		Source:       input.Source("test"),
+		SchemaString: symbolsTestSchema,
+	}, compiler.AllowUnprefixedObjectType())
+	require.NoError(t, err)
+
+	resolver, err := NewResolver(compiled)
+	require.NoError(t, err)
+	return resolver
+}
+
+func occurrenceString(occurrence SymbolOccurrence) string {
+	kind := "ref"
+	if occurrence.IsDeclaration {
+		kind = "decl"
+	}
+
+	symbol := occurrence.Symbol.DefinitionName
+	if occurrence.Symbol.RelationName != "" {
+		symbol += "#" + occurrence.Symbol.RelationName
+	}
+
+	return fmt.Sprintf("%s %s %d:%d-%d",
+		kind,
+		symbol,
+		occurrence.NameRange.Start.LineNumber,
+		occurrence.NameRange.Start.ColumnPosition,
+		occurrence.NameRange.End.ColumnPosition,
+	)
+}
+
+func TestSymbolOccurrences(t *testing.T) {
+	resolver := newSymbolsTestResolver(t)
+
+	occurrences, err := resolver.SymbolOccurrences(input.Source("test"))
+	require.NoError(t, err)
+
+	found := make([]string, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		found = append(found, occurrenceString(occurrence))
+	}
+
+	require.Equal(t, []string{
+		"decl somecaveat 0:7-17",
+		"decl user 4:11-15",
+		"decl organization 6:11-23",
+		"decl organization#admin 7:10-15",
+		"ref user 7:17-21",
+		"decl group 10:11-16",
+		"decl group#member 11:10-16",
+		"ref user 11:18-22",
+		"ref group 11:25-30",
+		"ref group#member 11:31-37",
+		"ref somecaveat 11:43-53",
+		"decl group#org 12:10-13",
+		"ref organization 12:15-27",
+		"decl group#manage 13:12-18",
+		"ref group#org 13:21-24",
+		"ref organization#admin 13:26-31",
+		"decl document 16:11-19",
+		"decl document#viewer 17:10-16",
+		"ref user 17:18-22",
+		"ref group 17:25-30",
+		"ref group#member 17:31-37",
+		"decl document#parent 18:10-16",
+		"ref group 18:18-23",
+		"ref organization 18:26-38",
+		"decl document#view 19:12-16",
+		"ref document#viewer 19:19-25",
+		"ref document#parent 19:28-34",
+		"ref organization#admin 19:36-41",
+		"ref document#parent 19:44-50",
+		"ref group#member 19:55-61",
+	}, found)
+}
+
+func TestSymbolOccurrencesDeclarationRanges(t *testing.T) {
+	resolver := newSymbolsTestResolver(t)
+
+	occurrences, err := resolver.SymbolOccurrences(input.Source("test"))
+	require.NoError(t, err)
+
+	for _, occurrence := range occurrences {
+		if occurrence.IsDeclaration && occurrence.Symbol.DefinitionName == "organization" && occurrence.Symbol.RelationName == "" {
+			require.Equal(t, SourceRange{
+				Start: input.Position{LineNumber: 6, ColumnPosition: 0},
+				End:   input.Position{LineNumber: 8, ColumnPosition: 1},
+			}, occurrence.DeclarationRange)
+			return
+		}
+	}
+
+	require.Fail(t, "declaration of organization not found")
+}
+
+func TestSymbolsAtPosition(t *testing.T) {
+	resolver := newSymbolsTestResolver(t)
+
+	tcs := []struct {
+		name     string
+		position input.Position
+		expected []string
+	}{
+		{"definition declaration", input.Position{LineNumber: 4, ColumnPosition: 13}, []string{"decl user 4:11-15"}},
+		{"end of name", input.Position{LineNumber: 4, ColumnPosition: 15}, []string{"decl user 4:11-15"}},
+		{"relation of type reference", input.Position{LineNumber: 11, ColumnPosition: 33}, []string{"ref group#member 11:31-37"}},
+		{"definition of type reference", input.Position{LineNumber: 11, ColumnPosition: 26}, []string{"ref group 11:25-30"}},
+		{"arrow target", input.Position{LineNumber: 19, ColumnPosition: 38}, []string{"ref organization#admin 19:36-41"}},
+		{"caveat reference", input.Position{LineNumber: 11, ColumnPosition: 45}, []string{"ref somecaveat 11:43-53"}},
+		{"keyword", input.Position{LineNumber: 4, ColumnPosition: 2}, nil},
+		{"caveat expression", input.Position{LineNumber: 1, ColumnPosition: 3}, nil},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			occurrences, err := resolver.SymbolsAtPosition(input.Source("test"), tc.position)
+			require.NoError(t, err)
+
+			var found []string
+			for _, occurrence := range occurrences {
+				found = append(found, occurrenceString(occurrence))
+			}
+			require.Equal(t, tc.expected, found)
+		})
+	}
+}
+
+func TestSymbolOccurrencesForOtherSource(t *testing.T) {
+	resolver := newSymbolsTestResolver(t)
+
+	occurrences, err := resolver.SymbolOccurrences(input.Source("other"))
+	require.NoError(t, err)
+	require.Empty(t, occurrences)
+}
@@ -0,0 +1,218 @@
+package development
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ccoveille/go-safecast/v2"
+
+	log "github.com/authzed/spicedb/internal/logging"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+	devinterface "github.com/authzed/spicedb/pkg/proto/developer/v1"
+	"github.com/authzed/spicedb/pkg/spiceerrors"
+	"github.com/authzed/spicedb/pkg/tuple"
+	"github.com/authzed/spicedb/pkg/validationfile"
+)
+
+// yamlErrorLineRegex matches the line found in errors returned by the YAML decoder.
+var yamlErrorLineRegex = regexp.MustCompile(`^yaml: line ([0-9]+): `)
+
+// ValidationFileResult is the result of running a validation file.
+type ValidationFileResult struct {
+	// InputErrors are the errors found when decoding the file, or when loading its schema and
+	// relationships. If any are found, neither the assertions nor the expected relations of the
+	// file are run.
+	InputErrors []*devinterface.DeveloperError
+
+	// AssertionErrors are the failures of the assertions of the file.
+	AssertionErrors []*devinterface.DeveloperError
+
+	// ValidationErrors are the failures of the expected relations of the file.
+	ValidationErrors []*devinterface.DeveloperError
+
+	// UpdatedValidationYaml is the contents of the `validation` block of the file, as generated
+	// from its schema and relationships for the objects and relations found within it.
+	UpdatedValidationYaml string
+}
+
+// RunValidationFile decodes the contents of a validation file, loads its schema and
+// relationships into a development context, and runs its assertions and expected relations.
+// The lines and columns of the errors returned are those found within the file, rather than
+// within the block of the file in which they occurred.
+func RunValidationFile(ctx context.Context, contents []byte) (*ValidationFileResult, error) {
+	lines := strings.Split(string(contents), "\n")
+	parsed, err := validationfile.DecodeValidationFile(contents)
+	if err != nil {
+		return &ValidationFileResult{
+			InputErrors: []*devinterface.DeveloperError{convertValidationFileError(err, lines)},
+		}, nil
+	}
+
+	if parsed.SchemaFile != "" {
+		return &ValidationFileResult{
+			InputErrors: []*devinterface.DeveloperError{{
+				Message: "validation files referencing a `schemaFile` are not supported; the schema must be specified in `schema`",
+				Kind:    devinterface.DeveloperError_PARSE_ERROR,
+				Source:  devinterface.DeveloperError_VALIDATION_YAML,
+			}},
+		}, nil
+	}
+
+	relationships := make([]*core.RelationTuple, 0, len(parsed.Relationships.Relationships))
+	for _, rel := range parsed.Relationships.Relationships {
+		relationships = append(relationships, rel.ToCoreTuple())
+	}
+
+	devContext, devErrs, err := NewDevContext(ctx, &devinterface.RequestContext{
+		Schema:        parsed.Schema.Schema,
+		Relationships: relationships,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if devErrs != nil {
+		for _, devErr := range devErrs.InputErrors {
+			positionInputError(devErr, parsed, lines)
+		}
+
+		return &ValidationFileResult{InputErrors: devErrs.InputErrors}, nil
+	}
+	defer devContext.Dispose()
+
+	assertionErrors, err := RunAllAssertions(devContext, &parsed.Assertions)
+	if err != nil {
+		return nil, err
+	}
+
+	membershipSet, validationErrors, err := RunValidation(devContext, &parsed.ExpectedRelations)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedValidationYaml, err := GenerateValidation(membershipSet)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ValidationFileResult{
+		AssertionErrors:       assertionErrors,
+		ValidationErrors:      validationErrors,
+		UpdatedValidationYaml: updatedValidationYaml,
+	}, nil
+}
+
+// convertValidationFileError converts an error found when decoding a validation file.
+func convertValidationFileError(err error, lines []string) *devinterface.DeveloperError {
+	if serr, ok := spiceerrors.AsWithSourceError(err); ok {
+		devErr := convertSourceError(devinterface.DeveloperError_VALIDATION_YAML, serr)
+
+		// The column of an error found within a block scalar is that of the block, rather than
+		// of the line on which it was found.
+		if column, ok := contextColumn(lines, devErr.Line, devErr.Context); ok {
+			devErr.Column = column
+		}
+		return devErr
+	}
+
+	devErr := convertError(devinterface.DeveloperError_VALIDATION_YAML, err)
+	if matches := yamlErrorLineRegex.FindStringSubmatch(err.Error()); matches != nil {
+		if lineNumber, perr := strconv.Atoi(matches[1]); perr == nil {
+			devErr.Line, _ = toDeveloperErrorPosition(spiceerrors.SourcePosition{LineNumber: lineNumber})
+		}
+	}
+	return devErr
+}
+
+// positionInputError updates the line and column of the input error, found in the schema or the
+// relationships of the validation file, to those within the file.
+func positionInputError(devErr *devinterface.DeveloperError, parsed *validationfile.ValidationFile, lines []string) {
+	switch devErr.Source {
+	case devinterface.DeveloperError_SCHEMA:
+		schemaLine, schemaColumn, ok := literalBlockPosition(parsed.Schema.SourcePosition, lines)
+		if !ok || devErr.Line == 0 {
+			// The schema is not written as a literal block, so its lines cannot be found within
+			// the file.
+			devErr.Line, devErr.Column = toDeveloperErrorPosition(parsed.Schema.SourcePosition)
+			return
+		}
+
+		blockLine, blockColumn := toDeveloperErrorPosition(spiceerrors.SourcePosition{LineNumber: schemaLine, ColumnPosition: schemaColumn})
+		devErr.Line += blockLine - 1
+		devErr.Column += blockColumn - 1
+
+	case devinterface.DeveloperError_RELATIONSHIP:
+		for index, rel := range parsed.Relationships.Relationships {
+			relString, err := tuple.String(rel)
+			if err != nil || relString != devErr.Context || index >= len(parsed.Relationships.RelationshipPositions) {
+				continue
+			}
+
+			// The column of a relationship is that of its block, so the start of its line is
+			// used instead.
+			position := parsed.Relationships.RelationshipPositions[index]
+			if position.LineNumber > 0 && position.LineNumber <= len(lines) {
+				line := lines[position.LineNumber-1]
+				position.ColumnPosition = len(line) - len(strings.TrimLeft(line, " \t")) + 1
+			}
+
+			devErr.Line, devErr.Column = toDeveloperErrorPosition(position)
+			return
+		}
+	}
+}
+
+// literalBlockPosition returns the line and column, both one-indexed, at which the contents of
+// the literal block scalar found at the position start within the file, if the value at the
+// position is such a block.
+func literalBlockPosition(position spiceerrors.SourcePosition, lines []string) (int, int, bool) {
+	if position.LineNumber < 1 || position.LineNumber >= len(lines) {
+		return 0, 0, false
+	}
+
+	indicatorLine := lines[position.LineNumber-1]
+	if position.ColumnPosition < 1 || position.ColumnPosition > len(indicatorLine) || indicatorLine[position.ColumnPosition-1] != '|' {
+		return 0, 0, false
+	}
+
+	for index := position.LineNumber; index < len(lines); index++ {
+		if strings.TrimSpace(lines[index]) == "" {
+			continue
+		}
+
+		return position.LineNumber + 1, len(lines[index]) - len(strings.TrimLeft(lines[index], " ")) + 1, true
+	}
+
+	return 0, 0, false
+}
+
+// contextColumn returns the one-indexed column at which the context is found on the
+// one-indexed line, if any.
+func contextColumn(lines []string, lineNumber uint32, context string) (uint32, bool) {
+	if context == "" || lineNumber == 0 || int(lineNumber) > len(lines) {
+		return 0, false
+	}
+
+	index := strings.Index(lines[lineNumber-1], context)
+	if index < 0 {
+		return 0, false
+	}
+
+	_, column := toDeveloperErrorPosition(spiceerrors.SourcePosition{ColumnPosition: index + 1})
+	return column, true
+}
+
+func toDeveloperErrorPosition(position spiceerrors.SourcePosition) (uint32, uint32) {
+	// NOTE: zeroes are fine here to mean "unknown"
+	lineNumber, err := safecast.Convert[uint32](position.LineNumber)
+	if err != nil {
+		log.Err(err).Msg("could not cast lineNumber to uint32")
+	}
+	columnPosition, err := safecast.Convert[uint32](position.ColumnPosition)
+	if err != nil {
+		log.Err(err).Msg("could not cast columnPosition to uint32")
+	}
+	return lineNumber, columnPosition
+}
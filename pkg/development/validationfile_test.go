@@ -0,0 +1,172 @@
+package development
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	devinterface "github.com/authzed/spicedb/pkg/proto/developer/v1"
+)
+
+func TestRunValidationFile(t *testing.T) {
+	tcs := []struct {
+		name                    string
+		contents                string
+		expectedInputErrors     []string
+		expectedAssertionErrors []string
+		expectedValidationErrs  []string
+		expectedValidationYaml  string
+	}{
+		{
+			"valid file",
+			`schema: |-
+  definition user {}
+
+  definition document {
+    relation viewer: user
+    permission view = viewer
+  }
+relationships: |-
+  document:firstdoc#viewer@user:tom
+assertions:
+  assertTrue:
+    - document:firstdoc#view@user:tom
+  assertFalse:
+    - document:firstdoc#view@user:fred
+validation:
+  document:firstdoc#view:
+    - "[user:tom] is <document:firstdoc#viewer>"
+`,
+			nil,
+			nil,
+			nil,
+			"document:firstdoc#view:\n- '[user:tom] is <document:firstdoc#viewer>'\n",
+		},
+		{
+			"failing assertion",
+			`schema: |-
+  definition user {}
+
+  definition document {
+    relation viewer: user
+    permission view = viewer
+  }
+relationships: |-
+  document:firstdoc#viewer@user:tom
+assertions:
+  assertTrue:
+    - document:firstdoc#view@user:tom
+    - document:firstdoc#view@user:fred
+`,
+			nil,
+			[]string{"13:7 Expected relation or permission document:firstdoc#view@user:fred to exist"},
+			nil,
+			"{}\n",
+		},
+		{
+			"failing expected relations",
+			`schema: |-
+  definition user {}
+
+  definition document {
+    relation viewer: user
+    permission view = viewer
+  }
+relationships: |-
+  document:firstdoc#viewer@user:tom
+validation:
+  document:firstdoc#view:
+    - "[user:fred] is <document:firstdoc#viewer>"
+`,
+			nil,
+			nil,
+			[]string{
+				"11:3 For object and permission/relation `document:firstdoc#view`, subject `user:tom` found but not listed in expected subjects",
+				"12:7 For object and permission/relation `document:firstdoc#view`, missing expected subject `user:fred`",
+			},
+			"document:firstdoc#view:\n- '[user:tom] is <document:firstdoc#viewer>'\n",
+		},
+		{
+			"schema error",
+			`schema: |-
+  definition user {}
+
+  definition document {
+    relation viewer: unknown
+  }
+relationships: ""
+`,
+			[]string{"5:22 could not lookup definition `unknown` for relation `viewer`: object definition `unknown` not found"},
+			nil,
+			nil,
+			"",
+		},
+		{
+			"relationship error",
+			`schema: |-
+  definition user {}
+
+  definition document {
+    relation viewer: user
+  }
+relationships: |-
+  document:firstdoc#viewer@user:tom
+  document:firstdoc#editor@user:tom
+`,
+			[]string{"9:3 relation/permission `editor` not found under definition `document`"},
+			nil,
+			nil,
+			"",
+		},
+		{
+			"invalid relationship",
+			`schema: |-
+  definition user {}
+relationships: |-
+  document:firstdoc#viewer@user:tom
+  document:firstdoc#vieweruser:tom
+`,
+			[]string{"5:3 error parsing relationship `document:firstdoc#vieweruser:tom`: invalid relationship string"},
+			nil,
+			nil,
+			"",
+		},
+		{
+			"invalid YAML",
+			"schema: |-\n  definition user {}\nrelationships: [\n",
+			[]string{"3:0 yaml: line 3: did not find expected node content"},
+			nil,
+			nil,
+			"",
+		},
+		{
+			"schema file",
+			"schemaFile: ./schema.zed\n",
+			[]string{"0:0 validation files referencing a `schemaFile` are not supported; the schema must be specified in `schema`"},
+			nil,
+			nil,
+			"",
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := RunValidationFile(t.Context(), []byte(tc.contents))
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expectedInputErrors, positionedErrorStrings(result.InputErrors))
+			require.Equal(t, tc.expectedAssertionErrors, positionedErrorStrings(result.AssertionErrors))
+			require.ElementsMatch(t, tc.expectedValidationErrs, positionedErrorStrings(result.ValidationErrors))
+			require.Equal(t, tc.expectedValidationYaml, result.UpdatedValidationYaml)
+		})
+	}
+}
+
+func positionedErrorStrings(devErrs []*devinterface.DeveloperError) []string {
+	var found []string
+	for _, devErr := range devErrs {
+		found = append(found, fmt.Sprintf("%d:%d %s", devErr.Line, devErr.Column, devErr.Message))
+	}
+	return found
+}
@@ -11,7 +11,7 @@ import (
 	"github.com/authzed/spicedb/pkg/tuple"
 )
 
-var lintRelationReferencesParentType = relationCheck{
+var lintRelationReferencesParentType = RelationCheck{
 	"relation-name-references-parent",
 	func(
 		ctx context.Context,
@@ -20,28 +20,29 @@ var lintRelationReferencesParentType = relationCheck{
 	) (*devinterface.DeveloperWarning, error) {
 		parentDef := def.Namespace()
 		if strings.HasSuffix(relation.Name, parentDef.Name) {
+			renameFix := suggestRename(ctx, def, relation.Name, strings.TrimRight(strings.TrimSuffix(relation.Name, parentDef.Name), "_"))
 			if def.IsPermission(relation.Name) {
-				return warningForMetadata(
+				return withFixes(WarningForMetadata(
 					"relation-name-references-parent",
 					fmt.Sprintf("Permission %q references parent type %q in its name; it is recommended to drop the suffix", relation.Name, parentDef.Name),
 					relation.Name,
 					relation,
-				), nil
+				), renameFix), nil
 			}
 
-			return warningForMetadata(
+			return withFixes(WarningForMetadata(
 				"relation-name-references-parent",
 				fmt.Sprintf("Relation %q references parent type %q in its name; it is recommended to drop the suffix", relation.Name, parentDef.Name),
 				relation.Name,
 				relation,
-			), nil
+			), renameFix), nil
 		}
 
 		return nil, nil
 	},
 }
 
-var lintPermissionReferencingItself = computedUsersetCheck{
+var lintPermissionReferencingItself = ComputedUsersetCheck{
 	"permission-references-itself",
 	func(
 		ctx context.Context,
@@ -52,23 +53,23 @@ var lintPermissionReferencingItself = computedUsersetCheck{
 		parentRelation := ctx.Value(relationKey).(*corev1.Relation)
 		permName := parentRelation.Name
 		if computedUserset.GetRelation() == permName {
-			return warningForPosition(
+			return withFixes(WarningForPosition(
 				"permission-references-itself",
 				fmt.Sprintf("Permission %q references itself, which will cause an error to be raised due to infinite recursion", permName),
 				permName,
 				sourcePosition,
-			), nil
+			), suggestOperandRemoval(ctx, sourcePosition, false, fmt.Sprintf("Remove reference to %q", permName))), nil
 		}
 
 		return nil, nil
 	},
 }
 
-var lintArrowReferencingUnreachable = ttuCheck{
+var lintArrowReferencingUnreachable = TTUCheck{
 	"arrow-references-unreachable-relation",
 	func(
 		ctx context.Context,
-		ttu ttu,
+		ttu TTU,
 		sourcePosition *corev1.SourcePosition,
 		def *schema.Definition,
 	) (*devinterface.DeveloperWarning, error) {
@@ -103,7 +104,7 @@ var lintArrowReferencingUnreachable = ttuCheck{
 				return nil, err
 			}
 
-			return warningForPosition(
+			return withFixes(WarningForPosition(
 				"arrow-references-unreachable-relation",
 				fmt.Sprintf(
 					"Arrow `%s` under permission %q references relation/permission %q that does not exist on any subject types of relation %q",
@@ -114,18 +115,18 @@ var lintArrowReferencingUnreachable = ttuCheck{
 				),
 				arrowString,
 				sourcePosition,
-			), nil
+			), suggestOperandRemoval(ctx, sourcePosition, true, fmt.Sprintf("Remove arrow `%s`", arrowString))), nil
 		}
 
 		return nil, nil
 	},
 }
 
-var lintArrowOverSubRelation = ttuCheck{
+var lintArrowOverSubRelation = TTUCheck{
 	"arrow-walks-subject-relation",
 	func(
 		ctx context.Context,
-		ttu ttu,
+		ttu TTU,
 		sourcePosition *corev1.SourcePosition,
 		def *schema.Definition,
 	) (*devinterface.DeveloperWarning, error) {
@@ -148,7 +149,7 @@ var lintArrowOverSubRelation = ttuCheck{
 
 		for _, subjectType := range allowedSubjectTypes {
 			if subjectType.GetRelation() != tuple.Ellipsis {
-				return warningForPosition(
+				return WarningForPosition(
 					"arrow-walks-subject-relation",
 					fmt.Sprintf(
 						"Arrow `%s` under permission %q references relation %q that has relation %q on subject %q: *the subject relation will be ignored for the arrow*",
@@ -168,11 +169,11 @@ var lintArrowOverSubRelation = ttuCheck{
 	},
 }
 
-var lintArrowReferencingRelation = ttuCheck{
+var lintArrowReferencingRelation = TTUCheck{
 	"arrow-references-relation",
 	func(
 		ctx context.Context,
-		ttu ttu,
+		ttu TTU,
 		sourcePosition *corev1.SourcePosition,
 		def *schema.Definition,
 	) (*devinterface.DeveloperWarning, error) {
@@ -212,7 +213,7 @@ var lintArrowReferencingRelation = ttuCheck{
 			}
 
 			if !nts.IsPermission(targetRelation.Name) {
-				return warningForPosition(
+				return WarningForPosition(
 					"arrow-references-relation",
 					fmt.Sprintf(
 						"Arrow `%s` under permission %q references relation %q on definition %q; it is recommended to point to a permission",
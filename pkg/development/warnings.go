@@ -15,24 +15,28 @@ import (
 )
 
 var allChecks = checks{
-	relationChecks: []relationCheck{
+	relationChecks: []RelationCheck{
 		lintRelationReferencesParentType,
 	},
-	computedUsersetChecks: []computedUsersetCheck{
+	computedUsersetChecks: []ComputedUsersetCheck{
 		lintPermissionReferencingItself,
 	},
-	ttuChecks: []ttuCheck{
+	ttuChecks: []TTUCheck{
 		lintArrowReferencingRelation,
 		lintArrowReferencingUnreachable,
 		lintArrowOverSubRelation,
 	},
 }
 
-func warningForMetadata(warningName string, message string, sourceCode string, metadata namespace.WithSourcePosition) *devinterface.DeveloperWarning {
-	return warningForPosition(warningName, message, sourceCode, metadata.GetSourcePosition())
+// WarningForMetadata returns a warning raised by the named check for the definition, caveat or
+// relation found at the source position of the metadata.
+func WarningForMetadata(warningName string, message string, sourceCode string, metadata namespace.WithSourcePosition) *devinterface.DeveloperWarning {
+	return WarningForPosition(warningName, message, sourceCode, metadata.GetSourcePosition())
 }
 
-func warningForPosition(warningName string, message string, sourceCode string, sourcePosition *corev1.SourcePosition) *devinterface.DeveloperWarning {
+// WarningForPosition returns a warning raised by the named check for the source code found at
+// the source position.
+func WarningForPosition(warningName string, message string, sourceCode string, sourcePosition *corev1.SourcePosition) *devinterface.DeveloperWarning {
 	if sourcePosition == nil {
 		return &devinterface.DeveloperWarning{
 			Message:    message,
@@ -58,39 +62,55 @@ func warningForPosition(warningName string, message string, sourceCode string, s
 	}
 }
 
-// GetWarnings returns a list of warnings for the given developer context.
+// GetWarnings returns a list of warnings for the given developer context, raised by the
+// built-in lint rules.
 func GetWarnings(ctx context.Context, devCtx *DevContext) ([]*devinterface.DeveloperWarning, error) {
-	warnings := []*devinterface.DeveloperWarning{}
-	res := schema.ResolverForCompiledSchema(*devCtx.CompiledSchema)
-	ts := schema.NewTypeSystem(res)
-
-	for _, def := range devCtx.CompiledSchema.ObjectDefinitions {
-		found, err := addDefinitionWarnings(ctx, def, ts)
-		if err != nil {
-			return nil, err
-		}
-		warnings = append(warnings, found...)
-	}
-
-	return warnings, nil
+	return DefaultLintRules().Warnings(ctx, devCtx)
 }
 
 type contextKey string
 
-var relationKey = contextKey("relation")
+var (
+	relationKey   = contextKey("relation")
+	definitionKey = contextKey("definition")
+)
+
+// CheckedRelation returns the relation or permission being checked, if any. Computed userset
+// and arrow checks are run under the permission in which they are found.
+func CheckedRelation(ctx context.Context) (*corev1.Relation, bool) {
+	relation, ok := ctx.Value(relationKey).(*corev1.Relation)
+	return relation, ok
+}
 
-func addDefinitionWarnings(ctx context.Context, nsDef *corev1.NamespaceDefinition, ts *schema.TypeSystem) ([]*devinterface.DeveloperWarning, error) {
+func addDefinitionWarnings(ctx context.Context, nsDef *corev1.NamespaceDefinition, ts *schema.TypeSystem, rules *LintRules) ([]*devinterface.DeveloperWarning, error) {
 	def, err := schema.NewDefinition(ts, nsDef)
 	if err != nil {
 		return nil, err
 	}
 
+	ctx = context.WithValue(ctx, definitionKey, nsDef)
+
 	warnings := []*devinterface.DeveloperWarning{}
+	for _, check := range rules.checks.definitionChecks {
+		if rules.shouldSkipCheck(nsDef.Metadata, check.name) {
+			continue
+		}
+
+		checkerWarning, err := check.fn(ctx, def)
+		if err != nil {
+			return nil, err
+		}
+
+		if checkerWarning != nil {
+			warnings = append(warnings, rules.report(ctx, check.name, checkerWarning))
+		}
+	}
+
 	for _, rel := range nsDef.Relation {
 		ctx = context.WithValue(ctx, relationKey, rel)
 
-		for _, check := range allChecks.relationChecks {
-			if shouldSkipCheck(rel.Metadata, check.name) {
+		for _, check := range rules.checks.relationChecks {
+			if rules.shouldSkipCheck(rel.Metadata, check.name) {
 				continue
 			}
 
@@ -100,12 +120,12 @@ func addDefinitionWarnings(ctx context.Context, nsDef *corev1.NamespaceDefinitio
 			}
 
 			if checkerWarning != nil {
-				warnings = append(warnings, checkerWarning)
+				warnings = append(warnings, rules.report(ctx, check.name, checkerWarning))
 			}
 		}
 
 		if def.IsPermission(rel.Name) {
-			found, err := walkUsersetRewrite(ctx, rel.UsersetRewrite, rel, allChecks, def)
+			found, err := walkUsersetRewrite(ctx, rel.UsersetRewrite, rel, rules, def)
 			if err != nil {
 				return nil, err
 			}
@@ -132,64 +152,109 @@ func shouldSkipCheck(metadata *corev1.Metadata, name string) bool {
 	return false
 }
 
-type tupleset interface {
+// Tupleset is the relation walked by an arrow.
+type Tupleset interface {
 	GetRelation() string
 }
 
-type ttu interface {
-	GetTupleset() tupleset
+// TTU is an arrow, or a functioned arrow, found under a permission.
+type TTU interface {
+	GetTupleset() Tupleset
 	GetComputedUserset() *corev1.ComputedUserset
 	GetArrowString() (string, error)
 }
 
 type (
-	relationChecker        func(ctx context.Context, relation *corev1.Relation, def *schema.Definition) (*devinterface.DeveloperWarning, error)
-	computedUsersetChecker func(ctx context.Context, computedUserset *corev1.ComputedUserset, sourcePosition *corev1.SourcePosition, def *schema.Definition) (*devinterface.DeveloperWarning, error)
-	ttuChecker             func(ctx context.Context, ttu ttu, sourcePosition *corev1.SourcePosition, def *schema.Definition) (*devinterface.DeveloperWarning, error)
+	// DefinitionChecker checks a definition, returning the warning raised, if any.
+	DefinitionChecker func(ctx context.Context, def *schema.Definition) (*devinterface.DeveloperWarning, error)
+
+	// RelationChecker checks a relation or permission of a definition, returning the warning
+	// raised, if any.
+	RelationChecker func(ctx context.Context, relation *corev1.Relation, def *schema.Definition) (*devinterface.DeveloperWarning, error)
+
+	// ComputedUsersetChecker checks a relation or permission referenced by a permission of a
+	// definition, returning the warning raised, if any.
+	ComputedUsersetChecker func(ctx context.Context, computedUserset *corev1.ComputedUserset, sourcePosition *corev1.SourcePosition, def *schema.Definition) (*devinterface.DeveloperWarning, error)
+
+	// TTUChecker checks an arrow found under a permission of a definition, returning the warning
+	// raised, if any.
+	TTUChecker func(ctx context.Context, ttu TTU, sourcePosition *corev1.SourcePosition, def *schema.Definition) (*devinterface.DeveloperWarning, error)
 )
 
-type relationCheck struct {
+// DefinitionCheck is a named check run over each definition of a schema.
+type DefinitionCheck struct {
+	name string
+	fn   DefinitionChecker
+}
+
+// RelationCheck is a named check run over each relation and permission of a schema.
+type RelationCheck struct {
 	name string
-	fn   relationChecker
+	fn   RelationChecker
 }
 
-type computedUsersetCheck struct {
+// ComputedUsersetCheck is a named check run over each relation or permission referenced by a
+// permission of a schema.
+type ComputedUsersetCheck struct {
 	name string
-	fn   computedUsersetChecker
+	fn   ComputedUsersetChecker
 }
 
-type ttuCheck struct {
+// TTUCheck is a named check run over each arrow found under a permission of a schema.
+type TTUCheck struct {
 	name string
-	fn   ttuChecker
+	fn   TTUChecker
+}
+
+// NewDefinitionCheck returns a check with the given name, run over each definition.
+func NewDefinitionCheck(name string, fn DefinitionChecker) DefinitionCheck {
+	return DefinitionCheck{name, fn}
+}
+
+// NewRelationCheck returns a check with the given name, run over each relation and permission.
+func NewRelationCheck(name string, fn RelationChecker) RelationCheck {
+	return RelationCheck{name, fn}
+}
+
+// NewComputedUsersetCheck returns a check with the given name, run over each relation or
+// permission referenced by a permission.
+func NewComputedUsersetCheck(name string, fn ComputedUsersetChecker) ComputedUsersetCheck {
+	return ComputedUsersetCheck{name, fn}
+}
+
+// NewTTUCheck returns a check with the given name, run over each arrow under a permission.
+func NewTTUCheck(name string, fn TTUChecker) TTUCheck {
+	return TTUCheck{name, fn}
 }
 
 type checks struct {
-	relationChecks        []relationCheck
-	computedUsersetChecks []computedUsersetCheck
-	ttuChecks             []ttuCheck
+	definitionChecks      []DefinitionCheck
+	relationChecks        []RelationCheck
+	computedUsersetChecks []ComputedUsersetCheck
+	ttuChecks             []TTUCheck
 }
 
-func walkUsersetRewrite(ctx context.Context, rewrite *corev1.UsersetRewrite, relation *corev1.Relation, checks checks, def *schema.Definition) ([]*devinterface.DeveloperWarning, error) {
+func walkUsersetRewrite(ctx context.Context, rewrite *corev1.UsersetRewrite, relation *corev1.Relation, rules *LintRules, def *schema.Definition) ([]*devinterface.DeveloperWarning, error) {
 	if rewrite == nil {
 		return nil, nil
 	}
 
 	switch t := (rewrite.RewriteOperation).(type) {
 	case *corev1.UsersetRewrite_Union:
-		return walkUsersetOperations(ctx, t.Union.Child, relation, checks, def)
+		return walkUsersetOperations(ctx, t.Union.Child, relation, rules, def)
 
 	case *corev1.UsersetRewrite_Intersection:
-		return walkUsersetOperations(ctx, t.Intersection.Child, relation, checks, def)
+		return walkUsersetOperations(ctx, t.Intersection.Child, relation, rules, def)
 
 	case *corev1.UsersetRewrite_Exclusion:
-		return walkUsersetOperations(ctx, t.Exclusion.Child, relation, checks, def)
+		return walkUsersetOperations(ctx, t.Exclusion.Child, relation, rules, def)
 
 	default:
 		return nil, spiceerrors.MustBugf("unexpected rewrite operation type %T", t)
 	}
 }
 
-func walkUsersetOperations(ctx context.Context, ops []*corev1.SetOperation_Child, relation *corev1.Relation, checks checks, def *schema.Definition) ([]*devinterface.DeveloperWarning, error) {
+func walkUsersetOperations(ctx context.Context, ops []*corev1.SetOperation_Child, relation *corev1.Relation, rules *LintRules, def *schema.Definition) ([]*devinterface.DeveloperWarning, error) {
 	warnings := []*devinterface.DeveloperWarning{}
 	for _, op := range ops {
 		switch t := op.ChildType.(type) {
@@ -197,8 +262,8 @@ func walkUsersetOperations(ctx context.Context, ops []*corev1.SetOperation_Child
 			continue
 
 		case *corev1.SetOperation_Child_ComputedUserset:
-			for _, check := range checks.computedUsersetChecks {
-				if shouldSkipCheck(relation.Metadata, check.name) {
+			for _, check := range rules.checks.computedUsersetChecks {
+				if rules.shouldSkipCheck(relation.Metadata, check.name) {
 					continue
 				}
 
@@ -208,12 +273,12 @@ func walkUsersetOperations(ctx context.Context, ops []*corev1.SetOperation_Child
 				}
 
 				if checkerWarning != nil {
-					warnings = append(warnings, checkerWarning)
+					warnings = append(warnings, rules.report(ctx, check.name, checkerWarning))
 				}
 			}
 
 		case *corev1.SetOperation_Child_UsersetRewrite:
-			found, err := walkUsersetRewrite(ctx, t.UsersetRewrite, relation, checks, def)
+			found, err := walkUsersetRewrite(ctx, t.UsersetRewrite, relation, rules, def)
 			if err != nil {
 				return nil, err
 			}
@@ -221,8 +286,8 @@ func walkUsersetOperations(ctx context.Context, ops []*corev1.SetOperation_Child
 			warnings = append(warnings, found...)
 
 		case *corev1.SetOperation_Child_FunctionedTupleToUserset:
-			for _, check := range checks.ttuChecks {
-				if shouldSkipCheck(relation.Metadata, check.name) {
+			for _, check := range rules.checks.ttuChecks {
+				if rules.shouldSkipCheck(relation.Metadata, check.name) {
 					continue
 				}
 
@@ -232,13 +297,13 @@ func walkUsersetOperations(ctx context.Context, ops []*corev1.SetOperation_Child
 				}
 
 				if checkerWarning != nil {
-					warnings = append(warnings, checkerWarning)
+					warnings = append(warnings, rules.report(ctx, check.name, checkerWarning))
 				}
 			}
 
 		case *corev1.SetOperation_Child_TupleToUserset:
-			for _, check := range checks.ttuChecks {
-				if shouldSkipCheck(relation.Metadata, check.name) {
+			for _, check := range rules.checks.ttuChecks {
+				if rules.shouldSkipCheck(relation.Metadata, check.name) {
 					continue
 				}
 
@@ -248,7 +313,7 @@ func walkUsersetOperations(ctx context.Context, ops []*corev1.SetOperation_Child
 				}
 
 				if checkerWarning != nil {
-					warnings = append(warnings, checkerWarning)
+					warnings = append(warnings, rules.report(ctx, check.name, checkerWarning))
 				}
 			}
 
@@ -267,7 +332,7 @@ type wrappedFunctionedTTU struct {
 	*corev1.FunctionedTupleToUserset
 }
 
-func (wfttu wrappedFunctionedTTU) GetTupleset() tupleset {
+func (wfttu wrappedFunctionedTTU) GetTupleset() Tupleset {
 	return wfttu.FunctionedTupleToUserset.GetTupleset()
 }
 
@@ -295,7 +360,7 @@ type wrappedTTU struct {
 	*corev1.TupleToUserset
 }
 
-func (wtu wrappedTTU) GetTupleset() tupleset {
+func (wtu wrappedTTU) GetTupleset() Tupleset {
 	return wtu.TupleToUserset.GetTupleset()
 }
 
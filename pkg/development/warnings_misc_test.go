@@ -11,7 +11,7 @@ import (
 )
 
 func TestWarningForPositionNilSourcePosition(t *testing.T) {
-	warning := warningForPosition("test-warning", "test message", "source code", nil)
+	warning := WarningForPosition("test-warning", "test message", "source code", nil)
 	require.NotNil(t, warning)
 	require.Equal(t, "test message", warning.Message) // No warning name appended when nil source position
 	require.Equal(t, "source code", warning.SourceCode)
@@ -25,7 +25,7 @@ func TestWarningForPositionWithSourcePosition(t *testing.T) {
 		ZeroIndexedColumnPosition: 9,
 	}
 
-	warning := warningForPosition("test-warning", "test message", "source code", sourcePos)
+	warning := WarningForPosition("test-warning", "test message", "source code", sourcePos)
 	require.NotNil(t, warning)
 	require.Equal(t, "test message (test-warning)", warning.Message) // Warning name appended
 	require.Equal(t, "source code", warning.SourceCode)
@@ -47,7 +47,7 @@ func TestWarningForMetadata(t *testing.T) {
 		SourcePosition: sourcePos,
 	}
 
-	warning := warningForMetadata("metadata-warning", "metadata message", "source", relation)
+	warning := WarningForMetadata("metadata-warning", "metadata message", "source", relation)
 	require.NotNil(t, warning)
 	require.Equal(t, "metadata message (metadata-warning)", warning.Message)
 	require.Equal(t, "source", warning.SourceCode)
@@ -183,7 +183,7 @@ func TestWarningForPositionWithCastingErrors(t *testing.T) {
 		ZeroIndexedColumnPosition: 4294967296, // Larger than uint32 max (2^32)
 	}
 
-	warning := warningForPosition("cast-error", "test message", "source code", sourcePos)
+	warning := WarningForPosition("cast-error", "test message", "source code", sourcePos)
 	require.NotNil(t, warning)
 	require.Equal(t, "test message (cast-error)", warning.Message)
 	require.Equal(t, "source code", warning.SourceCode)
@@ -1,6 +1,7 @@
 package development
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -8,6 +9,17 @@ import (
 	developerv1 "github.com/authzed/spicedb/pkg/proto/developer/v1"
 )
 
+// ignoreWarningFix returns the fix ignoring the warning for the relation or permission declared
+// on the line, indented by four tabs.
+func ignoreWarningFix(warningName string, relationName string, line uint32) *developerv1.SuggestedFix {
+	return &developerv1.SuggestedFix{
+		Description: fmt.Sprintf("Ignore warning %q for %q", warningName, relationName),
+		Edits: []*developerv1.SchemaEdit{
+			{Line: line, Column: 5, EndLine: line, EndColumn: 5, NewText: "// spicedb-ignore-warning: " + warningName + "\n\t\t\t\t"},
+		},
+	}
+}
+
 func TestWarnings(t *testing.T) {
 	tcs := []struct {
 		name            string
@@ -39,6 +51,9 @@ func TestWarnings(t *testing.T) {
 				Line:       2,
 				Column:     23,
 				SourceCode: "view",
+				SuggestedFixes: []*developerv1.SuggestedFix{
+					ignoreWarningFix("permission-references-itself", "view", 2),
+				},
 			},
 		},
 		{
@@ -53,6 +68,9 @@ func TestWarnings(t *testing.T) {
 				Line:       4,
 				Column:     42,
 				SourceCode: "view",
+				SuggestedFixes: []*developerv1.SuggestedFix{
+					ignoreWarningFix("permission-references-itself", "view", 4),
+				},
 			},
 		},
 		{
@@ -73,6 +91,9 @@ func TestWarnings(t *testing.T) {
 				Line:       9,
 				Column:     23,
 				SourceCode: "group->member",
+				SuggestedFixes: []*developerv1.SuggestedFix{
+					ignoreWarningFix("arrow-references-relation", "view", 9),
+				},
 			},
 		},
 		{
@@ -92,6 +113,9 @@ func TestWarnings(t *testing.T) {
 				Line:       8,
 				Column:     23,
 				SourceCode: "group->member",
+				SuggestedFixes: []*developerv1.SuggestedFix{
+					ignoreWarningFix("arrow-references-unreachable-relation", "view", 8),
+				},
 			},
 		},
 		{
@@ -113,6 +137,9 @@ func TestWarnings(t *testing.T) {
 				Line:       10,
 				Column:     23,
 				SourceCode: "parent_group->member",
+				SuggestedFixes: []*developerv1.SuggestedFix{
+					ignoreWarningFix("arrow-walks-subject-relation", "view", 10),
+				},
 			},
 		},
 		{
@@ -134,6 +161,9 @@ func TestWarnings(t *testing.T) {
 				Line:       10,
 				Column:     23,
 				SourceCode: "parent_group.all(member)",
+				SuggestedFixes: []*developerv1.SuggestedFix{
+					ignoreWarningFix("arrow-walks-subject-relation", "view", 10),
+				},
 			},
 		},
 		{
@@ -149,6 +179,15 @@ func TestWarnings(t *testing.T) {
 				Line:       5,
 				Column:     5,
 				SourceCode: "view_document",
+				SuggestedFixes: []*developerv1.SuggestedFix{
+					{
+						Description: "Rename \"view_document\" to \"view\"",
+						Edits: []*developerv1.SchemaEdit{
+							{Line: 5, Column: 16, EndLine: 5, EndColumn: 29, NewText: "view"},
+						},
+					},
+					ignoreWarningFix("relation-name-references-parent", "view_document", 5),
+				},
 			},
 		},
 		{
@@ -199,6 +238,9 @@ func TestWarnings(t *testing.T) {
 				Line:       3,
 				Column:     23,
 				SourceCode: "view",
+				SuggestedFixes: []*developerv1.SuggestedFix{
+					ignoreWarningFix("permission-references-itself", "view", 3),
+				},
 			},
 		},
 		{
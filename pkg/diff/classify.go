@@ -0,0 +1,191 @@
+package diff
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/authzed/spicedb/pkg/diff/caveats"
+	"github.com/authzed/spicedb/pkg/diff/namespace"
+	"github.com/authzed/spicedb/pkg/schema"
+)
+
+// ChangeClass defines the class of a change made to a schema, by how safe it is to apply.
+type ChangeClass string
+
+const (
+	// ChangeAdditive indicates that the change adds to the schema, or only changes its comments,
+	// and so is safe to apply.
+	ChangeAdditive ChangeClass = "additive"
+
+	// ChangeBehaviorChanging indicates that the change does not affect the relationships stored,
+	// but changes the results of the API, such as a changed permission granting access to other
+	// subjects, or a removed permission no longer being checkable.
+	ChangeBehaviorChanging ChangeClass = "behavior-changing"
+
+	// ChangeDataBreaking indicates that the change invalidates relationships which may be stored
+	// for the schema, such as those of a removed relation. Such changes cannot be applied while
+	// the relationships exist.
+	ChangeDataBreaking ChangeClass = "data-breaking"
+)
+
+var namespaceDeltaClasses = map[namespace.DeltaType]ChangeClass{
+	namespace.NamespaceAdded:             ChangeAdditive,
+	namespace.NamespaceRemoved:           ChangeDataBreaking,
+	namespace.NamespaceCommentsChanged:   ChangeAdditive,
+	namespace.AddedRelation:              ChangeAdditive,
+	namespace.RemovedRelation:            ChangeDataBreaking,
+	namespace.RenamedRelation:            ChangeBehaviorChanging,
+	namespace.AddedPermission:            ChangeAdditive,
+	namespace.RemovedPermission:          ChangeBehaviorChanging,
+	namespace.ChangedPermissionImpl:      ChangeBehaviorChanging,
+	namespace.ChangedPermissionComment:   ChangeAdditive,
+	namespace.LegacyChangedRelationImpl:  ChangeBehaviorChanging,
+	namespace.RelationAllowedTypeAdded:   ChangeAdditive,
+	namespace.RelationAllowedTypeRemoved: ChangeDataBreaking,
+	namespace.ChangedRelationComment:     ChangeAdditive,
+}
+
+var caveatDeltaClasses = map[caveats.DeltaType]ChangeClass{
+	caveats.CaveatAdded:             ChangeAdditive,
+	caveats.CaveatRemoved:           ChangeDataBreaking,
+	caveats.CaveatCommentsChanged:   ChangeAdditive,
+	caveats.AddedParameter:          ChangeBehaviorChanging,
+	caveats.RemovedParameter:        ChangeDataBreaking,
+	caveats.ParameterTypeChanged:    ChangeDataBreaking,
+	caveats.CaveatExpressionChanged: ChangeBehaviorChanging,
+}
+
+// ClassifyNamespaceDelta returns the class of the namespace delta.
+func ClassifyNamespaceDelta(delta namespace.Delta) ChangeClass {
+	if class, ok := namespaceDeltaClasses[delta.Type]; ok {
+		return class
+	}
+
+	// Unknown deltas are treated as the least safe.
+	return ChangeDataBreaking
+}
+
+// ClassifyCaveatDelta returns the class of the caveat delta.
+func ClassifyCaveatDelta(delta caveats.Delta) ChangeClass {
+	if class, ok := caveatDeltaClasses[delta.Type]; ok {
+		return class
+	}
+
+	// Unknown deltas are treated as the least safe.
+	return ChangeDataBreaking
+}
+
+// ClassifiedDelta is a delta of a schema diff, along with its class.
+type ClassifiedDelta struct {
+	// NamespaceName is the name of the namespace changed, if the delta is a namespace delta.
+	NamespaceName string
+
+	// NamespaceDelta is the delta of the namespace, if any.
+	NamespaceDelta *namespace.Delta
+
+	// CaveatName is the name of the caveat changed, if the delta is a caveat delta.
+	CaveatName string
+
+	// CaveatDelta is the delta of the caveat, if any.
+	CaveatDelta *caveats.Delta
+
+	// Class is the class of the delta.
+	Class ChangeClass
+}
+
+// MayWidenAccess returns whether the delta is a change of a permission which may grant access
+// to subjects which did not have it before, either because it widens access or because its
+// effect could not be determined.
+func (cd ClassifiedDelta) MayWidenAccess() bool {
+	if cd.NamespaceDelta == nil || cd.NamespaceDelta.Type != namespace.ChangedPermissionImpl {
+		return false
+	}
+
+	return cd.NamespaceDelta.AccessChange == namespace.AccessWidens || cd.NamespaceDelta.AccessChange == namespace.AccessUnknown
+}
+
+// ClassifiedDeltas returns the deltas of the diff along with their classes, including those of
+// the namespaces and caveats added and removed. The deltas are sorted by the namespace or caveat
+// to which they apply.
+func (sd *SchemaDiff) ClassifiedDeltas() []ClassifiedDelta {
+	classified := make([]ClassifiedDelta, 0)
+	addNamespaceDelta := func(name string, delta namespace.Delta) {
+		classified = append(classified, ClassifiedDelta{
+			NamespaceName:  name,
+			NamespaceDelta: &delta,
+			Class:          ClassifyNamespaceDelta(delta),
+		})
+	}
+	addCaveatDelta := func(name string, delta caveats.Delta) {
+		classified = append(classified, ClassifiedDelta{
+			CaveatName:  name,
+			CaveatDelta: &delta,
+			Class:       ClassifyCaveatDelta(delta),
+		})
+	}
+
+	for _, name := range sd.AddedNamespaces {
+		addNamespaceDelta(name, namespace.Delta{Type: namespace.NamespaceAdded})
+	}
+	for _, name := range sd.RemovedNamespaces {
+		addNamespaceDelta(name, namespace.Delta{Type: namespace.NamespaceRemoved})
+	}
+	for name, nsDiff := range sd.ChangedNamespaces {
+		for _, delta := range nsDiff.Deltas() {
+			addNamespaceDelta(name, delta)
+		}
+	}
+
+	for _, name := range sd.AddedCaveats {
+		addCaveatDelta(name, caveats.Delta{Type: caveats.CaveatAdded})
+	}
+	for _, name := range sd.RemovedCaveats {
+		addCaveatDelta(name, caveats.Delta{Type: caveats.CaveatRemoved})
+	}
+	for name, caveatDiff := range sd.ChangedCaveats {
+		for _, delta := range caveatDiff.Deltas() {
+			addCaveatDelta(name, delta)
+		}
+	}
+
+	slices.SortFunc(classified, func(a, b ClassifiedDelta) int {
+		return cmp.Or(
+			cmp.Compare(a.CaveatName, b.CaveatName),
+			cmp.Compare(a.NamespaceName, b.NamespaceName),
+			cmp.Compare(a.deltaType(), b.deltaType()),
+			cmp.Compare(a.subject(), b.subject()),
+		)
+	})
+	return classified
+}
+
+// WideningPermissionChanges returns the deltas of the diff changing permissions in a way which
+// may widen the access they grant.
+func (sd *SchemaDiff) WideningPermissionChanges() []ClassifiedDelta {
+	widening := make([]ClassifiedDelta, 0)
+	for _, delta := range sd.ClassifiedDeltas() {
+		if delta.MayWidenAccess() {
+			widening = append(widening, delta)
+		}
+	}
+	return widening
+}
+
+func (cd ClassifiedDelta) deltaType() string {
+	if cd.NamespaceDelta != nil {
+		return string(cd.NamespaceDelta.Type)
+	}
+	return string(cd.CaveatDelta.Type)
+}
+
+// subject returns the name of the relation or parameter to which the delta applies, if any,
+// along with the allowed type added or removed.
+func (cd ClassifiedDelta) subject() string {
+	if cd.NamespaceDelta != nil {
+		if cd.NamespaceDelta.AllowedType != nil {
+			return cd.NamespaceDelta.RelationName + ":" + schema.SourceForAllowedRelation(cd.NamespaceDelta.AllowedType)
+		}
+		return cd.NamespaceDelta.RelationName
+	}
+	return cd.CaveatDelta.ParameterName
+}
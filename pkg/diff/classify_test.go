@@ -0,0 +1,166 @@
+package diff
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	caveattypes "github.com/authzed/spicedb/pkg/caveats/types"
+	"github.com/authzed/spicedb/pkg/schemadsl/compiler"
+	"github.com/authzed/spicedb/pkg/schemadsl/input"
+)
+
+func TestClassifiedDeltas(t *testing.T) {
+	existingSchema := `definition user {}
+
+	definition team {
+		relation member: user
+	}
+
+	definition document {
+		relation viewer: user | team#member
+		relation editor: user
+		relation owner: user
+		permission edit = editor
+		permission view = viewer + edit
+		permission admin = owner
+		permission delete = owner
+	}
+
+	caveat only_weekdays(weekday string, timezone string) {
+		weekday != "saturday" && timezone != ""
+	}`
+
+	tcs := []struct {
+		name             string
+		comparisonSchema string
+		expected         []string
+		expectedWidening []string
+	}{
+		{
+			name:             "no changes",
+			comparisonSchema: existingSchema,
+			expected:         []string{},
+			expectedWidening: []string{},
+		},
+		{
+			name: "additive",
+			comparisonSchema: `definition user {}
+
+			definition team {
+				relation member: user
+			}
+
+			// documents, to be viewed
+			definition document {
+				relation viewer: user | team#member | user:*
+				relation editor: user
+				relation owner: user
+				relation commenter: user
+				permission edit = editor
+				permission view = viewer + edit
+				permission admin = owner
+				permission delete = owner
+				permission comment = commenter + edit
+			}
+
+			definition folder {}
+
+			caveat only_weekdays(weekday string, timezone string) {
+				weekday != "saturday" && timezone != ""
+			}
+
+			caveat only_weekends(weekday string) {
+				weekday == "saturday"
+			}`,
+			expected: []string{
+				"document added-permission comment: additive",
+				"document added-relation commenter: additive",
+				"document namespace-comments-changed: additive",
+				"document relation-allowed-type-added viewer: additive",
+				"folder namespace-added: additive",
+				"only_weekends caveat-added: additive",
+			},
+			expectedWidening: []string{},
+		},
+		{
+			name: "breaking",
+			comparisonSchema: `definition user {}
+
+			definition document {
+				relation viewer: user
+				relation owner: user
+				permission edit = owner
+				permission view = viewer + edit + owner
+				permission admin = owner & viewer
+			}
+
+			caveat only_weekdays(weekday int) {
+				weekday != 6
+			}`,
+			expected: []string{
+				"document changed-permission-implementation admin: behavior-changing (narrows)",
+				"document changed-permission-implementation edit: behavior-changing (unknown)",
+				"document changed-permission-implementation view: behavior-changing (widens)",
+				"document relation-allowed-type-removed viewer: data-breaking",
+				"document removed-permission delete: behavior-changing",
+				"document removed-relation editor: data-breaking",
+				"team namespace-removed: data-breaking",
+				"only_weekdays expression-has-changed: behavior-changing",
+				"only_weekdays parameter-type-changed weekday: data-breaking",
+				"only_weekdays removed-parameter timezone: data-breaking",
+			},
+			expectedWidening: []string{"edit", "view"},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			existing, err := compiler.Compile(compiler.InputSchema{
+				Source:       input.Source("schema"),
+				SchemaString: existingSchema,
+			}, compiler.AllowUnprefixedObjectType())
+			require.NoError(t, err)
+
+			comparison, err := compiler.Compile(compiler.InputSchema{
+				Source:       input.Source("schema"),
+				SchemaString: tc.comparisonSchema,
+			}, compiler.AllowUnprefixedObjectType())
+			require.NoError(t, err)
+
+			diff, err := DiffSchemas(NewDiffableSchemaFromCompiledSchema(existing), NewDiffableSchemaFromCompiledSchema(comparison), caveattypes.Default.TypeSet)
+			require.NoError(t, err)
+
+			found := make([]string, 0)
+			for _, delta := range diff.ClassifiedDeltas() {
+				if delta.NamespaceDelta != nil {
+					description := fmt.Sprintf("%s %s", delta.NamespaceName, delta.NamespaceDelta.Type)
+					if delta.NamespaceDelta.RelationName != "" {
+						description += " " + delta.NamespaceDelta.RelationName
+					}
+					description += ": " + string(delta.Class)
+					if delta.NamespaceDelta.AccessChange != "" {
+						description += fmt.Sprintf(" (%s)", delta.NamespaceDelta.AccessChange)
+					}
+					found = append(found, description)
+					continue
+				}
+
+				description := fmt.Sprintf("%s %s", delta.CaveatName, delta.CaveatDelta.Type)
+				if delta.CaveatDelta.ParameterName != "" {
+					description += " " + delta.CaveatDelta.ParameterName
+				}
+				found = append(found, description+": "+string(delta.Class))
+			}
+			require.Equal(t, tc.expected, found)
+
+			widening := make([]string, 0)
+			for _, delta := range diff.WideningPermissionChanges() {
+				require.True(t, delta.MayWidenAccess())
+				widening = append(widening, delta.NamespaceDelta.RelationName)
+			}
+			require.Equal(t, tc.expectedWidening, widening)
+		})
+	}
+}
@@ -84,6 +84,12 @@ type SchemaDiff struct {
 
 // DiffSchemas compares two schemas and returns the diff.
 func DiffSchemas(existing DiffableSchema, comparison DiffableSchema, caveatTypeSet *caveattypes.TypeSet) (*SchemaDiff, error) {
+	return DiffSchemasWithRenames(existing, comparison, caveatTypeSet, nil)
+}
+
+// DiffSchemasWithRenames compares two schemas and returns the diff, in which the relations
+// renamed, keyed by the name of their namespace, are reported as renamed.
+func DiffSchemasWithRenames(existing DiffableSchema, comparison DiffableSchema, caveatTypeSet *caveattypes.TypeSet, renames map[string]namespace.RelationRenames) (*SchemaDiff, error) {
 	existingNamespacesByName := make(map[string]*core.NamespaceDefinition, len(existing.ObjectDefinitions))
 	existingNamespaceNames := mapz.NewSet[string]()
 	for _, nsDef := range existing.ObjectDefinitions {
@@ -114,11 +120,19 @@ func DiffSchemas(existing DiffableSchema, comparison DiffableSchema, caveatTypeS
 
 	changedNamespaces := make(map[string]namespace.Diff, 0)
 	commonNamespaceNames := existingNamespaceNames.Intersect(comparisonNamespaceNames)
+	for nsName := range renames {
+		if !commonNamespaceNames.Has(nsName) {
+			if _, err := namespace.DiffNamespacesWithRenames(existingNamespacesByName[nsName], comparisonNamespacesByName[nsName], renames[nsName]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if err := commonNamespaceNames.ForEach(func(name string) error {
 		existingNamespace := existingNamespacesByName[name]
 		comparisonNamespace := comparisonNamespacesByName[name]
 
-		diff, err := namespace.DiffNamespaces(existingNamespace, comparisonNamespace)
+		diff, err := namespace.DiffNamespacesWithRenames(existingNamespace, comparisonNamespace, renames[name])
 		if err != nil {
 			return err
 		}
@@ -131,6 +131,41 @@ func TestDiffSchemasWithChangedNamespace(t *testing.T) {
 	require.Equal(t, "somerel", diff.ChangedNamespaces["user"].Deltas()[0].RelationName)
 }
 
+func TestDiffSchemasWithRenames(t *testing.T) {
+	existingSchema, err := compiler.Compile(compiler.InputSchema{
+		Source:       input.Source("schema"),
+		SchemaString: `definition user {} definition document { relation reader: user; permission view = reader; }`,
+	}, compiler.AllowUnprefixedObjectType())
+	require.NoError(t, err)
+
+	comparisonSchema, err := compiler.Compile(compiler.InputSchema{
+		Source:       input.Source("schema"),
+		SchemaString: `definition user {} definition document { relation viewer: user; permission view = viewer; }`,
+	}, compiler.AllowUnprefixedObjectType())
+	require.NoError(t, err)
+
+	diff, err := DiffSchemasWithRenames(
+		NewDiffableSchemaFromCompiledSchema(existingSchema),
+		NewDiffableSchemaFromCompiledSchema(comparisonSchema),
+		caveattypes.Default.TypeSet,
+		map[string]namespace.RelationRenames{"document": {"reader": "viewer"}},
+	)
+	require.NoError(t, err)
+
+	require.Len(t, diff.ChangedNamespaces, 1)
+	require.Equal(t, []namespace.Delta{
+		{Type: namespace.RenamedRelation, RelationName: "viewer", PreviousRelationName: "reader"},
+	}, diff.ChangedNamespaces["document"].Deltas())
+
+	_, err = DiffSchemasWithRenames(
+		NewDiffableSchemaFromCompiledSchema(existingSchema),
+		NewDiffableSchemaFromCompiledSchema(comparisonSchema),
+		caveattypes.Default.TypeSet,
+		map[string]namespace.RelationRenames{"folder": {"reader": "viewer"}},
+	)
+	require.ErrorAs(t, err, &namespace.InvalidRelationRenameError{})
+}
+
 func TestDiffSchemasWithChangedCaveat(t *testing.T) {
 	existingSchema, err := compiler.Compile(compiler.InputSchema{
 		Source:       input.Source("schema"),
@@ -0,0 +1,322 @@
+package namespace
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+)
+
+// AccessChange defines how a change to the expression of a permission changes the set of
+// subjects to which the permission grants access.
+type AccessChange string
+
+const (
+	// AccessUnchanged indicates that the permission grants access to the same subjects.
+	AccessUnchanged AccessChange = "unchanged"
+
+	// AccessWidens indicates that the permission grants access to the same subjects as before,
+	// and possibly to others.
+	AccessWidens AccessChange = "widens"
+
+	// AccessNarrows indicates that the permission grants access only to subjects which had access
+	// before, and possibly not to all of them.
+	AccessNarrows AccessChange = "narrows"
+
+	// AccessUnknown indicates that the change may both grant and revoke access, or that its
+	// effect could not be determined. Such a change must be treated as possibly widening access.
+	AccessUnknown AccessChange = "unknown"
+)
+
+// AccessChange returns how the change of the expression changes the access granted by it.
+//
+// The analysis is conservative: a change is only reported as widening or narrowing access if
+// it does so for every possible set of relationships, which holds for terms added to or removed
+// from a union, intersection or exclusion, and for arrows switched between `any` and `all`.
+// Changes referencing other relations or permissions are reported as unknown, since whether
+// they widen access depends on the relations referenced.
+func (ed *ExpressionDiff) AccessChange() AccessChange {
+	switch ed.change {
+	case ExpressionUnchanged:
+		return AccessUnchanged
+
+	case ExpressionOperationChanged:
+		return operationChangeAccess(ed.existing, ed.updated)
+	}
+
+	operation, _ := rewriteOperation(ed.updated)
+	change := AccessUnchanged
+	for _, childDiff := range ed.childDiffs {
+		change = combineAccessChanges(change, childDiff.accessChange(operation))
+	}
+
+	// Children of unions and intersections are compared by position, so reordering the
+	// children, or inserting one before others, is found as a change of each child moved. As
+	// such operations are commutative, compare their children as sets instead.
+	if change == AccessUnknown && ed.change == ExpressionChildrenChanged {
+		return commutativeAccessChange(ed.existing, ed.updated)
+	}
+
+	return change
+}
+
+// accessChange returns how the change of the set operation, found under an operation of the
+// given type, changes the access granted by the expression.
+func (od *OperationDiff) accessChange(operation string) AccessChange {
+	// The subtracted child of an exclusion grants access when it is narrowed, rather than when it
+	// is widened. Terms added to or removed from it are subtracted as a union.
+	subtracted := operation == "exclusion" && od.position > 0
+	if subtracted {
+		operation = "union"
+	}
+
+	var change AccessChange
+	switch od.change {
+	case OperationAdded:
+		change = addedTermAccess(operation)
+
+	case OperationRemoved:
+		change = invertAccessChange(addedTermAccess(operation))
+
+	case OperationChildExpressionChanged:
+		change = od.childExprDiff.AccessChange()
+
+	case OperationTypeChanged:
+		change = childTypeChangeAccess(od.existing, od.updated)
+
+	default:
+		return AccessUnknown
+	}
+
+	if subtracted {
+		return invertAccessChange(change)
+	}
+	return change
+}
+
+// addedTermAccess returns how adding a term to an operation of the given type changes the access
+// granted by it.
+func addedTermAccess(operation string) AccessChange {
+	switch operation {
+	case "union":
+		return AccessWidens
+
+	case "intersection":
+		return AccessNarrows
+
+	default:
+		return AccessUnknown
+	}
+}
+
+// childTypeChangeAccess returns how replacing a set operation by one of another type changes the
+// access granted by it.
+func childTypeChangeAccess(existing *core.SetOperation_Child, updated *core.SetOperation_Child) AccessChange {
+	existingType, err := typeOfSetOperationChild(existing)
+	if err != nil {
+		return AccessUnknown
+	}
+
+	updatedType, err := typeOfSetOperationChild(updated)
+	if err != nil {
+		return AccessUnknown
+	}
+
+	switch {
+	case existingType == "nil":
+		return AccessWidens
+
+	case updatedType == "nil":
+		return AccessNarrows
+
+	case updatedType == "usersetrewrite":
+		// The set operation was combined with others, such as `viewer` becoming `viewer + editor`.
+		return nestedChildAccess(existing, updated.GetUsersetRewrite())
+
+	case existingType == "usersetrewrite":
+		return invertAccessChange(nestedChildAccess(updated, existing.GetUsersetRewrite()))
+	}
+
+	// An arrow over all subjects of the tupleset grants access to a subset of those granted
+	// by the same arrow over any of them.
+	existingArrow, existingIsArrow := arrowRelations(existing)
+	updatedArrow, updatedIsArrow := arrowRelations(updated)
+	if !existingIsArrow || !updatedIsArrow || existingArrow != updatedArrow {
+		return AccessUnknown
+	}
+
+	switch {
+	case updatedType == "intersectionttu":
+		return AccessNarrows
+
+	case existingType == "intersectionttu":
+		return AccessWidens
+
+	default:
+		return AccessUnchanged
+	}
+}
+
+// nestedChildAccess returns how replacing the set operation by the rewrite, combining it with
+// other terms, changes the access granted by it.
+func nestedChildAccess(child *core.SetOperation_Child, rewrite *core.UsersetRewrite) AccessChange {
+	operation, setOperation := rewriteOperation(rewrite)
+	children := setOperation.GetChild()
+	if operation == "exclusion" {
+		// Subtracting from the set operation only ever narrows the access it grants.
+		if len(children) > 0 && !areDifferentChildren(child, children[0]) {
+			return AccessNarrows
+		}
+		return AccessUnknown
+	}
+
+	for _, nested := range children {
+		if !areDifferentChildren(child, nested) {
+			return addedTermAccess(operation)
+		}
+	}
+	return AccessUnknown
+}
+
+// arrowRelations returns the tupleset and computed userset relations of the set operation, if it
+// is an arrow.
+func arrowRelations(child *core.SetOperation_Child) ([2]string, bool) {
+	if ttu := child.GetTupleToUserset(); ttu != nil {
+		return [2]string{ttu.GetTupleset().GetRelation(), ttu.GetComputedUserset().GetRelation()}, true
+	}
+
+	if ttu := child.GetFunctionedTupleToUserset(); ttu != nil {
+		return [2]string{ttu.GetTupleset().GetRelation(), ttu.GetComputedUserset().GetRelation()}, true
+	}
+
+	return [2]string{}, false
+}
+
+// operationChangeAccess returns how replacing the operation of an expression changes the access
+// granted by it. Only changes between operations over the same children are analyzed.
+func operationChangeAccess(existing *core.UsersetRewrite, updated *core.UsersetRewrite) AccessChange {
+	existingOperation, existingSetOperation := rewriteOperation(existing)
+	updatedOperation, updatedSetOperation := rewriteOperation(updated)
+
+	existingOnly, updatedOnly := childrenDifference(existingSetOperation.GetChild(), updatedSetOperation.GetChild())
+	if len(existingOnly) > 0 || len(updatedOnly) > 0 {
+		return AccessUnknown
+	}
+
+	// The union of the children grants access to any subject granted access by either their
+	// intersection or their exclusion.
+	switch {
+	case updatedOperation == "union":
+		return AccessWidens
+
+	case existingOperation == "union":
+		return AccessNarrows
+
+	default:
+		return AccessUnknown
+	}
+}
+
+// commutativeAccessChange returns how the change of the children of a union or intersection
+// changes the access granted by it, comparing the children without regard to their order.
+func commutativeAccessChange(existing *core.UsersetRewrite, updated *core.UsersetRewrite) AccessChange {
+	operation, existingSetOperation := rewriteOperation(existing)
+	if operation != "union" && operation != "intersection" {
+		return AccessUnknown
+	}
+
+	_, updatedSetOperation := rewriteOperation(updated)
+	existingOnly, updatedOnly := childrenDifference(existingSetOperation.GetChild(), updatedSetOperation.GetChild())
+	switch {
+	case len(existingOnly) > 0 && len(updatedOnly) > 0:
+		return AccessUnknown
+
+	case len(updatedOnly) > 0:
+		return addedTermAccess(operation)
+
+	case len(existingOnly) > 0:
+		return invertAccessChange(addedTermAccess(operation))
+
+	default:
+		return AccessUnchanged
+	}
+}
+
+// childrenDifference returns the existing children not found in the updated children, and the
+// updated children not found in the existing children.
+func childrenDifference(existing []*core.SetOperation_Child, updated []*core.SetOperation_Child) ([]*core.SetOperation_Child, []*core.SetOperation_Child) {
+	difference := func(children []*core.SetOperation_Child, others []*core.SetOperation_Child) []*core.SetOperation_Child {
+		found := make([]*core.SetOperation_Child, 0)
+		for _, child := range children {
+			matched := false
+			for _, other := range others {
+				if !areDifferentChildren(child, other) {
+					matched = true
+					break
+				}
+			}
+
+			if !matched {
+				found = append(found, child)
+			}
+		}
+		return found
+	}
+
+	return difference(existing, updated), difference(updated, existing)
+}
+
+func areDifferentChildren(existing *core.SetOperation_Child, updated *core.SetOperation_Child) bool {
+	// Return whether the children are different, ignoring the SourcePosition message type.
+	delta := cmp.Diff(
+		existing,
+		updated,
+		protocmp.Transform(),
+		protocmp.IgnoreMessages(&core.SourcePosition{}),
+	)
+	return delta != ""
+}
+
+// rewriteOperation returns the type and set operation of the rewrite.
+func rewriteOperation(rewrite *core.UsersetRewrite) (string, *core.SetOperation) {
+	switch t := rewrite.GetRewriteOperation().(type) {
+	case *core.UsersetRewrite_Union:
+		return "union", t.Union
+
+	case *core.UsersetRewrite_Intersection:
+		return "intersection", t.Intersection
+
+	case *core.UsersetRewrite_Exclusion:
+		return "exclusion", t.Exclusion
+
+	default:
+		return "", nil
+	}
+}
+
+// combineAccessChanges returns the access change of an expression with both of the changes.
+func combineAccessChanges(first AccessChange, second AccessChange) AccessChange {
+	switch {
+	case first == AccessUnchanged:
+		return second
+
+	case second == AccessUnchanged || first == second:
+		return first
+
+	default:
+		return AccessUnknown
+	}
+}
+
+func invertAccessChange(change AccessChange) AccessChange {
+	switch change {
+	case AccessWidens:
+		return AccessNarrows
+
+	case AccessNarrows:
+		return AccessWidens
+
+	default:
+		return change
+	}
+}
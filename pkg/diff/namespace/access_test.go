@@ -0,0 +1,176 @@
+package namespace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpressionAccessChange(t *testing.T) {
+	tcs := []struct {
+		name     string
+		existing string
+		updated  string
+		expected AccessChange
+	}{
+		{
+			name:     "no change",
+			existing: `viewer + editor`,
+			updated:  `viewer + editor`,
+			expected: AccessUnchanged,
+		},
+		{
+			name:     "union term added",
+			existing: `viewer`,
+			updated:  `viewer + editor`,
+			expected: AccessWidens,
+		},
+		{
+			name:     "union term removed",
+			existing: `viewer + editor`,
+			updated:  `viewer`,
+			expected: AccessNarrows,
+		},
+		{
+			name:     "union term inserted before others",
+			existing: `viewer + editor`,
+			updated:  `owner + viewer + editor`,
+			expected: AccessWidens,
+		},
+		{
+			name:     "union terms reordered",
+			existing: `viewer + editor + owner`,
+			updated:  `owner + viewer + editor`,
+			expected: AccessUnchanged,
+		},
+		{
+			name:     "union term replaced",
+			existing: `viewer + editor`,
+			updated:  `viewer + owner`,
+			expected: AccessUnknown,
+		},
+		{
+			name:     "intersection term added",
+			existing: `viewer & editor`,
+			updated:  `viewer & editor & owner`,
+			expected: AccessNarrows,
+		},
+		{
+			name:     "intersection term removed",
+			existing: `viewer & editor & owner`,
+			updated:  `viewer & owner`,
+			expected: AccessWidens,
+		},
+		{
+			name:     "expanded to intersection",
+			existing: `viewer`,
+			updated:  `viewer & editor`,
+			expected: AccessNarrows,
+		},
+		{
+			name:     "expanded to exclusion",
+			existing: `viewer`,
+			updated:  `viewer - banned`,
+			expected: AccessNarrows,
+		},
+		{
+			name:     "exclusion subtracting more",
+			existing: `viewer - banned`,
+			updated:  `viewer - (banned + suspended)`,
+			expected: AccessNarrows,
+		},
+		{
+			name:     "exclusion subtracting less",
+			existing: `viewer - (banned + suspended)`,
+			updated:  `viewer - banned`,
+			expected: AccessWidens,
+		},
+		{
+			name:     "exclusion base widened",
+			existing: `viewer - banned`,
+			updated:  `(viewer + editor) - banned`,
+			expected: AccessWidens,
+		},
+		{
+			name:     "exclusion replaced by union",
+			existing: `viewer - banned`,
+			updated:  `viewer + banned`,
+			expected: AccessWidens,
+		},
+		{
+			name:     "union replaced by intersection",
+			existing: `viewer + editor`,
+			updated:  `viewer & editor`,
+			expected: AccessNarrows,
+		},
+		{
+			name:     "intersection replaced by exclusion",
+			existing: `viewer & editor`,
+			updated:  `viewer - editor`,
+			expected: AccessUnknown,
+		},
+		{
+			name:     "nested union term added",
+			existing: `viewer + (editor & owner)`,
+			updated:  `viewer + (editor & (owner + admin))`,
+			expected: AccessWidens,
+		},
+		{
+			name:     "widened and narrowed",
+			existing: `(viewer + editor) & (owner + admin)`,
+			updated:  `(viewer + editor + reader) & owner`,
+			expected: AccessUnknown,
+		},
+		{
+			name:     "arrow changed to all",
+			existing: `parent->view`,
+			updated:  `parent.all(view)`,
+			expected: AccessNarrows,
+		},
+		{
+			name:     "arrow changed to any",
+			existing: `parent.all(view)`,
+			updated:  `parent.any(view)`,
+			expected: AccessWidens,
+		},
+		{
+			name:     "arrow changed to any of another relation",
+			existing: `parent.all(view)`,
+			updated:  `parent.any(edit)`,
+			expected: AccessUnknown,
+		},
+		{
+			name:     "nil replaced",
+			existing: `nil`,
+			updated:  `viewer`,
+			expected: AccessWidens,
+		},
+		{
+			name:     "replaced by nil",
+			existing: `viewer`,
+			updated:  `nil`,
+			expected: AccessNarrows,
+		},
+		{
+			name:     "computed userset changed",
+			existing: `viewer`,
+			updated:  `editor`,
+			expected: AccessUnknown,
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			parsedExisting, err := parseUsersetRewrite(tc.existing)
+			require.NoError(t, err)
+
+			parsedUpdated, err := parseUsersetRewrite(tc.updated)
+			require.NoError(t, err)
+
+			diff, err := DiffExpressions(parsedExisting, parsedUpdated)
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expected, diff.AccessChange())
+		})
+	}
+}
@@ -32,6 +32,9 @@ const (
 	// RemovedRelation indicates that the relation was removed from the namespace.
 	RemovedRelation DeltaType = "removed-relation"
 
+	// RenamedRelation indicates that the relation was renamed within the namespace.
+	RenamedRelation DeltaType = "renamed-relation"
+
 	// AddedPermission indicates that the permission was added to the namespace.
 	AddedPermission DeltaType = "added-permission"
 
@@ -82,8 +85,15 @@ type Delta struct {
 	// RelationName is the name of the relation to which this delta applies, if any.
 	RelationName string
 
+	// PreviousRelationName is the name of the relation before it was renamed, if any.
+	PreviousRelationName string
+
 	// AllowedType is the allowed relation type added or removed, if any.
 	AllowedType *core.AllowedRelation
+
+	// AccessChange is how the change of the implementation of the permission changes the access
+	// granted by it, if the delta is a ChangedPermissionImpl.
+	AccessChange AccessChange
 }
 
 // DiffNamespaces performs a diff between two namespace definitions. One or both of the definitions
@@ -204,15 +214,21 @@ func DiffNamespaces(existing *core.NamespaceDefinition, updated *core.NamespaceD
 		return nil
 	})
 
-	_ = existingPermNames.Intersect(updatedPermNames).ForEach(func(shared string) error {
+	if err := existingPermNames.Intersect(updatedPermNames).ForEach(func(shared string) error {
 		existingPerm := existingPerms[shared]
 		updatedPerm := updatedPerms[shared]
 
 		// Compare implementations.
 		if areDifferentExpressions(existingPerm.UsersetRewrite, updatedPerm.UsersetRewrite) {
+			accessChange, err := permissionAccessChange(existingPerm, updatedPerm)
+			if err != nil {
+				return err
+			}
+
 			deltas = append(deltas, Delta{
 				Type:         ChangedPermissionImpl,
 				RelationName: shared,
+				AccessChange: accessChange,
 			})
 		}
 
@@ -226,7 +242,9 @@ func DiffNamespaces(existing *core.NamespaceDefinition, updated *core.NamespaceD
 			})
 		}
 		return nil
-	})
+	}); err != nil {
+		return nil, err
+	}
 
 	_ = existingRelNames.Intersect(updatedRelNames).ForEach(func(shared string) error {
 		existingRel := existingRels[shared]
@@ -309,6 +327,20 @@ func isPermission(relation *core.Relation) bool {
 	return nspkg.GetRelationKind(relation) == iv1.RelationMetadata_PERMISSION
 }
 
+// permissionAccessChange returns how the change of the implementation of the permission changes
+// the access granted by it.
+func permissionAccessChange(existing *core.Relation, updated *core.Relation) (AccessChange, error) {
+	if existing.UsersetRewrite == nil || updated.UsersetRewrite == nil {
+		return AccessUnknown, nil
+	}
+
+	diff, err := DiffExpressions(existing.UsersetRewrite, updated.UsersetRewrite)
+	if err != nil {
+		return "", err
+	}
+	return diff.AccessChange(), nil
+}
+
 func areDifferentExpressions(existing *core.UsersetRewrite, updated *core.UsersetRewrite) bool {
 	// Return whether the rewrites are different, ignoring the SourcePosition message type.
 	delta := cmp.Diff(
@@ -195,7 +195,26 @@ func TestNamespaceDiff(t *testing.T) {
 				)),
 			),
 			[]Delta{
-				{Type: ChangedPermissionImpl, RelationName: "somerel"},
+				{Type: ChangedPermissionImpl, RelationName: "somerel", AccessChange: AccessUnknown},
+			},
+		},
+		{
+			"widened permission impl",
+			ns.Namespace(
+				"document",
+				ns.MustRelation("somerel", ns.Union(
+					ns.ComputedUserset("editor"),
+				)),
+			),
+			ns.Namespace(
+				"document",
+				ns.MustRelation("somerel", ns.Union(
+					ns.ComputedUserset("editor"),
+					ns.ComputedUserset("owner"),
+				)),
+			),
+			[]Delta{
+				{Type: ChangedPermissionImpl, RelationName: "somerel", AccessChange: AccessWidens},
 			},
 		},
 		{
@@ -231,7 +250,7 @@ func TestNamespaceDiff(t *testing.T) {
 				)),
 			),
 			[]Delta{
-				{Type: ChangedPermissionImpl, RelationName: "somerel"},
+				{Type: ChangedPermissionImpl, RelationName: "somerel", AccessChange: AccessUnknown},
 				{Type: ChangedPermissionComment, RelationName: "somerel"},
 			},
 		},
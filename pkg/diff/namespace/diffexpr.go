@@ -85,6 +85,9 @@ type OperationDiff struct {
 	updated       *core.SetOperation_Child
 	change        SetOperationChangeType
 	childExprDiff *ExpressionDiff
+
+	// position is the index of the set operation within the children of its expression.
+	position int
 }
 
 // Existing returns the existing set operation, if any.
@@ -167,19 +170,21 @@ func DiffExpressions(existing *core.UsersetRewrite, updated *core.UsersetRewrite
 
 	childDiffs := make([]*OperationDiff, 0, abs(len(updatedOperation.Child)-len(existingOperation.Child)))
 	if len(existingOperation.Child) < len(updatedOperation.Child) {
-		for _, updatedChild := range updatedOperation.Child[len(existingOperation.Child):] {
+		for index, updatedChild := range updatedOperation.Child[len(existingOperation.Child):] {
 			childDiffs = append(childDiffs, &OperationDiff{
-				change:  OperationAdded,
-				updated: updatedChild,
+				change:   OperationAdded,
+				updated:  updatedChild,
+				position: len(existingOperation.Child) + index,
 			})
 		}
 	}
 
 	if len(existingOperation.Child) > len(updatedOperation.Child) {
-		for _, existingChild := range existingOperation.Child[len(updatedOperation.Child):] {
+		for index, existingChild := range existingOperation.Child[len(updatedOperation.Child):] {
 			childDiffs = append(childDiffs, &OperationDiff{
 				change:   OperationRemoved,
 				existing: existingChild,
+				position: len(updatedOperation.Child) + index,
 			})
 		}
 	}
@@ -191,6 +196,7 @@ func DiffExpressions(existing *core.UsersetRewrite, updated *core.UsersetRewrite
 		}
 
 		if childDiff.change != OperationUnchanged {
+			childDiff.position = i
 			childDiffs = append(childDiffs, childDiff)
 		}
 	}
@@ -0,0 +1,163 @@
+package namespace
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/rs/zerolog"
+
+	"github.com/authzed/spicedb/pkg/graph"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+)
+
+// RelationRenames maps the previous names of the relations renamed in a namespace to their
+// new names.
+type RelationRenames map[string]string
+
+// InvalidRelationRenameError occurs when a relation rename does not apply to the namespaces
+// being diffed.
+type InvalidRelationRenameError struct {
+	error
+	namespaceName string
+	relationName  string
+}
+
+// MarshalZerologObject implements zerolog object marshalling.
+func (err InvalidRelationRenameError) MarshalZerologObject(e *zerolog.Event) {
+	e.Err(err.error).Str("namespace", err.namespaceName).Str("relation", err.relationName)
+}
+
+// DetailsMetadata returns the metadata for details for this error.
+func (err InvalidRelationRenameError) DetailsMetadata() map[string]string {
+	return map[string]string{
+		"definition_name": err.namespaceName,
+		"relation_name":   err.relationName,
+	}
+}
+
+func newInvalidRelationRenameError(nsName string, relationName string, message string, args ...any) error {
+	return InvalidRelationRenameError{
+		error:         fmt.Errorf(message, args...),
+		namespaceName: nsName,
+		relationName:  relationName,
+	}
+}
+
+// DiffNamespacesWithRenames performs a diff between two namespace definitions, in which the
+// relations renamed are reported as RenamedRelation, rather than as removed and added. The
+// references to the renamed relations by the permissions of the existing namespace are
+// treated as references to their new names.
+func DiffNamespacesWithRenames(existing *core.NamespaceDefinition, updated *core.NamespaceDefinition, renames RelationRenames) (*Diff, error) {
+	if len(renames) == 0 {
+		return DiffNamespaces(existing, updated)
+	}
+
+	if existing == nil || updated == nil {
+		nsName := existing.GetName()
+		if existing == nil {
+			nsName = updated.GetName()
+		}
+		return nil, newInvalidRelationRenameError(nsName, "", "cannot rename relations of definition `%s`, as it is being added or removed", nsName)
+	}
+
+	previousNames := make([]string, 0, len(renames))
+	for previousName := range renames {
+		previousNames = append(previousNames, previousName)
+	}
+	slices.Sort(previousNames)
+
+	for _, previousName := range previousNames {
+		if err := validateRelationRename(existing, updated, previousName, renames[previousName]); err != nil {
+			return nil, err
+		}
+	}
+
+	renamed := existing.CloneVT()
+	for _, relation := range renamed.Relation {
+		if newName, ok := renames[relation.Name]; ok {
+			relation.Name = newName
+		}
+
+		if _, err := graph.WalkRewrite(relation.UsersetRewrite, func(childOneof *core.SetOperation_Child) (any, error) {
+			renameReferences(childOneof, renames)
+			return nil, nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	diff, err := DiffNamespaces(renamed, updated)
+	if err != nil {
+		return nil, err
+	}
+
+	deltas := make([]Delta, 0, len(previousNames)+len(diff.deltas))
+	for _, previousName := range previousNames {
+		deltas = append(deltas, Delta{
+			Type:                 RenamedRelation,
+			RelationName:         renames[previousName],
+			PreviousRelationName: previousName,
+		})
+	}
+
+	return &Diff{
+		existing: existing,
+		updated:  updated,
+		deltas:   append(deltas, diff.deltas...),
+	}, nil
+}
+
+// validateRelationRename ensures that the previous name is that of a relation only in the
+// existing namespace, and the new name that of a relation only in the updated namespace.
+func validateRelationRename(existing *core.NamespaceDefinition, updated *core.NamespaceDefinition, previousName string, newName string) error {
+	previous := findRelation(existing, previousName)
+	if previous == nil || isPermission(previous) {
+		return newInvalidRelationRenameError(existing.Name, previousName, "cannot rename `%s` in definition `%s`, as it is not an existing relation", previousName, existing.Name)
+	}
+
+	if findRelation(updated, previousName) != nil {
+		return newInvalidRelationRenameError(existing.Name, previousName, "cannot rename `%s` in definition `%s`, as it still exists in the updated definition", previousName, existing.Name)
+	}
+
+	if findRelation(existing, newName) != nil {
+		return newInvalidRelationRenameError(existing.Name, newName, "cannot rename `%s` to `%s` in definition `%s`, as `%s` already exists", previousName, newName, existing.Name, newName)
+	}
+
+	renamed := findRelation(updated, newName)
+	if renamed == nil || isPermission(renamed) {
+		return newInvalidRelationRenameError(existing.Name, newName, "cannot rename `%s` to `%s` in definition `%s`, as `%s` is not a relation in the updated definition", previousName, newName, existing.Name, newName)
+	}
+
+	return nil
+}
+
+// renameReferences renames the references to renamed relations of the namespace made by the
+// child of a permission. The computed relation of an arrow is that of another namespace, so
+// is left unchanged.
+func renameReferences(childOneof *core.SetOperation_Child, renames RelationRenames) {
+	switch child := childOneof.ChildType.(type) {
+	case *core.SetOperation_Child_ComputedUserset:
+		if newName, ok := renames[child.ComputedUserset.Relation]; ok {
+			child.ComputedUserset.Relation = newName
+		}
+
+	case *core.SetOperation_Child_TupleToUserset:
+		if newName, ok := renames[child.TupleToUserset.Tupleset.Relation]; ok {
+			child.TupleToUserset.Tupleset.Relation = newName
+		}
+
+	case *core.SetOperation_Child_FunctionedTupleToUserset:
+		if newName, ok := renames[child.FunctionedTupleToUserset.Tupleset.Relation]; ok {
+			child.FunctionedTupleToUserset.Tupleset.Relation = newName
+		}
+	}
+}
+
+func findRelation(nsDef *core.NamespaceDefinition, relationName string) *core.Relation {
+	for _, relation := range nsDef.Relation {
+		if relation.Name == relationName {
+			return relation
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package namespace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ns "github.com/authzed/spicedb/pkg/namespace"
+	core "github.com/authzed/spicedb/pkg/proto/core/v1"
+)
+
+func TestNamespaceDiffWithRenames(t *testing.T) {
+	testCases := []struct {
+		name           string
+		existing       *core.NamespaceDefinition
+		updated        *core.NamespaceDefinition
+		renames        RelationRenames
+		expectedDeltas []Delta
+	}{
+		{
+			"renamed relation",
+			ns.Namespace(
+				"document",
+				ns.MustRelation("reader", nil, ns.AllowedRelation("user", "...")),
+			),
+			ns.Namespace(
+				"document",
+				ns.MustRelation("viewer", nil, ns.AllowedRelation("user", "...")),
+			),
+			RelationRenames{"reader": "viewer"},
+			[]Delta{
+				{Type: RenamedRelation, RelationName: "viewer", PreviousRelationName: "reader"},
+			},
+		},
+		{
+			"renamed relation referenced by permissions",
+			ns.Namespace(
+				"document",
+				ns.MustRelation("reader", nil, ns.AllowedRelation("user", "...")),
+				ns.MustRelation("parent", nil, ns.AllowedRelation("folder", "...")),
+				ns.MustRelation("view", ns.Union(
+					ns.ComputedUserset("reader"),
+					ns.TupleToUserset("parent", "view"),
+				)),
+			),
+			ns.Namespace(
+				"document",
+				ns.MustRelation("viewer", nil, ns.AllowedRelation("user", "...")),
+				ns.MustRelation("folder", nil, ns.AllowedRelation("folder", "...")),
+				ns.MustRelation("view", ns.Union(
+					ns.ComputedUserset("viewer"),
+					ns.TupleToUserset("folder", "view"),
+				)),
+			),
+			RelationRenames{"reader": "viewer", "parent": "folder"},
+			[]Delta{
+				{Type: RenamedRelation, RelationName: "folder", PreviousRelationName: "parent"},
+				{Type: RenamedRelation, RelationName: "viewer", PreviousRelationName: "reader"},
+			},
+		},
+		{
+			"renamed relation with changed allowed types",
+			ns.Namespace(
+				"document",
+				ns.MustRelation("reader", nil, ns.AllowedRelation("user", "...")),
+			),
+			ns.Namespace(
+				"document",
+				ns.MustRelation("viewer", nil,
+					ns.AllowedRelation("user", "..."),
+					ns.AllowedRelation("group", "member"),
+				),
+			),
+			RelationRenames{"reader": "viewer"},
+			[]Delta{
+				{Type: RenamedRelation, RelationName: "viewer", PreviousRelationName: "reader"},
+				{Type: RelationAllowedTypeAdded, RelationName: "viewer", AllowedType: ns.AllowedRelation("group", "member")},
+			},
+		},
+		{
+			"no renames",
+			ns.Namespace(
+				"document",
+				ns.MustRelation("reader", nil),
+			),
+			ns.Namespace(
+				"document",
+				ns.MustRelation("viewer", nil),
+			),
+			nil,
+			[]Delta{
+				{Type: RemovedRelation, RelationName: "reader"},
+				{Type: AddedRelation, RelationName: "viewer"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			diff, err := DiffNamespacesWithRenames(tc.existing, tc.updated, tc.renames)
+			require.NoError(t, err)
+			require.ElementsMatch(t, tc.expectedDeltas, diff.Deltas())
+		})
+	}
+}
+
+func TestNamespaceDiffWithInvalidRenames(t *testing.T) {
+	existing := ns.Namespace(
+		"document",
+		ns.MustRelation("reader", nil),
+		ns.MustRelation("writer", nil),
+		ns.MustRelation("view", ns.Union(ns.ComputedUserset("reader"))),
+	)
+
+	testCases := []struct {
+		name          string
+		updated       *core.NamespaceDefinition
+		renames       RelationRenames
+		expectedError string
+	}{
+		{
+			"unknown relation",
+			ns.Namespace(
+				"document",
+				ns.MustRelation("viewer", nil),
+			),
+			RelationRenames{"unknown": "viewer"},
+			"cannot rename `unknown` in definition `document`, as it is not an existing relation",
+		},
+		{
+			"permission",
+			ns.Namespace(
+				"document",
+				ns.MustRelation("reader", nil),
+				ns.MustRelation("writer", nil),
+				ns.MustRelation("canview", nil),
+			),
+			RelationRenames{"view": "canview"},
+			"cannot rename `view` in definition `document`, as it is not an existing relation",
+		},
+		{
+			"relation still exists",
+			ns.Namespace(
+				"document",
+				ns.MustRelation("reader", nil),
+				ns.MustRelation("viewer", nil),
+			),
+			RelationRenames{"reader": "viewer"},
+			"cannot rename `reader` in definition `document`, as it still exists in the updated definition",
+		},
+		{
+			"existing target",
+			ns.Namespace(
+				"document",
+				ns.MustRelation("writer", nil),
+			),
+			RelationRenames{"reader": "writer"},
+			"cannot rename `reader` to `writer` in definition `document`, as `writer` already exists",
+		},
+		{
+			"missing target",
+			ns.Namespace(
+				"document",
+				ns.MustRelation("writer", nil),
+			),
+			RelationRenames{"reader": "viewer"},
+			"cannot rename `reader` to `viewer` in definition `document`, as `viewer` is not a relation in the updated definition",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := DiffNamespacesWithRenames(existing, tc.updated, tc.renames)
+			require.ErrorAs(t, err, &InvalidRelationRenameError{})
+			require.EqualError(t, err, tc.expectedError)
+		})
+	}
+
+	t.Run("removed namespace", func(t *testing.T) {
+		_, err := DiffNamespacesWithRenames(existing, nil, RelationRenames{"reader": "viewer"})
+		require.ErrorAs(t, err, &InvalidRelationRenameError{})
+	})
+}
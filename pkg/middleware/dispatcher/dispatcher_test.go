@@ -50,6 +50,10 @@ func (m *fakeDispatcher) DispatchLookupSubjects(req *v1.DispatchLookupSubjectsRe
 	return nil
 }
 
+func (m *fakeDispatcher) DispatchQueryPlan(ctx context.Context, req *v1.DispatchQueryPlanRequest) (*v1.DispatchQueryPlanResponse, error) {
+	return &v1.DispatchQueryPlanResponse{}, nil
+}
+
 func (m *fakeDispatcher) Close() error {
 	return nil
 }
@@ -114,6 +114,12 @@ func fromContext(ctx context.Context) (bool, string, metadata.MD) {
 	return haveRequestID, requestID, md
 }
 
+// FromContext returns the request ID found in the context, if any.
+func FromContext(ctx context.Context) (string, bool) {
+	haveRequestID, requestID, _ := fromContext(ctx)
+	return requestID, haveRequestID
+}
+
 // PropagateIfExists copies the request ID from the source context to the target context if it exists.
 // The updated target context is returned.
 func PropagateIfExists(source, target context.Context) context.Context {
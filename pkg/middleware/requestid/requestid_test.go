@@ -336,3 +336,13 @@ func (s *requestIDCustomGeneratorTestSuite) TestCustomGenerator() {
 	require.NotEmpty(s.T(), requestIDs)
 	require.Contains(s.T(), requestIDs[0], s.customIDPrefix, "Custom generator should be used")
 }
+
+func TestFromContext(t *testing.T) {
+	_, found := FromContext(t.Context())
+	require.False(t, found)
+
+	ctx := metadata.NewIncomingContext(t.Context(), metadata.Pairs(metadataKey, "some-request"))
+	requestID, found := FromContext(ctx)
+	require.True(t, found)
+	require.Equal(t, "some-request", requestID)
+}
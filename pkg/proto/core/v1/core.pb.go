@@ -387,6 +387,109 @@ func (CaveatOperation_Operation) EnumDescriptor() ([]byte, []int) {
 	return file_core_v1_core_proto_rawDescGZIP(), []int{30, 0}
 }
 
+type RelationshipFilter_ExpirationFilter int32
+
+const (
+	// EXPIRATION_FILTER_UNSPECIFIED matches all non-expired relationships, regardless of
+	// whether they have an expiration.
+	RelationshipFilter_EXPIRATION_FILTER_UNSPECIFIED RelationshipFilter_ExpirationFilter = 0
+	// EXPIRATION_FILTER_HAS_EXPIRATION matches only non-expired relationships which have
+	// an expiration.
+	RelationshipFilter_EXPIRATION_FILTER_HAS_EXPIRATION RelationshipFilter_ExpirationFilter = 1
+	// EXPIRATION_FILTER_NO_EXPIRATION matches only relationships without an expiration.
+	RelationshipFilter_EXPIRATION_FILTER_NO_EXPIRATION RelationshipFilter_ExpirationFilter = 2
+)
+
+// Enum value maps for RelationshipFilter_ExpirationFilter.
+var (
+	RelationshipFilter_ExpirationFilter_name = map[int32]string{
+		0: "EXPIRATION_FILTER_UNSPECIFIED",
+		1: "EXPIRATION_FILTER_HAS_EXPIRATION",
+		2: "EXPIRATION_FILTER_NO_EXPIRATION",
+	}
+	RelationshipFilter_ExpirationFilter_value = map[string]int32{
+		"EXPIRATION_FILTER_UNSPECIFIED":    0,
+		"EXPIRATION_FILTER_HAS_EXPIRATION": 1,
+		"EXPIRATION_FILTER_NO_EXPIRATION":  2,
+	}
+)
+
+func (x RelationshipFilter_ExpirationFilter) Enum() *RelationshipFilter_ExpirationFilter {
+	p := new(RelationshipFilter_ExpirationFilter)
+	*p = x
+	return p
+}
+
+func (x RelationshipFilter_ExpirationFilter) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RelationshipFilter_ExpirationFilter) Descriptor() protoreflect.EnumDescriptor {
+	return file_core_v1_core_proto_enumTypes[7].Descriptor()
+}
+
+func (RelationshipFilter_ExpirationFilter) Type() protoreflect.EnumType {
+	return &file_core_v1_core_proto_enumTypes[7]
+}
+
+func (x RelationshipFilter_ExpirationFilter) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RelationshipFilter_ExpirationFilter.Descriptor instead.
+func (RelationshipFilter_ExpirationFilter) EnumDescriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{31, 0}
+}
+
+type SchemaHistoryDelta_DefinitionKind int32
+
+const (
+	SchemaHistoryDelta_DEFINITION_KIND_UNSPECIFIED SchemaHistoryDelta_DefinitionKind = 0
+	SchemaHistoryDelta_DEFINITION_KIND_OBJECT      SchemaHistoryDelta_DefinitionKind = 1
+	SchemaHistoryDelta_DEFINITION_KIND_CAVEAT      SchemaHistoryDelta_DefinitionKind = 2
+)
+
+// Enum value maps for SchemaHistoryDelta_DefinitionKind.
+var (
+	SchemaHistoryDelta_DefinitionKind_name = map[int32]string{
+		0: "DEFINITION_KIND_UNSPECIFIED",
+		1: "DEFINITION_KIND_OBJECT",
+		2: "DEFINITION_KIND_CAVEAT",
+	}
+	SchemaHistoryDelta_DefinitionKind_value = map[string]int32{
+		"DEFINITION_KIND_UNSPECIFIED": 0,
+		"DEFINITION_KIND_OBJECT":      1,
+		"DEFINITION_KIND_CAVEAT":      2,
+	}
+)
+
+func (x SchemaHistoryDelta_DefinitionKind) Enum() *SchemaHistoryDelta_DefinitionKind {
+	p := new(SchemaHistoryDelta_DefinitionKind)
+	*p = x
+	return p
+}
+
+func (x SchemaHistoryDelta_DefinitionKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SchemaHistoryDelta_DefinitionKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_core_v1_core_proto_enumTypes[8].Descriptor()
+}
+
+func (SchemaHistoryDelta_DefinitionKind) Type() protoreflect.EnumType {
+	return &file_core_v1_core_proto_enumTypes[8]
+}
+
+func (x SchemaHistoryDelta_DefinitionKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SchemaHistoryDelta_DefinitionKind.Descriptor instead.
+func (SchemaHistoryDelta_DefinitionKind) EnumDescriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{34, 0}
+}
+
 type RelationTuple struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// * resource_and_relation is the resource for the tuple
@@ -2454,8 +2557,13 @@ type RelationshipFilter struct {
 	OptionalRelation string `protobuf:"bytes,3,opt,name=optional_relation,json=optionalRelation,proto3" json:"optional_relation,omitempty"`
 	// optional_subject_filter is the optional filter for the subjects of the relationships.
 	OptionalSubjectFilter *SubjectFilter `protobuf:"bytes,4,opt,name=optional_subject_filter,json=optionalSubjectFilter,proto3" json:"optional_subject_filter,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+	// optional_caveat_name is the *optional* name of the caveat on the relationship. If
+	// specified, only relationships with the named caveat are matched.
+	OptionalCaveatName string `protobuf:"bytes,6,opt,name=optional_caveat_name,json=optionalCaveatName,proto3" json:"optional_caveat_name,omitempty"`
+	// optional_expiration_filter is the *optional* filter on the expiration of the relationship.
+	OptionalExpirationFilter RelationshipFilter_ExpirationFilter `protobuf:"varint,7,opt,name=optional_expiration_filter,json=optionalExpirationFilter,proto3,enum=core.v1.RelationshipFilter_ExpirationFilter" json:"optional_expiration_filter,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
 }
 
 func (x *RelationshipFilter) Reset() {
@@ -2523,6 +2631,20 @@ func (x *RelationshipFilter) GetOptionalSubjectFilter() *SubjectFilter {
 	return nil
 }
 
+func (x *RelationshipFilter) GetOptionalCaveatName() string {
+	if x != nil {
+		return x.OptionalCaveatName
+	}
+	return ""
+}
+
+func (x *RelationshipFilter) GetOptionalExpirationFilter() RelationshipFilter_ExpirationFilter {
+	if x != nil {
+		return x.OptionalExpirationFilter
+	}
+	return RelationshipFilter_EXPIRATION_FILTER_UNSPECIFIED
+}
+
 // SubjectFilter specifies a filter on the subject of a relationship.
 //
 // subject_type is required and all other fields are optional, and will not
@@ -2587,6 +2709,189 @@ func (x *SubjectFilter) GetOptionalRelation() *SubjectFilter_RelationFilter {
 	return nil
 }
 
+// *
+// SchemaHistoryEntry is an entry in the log of the schema writes made to the datastore.
+type SchemaHistoryEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// * schema_text is the full schema written, in the schema language
+	SchemaText string `protobuf:"bytes,1,opt,name=schema_text,json=schemaText,proto3" json:"schema_text,omitempty"`
+	// * deltas are the changes made by the write to the previously written schema
+	Deltas []*SchemaHistoryDelta `protobuf:"bytes,2,rep,name=deltas,proto3" json:"deltas,omitempty"`
+	// * caller identifies the caller which wrote the schema, as authenticated by the auth middleware, if any
+	Caller string `protobuf:"bytes,3,opt,name=caller,proto3" json:"caller,omitempty"`
+	// * written_at is the time at which the schema was written
+	WrittenAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=written_at,json=writtenAt,proto3" json:"written_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaHistoryEntry) Reset() {
+	*x = SchemaHistoryEntry{}
+	mi := &file_core_v1_core_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaHistoryEntry) ProtoMessage() {}
+
+func (x *SchemaHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaHistoryEntry.ProtoReflect.Descriptor instead.
+func (*SchemaHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SchemaHistoryEntry) GetSchemaText() string {
+	if x != nil {
+		return x.SchemaText
+	}
+	return ""
+}
+
+func (x *SchemaHistoryEntry) GetDeltas() []*SchemaHistoryDelta {
+	if x != nil {
+		return x.Deltas
+	}
+	return nil
+}
+
+func (x *SchemaHistoryEntry) GetCaller() string {
+	if x != nil {
+		return x.Caller
+	}
+	return ""
+}
+
+func (x *SchemaHistoryEntry) GetWrittenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.WrittenAt
+	}
+	return nil
+}
+
+// *
+// SchemaHistoryDelta is a single change made by a schema write to an object definition or caveat.
+type SchemaHistoryDelta struct {
+	state          protoimpl.MessageState            `protogen:"open.v1"`
+	DefinitionKind SchemaHistoryDelta_DefinitionKind `protobuf:"varint,1,opt,name=definition_kind,json=definitionKind,proto3,enum=core.v1.SchemaHistoryDelta_DefinitionKind" json:"definition_kind,omitempty"`
+	// * definition_name is the name of the object definition or caveat changed
+	DefinitionName string `protobuf:"bytes,2,opt,name=definition_name,json=definitionName,proto3" json:"definition_name,omitempty"`
+	// * delta_type is the type of the change, such as `removed-relation`
+	DeltaType string `protobuf:"bytes,3,opt,name=delta_type,json=deltaType,proto3" json:"delta_type,omitempty"`
+	// * subject is the name of the relation or caveat parameter changed, if any
+	Subject string `protobuf:"bytes,4,opt,name=subject,proto3" json:"subject,omitempty"`
+	// * previous_subject is the previous name of the relation, if renamed
+	PreviousSubject string `protobuf:"bytes,5,opt,name=previous_subject,json=previousSubject,proto3" json:"previous_subject,omitempty"`
+	// * allowed_type is the allowed type added to or removed from the relation, if any
+	AllowedType string `protobuf:"bytes,6,opt,name=allowed_type,json=allowedType,proto3" json:"allowed_type,omitempty"`
+	// * change_class is the class of the change: `additive`, `behavior-changing` or `data-breaking`
+	ChangeClass string `protobuf:"bytes,7,opt,name=change_class,json=changeClass,proto3" json:"change_class,omitempty"`
+	// * access_change is how the change of a permission changes the access it grants, if any
+	AccessChange  string `protobuf:"bytes,8,opt,name=access_change,json=accessChange,proto3" json:"access_change,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaHistoryDelta) Reset() {
+	*x = SchemaHistoryDelta{}
+	mi := &file_core_v1_core_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaHistoryDelta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaHistoryDelta) ProtoMessage() {}
+
+func (x *SchemaHistoryDelta) ProtoReflect() protoreflect.Message {
+	mi := &file_core_v1_core_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaHistoryDelta.ProtoReflect.Descriptor instead.
+func (*SchemaHistoryDelta) Descriptor() ([]byte, []int) {
+	return file_core_v1_core_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SchemaHistoryDelta) GetDefinitionKind() SchemaHistoryDelta_DefinitionKind {
+	if x != nil {
+		return x.DefinitionKind
+	}
+	return SchemaHistoryDelta_DEFINITION_KIND_UNSPECIFIED
+}
+
+func (x *SchemaHistoryDelta) GetDefinitionName() string {
+	if x != nil {
+		return x.DefinitionName
+	}
+	return ""
+}
+
+func (x *SchemaHistoryDelta) GetDeltaType() string {
+	if x != nil {
+		return x.DeltaType
+	}
+	return ""
+}
+
+func (x *SchemaHistoryDelta) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *SchemaHistoryDelta) GetPreviousSubject() string {
+	if x != nil {
+		return x.PreviousSubject
+	}
+	return ""
+}
+
+func (x *SchemaHistoryDelta) GetAllowedType() string {
+	if x != nil {
+		return x.AllowedType
+	}
+	return ""
+}
+
+func (x *SchemaHistoryDelta) GetChangeClass() string {
+	if x != nil {
+		return x.ChangeClass
+	}
+	return ""
+}
+
+func (x *SchemaHistoryDelta) GetAccessChange() string {
+	if x != nil {
+		return x.AccessChange
+	}
+	return ""
+}
+
 type AllowedRelation_PublicWildcard struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
@@ -2595,7 +2900,7 @@ type AllowedRelation_PublicWildcard struct {
 
 func (x *AllowedRelation_PublicWildcard) Reset() {
 	*x = AllowedRelation_PublicWildcard{}
-	mi := &file_core_v1_core_proto_msgTypes[36]
+	mi := &file_core_v1_core_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2607,7 +2912,7 @@ func (x *AllowedRelation_PublicWildcard) String() string {
 func (*AllowedRelation_PublicWildcard) ProtoMessage() {}
 
 func (x *AllowedRelation_PublicWildcard) ProtoReflect() protoreflect.Message {
-	mi := &file_core_v1_core_proto_msgTypes[36]
+	mi := &file_core_v1_core_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2647,7 +2952,7 @@ type SetOperation_Child struct {
 
 func (x *SetOperation_Child) Reset() {
 	*x = SetOperation_Child{}
-	mi := &file_core_v1_core_proto_msgTypes[37]
+	mi := &file_core_v1_core_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2659,7 +2964,7 @@ func (x *SetOperation_Child) String() string {
 func (*SetOperation_Child) ProtoMessage() {}
 
 func (x *SetOperation_Child) ProtoReflect() protoreflect.Message {
-	mi := &file_core_v1_core_proto_msgTypes[37]
+	mi := &file_core_v1_core_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2798,7 +3103,7 @@ type SetOperation_Child_This struct {
 
 func (x *SetOperation_Child_This) Reset() {
 	*x = SetOperation_Child_This{}
-	mi := &file_core_v1_core_proto_msgTypes[38]
+	mi := &file_core_v1_core_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2810,7 +3115,7 @@ func (x *SetOperation_Child_This) String() string {
 func (*SetOperation_Child_This) ProtoMessage() {}
 
 func (x *SetOperation_Child_This) ProtoReflect() protoreflect.Message {
-	mi := &file_core_v1_core_proto_msgTypes[38]
+	mi := &file_core_v1_core_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2834,7 +3139,7 @@ type SetOperation_Child_Nil struct {
 
 func (x *SetOperation_Child_Nil) Reset() {
 	*x = SetOperation_Child_Nil{}
-	mi := &file_core_v1_core_proto_msgTypes[39]
+	mi := &file_core_v1_core_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2846,7 +3151,7 @@ func (x *SetOperation_Child_Nil) String() string {
 func (*SetOperation_Child_Nil) ProtoMessage() {}
 
 func (x *SetOperation_Child_Nil) ProtoReflect() protoreflect.Message {
-	mi := &file_core_v1_core_proto_msgTypes[39]
+	mi := &file_core_v1_core_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2871,7 +3176,7 @@ type TupleToUserset_Tupleset struct {
 
 func (x *TupleToUserset_Tupleset) Reset() {
 	*x = TupleToUserset_Tupleset{}
-	mi := &file_core_v1_core_proto_msgTypes[40]
+	mi := &file_core_v1_core_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2883,7 +3188,7 @@ func (x *TupleToUserset_Tupleset) String() string {
 func (*TupleToUserset_Tupleset) ProtoMessage() {}
 
 func (x *TupleToUserset_Tupleset) ProtoReflect() protoreflect.Message {
-	mi := &file_core_v1_core_proto_msgTypes[40]
+	mi := &file_core_v1_core_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2915,7 +3220,7 @@ type FunctionedTupleToUserset_Tupleset struct {
 
 func (x *FunctionedTupleToUserset_Tupleset) Reset() {
 	*x = FunctionedTupleToUserset_Tupleset{}
-	mi := &file_core_v1_core_proto_msgTypes[41]
+	mi := &file_core_v1_core_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2927,7 +3232,7 @@ func (x *FunctionedTupleToUserset_Tupleset) String() string {
 func (*FunctionedTupleToUserset_Tupleset) ProtoMessage() {}
 
 func (x *FunctionedTupleToUserset_Tupleset) ProtoReflect() protoreflect.Message {
-	mi := &file_core_v1_core_proto_msgTypes[41]
+	mi := &file_core_v1_core_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2959,7 +3264,7 @@ type SubjectFilter_RelationFilter struct {
 
 func (x *SubjectFilter_RelationFilter) Reset() {
 	*x = SubjectFilter_RelationFilter{}
-	mi := &file_core_v1_core_proto_msgTypes[42]
+	mi := &file_core_v1_core_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2971,7 +3276,7 @@ func (x *SubjectFilter_RelationFilter) String() string {
 func (*SubjectFilter_RelationFilter) ProtoMessage() {}
 
 func (x *SubjectFilter_RelationFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_core_v1_core_proto_msgTypes[42]
+	mi := &file_core_v1_core_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3184,19 +3489,46 @@ const file_core_v1_core_proto_rawDesc = "" +
 	"\aUNKNOWN\x10\x00\x12\x06\n" +
 	"\x02OR\x10\x01\x12\a\n" +
 	"\x03AND\x10\x02\x12\a\n" +
-	"\x03NOT\x10\x03\"\xee\x03\n" +
+	"\x03NOT\x10\x03\"\xcc\x06\n" +
 	"\x12RelationshipFilter\x12p\n" +
 	"\rresource_type\x18\x01 \x01(\tBK\xfaBHrF(\x80\x012A^(([a-z][a-z0-9_]{1,61}[a-z0-9]/)*[a-z][a-z0-9_]{1,62}[a-z0-9])?$R\fresourceType\x12W\n" +
 	"\x14optional_resource_id\x18\x02 \x01(\tB%\xfaB\"r (\x80\b2\x1b^([a-zA-Z0-9/_|\\-=+]{1,})?$R\x12optionalResourceId\x12d\n" +
 	"\x1boptional_resource_id_prefix\x18\x05 \x01(\tB%\xfaB\"r (\x80\b2\x1b^([a-zA-Z0-9/_|\\-=+]{1,})?$R\x18optionalResourceIdPrefix\x12W\n" +
 	"\x11optional_relation\x18\x03 \x01(\tB*\xfaB'r%(@2!^([a-z][a-z0-9_]{1,62}[a-z0-9])?$R\x10optionalRelation\x12N\n" +
-	"\x17optional_subject_filter\x18\x04 \x01(\v2\x16.core.v1.SubjectFilterR\x15optionalSubjectFilter\"\x86\x03\n" +
+	"\x17optional_subject_filter\x18\x04 \x01(\v2\x16.core.v1.SubjectFilterR\x15optionalSubjectFilter\x12c\n" +
+	"\x14optional_caveat_name\x18\x06 \x01(\tB1\xfaB.r,(\x80\x012'^([a-zA-Z0-9_][a-zA-Z0-9/_|-]{0,127})?$R\x12optionalCaveatName\x12t\n" +
+	"\x1aoptional_expiration_filter\x18\a \x01(\x0e2,.core.v1.RelationshipFilter.ExpirationFilterB\b\xfaB\x05\x82\x01\x02\x10\x01R\x18optionalExpirationFilter\"\x80\x01\n" +
+	"\x10ExpirationFilter\x12!\n" +
+	"\x1dEXPIRATION_FILTER_UNSPECIFIED\x10\x00\x12$\n" +
+	" EXPIRATION_FILTER_HAS_EXPIRATION\x10\x01\x12#\n" +
+	"\x1fEXPIRATION_FILTER_NO_EXPIRATION\x10\x02\"\x86\x03\n" +
 	"\rSubjectFilter\x12k\n" +
 	"\fsubject_type\x18\x01 \x01(\tBH\xfaBErC(\x80\x012>^([a-z][a-z0-9_]{1,61}[a-z0-9]/)*[a-z][a-z0-9_]{1,62}[a-z0-9]$R\vsubjectType\x12Z\n" +
 	"\x13optional_subject_id\x18\x02 \x01(\tB*\xfaB'r%(\x80\b2 ^(([a-zA-Z0-9/_|\\-=+]{1,})|\\*)?$R\x11optionalSubjectId\x12R\n" +
 	"\x11optional_relation\x18\x03 \x01(\v2%.core.v1.SubjectFilter.RelationFilterR\x10optionalRelation\x1aX\n" +
 	"\x0eRelationFilter\x12F\n" +
-	"\brelation\x18\x01 \x01(\tB*\xfaB'r%(@2!^([a-z][a-z0-9_]{1,62}[a-z0-9])?$R\brelationB\x8a\x01\n" +
+	"\brelation\x18\x01 \x01(\tB*\xfaB'r%(@2!^([a-z][a-z0-9_]{1,62}[a-z0-9])?$R\brelation\"\xbd\x01\n" +
+	"\x12SchemaHistoryEntry\x12\x1f\n" +
+	"\vschema_text\x18\x01 \x01(\tR\n" +
+	"schemaText\x123\n" +
+	"\x06deltas\x18\x02 \x03(\v2\x1b.core.v1.SchemaHistoryDeltaR\x06deltas\x12\x16\n" +
+	"\x06caller\x18\x03 \x01(\tR\x06caller\x129\n" +
+	"\n" +
+	"written_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\twrittenAt\"\xcc\x03\n" +
+	"\x12SchemaHistoryDelta\x12S\n" +
+	"\x0fdefinition_kind\x18\x01 \x01(\x0e2*.core.v1.SchemaHistoryDelta.DefinitionKindR\x0edefinitionKind\x12'\n" +
+	"\x0fdefinition_name\x18\x02 \x01(\tR\x0edefinitionName\x12\x1d\n" +
+	"\n" +
+	"delta_type\x18\x03 \x01(\tR\tdeltaType\x12\x18\n" +
+	"\asubject\x18\x04 \x01(\tR\asubject\x12)\n" +
+	"\x10previous_subject\x18\x05 \x01(\tR\x0fpreviousSubject\x12!\n" +
+	"\fallowed_type\x18\x06 \x01(\tR\vallowedType\x12!\n" +
+	"\fchange_class\x18\a \x01(\tR\vchangeClass\x12#\n" +
+	"\raccess_change\x18\b \x01(\tR\faccessChange\"i\n" +
+	"\x0eDefinitionKind\x12\x1f\n" +
+	"\x1bDEFINITION_KIND_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16DEFINITION_KIND_OBJECT\x10\x01\x12\x1a\n" +
+	"\x16DEFINITION_KIND_CAVEAT\x10\x02B\x8a\x01\n" +
 	"\vcom.core.v1B\tCoreProtoP\x01Z3github.com/authzed/spicedb/pkg/proto/core/v1;corev1\xa2\x02\x03CXX\xaa\x02\aCore.V1\xca\x02\aCore\\V1\xe2\x02\x13Core\\V1\\GPBMetadata\xea\x02\bCore::V1b\x06proto3"
 
 var (
@@ -3211,8 +3543,8 @@ func file_core_v1_core_proto_rawDescGZIP() []byte {
 	return file_core_v1_core_proto_rawDescData
 }
 
-var file_core_v1_core_proto_enumTypes = make([]protoimpl.EnumInfo, 7)
-var file_core_v1_core_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
+var file_core_v1_core_proto_enumTypes = make([]protoimpl.EnumInfo, 9)
+var file_core_v1_core_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
 var file_core_v1_core_proto_goTypes = []any{
 	(RelationTupleUpdate_Operation)(0),                     // 0: core.v1.RelationTupleUpdate.Operation
 	(SetOperationUserset_Operation)(0),                     // 1: core.v1.SetOperationUserset.Operation
@@ -3221,131 +3553,139 @@ var file_core_v1_core_proto_goTypes = []any{
 	(FunctionedTupleToUserset_Function)(0),                 // 4: core.v1.FunctionedTupleToUserset.Function
 	(ComputedUserset_Object)(0),                            // 5: core.v1.ComputedUserset.Object
 	(CaveatOperation_Operation)(0),                         // 6: core.v1.CaveatOperation.Operation
-	(*RelationTuple)(nil),                                  // 7: core.v1.RelationTuple
-	(*RelationshipIntegrity)(nil),                          // 8: core.v1.RelationshipIntegrity
-	(*ContextualizedCaveat)(nil),                           // 9: core.v1.ContextualizedCaveat
-	(*CaveatDefinition)(nil),                               // 10: core.v1.CaveatDefinition
-	(*CaveatTypeReference)(nil),                            // 11: core.v1.CaveatTypeReference
-	(*ObjectAndRelation)(nil),                              // 12: core.v1.ObjectAndRelation
-	(*RelationReference)(nil),                              // 13: core.v1.RelationReference
-	(*Zookie)(nil),                                         // 14: core.v1.Zookie
-	(*RelationTupleUpdate)(nil),                            // 15: core.v1.RelationTupleUpdate
-	(*RelationTupleTreeNode)(nil),                          // 16: core.v1.RelationTupleTreeNode
-	(*SetOperationUserset)(nil),                            // 17: core.v1.SetOperationUserset
-	(*DirectSubject)(nil),                                  // 18: core.v1.DirectSubject
-	(*DirectSubjects)(nil),                                 // 19: core.v1.DirectSubjects
-	(*Metadata)(nil),                                       // 20: core.v1.Metadata
-	(*NamespaceDefinition)(nil),                            // 21: core.v1.NamespaceDefinition
-	(*Relation)(nil),                                       // 22: core.v1.Relation
-	(*ReachabilityGraph)(nil),                              // 23: core.v1.ReachabilityGraph
-	(*ReachabilityEntrypoints)(nil),                        // 24: core.v1.ReachabilityEntrypoints
-	(*ReachabilityEntrypoint)(nil),                         // 25: core.v1.ReachabilityEntrypoint
-	(*TypeInformation)(nil),                                // 26: core.v1.TypeInformation
-	(*AllowedRelation)(nil),                                // 27: core.v1.AllowedRelation
-	(*ExpirationTrait)(nil),                                // 28: core.v1.ExpirationTrait
-	(*AllowedCaveat)(nil),                                  // 29: core.v1.AllowedCaveat
-	(*UsersetRewrite)(nil),                                 // 30: core.v1.UsersetRewrite
-	(*SetOperation)(nil),                                   // 31: core.v1.SetOperation
-	(*TupleToUserset)(nil),                                 // 32: core.v1.TupleToUserset
-	(*FunctionedTupleToUserset)(nil),                       // 33: core.v1.FunctionedTupleToUserset
-	(*ComputedUserset)(nil),                                // 34: core.v1.ComputedUserset
-	(*SourcePosition)(nil),                                 // 35: core.v1.SourcePosition
-	(*CaveatExpression)(nil),                               // 36: core.v1.CaveatExpression
-	(*CaveatOperation)(nil),                                // 37: core.v1.CaveatOperation
-	(*RelationshipFilter)(nil),                             // 38: core.v1.RelationshipFilter
-	(*SubjectFilter)(nil),                                  // 39: core.v1.SubjectFilter
-	nil,                                                    // 40: core.v1.CaveatDefinition.ParameterTypesEntry
-	nil,                                                    // 41: core.v1.ReachabilityGraph.EntrypointsBySubjectTypeEntry
-	nil,                                                    // 42: core.v1.ReachabilityGraph.EntrypointsBySubjectRelationEntry
-	(*AllowedRelation_PublicWildcard)(nil),                 // 43: core.v1.AllowedRelation.PublicWildcard
-	(*SetOperation_Child)(nil),                             // 44: core.v1.SetOperation.Child
-	(*SetOperation_Child_This)(nil),                        // 45: core.v1.SetOperation.Child.This
-	(*SetOperation_Child_Nil)(nil),                         // 46: core.v1.SetOperation.Child.Nil
-	(*TupleToUserset_Tupleset)(nil),                        // 47: core.v1.TupleToUserset.Tupleset
-	(*FunctionedTupleToUserset_Tupleset)(nil),              // 48: core.v1.FunctionedTupleToUserset.Tupleset
-	(*SubjectFilter_RelationFilter)(nil),                   // 49: core.v1.SubjectFilter.RelationFilter
-	(*timestamppb.Timestamp)(nil),                          // 50: google.protobuf.Timestamp
-	(*structpb.Struct)(nil),                                // 51: google.protobuf.Struct
-	(*anypb.Any)(nil),                                      // 52: google.protobuf.Any
+	(RelationshipFilter_ExpirationFilter)(0),               // 7: core.v1.RelationshipFilter.ExpirationFilter
+	(SchemaHistoryDelta_DefinitionKind)(0),                 // 8: core.v1.SchemaHistoryDelta.DefinitionKind
+	(*RelationTuple)(nil),                                  // 9: core.v1.RelationTuple
+	(*RelationshipIntegrity)(nil),                          // 10: core.v1.RelationshipIntegrity
+	(*ContextualizedCaveat)(nil),                           // 11: core.v1.ContextualizedCaveat
+	(*CaveatDefinition)(nil),                               // 12: core.v1.CaveatDefinition
+	(*CaveatTypeReference)(nil),                            // 13: core.v1.CaveatTypeReference
+	(*ObjectAndRelation)(nil),                              // 14: core.v1.ObjectAndRelation
+	(*RelationReference)(nil),                              // 15: core.v1.RelationReference
+	(*Zookie)(nil),                                         // 16: core.v1.Zookie
+	(*RelationTupleUpdate)(nil),                            // 17: core.v1.RelationTupleUpdate
+	(*RelationTupleTreeNode)(nil),                          // 18: core.v1.RelationTupleTreeNode
+	(*SetOperationUserset)(nil),                            // 19: core.v1.SetOperationUserset
+	(*DirectSubject)(nil),                                  // 20: core.v1.DirectSubject
+	(*DirectSubjects)(nil),                                 // 21: core.v1.DirectSubjects
+	(*Metadata)(nil),                                       // 22: core.v1.Metadata
+	(*NamespaceDefinition)(nil),                            // 23: core.v1.NamespaceDefinition
+	(*Relation)(nil),                                       // 24: core.v1.Relation
+	(*ReachabilityGraph)(nil),                              // 25: core.v1.ReachabilityGraph
+	(*ReachabilityEntrypoints)(nil),                        // 26: core.v1.ReachabilityEntrypoints
+	(*ReachabilityEntrypoint)(nil),                         // 27: core.v1.ReachabilityEntrypoint
+	(*TypeInformation)(nil),                                // 28: core.v1.TypeInformation
+	(*AllowedRelation)(nil),                                // 29: core.v1.AllowedRelation
+	(*ExpirationTrait)(nil),                                // 30: core.v1.ExpirationTrait
+	(*AllowedCaveat)(nil),                                  // 31: core.v1.AllowedCaveat
+	(*UsersetRewrite)(nil),                                 // 32: core.v1.UsersetRewrite
+	(*SetOperation)(nil),                                   // 33: core.v1.SetOperation
+	(*TupleToUserset)(nil),                                 // 34: core.v1.TupleToUserset
+	(*FunctionedTupleToUserset)(nil),                       // 35: core.v1.FunctionedTupleToUserset
+	(*ComputedUserset)(nil),                                // 36: core.v1.ComputedUserset
+	(*SourcePosition)(nil),                                 // 37: core.v1.SourcePosition
+	(*CaveatExpression)(nil),                               // 38: core.v1.CaveatExpression
+	(*CaveatOperation)(nil),                                // 39: core.v1.CaveatOperation
+	(*RelationshipFilter)(nil),                             // 40: core.v1.RelationshipFilter
+	(*SubjectFilter)(nil),                                  // 41: core.v1.SubjectFilter
+	(*SchemaHistoryEntry)(nil),                             // 42: core.v1.SchemaHistoryEntry
+	(*SchemaHistoryDelta)(nil),                             // 43: core.v1.SchemaHistoryDelta
+	nil,                                                    // 44: core.v1.CaveatDefinition.ParameterTypesEntry
+	nil,                                                    // 45: core.v1.ReachabilityGraph.EntrypointsBySubjectTypeEntry
+	nil,                                                    // 46: core.v1.ReachabilityGraph.EntrypointsBySubjectRelationEntry
+	(*AllowedRelation_PublicWildcard)(nil),                 // 47: core.v1.AllowedRelation.PublicWildcard
+	(*SetOperation_Child)(nil),                             // 48: core.v1.SetOperation.Child
+	(*SetOperation_Child_This)(nil),                        // 49: core.v1.SetOperation.Child.This
+	(*SetOperation_Child_Nil)(nil),                         // 50: core.v1.SetOperation.Child.Nil
+	(*TupleToUserset_Tupleset)(nil),                        // 51: core.v1.TupleToUserset.Tupleset
+	(*FunctionedTupleToUserset_Tupleset)(nil),              // 52: core.v1.FunctionedTupleToUserset.Tupleset
+	(*SubjectFilter_RelationFilter)(nil),                   // 53: core.v1.SubjectFilter.RelationFilter
+	(*timestamppb.Timestamp)(nil),                          // 54: google.protobuf.Timestamp
+	(*structpb.Struct)(nil),                                // 55: google.protobuf.Struct
+	(*anypb.Any)(nil),                                      // 56: google.protobuf.Any
 }
 var file_core_v1_core_proto_depIdxs = []int32{
-	12, // 0: core.v1.RelationTuple.resource_and_relation:type_name -> core.v1.ObjectAndRelation
-	12, // 1: core.v1.RelationTuple.subject:type_name -> core.v1.ObjectAndRelation
-	9,  // 2: core.v1.RelationTuple.caveat:type_name -> core.v1.ContextualizedCaveat
-	8,  // 3: core.v1.RelationTuple.integrity:type_name -> core.v1.RelationshipIntegrity
-	50, // 4: core.v1.RelationTuple.optional_expiration_time:type_name -> google.protobuf.Timestamp
-	50, // 5: core.v1.RelationshipIntegrity.hashed_at:type_name -> google.protobuf.Timestamp
-	51, // 6: core.v1.ContextualizedCaveat.context:type_name -> google.protobuf.Struct
-	40, // 7: core.v1.CaveatDefinition.parameter_types:type_name -> core.v1.CaveatDefinition.ParameterTypesEntry
-	20, // 8: core.v1.CaveatDefinition.metadata:type_name -> core.v1.Metadata
-	35, // 9: core.v1.CaveatDefinition.source_position:type_name -> core.v1.SourcePosition
-	11, // 10: core.v1.CaveatTypeReference.child_types:type_name -> core.v1.CaveatTypeReference
+	14, // 0: core.v1.RelationTuple.resource_and_relation:type_name -> core.v1.ObjectAndRelation
+	14, // 1: core.v1.RelationTuple.subject:type_name -> core.v1.ObjectAndRelation
+	11, // 2: core.v1.RelationTuple.caveat:type_name -> core.v1.ContextualizedCaveat
+	10, // 3: core.v1.RelationTuple.integrity:type_name -> core.v1.RelationshipIntegrity
+	54, // 4: core.v1.RelationTuple.optional_expiration_time:type_name -> google.protobuf.Timestamp
+	54, // 5: core.v1.RelationshipIntegrity.hashed_at:type_name -> google.protobuf.Timestamp
+	55, // 6: core.v1.ContextualizedCaveat.context:type_name -> google.protobuf.Struct
+	44, // 7: core.v1.CaveatDefinition.parameter_types:type_name -> core.v1.CaveatDefinition.ParameterTypesEntry
+	22, // 8: core.v1.CaveatDefinition.metadata:type_name -> core.v1.Metadata
+	37, // 9: core.v1.CaveatDefinition.source_position:type_name -> core.v1.SourcePosition
+	13, // 10: core.v1.CaveatTypeReference.child_types:type_name -> core.v1.CaveatTypeReference
 	0,  // 11: core.v1.RelationTupleUpdate.operation:type_name -> core.v1.RelationTupleUpdate.Operation
-	7,  // 12: core.v1.RelationTupleUpdate.tuple:type_name -> core.v1.RelationTuple
-	17, // 13: core.v1.RelationTupleTreeNode.intermediate_node:type_name -> core.v1.SetOperationUserset
-	19, // 14: core.v1.RelationTupleTreeNode.leaf_node:type_name -> core.v1.DirectSubjects
-	12, // 15: core.v1.RelationTupleTreeNode.expanded:type_name -> core.v1.ObjectAndRelation
-	36, // 16: core.v1.RelationTupleTreeNode.caveat_expression:type_name -> core.v1.CaveatExpression
+	9,  // 12: core.v1.RelationTupleUpdate.tuple:type_name -> core.v1.RelationTuple
+	19, // 13: core.v1.RelationTupleTreeNode.intermediate_node:type_name -> core.v1.SetOperationUserset
+	21, // 14: core.v1.RelationTupleTreeNode.leaf_node:type_name -> core.v1.DirectSubjects
+	14, // 15: core.v1.RelationTupleTreeNode.expanded:type_name -> core.v1.ObjectAndRelation
+	38, // 16: core.v1.RelationTupleTreeNode.caveat_expression:type_name -> core.v1.CaveatExpression
 	1,  // 17: core.v1.SetOperationUserset.operation:type_name -> core.v1.SetOperationUserset.Operation
-	16, // 18: core.v1.SetOperationUserset.child_nodes:type_name -> core.v1.RelationTupleTreeNode
-	12, // 19: core.v1.DirectSubject.subject:type_name -> core.v1.ObjectAndRelation
-	36, // 20: core.v1.DirectSubject.caveat_expression:type_name -> core.v1.CaveatExpression
-	18, // 21: core.v1.DirectSubjects.subjects:type_name -> core.v1.DirectSubject
-	52, // 22: core.v1.Metadata.metadata_message:type_name -> google.protobuf.Any
-	22, // 23: core.v1.NamespaceDefinition.relation:type_name -> core.v1.Relation
-	20, // 24: core.v1.NamespaceDefinition.metadata:type_name -> core.v1.Metadata
-	35, // 25: core.v1.NamespaceDefinition.source_position:type_name -> core.v1.SourcePosition
-	30, // 26: core.v1.Relation.userset_rewrite:type_name -> core.v1.UsersetRewrite
-	26, // 27: core.v1.Relation.type_information:type_name -> core.v1.TypeInformation
-	20, // 28: core.v1.Relation.metadata:type_name -> core.v1.Metadata
-	35, // 29: core.v1.Relation.source_position:type_name -> core.v1.SourcePosition
-	41, // 30: core.v1.ReachabilityGraph.entrypoints_by_subject_type:type_name -> core.v1.ReachabilityGraph.EntrypointsBySubjectTypeEntry
-	42, // 31: core.v1.ReachabilityGraph.entrypoints_by_subject_relation:type_name -> core.v1.ReachabilityGraph.EntrypointsBySubjectRelationEntry
-	25, // 32: core.v1.ReachabilityEntrypoints.entrypoints:type_name -> core.v1.ReachabilityEntrypoint
-	13, // 33: core.v1.ReachabilityEntrypoints.subject_relation:type_name -> core.v1.RelationReference
+	18, // 18: core.v1.SetOperationUserset.child_nodes:type_name -> core.v1.RelationTupleTreeNode
+	14, // 19: core.v1.DirectSubject.subject:type_name -> core.v1.ObjectAndRelation
+	38, // 20: core.v1.DirectSubject.caveat_expression:type_name -> core.v1.CaveatExpression
+	20, // 21: core.v1.DirectSubjects.subjects:type_name -> core.v1.DirectSubject
+	56, // 22: core.v1.Metadata.metadata_message:type_name -> google.protobuf.Any
+	24, // 23: core.v1.NamespaceDefinition.relation:type_name -> core.v1.Relation
+	22, // 24: core.v1.NamespaceDefinition.metadata:type_name -> core.v1.Metadata
+	37, // 25: core.v1.NamespaceDefinition.source_position:type_name -> core.v1.SourcePosition
+	32, // 26: core.v1.Relation.userset_rewrite:type_name -> core.v1.UsersetRewrite
+	28, // 27: core.v1.Relation.type_information:type_name -> core.v1.TypeInformation
+	22, // 28: core.v1.Relation.metadata:type_name -> core.v1.Metadata
+	37, // 29: core.v1.Relation.source_position:type_name -> core.v1.SourcePosition
+	45, // 30: core.v1.ReachabilityGraph.entrypoints_by_subject_type:type_name -> core.v1.ReachabilityGraph.EntrypointsBySubjectTypeEntry
+	46, // 31: core.v1.ReachabilityGraph.entrypoints_by_subject_relation:type_name -> core.v1.ReachabilityGraph.EntrypointsBySubjectRelationEntry
+	27, // 32: core.v1.ReachabilityEntrypoints.entrypoints:type_name -> core.v1.ReachabilityEntrypoint
+	15, // 33: core.v1.ReachabilityEntrypoints.subject_relation:type_name -> core.v1.RelationReference
 	2,  // 34: core.v1.ReachabilityEntrypoint.kind:type_name -> core.v1.ReachabilityEntrypoint.ReachabilityEntrypointKind
-	13, // 35: core.v1.ReachabilityEntrypoint.target_relation:type_name -> core.v1.RelationReference
+	15, // 35: core.v1.ReachabilityEntrypoint.target_relation:type_name -> core.v1.RelationReference
 	3,  // 36: core.v1.ReachabilityEntrypoint.result_status:type_name -> core.v1.ReachabilityEntrypoint.EntrypointResultStatus
-	27, // 37: core.v1.TypeInformation.allowed_direct_relations:type_name -> core.v1.AllowedRelation
-	43, // 38: core.v1.AllowedRelation.public_wildcard:type_name -> core.v1.AllowedRelation.PublicWildcard
-	35, // 39: core.v1.AllowedRelation.source_position:type_name -> core.v1.SourcePosition
-	29, // 40: core.v1.AllowedRelation.required_caveat:type_name -> core.v1.AllowedCaveat
-	28, // 41: core.v1.AllowedRelation.required_expiration:type_name -> core.v1.ExpirationTrait
-	31, // 42: core.v1.UsersetRewrite.union:type_name -> core.v1.SetOperation
-	31, // 43: core.v1.UsersetRewrite.intersection:type_name -> core.v1.SetOperation
-	31, // 44: core.v1.UsersetRewrite.exclusion:type_name -> core.v1.SetOperation
-	35, // 45: core.v1.UsersetRewrite.source_position:type_name -> core.v1.SourcePosition
-	44, // 46: core.v1.SetOperation.child:type_name -> core.v1.SetOperation.Child
-	47, // 47: core.v1.TupleToUserset.tupleset:type_name -> core.v1.TupleToUserset.Tupleset
-	34, // 48: core.v1.TupleToUserset.computed_userset:type_name -> core.v1.ComputedUserset
-	35, // 49: core.v1.TupleToUserset.source_position:type_name -> core.v1.SourcePosition
+	29, // 37: core.v1.TypeInformation.allowed_direct_relations:type_name -> core.v1.AllowedRelation
+	47, // 38: core.v1.AllowedRelation.public_wildcard:type_name -> core.v1.AllowedRelation.PublicWildcard
+	37, // 39: core.v1.AllowedRelation.source_position:type_name -> core.v1.SourcePosition
+	31, // 40: core.v1.AllowedRelation.required_caveat:type_name -> core.v1.AllowedCaveat
+	30, // 41: core.v1.AllowedRelation.required_expiration:type_name -> core.v1.ExpirationTrait
+	33, // 42: core.v1.UsersetRewrite.union:type_name -> core.v1.SetOperation
+	33, // 43: core.v1.UsersetRewrite.intersection:type_name -> core.v1.SetOperation
+	33, // 44: core.v1.UsersetRewrite.exclusion:type_name -> core.v1.SetOperation
+	37, // 45: core.v1.UsersetRewrite.source_position:type_name -> core.v1.SourcePosition
+	48, // 46: core.v1.SetOperation.child:type_name -> core.v1.SetOperation.Child
+	51, // 47: core.v1.TupleToUserset.tupleset:type_name -> core.v1.TupleToUserset.Tupleset
+	36, // 48: core.v1.TupleToUserset.computed_userset:type_name -> core.v1.ComputedUserset
+	37, // 49: core.v1.TupleToUserset.source_position:type_name -> core.v1.SourcePosition
 	4,  // 50: core.v1.FunctionedTupleToUserset.function:type_name -> core.v1.FunctionedTupleToUserset.Function
-	48, // 51: core.v1.FunctionedTupleToUserset.tupleset:type_name -> core.v1.FunctionedTupleToUserset.Tupleset
-	34, // 52: core.v1.FunctionedTupleToUserset.computed_userset:type_name -> core.v1.ComputedUserset
-	35, // 53: core.v1.FunctionedTupleToUserset.source_position:type_name -> core.v1.SourcePosition
+	52, // 51: core.v1.FunctionedTupleToUserset.tupleset:type_name -> core.v1.FunctionedTupleToUserset.Tupleset
+	36, // 52: core.v1.FunctionedTupleToUserset.computed_userset:type_name -> core.v1.ComputedUserset
+	37, // 53: core.v1.FunctionedTupleToUserset.source_position:type_name -> core.v1.SourcePosition
 	5,  // 54: core.v1.ComputedUserset.object:type_name -> core.v1.ComputedUserset.Object
-	35, // 55: core.v1.ComputedUserset.source_position:type_name -> core.v1.SourcePosition
-	37, // 56: core.v1.CaveatExpression.operation:type_name -> core.v1.CaveatOperation
-	9,  // 57: core.v1.CaveatExpression.caveat:type_name -> core.v1.ContextualizedCaveat
+	37, // 55: core.v1.ComputedUserset.source_position:type_name -> core.v1.SourcePosition
+	39, // 56: core.v1.CaveatExpression.operation:type_name -> core.v1.CaveatOperation
+	11, // 57: core.v1.CaveatExpression.caveat:type_name -> core.v1.ContextualizedCaveat
 	6,  // 58: core.v1.CaveatOperation.op:type_name -> core.v1.CaveatOperation.Operation
-	36, // 59: core.v1.CaveatOperation.children:type_name -> core.v1.CaveatExpression
-	39, // 60: core.v1.RelationshipFilter.optional_subject_filter:type_name -> core.v1.SubjectFilter
-	49, // 61: core.v1.SubjectFilter.optional_relation:type_name -> core.v1.SubjectFilter.RelationFilter
-	11, // 62: core.v1.CaveatDefinition.ParameterTypesEntry.value:type_name -> core.v1.CaveatTypeReference
-	24, // 63: core.v1.ReachabilityGraph.EntrypointsBySubjectTypeEntry.value:type_name -> core.v1.ReachabilityEntrypoints
-	24, // 64: core.v1.ReachabilityGraph.EntrypointsBySubjectRelationEntry.value:type_name -> core.v1.ReachabilityEntrypoints
-	45, // 65: core.v1.SetOperation.Child._this:type_name -> core.v1.SetOperation.Child.This
-	34, // 66: core.v1.SetOperation.Child.computed_userset:type_name -> core.v1.ComputedUserset
-	32, // 67: core.v1.SetOperation.Child.tuple_to_userset:type_name -> core.v1.TupleToUserset
-	30, // 68: core.v1.SetOperation.Child.userset_rewrite:type_name -> core.v1.UsersetRewrite
-	33, // 69: core.v1.SetOperation.Child.functioned_tuple_to_userset:type_name -> core.v1.FunctionedTupleToUserset
-	46, // 70: core.v1.SetOperation.Child._nil:type_name -> core.v1.SetOperation.Child.Nil
-	35, // 71: core.v1.SetOperation.Child.source_position:type_name -> core.v1.SourcePosition
-	72, // [72:72] is the sub-list for method output_type
-	72, // [72:72] is the sub-list for method input_type
-	72, // [72:72] is the sub-list for extension type_name
-	72, // [72:72] is the sub-list for extension extendee
-	0,  // [0:72] is the sub-list for field type_name
+	38, // 59: core.v1.CaveatOperation.children:type_name -> core.v1.CaveatExpression
+	41, // 60: core.v1.RelationshipFilter.optional_subject_filter:type_name -> core.v1.SubjectFilter
+	7,  // 61: core.v1.RelationshipFilter.optional_expiration_filter:type_name -> core.v1.RelationshipFilter.ExpirationFilter
+	53, // 62: core.v1.SubjectFilter.optional_relation:type_name -> core.v1.SubjectFilter.RelationFilter
+	43, // 63: core.v1.SchemaHistoryEntry.deltas:type_name -> core.v1.SchemaHistoryDelta
+	54, // 64: core.v1.SchemaHistoryEntry.written_at:type_name -> google.protobuf.Timestamp
+	8,  // 65: core.v1.SchemaHistoryDelta.definition_kind:type_name -> core.v1.SchemaHistoryDelta.DefinitionKind
+	13, // 66: core.v1.CaveatDefinition.ParameterTypesEntry.value:type_name -> core.v1.CaveatTypeReference
+	26, // 67: core.v1.ReachabilityGraph.EntrypointsBySubjectTypeEntry.value:type_name -> core.v1.ReachabilityEntrypoints
+	26, // 68: core.v1.ReachabilityGraph.EntrypointsBySubjectRelationEntry.value:type_name -> core.v1.ReachabilityEntrypoints
+	49, // 69: core.v1.SetOperation.Child._this:type_name -> core.v1.SetOperation.Child.This
+	36, // 70: core.v1.SetOperation.Child.computed_userset:type_name -> core.v1.ComputedUserset
+	34, // 71: core.v1.SetOperation.Child.tuple_to_userset:type_name -> core.v1.TupleToUserset
+	32, // 72: core.v1.SetOperation.Child.userset_rewrite:type_name -> core.v1.UsersetRewrite
+	35, // 73: core.v1.SetOperation.Child.functioned_tuple_to_userset:type_name -> core.v1.FunctionedTupleToUserset
+	50, // 74: core.v1.SetOperation.Child._nil:type_name -> core.v1.SetOperation.Child.Nil
+	37, // 75: core.v1.SetOperation.Child.source_position:type_name -> core.v1.SourcePosition
+	76, // [76:76] is the sub-list for method output_type
+	76, // [76:76] is the sub-list for method input_type
+	76, // [76:76] is the sub-list for extension type_name
+	76, // [76:76] is the sub-list for extension extendee
+	0,  // [0:76] is the sub-list for field type_name
 }
 
 func init() { file_core_v1_core_proto_init() }
@@ -3370,7 +3710,7 @@ func file_core_v1_core_proto_init() {
 		(*CaveatExpression_Operation)(nil),
 		(*CaveatExpression_Caveat)(nil),
 	}
-	file_core_v1_core_proto_msgTypes[37].OneofWrappers = []any{
+	file_core_v1_core_proto_msgTypes[39].OneofWrappers = []any{
 		(*SetOperation_Child_XThis)(nil),
 		(*SetOperation_Child_ComputedUserset)(nil),
 		(*SetOperation_Child_TupleToUserset)(nil),
@@ -3383,8 +3723,8 @@ func file_core_v1_core_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_core_v1_core_proto_rawDesc), len(file_core_v1_core_proto_rawDesc)),
-			NumEnums:      7,
-			NumMessages:   43,
+			NumEnums:      9,
+			NumMessages:   45,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
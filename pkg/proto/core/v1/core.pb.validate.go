@@ -5625,6 +5625,39 @@ func (m *RelationshipFilter) validate(all bool) error {
 		}
 	}
 
+	if len(m.GetOptionalCaveatName()) > 128 {
+		err := RelationshipFilterValidationError{
+			field:  "OptionalCaveatName",
+			reason: "value length must be at most 128 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_RelationshipFilter_OptionalCaveatName_Pattern.MatchString(m.GetOptionalCaveatName()) {
+		err := RelationshipFilterValidationError{
+			field:  "OptionalCaveatName",
+			reason: "value does not match regex pattern \"^([a-zA-Z0-9_][a-zA-Z0-9/_|-]{0,127})?$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := RelationshipFilter_ExpirationFilter_name[int32(m.GetOptionalExpirationFilter())]; !ok {
+		err := RelationshipFilterValidationError{
+			field:  "OptionalExpirationFilter",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
 	if len(errors) > 0 {
 		return RelationshipFilterMultiError(errors)
 	}
@@ -5713,6 +5746,8 @@ var _RelationshipFilter_OptionalResourceIdPrefix_Pattern = regexp.MustCompile("^
 
 var _RelationshipFilter_OptionalRelation_Pattern = regexp.MustCompile("^([a-z][a-z0-9_]{1,62}[a-z0-9])?$")
 
+var _RelationshipFilter_OptionalCaveatName_Pattern = regexp.MustCompile("^([a-zA-Z0-9_][a-zA-Z0-9/_|-]{0,127})?$")
+
 // Validate checks the field values on SubjectFilter with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -5890,6 +5925,293 @@ var _SubjectFilter_SubjectType_Pattern = regexp.MustCompile("^([a-z][a-z0-9_]{1,
 
 var _SubjectFilter_OptionalSubjectId_Pattern = regexp.MustCompile("^(([a-zA-Z0-9/_|\\-=+]{1,})|\\*)?$")
 
+// Validate checks the field values on SchemaHistoryEntry with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SchemaHistoryEntry) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SchemaHistoryEntry with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SchemaHistoryEntryMultiError, or nil if none found.
+func (m *SchemaHistoryEntry) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SchemaHistoryEntry) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SchemaText
+
+	for idx, item := range m.GetDeltas() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SchemaHistoryEntryValidationError{
+						field:  fmt.Sprintf("Deltas[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SchemaHistoryEntryValidationError{
+						field:  fmt.Sprintf("Deltas[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SchemaHistoryEntryValidationError{
+					field:  fmt.Sprintf("Deltas[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Caller
+
+	if all {
+		switch v := interface{}(m.GetWrittenAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SchemaHistoryEntryValidationError{
+					field:  "WrittenAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SchemaHistoryEntryValidationError{
+					field:  "WrittenAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetWrittenAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SchemaHistoryEntryValidationError{
+				field:  "WrittenAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SchemaHistoryEntryMultiError(errors)
+	}
+
+	return nil
+}
+
+// SchemaHistoryEntryMultiError is an error wrapping multiple validation errors
+// returned by SchemaHistoryEntry.ValidateAll() if the designated constraints
+// aren't met.
+type SchemaHistoryEntryMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SchemaHistoryEntryMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SchemaHistoryEntryMultiError) AllErrors() []error { return m }
+
+// SchemaHistoryEntryValidationError is the validation error returned by
+// SchemaHistoryEntry.Validate if the designated constraints aren't met.
+type SchemaHistoryEntryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SchemaHistoryEntryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SchemaHistoryEntryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SchemaHistoryEntryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SchemaHistoryEntryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SchemaHistoryEntryValidationError) ErrorName() string {
+	return "SchemaHistoryEntryValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SchemaHistoryEntryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSchemaHistoryEntry.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SchemaHistoryEntryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SchemaHistoryEntryValidationError{}
+
+// Validate checks the field values on SchemaHistoryDelta with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SchemaHistoryDelta) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SchemaHistoryDelta with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SchemaHistoryDeltaMultiError, or nil if none found.
+func (m *SchemaHistoryDelta) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SchemaHistoryDelta) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for DefinitionKind
+
+	// no validation rules for DefinitionName
+
+	// no validation rules for DeltaType
+
+	// no validation rules for Subject
+
+	// no validation rules for PreviousSubject
+
+	// no validation rules for AllowedType
+
+	// no validation rules for ChangeClass
+
+	// no validation rules for AccessChange
+
+	if len(errors) > 0 {
+		return SchemaHistoryDeltaMultiError(errors)
+	}
+
+	return nil
+}
+
+// SchemaHistoryDeltaMultiError is an error wrapping multiple validation errors
+// returned by SchemaHistoryDelta.ValidateAll() if the designated constraints
+// aren't met.
+type SchemaHistoryDeltaMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SchemaHistoryDeltaMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SchemaHistoryDeltaMultiError) AllErrors() []error { return m }
+
+// SchemaHistoryDeltaValidationError is the validation error returned by
+// SchemaHistoryDelta.Validate if the designated constraints aren't met.
+type SchemaHistoryDeltaValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SchemaHistoryDeltaValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SchemaHistoryDeltaValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SchemaHistoryDeltaValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SchemaHistoryDeltaValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SchemaHistoryDeltaValidationError) ErrorName() string {
+	return "SchemaHistoryDeltaValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SchemaHistoryDeltaValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSchemaHistoryDelta.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SchemaHistoryDeltaValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SchemaHistoryDeltaValidationError{}
+
 // Validate checks the field values on AllowedRelation_PublicWildcard with the
 // rules defined in the proto definition for this message. If any rules are
 // violated, the first error encountered is returned, or nil if there are no violations.
@@ -0,0 +1,263 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: counters/v1/counters.proto
+
+package countersv1
+
+import (
+	v1 "github.com/authzed/authzed-go/proto/authzed/api/v1"
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ExpirationScope scopes a counter by the expiration of the relationships. Expired relationships
+// are never counted.
+type ExpirationScope int32
+
+const (
+	// EXPIRATION_SCOPE_UNSPECIFIED counts relationships with or without an expiration.
+	ExpirationScope_EXPIRATION_SCOPE_UNSPECIFIED ExpirationScope = 0
+	// EXPIRATION_SCOPE_EXPIRING counts only relationships with an expiration.
+	ExpirationScope_EXPIRATION_SCOPE_EXPIRING ExpirationScope = 1
+	// EXPIRATION_SCOPE_NON_EXPIRING counts only relationships without an expiration.
+	ExpirationScope_EXPIRATION_SCOPE_NON_EXPIRING ExpirationScope = 2
+)
+
+// Enum value maps for ExpirationScope.
+var (
+	ExpirationScope_name = map[int32]string{
+		0: "EXPIRATION_SCOPE_UNSPECIFIED",
+		1: "EXPIRATION_SCOPE_EXPIRING",
+		2: "EXPIRATION_SCOPE_NON_EXPIRING",
+	}
+	ExpirationScope_value = map[string]int32{
+		"EXPIRATION_SCOPE_UNSPECIFIED":  0,
+		"EXPIRATION_SCOPE_EXPIRING":     1,
+		"EXPIRATION_SCOPE_NON_EXPIRING": 2,
+	}
+)
+
+func (x ExpirationScope) Enum() *ExpirationScope {
+	p := new(ExpirationScope)
+	*p = x
+	return p
+}
+
+func (x ExpirationScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExpirationScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_counters_v1_counters_proto_enumTypes[0].Descriptor()
+}
+
+func (ExpirationScope) Type() protoreflect.EnumType {
+	return &file_counters_v1_counters_proto_enumTypes[0]
+}
+
+func (x ExpirationScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExpirationScope.Descriptor instead.
+func (ExpirationScope) EnumDescriptor() ([]byte, []int) {
+	return file_counters_v1_counters_proto_rawDescGZIP(), []int{0}
+}
+
+type RegisterScopedRelationshipCounterRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name is the name of the counter being registered.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// relationship_filter defines the filter to be applied to the relationships to be counted.
+	RelationshipFilter *v1.RelationshipFilter `protobuf:"bytes,2,opt,name=relationship_filter,json=relationshipFilter,proto3" json:"relationship_filter,omitempty"`
+	// optional_caveat_name, if specified, scopes the counter to relationships with the named
+	// caveat.
+	OptionalCaveatName string `protobuf:"bytes,3,opt,name=optional_caveat_name,json=optionalCaveatName,proto3" json:"optional_caveat_name,omitempty"`
+	// optional_expiration_scope scopes the counter by the expiration of the relationships.
+	OptionalExpirationScope ExpirationScope `protobuf:"varint,4,opt,name=optional_expiration_scope,json=optionalExpirationScope,proto3,enum=counters.v1.ExpirationScope" json:"optional_expiration_scope,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *RegisterScopedRelationshipCounterRequest) Reset() {
+	*x = RegisterScopedRelationshipCounterRequest{}
+	mi := &file_counters_v1_counters_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterScopedRelationshipCounterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterScopedRelationshipCounterRequest) ProtoMessage() {}
+
+func (x *RegisterScopedRelationshipCounterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_counters_v1_counters_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterScopedRelationshipCounterRequest.ProtoReflect.Descriptor instead.
+func (*RegisterScopedRelationshipCounterRequest) Descriptor() ([]byte, []int) {
+	return file_counters_v1_counters_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RegisterScopedRelationshipCounterRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RegisterScopedRelationshipCounterRequest) GetRelationshipFilter() *v1.RelationshipFilter {
+	if x != nil {
+		return x.RelationshipFilter
+	}
+	return nil
+}
+
+func (x *RegisterScopedRelationshipCounterRequest) GetOptionalCaveatName() string {
+	if x != nil {
+		return x.OptionalCaveatName
+	}
+	return ""
+}
+
+func (x *RegisterScopedRelationshipCounterRequest) GetOptionalExpirationScope() ExpirationScope {
+	if x != nil {
+		return x.OptionalExpirationScope
+	}
+	return ExpirationScope_EXPIRATION_SCOPE_UNSPECIFIED
+}
+
+type RegisterScopedRelationshipCounterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterScopedRelationshipCounterResponse) Reset() {
+	*x = RegisterScopedRelationshipCounterResponse{}
+	mi := &file_counters_v1_counters_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterScopedRelationshipCounterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterScopedRelationshipCounterResponse) ProtoMessage() {}
+
+func (x *RegisterScopedRelationshipCounterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_counters_v1_counters_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterScopedRelationshipCounterResponse.ProtoReflect.Descriptor instead.
+func (*RegisterScopedRelationshipCounterResponse) Descriptor() ([]byte, []int) {
+	return file_counters_v1_counters_proto_rawDescGZIP(), []int{1}
+}
+
+var File_counters_v1_counters_proto protoreflect.FileDescriptor
+
+const file_counters_v1_counters_proto_rawDesc = "" +
+	"\n" +
+	"\x1acounters/v1/counters.proto\x12\vcounters.v1\x1a'authzed/api/v1/permission_service.proto\x1a\x17validate/validate.proto\"\xe9\x02\n" +
+	"(RegisterScopedRelationshipCounterRequest\x12>\n" +
+	"\x04name\x18\x01 \x01(\tB*\xfaB'r%(@2!^([a-z][a-z0-9_]{1,62}[a-z0-9])?$R\x04name\x12]\n" +
+	"\x13relationship_filter\x18\x02 \x01(\v2\".authzed.api.v1.RelationshipFilterB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x12relationshipFilter\x12:\n" +
+	"\x14optional_caveat_name\x18\x03 \x01(\tB\b\xfaB\x05r\x03(\x80\x01R\x12optionalCaveatName\x12b\n" +
+	"\x19optional_expiration_scope\x18\x04 \x01(\x0e2\x1c.counters.v1.ExpirationScopeB\b\xfaB\x05\x82\x01\x02\x10\x01R\x17optionalExpirationScope\"+\n" +
+	")RegisterScopedRelationshipCounterResponse*u\n" +
+	"\x0fExpirationScope\x12 \n" +
+	"\x1cEXPIRATION_SCOPE_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19EXPIRATION_SCOPE_EXPIRING\x10\x01\x12!\n" +
+	"\x1dEXPIRATION_SCOPE_NON_EXPIRING\x10\x022\xb3\x01\n" +
+	"\x1aRelationshipCounterService\x12\x94\x01\n" +
+	"!RegisterScopedRelationshipCounter\x125.counters.v1.RegisterScopedRelationshipCounterRequest\x1a6.counters.v1.RegisterScopedRelationshipCounterResponse\"\x00B\xaa\x01\n" +
+	"\x0fcom.counters.v1B\rCountersProtoP\x01Z;github.com/authzed/spicedb/pkg/proto/counters/v1;countersv1\xa2\x02\x03CXX\xaa\x02\vCounters.V1\xca\x02\vCounters\\V1\xe2\x02\x17Counters\\V1\\GPBMetadata\xea\x02\fCounters::V1b\x06proto3"
+
+var (
+	file_counters_v1_counters_proto_rawDescOnce sync.Once
+	file_counters_v1_counters_proto_rawDescData []byte
+)
+
+func file_counters_v1_counters_proto_rawDescGZIP() []byte {
+	file_counters_v1_counters_proto_rawDescOnce.Do(func() {
+		file_counters_v1_counters_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_counters_v1_counters_proto_rawDesc), len(file_counters_v1_counters_proto_rawDesc)))
+	})
+	return file_counters_v1_counters_proto_rawDescData
+}
+
+var file_counters_v1_counters_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_counters_v1_counters_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_counters_v1_counters_proto_goTypes = []any{
+	(ExpirationScope)(0),                              // 0: counters.v1.ExpirationScope
+	(*RegisterScopedRelationshipCounterRequest)(nil),  // 1: counters.v1.RegisterScopedRelationshipCounterRequest
+	(*RegisterScopedRelationshipCounterResponse)(nil), // 2: counters.v1.RegisterScopedRelationshipCounterResponse
+	(*v1.RelationshipFilter)(nil),                     // 3: authzed.api.v1.RelationshipFilter
+}
+var file_counters_v1_counters_proto_depIdxs = []int32{
+	3, // 0: counters.v1.RegisterScopedRelationshipCounterRequest.relationship_filter:type_name -> authzed.api.v1.RelationshipFilter
+	0, // 1: counters.v1.RegisterScopedRelationshipCounterRequest.optional_expiration_scope:type_name -> counters.v1.ExpirationScope
+	1, // 2: counters.v1.RelationshipCounterService.RegisterScopedRelationshipCounter:input_type -> counters.v1.RegisterScopedRelationshipCounterRequest
+	2, // 3: counters.v1.RelationshipCounterService.RegisterScopedRelationshipCounter:output_type -> counters.v1.RegisterScopedRelationshipCounterResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_counters_v1_counters_proto_init() }
+func file_counters_v1_counters_proto_init() {
+	if File_counters_v1_counters_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_counters_v1_counters_proto_rawDesc), len(file_counters_v1_counters_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_counters_v1_counters_proto_goTypes,
+		DependencyIndexes: file_counters_v1_counters_proto_depIdxs,
+		EnumInfos:         file_counters_v1_counters_proto_enumTypes,
+		MessageInfos:      file_counters_v1_counters_proto_msgTypes,
+	}.Build()
+	File_counters_v1_counters_proto = out.File
+	file_counters_v1_counters_proto_goTypes = nil
+	file_counters_v1_counters_proto_depIdxs = nil
+}
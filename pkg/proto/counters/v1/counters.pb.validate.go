@@ -0,0 +1,334 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: counters/v1/counters.proto
+
+package countersv1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on RegisterScopedRelationshipCounterRequest
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the first error encountered is returned, or nil if
+// there are no violations.
+func (m *RegisterScopedRelationshipCounterRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on
+// RegisterScopedRelationshipCounterRequest with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in
+// RegisterScopedRelationshipCounterRequestMultiError, or nil if none found.
+func (m *RegisterScopedRelationshipCounterRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RegisterScopedRelationshipCounterRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetName()) > 64 {
+		err := RegisterScopedRelationshipCounterRequestValidationError{
+			field:  "Name",
+			reason: "value length must be at most 64 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_RegisterScopedRelationshipCounterRequest_Name_Pattern.MatchString(m.GetName()) {
+		err := RegisterScopedRelationshipCounterRequestValidationError{
+			field:  "Name",
+			reason: "value does not match regex pattern \"^([a-z][a-z0-9_]{1,62}[a-z0-9])?$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetRelationshipFilter() == nil {
+		err := RegisterScopedRelationshipCounterRequestValidationError{
+			field:  "RelationshipFilter",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetRelationshipFilter()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RegisterScopedRelationshipCounterRequestValidationError{
+					field:  "RelationshipFilter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RegisterScopedRelationshipCounterRequestValidationError{
+					field:  "RelationshipFilter",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRelationshipFilter()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RegisterScopedRelationshipCounterRequestValidationError{
+				field:  "RelationshipFilter",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(m.GetOptionalCaveatName()) > 128 {
+		err := RegisterScopedRelationshipCounterRequestValidationError{
+			field:  "OptionalCaveatName",
+			reason: "value length must be at most 128 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := ExpirationScope_name[int32(m.GetOptionalExpirationScope())]; !ok {
+		err := RegisterScopedRelationshipCounterRequestValidationError{
+			field:  "OptionalExpirationScope",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RegisterScopedRelationshipCounterRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// RegisterScopedRelationshipCounterRequestMultiError is an error wrapping
+// multiple validation errors returned by
+// RegisterScopedRelationshipCounterRequest.ValidateAll() if the designated
+// constraints aren't met.
+type RegisterScopedRelationshipCounterRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RegisterScopedRelationshipCounterRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RegisterScopedRelationshipCounterRequestMultiError) AllErrors() []error { return m }
+
+// RegisterScopedRelationshipCounterRequestValidationError is the validation
+// error returned by RegisterScopedRelationshipCounterRequest.Validate if the
+// designated constraints aren't met.
+type RegisterScopedRelationshipCounterRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RegisterScopedRelationshipCounterRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RegisterScopedRelationshipCounterRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RegisterScopedRelationshipCounterRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RegisterScopedRelationshipCounterRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RegisterScopedRelationshipCounterRequestValidationError) ErrorName() string {
+	return "RegisterScopedRelationshipCounterRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RegisterScopedRelationshipCounterRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRegisterScopedRelationshipCounterRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RegisterScopedRelationshipCounterRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RegisterScopedRelationshipCounterRequestValidationError{}
+
+var _RegisterScopedRelationshipCounterRequest_Name_Pattern = regexp.MustCompile("^([a-z][a-z0-9_]{1,62}[a-z0-9])?$")
+
+// Validate checks the field values on
+// RegisterScopedRelationshipCounterResponse with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *RegisterScopedRelationshipCounterResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on
+// RegisterScopedRelationshipCounterResponse with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in
+// RegisterScopedRelationshipCounterResponseMultiError, or nil if none found.
+func (m *RegisterScopedRelationshipCounterResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RegisterScopedRelationshipCounterResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return RegisterScopedRelationshipCounterResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// RegisterScopedRelationshipCounterResponseMultiError is an error wrapping
+// multiple validation errors returned by
+// RegisterScopedRelationshipCounterResponse.ValidateAll() if the designated
+// constraints aren't met.
+type RegisterScopedRelationshipCounterResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RegisterScopedRelationshipCounterResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RegisterScopedRelationshipCounterResponseMultiError) AllErrors() []error { return m }
+
+// RegisterScopedRelationshipCounterResponseValidationError is the validation
+// error returned by RegisterScopedRelationshipCounterResponse.Validate if the
+// designated constraints aren't met.
+type RegisterScopedRelationshipCounterResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RegisterScopedRelationshipCounterResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RegisterScopedRelationshipCounterResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RegisterScopedRelationshipCounterResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RegisterScopedRelationshipCounterResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RegisterScopedRelationshipCounterResponseValidationError) ErrorName() string {
+	return "RegisterScopedRelationshipCounterResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RegisterScopedRelationshipCounterResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRegisterScopedRelationshipCounterResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RegisterScopedRelationshipCounterResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RegisterScopedRelationshipCounterResponseValidationError{}
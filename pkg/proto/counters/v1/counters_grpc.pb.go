@@ -0,0 +1,134 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: counters/v1/counters.proto
+
+package countersv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RelationshipCounterService_RegisterScopedRelationshipCounter_FullMethodName = "/counters.v1.RelationshipCounterService/RegisterScopedRelationshipCounter"
+)
+
+// RelationshipCounterServiceClient is the client API for RelationshipCounterService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RelationshipCounterService registers relationship counters scoped beyond what the filter of
+// ExperimentalRegisterRelationshipCounter can express. Counters registered through it are read
+// and unregistered through the ExperimentalService, like any other counter.
+type RelationshipCounterServiceClient interface {
+	// RegisterScopedRelationshipCounter registers a counter of the relationships matching the
+	// filter, further scoped by their caveat and expiration.
+	RegisterScopedRelationshipCounter(ctx context.Context, in *RegisterScopedRelationshipCounterRequest, opts ...grpc.CallOption) (*RegisterScopedRelationshipCounterResponse, error)
+}
+
+type relationshipCounterServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRelationshipCounterServiceClient(cc grpc.ClientConnInterface) RelationshipCounterServiceClient {
+	return &relationshipCounterServiceClient{cc}
+}
+
+func (c *relationshipCounterServiceClient) RegisterScopedRelationshipCounter(ctx context.Context, in *RegisterScopedRelationshipCounterRequest, opts ...grpc.CallOption) (*RegisterScopedRelationshipCounterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterScopedRelationshipCounterResponse)
+	err := c.cc.Invoke(ctx, RelationshipCounterService_RegisterScopedRelationshipCounter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RelationshipCounterServiceServer is the server API for RelationshipCounterService service.
+// All implementations must embed UnimplementedRelationshipCounterServiceServer
+// for forward compatibility.
+//
+// RelationshipCounterService registers relationship counters scoped beyond what the filter of
+// ExperimentalRegisterRelationshipCounter can express. Counters registered through it are read
+// and unregistered through the ExperimentalService, like any other counter.
+type RelationshipCounterServiceServer interface {
+	// RegisterScopedRelationshipCounter registers a counter of the relationships matching the
+	// filter, further scoped by their caveat and expiration.
+	RegisterScopedRelationshipCounter(context.Context, *RegisterScopedRelationshipCounterRequest) (*RegisterScopedRelationshipCounterResponse, error)
+	mustEmbedUnimplementedRelationshipCounterServiceServer()
+}
+
+// UnimplementedRelationshipCounterServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRelationshipCounterServiceServer struct{}
+
+func (UnimplementedRelationshipCounterServiceServer) RegisterScopedRelationshipCounter(context.Context, *RegisterScopedRelationshipCounterRequest) (*RegisterScopedRelationshipCounterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterScopedRelationshipCounter not implemented")
+}
+func (UnimplementedRelationshipCounterServiceServer) mustEmbedUnimplementedRelationshipCounterServiceServer() {
+}
+func (UnimplementedRelationshipCounterServiceServer) testEmbeddedByValue() {}
+
+// UnsafeRelationshipCounterServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RelationshipCounterServiceServer will
+// result in compilation errors.
+type UnsafeRelationshipCounterServiceServer interface {
+	mustEmbedUnimplementedRelationshipCounterServiceServer()
+}
+
+func RegisterRelationshipCounterServiceServer(s grpc.ServiceRegistrar, srv RelationshipCounterServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRelationshipCounterServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RelationshipCounterService_ServiceDesc, srv)
+}
+
+func _RelationshipCounterService_RegisterScopedRelationshipCounter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterScopedRelationshipCounterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RelationshipCounterServiceServer).RegisterScopedRelationshipCounter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RelationshipCounterService_RegisterScopedRelationshipCounter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RelationshipCounterServiceServer).RegisterScopedRelationshipCounter(ctx, req.(*RegisterScopedRelationshipCounterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RelationshipCounterService_ServiceDesc is the grpc.ServiceDesc for RelationshipCounterService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RelationshipCounterService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "counters.v1.RelationshipCounterService",
+	HandlerType: (*RelationshipCounterServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterScopedRelationshipCounter",
+			Handler:    _RelationshipCounterService_RegisterScopedRelationshipCounter_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "counters/v1/counters.proto",
+}
@@ -26,6 +26,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type DeveloperWarning_Severity int32
+
+const (
+	DeveloperWarning_WARNING DeveloperWarning_Severity = 0
+	DeveloperWarning_ERROR   DeveloperWarning_Severity = 1
+	DeveloperWarning_INFO    DeveloperWarning_Severity = 2
+)
+
+// Enum value maps for DeveloperWarning_Severity.
+var (
+	DeveloperWarning_Severity_name = map[int32]string{
+		0: "WARNING",
+		1: "ERROR",
+		2: "INFO",
+	}
+	DeveloperWarning_Severity_value = map[string]int32{
+		"WARNING": 0,
+		"ERROR":   1,
+		"INFO":    2,
+	}
+)
+
+func (x DeveloperWarning_Severity) Enum() *DeveloperWarning_Severity {
+	p := new(DeveloperWarning_Severity)
+	*p = x
+	return p
+}
+
+func (x DeveloperWarning_Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeveloperWarning_Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_developer_v1_developer_proto_enumTypes[0].Descriptor()
+}
+
+func (DeveloperWarning_Severity) Type() protoreflect.EnumType {
+	return &file_developer_v1_developer_proto_enumTypes[0]
+}
+
+func (x DeveloperWarning_Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeveloperWarning_Severity.Descriptor instead.
+func (DeveloperWarning_Severity) EnumDescriptor() ([]byte, []int) {
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{6, 0}
+}
+
 type DeveloperError_Source int32
 
 const (
@@ -68,11 +117,11 @@ func (x DeveloperError_Source) String() string {
 }
 
 func (DeveloperError_Source) Descriptor() protoreflect.EnumDescriptor {
-	return file_developer_v1_developer_proto_enumTypes[0].Descriptor()
+	return file_developer_v1_developer_proto_enumTypes[1].Descriptor()
 }
 
 func (DeveloperError_Source) Type() protoreflect.EnumType {
-	return &file_developer_v1_developer_proto_enumTypes[0]
+	return &file_developer_v1_developer_proto_enumTypes[1]
 }
 
 func (x DeveloperError_Source) Number() protoreflect.EnumNumber {
@@ -81,7 +130,7 @@ func (x DeveloperError_Source) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DeveloperError_Source.Descriptor instead.
 func (DeveloperError_Source) EnumDescriptor() ([]byte, []int) {
-	return file_developer_v1_developer_proto_rawDescGZIP(), []int{7, 0}
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{9, 0}
 }
 
 type DeveloperError_ErrorKind int32
@@ -141,11 +190,11 @@ func (x DeveloperError_ErrorKind) String() string {
 }
 
 func (DeveloperError_ErrorKind) Descriptor() protoreflect.EnumDescriptor {
-	return file_developer_v1_developer_proto_enumTypes[1].Descriptor()
+	return file_developer_v1_developer_proto_enumTypes[2].Descriptor()
 }
 
 func (DeveloperError_ErrorKind) Type() protoreflect.EnumType {
-	return &file_developer_v1_developer_proto_enumTypes[1]
+	return &file_developer_v1_developer_proto_enumTypes[2]
 }
 
 func (x DeveloperError_ErrorKind) Number() protoreflect.EnumNumber {
@@ -154,7 +203,7 @@ func (x DeveloperError_ErrorKind) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use DeveloperError_ErrorKind.Descriptor instead.
 func (DeveloperError_ErrorKind) EnumDescriptor() ([]byte, []int) {
-	return file_developer_v1_developer_proto_rawDescGZIP(), []int{7, 1}
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{9, 1}
 }
 
 type CheckOperationsResult_Membership int32
@@ -193,11 +242,11 @@ func (x CheckOperationsResult_Membership) String() string {
 }
 
 func (CheckOperationsResult_Membership) Descriptor() protoreflect.EnumDescriptor {
-	return file_developer_v1_developer_proto_enumTypes[2].Descriptor()
+	return file_developer_v1_developer_proto_enumTypes[3].Descriptor()
 }
 
 func (CheckOperationsResult_Membership) Type() protoreflect.EnumType {
-	return &file_developer_v1_developer_proto_enumTypes[2]
+	return &file_developer_v1_developer_proto_enumTypes[3]
 }
 
 func (x CheckOperationsResult_Membership) Number() protoreflect.EnumNumber {
@@ -206,7 +255,7 @@ func (x CheckOperationsResult_Membership) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CheckOperationsResult_Membership.Descriptor instead.
 func (CheckOperationsResult_Membership) EnumDescriptor() ([]byte, []int) {
-	return file_developer_v1_developer_proto_rawDescGZIP(), []int{10, 0}
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{12, 0}
 }
 
 // DeveloperRequest is a single request made to the developer platform, containing zero or more
@@ -594,7 +643,12 @@ type DeveloperWarning struct {
 	// column is the 1-indexed column on the line for the developer warning.
 	Column uint32 `protobuf:"varint,3,opt,name=column,proto3" json:"column,omitempty"`
 	// source_code is the source code for the developer warning, if any.
-	SourceCode    string `protobuf:"bytes,4,opt,name=source_code,json=sourceCode,proto3" json:"source_code,omitempty"`
+	SourceCode string `protobuf:"bytes,4,opt,name=source_code,json=sourceCode,proto3" json:"source_code,omitempty"`
+	// suggested_fixes are the fixes suggested for the developer warning, if any, in order of
+	// preference.
+	SuggestedFixes []*SuggestedFix `protobuf:"bytes,5,rep,name=suggested_fixes,json=suggestedFixes,proto3" json:"suggested_fixes,omitempty"`
+	// severity is the severity configured for the lint rule raising the developer warning.
+	Severity      DeveloperWarning_Severity `protobuf:"varint,6,opt,name=severity,proto3,enum=developer.v1.DeveloperWarning_Severity" json:"severity,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -657,6 +711,158 @@ func (x *DeveloperWarning) GetSourceCode() string {
 	return ""
 }
 
+func (x *DeveloperWarning) GetSuggestedFixes() []*SuggestedFix {
+	if x != nil {
+		return x.SuggestedFixes
+	}
+	return nil
+}
+
+func (x *DeveloperWarning) GetSeverity() DeveloperWarning_Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return DeveloperWarning_WARNING
+}
+
+// SuggestedFix is a fix suggested for a developer warning, applied by making each of its edits
+// to the schema.
+type SuggestedFix struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// description is the human-readable description of the fix.
+	Description string `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	// edits are the edits to the schema making up the fix, which do not overlap.
+	Edits         []*SchemaEdit `protobuf:"bytes,2,rep,name=edits,proto3" json:"edits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestedFix) Reset() {
+	*x = SuggestedFix{}
+	mi := &file_developer_v1_developer_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestedFix) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestedFix) ProtoMessage() {}
+
+func (x *SuggestedFix) ProtoReflect() protoreflect.Message {
+	mi := &file_developer_v1_developer_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestedFix.ProtoReflect.Descriptor instead.
+func (*SuggestedFix) Descriptor() ([]byte, []int) {
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SuggestedFix) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SuggestedFix) GetEdits() []*SchemaEdit {
+	if x != nil {
+		return x.Edits
+	}
+	return nil
+}
+
+// SchemaEdit is the replacement of a range of text in the schema.
+type SchemaEdit struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// line is the 1-indexed line at which the replaced text starts.
+	Line uint32 `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	// column is the 1-indexed column on the line at which the replaced text starts.
+	Column uint32 `protobuf:"varint,2,opt,name=column,proto3" json:"column,omitempty"`
+	// end_line is the 1-indexed line at which the replaced text ends.
+	EndLine uint32 `protobuf:"varint,3,opt,name=end_line,json=endLine,proto3" json:"end_line,omitempty"`
+	// end_column is the 1-indexed column on the end line immediately after the replaced text.
+	EndColumn uint32 `protobuf:"varint,4,opt,name=end_column,json=endColumn,proto3" json:"end_column,omitempty"`
+	// new_text is the text replacing that found within the range, which may be empty.
+	NewText       string `protobuf:"bytes,5,opt,name=new_text,json=newText,proto3" json:"new_text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaEdit) Reset() {
+	*x = SchemaEdit{}
+	mi := &file_developer_v1_developer_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaEdit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaEdit) ProtoMessage() {}
+
+func (x *SchemaEdit) ProtoReflect() protoreflect.Message {
+	mi := &file_developer_v1_developer_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaEdit.ProtoReflect.Descriptor instead.
+func (*SchemaEdit) Descriptor() ([]byte, []int) {
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SchemaEdit) GetLine() uint32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *SchemaEdit) GetColumn() uint32 {
+	if x != nil {
+		return x.Column
+	}
+	return 0
+}
+
+func (x *SchemaEdit) GetEndLine() uint32 {
+	if x != nil {
+		return x.EndLine
+	}
+	return 0
+}
+
+func (x *SchemaEdit) GetEndColumn() uint32 {
+	if x != nil {
+		return x.EndColumn
+	}
+	return 0
+}
+
+func (x *SchemaEdit) GetNewText() string {
+	if x != nil {
+		return x.NewText
+	}
+	return ""
+}
+
 // DeveloperError represents a single error raised by the development package. Unlike an internal
 // error, it represents an issue with the entered information by the calling developer.
 type DeveloperError struct {
@@ -685,7 +891,7 @@ type DeveloperError struct {
 
 func (x *DeveloperError) Reset() {
 	*x = DeveloperError{}
-	mi := &file_developer_v1_developer_proto_msgTypes[7]
+	mi := &file_developer_v1_developer_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -697,7 +903,7 @@ func (x *DeveloperError) String() string {
 func (*DeveloperError) ProtoMessage() {}
 
 func (x *DeveloperError) ProtoReflect() protoreflect.Message {
-	mi := &file_developer_v1_developer_proto_msgTypes[7]
+	mi := &file_developer_v1_developer_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -710,7 +916,7 @@ func (x *DeveloperError) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeveloperError.ProtoReflect.Descriptor instead.
 func (*DeveloperError) Descriptor() ([]byte, []int) {
-	return file_developer_v1_developer_proto_rawDescGZIP(), []int{7}
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *DeveloperError) GetMessage() string {
@@ -787,7 +993,7 @@ type DeveloperErrors struct {
 
 func (x *DeveloperErrors) Reset() {
 	*x = DeveloperErrors{}
-	mi := &file_developer_v1_developer_proto_msgTypes[8]
+	mi := &file_developer_v1_developer_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -799,7 +1005,7 @@ func (x *DeveloperErrors) String() string {
 func (*DeveloperErrors) ProtoMessage() {}
 
 func (x *DeveloperErrors) ProtoReflect() protoreflect.Message {
-	mi := &file_developer_v1_developer_proto_msgTypes[8]
+	mi := &file_developer_v1_developer_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -812,7 +1018,7 @@ func (x *DeveloperErrors) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeveloperErrors.ProtoReflect.Descriptor instead.
 func (*DeveloperErrors) Descriptor() ([]byte, []int) {
-	return file_developer_v1_developer_proto_rawDescGZIP(), []int{8}
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DeveloperErrors) GetInputErrors() []*DeveloperError {
@@ -835,7 +1041,7 @@ type CheckOperationParameters struct {
 
 func (x *CheckOperationParameters) Reset() {
 	*x = CheckOperationParameters{}
-	mi := &file_developer_v1_developer_proto_msgTypes[9]
+	mi := &file_developer_v1_developer_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -847,7 +1053,7 @@ func (x *CheckOperationParameters) String() string {
 func (*CheckOperationParameters) ProtoMessage() {}
 
 func (x *CheckOperationParameters) ProtoReflect() protoreflect.Message {
-	mi := &file_developer_v1_developer_proto_msgTypes[9]
+	mi := &file_developer_v1_developer_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -860,7 +1066,7 @@ func (x *CheckOperationParameters) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckOperationParameters.ProtoReflect.Descriptor instead.
 func (*CheckOperationParameters) Descriptor() ([]byte, []int) {
-	return file_developer_v1_developer_proto_rawDescGZIP(), []int{9}
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CheckOperationParameters) GetResource() *v1.ObjectAndRelation {
@@ -902,7 +1108,7 @@ type CheckOperationsResult struct {
 
 func (x *CheckOperationsResult) Reset() {
 	*x = CheckOperationsResult{}
-	mi := &file_developer_v1_developer_proto_msgTypes[10]
+	mi := &file_developer_v1_developer_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -914,7 +1120,7 @@ func (x *CheckOperationsResult) String() string {
 func (*CheckOperationsResult) ProtoMessage() {}
 
 func (x *CheckOperationsResult) ProtoReflect() protoreflect.Message {
-	mi := &file_developer_v1_developer_proto_msgTypes[10]
+	mi := &file_developer_v1_developer_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -927,7 +1133,7 @@ func (x *CheckOperationsResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CheckOperationsResult.ProtoReflect.Descriptor instead.
 func (*CheckOperationsResult) Descriptor() ([]byte, []int) {
-	return file_developer_v1_developer_proto_rawDescGZIP(), []int{10}
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *CheckOperationsResult) GetMembership() CheckOperationsResult_Membership {
@@ -978,7 +1184,7 @@ type PartialCaveatInfo struct {
 
 func (x *PartialCaveatInfo) Reset() {
 	*x = PartialCaveatInfo{}
-	mi := &file_developer_v1_developer_proto_msgTypes[11]
+	mi := &file_developer_v1_developer_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -990,7 +1196,7 @@ func (x *PartialCaveatInfo) String() string {
 func (*PartialCaveatInfo) ProtoMessage() {}
 
 func (x *PartialCaveatInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_developer_v1_developer_proto_msgTypes[11]
+	mi := &file_developer_v1_developer_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1003,7 +1209,7 @@ func (x *PartialCaveatInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PartialCaveatInfo.ProtoReflect.Descriptor instead.
 func (*PartialCaveatInfo) Descriptor() ([]byte, []int) {
-	return file_developer_v1_developer_proto_rawDescGZIP(), []int{11}
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *PartialCaveatInfo) GetMissingRequiredContext() []string {
@@ -1024,7 +1230,7 @@ type RunAssertionsParameters struct {
 
 func (x *RunAssertionsParameters) Reset() {
 	*x = RunAssertionsParameters{}
-	mi := &file_developer_v1_developer_proto_msgTypes[12]
+	mi := &file_developer_v1_developer_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1036,7 +1242,7 @@ func (x *RunAssertionsParameters) String() string {
 func (*RunAssertionsParameters) ProtoMessage() {}
 
 func (x *RunAssertionsParameters) ProtoReflect() protoreflect.Message {
-	mi := &file_developer_v1_developer_proto_msgTypes[12]
+	mi := &file_developer_v1_developer_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1049,7 +1255,7 @@ func (x *RunAssertionsParameters) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunAssertionsParameters.ProtoReflect.Descriptor instead.
 func (*RunAssertionsParameters) Descriptor() ([]byte, []int) {
-	return file_developer_v1_developer_proto_rawDescGZIP(), []int{12}
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *RunAssertionsParameters) GetAssertionsYaml() string {
@@ -1072,7 +1278,7 @@ type RunAssertionsResult struct {
 
 func (x *RunAssertionsResult) Reset() {
 	*x = RunAssertionsResult{}
-	mi := &file_developer_v1_developer_proto_msgTypes[13]
+	mi := &file_developer_v1_developer_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1084,7 +1290,7 @@ func (x *RunAssertionsResult) String() string {
 func (*RunAssertionsResult) ProtoMessage() {}
 
 func (x *RunAssertionsResult) ProtoReflect() protoreflect.Message {
-	mi := &file_developer_v1_developer_proto_msgTypes[13]
+	mi := &file_developer_v1_developer_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1097,7 +1303,7 @@ func (x *RunAssertionsResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunAssertionsResult.ProtoReflect.Descriptor instead.
 func (*RunAssertionsResult) Descriptor() ([]byte, []int) {
-	return file_developer_v1_developer_proto_rawDescGZIP(), []int{13}
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *RunAssertionsResult) GetInputError() *DeveloperError {
@@ -1125,7 +1331,7 @@ type RunValidationParameters struct {
 
 func (x *RunValidationParameters) Reset() {
 	*x = RunValidationParameters{}
-	mi := &file_developer_v1_developer_proto_msgTypes[14]
+	mi := &file_developer_v1_developer_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1137,7 +1343,7 @@ func (x *RunValidationParameters) String() string {
 func (*RunValidationParameters) ProtoMessage() {}
 
 func (x *RunValidationParameters) ProtoReflect() protoreflect.Message {
-	mi := &file_developer_v1_developer_proto_msgTypes[14]
+	mi := &file_developer_v1_developer_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1150,7 +1356,7 @@ func (x *RunValidationParameters) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunValidationParameters.ProtoReflect.Descriptor instead.
 func (*RunValidationParameters) Descriptor() ([]byte, []int) {
-	return file_developer_v1_developer_proto_rawDescGZIP(), []int{14}
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *RunValidationParameters) GetValidationYaml() string {
@@ -1176,7 +1382,7 @@ type RunValidationResult struct {
 
 func (x *RunValidationResult) Reset() {
 	*x = RunValidationResult{}
-	mi := &file_developer_v1_developer_proto_msgTypes[15]
+	mi := &file_developer_v1_developer_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1188,7 +1394,7 @@ func (x *RunValidationResult) String() string {
 func (*RunValidationResult) ProtoMessage() {}
 
 func (x *RunValidationResult) ProtoReflect() protoreflect.Message {
-	mi := &file_developer_v1_developer_proto_msgTypes[15]
+	mi := &file_developer_v1_developer_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1201,7 +1407,7 @@ func (x *RunValidationResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RunValidationResult.ProtoReflect.Descriptor instead.
 func (*RunValidationResult) Descriptor() ([]byte, []int) {
-	return file_developer_v1_developer_proto_rawDescGZIP(), []int{15}
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *RunValidationResult) GetInputError() *DeveloperError {
@@ -1234,7 +1440,7 @@ type FormatSchemaParameters struct {
 
 func (x *FormatSchemaParameters) Reset() {
 	*x = FormatSchemaParameters{}
-	mi := &file_developer_v1_developer_proto_msgTypes[16]
+	mi := &file_developer_v1_developer_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1246,7 +1452,7 @@ func (x *FormatSchemaParameters) String() string {
 func (*FormatSchemaParameters) ProtoMessage() {}
 
 func (x *FormatSchemaParameters) ProtoReflect() protoreflect.Message {
-	mi := &file_developer_v1_developer_proto_msgTypes[16]
+	mi := &file_developer_v1_developer_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1259,7 +1465,7 @@ func (x *FormatSchemaParameters) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FormatSchemaParameters.ProtoReflect.Descriptor instead.
 func (*FormatSchemaParameters) Descriptor() ([]byte, []int) {
-	return file_developer_v1_developer_proto_rawDescGZIP(), []int{16}
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{18}
 }
 
 // FormatSchemaResult is the result of the `formatSchema` operation.
@@ -1272,7 +1478,7 @@ type FormatSchemaResult struct {
 
 func (x *FormatSchemaResult) Reset() {
 	*x = FormatSchemaResult{}
-	mi := &file_developer_v1_developer_proto_msgTypes[17]
+	mi := &file_developer_v1_developer_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1284,7 +1490,7 @@ func (x *FormatSchemaResult) String() string {
 func (*FormatSchemaResult) ProtoMessage() {}
 
 func (x *FormatSchemaResult) ProtoReflect() protoreflect.Message {
-	mi := &file_developer_v1_developer_proto_msgTypes[17]
+	mi := &file_developer_v1_developer_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1297,7 +1503,7 @@ func (x *FormatSchemaResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FormatSchemaResult.ProtoReflect.Descriptor instead.
 func (*FormatSchemaResult) Descriptor() ([]byte, []int) {
-	return file_developer_v1_developer_proto_rawDescGZIP(), []int{17}
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *FormatSchemaResult) GetFormattedSchema() string {
@@ -1316,7 +1522,7 @@ type SchemaWarningsParameters struct {
 
 func (x *SchemaWarningsParameters) Reset() {
 	*x = SchemaWarningsParameters{}
-	mi := &file_developer_v1_developer_proto_msgTypes[18]
+	mi := &file_developer_v1_developer_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1328,7 +1534,7 @@ func (x *SchemaWarningsParameters) String() string {
 func (*SchemaWarningsParameters) ProtoMessage() {}
 
 func (x *SchemaWarningsParameters) ProtoReflect() protoreflect.Message {
-	mi := &file_developer_v1_developer_proto_msgTypes[18]
+	mi := &file_developer_v1_developer_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1341,7 +1547,7 @@ func (x *SchemaWarningsParameters) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SchemaWarningsParameters.ProtoReflect.Descriptor instead.
 func (*SchemaWarningsParameters) Descriptor() ([]byte, []int) {
-	return file_developer_v1_developer_proto_rawDescGZIP(), []int{18}
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{20}
 }
 
 // SchemaWarningsResult is the result of the `schemaWarnings` operation.
@@ -1354,7 +1560,7 @@ type SchemaWarningsResult struct {
 
 func (x *SchemaWarningsResult) Reset() {
 	*x = SchemaWarningsResult{}
-	mi := &file_developer_v1_developer_proto_msgTypes[19]
+	mi := &file_developer_v1_developer_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1366,7 +1572,7 @@ func (x *SchemaWarningsResult) String() string {
 func (*SchemaWarningsResult) ProtoMessage() {}
 
 func (x *SchemaWarningsResult) ProtoReflect() protoreflect.Message {
-	mi := &file_developer_v1_developer_proto_msgTypes[19]
+	mi := &file_developer_v1_developer_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1379,7 +1585,7 @@ func (x *SchemaWarningsResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SchemaWarningsResult.ProtoReflect.Descriptor instead.
 func (*SchemaWarningsResult) Descriptor() ([]byte, []int) {
-	return file_developer_v1_developer_proto_rawDescGZIP(), []int{19}
+	return file_developer_v1_developer_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *SchemaWarningsResult) GetWarnings() []*DeveloperWarning {
@@ -1422,13 +1628,30 @@ const file_developer_v1_developer_proto_rawDesc = "" +
 	"\x11assertions_result\x18\x02 \x01(\v2!.developer.v1.RunAssertionsResultR\x10assertionsResult\x12N\n" +
 	"\x11validation_result\x18\x03 \x01(\v2!.developer.v1.RunValidationResultR\x10validationResult\x12R\n" +
 	"\x14format_schema_result\x18\x04 \x01(\v2 .developer.v1.FormatSchemaResultR\x12formatSchemaResult\x12X\n" +
-	"\x16schema_warnings_result\x18\x05 \x01(\v2\".developer.v1.SchemaWarningsResultR\x14schemaWarningsResult\"y\n" +
+	"\x16schema_warnings_result\x18\x05 \x01(\v2\".developer.v1.SchemaWarningsResultR\x14schemaWarningsResult\"\xb1\x02\n" +
 	"\x10DeveloperWarning\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x12\n" +
 	"\x04line\x18\x02 \x01(\rR\x04line\x12\x16\n" +
 	"\x06column\x18\x03 \x01(\rR\x06column\x12\x1f\n" +
 	"\vsource_code\x18\x04 \x01(\tR\n" +
-	"sourceCode\"\xc6\x06\n" +
+	"sourceCode\x12C\n" +
+	"\x0fsuggested_fixes\x18\x05 \x03(\v2\x1a.developer.v1.SuggestedFixR\x0esuggestedFixes\x12C\n" +
+	"\bseverity\x18\x06 \x01(\x0e2'.developer.v1.DeveloperWarning.SeverityR\bseverity\",\n" +
+	"\bSeverity\x12\v\n" +
+	"\aWARNING\x10\x00\x12\t\n" +
+	"\x05ERROR\x10\x01\x12\b\n" +
+	"\x04INFO\x10\x02\"`\n" +
+	"\fSuggestedFix\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12.\n" +
+	"\x05edits\x18\x02 \x03(\v2\x18.developer.v1.SchemaEditR\x05edits\"\x8d\x01\n" +
+	"\n" +
+	"SchemaEdit\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\rR\x04line\x12\x16\n" +
+	"\x06column\x18\x02 \x01(\rR\x06column\x12\x19\n" +
+	"\bend_line\x18\x03 \x01(\rR\aendLine\x12\x1d\n" +
+	"\n" +
+	"end_column\x18\x04 \x01(\rR\tendColumn\x12\x19\n" +
+	"\bnew_text\x18\x05 \x01(\tR\anewText\"\xc6\x06\n" +
 	"\x0eDeveloperError\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\tR\amessage\x12\x12\n" +
 	"\x04line\x18\x02 \x01(\rR\x04line\x12\x16\n" +
@@ -1518,80 +1741,86 @@ func file_developer_v1_developer_proto_rawDescGZIP() []byte {
 	return file_developer_v1_developer_proto_rawDescData
 }
 
-var file_developer_v1_developer_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_developer_v1_developer_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
+var file_developer_v1_developer_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_developer_v1_developer_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_developer_v1_developer_proto_goTypes = []any{
-	(DeveloperError_Source)(0),            // 0: developer.v1.DeveloperError.Source
-	(DeveloperError_ErrorKind)(0),         // 1: developer.v1.DeveloperError.ErrorKind
-	(CheckOperationsResult_Membership)(0), // 2: developer.v1.CheckOperationsResult.Membership
-	(*DeveloperRequest)(nil),              // 3: developer.v1.DeveloperRequest
-	(*DeveloperResponse)(nil),             // 4: developer.v1.DeveloperResponse
-	(*RequestContext)(nil),                // 5: developer.v1.RequestContext
-	(*Operation)(nil),                     // 6: developer.v1.Operation
-	(*OperationsResults)(nil),             // 7: developer.v1.OperationsResults
-	(*OperationResult)(nil),               // 8: developer.v1.OperationResult
-	(*DeveloperWarning)(nil),              // 9: developer.v1.DeveloperWarning
-	(*DeveloperError)(nil),                // 10: developer.v1.DeveloperError
-	(*DeveloperErrors)(nil),               // 11: developer.v1.DeveloperErrors
-	(*CheckOperationParameters)(nil),      // 12: developer.v1.CheckOperationParameters
-	(*CheckOperationsResult)(nil),         // 13: developer.v1.CheckOperationsResult
-	(*PartialCaveatInfo)(nil),             // 14: developer.v1.PartialCaveatInfo
-	(*RunAssertionsParameters)(nil),       // 15: developer.v1.RunAssertionsParameters
-	(*RunAssertionsResult)(nil),           // 16: developer.v1.RunAssertionsResult
-	(*RunValidationParameters)(nil),       // 17: developer.v1.RunValidationParameters
-	(*RunValidationResult)(nil),           // 18: developer.v1.RunValidationResult
-	(*FormatSchemaParameters)(nil),        // 19: developer.v1.FormatSchemaParameters
-	(*FormatSchemaResult)(nil),            // 20: developer.v1.FormatSchemaResult
-	(*SchemaWarningsParameters)(nil),      // 21: developer.v1.SchemaWarningsParameters
-	(*SchemaWarningsResult)(nil),          // 22: developer.v1.SchemaWarningsResult
-	nil,                                   // 23: developer.v1.OperationsResults.ResultsEntry
-	(*v1.RelationTuple)(nil),              // 24: core.v1.RelationTuple
-	(*v11.DebugInformation)(nil),          // 25: dispatch.v1.DebugInformation
-	(*v12.DebugInformation)(nil),          // 26: authzed.api.v1.DebugInformation
-	(*v1.ObjectAndRelation)(nil),          // 27: core.v1.ObjectAndRelation
-	(*structpb.Struct)(nil),               // 28: google.protobuf.Struct
+	(DeveloperWarning_Severity)(0),        // 0: developer.v1.DeveloperWarning.Severity
+	(DeveloperError_Source)(0),            // 1: developer.v1.DeveloperError.Source
+	(DeveloperError_ErrorKind)(0),         // 2: developer.v1.DeveloperError.ErrorKind
+	(CheckOperationsResult_Membership)(0), // 3: developer.v1.CheckOperationsResult.Membership
+	(*DeveloperRequest)(nil),              // 4: developer.v1.DeveloperRequest
+	(*DeveloperResponse)(nil),             // 5: developer.v1.DeveloperResponse
+	(*RequestContext)(nil),                // 6: developer.v1.RequestContext
+	(*Operation)(nil),                     // 7: developer.v1.Operation
+	(*OperationsResults)(nil),             // 8: developer.v1.OperationsResults
+	(*OperationResult)(nil),               // 9: developer.v1.OperationResult
+	(*DeveloperWarning)(nil),              // 10: developer.v1.DeveloperWarning
+	(*SuggestedFix)(nil),                  // 11: developer.v1.SuggestedFix
+	(*SchemaEdit)(nil),                    // 12: developer.v1.SchemaEdit
+	(*DeveloperError)(nil),                // 13: developer.v1.DeveloperError
+	(*DeveloperErrors)(nil),               // 14: developer.v1.DeveloperErrors
+	(*CheckOperationParameters)(nil),      // 15: developer.v1.CheckOperationParameters
+	(*CheckOperationsResult)(nil),         // 16: developer.v1.CheckOperationsResult
+	(*PartialCaveatInfo)(nil),             // 17: developer.v1.PartialCaveatInfo
+	(*RunAssertionsParameters)(nil),       // 18: developer.v1.RunAssertionsParameters
+	(*RunAssertionsResult)(nil),           // 19: developer.v1.RunAssertionsResult
+	(*RunValidationParameters)(nil),       // 20: developer.v1.RunValidationParameters
+	(*RunValidationResult)(nil),           // 21: developer.v1.RunValidationResult
+	(*FormatSchemaParameters)(nil),        // 22: developer.v1.FormatSchemaParameters
+	(*FormatSchemaResult)(nil),            // 23: developer.v1.FormatSchemaResult
+	(*SchemaWarningsParameters)(nil),      // 24: developer.v1.SchemaWarningsParameters
+	(*SchemaWarningsResult)(nil),          // 25: developer.v1.SchemaWarningsResult
+	nil,                                   // 26: developer.v1.OperationsResults.ResultsEntry
+	(*v1.RelationTuple)(nil),              // 27: core.v1.RelationTuple
+	(*v11.DebugInformation)(nil),          // 28: dispatch.v1.DebugInformation
+	(*v12.DebugInformation)(nil),          // 29: authzed.api.v1.DebugInformation
+	(*v1.ObjectAndRelation)(nil),          // 30: core.v1.ObjectAndRelation
+	(*structpb.Struct)(nil),               // 31: google.protobuf.Struct
 }
 var file_developer_v1_developer_proto_depIdxs = []int32{
-	5,  // 0: developer.v1.DeveloperRequest.context:type_name -> developer.v1.RequestContext
-	6,  // 1: developer.v1.DeveloperRequest.operations:type_name -> developer.v1.Operation
-	11, // 2: developer.v1.DeveloperResponse.developer_errors:type_name -> developer.v1.DeveloperErrors
-	7,  // 3: developer.v1.DeveloperResponse.operations_results:type_name -> developer.v1.OperationsResults
-	24, // 4: developer.v1.RequestContext.relationships:type_name -> core.v1.RelationTuple
-	12, // 5: developer.v1.Operation.check_parameters:type_name -> developer.v1.CheckOperationParameters
-	15, // 6: developer.v1.Operation.assertions_parameters:type_name -> developer.v1.RunAssertionsParameters
-	17, // 7: developer.v1.Operation.validation_parameters:type_name -> developer.v1.RunValidationParameters
-	19, // 8: developer.v1.Operation.format_schema_parameters:type_name -> developer.v1.FormatSchemaParameters
-	21, // 9: developer.v1.Operation.schema_warnings_parameters:type_name -> developer.v1.SchemaWarningsParameters
-	23, // 10: developer.v1.OperationsResults.results:type_name -> developer.v1.OperationsResults.ResultsEntry
-	13, // 11: developer.v1.OperationResult.check_result:type_name -> developer.v1.CheckOperationsResult
-	16, // 12: developer.v1.OperationResult.assertions_result:type_name -> developer.v1.RunAssertionsResult
-	18, // 13: developer.v1.OperationResult.validation_result:type_name -> developer.v1.RunValidationResult
-	20, // 14: developer.v1.OperationResult.format_schema_result:type_name -> developer.v1.FormatSchemaResult
-	22, // 15: developer.v1.OperationResult.schema_warnings_result:type_name -> developer.v1.SchemaWarningsResult
-	0,  // 16: developer.v1.DeveloperError.source:type_name -> developer.v1.DeveloperError.Source
-	1,  // 17: developer.v1.DeveloperError.kind:type_name -> developer.v1.DeveloperError.ErrorKind
-	25, // 18: developer.v1.DeveloperError.check_debug_information:type_name -> dispatch.v1.DebugInformation
-	26, // 19: developer.v1.DeveloperError.check_resolved_debug_information:type_name -> authzed.api.v1.DebugInformation
-	10, // 20: developer.v1.DeveloperErrors.input_errors:type_name -> developer.v1.DeveloperError
-	27, // 21: developer.v1.CheckOperationParameters.resource:type_name -> core.v1.ObjectAndRelation
-	27, // 22: developer.v1.CheckOperationParameters.subject:type_name -> core.v1.ObjectAndRelation
-	28, // 23: developer.v1.CheckOperationParameters.caveat_context:type_name -> google.protobuf.Struct
-	2,  // 24: developer.v1.CheckOperationsResult.membership:type_name -> developer.v1.CheckOperationsResult.Membership
-	10, // 25: developer.v1.CheckOperationsResult.check_error:type_name -> developer.v1.DeveloperError
-	25, // 26: developer.v1.CheckOperationsResult.debug_information:type_name -> dispatch.v1.DebugInformation
-	14, // 27: developer.v1.CheckOperationsResult.partial_caveat_info:type_name -> developer.v1.PartialCaveatInfo
-	26, // 28: developer.v1.CheckOperationsResult.resolved_debug_information:type_name -> authzed.api.v1.DebugInformation
-	10, // 29: developer.v1.RunAssertionsResult.input_error:type_name -> developer.v1.DeveloperError
-	10, // 30: developer.v1.RunAssertionsResult.validation_errors:type_name -> developer.v1.DeveloperError
-	10, // 31: developer.v1.RunValidationResult.input_error:type_name -> developer.v1.DeveloperError
-	10, // 32: developer.v1.RunValidationResult.validation_errors:type_name -> developer.v1.DeveloperError
-	9,  // 33: developer.v1.SchemaWarningsResult.warnings:type_name -> developer.v1.DeveloperWarning
-	8,  // 34: developer.v1.OperationsResults.ResultsEntry.value:type_name -> developer.v1.OperationResult
-	35, // [35:35] is the sub-list for method output_type
-	35, // [35:35] is the sub-list for method input_type
-	35, // [35:35] is the sub-list for extension type_name
-	35, // [35:35] is the sub-list for extension extendee
-	0,  // [0:35] is the sub-list for field type_name
+	6,  // 0: developer.v1.DeveloperRequest.context:type_name -> developer.v1.RequestContext
+	7,  // 1: developer.v1.DeveloperRequest.operations:type_name -> developer.v1.Operation
+	14, // 2: developer.v1.DeveloperResponse.developer_errors:type_name -> developer.v1.DeveloperErrors
+	8,  // 3: developer.v1.DeveloperResponse.operations_results:type_name -> developer.v1.OperationsResults
+	27, // 4: developer.v1.RequestContext.relationships:type_name -> core.v1.RelationTuple
+	15, // 5: developer.v1.Operation.check_parameters:type_name -> developer.v1.CheckOperationParameters
+	18, // 6: developer.v1.Operation.assertions_parameters:type_name -> developer.v1.RunAssertionsParameters
+	20, // 7: developer.v1.Operation.validation_parameters:type_name -> developer.v1.RunValidationParameters
+	22, // 8: developer.v1.Operation.format_schema_parameters:type_name -> developer.v1.FormatSchemaParameters
+	24, // 9: developer.v1.Operation.schema_warnings_parameters:type_name -> developer.v1.SchemaWarningsParameters
+	26, // 10: developer.v1.OperationsResults.results:type_name -> developer.v1.OperationsResults.ResultsEntry
+	16, // 11: developer.v1.OperationResult.check_result:type_name -> developer.v1.CheckOperationsResult
+	19, // 12: developer.v1.OperationResult.assertions_result:type_name -> developer.v1.RunAssertionsResult
+	21, // 13: developer.v1.OperationResult.validation_result:type_name -> developer.v1.RunValidationResult
+	23, // 14: developer.v1.OperationResult.format_schema_result:type_name -> developer.v1.FormatSchemaResult
+	25, // 15: developer.v1.OperationResult.schema_warnings_result:type_name -> developer.v1.SchemaWarningsResult
+	11, // 16: developer.v1.DeveloperWarning.suggested_fixes:type_name -> developer.v1.SuggestedFix
+	0,  // 17: developer.v1.DeveloperWarning.severity:type_name -> developer.v1.DeveloperWarning.Severity
+	12, // 18: developer.v1.SuggestedFix.edits:type_name -> developer.v1.SchemaEdit
+	1,  // 19: developer.v1.DeveloperError.source:type_name -> developer.v1.DeveloperError.Source
+	2,  // 20: developer.v1.DeveloperError.kind:type_name -> developer.v1.DeveloperError.ErrorKind
+	28, // 21: developer.v1.DeveloperError.check_debug_information:type_name -> dispatch.v1.DebugInformation
+	29, // 22: developer.v1.DeveloperError.check_resolved_debug_information:type_name -> authzed.api.v1.DebugInformation
+	13, // 23: developer.v1.DeveloperErrors.input_errors:type_name -> developer.v1.DeveloperError
+	30, // 24: developer.v1.CheckOperationParameters.resource:type_name -> core.v1.ObjectAndRelation
+	30, // 25: developer.v1.CheckOperationParameters.subject:type_name -> core.v1.ObjectAndRelation
+	31, // 26: developer.v1.CheckOperationParameters.caveat_context:type_name -> google.protobuf.Struct
+	3,  // 27: developer.v1.CheckOperationsResult.membership:type_name -> developer.v1.CheckOperationsResult.Membership
+	13, // 28: developer.v1.CheckOperationsResult.check_error:type_name -> developer.v1.DeveloperError
+	28, // 29: developer.v1.CheckOperationsResult.debug_information:type_name -> dispatch.v1.DebugInformation
+	17, // 30: developer.v1.CheckOperationsResult.partial_caveat_info:type_name -> developer.v1.PartialCaveatInfo
+	29, // 31: developer.v1.CheckOperationsResult.resolved_debug_information:type_name -> authzed.api.v1.DebugInformation
+	13, // 32: developer.v1.RunAssertionsResult.input_error:type_name -> developer.v1.DeveloperError
+	13, // 33: developer.v1.RunAssertionsResult.validation_errors:type_name -> developer.v1.DeveloperError
+	13, // 34: developer.v1.RunValidationResult.input_error:type_name -> developer.v1.DeveloperError
+	13, // 35: developer.v1.RunValidationResult.validation_errors:type_name -> developer.v1.DeveloperError
+	10, // 36: developer.v1.SchemaWarningsResult.warnings:type_name -> developer.v1.DeveloperWarning
+	9,  // 37: developer.v1.OperationsResults.ResultsEntry.value:type_name -> developer.v1.OperationResult
+	38, // [38:38] is the sub-list for method output_type
+	38, // [38:38] is the sub-list for method input_type
+	38, // [38:38] is the sub-list for extension type_name
+	38, // [38:38] is the sub-list for extension extendee
+	0,  // [0:38] is the sub-list for field type_name
 }
 
 func init() { file_developer_v1_developer_proto_init() }
@@ -1604,8 +1833,8 @@ func file_developer_v1_developer_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_developer_v1_developer_proto_rawDesc), len(file_developer_v1_developer_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   21,
+			NumEnums:      4,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -1163,6 +1163,42 @@ func (m *DeveloperWarning) validate(all bool) error {
 
 	// no validation rules for SourceCode
 
+	for idx, item := range m.GetSuggestedFixes() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DeveloperWarningValidationError{
+						field:  fmt.Sprintf("SuggestedFixes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DeveloperWarningValidationError{
+						field:  fmt.Sprintf("SuggestedFixes[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DeveloperWarningValidationError{
+					field:  fmt.Sprintf("SuggestedFixes[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for Severity
+
 	if len(errors) > 0 {
 		return DeveloperWarningMultiError(errors)
 	}
@@ -1241,6 +1277,250 @@ var _ interface {
 	ErrorName() string
 } = DeveloperWarningValidationError{}
 
+// Validate checks the field values on SuggestedFix with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SuggestedFix) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SuggestedFix with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SuggestedFixMultiError, or
+// nil if none found.
+func (m *SuggestedFix) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SuggestedFix) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Description
+
+	for idx, item := range m.GetEdits() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SuggestedFixValidationError{
+						field:  fmt.Sprintf("Edits[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SuggestedFixValidationError{
+						field:  fmt.Sprintf("Edits[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SuggestedFixValidationError{
+					field:  fmt.Sprintf("Edits[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return SuggestedFixMultiError(errors)
+	}
+
+	return nil
+}
+
+// SuggestedFixMultiError is an error wrapping multiple validation errors
+// returned by SuggestedFix.ValidateAll() if the designated constraints aren't met.
+type SuggestedFixMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SuggestedFixMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SuggestedFixMultiError) AllErrors() []error { return m }
+
+// SuggestedFixValidationError is the validation error returned by
+// SuggestedFix.Validate if the designated constraints aren't met.
+type SuggestedFixValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SuggestedFixValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SuggestedFixValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SuggestedFixValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SuggestedFixValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SuggestedFixValidationError) ErrorName() string { return "SuggestedFixValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SuggestedFixValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSuggestedFix.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SuggestedFixValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SuggestedFixValidationError{}
+
+// Validate checks the field values on SchemaEdit with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SchemaEdit) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SchemaEdit with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SchemaEditMultiError, or
+// nil if none found.
+func (m *SchemaEdit) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SchemaEdit) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Line
+
+	// no validation rules for Column
+
+	// no validation rules for EndLine
+
+	// no validation rules for EndColumn
+
+	// no validation rules for NewText
+
+	if len(errors) > 0 {
+		return SchemaEditMultiError(errors)
+	}
+
+	return nil
+}
+
+// SchemaEditMultiError is an error wrapping multiple validation errors
+// returned by SchemaEdit.ValidateAll() if the designated constraints aren't met.
+type SchemaEditMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SchemaEditMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SchemaEditMultiError) AllErrors() []error { return m }
+
+// SchemaEditValidationError is the validation error returned by
+// SchemaEdit.Validate if the designated constraints aren't met.
+type SchemaEditValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SchemaEditValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SchemaEditValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SchemaEditValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SchemaEditValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SchemaEditValidationError) ErrorName() string { return "SchemaEditValidationError" }
+
+// Error satisfies the builtin error interface
+func (e SchemaEditValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSchemaEdit.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SchemaEditValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SchemaEditValidationError{}
+
 // Validate checks the field values on DeveloperError with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
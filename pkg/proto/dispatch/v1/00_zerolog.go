@@ -63,6 +63,23 @@ func (ls *DispatchLookupSubjectsRequest) MarshalZerologObject(e *zerolog.Event)
 	e.Array("resource-ids", strArray(ls.ResourceIds))
 }
 
+// MarshalZerologObject implements zerolog object marshalling.
+func (qp *DispatchQueryPlanRequest) MarshalZerologObject(e *zerolog.Event) {
+	e.Object("metadata", qp.Metadata)
+	e.Stringer("operation", qp.Operation)
+	e.Str("resource-type", qp.ResourceType)
+	e.Array("resource-ids", strArray(qp.ResourceIds))
+	if qp.Subject != nil {
+		e.Str("subject", tuple.StringCoreONR(qp.Subject))
+	}
+}
+
+// MarshalZerologObject implements zerolog object marshalling.
+func (qp *DispatchQueryPlanResponse) MarshalZerologObject(e *zerolog.Event) {
+	e.Object("metadata", qp.Metadata)
+	e.Int("path-count", len(qp.Paths))
+}
+
 type strArray []string
 
 // MarshalZerologArray implements zerolog array marshalling.
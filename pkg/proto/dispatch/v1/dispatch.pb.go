@@ -13,6 +13,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -218,6 +219,55 @@ func (DispatchExpandRequest_ExpansionMode) EnumDescriptor() ([]byte, []int) {
 	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{4, 0}
 }
 
+type DispatchQueryPlanRequest_Operation int32
+
+const (
+	DispatchQueryPlanRequest_CHECK          DispatchQueryPlanRequest_Operation = 0
+	DispatchQueryPlanRequest_ITER_SUBJECTS  DispatchQueryPlanRequest_Operation = 1
+	DispatchQueryPlanRequest_ITER_RESOURCES DispatchQueryPlanRequest_Operation = 2
+)
+
+// Enum value maps for DispatchQueryPlanRequest_Operation.
+var (
+	DispatchQueryPlanRequest_Operation_name = map[int32]string{
+		0: "CHECK",
+		1: "ITER_SUBJECTS",
+		2: "ITER_RESOURCES",
+	}
+	DispatchQueryPlanRequest_Operation_value = map[string]int32{
+		"CHECK":          0,
+		"ITER_SUBJECTS":  1,
+		"ITER_RESOURCES": 2,
+	}
+)
+
+func (x DispatchQueryPlanRequest_Operation) Enum() *DispatchQueryPlanRequest_Operation {
+	p := new(DispatchQueryPlanRequest_Operation)
+	*p = x
+	return p
+}
+
+func (x DispatchQueryPlanRequest_Operation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DispatchQueryPlanRequest_Operation) Descriptor() protoreflect.EnumDescriptor {
+	return file_dispatch_v1_dispatch_proto_enumTypes[4].Descriptor()
+}
+
+func (DispatchQueryPlanRequest_Operation) Type() protoreflect.EnumType {
+	return &file_dispatch_v1_dispatch_proto_enumTypes[4]
+}
+
+func (x DispatchQueryPlanRequest_Operation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DispatchQueryPlanRequest_Operation.Descriptor instead.
+func (DispatchQueryPlanRequest_Operation) EnumDescriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{17, 0}
+}
+
 type CheckDebugTrace_RelationType int32
 
 const (
@@ -251,11 +301,11 @@ func (x CheckDebugTrace_RelationType) String() string {
 }
 
 func (CheckDebugTrace_RelationType) Descriptor() protoreflect.EnumDescriptor {
-	return file_dispatch_v1_dispatch_proto_enumTypes[4].Descriptor()
+	return file_dispatch_v1_dispatch_proto_enumTypes[5].Descriptor()
 }
 
 func (CheckDebugTrace_RelationType) Type() protoreflect.EnumType {
-	return &file_dispatch_v1_dispatch_proto_enumTypes[4]
+	return &file_dispatch_v1_dispatch_proto_enumTypes[5]
 }
 
 func (x CheckDebugTrace_RelationType) Number() protoreflect.EnumNumber {
@@ -264,7 +314,7 @@ func (x CheckDebugTrace_RelationType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use CheckDebugTrace_RelationType.Descriptor instead.
 func (CheckDebugTrace_RelationType) EnumDescriptor() ([]byte, []int) {
-	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{20, 0}
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{35, 0}
 }
 
 type DispatchCheckRequest struct {
@@ -1365,31 +1415,48 @@ func (x *DispatchLookupSubjectsResponse) GetMetadata() *ResponseMeta {
 	return nil
 }
 
-type ResolverMeta struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	AtRevision     string                 `protobuf:"bytes,1,opt,name=at_revision,json=atRevision,proto3" json:"at_revision,omitempty"`
-	DepthRemaining uint32                 `protobuf:"varint,2,opt,name=depth_remaining,json=depthRemaining,proto3" json:"depth_remaining,omitempty"`
-	// Deprecated: Marked as deprecated in dispatch/v1/dispatch.proto.
-	RequestId      string `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
-	TraversalBloom []byte `protobuf:"bytes,4,opt,name=traversal_bloom,json=traversalBloom,proto3" json:"traversal_bloom,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+type DispatchQueryPlanRequest struct {
+	state     protoimpl.MessageState             `protogen:"open.v1"`
+	Metadata  *ResolverMeta                      `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Plan      *QueryPlanNode                     `protobuf:"bytes,2,opt,name=plan,proto3" json:"plan,omitempty"`
+	Operation DispatchQueryPlanRequest_Operation `protobuf:"varint,3,opt,name=operation,proto3,enum=dispatch.v1.DispatchQueryPlanRequest_Operation" json:"operation,omitempty"`
+	// *
+	// resource_type and resource_ids are the resources to check, or, for ITER_SUBJECTS, the single
+	// resource whose subjects are iterated.
+	ResourceType string   `protobuf:"bytes,4,opt,name=resource_type,json=resourceType,proto3" json:"resource_type,omitempty"`
+	ResourceIds  []string `protobuf:"bytes,5,rep,name=resource_ids,json=resourceIds,proto3" json:"resource_ids,omitempty"`
+	// *
+	// subject is the subject to check or whose resources are iterated. Unset for ITER_SUBJECTS.
+	Subject           *v1.ObjectAndRelation `protobuf:"bytes,6,opt,name=subject,proto3" json:"subject,omitempty"`
+	CaveatContext     *structpb.Struct      `protobuf:"bytes,7,opt,name=caveat_context,json=caveatContext,proto3" json:"caveat_context,omitempty"`
+	MaxRecursionDepth uint32                `protobuf:"varint,8,opt,name=max_recursion_depth,json=maxRecursionDepth,proto3" json:"max_recursion_depth,omitempty"`
+	// *
+	// analyze, if true, asks for the analysis of the evaluation of the subtree to be returned in
+	// the debug information of the response.
+	Analyze bool `protobuf:"varint,9,opt,name=analyze,proto3" json:"analyze,omitempty"`
+	// *
+	// concurrency_limit is the number of subiterators the request which dispatched the subtree
+	// allows to be evaluated concurrently. The node evaluating it uses the lower of this limit
+	// and its own; if unset, its own limit is used.
+	ConcurrencyLimit *uint32 `protobuf:"varint,10,opt,name=concurrency_limit,json=concurrencyLimit,proto3,oneof" json:"concurrency_limit,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *ResolverMeta) Reset() {
-	*x = ResolverMeta{}
+func (x *DispatchQueryPlanRequest) Reset() {
+	*x = DispatchQueryPlanRequest{}
 	mi := &file_dispatch_v1_dispatch_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResolverMeta) String() string {
+func (x *DispatchQueryPlanRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResolverMeta) ProtoMessage() {}
+func (*DispatchQueryPlanRequest) ProtoMessage() {}
 
-func (x *ResolverMeta) ProtoReflect() protoreflect.Message {
+func (x *DispatchQueryPlanRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_dispatch_v1_dispatch_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1401,64 +1468,103 @@ func (x *ResolverMeta) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResolverMeta.ProtoReflect.Descriptor instead.
-func (*ResolverMeta) Descriptor() ([]byte, []int) {
+// Deprecated: Use DispatchQueryPlanRequest.ProtoReflect.Descriptor instead.
+func (*DispatchQueryPlanRequest) Descriptor() ([]byte, []int) {
 	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *ResolverMeta) GetAtRevision() string {
+func (x *DispatchQueryPlanRequest) GetMetadata() *ResolverMeta {
 	if x != nil {
-		return x.AtRevision
+		return x.Metadata
 	}
-	return ""
+	return nil
 }
 
-func (x *ResolverMeta) GetDepthRemaining() uint32 {
+func (x *DispatchQueryPlanRequest) GetPlan() *QueryPlanNode {
 	if x != nil {
-		return x.DepthRemaining
+		return x.Plan
 	}
-	return 0
+	return nil
 }
 
-// Deprecated: Marked as deprecated in dispatch/v1/dispatch.proto.
-func (x *ResolverMeta) GetRequestId() string {
+func (x *DispatchQueryPlanRequest) GetOperation() DispatchQueryPlanRequest_Operation {
 	if x != nil {
-		return x.RequestId
+		return x.Operation
+	}
+	return DispatchQueryPlanRequest_CHECK
+}
+
+func (x *DispatchQueryPlanRequest) GetResourceType() string {
+	if x != nil {
+		return x.ResourceType
 	}
 	return ""
 }
 
-func (x *ResolverMeta) GetTraversalBloom() []byte {
+func (x *DispatchQueryPlanRequest) GetResourceIds() []string {
 	if x != nil {
-		return x.TraversalBloom
+		return x.ResourceIds
 	}
 	return nil
 }
 
-type ResponseMeta struct {
-	state               protoimpl.MessageState `protogen:"open.v1"`
-	DispatchCount       uint32                 `protobuf:"varint,1,opt,name=dispatch_count,json=dispatchCount,proto3" json:"dispatch_count,omitempty"`
-	DepthRequired       uint32                 `protobuf:"varint,2,opt,name=depth_required,json=depthRequired,proto3" json:"depth_required,omitempty"`
-	CachedDispatchCount uint32                 `protobuf:"varint,3,opt,name=cached_dispatch_count,json=cachedDispatchCount,proto3" json:"cached_dispatch_count,omitempty"`
-	DebugInfo           *DebugInformation      `protobuf:"bytes,6,opt,name=debug_info,json=debugInfo,proto3" json:"debug_info,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+func (x *DispatchQueryPlanRequest) GetSubject() *v1.ObjectAndRelation {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
 }
 
-func (x *ResponseMeta) Reset() {
-	*x = ResponseMeta{}
+func (x *DispatchQueryPlanRequest) GetCaveatContext() *structpb.Struct {
+	if x != nil {
+		return x.CaveatContext
+	}
+	return nil
+}
+
+func (x *DispatchQueryPlanRequest) GetMaxRecursionDepth() uint32 {
+	if x != nil {
+		return x.MaxRecursionDepth
+	}
+	return 0
+}
+
+func (x *DispatchQueryPlanRequest) GetAnalyze() bool {
+	if x != nil {
+		return x.Analyze
+	}
+	return false
+}
+
+func (x *DispatchQueryPlanRequest) GetConcurrencyLimit() uint32 {
+	if x != nil && x.ConcurrencyLimit != nil {
+		return *x.ConcurrencyLimit
+	}
+	return 0
+}
+
+type DispatchQueryPlanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Metadata      *ResponseMeta          `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	Paths         []*QueryPlanPath       `protobuf:"bytes,2,rep,name=paths,proto3" json:"paths,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DispatchQueryPlanResponse) Reset() {
+	*x = DispatchQueryPlanResponse{}
 	mi := &file_dispatch_v1_dispatch_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResponseMeta) String() string {
+func (x *DispatchQueryPlanResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResponseMeta) ProtoMessage() {}
+func (*DispatchQueryPlanResponse) ProtoMessage() {}
 
-func (x *ResponseMeta) ProtoReflect() protoreflect.Message {
+func (x *DispatchQueryPlanResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_dispatch_v1_dispatch_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1470,60 +1576,62 @@ func (x *ResponseMeta) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResponseMeta.ProtoReflect.Descriptor instead.
-func (*ResponseMeta) Descriptor() ([]byte, []int) {
+// Deprecated: Use DispatchQueryPlanResponse.ProtoReflect.Descriptor instead.
+func (*DispatchQueryPlanResponse) Descriptor() ([]byte, []int) {
 	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *ResponseMeta) GetDispatchCount() uint32 {
-	if x != nil {
-		return x.DispatchCount
-	}
-	return 0
-}
-
-func (x *ResponseMeta) GetDepthRequired() uint32 {
-	if x != nil {
-		return x.DepthRequired
-	}
-	return 0
-}
-
-func (x *ResponseMeta) GetCachedDispatchCount() uint32 {
+func (x *DispatchQueryPlanResponse) GetMetadata() *ResponseMeta {
 	if x != nil {
-		return x.CachedDispatchCount
+		return x.Metadata
 	}
-	return 0
+	return nil
 }
 
-func (x *ResponseMeta) GetDebugInfo() *DebugInformation {
+func (x *DispatchQueryPlanResponse) GetPaths() []*QueryPlanPath {
 	if x != nil {
-		return x.DebugInfo
+		return x.Paths
 	}
 	return nil
 }
 
-type DebugInformation struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Check         *CheckDebugTrace       `protobuf:"bytes,1,opt,name=check,proto3" json:"check,omitempty"`
+// *
+// QueryPlanNode is a serialized subtree of a query plan. Relations are referenced by name and
+// resolved against the schema at the revision of the request.
+type QueryPlanNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Node:
+	//
+	//	*QueryPlanNode_Relation
+	//	*QueryPlanNode_Union
+	//	*QueryPlanNode_Intersection
+	//	*QueryPlanNode_Exclusion
+	//	*QueryPlanNode_Arrow
+	//	*QueryPlanNode_IntersectionArrow
+	//	*QueryPlanNode_Alias
+	//	*QueryPlanNode_Caveat
+	//	*QueryPlanNode_Fixed
+	//	*QueryPlanNode_Recursive
+	//	*QueryPlanNode_RecursiveSentinel
+	Node          isQueryPlanNode_Node `protobuf_oneof:"node"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DebugInformation) Reset() {
-	*x = DebugInformation{}
+func (x *QueryPlanNode) Reset() {
+	*x = QueryPlanNode{}
 	mi := &file_dispatch_v1_dispatch_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DebugInformation) String() string {
+func (x *QueryPlanNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DebugInformation) ProtoMessage() {}
+func (*QueryPlanNode) ProtoMessage() {}
 
-func (x *DebugInformation) ProtoReflect() protoreflect.Message {
+func (x *QueryPlanNode) ProtoReflect() protoreflect.Message {
 	mi := &file_dispatch_v1_dispatch_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1535,154 +1643,1491 @@ func (x *DebugInformation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DebugInformation.ProtoReflect.Descriptor instead.
-func (*DebugInformation) Descriptor() ([]byte, []int) {
+// Deprecated: Use QueryPlanNode.ProtoReflect.Descriptor instead.
+func (*QueryPlanNode) Descriptor() ([]byte, []int) {
 	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *DebugInformation) GetCheck() *CheckDebugTrace {
+func (x *QueryPlanNode) GetNode() isQueryPlanNode_Node {
 	if x != nil {
-		return x.Check
+		return x.Node
 	}
 	return nil
 }
 
-type CheckDebugTrace struct {
-	state                protoimpl.MessageState          `protogen:"open.v1"`
-	Request              *DispatchCheckRequest           `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
-	ResourceRelationType CheckDebugTrace_RelationType    `protobuf:"varint,2,opt,name=resource_relation_type,json=resourceRelationType,proto3,enum=dispatch.v1.CheckDebugTrace_RelationType" json:"resource_relation_type,omitempty"`
-	Results              map[string]*ResourceCheckResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	IsCachedResult       bool                            `protobuf:"varint,4,opt,name=is_cached_result,json=isCachedResult,proto3" json:"is_cached_result,omitempty"`
-	SubProblems          []*CheckDebugTrace              `protobuf:"bytes,5,rep,name=sub_problems,json=subProblems,proto3" json:"sub_problems,omitempty"`
-	Duration             *durationpb.Duration            `protobuf:"bytes,6,opt,name=duration,proto3" json:"duration,omitempty"`
-	TraceId              string                          `protobuf:"bytes,7,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
-	SourceId             string                          `protobuf:"bytes,8,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
-}
-
-func (x *CheckDebugTrace) Reset() {
-	*x = CheckDebugTrace{}
-	mi := &file_dispatch_v1_dispatch_proto_msgTypes[20]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *CheckDebugTrace) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *QueryPlanNode) GetRelation() *QueryPlanRelation {
+	if x != nil {
+		if x, ok := x.Node.(*QueryPlanNode_Relation); ok {
+			return x.Relation
+		}
+	}
+	return nil
 }
 
-func (*CheckDebugTrace) ProtoMessage() {}
-
-func (x *CheckDebugTrace) ProtoReflect() protoreflect.Message {
-	mi := &file_dispatch_v1_dispatch_proto_msgTypes[20]
+func (x *QueryPlanNode) GetUnion() *QueryPlanChildren {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
+		if x, ok := x.Node.(*QueryPlanNode_Union); ok {
+			return x.Union
 		}
-		return ms
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use CheckDebugTrace.ProtoReflect.Descriptor instead.
-func (*CheckDebugTrace) Descriptor() ([]byte, []int) {
-	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{20}
+func (x *QueryPlanNode) GetIntersection() *QueryPlanChildren {
+	if x != nil {
+		if x, ok := x.Node.(*QueryPlanNode_Intersection); ok {
+			return x.Intersection
+		}
+	}
+	return nil
 }
 
-func (x *CheckDebugTrace) GetRequest() *DispatchCheckRequest {
+func (x *QueryPlanNode) GetExclusion() *QueryPlanExclusion {
 	if x != nil {
-		return x.Request
+		if x, ok := x.Node.(*QueryPlanNode_Exclusion); ok {
+			return x.Exclusion
+		}
 	}
 	return nil
 }
 
-func (x *CheckDebugTrace) GetResourceRelationType() CheckDebugTrace_RelationType {
+func (x *QueryPlanNode) GetArrow() *QueryPlanArrow {
 	if x != nil {
-		return x.ResourceRelationType
+		if x, ok := x.Node.(*QueryPlanNode_Arrow); ok {
+			return x.Arrow
+		}
 	}
-	return CheckDebugTrace_UNKNOWN
+	return nil
 }
 
-func (x *CheckDebugTrace) GetResults() map[string]*ResourceCheckResult {
+func (x *QueryPlanNode) GetIntersectionArrow() *QueryPlanArrow {
 	if x != nil {
-		return x.Results
+		if x, ok := x.Node.(*QueryPlanNode_IntersectionArrow); ok {
+			return x.IntersectionArrow
+		}
 	}
 	return nil
 }
 
-func (x *CheckDebugTrace) GetIsCachedResult() bool {
+func (x *QueryPlanNode) GetAlias() *QueryPlanAlias {
 	if x != nil {
-		return x.IsCachedResult
+		if x, ok := x.Node.(*QueryPlanNode_Alias); ok {
+			return x.Alias
+		}
 	}
-	return false
+	return nil
 }
 
-func (x *CheckDebugTrace) GetSubProblems() []*CheckDebugTrace {
+func (x *QueryPlanNode) GetCaveat() *QueryPlanCaveat {
 	if x != nil {
-		return x.SubProblems
+		if x, ok := x.Node.(*QueryPlanNode_Caveat); ok {
+			return x.Caveat
+		}
 	}
 	return nil
 }
 
-func (x *CheckDebugTrace) GetDuration() *durationpb.Duration {
+func (x *QueryPlanNode) GetFixed() *QueryPlanFixed {
 	if x != nil {
-		return x.Duration
+		if x, ok := x.Node.(*QueryPlanNode_Fixed); ok {
+			return x.Fixed
+		}
 	}
 	return nil
 }
 
-func (x *CheckDebugTrace) GetTraceId() string {
+func (x *QueryPlanNode) GetRecursive() *QueryPlanRecursive {
 	if x != nil {
-		return x.TraceId
+		if x, ok := x.Node.(*QueryPlanNode_Recursive); ok {
+			return x.Recursive
+		}
 	}
-	return ""
+	return nil
 }
 
-func (x *CheckDebugTrace) GetSourceId() string {
+func (x *QueryPlanNode) GetRecursiveSentinel() *QueryPlanRecursiveSentinel {
 	if x != nil {
-		return x.SourceId
+		if x, ok := x.Node.(*QueryPlanNode_RecursiveSentinel); ok {
+			return x.RecursiveSentinel
+		}
 	}
-	return ""
+	return nil
 }
 
-var File_dispatch_v1_dispatch_proto protoreflect.FileDescriptor
+type isQueryPlanNode_Node interface {
+	isQueryPlanNode_Node()
+}
 
-const file_dispatch_v1_dispatch_proto_rawDesc = "" +
-	"\n" +
-	"\x1adispatch/v1/dispatch.proto\x12\vdispatch.v1\x1a\x12core/v1/core.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x17validate/validate.proto\"\x81\x05\n" +
-	"\x14DispatchCheckRequest\x12?\n" +
-	"\bmetadata\x18\x01 \x01(\v2\x19.dispatch.v1.ResolverMetaB\b\xfaB\x05\x8a\x01\x02\x10\x01R\bmetadata\x12Q\n" +
-	"\x11resource_relation\x18\x02 \x01(\v2\x1a.core.v1.RelationReferenceB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x10resourceRelation\x12!\n" +
-	"\fresource_ids\x18\x03 \x03(\tR\vresourceIds\x12>\n" +
-	"\asubject\x18\x04 \x01(\v2\x1a.core.v1.ObjectAndRelationB\b\xfaB\x05\x8a\x01\x02\x10\x01R\asubject\x12Y\n" +
-	"\x0fresults_setting\x18\x05 \x01(\x0e20.dispatch.v1.DispatchCheckRequest.ResultsSettingR\x0eresultsSetting\x12D\n" +
-	"\x05debug\x18\x06 \x01(\x0e2..dispatch.v1.DispatchCheckRequest.DebugSettingR\x05debug\x127\n" +
-	"\vcheck_hints\x18\a \x03(\v2\x16.dispatch.v1.CheckHintR\n" +
-	"checkHints\"T\n" +
-	"\fDebugSetting\x12\f\n" +
-	"\bNO_DEBUG\x10\x00\x12\x1a\n" +
-	"\x16ENABLE_BASIC_DEBUGGING\x10\x01\x12\x1a\n" +
-	"\x16ENABLE_TRACE_DEBUGGING\x10\x02\"B\n" +
-	"\x0eResultsSetting\x12\x17\n" +
-	"\x13REQUIRE_ALL_RESULTS\x10\x00\x12\x17\n" +
-	"\x13ALLOW_SINGLE_RESULT\x10\x01\"\xf6\x01\n" +
-	"\tCheckHint\x126\n" +
-	"\bresource\x18\x01 \x01(\v2\x1a.core.v1.ObjectAndRelationR\bresource\x124\n" +
-	"\asubject\x18\x02 \x01(\v2\x1a.core.v1.ObjectAndRelationR\asubject\x12A\n" +
-	"\x1dttu_computed_userset_relation\x18\x03 \x01(\tR\x1attuComputedUsersetRelation\x128\n" +
-	"\x06result\x18\x04 \x01(\v2 .dispatch.v1.ResourceCheckResultR\x06result\"\xaa\x02\n" +
-	"\x15DispatchCheckResponse\x125\n" +
-	"\bmetadata\x18\x01 \x01(\v2\x19.dispatch.v1.ResponseMetaR\bmetadata\x12p\n" +
-	"\x16results_by_resource_id\x18\x02 \x03(\v2;.dispatch.v1.DispatchCheckResponse.ResultsByResourceIdEntryR\x13resultsByResourceId\x1ah\n" +
-	"\x18ResultsByResourceIdEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x126\n" +
-	"\x05value\x18\x02 \x01(\v2 .dispatch.v1.ResourceCheckResultR\x05value:\x028\x01\"\x99\x02\n" +
-	"\x13ResourceCheckResult\x12K\n" +
-	"\n" +
-	"membership\x18\x01 \x01(\x0e2+.dispatch.v1.ResourceCheckResult.MembershipR\n" +
-	"membership\x129\n" +
+type QueryPlanNode_Relation struct {
+	Relation *QueryPlanRelation `protobuf:"bytes,1,opt,name=relation,proto3,oneof"`
+}
+
+type QueryPlanNode_Union struct {
+	Union *QueryPlanChildren `protobuf:"bytes,2,opt,name=union,proto3,oneof"`
+}
+
+type QueryPlanNode_Intersection struct {
+	Intersection *QueryPlanChildren `protobuf:"bytes,3,opt,name=intersection,proto3,oneof"`
+}
+
+type QueryPlanNode_Exclusion struct {
+	Exclusion *QueryPlanExclusion `protobuf:"bytes,4,opt,name=exclusion,proto3,oneof"`
+}
+
+type QueryPlanNode_Arrow struct {
+	Arrow *QueryPlanArrow `protobuf:"bytes,5,opt,name=arrow,proto3,oneof"`
+}
+
+type QueryPlanNode_IntersectionArrow struct {
+	IntersectionArrow *QueryPlanArrow `protobuf:"bytes,6,opt,name=intersection_arrow,json=intersectionArrow,proto3,oneof"`
+}
+
+type QueryPlanNode_Alias struct {
+	Alias *QueryPlanAlias `protobuf:"bytes,7,opt,name=alias,proto3,oneof"`
+}
+
+type QueryPlanNode_Caveat struct {
+	Caveat *QueryPlanCaveat `protobuf:"bytes,8,opt,name=caveat,proto3,oneof"`
+}
+
+type QueryPlanNode_Fixed struct {
+	Fixed *QueryPlanFixed `protobuf:"bytes,9,opt,name=fixed,proto3,oneof"`
+}
+
+type QueryPlanNode_Recursive struct {
+	Recursive *QueryPlanRecursive `protobuf:"bytes,10,opt,name=recursive,proto3,oneof"`
+}
+
+type QueryPlanNode_RecursiveSentinel struct {
+	RecursiveSentinel *QueryPlanRecursiveSentinel `protobuf:"bytes,11,opt,name=recursive_sentinel,json=recursiveSentinel,proto3,oneof"`
+}
+
+func (*QueryPlanNode_Relation) isQueryPlanNode_Node() {}
+
+func (*QueryPlanNode_Union) isQueryPlanNode_Node() {}
+
+func (*QueryPlanNode_Intersection) isQueryPlanNode_Node() {}
+
+func (*QueryPlanNode_Exclusion) isQueryPlanNode_Node() {}
+
+func (*QueryPlanNode_Arrow) isQueryPlanNode_Node() {}
+
+func (*QueryPlanNode_IntersectionArrow) isQueryPlanNode_Node() {}
+
+func (*QueryPlanNode_Alias) isQueryPlanNode_Node() {}
+
+func (*QueryPlanNode_Caveat) isQueryPlanNode_Node() {}
+
+func (*QueryPlanNode_Fixed) isQueryPlanNode_Node() {}
+
+func (*QueryPlanNode_Recursive) isQueryPlanNode_Node() {}
+
+func (*QueryPlanNode_RecursiveSentinel) isQueryPlanNode_Node() {}
+
+type QueryPlanRelation struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DefinitionName  string                 `protobuf:"bytes,1,opt,name=definition_name,json=definitionName,proto3" json:"definition_name,omitempty"`
+	RelationName    string                 `protobuf:"bytes,2,opt,name=relation_name,json=relationName,proto3" json:"relation_name,omitempty"`
+	SubjectType     string                 `protobuf:"bytes,3,opt,name=subject_type,json=subjectType,proto3" json:"subject_type,omitempty"`
+	SubjectRelation string                 `protobuf:"bytes,4,opt,name=subject_relation,json=subjectRelation,proto3" json:"subject_relation,omitempty"`
+	CaveatName      string                 `protobuf:"bytes,5,opt,name=caveat_name,json=caveatName,proto3" json:"caveat_name,omitempty"`
+	Expiration      bool                   `protobuf:"varint,6,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Wildcard        bool                   `protobuf:"varint,7,opt,name=wildcard,proto3" json:"wildcard,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *QueryPlanRelation) Reset() {
+	*x = QueryPlanRelation{}
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryPlanRelation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryPlanRelation) ProtoMessage() {}
+
+func (x *QueryPlanRelation) ProtoReflect() protoreflect.Message {
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryPlanRelation.ProtoReflect.Descriptor instead.
+func (*QueryPlanRelation) Descriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *QueryPlanRelation) GetDefinitionName() string {
+	if x != nil {
+		return x.DefinitionName
+	}
+	return ""
+}
+
+func (x *QueryPlanRelation) GetRelationName() string {
+	if x != nil {
+		return x.RelationName
+	}
+	return ""
+}
+
+func (x *QueryPlanRelation) GetSubjectType() string {
+	if x != nil {
+		return x.SubjectType
+	}
+	return ""
+}
+
+func (x *QueryPlanRelation) GetSubjectRelation() string {
+	if x != nil {
+		return x.SubjectRelation
+	}
+	return ""
+}
+
+func (x *QueryPlanRelation) GetCaveatName() string {
+	if x != nil {
+		return x.CaveatName
+	}
+	return ""
+}
+
+func (x *QueryPlanRelation) GetExpiration() bool {
+	if x != nil {
+		return x.Expiration
+	}
+	return false
+}
+
+func (x *QueryPlanRelation) GetWildcard() bool {
+	if x != nil {
+		return x.Wildcard
+	}
+	return false
+}
+
+type QueryPlanChildren struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Children      []*QueryPlanNode       `protobuf:"bytes,1,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryPlanChildren) Reset() {
+	*x = QueryPlanChildren{}
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryPlanChildren) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryPlanChildren) ProtoMessage() {}
+
+func (x *QueryPlanChildren) ProtoReflect() protoreflect.Message {
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryPlanChildren.ProtoReflect.Descriptor instead.
+func (*QueryPlanChildren) Descriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *QueryPlanChildren) GetChildren() []*QueryPlanNode {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type QueryPlanExclusion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MainSet       *QueryPlanNode         `protobuf:"bytes,1,opt,name=main_set,json=mainSet,proto3" json:"main_set,omitempty"`
+	Excluded      *QueryPlanNode         `protobuf:"bytes,2,opt,name=excluded,proto3" json:"excluded,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryPlanExclusion) Reset() {
+	*x = QueryPlanExclusion{}
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryPlanExclusion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryPlanExclusion) ProtoMessage() {}
+
+func (x *QueryPlanExclusion) ProtoReflect() protoreflect.Message {
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryPlanExclusion.ProtoReflect.Descriptor instead.
+func (*QueryPlanExclusion) Descriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *QueryPlanExclusion) GetMainSet() *QueryPlanNode {
+	if x != nil {
+		return x.MainSet
+	}
+	return nil
+}
+
+func (x *QueryPlanExclusion) GetExcluded() *QueryPlanNode {
+	if x != nil {
+		return x.Excluded
+	}
+	return nil
+}
+
+type QueryPlanArrow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Left          *QueryPlanNode         `protobuf:"bytes,1,opt,name=left,proto3" json:"left,omitempty"`
+	Right         *QueryPlanNode         `protobuf:"bytes,2,opt,name=right,proto3" json:"right,omitempty"`
+	RightToLeft   bool                   `protobuf:"varint,3,opt,name=right_to_left,json=rightToLeft,proto3" json:"right_to_left,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryPlanArrow) Reset() {
+	*x = QueryPlanArrow{}
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryPlanArrow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryPlanArrow) ProtoMessage() {}
+
+func (x *QueryPlanArrow) ProtoReflect() protoreflect.Message {
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryPlanArrow.ProtoReflect.Descriptor instead.
+func (*QueryPlanArrow) Descriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *QueryPlanArrow) GetLeft() *QueryPlanNode {
+	if x != nil {
+		return x.Left
+	}
+	return nil
+}
+
+func (x *QueryPlanArrow) GetRight() *QueryPlanNode {
+	if x != nil {
+		return x.Right
+	}
+	return nil
+}
+
+func (x *QueryPlanArrow) GetRightToLeft() bool {
+	if x != nil {
+		return x.RightToLeft
+	}
+	return false
+}
+
+type QueryPlanAlias struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Relation      string                 `protobuf:"bytes,1,opt,name=relation,proto3" json:"relation,omitempty"`
+	Child         *QueryPlanNode         `protobuf:"bytes,2,opt,name=child,proto3" json:"child,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryPlanAlias) Reset() {
+	*x = QueryPlanAlias{}
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryPlanAlias) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryPlanAlias) ProtoMessage() {}
+
+func (x *QueryPlanAlias) ProtoReflect() protoreflect.Message {
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryPlanAlias.ProtoReflect.Descriptor instead.
+func (*QueryPlanAlias) Descriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *QueryPlanAlias) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *QueryPlanAlias) GetChild() *QueryPlanNode {
+	if x != nil {
+		return x.Child
+	}
+	return nil
+}
+
+type QueryPlanCaveat struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	Caveat        *v1.ContextualizedCaveat `protobuf:"bytes,1,opt,name=caveat,proto3" json:"caveat,omitempty"`
+	Child         *QueryPlanNode           `protobuf:"bytes,2,opt,name=child,proto3" json:"child,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryPlanCaveat) Reset() {
+	*x = QueryPlanCaveat{}
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryPlanCaveat) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryPlanCaveat) ProtoMessage() {}
+
+func (x *QueryPlanCaveat) ProtoReflect() protoreflect.Message {
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryPlanCaveat.ProtoReflect.Descriptor instead.
+func (*QueryPlanCaveat) Descriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *QueryPlanCaveat) GetCaveat() *v1.ContextualizedCaveat {
+	if x != nil {
+		return x.Caveat
+	}
+	return nil
+}
+
+func (x *QueryPlanCaveat) GetChild() *QueryPlanNode {
+	if x != nil {
+		return x.Child
+	}
+	return nil
+}
+
+type QueryPlanFixed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Paths         []*QueryPlanPath       `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryPlanFixed) Reset() {
+	*x = QueryPlanFixed{}
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryPlanFixed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryPlanFixed) ProtoMessage() {}
+
+func (x *QueryPlanFixed) ProtoReflect() protoreflect.Message {
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryPlanFixed.ProtoReflect.Descriptor instead.
+func (*QueryPlanFixed) Descriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *QueryPlanFixed) GetPaths() []*QueryPlanPath {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+type QueryPlanRecursive struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DefinitionName string                 `protobuf:"bytes,1,opt,name=definition_name,json=definitionName,proto3" json:"definition_name,omitempty"`
+	RelationName   string                 `protobuf:"bytes,2,opt,name=relation_name,json=relationName,proto3" json:"relation_name,omitempty"`
+	TemplateTree   *QueryPlanNode         `protobuf:"bytes,3,opt,name=template_tree,json=templateTree,proto3" json:"template_tree,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *QueryPlanRecursive) Reset() {
+	*x = QueryPlanRecursive{}
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryPlanRecursive) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryPlanRecursive) ProtoMessage() {}
+
+func (x *QueryPlanRecursive) ProtoReflect() protoreflect.Message {
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryPlanRecursive.ProtoReflect.Descriptor instead.
+func (*QueryPlanRecursive) Descriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *QueryPlanRecursive) GetDefinitionName() string {
+	if x != nil {
+		return x.DefinitionName
+	}
+	return ""
+}
+
+func (x *QueryPlanRecursive) GetRelationName() string {
+	if x != nil {
+		return x.RelationName
+	}
+	return ""
+}
+
+func (x *QueryPlanRecursive) GetTemplateTree() *QueryPlanNode {
+	if x != nil {
+		return x.TemplateTree
+	}
+	return nil
+}
+
+type QueryPlanRecursiveSentinel struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	DefinitionName   string                 `protobuf:"bytes,1,opt,name=definition_name,json=definitionName,proto3" json:"definition_name,omitempty"`
+	RelationName     string                 `protobuf:"bytes,2,opt,name=relation_name,json=relationName,proto3" json:"relation_name,omitempty"`
+	WithSubRelations bool                   `protobuf:"varint,3,opt,name=with_sub_relations,json=withSubRelations,proto3" json:"with_sub_relations,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *QueryPlanRecursiveSentinel) Reset() {
+	*x = QueryPlanRecursiveSentinel{}
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryPlanRecursiveSentinel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryPlanRecursiveSentinel) ProtoMessage() {}
+
+func (x *QueryPlanRecursiveSentinel) ProtoReflect() protoreflect.Message {
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryPlanRecursiveSentinel.ProtoReflect.Descriptor instead.
+func (*QueryPlanRecursiveSentinel) Descriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *QueryPlanRecursiveSentinel) GetDefinitionName() string {
+	if x != nil {
+		return x.DefinitionName
+	}
+	return ""
+}
+
+func (x *QueryPlanRecursiveSentinel) GetRelationName() string {
+	if x != nil {
+		return x.RelationName
+	}
+	return ""
+}
+
+func (x *QueryPlanRecursiveSentinel) GetWithSubRelations() bool {
+	if x != nil {
+		return x.WithSubRelations
+	}
+	return false
+}
+
+type QueryPlanPath struct {
+	state         protoimpl.MessageState      `protogen:"open.v1"`
+	Resource      *v1.ObjectAndRelation       `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	Subject       *v1.ObjectAndRelation       `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Caveat        *v1.CaveatExpression        `protobuf:"bytes,3,opt,name=caveat,proto3" json:"caveat,omitempty"`
+	Expiration    *timestamppb.Timestamp      `protobuf:"bytes,4,opt,name=expiration,proto3" json:"expiration,omitempty"`
+	Integrity     []*v1.RelationshipIntegrity `protobuf:"bytes,5,rep,name=integrity,proto3" json:"integrity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryPlanPath) Reset() {
+	*x = QueryPlanPath{}
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryPlanPath) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryPlanPath) ProtoMessage() {}
+
+func (x *QueryPlanPath) ProtoReflect() protoreflect.Message {
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryPlanPath.ProtoReflect.Descriptor instead.
+func (*QueryPlanPath) Descriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *QueryPlanPath) GetResource() *v1.ObjectAndRelation {
+	if x != nil {
+		return x.Resource
+	}
+	return nil
+}
+
+func (x *QueryPlanPath) GetSubject() *v1.ObjectAndRelation {
+	if x != nil {
+		return x.Subject
+	}
+	return nil
+}
+
+func (x *QueryPlanPath) GetCaveat() *v1.CaveatExpression {
+	if x != nil {
+		return x.Caveat
+	}
+	return nil
+}
+
+func (x *QueryPlanPath) GetExpiration() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expiration
+	}
+	return nil
+}
+
+func (x *QueryPlanPath) GetIntegrity() []*v1.RelationshipIntegrity {
+	if x != nil {
+		return x.Integrity
+	}
+	return nil
+}
+
+// *
+// DispatchPeerCacheLookupRequest requests the result cached by a peer for a dispatch request,
+// without computing it if it is not found.
+type DispatchPeerCacheLookupRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Request:
+	//
+	//	*DispatchPeerCacheLookupRequest_Check
+	//	*DispatchPeerCacheLookupRequest_LookupResources2
+	//	*DispatchPeerCacheLookupRequest_LookupResources3
+	//	*DispatchPeerCacheLookupRequest_LookupSubjects
+	Request       isDispatchPeerCacheLookupRequest_Request `protobuf_oneof:"request"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DispatchPeerCacheLookupRequest) Reset() {
+	*x = DispatchPeerCacheLookupRequest{}
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DispatchPeerCacheLookupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DispatchPeerCacheLookupRequest) ProtoMessage() {}
+
+func (x *DispatchPeerCacheLookupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DispatchPeerCacheLookupRequest.ProtoReflect.Descriptor instead.
+func (*DispatchPeerCacheLookupRequest) Descriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DispatchPeerCacheLookupRequest) GetRequest() isDispatchPeerCacheLookupRequest_Request {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *DispatchPeerCacheLookupRequest) GetCheck() *DispatchCheckRequest {
+	if x != nil {
+		if x, ok := x.Request.(*DispatchPeerCacheLookupRequest_Check); ok {
+			return x.Check
+		}
+	}
+	return nil
+}
+
+func (x *DispatchPeerCacheLookupRequest) GetLookupResources2() *DispatchLookupResources2Request {
+	if x != nil {
+		if x, ok := x.Request.(*DispatchPeerCacheLookupRequest_LookupResources2); ok {
+			return x.LookupResources2
+		}
+	}
+	return nil
+}
+
+func (x *DispatchPeerCacheLookupRequest) GetLookupResources3() *DispatchLookupResources3Request {
+	if x != nil {
+		if x, ok := x.Request.(*DispatchPeerCacheLookupRequest_LookupResources3); ok {
+			return x.LookupResources3
+		}
+	}
+	return nil
+}
+
+func (x *DispatchPeerCacheLookupRequest) GetLookupSubjects() *DispatchLookupSubjectsRequest {
+	if x != nil {
+		if x, ok := x.Request.(*DispatchPeerCacheLookupRequest_LookupSubjects); ok {
+			return x.LookupSubjects
+		}
+	}
+	return nil
+}
+
+type isDispatchPeerCacheLookupRequest_Request interface {
+	isDispatchPeerCacheLookupRequest_Request()
+}
+
+type DispatchPeerCacheLookupRequest_Check struct {
+	Check *DispatchCheckRequest `protobuf:"bytes,1,opt,name=check,proto3,oneof"`
+}
+
+type DispatchPeerCacheLookupRequest_LookupResources2 struct {
+	LookupResources2 *DispatchLookupResources2Request `protobuf:"bytes,2,opt,name=lookup_resources2,json=lookupResources2,proto3,oneof"`
+}
+
+type DispatchPeerCacheLookupRequest_LookupResources3 struct {
+	LookupResources3 *DispatchLookupResources3Request `protobuf:"bytes,3,opt,name=lookup_resources3,json=lookupResources3,proto3,oneof"`
+}
+
+type DispatchPeerCacheLookupRequest_LookupSubjects struct {
+	LookupSubjects *DispatchLookupSubjectsRequest `protobuf:"bytes,4,opt,name=lookup_subjects,json=lookupSubjects,proto3,oneof"`
+}
+
+func (*DispatchPeerCacheLookupRequest_Check) isDispatchPeerCacheLookupRequest_Request() {}
+
+func (*DispatchPeerCacheLookupRequest_LookupResources2) isDispatchPeerCacheLookupRequest_Request() {}
+
+func (*DispatchPeerCacheLookupRequest_LookupResources3) isDispatchPeerCacheLookupRequest_Request() {}
+
+func (*DispatchPeerCacheLookupRequest_LookupSubjects) isDispatchPeerCacheLookupRequest_Request() {}
+
+type DispatchPeerCacheLookupResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Found bool                   `protobuf:"varint,1,opt,name=found,proto3" json:"found,omitempty"`
+	// *
+	// cached_responses are the marshaled responses cached for the request: the single response
+	// of a check, or the responses of a lookup, in the order in which they were streamed.
+	CachedResponses [][]byte `protobuf:"bytes,2,rep,name=cached_responses,json=cachedResponses,proto3" json:"cached_responses,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DispatchPeerCacheLookupResponse) Reset() {
+	*x = DispatchPeerCacheLookupResponse{}
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DispatchPeerCacheLookupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DispatchPeerCacheLookupResponse) ProtoMessage() {}
+
+func (x *DispatchPeerCacheLookupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DispatchPeerCacheLookupResponse.ProtoReflect.Descriptor instead.
+func (*DispatchPeerCacheLookupResponse) Descriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DispatchPeerCacheLookupResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+func (x *DispatchPeerCacheLookupResponse) GetCachedResponses() [][]byte {
+	if x != nil {
+		return x.CachedResponses
+	}
+	return nil
+}
+
+type ResolverMeta struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AtRevision     string                 `protobuf:"bytes,1,opt,name=at_revision,json=atRevision,proto3" json:"at_revision,omitempty"`
+	DepthRemaining uint32                 `protobuf:"varint,2,opt,name=depth_remaining,json=depthRemaining,proto3" json:"depth_remaining,omitempty"`
+	// Deprecated: Marked as deprecated in dispatch/v1/dispatch.proto.
+	RequestId      string `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	TraversalBloom []byte `protobuf:"bytes,4,opt,name=traversal_bloom,json=traversalBloom,proto3" json:"traversal_bloom,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ResolverMeta) Reset() {
+	*x = ResolverMeta{}
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolverMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolverMeta) ProtoMessage() {}
+
+func (x *ResolverMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolverMeta.ProtoReflect.Descriptor instead.
+func (*ResolverMeta) Descriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ResolverMeta) GetAtRevision() string {
+	if x != nil {
+		return x.AtRevision
+	}
+	return ""
+}
+
+func (x *ResolverMeta) GetDepthRemaining() uint32 {
+	if x != nil {
+		return x.DepthRemaining
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in dispatch/v1/dispatch.proto.
+func (x *ResolverMeta) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *ResolverMeta) GetTraversalBloom() []byte {
+	if x != nil {
+		return x.TraversalBloom
+	}
+	return nil
+}
+
+type ResponseMeta struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	DispatchCount       uint32                 `protobuf:"varint,1,opt,name=dispatch_count,json=dispatchCount,proto3" json:"dispatch_count,omitempty"`
+	DepthRequired       uint32                 `protobuf:"varint,2,opt,name=depth_required,json=depthRequired,proto3" json:"depth_required,omitempty"`
+	CachedDispatchCount uint32                 `protobuf:"varint,3,opt,name=cached_dispatch_count,json=cachedDispatchCount,proto3" json:"cached_dispatch_count,omitempty"`
+	DebugInfo           *DebugInformation      `protobuf:"bytes,6,opt,name=debug_info,json=debugInfo,proto3" json:"debug_info,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *ResponseMeta) Reset() {
+	*x = ResponseMeta{}
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResponseMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResponseMeta) ProtoMessage() {}
+
+func (x *ResponseMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResponseMeta.ProtoReflect.Descriptor instead.
+func (*ResponseMeta) Descriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ResponseMeta) GetDispatchCount() uint32 {
+	if x != nil {
+		return x.DispatchCount
+	}
+	return 0
+}
+
+func (x *ResponseMeta) GetDepthRequired() uint32 {
+	if x != nil {
+		return x.DepthRequired
+	}
+	return 0
+}
+
+func (x *ResponseMeta) GetCachedDispatchCount() uint32 {
+	if x != nil {
+		return x.CachedDispatchCount
+	}
+	return 0
+}
+
+func (x *ResponseMeta) GetDebugInfo() *DebugInformation {
+	if x != nil {
+		return x.DebugInfo
+	}
+	return nil
+}
+
+type DebugInformation struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Check             *CheckDebugTrace       `protobuf:"bytes,1,opt,name=check,proto3" json:"check,omitempty"`
+	QueryPlanAnalysis []*QueryPlanAnalysis   `protobuf:"bytes,2,rep,name=query_plan_analysis,json=queryPlanAnalysis,proto3" json:"query_plan_analysis,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DebugInformation) Reset() {
+	*x = DebugInformation{}
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DebugInformation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DebugInformation) ProtoMessage() {}
+
+func (x *DebugInformation) ProtoReflect() protoreflect.Message {
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DebugInformation.ProtoReflect.Descriptor instead.
+func (*DebugInformation) Descriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DebugInformation) GetCheck() *CheckDebugTrace {
+	if x != nil {
+		return x.Check
+	}
+	return nil
+}
+
+func (x *DebugInformation) GetQueryPlanAnalysis() []*QueryPlanAnalysis {
+	if x != nil {
+		return x.QueryPlanAnalysis
+	}
+	return nil
+}
+
+type CheckDebugTrace struct {
+	state                protoimpl.MessageState          `protogen:"open.v1"`
+	Request              *DispatchCheckRequest           `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	ResourceRelationType CheckDebugTrace_RelationType    `protobuf:"varint,2,opt,name=resource_relation_type,json=resourceRelationType,proto3,enum=dispatch.v1.CheckDebugTrace_RelationType" json:"resource_relation_type,omitempty"`
+	Results              map[string]*ResourceCheckResult `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	IsCachedResult       bool                            `protobuf:"varint,4,opt,name=is_cached_result,json=isCachedResult,proto3" json:"is_cached_result,omitempty"`
+	SubProblems          []*CheckDebugTrace              `protobuf:"bytes,5,rep,name=sub_problems,json=subProblems,proto3" json:"sub_problems,omitempty"`
+	Duration             *durationpb.Duration            `protobuf:"bytes,6,opt,name=duration,proto3" json:"duration,omitempty"`
+	TraceId              string                          `protobuf:"bytes,7,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	SourceId             string                          `protobuf:"bytes,8,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *CheckDebugTrace) Reset() {
+	*x = CheckDebugTrace{}
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckDebugTrace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckDebugTrace) ProtoMessage() {}
+
+func (x *CheckDebugTrace) ProtoReflect() protoreflect.Message {
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckDebugTrace.ProtoReflect.Descriptor instead.
+func (*CheckDebugTrace) Descriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CheckDebugTrace) GetRequest() *DispatchCheckRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *CheckDebugTrace) GetResourceRelationType() CheckDebugTrace_RelationType {
+	if x != nil {
+		return x.ResourceRelationType
+	}
+	return CheckDebugTrace_UNKNOWN
+}
+
+func (x *CheckDebugTrace) GetResults() map[string]*ResourceCheckResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *CheckDebugTrace) GetIsCachedResult() bool {
+	if x != nil {
+		return x.IsCachedResult
+	}
+	return false
+}
+
+func (x *CheckDebugTrace) GetSubProblems() []*CheckDebugTrace {
+	if x != nil {
+		return x.SubProblems
+	}
+	return nil
+}
+
+func (x *CheckDebugTrace) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *CheckDebugTrace) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *CheckDebugTrace) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+// *
+// QueryPlanAnalysis holds the statistics of the evaluation of an iterator of a query plan, and
+// those of the subiterators it evaluated, as returned by EXPLAIN ANALYZE.
+type QueryPlanAnalysis struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// *
+	// iterator describes the iterator evaluated.
+	Iterator string `protobuf:"bytes,1,opt,name=iterator,proto3" json:"iterator,omitempty"`
+	// *
+	// calls is the number of times the iterator was evaluated.
+	Calls uint32 `protobuf:"varint,2,opt,name=calls,proto3" json:"calls,omitempty"`
+	// *
+	// duration is the wall time spent evaluating the iterator, including its subiterators.
+	Duration *durationpb.Duration `protobuf:"bytes,3,opt,name=duration,proto3" json:"duration,omitempty"`
+	// *
+	// results is the number of paths produced by the iterator.
+	Results uint64 `protobuf:"varint,4,opt,name=results,proto3" json:"results,omitempty"`
+	// *
+	// datastore_queries and rows_read are the queries issued, and relationships read, by the
+	// iterator itself.
+	DatastoreQueries uint64 `protobuf:"varint,5,opt,name=datastore_queries,json=datastoreQueries,proto3" json:"datastore_queries,omitempty"`
+	RowsRead         uint64 `protobuf:"varint,6,opt,name=rows_read,json=rowsRead,proto3" json:"rows_read,omitempty"`
+	// *
+	// cache_hits is the number of dispatched operations for the iterator answered from cache.
+	CacheHits uint64 `protobuf:"varint,7,opt,name=cache_hits,json=cacheHits,proto3" json:"cache_hits,omitempty"`
+	// *
+	// dispatched indicates that the iterator was evaluated by another node of the cluster.
+	Dispatched bool                 `protobuf:"varint,8,opt,name=dispatched,proto3" json:"dispatched,omitempty"`
+	Children   []*QueryPlanAnalysis `protobuf:"bytes,9,rep,name=children,proto3" json:"children,omitempty"`
+	// *
+	// estimate is the cost-based optimizer's estimate of evaluating the iterator, if computed.
+	Estimate      *QueryPlanEstimate `protobuf:"bytes,10,opt,name=estimate,proto3" json:"estimate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryPlanAnalysis) Reset() {
+	*x = QueryPlanAnalysis{}
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryPlanAnalysis) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryPlanAnalysis) ProtoMessage() {}
+
+func (x *QueryPlanAnalysis) ProtoReflect() protoreflect.Message {
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryPlanAnalysis.ProtoReflect.Descriptor instead.
+func (*QueryPlanAnalysis) Descriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *QueryPlanAnalysis) GetIterator() string {
+	if x != nil {
+		return x.Iterator
+	}
+	return ""
+}
+
+func (x *QueryPlanAnalysis) GetCalls() uint32 {
+	if x != nil {
+		return x.Calls
+	}
+	return 0
+}
+
+func (x *QueryPlanAnalysis) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *QueryPlanAnalysis) GetResults() uint64 {
+	if x != nil {
+		return x.Results
+	}
+	return 0
+}
+
+func (x *QueryPlanAnalysis) GetDatastoreQueries() uint64 {
+	if x != nil {
+		return x.DatastoreQueries
+	}
+	return 0
+}
+
+func (x *QueryPlanAnalysis) GetRowsRead() uint64 {
+	if x != nil {
+		return x.RowsRead
+	}
+	return 0
+}
+
+func (x *QueryPlanAnalysis) GetCacheHits() uint64 {
+	if x != nil {
+		return x.CacheHits
+	}
+	return 0
+}
+
+func (x *QueryPlanAnalysis) GetDispatched() bool {
+	if x != nil {
+		return x.Dispatched
+	}
+	return false
+}
+
+func (x *QueryPlanAnalysis) GetChildren() []*QueryPlanAnalysis {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *QueryPlanAnalysis) GetEstimate() *QueryPlanEstimate {
+	if x != nil {
+		return x.Estimate
+	}
+	return nil
+}
+
+// *
+// QueryPlanEstimate is the cost-based optimizer's estimate of checking a single resource
+// against a single subject with an iterator of a query plan.
+type QueryPlanEstimate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// *
+	// cost is the estimated number of datastore queries made.
+	Cost float64 `protobuf:"fixed64,1,opt,name=cost,proto3" json:"cost,omitempty"`
+	// *
+	// selectivity is the estimated probability, between 0 and 1, that a path is found.
+	Selectivity float64 `protobuf:"fixed64,2,opt,name=selectivity,proto3" json:"selectivity,omitempty"`
+	// *
+	// fanout and fanin are the estimated numbers of subjects reached from a single resource,
+	// and of resources reached from a single subject.
+	Fanout        float64 `protobuf:"fixed64,3,opt,name=fanout,proto3" json:"fanout,omitempty"`
+	Fanin         float64 `protobuf:"fixed64,4,opt,name=fanin,proto3" json:"fanin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryPlanEstimate) Reset() {
+	*x = QueryPlanEstimate{}
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryPlanEstimate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryPlanEstimate) ProtoMessage() {}
+
+func (x *QueryPlanEstimate) ProtoReflect() protoreflect.Message {
+	mi := &file_dispatch_v1_dispatch_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryPlanEstimate.ProtoReflect.Descriptor instead.
+func (*QueryPlanEstimate) Descriptor() ([]byte, []int) {
+	return file_dispatch_v1_dispatch_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *QueryPlanEstimate) GetCost() float64 {
+	if x != nil {
+		return x.Cost
+	}
+	return 0
+}
+
+func (x *QueryPlanEstimate) GetSelectivity() float64 {
+	if x != nil {
+		return x.Selectivity
+	}
+	return 0
+}
+
+func (x *QueryPlanEstimate) GetFanout() float64 {
+	if x != nil {
+		return x.Fanout
+	}
+	return 0
+}
+
+func (x *QueryPlanEstimate) GetFanin() float64 {
+	if x != nil {
+		return x.Fanin
+	}
+	return 0
+}
+
+var File_dispatch_v1_dispatch_proto protoreflect.FileDescriptor
+
+const file_dispatch_v1_dispatch_proto_rawDesc = "" +
+	"\n" +
+	"\x1adispatch/v1/dispatch.proto\x12\vdispatch.v1\x1a\x12core/v1/core.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17validate/validate.proto\"\x81\x05\n" +
+	"\x14DispatchCheckRequest\x12?\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x19.dispatch.v1.ResolverMetaB\b\xfaB\x05\x8a\x01\x02\x10\x01R\bmetadata\x12Q\n" +
+	"\x11resource_relation\x18\x02 \x01(\v2\x1a.core.v1.RelationReferenceB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x10resourceRelation\x12!\n" +
+	"\fresource_ids\x18\x03 \x03(\tR\vresourceIds\x12>\n" +
+	"\asubject\x18\x04 \x01(\v2\x1a.core.v1.ObjectAndRelationB\b\xfaB\x05\x8a\x01\x02\x10\x01R\asubject\x12Y\n" +
+	"\x0fresults_setting\x18\x05 \x01(\x0e20.dispatch.v1.DispatchCheckRequest.ResultsSettingR\x0eresultsSetting\x12D\n" +
+	"\x05debug\x18\x06 \x01(\x0e2..dispatch.v1.DispatchCheckRequest.DebugSettingR\x05debug\x127\n" +
+	"\vcheck_hints\x18\a \x03(\v2\x16.dispatch.v1.CheckHintR\n" +
+	"checkHints\"T\n" +
+	"\fDebugSetting\x12\f\n" +
+	"\bNO_DEBUG\x10\x00\x12\x1a\n" +
+	"\x16ENABLE_BASIC_DEBUGGING\x10\x01\x12\x1a\n" +
+	"\x16ENABLE_TRACE_DEBUGGING\x10\x02\"B\n" +
+	"\x0eResultsSetting\x12\x17\n" +
+	"\x13REQUIRE_ALL_RESULTS\x10\x00\x12\x17\n" +
+	"\x13ALLOW_SINGLE_RESULT\x10\x01\"\xf6\x01\n" +
+	"\tCheckHint\x126\n" +
+	"\bresource\x18\x01 \x01(\v2\x1a.core.v1.ObjectAndRelationR\bresource\x124\n" +
+	"\asubject\x18\x02 \x01(\v2\x1a.core.v1.ObjectAndRelationR\asubject\x12A\n" +
+	"\x1dttu_computed_userset_relation\x18\x03 \x01(\tR\x1attuComputedUsersetRelation\x128\n" +
+	"\x06result\x18\x04 \x01(\v2 .dispatch.v1.ResourceCheckResultR\x06result\"\xaa\x02\n" +
+	"\x15DispatchCheckResponse\x125\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x19.dispatch.v1.ResponseMetaR\bmetadata\x12p\n" +
+	"\x16results_by_resource_id\x18\x02 \x03(\v2;.dispatch.v1.DispatchCheckResponse.ResultsByResourceIdEntryR\x13resultsByResourceId\x1ah\n" +
+	"\x18ResultsByResourceIdEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x126\n" +
+	"\x05value\x18\x02 \x01(\v2 .dispatch.v1.ResourceCheckResultR\x05value:\x028\x01\"\x99\x02\n" +
+	"\x13ResourceCheckResult\x12K\n" +
+	"\n" +
+	"membership\x18\x01 \x01(\x0e2+.dispatch.v1.ResourceCheckResult.MembershipR\n" +
+	"membership\x129\n" +
 	"\n" +
 	"expression\x18\x02 \x01(\v2\x19.core.v1.CaveatExpressionR\n" +
 	"expression\x12.\n" +
@@ -1762,7 +3207,94 @@ const file_dispatch_v1_dispatch_proto_rawDesc = "" +
 	"\bmetadata\x18\x02 \x01(\v2\x19.dispatch.v1.ResponseMetaR\bmetadata\x1ah\n" +
 	"\x1eFoundSubjectsByResourceIdEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x120\n" +
-	"\x05value\x18\x02 \x01(\v2\x1a.dispatch.v1.FoundSubjectsR\x05value:\x028\x01\"\xb7\x01\n" +
+	"\x05value\x18\x02 \x01(\v2\x1a.dispatch.v1.FoundSubjectsR\x05value:\x028\x01\"\xf3\x04\n" +
+	"\x18DispatchQueryPlanRequest\x12?\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x19.dispatch.v1.ResolverMetaB\b\xfaB\x05\x8a\x01\x02\x10\x01R\bmetadata\x128\n" +
+	"\x04plan\x18\x02 \x01(\v2\x1a.dispatch.v1.QueryPlanNodeB\b\xfaB\x05\x8a\x01\x02\x10\x01R\x04plan\x12M\n" +
+	"\toperation\x18\x03 \x01(\x0e2/.dispatch.v1.DispatchQueryPlanRequest.OperationR\toperation\x12#\n" +
+	"\rresource_type\x18\x04 \x01(\tR\fresourceType\x12!\n" +
+	"\fresource_ids\x18\x05 \x03(\tR\vresourceIds\x124\n" +
+	"\asubject\x18\x06 \x01(\v2\x1a.core.v1.ObjectAndRelationR\asubject\x12>\n" +
+	"\x0ecaveat_context\x18\a \x01(\v2\x17.google.protobuf.StructR\rcaveatContext\x12.\n" +
+	"\x13max_recursion_depth\x18\b \x01(\rR\x11maxRecursionDepth\x12\x18\n" +
+	"\aanalyze\x18\t \x01(\bR\aanalyze\x120\n" +
+	"\x11concurrency_limit\x18\n" +
+	" \x01(\rH\x00R\x10concurrencyLimit\x88\x01\x01\"=\n" +
+	"\tOperation\x12\t\n" +
+	"\x05CHECK\x10\x00\x12\x11\n" +
+	"\rITER_SUBJECTS\x10\x01\x12\x12\n" +
+	"\x0eITER_RESOURCES\x10\x02B\x14\n" +
+	"\x12_concurrency_limit\"\x84\x01\n" +
+	"\x19DispatchQueryPlanResponse\x125\n" +
+	"\bmetadata\x18\x01 \x01(\v2\x19.dispatch.v1.ResponseMetaR\bmetadata\x120\n" +
+	"\x05paths\x18\x02 \x03(\v2\x1a.dispatch.v1.QueryPlanPathR\x05paths\"\xd4\x05\n" +
+	"\rQueryPlanNode\x12<\n" +
+	"\brelation\x18\x01 \x01(\v2\x1e.dispatch.v1.QueryPlanRelationH\x00R\brelation\x126\n" +
+	"\x05union\x18\x02 \x01(\v2\x1e.dispatch.v1.QueryPlanChildrenH\x00R\x05union\x12D\n" +
+	"\fintersection\x18\x03 \x01(\v2\x1e.dispatch.v1.QueryPlanChildrenH\x00R\fintersection\x12?\n" +
+	"\texclusion\x18\x04 \x01(\v2\x1f.dispatch.v1.QueryPlanExclusionH\x00R\texclusion\x123\n" +
+	"\x05arrow\x18\x05 \x01(\v2\x1b.dispatch.v1.QueryPlanArrowH\x00R\x05arrow\x12L\n" +
+	"\x12intersection_arrow\x18\x06 \x01(\v2\x1b.dispatch.v1.QueryPlanArrowH\x00R\x11intersectionArrow\x123\n" +
+	"\x05alias\x18\a \x01(\v2\x1b.dispatch.v1.QueryPlanAliasH\x00R\x05alias\x126\n" +
+	"\x06caveat\x18\b \x01(\v2\x1c.dispatch.v1.QueryPlanCaveatH\x00R\x06caveat\x123\n" +
+	"\x05fixed\x18\t \x01(\v2\x1b.dispatch.v1.QueryPlanFixedH\x00R\x05fixed\x12?\n" +
+	"\trecursive\x18\n" +
+	" \x01(\v2\x1f.dispatch.v1.QueryPlanRecursiveH\x00R\trecursive\x12X\n" +
+	"\x12recursive_sentinel\x18\v \x01(\v2'.dispatch.v1.QueryPlanRecursiveSentinelH\x00R\x11recursiveSentinelB\x06\n" +
+	"\x04node\"\x8c\x02\n" +
+	"\x11QueryPlanRelation\x12'\n" +
+	"\x0fdefinition_name\x18\x01 \x01(\tR\x0edefinitionName\x12#\n" +
+	"\rrelation_name\x18\x02 \x01(\tR\frelationName\x12!\n" +
+	"\fsubject_type\x18\x03 \x01(\tR\vsubjectType\x12)\n" +
+	"\x10subject_relation\x18\x04 \x01(\tR\x0fsubjectRelation\x12\x1f\n" +
+	"\vcaveat_name\x18\x05 \x01(\tR\n" +
+	"caveatName\x12\x1e\n" +
+	"\n" +
+	"expiration\x18\x06 \x01(\bR\n" +
+	"expiration\x12\x1a\n" +
+	"\bwildcard\x18\a \x01(\bR\bwildcard\"K\n" +
+	"\x11QueryPlanChildren\x126\n" +
+	"\bchildren\x18\x01 \x03(\v2\x1a.dispatch.v1.QueryPlanNodeR\bchildren\"\x83\x01\n" +
+	"\x12QueryPlanExclusion\x125\n" +
+	"\bmain_set\x18\x01 \x01(\v2\x1a.dispatch.v1.QueryPlanNodeR\amainSet\x126\n" +
+	"\bexcluded\x18\x02 \x01(\v2\x1a.dispatch.v1.QueryPlanNodeR\bexcluded\"\x96\x01\n" +
+	"\x0eQueryPlanArrow\x12.\n" +
+	"\x04left\x18\x01 \x01(\v2\x1a.dispatch.v1.QueryPlanNodeR\x04left\x120\n" +
+	"\x05right\x18\x02 \x01(\v2\x1a.dispatch.v1.QueryPlanNodeR\x05right\x12\"\n" +
+	"\rright_to_left\x18\x03 \x01(\bR\vrightToLeft\"^\n" +
+	"\x0eQueryPlanAlias\x12\x1a\n" +
+	"\brelation\x18\x01 \x01(\tR\brelation\x120\n" +
+	"\x05child\x18\x02 \x01(\v2\x1a.dispatch.v1.QueryPlanNodeR\x05child\"z\n" +
+	"\x0fQueryPlanCaveat\x125\n" +
+	"\x06caveat\x18\x01 \x01(\v2\x1d.core.v1.ContextualizedCaveatR\x06caveat\x120\n" +
+	"\x05child\x18\x02 \x01(\v2\x1a.dispatch.v1.QueryPlanNodeR\x05child\"B\n" +
+	"\x0eQueryPlanFixed\x120\n" +
+	"\x05paths\x18\x01 \x03(\v2\x1a.dispatch.v1.QueryPlanPathR\x05paths\"\xa3\x01\n" +
+	"\x12QueryPlanRecursive\x12'\n" +
+	"\x0fdefinition_name\x18\x01 \x01(\tR\x0edefinitionName\x12#\n" +
+	"\rrelation_name\x18\x02 \x01(\tR\frelationName\x12?\n" +
+	"\rtemplate_tree\x18\x03 \x01(\v2\x1a.dispatch.v1.QueryPlanNodeR\ftemplateTree\"\x98\x01\n" +
+	"\x1aQueryPlanRecursiveSentinel\x12'\n" +
+	"\x0fdefinition_name\x18\x01 \x01(\tR\x0edefinitionName\x12#\n" +
+	"\rrelation_name\x18\x02 \x01(\tR\frelationName\x12,\n" +
+	"\x12with_sub_relations\x18\x03 \x01(\bR\x10withSubRelations\"\xaa\x02\n" +
+	"\rQueryPlanPath\x126\n" +
+	"\bresource\x18\x01 \x01(\v2\x1a.core.v1.ObjectAndRelationR\bresource\x124\n" +
+	"\asubject\x18\x02 \x01(\v2\x1a.core.v1.ObjectAndRelationR\asubject\x121\n" +
+	"\x06caveat\x18\x03 \x01(\v2\x19.core.v1.CaveatExpressionR\x06caveat\x12:\n" +
+	"\n" +
+	"expiration\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expiration\x12<\n" +
+	"\tintegrity\x18\x05 \x03(\v2\x1e.core.v1.RelationshipIntegrityR\tintegrity\"\xfc\x02\n" +
+	"\x1eDispatchPeerCacheLookupRequest\x129\n" +
+	"\x05check\x18\x01 \x01(\v2!.dispatch.v1.DispatchCheckRequestH\x00R\x05check\x12[\n" +
+	"\x11lookup_resources2\x18\x02 \x01(\v2,.dispatch.v1.DispatchLookupResources2RequestH\x00R\x10lookupResources2\x12[\n" +
+	"\x11lookup_resources3\x18\x03 \x01(\v2,.dispatch.v1.DispatchLookupResources3RequestH\x00R\x10lookupResources3\x12U\n" +
+	"\x0flookup_subjects\x18\x04 \x01(\v2*.dispatch.v1.DispatchLookupSubjectsRequestH\x00R\x0elookupSubjectsB\x0e\n" +
+	"\arequest\x12\x03\xf8B\x01\"b\n" +
+	"\x1fDispatchPeerCacheLookupResponse\x12\x14\n" +
+	"\x05found\x18\x01 \x01(\bR\x05found\x12)\n" +
+	"\x10cached_responses\x18\x02 \x03(\fR\x0fcachedResponses\"\xb7\x01\n" +
 	"\fResolverMeta\x12\x1f\n" +
 	"\vat_revision\x18\x01 \x01(\tR\n" +
 	"atRevision\x120\n" +
@@ -1775,9 +3307,10 @@ const file_dispatch_v1_dispatch_proto_rawDesc = "" +
 	"\x0edepth_required\x18\x02 \x01(\rR\rdepthRequired\x122\n" +
 	"\x15cached_dispatch_count\x18\x03 \x01(\rR\x13cachedDispatchCount\x12<\n" +
 	"\n" +
-	"debug_info\x18\x06 \x01(\v2\x1d.dispatch.v1.DebugInformationR\tdebugInfoJ\x04\b\x04\x10\x05J\x04\b\x05\x10\x06\"F\n" +
+	"debug_info\x18\x06 \x01(\v2\x1d.dispatch.v1.DebugInformationR\tdebugInfoJ\x04\b\x04\x10\x05J\x04\b\x05\x10\x06\"\x96\x01\n" +
 	"\x10DebugInformation\x122\n" +
-	"\x05check\x18\x01 \x01(\v2\x1c.dispatch.v1.CheckDebugTraceR\x05check\"\xe7\x04\n" +
+	"\x05check\x18\x01 \x01(\v2\x1c.dispatch.v1.CheckDebugTraceR\x05check\x12N\n" +
+	"\x13query_plan_analysis\x18\x02 \x03(\v2\x1e.dispatch.v1.QueryPlanAnalysisR\x11queryPlanAnalysis\"\xe7\x04\n" +
 	"\x0fCheckDebugTrace\x12;\n" +
 	"\arequest\x18\x01 \x01(\v2!.dispatch.v1.DispatchCheckRequestR\arequest\x12_\n" +
 	"\x16resource_relation_type\x18\x02 \x01(\x0e2).dispatch.v1.CheckDebugTrace.RelationTypeR\x14resourceRelationType\x12C\n" +
@@ -1794,13 +3327,35 @@ const file_dispatch_v1_dispatch_proto_rawDesc = "" +
 	"\aUNKNOWN\x10\x00\x12\f\n" +
 	"\bRELATION\x10\x01\x12\x0e\n" +
 	"\n" +
-	"PERMISSION\x10\x022\xb9\x04\n" +
+	"PERMISSION\x10\x02\"\x97\x03\n" +
+	"\x11QueryPlanAnalysis\x12\x1a\n" +
+	"\biterator\x18\x01 \x01(\tR\biterator\x12\x14\n" +
+	"\x05calls\x18\x02 \x01(\rR\x05calls\x125\n" +
+	"\bduration\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\bduration\x12\x18\n" +
+	"\aresults\x18\x04 \x01(\x04R\aresults\x12+\n" +
+	"\x11datastore_queries\x18\x05 \x01(\x04R\x10datastoreQueries\x12\x1b\n" +
+	"\trows_read\x18\x06 \x01(\x04R\browsRead\x12\x1d\n" +
+	"\n" +
+	"cache_hits\x18\a \x01(\x04R\tcacheHits\x12\x1e\n" +
+	"\n" +
+	"dispatched\x18\b \x01(\bR\n" +
+	"dispatched\x12:\n" +
+	"\bchildren\x18\t \x03(\v2\x1e.dispatch.v1.QueryPlanAnalysisR\bchildren\x12:\n" +
+	"\bestimate\x18\n" +
+	" \x01(\v2\x1e.dispatch.v1.QueryPlanEstimateR\bestimate\"w\n" +
+	"\x11QueryPlanEstimate\x12\x12\n" +
+	"\x04cost\x18\x01 \x01(\x01R\x04cost\x12 \n" +
+	"\vselectivity\x18\x02 \x01(\x01R\vselectivity\x12\x16\n" +
+	"\x06fanout\x18\x03 \x01(\x01R\x06fanout\x12\x14\n" +
+	"\x05fanin\x18\x04 \x01(\x01R\x05fanin2\x97\x06\n" +
 	"\x0fDispatchService\x12X\n" +
 	"\rDispatchCheck\x12!.dispatch.v1.DispatchCheckRequest\x1a\".dispatch.v1.DispatchCheckResponse\"\x00\x12[\n" +
 	"\x0eDispatchExpand\x12\".dispatch.v1.DispatchExpandRequest\x1a#.dispatch.v1.DispatchExpandResponse\"\x00\x12u\n" +
 	"\x16DispatchLookupSubjects\x12*.dispatch.v1.DispatchLookupSubjectsRequest\x1a+.dispatch.v1.DispatchLookupSubjectsResponse\"\x000\x01\x12{\n" +
 	"\x18DispatchLookupResources2\x12,.dispatch.v1.DispatchLookupResources2Request\x1a-.dispatch.v1.DispatchLookupResources2Response\"\x000\x01\x12{\n" +
-	"\x18DispatchLookupResources3\x12,.dispatch.v1.DispatchLookupResources3Request\x1a-.dispatch.v1.DispatchLookupResources3Response\"\x000\x01B\xaa\x01\n" +
+	"\x18DispatchLookupResources3\x12,.dispatch.v1.DispatchLookupResources3Request\x1a-.dispatch.v1.DispatchLookupResources3Response\"\x000\x01\x12d\n" +
+	"\x11DispatchQueryPlan\x12%.dispatch.v1.DispatchQueryPlanRequest\x1a&.dispatch.v1.DispatchQueryPlanResponse\"\x00\x12v\n" +
+	"\x17DispatchPeerCacheLookup\x12+.dispatch.v1.DispatchPeerCacheLookupRequest\x1a,.dispatch.v1.DispatchPeerCacheLookupResponse\"\x00B\xaa\x01\n" +
 	"\x0fcom.dispatch.v1B\rDispatchProtoP\x01Z;github.com/authzed/spicedb/pkg/proto/dispatch/v1;dispatchv1\xa2\x02\x03DXX\xaa\x02\vDispatch.V1\xca\x02\vDispatch\\V1\xe2\x02\x17Dispatch\\V1\\GPBMetadata\xea\x02\fDispatch::V1b\x06proto3"
 
 var (
@@ -1815,112 +3370,178 @@ func file_dispatch_v1_dispatch_proto_rawDescGZIP() []byte {
 	return file_dispatch_v1_dispatch_proto_rawDescData
 }
 
-var file_dispatch_v1_dispatch_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_dispatch_v1_dispatch_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_dispatch_v1_dispatch_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_dispatch_v1_dispatch_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
 var file_dispatch_v1_dispatch_proto_goTypes = []any{
 	(DispatchCheckRequest_DebugSetting)(0),   // 0: dispatch.v1.DispatchCheckRequest.DebugSetting
 	(DispatchCheckRequest_ResultsSetting)(0), // 1: dispatch.v1.DispatchCheckRequest.ResultsSetting
 	(ResourceCheckResult_Membership)(0),      // 2: dispatch.v1.ResourceCheckResult.Membership
 	(DispatchExpandRequest_ExpansionMode)(0), // 3: dispatch.v1.DispatchExpandRequest.ExpansionMode
-	(CheckDebugTrace_RelationType)(0),        // 4: dispatch.v1.CheckDebugTrace.RelationType
-	(*DispatchCheckRequest)(nil),             // 5: dispatch.v1.DispatchCheckRequest
-	(*CheckHint)(nil),                        // 6: dispatch.v1.CheckHint
-	(*DispatchCheckResponse)(nil),            // 7: dispatch.v1.DispatchCheckResponse
-	(*ResourceCheckResult)(nil),              // 8: dispatch.v1.ResourceCheckResult
-	(*DispatchExpandRequest)(nil),            // 9: dispatch.v1.DispatchExpandRequest
-	(*DispatchExpandResponse)(nil),           // 10: dispatch.v1.DispatchExpandResponse
-	(*Cursor)(nil),                           // 11: dispatch.v1.Cursor
-	(*DispatchLookupResources2Request)(nil),  // 12: dispatch.v1.DispatchLookupResources2Request
-	(*PossibleResource)(nil),                 // 13: dispatch.v1.PossibleResource
-	(*DispatchLookupResources2Response)(nil), // 14: dispatch.v1.DispatchLookupResources2Response
-	(*DispatchLookupResources3Request)(nil),  // 15: dispatch.v1.DispatchLookupResources3Request
-	(*DispatchLookupResources3Response)(nil), // 16: dispatch.v1.DispatchLookupResources3Response
-	(*LR3Item)(nil),                          // 17: dispatch.v1.LR3Item
-	(*DispatchLookupSubjectsRequest)(nil),    // 18: dispatch.v1.DispatchLookupSubjectsRequest
-	(*FoundSubject)(nil),                     // 19: dispatch.v1.FoundSubject
-	(*FoundSubjects)(nil),                    // 20: dispatch.v1.FoundSubjects
-	(*DispatchLookupSubjectsResponse)(nil),   // 21: dispatch.v1.DispatchLookupSubjectsResponse
-	(*ResolverMeta)(nil),                     // 22: dispatch.v1.ResolverMeta
-	(*ResponseMeta)(nil),                     // 23: dispatch.v1.ResponseMeta
-	(*DebugInformation)(nil),                 // 24: dispatch.v1.DebugInformation
-	(*CheckDebugTrace)(nil),                  // 25: dispatch.v1.CheckDebugTrace
-	nil,                                      // 26: dispatch.v1.DispatchCheckResponse.ResultsByResourceIdEntry
-	nil,                                      // 27: dispatch.v1.DispatchLookupSubjectsResponse.FoundSubjectsByResourceIdEntry
-	nil,                                      // 28: dispatch.v1.CheckDebugTrace.ResultsEntry
-	(*v1.RelationReference)(nil),             // 29: core.v1.RelationReference
-	(*v1.ObjectAndRelation)(nil),             // 30: core.v1.ObjectAndRelation
-	(*v1.CaveatExpression)(nil),              // 31: core.v1.CaveatExpression
-	(*v1.RelationTupleTreeNode)(nil),         // 32: core.v1.RelationTupleTreeNode
-	(*structpb.Struct)(nil),                  // 33: google.protobuf.Struct
-	(*durationpb.Duration)(nil),              // 34: google.protobuf.Duration
+	(DispatchQueryPlanRequest_Operation)(0),  // 4: dispatch.v1.DispatchQueryPlanRequest.Operation
+	(CheckDebugTrace_RelationType)(0),        // 5: dispatch.v1.CheckDebugTrace.RelationType
+	(*DispatchCheckRequest)(nil),             // 6: dispatch.v1.DispatchCheckRequest
+	(*CheckHint)(nil),                        // 7: dispatch.v1.CheckHint
+	(*DispatchCheckResponse)(nil),            // 8: dispatch.v1.DispatchCheckResponse
+	(*ResourceCheckResult)(nil),              // 9: dispatch.v1.ResourceCheckResult
+	(*DispatchExpandRequest)(nil),            // 10: dispatch.v1.DispatchExpandRequest
+	(*DispatchExpandResponse)(nil),           // 11: dispatch.v1.DispatchExpandResponse
+	(*Cursor)(nil),                           // 12: dispatch.v1.Cursor
+	(*DispatchLookupResources2Request)(nil),  // 13: dispatch.v1.DispatchLookupResources2Request
+	(*PossibleResource)(nil),                 // 14: dispatch.v1.PossibleResource
+	(*DispatchLookupResources2Response)(nil), // 15: dispatch.v1.DispatchLookupResources2Response
+	(*DispatchLookupResources3Request)(nil),  // 16: dispatch.v1.DispatchLookupResources3Request
+	(*DispatchLookupResources3Response)(nil), // 17: dispatch.v1.DispatchLookupResources3Response
+	(*LR3Item)(nil),                          // 18: dispatch.v1.LR3Item
+	(*DispatchLookupSubjectsRequest)(nil),    // 19: dispatch.v1.DispatchLookupSubjectsRequest
+	(*FoundSubject)(nil),                     // 20: dispatch.v1.FoundSubject
+	(*FoundSubjects)(nil),                    // 21: dispatch.v1.FoundSubjects
+	(*DispatchLookupSubjectsResponse)(nil),   // 22: dispatch.v1.DispatchLookupSubjectsResponse
+	(*DispatchQueryPlanRequest)(nil),         // 23: dispatch.v1.DispatchQueryPlanRequest
+	(*DispatchQueryPlanResponse)(nil),        // 24: dispatch.v1.DispatchQueryPlanResponse
+	(*QueryPlanNode)(nil),                    // 25: dispatch.v1.QueryPlanNode
+	(*QueryPlanRelation)(nil),                // 26: dispatch.v1.QueryPlanRelation
+	(*QueryPlanChildren)(nil),                // 27: dispatch.v1.QueryPlanChildren
+	(*QueryPlanExclusion)(nil),               // 28: dispatch.v1.QueryPlanExclusion
+	(*QueryPlanArrow)(nil),                   // 29: dispatch.v1.QueryPlanArrow
+	(*QueryPlanAlias)(nil),                   // 30: dispatch.v1.QueryPlanAlias
+	(*QueryPlanCaveat)(nil),                  // 31: dispatch.v1.QueryPlanCaveat
+	(*QueryPlanFixed)(nil),                   // 32: dispatch.v1.QueryPlanFixed
+	(*QueryPlanRecursive)(nil),               // 33: dispatch.v1.QueryPlanRecursive
+	(*QueryPlanRecursiveSentinel)(nil),       // 34: dispatch.v1.QueryPlanRecursiveSentinel
+	(*QueryPlanPath)(nil),                    // 35: dispatch.v1.QueryPlanPath
+	(*DispatchPeerCacheLookupRequest)(nil),   // 36: dispatch.v1.DispatchPeerCacheLookupRequest
+	(*DispatchPeerCacheLookupResponse)(nil),  // 37: dispatch.v1.DispatchPeerCacheLookupResponse
+	(*ResolverMeta)(nil),                     // 38: dispatch.v1.ResolverMeta
+	(*ResponseMeta)(nil),                     // 39: dispatch.v1.ResponseMeta
+	(*DebugInformation)(nil),                 // 40: dispatch.v1.DebugInformation
+	(*CheckDebugTrace)(nil),                  // 41: dispatch.v1.CheckDebugTrace
+	(*QueryPlanAnalysis)(nil),                // 42: dispatch.v1.QueryPlanAnalysis
+	(*QueryPlanEstimate)(nil),                // 43: dispatch.v1.QueryPlanEstimate
+	nil,                                      // 44: dispatch.v1.DispatchCheckResponse.ResultsByResourceIdEntry
+	nil,                                      // 45: dispatch.v1.DispatchLookupSubjectsResponse.FoundSubjectsByResourceIdEntry
+	nil,                                      // 46: dispatch.v1.CheckDebugTrace.ResultsEntry
+	(*v1.RelationReference)(nil),             // 47: core.v1.RelationReference
+	(*v1.ObjectAndRelation)(nil),             // 48: core.v1.ObjectAndRelation
+	(*v1.CaveatExpression)(nil),              // 49: core.v1.CaveatExpression
+	(*v1.RelationTupleTreeNode)(nil),         // 50: core.v1.RelationTupleTreeNode
+	(*structpb.Struct)(nil),                  // 51: google.protobuf.Struct
+	(*v1.ContextualizedCaveat)(nil),          // 52: core.v1.ContextualizedCaveat
+	(*timestamppb.Timestamp)(nil),            // 53: google.protobuf.Timestamp
+	(*v1.RelationshipIntegrity)(nil),         // 54: core.v1.RelationshipIntegrity
+	(*durationpb.Duration)(nil),              // 55: google.protobuf.Duration
 }
 var file_dispatch_v1_dispatch_proto_depIdxs = []int32{
-	22, // 0: dispatch.v1.DispatchCheckRequest.metadata:type_name -> dispatch.v1.ResolverMeta
-	29, // 1: dispatch.v1.DispatchCheckRequest.resource_relation:type_name -> core.v1.RelationReference
-	30, // 2: dispatch.v1.DispatchCheckRequest.subject:type_name -> core.v1.ObjectAndRelation
+	38, // 0: dispatch.v1.DispatchCheckRequest.metadata:type_name -> dispatch.v1.ResolverMeta
+	47, // 1: dispatch.v1.DispatchCheckRequest.resource_relation:type_name -> core.v1.RelationReference
+	48, // 2: dispatch.v1.DispatchCheckRequest.subject:type_name -> core.v1.ObjectAndRelation
 	1,  // 3: dispatch.v1.DispatchCheckRequest.results_setting:type_name -> dispatch.v1.DispatchCheckRequest.ResultsSetting
 	0,  // 4: dispatch.v1.DispatchCheckRequest.debug:type_name -> dispatch.v1.DispatchCheckRequest.DebugSetting
-	6,  // 5: dispatch.v1.DispatchCheckRequest.check_hints:type_name -> dispatch.v1.CheckHint
-	30, // 6: dispatch.v1.CheckHint.resource:type_name -> core.v1.ObjectAndRelation
-	30, // 7: dispatch.v1.CheckHint.subject:type_name -> core.v1.ObjectAndRelation
-	8,  // 8: dispatch.v1.CheckHint.result:type_name -> dispatch.v1.ResourceCheckResult
-	23, // 9: dispatch.v1.DispatchCheckResponse.metadata:type_name -> dispatch.v1.ResponseMeta
-	26, // 10: dispatch.v1.DispatchCheckResponse.results_by_resource_id:type_name -> dispatch.v1.DispatchCheckResponse.ResultsByResourceIdEntry
+	7,  // 5: dispatch.v1.DispatchCheckRequest.check_hints:type_name -> dispatch.v1.CheckHint
+	48, // 6: dispatch.v1.CheckHint.resource:type_name -> core.v1.ObjectAndRelation
+	48, // 7: dispatch.v1.CheckHint.subject:type_name -> core.v1.ObjectAndRelation
+	9,  // 8: dispatch.v1.CheckHint.result:type_name -> dispatch.v1.ResourceCheckResult
+	39, // 9: dispatch.v1.DispatchCheckResponse.metadata:type_name -> dispatch.v1.ResponseMeta
+	44, // 10: dispatch.v1.DispatchCheckResponse.results_by_resource_id:type_name -> dispatch.v1.DispatchCheckResponse.ResultsByResourceIdEntry
 	2,  // 11: dispatch.v1.ResourceCheckResult.membership:type_name -> dispatch.v1.ResourceCheckResult.Membership
-	31, // 12: dispatch.v1.ResourceCheckResult.expression:type_name -> core.v1.CaveatExpression
-	22, // 13: dispatch.v1.DispatchExpandRequest.metadata:type_name -> dispatch.v1.ResolverMeta
-	30, // 14: dispatch.v1.DispatchExpandRequest.resource_and_relation:type_name -> core.v1.ObjectAndRelation
+	49, // 12: dispatch.v1.ResourceCheckResult.expression:type_name -> core.v1.CaveatExpression
+	38, // 13: dispatch.v1.DispatchExpandRequest.metadata:type_name -> dispatch.v1.ResolverMeta
+	48, // 14: dispatch.v1.DispatchExpandRequest.resource_and_relation:type_name -> core.v1.ObjectAndRelation
 	3,  // 15: dispatch.v1.DispatchExpandRequest.expansion_mode:type_name -> dispatch.v1.DispatchExpandRequest.ExpansionMode
-	23, // 16: dispatch.v1.DispatchExpandResponse.metadata:type_name -> dispatch.v1.ResponseMeta
-	32, // 17: dispatch.v1.DispatchExpandResponse.tree_node:type_name -> core.v1.RelationTupleTreeNode
-	22, // 18: dispatch.v1.DispatchLookupResources2Request.metadata:type_name -> dispatch.v1.ResolverMeta
-	29, // 19: dispatch.v1.DispatchLookupResources2Request.resource_relation:type_name -> core.v1.RelationReference
-	29, // 20: dispatch.v1.DispatchLookupResources2Request.subject_relation:type_name -> core.v1.RelationReference
-	30, // 21: dispatch.v1.DispatchLookupResources2Request.terminal_subject:type_name -> core.v1.ObjectAndRelation
-	33, // 22: dispatch.v1.DispatchLookupResources2Request.context:type_name -> google.protobuf.Struct
-	11, // 23: dispatch.v1.DispatchLookupResources2Request.optional_cursor:type_name -> dispatch.v1.Cursor
-	13, // 24: dispatch.v1.DispatchLookupResources2Response.resource:type_name -> dispatch.v1.PossibleResource
-	23, // 25: dispatch.v1.DispatchLookupResources2Response.metadata:type_name -> dispatch.v1.ResponseMeta
-	11, // 26: dispatch.v1.DispatchLookupResources2Response.after_response_cursor:type_name -> dispatch.v1.Cursor
-	22, // 27: dispatch.v1.DispatchLookupResources3Request.metadata:type_name -> dispatch.v1.ResolverMeta
-	29, // 28: dispatch.v1.DispatchLookupResources3Request.resource_relation:type_name -> core.v1.RelationReference
-	29, // 29: dispatch.v1.DispatchLookupResources3Request.subject_relation:type_name -> core.v1.RelationReference
-	30, // 30: dispatch.v1.DispatchLookupResources3Request.terminal_subject:type_name -> core.v1.ObjectAndRelation
-	33, // 31: dispatch.v1.DispatchLookupResources3Request.context:type_name -> google.protobuf.Struct
-	17, // 32: dispatch.v1.DispatchLookupResources3Response.items:type_name -> dispatch.v1.LR3Item
-	22, // 33: dispatch.v1.DispatchLookupSubjectsRequest.metadata:type_name -> dispatch.v1.ResolverMeta
-	29, // 34: dispatch.v1.DispatchLookupSubjectsRequest.resource_relation:type_name -> core.v1.RelationReference
-	29, // 35: dispatch.v1.DispatchLookupSubjectsRequest.subject_relation:type_name -> core.v1.RelationReference
-	31, // 36: dispatch.v1.FoundSubject.caveat_expression:type_name -> core.v1.CaveatExpression
-	19, // 37: dispatch.v1.FoundSubject.excluded_subjects:type_name -> dispatch.v1.FoundSubject
-	19, // 38: dispatch.v1.FoundSubjects.found_subjects:type_name -> dispatch.v1.FoundSubject
-	27, // 39: dispatch.v1.DispatchLookupSubjectsResponse.found_subjects_by_resource_id:type_name -> dispatch.v1.DispatchLookupSubjectsResponse.FoundSubjectsByResourceIdEntry
-	23, // 40: dispatch.v1.DispatchLookupSubjectsResponse.metadata:type_name -> dispatch.v1.ResponseMeta
-	24, // 41: dispatch.v1.ResponseMeta.debug_info:type_name -> dispatch.v1.DebugInformation
-	25, // 42: dispatch.v1.DebugInformation.check:type_name -> dispatch.v1.CheckDebugTrace
-	5,  // 43: dispatch.v1.CheckDebugTrace.request:type_name -> dispatch.v1.DispatchCheckRequest
-	4,  // 44: dispatch.v1.CheckDebugTrace.resource_relation_type:type_name -> dispatch.v1.CheckDebugTrace.RelationType
-	28, // 45: dispatch.v1.CheckDebugTrace.results:type_name -> dispatch.v1.CheckDebugTrace.ResultsEntry
-	25, // 46: dispatch.v1.CheckDebugTrace.sub_problems:type_name -> dispatch.v1.CheckDebugTrace
-	34, // 47: dispatch.v1.CheckDebugTrace.duration:type_name -> google.protobuf.Duration
-	8,  // 48: dispatch.v1.DispatchCheckResponse.ResultsByResourceIdEntry.value:type_name -> dispatch.v1.ResourceCheckResult
-	20, // 49: dispatch.v1.DispatchLookupSubjectsResponse.FoundSubjectsByResourceIdEntry.value:type_name -> dispatch.v1.FoundSubjects
-	8,  // 50: dispatch.v1.CheckDebugTrace.ResultsEntry.value:type_name -> dispatch.v1.ResourceCheckResult
-	5,  // 51: dispatch.v1.DispatchService.DispatchCheck:input_type -> dispatch.v1.DispatchCheckRequest
-	9,  // 52: dispatch.v1.DispatchService.DispatchExpand:input_type -> dispatch.v1.DispatchExpandRequest
-	18, // 53: dispatch.v1.DispatchService.DispatchLookupSubjects:input_type -> dispatch.v1.DispatchLookupSubjectsRequest
-	12, // 54: dispatch.v1.DispatchService.DispatchLookupResources2:input_type -> dispatch.v1.DispatchLookupResources2Request
-	15, // 55: dispatch.v1.DispatchService.DispatchLookupResources3:input_type -> dispatch.v1.DispatchLookupResources3Request
-	7,  // 56: dispatch.v1.DispatchService.DispatchCheck:output_type -> dispatch.v1.DispatchCheckResponse
-	10, // 57: dispatch.v1.DispatchService.DispatchExpand:output_type -> dispatch.v1.DispatchExpandResponse
-	21, // 58: dispatch.v1.DispatchService.DispatchLookupSubjects:output_type -> dispatch.v1.DispatchLookupSubjectsResponse
-	14, // 59: dispatch.v1.DispatchService.DispatchLookupResources2:output_type -> dispatch.v1.DispatchLookupResources2Response
-	16, // 60: dispatch.v1.DispatchService.DispatchLookupResources3:output_type -> dispatch.v1.DispatchLookupResources3Response
-	56, // [56:61] is the sub-list for method output_type
-	51, // [51:56] is the sub-list for method input_type
-	51, // [51:51] is the sub-list for extension type_name
-	51, // [51:51] is the sub-list for extension extendee
-	0,  // [0:51] is the sub-list for field type_name
+	39, // 16: dispatch.v1.DispatchExpandResponse.metadata:type_name -> dispatch.v1.ResponseMeta
+	50, // 17: dispatch.v1.DispatchExpandResponse.tree_node:type_name -> core.v1.RelationTupleTreeNode
+	38, // 18: dispatch.v1.DispatchLookupResources2Request.metadata:type_name -> dispatch.v1.ResolverMeta
+	47, // 19: dispatch.v1.DispatchLookupResources2Request.resource_relation:type_name -> core.v1.RelationReference
+	47, // 20: dispatch.v1.DispatchLookupResources2Request.subject_relation:type_name -> core.v1.RelationReference
+	48, // 21: dispatch.v1.DispatchLookupResources2Request.terminal_subject:type_name -> core.v1.ObjectAndRelation
+	51, // 22: dispatch.v1.DispatchLookupResources2Request.context:type_name -> google.protobuf.Struct
+	12, // 23: dispatch.v1.DispatchLookupResources2Request.optional_cursor:type_name -> dispatch.v1.Cursor
+	14, // 24: dispatch.v1.DispatchLookupResources2Response.resource:type_name -> dispatch.v1.PossibleResource
+	39, // 25: dispatch.v1.DispatchLookupResources2Response.metadata:type_name -> dispatch.v1.ResponseMeta
+	12, // 26: dispatch.v1.DispatchLookupResources2Response.after_response_cursor:type_name -> dispatch.v1.Cursor
+	38, // 27: dispatch.v1.DispatchLookupResources3Request.metadata:type_name -> dispatch.v1.ResolverMeta
+	47, // 28: dispatch.v1.DispatchLookupResources3Request.resource_relation:type_name -> core.v1.RelationReference
+	47, // 29: dispatch.v1.DispatchLookupResources3Request.subject_relation:type_name -> core.v1.RelationReference
+	48, // 30: dispatch.v1.DispatchLookupResources3Request.terminal_subject:type_name -> core.v1.ObjectAndRelation
+	51, // 31: dispatch.v1.DispatchLookupResources3Request.context:type_name -> google.protobuf.Struct
+	18, // 32: dispatch.v1.DispatchLookupResources3Response.items:type_name -> dispatch.v1.LR3Item
+	38, // 33: dispatch.v1.DispatchLookupSubjectsRequest.metadata:type_name -> dispatch.v1.ResolverMeta
+	47, // 34: dispatch.v1.DispatchLookupSubjectsRequest.resource_relation:type_name -> core.v1.RelationReference
+	47, // 35: dispatch.v1.DispatchLookupSubjectsRequest.subject_relation:type_name -> core.v1.RelationReference
+	49, // 36: dispatch.v1.FoundSubject.caveat_expression:type_name -> core.v1.CaveatExpression
+	20, // 37: dispatch.v1.FoundSubject.excluded_subjects:type_name -> dispatch.v1.FoundSubject
+	20, // 38: dispatch.v1.FoundSubjects.found_subjects:type_name -> dispatch.v1.FoundSubject
+	45, // 39: dispatch.v1.DispatchLookupSubjectsResponse.found_subjects_by_resource_id:type_name -> dispatch.v1.DispatchLookupSubjectsResponse.FoundSubjectsByResourceIdEntry
+	39, // 40: dispatch.v1.DispatchLookupSubjectsResponse.metadata:type_name -> dispatch.v1.ResponseMeta
+	38, // 41: dispatch.v1.DispatchQueryPlanRequest.metadata:type_name -> dispatch.v1.ResolverMeta
+	25, // 42: dispatch.v1.DispatchQueryPlanRequest.plan:type_name -> dispatch.v1.QueryPlanNode
+	4,  // 43: dispatch.v1.DispatchQueryPlanRequest.operation:type_name -> dispatch.v1.DispatchQueryPlanRequest.Operation
+	48, // 44: dispatch.v1.DispatchQueryPlanRequest.subject:type_name -> core.v1.ObjectAndRelation
+	51, // 45: dispatch.v1.DispatchQueryPlanRequest.caveat_context:type_name -> google.protobuf.Struct
+	39, // 46: dispatch.v1.DispatchQueryPlanResponse.metadata:type_name -> dispatch.v1.ResponseMeta
+	35, // 47: dispatch.v1.DispatchQueryPlanResponse.paths:type_name -> dispatch.v1.QueryPlanPath
+	26, // 48: dispatch.v1.QueryPlanNode.relation:type_name -> dispatch.v1.QueryPlanRelation
+	27, // 49: dispatch.v1.QueryPlanNode.union:type_name -> dispatch.v1.QueryPlanChildren
+	27, // 50: dispatch.v1.QueryPlanNode.intersection:type_name -> dispatch.v1.QueryPlanChildren
+	28, // 51: dispatch.v1.QueryPlanNode.exclusion:type_name -> dispatch.v1.QueryPlanExclusion
+	29, // 52: dispatch.v1.QueryPlanNode.arrow:type_name -> dispatch.v1.QueryPlanArrow
+	29, // 53: dispatch.v1.QueryPlanNode.intersection_arrow:type_name -> dispatch.v1.QueryPlanArrow
+	30, // 54: dispatch.v1.QueryPlanNode.alias:type_name -> dispatch.v1.QueryPlanAlias
+	31, // 55: dispatch.v1.QueryPlanNode.caveat:type_name -> dispatch.v1.QueryPlanCaveat
+	32, // 56: dispatch.v1.QueryPlanNode.fixed:type_name -> dispatch.v1.QueryPlanFixed
+	33, // 57: dispatch.v1.QueryPlanNode.recursive:type_name -> dispatch.v1.QueryPlanRecursive
+	34, // 58: dispatch.v1.QueryPlanNode.recursive_sentinel:type_name -> dispatch.v1.QueryPlanRecursiveSentinel
+	25, // 59: dispatch.v1.QueryPlanChildren.children:type_name -> dispatch.v1.QueryPlanNode
+	25, // 60: dispatch.v1.QueryPlanExclusion.main_set:type_name -> dispatch.v1.QueryPlanNode
+	25, // 61: dispatch.v1.QueryPlanExclusion.excluded:type_name -> dispatch.v1.QueryPlanNode
+	25, // 62: dispatch.v1.QueryPlanArrow.left:type_name -> dispatch.v1.QueryPlanNode
+	25, // 63: dispatch.v1.QueryPlanArrow.right:type_name -> dispatch.v1.QueryPlanNode
+	25, // 64: dispatch.v1.QueryPlanAlias.child:type_name -> dispatch.v1.QueryPlanNode
+	52, // 65: dispatch.v1.QueryPlanCaveat.caveat:type_name -> core.v1.ContextualizedCaveat
+	25, // 66: dispatch.v1.QueryPlanCaveat.child:type_name -> dispatch.v1.QueryPlanNode
+	35, // 67: dispatch.v1.QueryPlanFixed.paths:type_name -> dispatch.v1.QueryPlanPath
+	25, // 68: dispatch.v1.QueryPlanRecursive.template_tree:type_name -> dispatch.v1.QueryPlanNode
+	48, // 69: dispatch.v1.QueryPlanPath.resource:type_name -> core.v1.ObjectAndRelation
+	48, // 70: dispatch.v1.QueryPlanPath.subject:type_name -> core.v1.ObjectAndRelation
+	49, // 71: dispatch.v1.QueryPlanPath.caveat:type_name -> core.v1.CaveatExpression
+	53, // 72: dispatch.v1.QueryPlanPath.expiration:type_name -> google.protobuf.Timestamp
+	54, // 73: dispatch.v1.QueryPlanPath.integrity:type_name -> core.v1.RelationshipIntegrity
+	6,  // 74: dispatch.v1.DispatchPeerCacheLookupRequest.check:type_name -> dispatch.v1.DispatchCheckRequest
+	13, // 75: dispatch.v1.DispatchPeerCacheLookupRequest.lookup_resources2:type_name -> dispatch.v1.DispatchLookupResources2Request
+	16, // 76: dispatch.v1.DispatchPeerCacheLookupRequest.lookup_resources3:type_name -> dispatch.v1.DispatchLookupResources3Request
+	19, // 77: dispatch.v1.DispatchPeerCacheLookupRequest.lookup_subjects:type_name -> dispatch.v1.DispatchLookupSubjectsRequest
+	40, // 78: dispatch.v1.ResponseMeta.debug_info:type_name -> dispatch.v1.DebugInformation
+	41, // 79: dispatch.v1.DebugInformation.check:type_name -> dispatch.v1.CheckDebugTrace
+	42, // 80: dispatch.v1.DebugInformation.query_plan_analysis:type_name -> dispatch.v1.QueryPlanAnalysis
+	6,  // 81: dispatch.v1.CheckDebugTrace.request:type_name -> dispatch.v1.DispatchCheckRequest
+	5,  // 82: dispatch.v1.CheckDebugTrace.resource_relation_type:type_name -> dispatch.v1.CheckDebugTrace.RelationType
+	46, // 83: dispatch.v1.CheckDebugTrace.results:type_name -> dispatch.v1.CheckDebugTrace.ResultsEntry
+	41, // 84: dispatch.v1.CheckDebugTrace.sub_problems:type_name -> dispatch.v1.CheckDebugTrace
+	55, // 85: dispatch.v1.CheckDebugTrace.duration:type_name -> google.protobuf.Duration
+	55, // 86: dispatch.v1.QueryPlanAnalysis.duration:type_name -> google.protobuf.Duration
+	42, // 87: dispatch.v1.QueryPlanAnalysis.children:type_name -> dispatch.v1.QueryPlanAnalysis
+	43, // 88: dispatch.v1.QueryPlanAnalysis.estimate:type_name -> dispatch.v1.QueryPlanEstimate
+	9,  // 89: dispatch.v1.DispatchCheckResponse.ResultsByResourceIdEntry.value:type_name -> dispatch.v1.ResourceCheckResult
+	21, // 90: dispatch.v1.DispatchLookupSubjectsResponse.FoundSubjectsByResourceIdEntry.value:type_name -> dispatch.v1.FoundSubjects
+	9,  // 91: dispatch.v1.CheckDebugTrace.ResultsEntry.value:type_name -> dispatch.v1.ResourceCheckResult
+	6,  // 92: dispatch.v1.DispatchService.DispatchCheck:input_type -> dispatch.v1.DispatchCheckRequest
+	10, // 93: dispatch.v1.DispatchService.DispatchExpand:input_type -> dispatch.v1.DispatchExpandRequest
+	19, // 94: dispatch.v1.DispatchService.DispatchLookupSubjects:input_type -> dispatch.v1.DispatchLookupSubjectsRequest
+	13, // 95: dispatch.v1.DispatchService.DispatchLookupResources2:input_type -> dispatch.v1.DispatchLookupResources2Request
+	16, // 96: dispatch.v1.DispatchService.DispatchLookupResources3:input_type -> dispatch.v1.DispatchLookupResources3Request
+	23, // 97: dispatch.v1.DispatchService.DispatchQueryPlan:input_type -> dispatch.v1.DispatchQueryPlanRequest
+	36, // 98: dispatch.v1.DispatchService.DispatchPeerCacheLookup:input_type -> dispatch.v1.DispatchPeerCacheLookupRequest
+	8,  // 99: dispatch.v1.DispatchService.DispatchCheck:output_type -> dispatch.v1.DispatchCheckResponse
+	11, // 100: dispatch.v1.DispatchService.DispatchExpand:output_type -> dispatch.v1.DispatchExpandResponse
+	22, // 101: dispatch.v1.DispatchService.DispatchLookupSubjects:output_type -> dispatch.v1.DispatchLookupSubjectsResponse
+	15, // 102: dispatch.v1.DispatchService.DispatchLookupResources2:output_type -> dispatch.v1.DispatchLookupResources2Response
+	17, // 103: dispatch.v1.DispatchService.DispatchLookupResources3:output_type -> dispatch.v1.DispatchLookupResources3Response
+	24, // 104: dispatch.v1.DispatchService.DispatchQueryPlan:output_type -> dispatch.v1.DispatchQueryPlanResponse
+	37, // 105: dispatch.v1.DispatchService.DispatchPeerCacheLookup:output_type -> dispatch.v1.DispatchPeerCacheLookupResponse
+	99, // [99:106] is the sub-list for method output_type
+	92, // [92:99] is the sub-list for method input_type
+	92, // [92:92] is the sub-list for extension type_name
+	92, // [92:92] is the sub-list for extension extendee
+	0,  // [0:92] is the sub-list for field type_name
 }
 
 func init() { file_dispatch_v1_dispatch_proto_init() }
@@ -1928,13 +3549,33 @@ func file_dispatch_v1_dispatch_proto_init() {
 	if File_dispatch_v1_dispatch_proto != nil {
 		return
 	}
+	file_dispatch_v1_dispatch_proto_msgTypes[17].OneofWrappers = []any{}
+	file_dispatch_v1_dispatch_proto_msgTypes[19].OneofWrappers = []any{
+		(*QueryPlanNode_Relation)(nil),
+		(*QueryPlanNode_Union)(nil),
+		(*QueryPlanNode_Intersection)(nil),
+		(*QueryPlanNode_Exclusion)(nil),
+		(*QueryPlanNode_Arrow)(nil),
+		(*QueryPlanNode_IntersectionArrow)(nil),
+		(*QueryPlanNode_Alias)(nil),
+		(*QueryPlanNode_Caveat)(nil),
+		(*QueryPlanNode_Fixed)(nil),
+		(*QueryPlanNode_Recursive)(nil),
+		(*QueryPlanNode_RecursiveSentinel)(nil),
+	}
+	file_dispatch_v1_dispatch_proto_msgTypes[30].OneofWrappers = []any{
+		(*DispatchPeerCacheLookupRequest_Check)(nil),
+		(*DispatchPeerCacheLookupRequest_LookupResources2)(nil),
+		(*DispatchPeerCacheLookupRequest_LookupResources3)(nil),
+		(*DispatchPeerCacheLookupRequest_LookupSubjects)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_dispatch_v1_dispatch_proto_rawDesc), len(file_dispatch_v1_dispatch_proto_rawDesc)),
-			NumEnums:      5,
-			NumMessages:   24,
+			NumEnums:      6,
+			NumMessages:   41,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -3086,6 +3086,2859 @@ var _ interface {
 	ErrorName() string
 } = DispatchLookupSubjectsResponseValidationError{}
 
+// Validate checks the field values on DispatchQueryPlanRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DispatchQueryPlanRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DispatchQueryPlanRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DispatchQueryPlanRequestMultiError, or nil if none found.
+func (m *DispatchQueryPlanRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DispatchQueryPlanRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.GetMetadata() == nil {
+		err := DispatchQueryPlanRequestValidationError{
+			field:  "Metadata",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetMetadata()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DispatchQueryPlanRequestValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DispatchQueryPlanRequestValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMetadata()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DispatchQueryPlanRequestValidationError{
+				field:  "Metadata",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.GetPlan() == nil {
+		err := DispatchQueryPlanRequestValidationError{
+			field:  "Plan",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetPlan()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DispatchQueryPlanRequestValidationError{
+					field:  "Plan",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DispatchQueryPlanRequestValidationError{
+					field:  "Plan",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetPlan()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DispatchQueryPlanRequestValidationError{
+				field:  "Plan",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Operation
+
+	// no validation rules for ResourceType
+
+	if all {
+		switch v := interface{}(m.GetSubject()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DispatchQueryPlanRequestValidationError{
+					field:  "Subject",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DispatchQueryPlanRequestValidationError{
+					field:  "Subject",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSubject()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DispatchQueryPlanRequestValidationError{
+				field:  "Subject",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetCaveatContext()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DispatchQueryPlanRequestValidationError{
+					field:  "CaveatContext",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DispatchQueryPlanRequestValidationError{
+					field:  "CaveatContext",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCaveatContext()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DispatchQueryPlanRequestValidationError{
+				field:  "CaveatContext",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for MaxRecursionDepth
+
+	// no validation rules for Analyze
+
+	if m.ConcurrencyLimit != nil {
+		// no validation rules for ConcurrencyLimit
+	}
+
+	if len(errors) > 0 {
+		return DispatchQueryPlanRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DispatchQueryPlanRequestMultiError is an error wrapping multiple validation
+// errors returned by DispatchQueryPlanRequest.ValidateAll() if the designated
+// constraints aren't met.
+type DispatchQueryPlanRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DispatchQueryPlanRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DispatchQueryPlanRequestMultiError) AllErrors() []error { return m }
+
+// DispatchQueryPlanRequestValidationError is the validation error returned by
+// DispatchQueryPlanRequest.Validate if the designated constraints aren't met.
+type DispatchQueryPlanRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DispatchQueryPlanRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DispatchQueryPlanRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DispatchQueryPlanRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DispatchQueryPlanRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DispatchQueryPlanRequestValidationError) ErrorName() string {
+	return "DispatchQueryPlanRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DispatchQueryPlanRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDispatchQueryPlanRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DispatchQueryPlanRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DispatchQueryPlanRequestValidationError{}
+
+// Validate checks the field values on DispatchQueryPlanResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DispatchQueryPlanResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DispatchQueryPlanResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// DispatchQueryPlanResponseMultiError, or nil if none found.
+func (m *DispatchQueryPlanResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DispatchQueryPlanResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetMetadata()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, DispatchQueryPlanResponseValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DispatchQueryPlanResponseValidationError{
+					field:  "Metadata",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMetadata()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DispatchQueryPlanResponseValidationError{
+				field:  "Metadata",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetPaths() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DispatchQueryPlanResponseValidationError{
+						field:  fmt.Sprintf("Paths[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DispatchQueryPlanResponseValidationError{
+						field:  fmt.Sprintf("Paths[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DispatchQueryPlanResponseValidationError{
+					field:  fmt.Sprintf("Paths[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return DispatchQueryPlanResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// DispatchQueryPlanResponseMultiError is an error wrapping multiple validation
+// errors returned by DispatchQueryPlanResponse.ValidateAll() if the
+// designated constraints aren't met.
+type DispatchQueryPlanResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DispatchQueryPlanResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DispatchQueryPlanResponseMultiError) AllErrors() []error { return m }
+
+// DispatchQueryPlanResponseValidationError is the validation error returned by
+// DispatchQueryPlanResponse.Validate if the designated constraints aren't met.
+type DispatchQueryPlanResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DispatchQueryPlanResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DispatchQueryPlanResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DispatchQueryPlanResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DispatchQueryPlanResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DispatchQueryPlanResponseValidationError) ErrorName() string {
+	return "DispatchQueryPlanResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DispatchQueryPlanResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDispatchQueryPlanResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DispatchQueryPlanResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DispatchQueryPlanResponseValidationError{}
+
+// Validate checks the field values on QueryPlanNode with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *QueryPlanNode) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on QueryPlanNode with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in QueryPlanNodeMultiError, or
+// nil if none found.
+func (m *QueryPlanNode) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *QueryPlanNode) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	switch v := m.Node.(type) {
+	case *QueryPlanNode_Relation:
+		if v == nil {
+			err := QueryPlanNodeValidationError{
+				field:  "Node",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetRelation()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "Relation",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "Relation",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetRelation()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return QueryPlanNodeValidationError{
+					field:  "Relation",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *QueryPlanNode_Union:
+		if v == nil {
+			err := QueryPlanNodeValidationError{
+				field:  "Node",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetUnion()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "Union",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "Union",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetUnion()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return QueryPlanNodeValidationError{
+					field:  "Union",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *QueryPlanNode_Intersection:
+		if v == nil {
+			err := QueryPlanNodeValidationError{
+				field:  "Node",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetIntersection()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "Intersection",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "Intersection",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetIntersection()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return QueryPlanNodeValidationError{
+					field:  "Intersection",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *QueryPlanNode_Exclusion:
+		if v == nil {
+			err := QueryPlanNodeValidationError{
+				field:  "Node",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetExclusion()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "Exclusion",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "Exclusion",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetExclusion()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return QueryPlanNodeValidationError{
+					field:  "Exclusion",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *QueryPlanNode_Arrow:
+		if v == nil {
+			err := QueryPlanNodeValidationError{
+				field:  "Node",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetArrow()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "Arrow",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "Arrow",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetArrow()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return QueryPlanNodeValidationError{
+					field:  "Arrow",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *QueryPlanNode_IntersectionArrow:
+		if v == nil {
+			err := QueryPlanNodeValidationError{
+				field:  "Node",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetIntersectionArrow()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "IntersectionArrow",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "IntersectionArrow",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetIntersectionArrow()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return QueryPlanNodeValidationError{
+					field:  "IntersectionArrow",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *QueryPlanNode_Alias:
+		if v == nil {
+			err := QueryPlanNodeValidationError{
+				field:  "Node",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetAlias()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "Alias",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "Alias",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetAlias()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return QueryPlanNodeValidationError{
+					field:  "Alias",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *QueryPlanNode_Caveat:
+		if v == nil {
+			err := QueryPlanNodeValidationError{
+				field:  "Node",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetCaveat()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "Caveat",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "Caveat",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetCaveat()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return QueryPlanNodeValidationError{
+					field:  "Caveat",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *QueryPlanNode_Fixed:
+		if v == nil {
+			err := QueryPlanNodeValidationError{
+				field:  "Node",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetFixed()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "Fixed",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "Fixed",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetFixed()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return QueryPlanNodeValidationError{
+					field:  "Fixed",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *QueryPlanNode_Recursive:
+		if v == nil {
+			err := QueryPlanNodeValidationError{
+				field:  "Node",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetRecursive()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "Recursive",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "Recursive",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetRecursive()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return QueryPlanNodeValidationError{
+					field:  "Recursive",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *QueryPlanNode_RecursiveSentinel:
+		if v == nil {
+			err := QueryPlanNodeValidationError{
+				field:  "Node",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if all {
+			switch v := interface{}(m.GetRecursiveSentinel()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "RecursiveSentinel",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, QueryPlanNodeValidationError{
+						field:  "RecursiveSentinel",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetRecursiveSentinel()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return QueryPlanNodeValidationError{
+					field:  "RecursiveSentinel",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+
+	if len(errors) > 0 {
+		return QueryPlanNodeMultiError(errors)
+	}
+
+	return nil
+}
+
+// QueryPlanNodeMultiError is an error wrapping multiple validation errors
+// returned by QueryPlanNode.ValidateAll() if the designated constraints
+// aren't met.
+type QueryPlanNodeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m QueryPlanNodeMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m QueryPlanNodeMultiError) AllErrors() []error { return m }
+
+// QueryPlanNodeValidationError is the validation error returned by
+// QueryPlanNode.Validate if the designated constraints aren't met.
+type QueryPlanNodeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e QueryPlanNodeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e QueryPlanNodeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e QueryPlanNodeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e QueryPlanNodeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e QueryPlanNodeValidationError) ErrorName() string { return "QueryPlanNodeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e QueryPlanNodeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sQueryPlanNode.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = QueryPlanNodeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = QueryPlanNodeValidationError{}
+
+// Validate checks the field values on QueryPlanRelation with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *QueryPlanRelation) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on QueryPlanRelation with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// QueryPlanRelationMultiError, or nil if none found.
+func (m *QueryPlanRelation) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *QueryPlanRelation) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for DefinitionName
+
+	// no validation rules for RelationName
+
+	// no validation rules for SubjectType
+
+	// no validation rules for SubjectRelation
+
+	// no validation rules for CaveatName
+
+	// no validation rules for Expiration
+
+	// no validation rules for Wildcard
+
+	if len(errors) > 0 {
+		return QueryPlanRelationMultiError(errors)
+	}
+
+	return nil
+}
+
+// QueryPlanRelationMultiError is an error wrapping multiple validation errors
+// returned by QueryPlanRelation.ValidateAll() if the designated constraints
+// aren't met.
+type QueryPlanRelationMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m QueryPlanRelationMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m QueryPlanRelationMultiError) AllErrors() []error { return m }
+
+// QueryPlanRelationValidationError is the validation error returned by
+// QueryPlanRelation.Validate if the designated constraints aren't met.
+type QueryPlanRelationValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e QueryPlanRelationValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e QueryPlanRelationValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e QueryPlanRelationValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e QueryPlanRelationValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e QueryPlanRelationValidationError) ErrorName() string {
+	return "QueryPlanRelationValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e QueryPlanRelationValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sQueryPlanRelation.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = QueryPlanRelationValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = QueryPlanRelationValidationError{}
+
+// Validate checks the field values on QueryPlanChildren with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *QueryPlanChildren) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on QueryPlanChildren with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// QueryPlanChildrenMultiError, or nil if none found.
+func (m *QueryPlanChildren) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *QueryPlanChildren) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetChildren() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, QueryPlanChildrenValidationError{
+						field:  fmt.Sprintf("Children[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, QueryPlanChildrenValidationError{
+						field:  fmt.Sprintf("Children[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return QueryPlanChildrenValidationError{
+					field:  fmt.Sprintf("Children[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return QueryPlanChildrenMultiError(errors)
+	}
+
+	return nil
+}
+
+// QueryPlanChildrenMultiError is an error wrapping multiple validation errors
+// returned by QueryPlanChildren.ValidateAll() if the designated constraints
+// aren't met.
+type QueryPlanChildrenMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m QueryPlanChildrenMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m QueryPlanChildrenMultiError) AllErrors() []error { return m }
+
+// QueryPlanChildrenValidationError is the validation error returned by
+// QueryPlanChildren.Validate if the designated constraints aren't met.
+type QueryPlanChildrenValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e QueryPlanChildrenValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e QueryPlanChildrenValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e QueryPlanChildrenValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e QueryPlanChildrenValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e QueryPlanChildrenValidationError) ErrorName() string {
+	return "QueryPlanChildrenValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e QueryPlanChildrenValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sQueryPlanChildren.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = QueryPlanChildrenValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = QueryPlanChildrenValidationError{}
+
+// Validate checks the field values on QueryPlanExclusion with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *QueryPlanExclusion) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on QueryPlanExclusion with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// QueryPlanExclusionMultiError, or nil if none found.
+func (m *QueryPlanExclusion) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *QueryPlanExclusion) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetMainSet()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, QueryPlanExclusionValidationError{
+					field:  "MainSet",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, QueryPlanExclusionValidationError{
+					field:  "MainSet",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetMainSet()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return QueryPlanExclusionValidationError{
+				field:  "MainSet",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetExcluded()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, QueryPlanExclusionValidationError{
+					field:  "Excluded",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, QueryPlanExclusionValidationError{
+					field:  "Excluded",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExcluded()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return QueryPlanExclusionValidationError{
+				field:  "Excluded",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return QueryPlanExclusionMultiError(errors)
+	}
+
+	return nil
+}
+
+// QueryPlanExclusionMultiError is an error wrapping multiple validation errors
+// returned by QueryPlanExclusion.ValidateAll() if the designated constraints
+// aren't met.
+type QueryPlanExclusionMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m QueryPlanExclusionMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m QueryPlanExclusionMultiError) AllErrors() []error { return m }
+
+// QueryPlanExclusionValidationError is the validation error returned by
+// QueryPlanExclusion.Validate if the designated constraints aren't met.
+type QueryPlanExclusionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e QueryPlanExclusionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e QueryPlanExclusionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e QueryPlanExclusionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e QueryPlanExclusionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e QueryPlanExclusionValidationError) ErrorName() string {
+	return "QueryPlanExclusionValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e QueryPlanExclusionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sQueryPlanExclusion.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = QueryPlanExclusionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = QueryPlanExclusionValidationError{}
+
+// Validate checks the field values on QueryPlanArrow with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *QueryPlanArrow) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on QueryPlanArrow with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in QueryPlanArrowMultiError,
+// or nil if none found.
+func (m *QueryPlanArrow) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *QueryPlanArrow) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetLeft()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, QueryPlanArrowValidationError{
+					field:  "Left",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, QueryPlanArrowValidationError{
+					field:  "Left",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLeft()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return QueryPlanArrowValidationError{
+				field:  "Left",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetRight()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, QueryPlanArrowValidationError{
+					field:  "Right",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, QueryPlanArrowValidationError{
+					field:  "Right",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRight()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return QueryPlanArrowValidationError{
+				field:  "Right",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for RightToLeft
+
+	if len(errors) > 0 {
+		return QueryPlanArrowMultiError(errors)
+	}
+
+	return nil
+}
+
+// QueryPlanArrowMultiError is an error wrapping multiple validation errors
+// returned by QueryPlanArrow.ValidateAll() if the designated constraints
+// aren't met.
+type QueryPlanArrowMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m QueryPlanArrowMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m QueryPlanArrowMultiError) AllErrors() []error { return m }
+
+// QueryPlanArrowValidationError is the validation error returned by
+// QueryPlanArrow.Validate if the designated constraints aren't met.
+type QueryPlanArrowValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e QueryPlanArrowValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e QueryPlanArrowValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e QueryPlanArrowValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e QueryPlanArrowValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e QueryPlanArrowValidationError) ErrorName() string { return "QueryPlanArrowValidationError" }
+
+// Error satisfies the builtin error interface
+func (e QueryPlanArrowValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sQueryPlanArrow.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = QueryPlanArrowValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = QueryPlanArrowValidationError{}
+
+// Validate checks the field values on QueryPlanAlias with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *QueryPlanAlias) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on QueryPlanAlias with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in QueryPlanAliasMultiError,
+// or nil if none found.
+func (m *QueryPlanAlias) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *QueryPlanAlias) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Relation
+
+	if all {
+		switch v := interface{}(m.GetChild()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, QueryPlanAliasValidationError{
+					field:  "Child",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, QueryPlanAliasValidationError{
+					field:  "Child",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetChild()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return QueryPlanAliasValidationError{
+				field:  "Child",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return QueryPlanAliasMultiError(errors)
+	}
+
+	return nil
+}
+
+// QueryPlanAliasMultiError is an error wrapping multiple validation errors
+// returned by QueryPlanAlias.ValidateAll() if the designated constraints
+// aren't met.
+type QueryPlanAliasMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m QueryPlanAliasMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m QueryPlanAliasMultiError) AllErrors() []error { return m }
+
+// QueryPlanAliasValidationError is the validation error returned by
+// QueryPlanAlias.Validate if the designated constraints aren't met.
+type QueryPlanAliasValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e QueryPlanAliasValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e QueryPlanAliasValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e QueryPlanAliasValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e QueryPlanAliasValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e QueryPlanAliasValidationError) ErrorName() string { return "QueryPlanAliasValidationError" }
+
+// Error satisfies the builtin error interface
+func (e QueryPlanAliasValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sQueryPlanAlias.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = QueryPlanAliasValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = QueryPlanAliasValidationError{}
+
+// Validate checks the field values on QueryPlanCaveat with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *QueryPlanCaveat) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on QueryPlanCaveat with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// QueryPlanCaveatMultiError, or nil if none found.
+func (m *QueryPlanCaveat) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *QueryPlanCaveat) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetCaveat()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, QueryPlanCaveatValidationError{
+					field:  "Caveat",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, QueryPlanCaveatValidationError{
+					field:  "Caveat",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCaveat()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return QueryPlanCaveatValidationError{
+				field:  "Caveat",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetChild()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, QueryPlanCaveatValidationError{
+					field:  "Child",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, QueryPlanCaveatValidationError{
+					field:  "Child",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetChild()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return QueryPlanCaveatValidationError{
+				field:  "Child",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return QueryPlanCaveatMultiError(errors)
+	}
+
+	return nil
+}
+
+// QueryPlanCaveatMultiError is an error wrapping multiple validation errors
+// returned by QueryPlanCaveat.ValidateAll() if the designated constraints
+// aren't met.
+type QueryPlanCaveatMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m QueryPlanCaveatMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m QueryPlanCaveatMultiError) AllErrors() []error { return m }
+
+// QueryPlanCaveatValidationError is the validation error returned by
+// QueryPlanCaveat.Validate if the designated constraints aren't met.
+type QueryPlanCaveatValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e QueryPlanCaveatValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e QueryPlanCaveatValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e QueryPlanCaveatValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e QueryPlanCaveatValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e QueryPlanCaveatValidationError) ErrorName() string { return "QueryPlanCaveatValidationError" }
+
+// Error satisfies the builtin error interface
+func (e QueryPlanCaveatValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sQueryPlanCaveat.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = QueryPlanCaveatValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = QueryPlanCaveatValidationError{}
+
+// Validate checks the field values on QueryPlanFixed with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *QueryPlanFixed) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on QueryPlanFixed with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in QueryPlanFixedMultiError,
+// or nil if none found.
+func (m *QueryPlanFixed) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *QueryPlanFixed) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetPaths() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, QueryPlanFixedValidationError{
+						field:  fmt.Sprintf("Paths[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, QueryPlanFixedValidationError{
+						field:  fmt.Sprintf("Paths[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return QueryPlanFixedValidationError{
+					field:  fmt.Sprintf("Paths[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return QueryPlanFixedMultiError(errors)
+	}
+
+	return nil
+}
+
+// QueryPlanFixedMultiError is an error wrapping multiple validation errors
+// returned by QueryPlanFixed.ValidateAll() if the designated constraints
+// aren't met.
+type QueryPlanFixedMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m QueryPlanFixedMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m QueryPlanFixedMultiError) AllErrors() []error { return m }
+
+// QueryPlanFixedValidationError is the validation error returned by
+// QueryPlanFixed.Validate if the designated constraints aren't met.
+type QueryPlanFixedValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e QueryPlanFixedValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e QueryPlanFixedValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e QueryPlanFixedValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e QueryPlanFixedValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e QueryPlanFixedValidationError) ErrorName() string { return "QueryPlanFixedValidationError" }
+
+// Error satisfies the builtin error interface
+func (e QueryPlanFixedValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sQueryPlanFixed.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = QueryPlanFixedValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = QueryPlanFixedValidationError{}
+
+// Validate checks the field values on QueryPlanRecursive with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *QueryPlanRecursive) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on QueryPlanRecursive with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// QueryPlanRecursiveMultiError, or nil if none found.
+func (m *QueryPlanRecursive) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *QueryPlanRecursive) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for DefinitionName
+
+	// no validation rules for RelationName
+
+	if all {
+		switch v := interface{}(m.GetTemplateTree()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, QueryPlanRecursiveValidationError{
+					field:  "TemplateTree",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, QueryPlanRecursiveValidationError{
+					field:  "TemplateTree",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTemplateTree()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return QueryPlanRecursiveValidationError{
+				field:  "TemplateTree",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return QueryPlanRecursiveMultiError(errors)
+	}
+
+	return nil
+}
+
+// QueryPlanRecursiveMultiError is an error wrapping multiple validation errors
+// returned by QueryPlanRecursive.ValidateAll() if the designated constraints
+// aren't met.
+type QueryPlanRecursiveMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m QueryPlanRecursiveMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m QueryPlanRecursiveMultiError) AllErrors() []error { return m }
+
+// QueryPlanRecursiveValidationError is the validation error returned by
+// QueryPlanRecursive.Validate if the designated constraints aren't met.
+type QueryPlanRecursiveValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e QueryPlanRecursiveValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e QueryPlanRecursiveValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e QueryPlanRecursiveValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e QueryPlanRecursiveValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e QueryPlanRecursiveValidationError) ErrorName() string {
+	return "QueryPlanRecursiveValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e QueryPlanRecursiveValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sQueryPlanRecursive.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = QueryPlanRecursiveValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = QueryPlanRecursiveValidationError{}
+
+// Validate checks the field values on QueryPlanRecursiveSentinel with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *QueryPlanRecursiveSentinel) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on QueryPlanRecursiveSentinel with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// QueryPlanRecursiveSentinelMultiError, or nil if none found.
+func (m *QueryPlanRecursiveSentinel) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *QueryPlanRecursiveSentinel) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for DefinitionName
+
+	// no validation rules for RelationName
+
+	// no validation rules for WithSubRelations
+
+	if len(errors) > 0 {
+		return QueryPlanRecursiveSentinelMultiError(errors)
+	}
+
+	return nil
+}
+
+// QueryPlanRecursiveSentinelMultiError is an error wrapping multiple
+// validation errors returned by QueryPlanRecursiveSentinel.ValidateAll() if
+// the designated constraints aren't met.
+type QueryPlanRecursiveSentinelMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m QueryPlanRecursiveSentinelMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m QueryPlanRecursiveSentinelMultiError) AllErrors() []error { return m }
+
+// QueryPlanRecursiveSentinelValidationError is the validation error returned
+// by QueryPlanRecursiveSentinel.Validate if the designated constraints aren't met.
+type QueryPlanRecursiveSentinelValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e QueryPlanRecursiveSentinelValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e QueryPlanRecursiveSentinelValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e QueryPlanRecursiveSentinelValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e QueryPlanRecursiveSentinelValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e QueryPlanRecursiveSentinelValidationError) ErrorName() string {
+	return "QueryPlanRecursiveSentinelValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e QueryPlanRecursiveSentinelValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sQueryPlanRecursiveSentinel.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = QueryPlanRecursiveSentinelValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = QueryPlanRecursiveSentinelValidationError{}
+
+// Validate checks the field values on QueryPlanPath with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *QueryPlanPath) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on QueryPlanPath with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in QueryPlanPathMultiError, or
+// nil if none found.
+func (m *QueryPlanPath) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *QueryPlanPath) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetResource()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, QueryPlanPathValidationError{
+					field:  "Resource",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, QueryPlanPathValidationError{
+					field:  "Resource",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetResource()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return QueryPlanPathValidationError{
+				field:  "Resource",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetSubject()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, QueryPlanPathValidationError{
+					field:  "Subject",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, QueryPlanPathValidationError{
+					field:  "Subject",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSubject()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return QueryPlanPathValidationError{
+				field:  "Subject",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetCaveat()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, QueryPlanPathValidationError{
+					field:  "Caveat",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, QueryPlanPathValidationError{
+					field:  "Caveat",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCaveat()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return QueryPlanPathValidationError{
+				field:  "Caveat",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetExpiration()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, QueryPlanPathValidationError{
+					field:  "Expiration",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, QueryPlanPathValidationError{
+					field:  "Expiration",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetExpiration()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return QueryPlanPathValidationError{
+				field:  "Expiration",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetIntegrity() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, QueryPlanPathValidationError{
+						field:  fmt.Sprintf("Integrity[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, QueryPlanPathValidationError{
+						field:  fmt.Sprintf("Integrity[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return QueryPlanPathValidationError{
+					field:  fmt.Sprintf("Integrity[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return QueryPlanPathMultiError(errors)
+	}
+
+	return nil
+}
+
+// QueryPlanPathMultiError is an error wrapping multiple validation errors
+// returned by QueryPlanPath.ValidateAll() if the designated constraints
+// aren't met.
+type QueryPlanPathMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m QueryPlanPathMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m QueryPlanPathMultiError) AllErrors() []error { return m }
+
+// QueryPlanPathValidationError is the validation error returned by
+// QueryPlanPath.Validate if the designated constraints aren't met.
+type QueryPlanPathValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e QueryPlanPathValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e QueryPlanPathValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e QueryPlanPathValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e QueryPlanPathValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e QueryPlanPathValidationError) ErrorName() string { return "QueryPlanPathValidationError" }
+
+// Error satisfies the builtin error interface
+func (e QueryPlanPathValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sQueryPlanPath.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = QueryPlanPathValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = QueryPlanPathValidationError{}
+
+// Validate checks the field values on DispatchPeerCacheLookupRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DispatchPeerCacheLookupRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DispatchPeerCacheLookupRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// DispatchPeerCacheLookupRequestMultiError, or nil if none found.
+func (m *DispatchPeerCacheLookupRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DispatchPeerCacheLookupRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	oneofRequestPresent := false
+	switch v := m.Request.(type) {
+	case *DispatchPeerCacheLookupRequest_Check:
+		if v == nil {
+			err := DispatchPeerCacheLookupRequestValidationError{
+				field:  "Request",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofRequestPresent = true
+
+		if all {
+			switch v := interface{}(m.GetCheck()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DispatchPeerCacheLookupRequestValidationError{
+						field:  "Check",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DispatchPeerCacheLookupRequestValidationError{
+						field:  "Check",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetCheck()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DispatchPeerCacheLookupRequestValidationError{
+					field:  "Check",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *DispatchPeerCacheLookupRequest_LookupResources2:
+		if v == nil {
+			err := DispatchPeerCacheLookupRequestValidationError{
+				field:  "Request",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofRequestPresent = true
+
+		if all {
+			switch v := interface{}(m.GetLookupResources2()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DispatchPeerCacheLookupRequestValidationError{
+						field:  "LookupResources2",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DispatchPeerCacheLookupRequestValidationError{
+						field:  "LookupResources2",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetLookupResources2()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DispatchPeerCacheLookupRequestValidationError{
+					field:  "LookupResources2",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *DispatchPeerCacheLookupRequest_LookupResources3:
+		if v == nil {
+			err := DispatchPeerCacheLookupRequestValidationError{
+				field:  "Request",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofRequestPresent = true
+
+		if all {
+			switch v := interface{}(m.GetLookupResources3()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DispatchPeerCacheLookupRequestValidationError{
+						field:  "LookupResources3",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DispatchPeerCacheLookupRequestValidationError{
+						field:  "LookupResources3",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetLookupResources3()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DispatchPeerCacheLookupRequestValidationError{
+					field:  "LookupResources3",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *DispatchPeerCacheLookupRequest_LookupSubjects:
+		if v == nil {
+			err := DispatchPeerCacheLookupRequestValidationError{
+				field:  "Request",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofRequestPresent = true
+
+		if all {
+			switch v := interface{}(m.GetLookupSubjects()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DispatchPeerCacheLookupRequestValidationError{
+						field:  "LookupSubjects",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DispatchPeerCacheLookupRequestValidationError{
+						field:  "LookupSubjects",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetLookupSubjects()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return DispatchPeerCacheLookupRequestValidationError{
+					field:  "LookupSubjects",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+	if !oneofRequestPresent {
+		err := DispatchPeerCacheLookupRequestValidationError{
+			field:  "Request",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return DispatchPeerCacheLookupRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// DispatchPeerCacheLookupRequestMultiError is an error wrapping multiple
+// validation errors returned by DispatchPeerCacheLookupRequest.ValidateAll()
+// if the designated constraints aren't met.
+type DispatchPeerCacheLookupRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DispatchPeerCacheLookupRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DispatchPeerCacheLookupRequestMultiError) AllErrors() []error { return m }
+
+// DispatchPeerCacheLookupRequestValidationError is the validation error
+// returned by DispatchPeerCacheLookupRequest.Validate if the designated
+// constraints aren't met.
+type DispatchPeerCacheLookupRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DispatchPeerCacheLookupRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DispatchPeerCacheLookupRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DispatchPeerCacheLookupRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DispatchPeerCacheLookupRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DispatchPeerCacheLookupRequestValidationError) ErrorName() string {
+	return "DispatchPeerCacheLookupRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DispatchPeerCacheLookupRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDispatchPeerCacheLookupRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DispatchPeerCacheLookupRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DispatchPeerCacheLookupRequestValidationError{}
+
+// Validate checks the field values on DispatchPeerCacheLookupResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *DispatchPeerCacheLookupResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on DispatchPeerCacheLookupResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// DispatchPeerCacheLookupResponseMultiError, or nil if none found.
+func (m *DispatchPeerCacheLookupResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *DispatchPeerCacheLookupResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Found
+
+	if len(errors) > 0 {
+		return DispatchPeerCacheLookupResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// DispatchPeerCacheLookupResponseMultiError is an error wrapping multiple
+// validation errors returned by DispatchPeerCacheLookupResponse.ValidateAll()
+// if the designated constraints aren't met.
+type DispatchPeerCacheLookupResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m DispatchPeerCacheLookupResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m DispatchPeerCacheLookupResponseMultiError) AllErrors() []error { return m }
+
+// DispatchPeerCacheLookupResponseValidationError is the validation error
+// returned by DispatchPeerCacheLookupResponse.Validate if the designated
+// constraints aren't met.
+type DispatchPeerCacheLookupResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e DispatchPeerCacheLookupResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e DispatchPeerCacheLookupResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e DispatchPeerCacheLookupResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e DispatchPeerCacheLookupResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e DispatchPeerCacheLookupResponseValidationError) ErrorName() string {
+	return "DispatchPeerCacheLookupResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e DispatchPeerCacheLookupResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sDispatchPeerCacheLookupResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = DispatchPeerCacheLookupResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = DispatchPeerCacheLookupResponseValidationError{}
+
 // Validate checks the field values on ResolverMeta with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -3377,23 +6230,57 @@ func (m *DebugInformation) validate(all bool) error {
 					cause:  err,
 				})
 			}
-		case interface{ Validate() error }:
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, DebugInformationValidationError{
+					field:  "Check",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCheck()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return DebugInformationValidationError{
+				field:  "Check",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetQueryPlanAnalysis() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, DebugInformationValidationError{
+						field:  fmt.Sprintf("QueryPlanAnalysis[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, DebugInformationValidationError{
+						field:  fmt.Sprintf("QueryPlanAnalysis[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				errors = append(errors, DebugInformationValidationError{
-					field:  "Check",
+				return DebugInformationValidationError{
+					field:  fmt.Sprintf("QueryPlanAnalysis[%v]", idx),
 					reason: "embedded message failed validation",
 					cause:  err,
-				})
-			}
-		}
-	} else if v, ok := interface{}(m.GetCheck()).(interface{ Validate() error }); ok {
-		if err := v.Validate(); err != nil {
-			return DebugInformationValidationError{
-				field:  "Check",
-				reason: "embedded message failed validation",
-				cause:  err,
+				}
 			}
 		}
+
 	}
 
 	if len(errors) > 0 {
@@ -3719,3 +6606,321 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = CheckDebugTraceValidationError{}
+
+// Validate checks the field values on QueryPlanAnalysis with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *QueryPlanAnalysis) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on QueryPlanAnalysis with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// QueryPlanAnalysisMultiError, or nil if none found.
+func (m *QueryPlanAnalysis) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *QueryPlanAnalysis) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Iterator
+
+	// no validation rules for Calls
+
+	if all {
+		switch v := interface{}(m.GetDuration()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, QueryPlanAnalysisValidationError{
+					field:  "Duration",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, QueryPlanAnalysisValidationError{
+					field:  "Duration",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDuration()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return QueryPlanAnalysisValidationError{
+				field:  "Duration",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Results
+
+	// no validation rules for DatastoreQueries
+
+	// no validation rules for RowsRead
+
+	// no validation rules for CacheHits
+
+	// no validation rules for Dispatched
+
+	for idx, item := range m.GetChildren() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, QueryPlanAnalysisValidationError{
+						field:  fmt.Sprintf("Children[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, QueryPlanAnalysisValidationError{
+						field:  fmt.Sprintf("Children[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return QueryPlanAnalysisValidationError{
+					field:  fmt.Sprintf("Children[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetEstimate()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, QueryPlanAnalysisValidationError{
+					field:  "Estimate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, QueryPlanAnalysisValidationError{
+					field:  "Estimate",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEstimate()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return QueryPlanAnalysisValidationError{
+				field:  "Estimate",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return QueryPlanAnalysisMultiError(errors)
+	}
+
+	return nil
+}
+
+// QueryPlanAnalysisMultiError is an error wrapping multiple validation errors
+// returned by QueryPlanAnalysis.ValidateAll() if the designated constraints
+// aren't met.
+type QueryPlanAnalysisMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m QueryPlanAnalysisMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m QueryPlanAnalysisMultiError) AllErrors() []error { return m }
+
+// QueryPlanAnalysisValidationError is the validation error returned by
+// QueryPlanAnalysis.Validate if the designated constraints aren't met.
+type QueryPlanAnalysisValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e QueryPlanAnalysisValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e QueryPlanAnalysisValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e QueryPlanAnalysisValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e QueryPlanAnalysisValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e QueryPlanAnalysisValidationError) ErrorName() string {
+	return "QueryPlanAnalysisValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e QueryPlanAnalysisValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sQueryPlanAnalysis.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = QueryPlanAnalysisValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = QueryPlanAnalysisValidationError{}
+
+// Validate checks the field values on QueryPlanEstimate with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *QueryPlanEstimate) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on QueryPlanEstimate with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// QueryPlanEstimateMultiError, or nil if none found.
+func (m *QueryPlanEstimate) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *QueryPlanEstimate) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Cost
+
+	// no validation rules for Selectivity
+
+	// no validation rules for Fanout
+
+	// no validation rules for Fanin
+
+	if len(errors) > 0 {
+		return QueryPlanEstimateMultiError(errors)
+	}
+
+	return nil
+}
+
+// QueryPlanEstimateMultiError is an error wrapping multiple validation errors
+// returned by QueryPlanEstimate.ValidateAll() if the designated constraints
+// aren't met.
+type QueryPlanEstimateMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m QueryPlanEstimateMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m QueryPlanEstimateMultiError) AllErrors() []error { return m }
+
+// QueryPlanEstimateValidationError is the validation error returned by
+// QueryPlanEstimate.Validate if the designated constraints aren't met.
+type QueryPlanEstimateValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e QueryPlanEstimateValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e QueryPlanEstimateValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e QueryPlanEstimateValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e QueryPlanEstimateValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e QueryPlanEstimateValidationError) ErrorName() string {
+	return "QueryPlanEstimateValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e QueryPlanEstimateValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sQueryPlanEstimate.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = QueryPlanEstimateValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = QueryPlanEstimateValidationError{}
@@ -24,6 +24,8 @@ const (
 	DispatchService_DispatchLookupSubjects_FullMethodName   = "/dispatch.v1.DispatchService/DispatchLookupSubjects"
 	DispatchService_DispatchLookupResources2_FullMethodName = "/dispatch.v1.DispatchService/DispatchLookupResources2"
 	DispatchService_DispatchLookupResources3_FullMethodName = "/dispatch.v1.DispatchService/DispatchLookupResources3"
+	DispatchService_DispatchQueryPlan_FullMethodName        = "/dispatch.v1.DispatchService/DispatchQueryPlan"
+	DispatchService_DispatchPeerCacheLookup_FullMethodName  = "/dispatch.v1.DispatchService/DispatchPeerCacheLookup"
 )
 
 // DispatchServiceClient is the client API for DispatchService service.
@@ -35,6 +37,8 @@ type DispatchServiceClient interface {
 	DispatchLookupSubjects(ctx context.Context, in *DispatchLookupSubjectsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DispatchLookupSubjectsResponse], error)
 	DispatchLookupResources2(ctx context.Context, in *DispatchLookupResources2Request, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DispatchLookupResources2Response], error)
 	DispatchLookupResources3(ctx context.Context, in *DispatchLookupResources3Request, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DispatchLookupResources3Response], error)
+	DispatchQueryPlan(ctx context.Context, in *DispatchQueryPlanRequest, opts ...grpc.CallOption) (*DispatchQueryPlanResponse, error)
+	DispatchPeerCacheLookup(ctx context.Context, in *DispatchPeerCacheLookupRequest, opts ...grpc.CallOption) (*DispatchPeerCacheLookupResponse, error)
 }
 
 type dispatchServiceClient struct {
@@ -122,6 +126,26 @@ func (c *dispatchServiceClient) DispatchLookupResources3(ctx context.Context, in
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DispatchService_DispatchLookupResources3Client = grpc.ServerStreamingClient[DispatchLookupResources3Response]
 
+func (c *dispatchServiceClient) DispatchQueryPlan(ctx context.Context, in *DispatchQueryPlanRequest, opts ...grpc.CallOption) (*DispatchQueryPlanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DispatchQueryPlanResponse)
+	err := c.cc.Invoke(ctx, DispatchService_DispatchQueryPlan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dispatchServiceClient) DispatchPeerCacheLookup(ctx context.Context, in *DispatchPeerCacheLookupRequest, opts ...grpc.CallOption) (*DispatchPeerCacheLookupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DispatchPeerCacheLookupResponse)
+	err := c.cc.Invoke(ctx, DispatchService_DispatchPeerCacheLookup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DispatchServiceServer is the server API for DispatchService service.
 // All implementations must embed UnimplementedDispatchServiceServer
 // for forward compatibility.
@@ -131,6 +155,8 @@ type DispatchServiceServer interface {
 	DispatchLookupSubjects(*DispatchLookupSubjectsRequest, grpc.ServerStreamingServer[DispatchLookupSubjectsResponse]) error
 	DispatchLookupResources2(*DispatchLookupResources2Request, grpc.ServerStreamingServer[DispatchLookupResources2Response]) error
 	DispatchLookupResources3(*DispatchLookupResources3Request, grpc.ServerStreamingServer[DispatchLookupResources3Response]) error
+	DispatchQueryPlan(context.Context, *DispatchQueryPlanRequest) (*DispatchQueryPlanResponse, error)
+	DispatchPeerCacheLookup(context.Context, *DispatchPeerCacheLookupRequest) (*DispatchPeerCacheLookupResponse, error)
 	mustEmbedUnimplementedDispatchServiceServer()
 }
 
@@ -156,6 +182,12 @@ func (UnimplementedDispatchServiceServer) DispatchLookupResources2(*DispatchLook
 func (UnimplementedDispatchServiceServer) DispatchLookupResources3(*DispatchLookupResources3Request, grpc.ServerStreamingServer[DispatchLookupResources3Response]) error {
 	return status.Errorf(codes.Unimplemented, "method DispatchLookupResources3 not implemented")
 }
+func (UnimplementedDispatchServiceServer) DispatchQueryPlan(context.Context, *DispatchQueryPlanRequest) (*DispatchQueryPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DispatchQueryPlan not implemented")
+}
+func (UnimplementedDispatchServiceServer) DispatchPeerCacheLookup(context.Context, *DispatchPeerCacheLookupRequest) (*DispatchPeerCacheLookupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DispatchPeerCacheLookup not implemented")
+}
 func (UnimplementedDispatchServiceServer) mustEmbedUnimplementedDispatchServiceServer() {}
 func (UnimplementedDispatchServiceServer) testEmbeddedByValue()                         {}
 
@@ -246,6 +278,42 @@ func _DispatchService_DispatchLookupResources3_Handler(srv interface{}, stream g
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type DispatchService_DispatchLookupResources3Server = grpc.ServerStreamingServer[DispatchLookupResources3Response]
 
+func _DispatchService_DispatchQueryPlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DispatchQueryPlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DispatchServiceServer).DispatchQueryPlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DispatchService_DispatchQueryPlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DispatchServiceServer).DispatchQueryPlan(ctx, req.(*DispatchQueryPlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DispatchService_DispatchPeerCacheLookup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DispatchPeerCacheLookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DispatchServiceServer).DispatchPeerCacheLookup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DispatchService_DispatchPeerCacheLookup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DispatchServiceServer).DispatchPeerCacheLookup(ctx, req.(*DispatchPeerCacheLookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DispatchService_ServiceDesc is the grpc.ServiceDesc for DispatchService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -261,6 +329,14 @@ var DispatchService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DispatchExpand",
 			Handler:    _DispatchService_DispatchExpand_Handler,
 		},
+		{
+			MethodName: "DispatchQueryPlan",
+			Handler:    _DispatchService_DispatchQueryPlan_Handler,
+		},
+		{
+			MethodName: "DispatchPeerCacheLookup",
+			Handler:    _DispatchService_DispatchPeerCacheLookup_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
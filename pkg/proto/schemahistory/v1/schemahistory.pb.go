@@ -0,0 +1,298 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: schemahistory/v1/schemahistory.proto
+
+package schemahistoryv1
+
+import (
+	v1 "github.com/authzed/spicedb/pkg/proto/core/v1"
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReadSchemaHistoryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// optional_at is the ZedToken of the revision at which to read the schema and its history.
+	// It must still be within the garbage collection window of the datastore. If empty, the
+	// head revision is used.
+	OptionalAt string `protobuf:"bytes,1,opt,name=optional_at,json=optionalAt,proto3" json:"optional_at,omitempty"`
+	// optional_limit is the maximum number of entries to return. Defaults to 100.
+	OptionalLimit uint32 `protobuf:"varint,2,opt,name=optional_limit,json=optionalLimit,proto3" json:"optional_limit,omitempty"`
+	// optional_cursor is the cursor returned by the previous call, if any, from which to
+	// continue reading older entries.
+	OptionalCursor string `protobuf:"bytes,3,opt,name=optional_cursor,json=optionalCursor,proto3" json:"optional_cursor,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReadSchemaHistoryRequest) Reset() {
+	*x = ReadSchemaHistoryRequest{}
+	mi := &file_schemahistory_v1_schemahistory_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadSchemaHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadSchemaHistoryRequest) ProtoMessage() {}
+
+func (x *ReadSchemaHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemahistory_v1_schemahistory_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadSchemaHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ReadSchemaHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_schemahistory_v1_schemahistory_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReadSchemaHistoryRequest) GetOptionalAt() string {
+	if x != nil {
+		return x.OptionalAt
+	}
+	return ""
+}
+
+func (x *ReadSchemaHistoryRequest) GetOptionalLimit() uint32 {
+	if x != nil {
+		return x.OptionalLimit
+	}
+	return 0
+}
+
+func (x *ReadSchemaHistoryRequest) GetOptionalCursor() string {
+	if x != nil {
+		return x.OptionalCursor
+	}
+	return ""
+}
+
+// SchemaHistoryEntry is an entry of the schema history, recording a schema write.
+type SchemaHistoryEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// written_at is the ZedToken of the revision at which the schema was written.
+	WrittenAt string `protobuf:"bytes,1,opt,name=written_at,json=writtenAt,proto3" json:"written_at,omitempty"`
+	// entry holds the schema written, the changes made by it and the caller which wrote it.
+	Entry         *v1.SchemaHistoryEntry `protobuf:"bytes,2,opt,name=entry,proto3" json:"entry,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaHistoryEntry) Reset() {
+	*x = SchemaHistoryEntry{}
+	mi := &file_schemahistory_v1_schemahistory_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaHistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaHistoryEntry) ProtoMessage() {}
+
+func (x *SchemaHistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_schemahistory_v1_schemahistory_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaHistoryEntry.ProtoReflect.Descriptor instead.
+func (*SchemaHistoryEntry) Descriptor() ([]byte, []int) {
+	return file_schemahistory_v1_schemahistory_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SchemaHistoryEntry) GetWrittenAt() string {
+	if x != nil {
+		return x.WrittenAt
+	}
+	return ""
+}
+
+func (x *SchemaHistoryEntry) GetEntry() *v1.SchemaHistoryEntry {
+	if x != nil {
+		return x.Entry
+	}
+	return nil
+}
+
+type ReadSchemaHistoryResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// schema_text is the schema as of the revision read, in the schema language. It is empty
+	// if no schema was defined at the revision.
+	SchemaText string `protobuf:"bytes,1,opt,name=schema_text,json=schemaText,proto3" json:"schema_text,omitempty"`
+	// read_at is the ZedToken of the revision at which the schema and its history were read.
+	ReadAt string `protobuf:"bytes,2,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"`
+	// entries are the entries of the schema history, newest first.
+	Entries []*SchemaHistoryEntry `protobuf:"bytes,3,rep,name=entries,proto3" json:"entries,omitempty"`
+	// after_result_cursor is the cursor with which to read the next older entries, if the
+	// limit was reached.
+	AfterResultCursor string `protobuf:"bytes,4,opt,name=after_result_cursor,json=afterResultCursor,proto3" json:"after_result_cursor,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ReadSchemaHistoryResponse) Reset() {
+	*x = ReadSchemaHistoryResponse{}
+	mi := &file_schemahistory_v1_schemahistory_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReadSchemaHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReadSchemaHistoryResponse) ProtoMessage() {}
+
+func (x *ReadSchemaHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemahistory_v1_schemahistory_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReadSchemaHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ReadSchemaHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_schemahistory_v1_schemahistory_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReadSchemaHistoryResponse) GetSchemaText() string {
+	if x != nil {
+		return x.SchemaText
+	}
+	return ""
+}
+
+func (x *ReadSchemaHistoryResponse) GetReadAt() string {
+	if x != nil {
+		return x.ReadAt
+	}
+	return ""
+}
+
+func (x *ReadSchemaHistoryResponse) GetEntries() []*SchemaHistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ReadSchemaHistoryResponse) GetAfterResultCursor() string {
+	if x != nil {
+		return x.AfterResultCursor
+	}
+	return ""
+}
+
+var File_schemahistory_v1_schemahistory_proto protoreflect.FileDescriptor
+
+const file_schemahistory_v1_schemahistory_proto_rawDesc = "" +
+	"\n" +
+	"$schemahistory/v1/schemahistory.proto\x12\x10schemahistory.v1\x1a\x12core/v1/core.proto\x1a\x17validate/validate.proto\"\xa9\x01\n" +
+	"\x18ReadSchemaHistoryRequest\x12)\n" +
+	"\voptional_at\x18\x01 \x01(\tB\b\xfaB\x05r\x03(\x80\bR\n" +
+	"optionalAt\x12/\n" +
+	"\x0eoptional_limit\x18\x02 \x01(\rB\b\xfaB\x05*\x03\x18\xe8\aR\roptionalLimit\x121\n" +
+	"\x0foptional_cursor\x18\x03 \x01(\tB\b\xfaB\x05r\x03(\x80\bR\x0eoptionalCursor\"f\n" +
+	"\x12SchemaHistoryEntry\x12\x1d\n" +
+	"\n" +
+	"written_at\x18\x01 \x01(\tR\twrittenAt\x121\n" +
+	"\x05entry\x18\x02 \x01(\v2\x1b.core.v1.SchemaHistoryEntryR\x05entry\"\xc5\x01\n" +
+	"\x19ReadSchemaHistoryResponse\x12\x1f\n" +
+	"\vschema_text\x18\x01 \x01(\tR\n" +
+	"schemaText\x12\x17\n" +
+	"\aread_at\x18\x02 \x01(\tR\x06readAt\x12>\n" +
+	"\aentries\x18\x03 \x03(\v2$.schemahistory.v1.SchemaHistoryEntryR\aentries\x12.\n" +
+	"\x13after_result_cursor\x18\x04 \x01(\tR\x11afterResultCursor2\x86\x01\n" +
+	"\x14SchemaHistoryService\x12n\n" +
+	"\x11ReadSchemaHistory\x12*.schemahistory.v1.ReadSchemaHistoryRequest\x1a+.schemahistory.v1.ReadSchemaHistoryResponse\"\x00B\xd2\x01\n" +
+	"\x14com.schemahistory.v1B\x12SchemahistoryProtoP\x01ZEgithub.com/authzed/spicedb/pkg/proto/schemahistory/v1;schemahistoryv1\xa2\x02\x03SXX\xaa\x02\x10Schemahistory.V1\xca\x02\x10Schemahistory\\V1\xe2\x02\x1cSchemahistory\\V1\\GPBMetadata\xea\x02\x11Schemahistory::V1b\x06proto3"
+
+var (
+	file_schemahistory_v1_schemahistory_proto_rawDescOnce sync.Once
+	file_schemahistory_v1_schemahistory_proto_rawDescData []byte
+)
+
+func file_schemahistory_v1_schemahistory_proto_rawDescGZIP() []byte {
+	file_schemahistory_v1_schemahistory_proto_rawDescOnce.Do(func() {
+		file_schemahistory_v1_schemahistory_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_schemahistory_v1_schemahistory_proto_rawDesc), len(file_schemahistory_v1_schemahistory_proto_rawDesc)))
+	})
+	return file_schemahistory_v1_schemahistory_proto_rawDescData
+}
+
+var file_schemahistory_v1_schemahistory_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_schemahistory_v1_schemahistory_proto_goTypes = []any{
+	(*ReadSchemaHistoryRequest)(nil),  // 0: schemahistory.v1.ReadSchemaHistoryRequest
+	(*SchemaHistoryEntry)(nil),        // 1: schemahistory.v1.SchemaHistoryEntry
+	(*ReadSchemaHistoryResponse)(nil), // 2: schemahistory.v1.ReadSchemaHistoryResponse
+	(*v1.SchemaHistoryEntry)(nil),     // 3: core.v1.SchemaHistoryEntry
+}
+var file_schemahistory_v1_schemahistory_proto_depIdxs = []int32{
+	3, // 0: schemahistory.v1.SchemaHistoryEntry.entry:type_name -> core.v1.SchemaHistoryEntry
+	1, // 1: schemahistory.v1.ReadSchemaHistoryResponse.entries:type_name -> schemahistory.v1.SchemaHistoryEntry
+	0, // 2: schemahistory.v1.SchemaHistoryService.ReadSchemaHistory:input_type -> schemahistory.v1.ReadSchemaHistoryRequest
+	2, // 3: schemahistory.v1.SchemaHistoryService.ReadSchemaHistory:output_type -> schemahistory.v1.ReadSchemaHistoryResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_schemahistory_v1_schemahistory_proto_init() }
+func file_schemahistory_v1_schemahistory_proto_init() {
+	if File_schemahistory_v1_schemahistory_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schemahistory_v1_schemahistory_proto_rawDesc), len(file_schemahistory_v1_schemahistory_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_schemahistory_v1_schemahistory_proto_goTypes,
+		DependencyIndexes: file_schemahistory_v1_schemahistory_proto_depIdxs,
+		MessageInfos:      file_schemahistory_v1_schemahistory_proto_msgTypes,
+	}.Build()
+	File_schemahistory_v1_schemahistory_proto = out.File
+	file_schemahistory_v1_schemahistory_proto_goTypes = nil
+	file_schemahistory_v1_schemahistory_proto_depIdxs = nil
+}
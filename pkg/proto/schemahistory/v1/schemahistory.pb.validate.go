@@ -0,0 +1,446 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: schemahistory/v1/schemahistory.proto
+
+package schemahistoryv1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on ReadSchemaHistoryRequest with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ReadSchemaHistoryRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReadSchemaHistoryRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReadSchemaHistoryRequestMultiError, or nil if none found.
+func (m *ReadSchemaHistoryRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReadSchemaHistoryRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetOptionalAt()) > 1024 {
+		err := ReadSchemaHistoryRequestValidationError{
+			field:  "OptionalAt",
+			reason: "value length must be at most 1024 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetOptionalLimit() > 1000 {
+		err := ReadSchemaHistoryRequestValidationError{
+			field:  "OptionalLimit",
+			reason: "value must be less than or equal to 1000",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetOptionalCursor()) > 1024 {
+		err := ReadSchemaHistoryRequestValidationError{
+			field:  "OptionalCursor",
+			reason: "value length must be at most 1024 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ReadSchemaHistoryRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReadSchemaHistoryRequestMultiError is an error wrapping multiple validation
+// errors returned by ReadSchemaHistoryRequest.ValidateAll() if the designated
+// constraints aren't met.
+type ReadSchemaHistoryRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReadSchemaHistoryRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReadSchemaHistoryRequestMultiError) AllErrors() []error { return m }
+
+// ReadSchemaHistoryRequestValidationError is the validation error returned by
+// ReadSchemaHistoryRequest.Validate if the designated constraints aren't met.
+type ReadSchemaHistoryRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReadSchemaHistoryRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReadSchemaHistoryRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReadSchemaHistoryRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReadSchemaHistoryRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReadSchemaHistoryRequestValidationError) ErrorName() string {
+	return "ReadSchemaHistoryRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReadSchemaHistoryRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReadSchemaHistoryRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReadSchemaHistoryRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReadSchemaHistoryRequestValidationError{}
+
+// Validate checks the field values on SchemaHistoryEntry with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SchemaHistoryEntry) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SchemaHistoryEntry with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SchemaHistoryEntryMultiError, or nil if none found.
+func (m *SchemaHistoryEntry) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SchemaHistoryEntry) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for WrittenAt
+
+	if all {
+		switch v := interface{}(m.GetEntry()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SchemaHistoryEntryValidationError{
+					field:  "Entry",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SchemaHistoryEntryValidationError{
+					field:  "Entry",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEntry()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SchemaHistoryEntryValidationError{
+				field:  "Entry",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return SchemaHistoryEntryMultiError(errors)
+	}
+
+	return nil
+}
+
+// SchemaHistoryEntryMultiError is an error wrapping multiple validation errors
+// returned by SchemaHistoryEntry.ValidateAll() if the designated constraints
+// aren't met.
+type SchemaHistoryEntryMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SchemaHistoryEntryMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SchemaHistoryEntryMultiError) AllErrors() []error { return m }
+
+// SchemaHistoryEntryValidationError is the validation error returned by
+// SchemaHistoryEntry.Validate if the designated constraints aren't met.
+type SchemaHistoryEntryValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SchemaHistoryEntryValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SchemaHistoryEntryValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SchemaHistoryEntryValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SchemaHistoryEntryValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SchemaHistoryEntryValidationError) ErrorName() string {
+	return "SchemaHistoryEntryValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SchemaHistoryEntryValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSchemaHistoryEntry.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SchemaHistoryEntryValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SchemaHistoryEntryValidationError{}
+
+// Validate checks the field values on ReadSchemaHistoryResponse with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ReadSchemaHistoryResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ReadSchemaHistoryResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ReadSchemaHistoryResponseMultiError, or nil if none found.
+func (m *ReadSchemaHistoryResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ReadSchemaHistoryResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SchemaText
+
+	// no validation rules for ReadAt
+
+	for idx, item := range m.GetEntries() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ReadSchemaHistoryResponseValidationError{
+						field:  fmt.Sprintf("Entries[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ReadSchemaHistoryResponseValidationError{
+						field:  fmt.Sprintf("Entries[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ReadSchemaHistoryResponseValidationError{
+					field:  fmt.Sprintf("Entries[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for AfterResultCursor
+
+	if len(errors) > 0 {
+		return ReadSchemaHistoryResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ReadSchemaHistoryResponseMultiError is an error wrapping multiple validation
+// errors returned by ReadSchemaHistoryResponse.ValidateAll() if the
+// designated constraints aren't met.
+type ReadSchemaHistoryResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ReadSchemaHistoryResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ReadSchemaHistoryResponseMultiError) AllErrors() []error { return m }
+
+// ReadSchemaHistoryResponseValidationError is the validation error returned by
+// ReadSchemaHistoryResponse.Validate if the designated constraints aren't met.
+type ReadSchemaHistoryResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ReadSchemaHistoryResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ReadSchemaHistoryResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ReadSchemaHistoryResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ReadSchemaHistoryResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ReadSchemaHistoryResponseValidationError) ErrorName() string {
+	return "ReadSchemaHistoryResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ReadSchemaHistoryResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sReadSchemaHistoryResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ReadSchemaHistoryResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ReadSchemaHistoryResponseValidationError{}
@@ -0,0 +1,131 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: schemahistory/v1/schemahistory.proto
+
+package schemahistoryv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SchemaHistoryService_ReadSchemaHistory_FullMethodName = "/schemahistory.v1.SchemaHistoryService/ReadSchemaHistory"
+)
+
+// SchemaHistoryServiceClient is the client API for SchemaHistoryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SchemaHistoryService reads the log of the schema writes made to the datastore, and the
+// schema as of past revisions.
+type SchemaHistoryServiceClient interface {
+	// ReadSchemaHistory returns the schema as of the requested revision, along with the
+	// entries of the schema history written at or before it, newest first.
+	ReadSchemaHistory(ctx context.Context, in *ReadSchemaHistoryRequest, opts ...grpc.CallOption) (*ReadSchemaHistoryResponse, error)
+}
+
+type schemaHistoryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSchemaHistoryServiceClient(cc grpc.ClientConnInterface) SchemaHistoryServiceClient {
+	return &schemaHistoryServiceClient{cc}
+}
+
+func (c *schemaHistoryServiceClient) ReadSchemaHistory(ctx context.Context, in *ReadSchemaHistoryRequest, opts ...grpc.CallOption) (*ReadSchemaHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReadSchemaHistoryResponse)
+	err := c.cc.Invoke(ctx, SchemaHistoryService_ReadSchemaHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SchemaHistoryServiceServer is the server API for SchemaHistoryService service.
+// All implementations must embed UnimplementedSchemaHistoryServiceServer
+// for forward compatibility.
+//
+// SchemaHistoryService reads the log of the schema writes made to the datastore, and the
+// schema as of past revisions.
+type SchemaHistoryServiceServer interface {
+	// ReadSchemaHistory returns the schema as of the requested revision, along with the
+	// entries of the schema history written at or before it, newest first.
+	ReadSchemaHistory(context.Context, *ReadSchemaHistoryRequest) (*ReadSchemaHistoryResponse, error)
+	mustEmbedUnimplementedSchemaHistoryServiceServer()
+}
+
+// UnimplementedSchemaHistoryServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSchemaHistoryServiceServer struct{}
+
+func (UnimplementedSchemaHistoryServiceServer) ReadSchemaHistory(context.Context, *ReadSchemaHistoryRequest) (*ReadSchemaHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReadSchemaHistory not implemented")
+}
+func (UnimplementedSchemaHistoryServiceServer) mustEmbedUnimplementedSchemaHistoryServiceServer() {}
+func (UnimplementedSchemaHistoryServiceServer) testEmbeddedByValue()                              {}
+
+// UnsafeSchemaHistoryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SchemaHistoryServiceServer will
+// result in compilation errors.
+type UnsafeSchemaHistoryServiceServer interface {
+	mustEmbedUnimplementedSchemaHistoryServiceServer()
+}
+
+func RegisterSchemaHistoryServiceServer(s grpc.ServiceRegistrar, srv SchemaHistoryServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSchemaHistoryServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SchemaHistoryService_ServiceDesc, srv)
+}
+
+func _SchemaHistoryService_ReadSchemaHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReadSchemaHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchemaHistoryServiceServer).ReadSchemaHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchemaHistoryService_ReadSchemaHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchemaHistoryServiceServer).ReadSchemaHistory(ctx, req.(*ReadSchemaHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SchemaHistoryService_ServiceDesc is the grpc.ServiceDesc for SchemaHistoryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SchemaHistoryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "schemahistory.v1.SchemaHistoryService",
+	HandlerType: (*SchemaHistoryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReadSchemaHistory",
+			Handler:    _SchemaHistoryService_ReadSchemaHistory_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "schemahistory/v1/schemahistory.proto",
+}
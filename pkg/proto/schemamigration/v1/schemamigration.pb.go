@@ -0,0 +1,783 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: schemamigration/v1/schemamigration.proto
+
+package schemamigrationv1
+
+import (
+	_ "github.com/envoyproxy/protoc-gen-validate/validate"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SchemaMigrationStep_Kind int32
+
+const (
+	SchemaMigrationStep_KIND_UNSPECIFIED SchemaMigrationStep_Kind = 0
+	// DELETE_RELATIONSHIPS deletes the matching relationships.
+	SchemaMigrationStep_DELETE_RELATIONSHIPS SchemaMigrationStep_Kind = 1
+	// REWRITE_RESOURCE_RELATION rewrites the relation of the matching relationships.
+	SchemaMigrationStep_REWRITE_RESOURCE_RELATION SchemaMigrationStep_Kind = 2
+	// REWRITE_SUBJECT_RELATION rewrites the relation of the subject of the matching
+	// relationships.
+	SchemaMigrationStep_REWRITE_SUBJECT_RELATION SchemaMigrationStep_Kind = 3
+	// REMOVE_CAVEAT_PARAMETER removes a parameter from the caveat context of the matching
+	// relationships.
+	SchemaMigrationStep_REMOVE_CAVEAT_PARAMETER SchemaMigrationStep_Kind = 4
+)
+
+// Enum value maps for SchemaMigrationStep_Kind.
+var (
+	SchemaMigrationStep_Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "DELETE_RELATIONSHIPS",
+		2: "REWRITE_RESOURCE_RELATION",
+		3: "REWRITE_SUBJECT_RELATION",
+		4: "REMOVE_CAVEAT_PARAMETER",
+	}
+	SchemaMigrationStep_Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED":          0,
+		"DELETE_RELATIONSHIPS":      1,
+		"REWRITE_RESOURCE_RELATION": 2,
+		"REWRITE_SUBJECT_RELATION":  3,
+		"REMOVE_CAVEAT_PARAMETER":   4,
+	}
+)
+
+func (x SchemaMigrationStep_Kind) Enum() *SchemaMigrationStep_Kind {
+	p := new(SchemaMigrationStep_Kind)
+	*p = x
+	return p
+}
+
+func (x SchemaMigrationStep_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SchemaMigrationStep_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_schemamigration_v1_schemamigration_proto_enumTypes[0].Descriptor()
+}
+
+func (SchemaMigrationStep_Kind) Type() protoreflect.EnumType {
+	return &file_schemamigration_v1_schemamigration_proto_enumTypes[0]
+}
+
+func (x SchemaMigrationStep_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SchemaMigrationStep_Kind.Descriptor instead.
+func (SchemaMigrationStep_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_schemamigration_v1_schemamigration_proto_rawDescGZIP(), []int{2, 0}
+}
+
+// RelationRewrite asks for the relationships of a relation removed by the target schema to
+// be rewritten to another relation of the same definition, rather than deleted.
+type RelationRewrite struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DefinitionName string                 `protobuf:"bytes,1,opt,name=definition_name,json=definitionName,proto3" json:"definition_name,omitempty"`
+	FromRelation   string                 `protobuf:"bytes,2,opt,name=from_relation,json=fromRelation,proto3" json:"from_relation,omitempty"`
+	ToRelation     string                 `protobuf:"bytes,3,opt,name=to_relation,json=toRelation,proto3" json:"to_relation,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RelationRewrite) Reset() {
+	*x = RelationRewrite{}
+	mi := &file_schemamigration_v1_schemamigration_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationRewrite) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationRewrite) ProtoMessage() {}
+
+func (x *RelationRewrite) ProtoReflect() protoreflect.Message {
+	mi := &file_schemamigration_v1_schemamigration_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationRewrite.ProtoReflect.Descriptor instead.
+func (*RelationRewrite) Descriptor() ([]byte, []int) {
+	return file_schemamigration_v1_schemamigration_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RelationRewrite) GetDefinitionName() string {
+	if x != nil {
+		return x.DefinitionName
+	}
+	return ""
+}
+
+func (x *RelationRewrite) GetFromRelation() string {
+	if x != nil {
+		return x.FromRelation
+	}
+	return ""
+}
+
+func (x *RelationRewrite) GetToRelation() string {
+	if x != nil {
+		return x.ToRelation
+	}
+	return ""
+}
+
+// RelationRename declares a relation of a definition as renamed by the target schema.
+type RelationRename struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DefinitionName string                 `protobuf:"bytes,1,opt,name=definition_name,json=definitionName,proto3" json:"definition_name,omitempty"`
+	PreviousName   string                 `protobuf:"bytes,2,opt,name=previous_name,json=previousName,proto3" json:"previous_name,omitempty"`
+	NewName        string                 `protobuf:"bytes,3,opt,name=new_name,json=newName,proto3" json:"new_name,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RelationRename) Reset() {
+	*x = RelationRename{}
+	mi := &file_schemamigration_v1_schemamigration_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationRename) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationRename) ProtoMessage() {}
+
+func (x *RelationRename) ProtoReflect() protoreflect.Message {
+	mi := &file_schemamigration_v1_schemamigration_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationRename.ProtoReflect.Descriptor instead.
+func (*RelationRename) Descriptor() ([]byte, []int) {
+	return file_schemamigration_v1_schemamigration_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RelationRename) GetDefinitionName() string {
+	if x != nil {
+		return x.DefinitionName
+	}
+	return ""
+}
+
+func (x *RelationRename) GetPreviousName() string {
+	if x != nil {
+		return x.PreviousName
+	}
+	return ""
+}
+
+func (x *RelationRename) GetNewName() string {
+	if x != nil {
+		return x.NewName
+	}
+	return ""
+}
+
+// SchemaMigrationStep is a single change to the stored relationships in a migration plan.
+type SchemaMigrationStep struct {
+	state protoimpl.MessageState   `protogen:"open.v1"`
+	Kind  SchemaMigrationStep_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=schemamigration.v1.SchemaMigrationStep_Kind" json:"kind,omitempty"`
+	// description describes the step and the relationships it changes.
+	Description string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	// affected_relationship_count is the number of relationships currently matched by the
+	// step, if requested.
+	AffectedRelationshipCount uint64 `protobuf:"varint,3,opt,name=affected_relationship_count,json=affectedRelationshipCount,proto3" json:"affected_relationship_count,omitempty"`
+	unknownFields             protoimpl.UnknownFields
+	sizeCache                 protoimpl.SizeCache
+}
+
+func (x *SchemaMigrationStep) Reset() {
+	*x = SchemaMigrationStep{}
+	mi := &file_schemamigration_v1_schemamigration_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaMigrationStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaMigrationStep) ProtoMessage() {}
+
+func (x *SchemaMigrationStep) ProtoReflect() protoreflect.Message {
+	mi := &file_schemamigration_v1_schemamigration_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaMigrationStep.ProtoReflect.Descriptor instead.
+func (*SchemaMigrationStep) Descriptor() ([]byte, []int) {
+	return file_schemamigration_v1_schemamigration_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SchemaMigrationStep) GetKind() SchemaMigrationStep_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return SchemaMigrationStep_KIND_UNSPECIFIED
+}
+
+func (x *SchemaMigrationStep) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SchemaMigrationStep) GetAffectedRelationshipCount() uint64 {
+	if x != nil {
+		return x.AffectedRelationshipCount
+	}
+	return 0
+}
+
+type PlanSchemaMigrationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// schema is the target schema, in the schema language.
+	Schema           string             `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	RelationRewrites []*RelationRewrite `protobuf:"bytes,2,rep,name=relation_rewrites,json=relationRewrites,proto3" json:"relation_rewrites,omitempty"`
+	// count_affected_relationships, if true, asks for the relationships affected by each step
+	// to be counted, as a dry run of the migration.
+	CountAffectedRelationships bool `protobuf:"varint,3,opt,name=count_affected_relationships,json=countAffectedRelationships,proto3" json:"count_affected_relationships,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
+}
+
+func (x *PlanSchemaMigrationRequest) Reset() {
+	*x = PlanSchemaMigrationRequest{}
+	mi := &file_schemamigration_v1_schemamigration_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanSchemaMigrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanSchemaMigrationRequest) ProtoMessage() {}
+
+func (x *PlanSchemaMigrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemamigration_v1_schemamigration_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanSchemaMigrationRequest.ProtoReflect.Descriptor instead.
+func (*PlanSchemaMigrationRequest) Descriptor() ([]byte, []int) {
+	return file_schemamigration_v1_schemamigration_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PlanSchemaMigrationRequest) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *PlanSchemaMigrationRequest) GetRelationRewrites() []*RelationRewrite {
+	if x != nil {
+		return x.RelationRewrites
+	}
+	return nil
+}
+
+func (x *PlanSchemaMigrationRequest) GetCountAffectedRelationships() bool {
+	if x != nil {
+		return x.CountAffectedRelationships
+	}
+	return false
+}
+
+type PlanSchemaMigrationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// steps are the steps of the migration, in the order in which they are executed. If
+	// empty, the target schema can be written without any migration.
+	Steps []*SchemaMigrationStep `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+	// blocking_changes describe the changes of the target schema which cannot be migrated,
+	// and will cause its write to fail if data exists for them.
+	BlockingChanges []string `protobuf:"bytes,2,rep,name=blocking_changes,json=blockingChanges,proto3" json:"blocking_changes,omitempty"`
+	// planned_at is the ZedToken of the revision at which the plan was computed.
+	PlannedAt     string `protobuf:"bytes,3,opt,name=planned_at,json=plannedAt,proto3" json:"planned_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanSchemaMigrationResponse) Reset() {
+	*x = PlanSchemaMigrationResponse{}
+	mi := &file_schemamigration_v1_schemamigration_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanSchemaMigrationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanSchemaMigrationResponse) ProtoMessage() {}
+
+func (x *PlanSchemaMigrationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemamigration_v1_schemamigration_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanSchemaMigrationResponse.ProtoReflect.Descriptor instead.
+func (*PlanSchemaMigrationResponse) Descriptor() ([]byte, []int) {
+	return file_schemamigration_v1_schemamigration_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PlanSchemaMigrationResponse) GetSteps() []*SchemaMigrationStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *PlanSchemaMigrationResponse) GetBlockingChanges() []string {
+	if x != nil {
+		return x.BlockingChanges
+	}
+	return nil
+}
+
+func (x *PlanSchemaMigrationResponse) GetPlannedAt() string {
+	if x != nil {
+		return x.PlannedAt
+	}
+	return ""
+}
+
+type ExecuteSchemaMigrationRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// schema is the target schema, in the schema language.
+	Schema           string             `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	RelationRewrites []*RelationRewrite `protobuf:"bytes,2,rep,name=relation_rewrites,json=relationRewrites,proto3" json:"relation_rewrites,omitempty"`
+	// batch_size is the maximum number of relationships to change in this call, in a single
+	// transaction. Defaults to 1000.
+	BatchSize uint32 `protobuf:"varint,3,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	// optional_cursor is the cursor returned by the previous call, if any. It is only valid
+	// for the same target schema and relation rewrites.
+	OptionalCursor string `protobuf:"bytes,4,opt,name=optional_cursor,json=optionalCursor,proto3" json:"optional_cursor,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ExecuteSchemaMigrationRequest) Reset() {
+	*x = ExecuteSchemaMigrationRequest{}
+	mi := &file_schemamigration_v1_schemamigration_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteSchemaMigrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteSchemaMigrationRequest) ProtoMessage() {}
+
+func (x *ExecuteSchemaMigrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemamigration_v1_schemamigration_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteSchemaMigrationRequest.ProtoReflect.Descriptor instead.
+func (*ExecuteSchemaMigrationRequest) Descriptor() ([]byte, []int) {
+	return file_schemamigration_v1_schemamigration_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExecuteSchemaMigrationRequest) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *ExecuteSchemaMigrationRequest) GetRelationRewrites() []*RelationRewrite {
+	if x != nil {
+		return x.RelationRewrites
+	}
+	return nil
+}
+
+func (x *ExecuteSchemaMigrationRequest) GetBatchSize() uint32 {
+	if x != nil {
+		return x.BatchSize
+	}
+	return 0
+}
+
+func (x *ExecuteSchemaMigrationRequest) GetOptionalCursor() string {
+	if x != nil {
+		return x.OptionalCursor
+	}
+	return ""
+}
+
+type ExecuteSchemaMigrationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// relationships_deleted is the number of relationships deleted by this call.
+	RelationshipsDeleted uint64 `protobuf:"varint,1,opt,name=relationships_deleted,json=relationshipsDeleted,proto3" json:"relationships_deleted,omitempty"`
+	// relationships_rewritten is the number of relationships rewritten by this call.
+	RelationshipsRewritten uint64 `protobuf:"varint,2,opt,name=relationships_rewritten,json=relationshipsRewritten,proto3" json:"relationships_rewritten,omitempty"`
+	// completed is true once every step of the migration has been executed.
+	Completed bool `protobuf:"varint,3,opt,name=completed,proto3" json:"completed,omitempty"`
+	// after_result_cursor is the cursor with which to execute the next batch, if not completed.
+	AfterResultCursor string `protobuf:"bytes,4,opt,name=after_result_cursor,json=afterResultCursor,proto3" json:"after_result_cursor,omitempty"`
+	// written_at is the ZedToken of the revision at which the changes of this call were
+	// written.
+	WrittenAt     string `protobuf:"bytes,5,opt,name=written_at,json=writtenAt,proto3" json:"written_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExecuteSchemaMigrationResponse) Reset() {
+	*x = ExecuteSchemaMigrationResponse{}
+	mi := &file_schemamigration_v1_schemamigration_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExecuteSchemaMigrationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecuteSchemaMigrationResponse) ProtoMessage() {}
+
+func (x *ExecuteSchemaMigrationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemamigration_v1_schemamigration_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecuteSchemaMigrationResponse.ProtoReflect.Descriptor instead.
+func (*ExecuteSchemaMigrationResponse) Descriptor() ([]byte, []int) {
+	return file_schemamigration_v1_schemamigration_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ExecuteSchemaMigrationResponse) GetRelationshipsDeleted() uint64 {
+	if x != nil {
+		return x.RelationshipsDeleted
+	}
+	return 0
+}
+
+func (x *ExecuteSchemaMigrationResponse) GetRelationshipsRewritten() uint64 {
+	if x != nil {
+		return x.RelationshipsRewritten
+	}
+	return 0
+}
+
+func (x *ExecuteSchemaMigrationResponse) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+func (x *ExecuteSchemaMigrationResponse) GetAfterResultCursor() string {
+	if x != nil {
+		return x.AfterResultCursor
+	}
+	return ""
+}
+
+func (x *ExecuteSchemaMigrationResponse) GetWrittenAt() string {
+	if x != nil {
+		return x.WrittenAt
+	}
+	return ""
+}
+
+type WriteSchemaWithRelationRenamesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// schema is the target schema, in the schema language.
+	Schema          string            `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	RelationRenames []*RelationRename `protobuf:"bytes,2,rep,name=relation_renames,json=relationRenames,proto3" json:"relation_renames,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *WriteSchemaWithRelationRenamesRequest) Reset() {
+	*x = WriteSchemaWithRelationRenamesRequest{}
+	mi := &file_schemamigration_v1_schemamigration_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteSchemaWithRelationRenamesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteSchemaWithRelationRenamesRequest) ProtoMessage() {}
+
+func (x *WriteSchemaWithRelationRenamesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_schemamigration_v1_schemamigration_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteSchemaWithRelationRenamesRequest.ProtoReflect.Descriptor instead.
+func (*WriteSchemaWithRelationRenamesRequest) Descriptor() ([]byte, []int) {
+	return file_schemamigration_v1_schemamigration_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WriteSchemaWithRelationRenamesRequest) GetSchema() string {
+	if x != nil {
+		return x.Schema
+	}
+	return ""
+}
+
+func (x *WriteSchemaWithRelationRenamesRequest) GetRelationRenames() []*RelationRename {
+	if x != nil {
+		return x.RelationRenames
+	}
+	return nil
+}
+
+type WriteSchemaWithRelationRenamesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// written_at is the ZedToken of the revision at which the schema was written.
+	WrittenAt     string `protobuf:"bytes,1,opt,name=written_at,json=writtenAt,proto3" json:"written_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteSchemaWithRelationRenamesResponse) Reset() {
+	*x = WriteSchemaWithRelationRenamesResponse{}
+	mi := &file_schemamigration_v1_schemamigration_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteSchemaWithRelationRenamesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteSchemaWithRelationRenamesResponse) ProtoMessage() {}
+
+func (x *WriteSchemaWithRelationRenamesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_schemamigration_v1_schemamigration_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteSchemaWithRelationRenamesResponse.ProtoReflect.Descriptor instead.
+func (*WriteSchemaWithRelationRenamesResponse) Descriptor() ([]byte, []int) {
+	return file_schemamigration_v1_schemamigration_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WriteSchemaWithRelationRenamesResponse) GetWrittenAt() string {
+	if x != nil {
+		return x.WrittenAt
+	}
+	return ""
+}
+
+var File_schemamigration_v1_schemamigration_proto protoreflect.FileDescriptor
+
+const file_schemamigration_v1_schemamigration_proto_rawDesc = "" +
+	"\n" +
+	"(schemamigration/v1/schemamigration.proto\x12\x12schemamigration.v1\x1a\x17validate/validate.proto\"\x9c\x02\n" +
+	"\x0fRelationRewrite\x12q\n" +
+	"\x0fdefinition_name\x18\x01 \x01(\tBH\xfaBErC(\x80\x012>^([a-z][a-z0-9_]{1,61}[a-z0-9]/)*[a-z][a-z0-9_]{1,62}[a-z0-9]$R\x0edefinitionName\x12L\n" +
+	"\rfrom_relation\x18\x02 \x01(\tB'\xfaB$r\"(@2\x1e^[a-z][a-z0-9_]{1,62}[a-z0-9]$R\ffromRelation\x12H\n" +
+	"\vto_relation\x18\x03 \x01(\tB'\xfaB$r\"(@2\x1e^[a-z][a-z0-9_]{1,62}[a-z0-9]$R\n" +
+	"toRelation\"\x95\x02\n" +
+	"\x0eRelationRename\x12q\n" +
+	"\x0fdefinition_name\x18\x01 \x01(\tBH\xfaBErC(\x80\x012>^([a-z][a-z0-9_]{1,61}[a-z0-9]/)*[a-z][a-z0-9_]{1,62}[a-z0-9]$R\x0edefinitionName\x12L\n" +
+	"\rprevious_name\x18\x02 \x01(\tB'\xfaB$r\"(@2\x1e^[a-z][a-z0-9_]{1,62}[a-z0-9]$R\fpreviousName\x12B\n" +
+	"\bnew_name\x18\x03 \x01(\tB'\xfaB$r\"(@2\x1e^[a-z][a-z0-9_]{1,62}[a-z0-9]$R\anewName\"\xcc\x02\n" +
+	"\x13SchemaMigrationStep\x12@\n" +
+	"\x04kind\x18\x01 \x01(\x0e2,.schemamigration.v1.SchemaMigrationStep.KindR\x04kind\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12>\n" +
+	"\x1baffected_relationship_count\x18\x03 \x01(\x04R\x19affectedRelationshipCount\"\x90\x01\n" +
+	"\x04Kind\x12\x14\n" +
+	"\x10KIND_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14DELETE_RELATIONSHIPS\x10\x01\x12\x1d\n" +
+	"\x19REWRITE_RESOURCE_RELATION\x10\x02\x12\x1c\n" +
+	"\x18REWRITE_SUBJECT_RELATION\x10\x03\x12\x1b\n" +
+	"\x17REMOVE_CAVEAT_PARAMETER\x10\x04\"\xd4\x01\n" +
+	"\x1aPlanSchemaMigrationRequest\x12\"\n" +
+	"\x06schema\x18\x01 \x01(\tB\n" +
+	"\xfaB\ar\x05(\x80\x80\x80\x02R\x06schema\x12P\n" +
+	"\x11relation_rewrites\x18\x02 \x03(\v2#.schemamigration.v1.RelationRewriteR\x10relationRewrites\x12@\n" +
+	"\x1ccount_affected_relationships\x18\x03 \x01(\bR\x1acountAffectedRelationships\"\xa6\x01\n" +
+	"\x1bPlanSchemaMigrationResponse\x12=\n" +
+	"\x05steps\x18\x01 \x03(\v2'.schemamigration.v1.SchemaMigrationStepR\x05steps\x12)\n" +
+	"\x10blocking_changes\x18\x02 \x03(\tR\x0fblockingChanges\x12\x1d\n" +
+	"\n" +
+	"planned_at\x18\x03 \x01(\tR\tplannedAt\"\xf2\x01\n" +
+	"\x1dExecuteSchemaMigrationRequest\x12\"\n" +
+	"\x06schema\x18\x01 \x01(\tB\n" +
+	"\xfaB\ar\x05(\x80\x80\x80\x02R\x06schema\x12P\n" +
+	"\x11relation_rewrites\x18\x02 \x03(\v2#.schemamigration.v1.RelationRewriteR\x10relationRewrites\x12'\n" +
+	"\n" +
+	"batch_size\x18\x03 \x01(\rB\b\xfaB\x05*\x03\x18\x90NR\tbatchSize\x122\n" +
+	"\x0foptional_cursor\x18\x04 \x01(\tB\t\xfaB\x06r\x04(\x80\xa0\x06R\x0eoptionalCursor\"\xfb\x01\n" +
+	"\x1eExecuteSchemaMigrationResponse\x123\n" +
+	"\x15relationships_deleted\x18\x01 \x01(\x04R\x14relationshipsDeleted\x127\n" +
+	"\x17relationships_rewritten\x18\x02 \x01(\x04R\x16relationshipsRewritten\x12\x1c\n" +
+	"\tcompleted\x18\x03 \x01(\bR\tcompleted\x12.\n" +
+	"\x13after_result_cursor\x18\x04 \x01(\tR\x11afterResultCursor\x12\x1d\n" +
+	"\n" +
+	"written_at\x18\x05 \x01(\tR\twrittenAt\"\xa4\x01\n" +
+	"%WriteSchemaWithRelationRenamesRequest\x12\"\n" +
+	"\x06schema\x18\x01 \x01(\tB\n" +
+	"\xfaB\ar\x05(\x80\x80\x80\x02R\x06schema\x12W\n" +
+	"\x10relation_renames\x18\x02 \x03(\v2\".schemamigration.v1.RelationRenameB\b\xfaB\x05\x92\x01\x02\b\x01R\x0frelationRenames\"G\n" +
+	"&WriteSchemaWithRelationRenamesResponse\x12\x1d\n" +
+	"\n" +
+	"written_at\x18\x01 \x01(\tR\twrittenAt2\xb2\x03\n" +
+	"\x16SchemaMigrationService\x12x\n" +
+	"\x13PlanSchemaMigration\x12..schemamigration.v1.PlanSchemaMigrationRequest\x1a/.schemamigration.v1.PlanSchemaMigrationResponse\"\x00\x12\x81\x01\n" +
+	"\x16ExecuteSchemaMigration\x121.schemamigration.v1.ExecuteSchemaMigrationRequest\x1a2.schemamigration.v1.ExecuteSchemaMigrationResponse\"\x00\x12\x99\x01\n" +
+	"\x1eWriteSchemaWithRelationRenames\x129.schemamigration.v1.WriteSchemaWithRelationRenamesRequest\x1a:.schemamigration.v1.WriteSchemaWithRelationRenamesResponse\"\x00B\xe2\x01\n" +
+	"\x16com.schemamigration.v1B\x14SchemamigrationProtoP\x01ZIgithub.com/authzed/spicedb/pkg/proto/schemamigration/v1;schemamigrationv1\xa2\x02\x03SXX\xaa\x02\x12Schemamigration.V1\xca\x02\x12Schemamigration\\V1\xe2\x02\x1eSchemamigration\\V1\\GPBMetadata\xea\x02\x13Schemamigration::V1b\x06proto3"
+
+var (
+	file_schemamigration_v1_schemamigration_proto_rawDescOnce sync.Once
+	file_schemamigration_v1_schemamigration_proto_rawDescData []byte
+)
+
+func file_schemamigration_v1_schemamigration_proto_rawDescGZIP() []byte {
+	file_schemamigration_v1_schemamigration_proto_rawDescOnce.Do(func() {
+		file_schemamigration_v1_schemamigration_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_schemamigration_v1_schemamigration_proto_rawDesc), len(file_schemamigration_v1_schemamigration_proto_rawDesc)))
+	})
+	return file_schemamigration_v1_schemamigration_proto_rawDescData
+}
+
+var file_schemamigration_v1_schemamigration_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_schemamigration_v1_schemamigration_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_schemamigration_v1_schemamigration_proto_goTypes = []any{
+	(SchemaMigrationStep_Kind)(0),                  // 0: schemamigration.v1.SchemaMigrationStep.Kind
+	(*RelationRewrite)(nil),                        // 1: schemamigration.v1.RelationRewrite
+	(*RelationRename)(nil),                         // 2: schemamigration.v1.RelationRename
+	(*SchemaMigrationStep)(nil),                    // 3: schemamigration.v1.SchemaMigrationStep
+	(*PlanSchemaMigrationRequest)(nil),             // 4: schemamigration.v1.PlanSchemaMigrationRequest
+	(*PlanSchemaMigrationResponse)(nil),            // 5: schemamigration.v1.PlanSchemaMigrationResponse
+	(*ExecuteSchemaMigrationRequest)(nil),          // 6: schemamigration.v1.ExecuteSchemaMigrationRequest
+	(*ExecuteSchemaMigrationResponse)(nil),         // 7: schemamigration.v1.ExecuteSchemaMigrationResponse
+	(*WriteSchemaWithRelationRenamesRequest)(nil),  // 8: schemamigration.v1.WriteSchemaWithRelationRenamesRequest
+	(*WriteSchemaWithRelationRenamesResponse)(nil), // 9: schemamigration.v1.WriteSchemaWithRelationRenamesResponse
+}
+var file_schemamigration_v1_schemamigration_proto_depIdxs = []int32{
+	0, // 0: schemamigration.v1.SchemaMigrationStep.kind:type_name -> schemamigration.v1.SchemaMigrationStep.Kind
+	1, // 1: schemamigration.v1.PlanSchemaMigrationRequest.relation_rewrites:type_name -> schemamigration.v1.RelationRewrite
+	3, // 2: schemamigration.v1.PlanSchemaMigrationResponse.steps:type_name -> schemamigration.v1.SchemaMigrationStep
+	1, // 3: schemamigration.v1.ExecuteSchemaMigrationRequest.relation_rewrites:type_name -> schemamigration.v1.RelationRewrite
+	2, // 4: schemamigration.v1.WriteSchemaWithRelationRenamesRequest.relation_renames:type_name -> schemamigration.v1.RelationRename
+	4, // 5: schemamigration.v1.SchemaMigrationService.PlanSchemaMigration:input_type -> schemamigration.v1.PlanSchemaMigrationRequest
+	6, // 6: schemamigration.v1.SchemaMigrationService.ExecuteSchemaMigration:input_type -> schemamigration.v1.ExecuteSchemaMigrationRequest
+	8, // 7: schemamigration.v1.SchemaMigrationService.WriteSchemaWithRelationRenames:input_type -> schemamigration.v1.WriteSchemaWithRelationRenamesRequest
+	5, // 8: schemamigration.v1.SchemaMigrationService.PlanSchemaMigration:output_type -> schemamigration.v1.PlanSchemaMigrationResponse
+	7, // 9: schemamigration.v1.SchemaMigrationService.ExecuteSchemaMigration:output_type -> schemamigration.v1.ExecuteSchemaMigrationResponse
+	9, // 10: schemamigration.v1.SchemaMigrationService.WriteSchemaWithRelationRenames:output_type -> schemamigration.v1.WriteSchemaWithRelationRenamesResponse
+	8, // [8:11] is the sub-list for method output_type
+	5, // [5:8] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_schemamigration_v1_schemamigration_proto_init() }
+func file_schemamigration_v1_schemamigration_proto_init() {
+	if File_schemamigration_v1_schemamigration_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_schemamigration_v1_schemamigration_proto_rawDesc), len(file_schemamigration_v1_schemamigration_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_schemamigration_v1_schemamigration_proto_goTypes,
+		DependencyIndexes: file_schemamigration_v1_schemamigration_proto_depIdxs,
+		EnumInfos:         file_schemamigration_v1_schemamigration_proto_enumTypes,
+		MessageInfos:      file_schemamigration_v1_schemamigration_proto_msgTypes,
+	}.Build()
+	File_schemamigration_v1_schemamigration_proto = out.File
+	file_schemamigration_v1_schemamigration_proto_goTypes = nil
+	file_schemamigration_v1_schemamigration_proto_depIdxs = nil
+}
@@ -0,0 +1,1328 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: schemamigration/v1/schemamigration.proto
+
+package schemamigrationv1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on RelationRewrite with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *RelationRewrite) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RelationRewrite with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RelationRewriteMultiError, or nil if none found.
+func (m *RelationRewrite) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RelationRewrite) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetDefinitionName()) > 128 {
+		err := RelationRewriteValidationError{
+			field:  "DefinitionName",
+			reason: "value length must be at most 128 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_RelationRewrite_DefinitionName_Pattern.MatchString(m.GetDefinitionName()) {
+		err := RelationRewriteValidationError{
+			field:  "DefinitionName",
+			reason: "value does not match regex pattern \"^([a-z][a-z0-9_]{1,61}[a-z0-9]/)*[a-z][a-z0-9_]{1,62}[a-z0-9]$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetFromRelation()) > 64 {
+		err := RelationRewriteValidationError{
+			field:  "FromRelation",
+			reason: "value length must be at most 64 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_RelationRewrite_FromRelation_Pattern.MatchString(m.GetFromRelation()) {
+		err := RelationRewriteValidationError{
+			field:  "FromRelation",
+			reason: "value does not match regex pattern \"^[a-z][a-z0-9_]{1,62}[a-z0-9]$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetToRelation()) > 64 {
+		err := RelationRewriteValidationError{
+			field:  "ToRelation",
+			reason: "value length must be at most 64 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_RelationRewrite_ToRelation_Pattern.MatchString(m.GetToRelation()) {
+		err := RelationRewriteValidationError{
+			field:  "ToRelation",
+			reason: "value does not match regex pattern \"^[a-z][a-z0-9_]{1,62}[a-z0-9]$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RelationRewriteMultiError(errors)
+	}
+
+	return nil
+}
+
+// RelationRewriteMultiError is an error wrapping multiple validation errors
+// returned by RelationRewrite.ValidateAll() if the designated constraints
+// aren't met.
+type RelationRewriteMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RelationRewriteMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RelationRewriteMultiError) AllErrors() []error { return m }
+
+// RelationRewriteValidationError is the validation error returned by
+// RelationRewrite.Validate if the designated constraints aren't met.
+type RelationRewriteValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RelationRewriteValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RelationRewriteValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RelationRewriteValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RelationRewriteValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RelationRewriteValidationError) ErrorName() string { return "RelationRewriteValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RelationRewriteValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRelationRewrite.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RelationRewriteValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RelationRewriteValidationError{}
+
+var _RelationRewrite_DefinitionName_Pattern = regexp.MustCompile("^([a-z][a-z0-9_]{1,61}[a-z0-9]/)*[a-z][a-z0-9_]{1,62}[a-z0-9]$")
+
+var _RelationRewrite_FromRelation_Pattern = regexp.MustCompile("^[a-z][a-z0-9_]{1,62}[a-z0-9]$")
+
+var _RelationRewrite_ToRelation_Pattern = regexp.MustCompile("^[a-z][a-z0-9_]{1,62}[a-z0-9]$")
+
+// Validate checks the field values on RelationRename with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *RelationRename) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RelationRename with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in RelationRenameMultiError,
+// or nil if none found.
+func (m *RelationRename) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RelationRename) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetDefinitionName()) > 128 {
+		err := RelationRenameValidationError{
+			field:  "DefinitionName",
+			reason: "value length must be at most 128 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_RelationRename_DefinitionName_Pattern.MatchString(m.GetDefinitionName()) {
+		err := RelationRenameValidationError{
+			field:  "DefinitionName",
+			reason: "value does not match regex pattern \"^([a-z][a-z0-9_]{1,61}[a-z0-9]/)*[a-z][a-z0-9_]{1,62}[a-z0-9]$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetPreviousName()) > 64 {
+		err := RelationRenameValidationError{
+			field:  "PreviousName",
+			reason: "value length must be at most 64 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_RelationRename_PreviousName_Pattern.MatchString(m.GetPreviousName()) {
+		err := RelationRenameValidationError{
+			field:  "PreviousName",
+			reason: "value does not match regex pattern \"^[a-z][a-z0-9_]{1,62}[a-z0-9]$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetNewName()) > 64 {
+		err := RelationRenameValidationError{
+			field:  "NewName",
+			reason: "value length must be at most 64 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_RelationRename_NewName_Pattern.MatchString(m.GetNewName()) {
+		err := RelationRenameValidationError{
+			field:  "NewName",
+			reason: "value does not match regex pattern \"^[a-z][a-z0-9_]{1,62}[a-z0-9]$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RelationRenameMultiError(errors)
+	}
+
+	return nil
+}
+
+// RelationRenameMultiError is an error wrapping multiple validation errors
+// returned by RelationRename.ValidateAll() if the designated constraints
+// aren't met.
+type RelationRenameMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RelationRenameMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RelationRenameMultiError) AllErrors() []error { return m }
+
+// RelationRenameValidationError is the validation error returned by
+// RelationRename.Validate if the designated constraints aren't met.
+type RelationRenameValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RelationRenameValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RelationRenameValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RelationRenameValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RelationRenameValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RelationRenameValidationError) ErrorName() string { return "RelationRenameValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RelationRenameValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRelationRename.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RelationRenameValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RelationRenameValidationError{}
+
+var _RelationRename_DefinitionName_Pattern = regexp.MustCompile("^([a-z][a-z0-9_]{1,61}[a-z0-9]/)*[a-z][a-z0-9_]{1,62}[a-z0-9]$")
+
+var _RelationRename_PreviousName_Pattern = regexp.MustCompile("^[a-z][a-z0-9_]{1,62}[a-z0-9]$")
+
+var _RelationRename_NewName_Pattern = regexp.MustCompile("^[a-z][a-z0-9_]{1,62}[a-z0-9]$")
+
+// Validate checks the field values on SchemaMigrationStep with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *SchemaMigrationStep) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on SchemaMigrationStep with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SchemaMigrationStepMultiError, or nil if none found.
+func (m *SchemaMigrationStep) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *SchemaMigrationStep) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Kind
+
+	// no validation rules for Description
+
+	// no validation rules for AffectedRelationshipCount
+
+	if len(errors) > 0 {
+		return SchemaMigrationStepMultiError(errors)
+	}
+
+	return nil
+}
+
+// SchemaMigrationStepMultiError is an error wrapping multiple validation
+// errors returned by SchemaMigrationStep.ValidateAll() if the designated
+// constraints aren't met.
+type SchemaMigrationStepMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m SchemaMigrationStepMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m SchemaMigrationStepMultiError) AllErrors() []error { return m }
+
+// SchemaMigrationStepValidationError is the validation error returned by
+// SchemaMigrationStep.Validate if the designated constraints aren't met.
+type SchemaMigrationStepValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e SchemaMigrationStepValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e SchemaMigrationStepValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e SchemaMigrationStepValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e SchemaMigrationStepValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e SchemaMigrationStepValidationError) ErrorName() string {
+	return "SchemaMigrationStepValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e SchemaMigrationStepValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sSchemaMigrationStep.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = SchemaMigrationStepValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = SchemaMigrationStepValidationError{}
+
+// Validate checks the field values on PlanSchemaMigrationRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PlanSchemaMigrationRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PlanSchemaMigrationRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PlanSchemaMigrationRequestMultiError, or nil if none found.
+func (m *PlanSchemaMigrationRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PlanSchemaMigrationRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetSchema()) > 4194304 {
+		err := PlanSchemaMigrationRequestValidationError{
+			field:  "Schema",
+			reason: "value length must be at most 4194304 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetRelationRewrites() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, PlanSchemaMigrationRequestValidationError{
+						field:  fmt.Sprintf("RelationRewrites[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, PlanSchemaMigrationRequestValidationError{
+						field:  fmt.Sprintf("RelationRewrites[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return PlanSchemaMigrationRequestValidationError{
+					field:  fmt.Sprintf("RelationRewrites[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for CountAffectedRelationships
+
+	if len(errors) > 0 {
+		return PlanSchemaMigrationRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// PlanSchemaMigrationRequestMultiError is an error wrapping multiple
+// validation errors returned by PlanSchemaMigrationRequest.ValidateAll() if
+// the designated constraints aren't met.
+type PlanSchemaMigrationRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PlanSchemaMigrationRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PlanSchemaMigrationRequestMultiError) AllErrors() []error { return m }
+
+// PlanSchemaMigrationRequestValidationError is the validation error returned
+// by PlanSchemaMigrationRequest.Validate if the designated constraints aren't met.
+type PlanSchemaMigrationRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PlanSchemaMigrationRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PlanSchemaMigrationRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PlanSchemaMigrationRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PlanSchemaMigrationRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PlanSchemaMigrationRequestValidationError) ErrorName() string {
+	return "PlanSchemaMigrationRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PlanSchemaMigrationRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPlanSchemaMigrationRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PlanSchemaMigrationRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PlanSchemaMigrationRequestValidationError{}
+
+// Validate checks the field values on PlanSchemaMigrationResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *PlanSchemaMigrationResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on PlanSchemaMigrationResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// PlanSchemaMigrationResponseMultiError, or nil if none found.
+func (m *PlanSchemaMigrationResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *PlanSchemaMigrationResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetSteps() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, PlanSchemaMigrationResponseValidationError{
+						field:  fmt.Sprintf("Steps[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, PlanSchemaMigrationResponseValidationError{
+						field:  fmt.Sprintf("Steps[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return PlanSchemaMigrationResponseValidationError{
+					field:  fmt.Sprintf("Steps[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for PlannedAt
+
+	if len(errors) > 0 {
+		return PlanSchemaMigrationResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// PlanSchemaMigrationResponseMultiError is an error wrapping multiple
+// validation errors returned by PlanSchemaMigrationResponse.ValidateAll() if
+// the designated constraints aren't met.
+type PlanSchemaMigrationResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m PlanSchemaMigrationResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m PlanSchemaMigrationResponseMultiError) AllErrors() []error { return m }
+
+// PlanSchemaMigrationResponseValidationError is the validation error returned
+// by PlanSchemaMigrationResponse.Validate if the designated constraints
+// aren't met.
+type PlanSchemaMigrationResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e PlanSchemaMigrationResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e PlanSchemaMigrationResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e PlanSchemaMigrationResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e PlanSchemaMigrationResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e PlanSchemaMigrationResponseValidationError) ErrorName() string {
+	return "PlanSchemaMigrationResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e PlanSchemaMigrationResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sPlanSchemaMigrationResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = PlanSchemaMigrationResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = PlanSchemaMigrationResponseValidationError{}
+
+// Validate checks the field values on ExecuteSchemaMigrationRequest with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ExecuteSchemaMigrationRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExecuteSchemaMigrationRequest with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ExecuteSchemaMigrationRequestMultiError, or nil if none found.
+func (m *ExecuteSchemaMigrationRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExecuteSchemaMigrationRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetSchema()) > 4194304 {
+		err := ExecuteSchemaMigrationRequestValidationError{
+			field:  "Schema",
+			reason: "value length must be at most 4194304 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetRelationRewrites() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ExecuteSchemaMigrationRequestValidationError{
+						field:  fmt.Sprintf("RelationRewrites[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ExecuteSchemaMigrationRequestValidationError{
+						field:  fmt.Sprintf("RelationRewrites[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ExecuteSchemaMigrationRequestValidationError{
+					field:  fmt.Sprintf("RelationRewrites[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if m.GetBatchSize() > 10000 {
+		err := ExecuteSchemaMigrationRequestValidationError{
+			field:  "BatchSize",
+			reason: "value must be less than or equal to 10000",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetOptionalCursor()) > 102400 {
+		err := ExecuteSchemaMigrationRequestValidationError{
+			field:  "OptionalCursor",
+			reason: "value length must be at most 102400 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ExecuteSchemaMigrationRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExecuteSchemaMigrationRequestMultiError is an error wrapping multiple
+// validation errors returned by ExecuteSchemaMigrationRequest.ValidateAll()
+// if the designated constraints aren't met.
+type ExecuteSchemaMigrationRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExecuteSchemaMigrationRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExecuteSchemaMigrationRequestMultiError) AllErrors() []error { return m }
+
+// ExecuteSchemaMigrationRequestValidationError is the validation error
+// returned by ExecuteSchemaMigrationRequest.Validate if the designated
+// constraints aren't met.
+type ExecuteSchemaMigrationRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExecuteSchemaMigrationRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExecuteSchemaMigrationRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExecuteSchemaMigrationRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExecuteSchemaMigrationRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExecuteSchemaMigrationRequestValidationError) ErrorName() string {
+	return "ExecuteSchemaMigrationRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ExecuteSchemaMigrationRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExecuteSchemaMigrationRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExecuteSchemaMigrationRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExecuteSchemaMigrationRequestValidationError{}
+
+// Validate checks the field values on ExecuteSchemaMigrationResponse with the
+// rules defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ExecuteSchemaMigrationResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ExecuteSchemaMigrationResponse with
+// the rules defined in the proto definition for this message. If any rules
+// are violated, the result is a list of violation errors wrapped in
+// ExecuteSchemaMigrationResponseMultiError, or nil if none found.
+func (m *ExecuteSchemaMigrationResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ExecuteSchemaMigrationResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for RelationshipsDeleted
+
+	// no validation rules for RelationshipsRewritten
+
+	// no validation rules for Completed
+
+	// no validation rules for AfterResultCursor
+
+	// no validation rules for WrittenAt
+
+	if len(errors) > 0 {
+		return ExecuteSchemaMigrationResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// ExecuteSchemaMigrationResponseMultiError is an error wrapping multiple
+// validation errors returned by ExecuteSchemaMigrationResponse.ValidateAll()
+// if the designated constraints aren't met.
+type ExecuteSchemaMigrationResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ExecuteSchemaMigrationResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ExecuteSchemaMigrationResponseMultiError) AllErrors() []error { return m }
+
+// ExecuteSchemaMigrationResponseValidationError is the validation error
+// returned by ExecuteSchemaMigrationResponse.Validate if the designated
+// constraints aren't met.
+type ExecuteSchemaMigrationResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ExecuteSchemaMigrationResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ExecuteSchemaMigrationResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ExecuteSchemaMigrationResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ExecuteSchemaMigrationResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ExecuteSchemaMigrationResponseValidationError) ErrorName() string {
+	return "ExecuteSchemaMigrationResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ExecuteSchemaMigrationResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sExecuteSchemaMigrationResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ExecuteSchemaMigrationResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ExecuteSchemaMigrationResponseValidationError{}
+
+// Validate checks the field values on WriteSchemaWithRelationRenamesRequest
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the first error encountered is returned, or nil if
+// there are no violations.
+func (m *WriteSchemaWithRelationRenamesRequest) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WriteSchemaWithRelationRenamesRequest
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the result is a list of violation errors wrapped in
+// WriteSchemaWithRelationRenamesRequestMultiError, or nil if none found.
+func (m *WriteSchemaWithRelationRenamesRequest) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WriteSchemaWithRelationRenamesRequest) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(m.GetSchema()) > 4194304 {
+		err := WriteSchemaWithRelationRenamesRequestValidationError{
+			field:  "Schema",
+			reason: "value length must be at most 4194304 bytes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(m.GetRelationRenames()) < 1 {
+		err := WriteSchemaWithRelationRenamesRequestValidationError{
+			field:  "RelationRenames",
+			reason: "value must contain at least 1 item(s)",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetRelationRenames() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, WriteSchemaWithRelationRenamesRequestValidationError{
+						field:  fmt.Sprintf("RelationRenames[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, WriteSchemaWithRelationRenamesRequestValidationError{
+						field:  fmt.Sprintf("RelationRenames[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return WriteSchemaWithRelationRenamesRequestValidationError{
+					field:  fmt.Sprintf("RelationRenames[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return WriteSchemaWithRelationRenamesRequestMultiError(errors)
+	}
+
+	return nil
+}
+
+// WriteSchemaWithRelationRenamesRequestMultiError is an error wrapping
+// multiple validation errors returned by
+// WriteSchemaWithRelationRenamesRequest.ValidateAll() if the designated
+// constraints aren't met.
+type WriteSchemaWithRelationRenamesRequestMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WriteSchemaWithRelationRenamesRequestMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WriteSchemaWithRelationRenamesRequestMultiError) AllErrors() []error { return m }
+
+// WriteSchemaWithRelationRenamesRequestValidationError is the validation error
+// returned by WriteSchemaWithRelationRenamesRequest.Validate if the
+// designated constraints aren't met.
+type WriteSchemaWithRelationRenamesRequestValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WriteSchemaWithRelationRenamesRequestValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WriteSchemaWithRelationRenamesRequestValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WriteSchemaWithRelationRenamesRequestValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WriteSchemaWithRelationRenamesRequestValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WriteSchemaWithRelationRenamesRequestValidationError) ErrorName() string {
+	return "WriteSchemaWithRelationRenamesRequestValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e WriteSchemaWithRelationRenamesRequestValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWriteSchemaWithRelationRenamesRequest.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WriteSchemaWithRelationRenamesRequestValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WriteSchemaWithRelationRenamesRequestValidationError{}
+
+// Validate checks the field values on WriteSchemaWithRelationRenamesResponse
+// with the rules defined in the proto definition for this message. If any
+// rules are violated, the first error encountered is returned, or nil if
+// there are no violations.
+func (m *WriteSchemaWithRelationRenamesResponse) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on
+// WriteSchemaWithRelationRenamesResponse with the rules defined in the proto
+// definition for this message. If any rules are violated, the result is a
+// list of violation errors wrapped in
+// WriteSchemaWithRelationRenamesResponseMultiError, or nil if none found.
+func (m *WriteSchemaWithRelationRenamesResponse) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WriteSchemaWithRelationRenamesResponse) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for WrittenAt
+
+	if len(errors) > 0 {
+		return WriteSchemaWithRelationRenamesResponseMultiError(errors)
+	}
+
+	return nil
+}
+
+// WriteSchemaWithRelationRenamesResponseMultiError is an error wrapping
+// multiple validation errors returned by
+// WriteSchemaWithRelationRenamesResponse.ValidateAll() if the designated
+// constraints aren't met.
+type WriteSchemaWithRelationRenamesResponseMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WriteSchemaWithRelationRenamesResponseMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WriteSchemaWithRelationRenamesResponseMultiError) AllErrors() []error { return m }
+
+// WriteSchemaWithRelationRenamesResponseValidationError is the validation
+// error returned by WriteSchemaWithRelationRenamesResponse.Validate if the
+// designated constraints aren't met.
+type WriteSchemaWithRelationRenamesResponseValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WriteSchemaWithRelationRenamesResponseValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WriteSchemaWithRelationRenamesResponseValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WriteSchemaWithRelationRenamesResponseValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WriteSchemaWithRelationRenamesResponseValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WriteSchemaWithRelationRenamesResponseValidationError) ErrorName() string {
+	return "WriteSchemaWithRelationRenamesResponseValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e WriteSchemaWithRelationRenamesResponseValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWriteSchemaWithRelationRenamesResponse.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WriteSchemaWithRelationRenamesResponseValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WriteSchemaWithRelationRenamesResponseValidationError{}
@@ -0,0 +1,220 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: schemamigration/v1/schemamigration.proto
+
+package schemamigrationv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SchemaMigrationService_PlanSchemaMigration_FullMethodName            = "/schemamigration.v1.SchemaMigrationService/PlanSchemaMigration"
+	SchemaMigrationService_ExecuteSchemaMigration_FullMethodName         = "/schemamigration.v1.SchemaMigrationService/ExecuteSchemaMigration"
+	SchemaMigrationService_WriteSchemaWithRelationRenames_FullMethodName = "/schemamigration.v1.SchemaMigrationService/WriteSchemaWithRelationRenames"
+)
+
+// SchemaMigrationServiceClient is the client API for SchemaMigrationService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SchemaMigrationService plans and executes the changes to stored relationships required
+// before a schema can be written, such as deleting the relationships of a removed relation.
+type SchemaMigrationServiceClient interface {
+	// PlanSchemaMigration computes the steps required to migrate the stored relationships to
+	// the target schema, without changing them.
+	PlanSchemaMigration(ctx context.Context, in *PlanSchemaMigrationRequest, opts ...grpc.CallOption) (*PlanSchemaMigrationResponse, error)
+	// ExecuteSchemaMigration executes the next batch of the steps of the migration to the
+	// target schema. It should be called with the cursor returned until the migration is
+	// completed, after which the target schema can be written.
+	ExecuteSchemaMigration(ctx context.Context, in *ExecuteSchemaMigrationRequest, opts ...grpc.CallOption) (*ExecuteSchemaMigrationResponse, error)
+	// WriteSchemaWithRelationRenames writes the target schema, which renames the relations
+	// given. The relationships of each renamed relation, and those with subjects of it, are
+	// rewritten to its new name in the same transaction as the schema write.
+	WriteSchemaWithRelationRenames(ctx context.Context, in *WriteSchemaWithRelationRenamesRequest, opts ...grpc.CallOption) (*WriteSchemaWithRelationRenamesResponse, error)
+}
+
+type schemaMigrationServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSchemaMigrationServiceClient(cc grpc.ClientConnInterface) SchemaMigrationServiceClient {
+	return &schemaMigrationServiceClient{cc}
+}
+
+func (c *schemaMigrationServiceClient) PlanSchemaMigration(ctx context.Context, in *PlanSchemaMigrationRequest, opts ...grpc.CallOption) (*PlanSchemaMigrationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlanSchemaMigrationResponse)
+	err := c.cc.Invoke(ctx, SchemaMigrationService_PlanSchemaMigration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schemaMigrationServiceClient) ExecuteSchemaMigration(ctx context.Context, in *ExecuteSchemaMigrationRequest, opts ...grpc.CallOption) (*ExecuteSchemaMigrationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExecuteSchemaMigrationResponse)
+	err := c.cc.Invoke(ctx, SchemaMigrationService_ExecuteSchemaMigration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *schemaMigrationServiceClient) WriteSchemaWithRelationRenames(ctx context.Context, in *WriteSchemaWithRelationRenamesRequest, opts ...grpc.CallOption) (*WriteSchemaWithRelationRenamesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WriteSchemaWithRelationRenamesResponse)
+	err := c.cc.Invoke(ctx, SchemaMigrationService_WriteSchemaWithRelationRenames_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SchemaMigrationServiceServer is the server API for SchemaMigrationService service.
+// All implementations must embed UnimplementedSchemaMigrationServiceServer
+// for forward compatibility.
+//
+// SchemaMigrationService plans and executes the changes to stored relationships required
+// before a schema can be written, such as deleting the relationships of a removed relation.
+type SchemaMigrationServiceServer interface {
+	// PlanSchemaMigration computes the steps required to migrate the stored relationships to
+	// the target schema, without changing them.
+	PlanSchemaMigration(context.Context, *PlanSchemaMigrationRequest) (*PlanSchemaMigrationResponse, error)
+	// ExecuteSchemaMigration executes the next batch of the steps of the migration to the
+	// target schema. It should be called with the cursor returned until the migration is
+	// completed, after which the target schema can be written.
+	ExecuteSchemaMigration(context.Context, *ExecuteSchemaMigrationRequest) (*ExecuteSchemaMigrationResponse, error)
+	// WriteSchemaWithRelationRenames writes the target schema, which renames the relations
+	// given. The relationships of each renamed relation, and those with subjects of it, are
+	// rewritten to its new name in the same transaction as the schema write.
+	WriteSchemaWithRelationRenames(context.Context, *WriteSchemaWithRelationRenamesRequest) (*WriteSchemaWithRelationRenamesResponse, error)
+	mustEmbedUnimplementedSchemaMigrationServiceServer()
+}
+
+// UnimplementedSchemaMigrationServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSchemaMigrationServiceServer struct{}
+
+func (UnimplementedSchemaMigrationServiceServer) PlanSchemaMigration(context.Context, *PlanSchemaMigrationRequest) (*PlanSchemaMigrationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlanSchemaMigration not implemented")
+}
+func (UnimplementedSchemaMigrationServiceServer) ExecuteSchemaMigration(context.Context, *ExecuteSchemaMigrationRequest) (*ExecuteSchemaMigrationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteSchemaMigration not implemented")
+}
+func (UnimplementedSchemaMigrationServiceServer) WriteSchemaWithRelationRenames(context.Context, *WriteSchemaWithRelationRenamesRequest) (*WriteSchemaWithRelationRenamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WriteSchemaWithRelationRenames not implemented")
+}
+func (UnimplementedSchemaMigrationServiceServer) mustEmbedUnimplementedSchemaMigrationServiceServer() {
+}
+func (UnimplementedSchemaMigrationServiceServer) testEmbeddedByValue() {}
+
+// UnsafeSchemaMigrationServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SchemaMigrationServiceServer will
+// result in compilation errors.
+type UnsafeSchemaMigrationServiceServer interface {
+	mustEmbedUnimplementedSchemaMigrationServiceServer()
+}
+
+func RegisterSchemaMigrationServiceServer(s grpc.ServiceRegistrar, srv SchemaMigrationServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSchemaMigrationServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SchemaMigrationService_ServiceDesc, srv)
+}
+
+func _SchemaMigrationService_PlanSchemaMigration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanSchemaMigrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchemaMigrationServiceServer).PlanSchemaMigration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchemaMigrationService_PlanSchemaMigration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchemaMigrationServiceServer).PlanSchemaMigration(ctx, req.(*PlanSchemaMigrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchemaMigrationService_ExecuteSchemaMigration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteSchemaMigrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchemaMigrationServiceServer).ExecuteSchemaMigration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchemaMigrationService_ExecuteSchemaMigration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchemaMigrationServiceServer).ExecuteSchemaMigration(ctx, req.(*ExecuteSchemaMigrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SchemaMigrationService_WriteSchemaWithRelationRenames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WriteSchemaWithRelationRenamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SchemaMigrationServiceServer).WriteSchemaWithRelationRenames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SchemaMigrationService_WriteSchemaWithRelationRenames_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SchemaMigrationServiceServer).WriteSchemaWithRelationRenames(ctx, req.(*WriteSchemaWithRelationRenamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SchemaMigrationService_ServiceDesc is the grpc.ServiceDesc for SchemaMigrationService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SchemaMigrationService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "schemamigration.v1.SchemaMigrationService",
+	HandlerType: (*SchemaMigrationServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PlanSchemaMigration",
+			Handler:    _SchemaMigrationService_PlanSchemaMigration_Handler,
+		},
+		{
+			MethodName: "ExecuteSchemaMigration",
+			Handler:    _SchemaMigrationService_ExecuteSchemaMigration_Handler,
+		},
+		{
+			MethodName: "WriteSchemaWithRelationRenames",
+			Handler:    _SchemaMigrationService_WriteSchemaWithRelationRenames_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "schemamigration/v1/schemamigration.proto",
+}
@@ -0,0 +1,260 @@
+package query
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/datastore/options"
+	dispatchv1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// Analyzer records the statistics of each iterator evaluated by a query, for EXPLAIN
+// ANALYZE. Unlike the TraceLogger, it is safe for concurrent use, so analyzed queries
+// are evaluated by their executor as they otherwise would be.
+//
+// Iterators are recorded under the iterator which evaluated them. Evaluations of
+// identical iterators by the same parent, such as the clones built by a
+// RecursiveIterator on each call, are recorded together.
+type Analyzer struct {
+	lock  sync.Mutex
+	roots []*analyzedIterator
+	keys  map[Iterator]string
+	stats *Statistics
+}
+
+// NewAnalyzer creates an analyzer.
+func NewAnalyzer() *Analyzer {
+	return &Analyzer{keys: make(map[Iterator]string)}
+}
+
+// NewAnalyzerWithStatistics creates an analyzer which also records the estimated cost of
+// each iterator evaluated, as computed from the statistics by the cost-based optimizer.
+func NewAnalyzerWithStatistics(stats Statistics) *Analyzer {
+	return &Analyzer{keys: make(map[Iterator]string), stats: &stats}
+}
+
+type analyzedIterator struct {
+	key      string
+	iterator string
+	estimate *dispatchv1.QueryPlanEstimate
+
+	calls            atomic.Uint32
+	duration         atomic.Int64
+	results          atomic.Uint64
+	datastoreQueries atomic.Uint64
+	rowsRead         atomic.Uint64
+	cacheHits        atomic.Uint64
+	dispatched       atomic.Bool
+
+	// children and remote are guarded by the lock of the Analyzer.
+	children []*analyzedIterator
+	remote   []*dispatchv1.QueryPlanAnalysis
+}
+
+// enter returns the node recording the evaluation of the iterator by the parent, which is
+// nil for the iterators evaluated directly by the query.
+func (a *Analyzer) enter(parent *analyzedIterator, it Iterator) *analyzedIterator {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	key, ok := a.keys[it]
+	if !ok {
+		key = it.Explain().String()
+		a.keys[it] = key
+	}
+
+	siblings := &a.roots
+	if parent != nil {
+		siblings = &parent.children
+	}
+
+	for _, node := range *siblings {
+		if node.key == key {
+			return node
+		}
+	}
+
+	node := &analyzedIterator{key: key, iterator: it.Explain().Info}
+	if a.stats != nil {
+		estimate := a.stats.Estimate(it)
+		node.estimate = &dispatchv1.QueryPlanEstimate{
+			Cost:        estimate.Cost,
+			Selectivity: estimate.Selectivity,
+			Fanout:      estimate.Fanout,
+			Fanin:       estimate.Fanin,
+		}
+	}
+	*siblings = append(*siblings, node)
+	return node
+}
+
+// recordDispatch records that the iterator of the node was dispatched, along with the
+// analysis of its subiterators returned.
+func (a *Analyzer) recordDispatch(node *analyzedIterator, cachedDispatchCount uint32, remote []*dispatchv1.QueryPlanAnalysis) {
+	node.dispatched.Store(true)
+	node.cacheHits.Add(uint64(cachedDispatchCount))
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	node.remote = mergeAnalyses(node.remote, remote)
+}
+
+// Analysis returns the statistics of the iterators evaluated directly by the query, and
+// under them, those of their subiterators.
+func (a *Analyzer) Analysis() []*dispatchv1.QueryPlanAnalysis {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return analysesOf(a.roots)
+}
+
+func analysesOf(nodes []*analyzedIterator) []*dispatchv1.QueryPlanAnalysis {
+	analyses := make([]*dispatchv1.QueryPlanAnalysis, 0, len(nodes))
+	for _, node := range nodes {
+		analyses = append(analyses, &dispatchv1.QueryPlanAnalysis{
+			Iterator:         node.iterator,
+			Calls:            node.calls.Load(),
+			Duration:         durationpb.New(time.Duration(node.duration.Load())),
+			Results:          node.results.Load(),
+			DatastoreQueries: node.datastoreQueries.Load(),
+			RowsRead:         node.rowsRead.Load(),
+			CacheHits:        node.cacheHits.Load(),
+			Dispatched:       node.dispatched.Load(),
+			Children:         mergeAnalyses(analysesOf(node.children), node.remote),
+			Estimate:         node.estimate,
+		})
+	}
+	return analyses
+}
+
+// mergeAnalyses merges the analyses into those existing, combining those of iterators
+// with the same description.
+func mergeAnalyses(existing []*dispatchv1.QueryPlanAnalysis, incoming []*dispatchv1.QueryPlanAnalysis) []*dispatchv1.QueryPlanAnalysis {
+	for _, analysis := range incoming {
+		merged := false
+		for _, current := range existing {
+			if current.Iterator != analysis.Iterator {
+				continue
+			}
+
+			current.Calls += analysis.Calls
+			current.Duration = durationpb.New(current.Duration.AsDuration() + analysis.Duration.AsDuration())
+			current.Results += analysis.Results
+			current.DatastoreQueries += analysis.DatastoreQueries
+			current.RowsRead += analysis.RowsRead
+			current.CacheHits += analysis.CacheHits
+			current.Dispatched = current.Dispatched || analysis.Dispatched
+			current.Children = mergeAnalyses(current.Children, analysis.Children)
+			if current.Estimate == nil {
+				current.Estimate = analysis.Estimate
+			}
+			merged = true
+			break
+		}
+
+		if !merged {
+			existing = append(existing, analysis.CloneVT())
+		}
+	}
+	return existing
+}
+
+// enterAnalysis returns the context with which the iterator is to be evaluated, and the
+// node recording its evaluation, if the query is being analyzed.
+func (ctx *Context) enterAnalysis(it Iterator) (*Context, *analyzedIterator) {
+	if ctx.Analyzer == nil {
+		return ctx, nil
+	}
+
+	node := ctx.Analyzer.enter(ctx.analyzed, it)
+	node.calls.Add(1)
+
+	// The reader is wrapped to attribute the queries made by the iterator to it, rather
+	// than to the iterator which evaluated it.
+	reader := ctx.Reader
+	if analyzed, ok := reader.(*analyzedReader); ok {
+		reader = analyzed.Reader
+	}
+
+	childCtx := *ctx
+	childCtx.analyzed = node
+	childCtx.Reader = &analyzedReader{Reader: reader, node: node}
+	return &childCtx, node
+}
+
+// wrapPathSeqForAnalysis wraps a PathSeq to record the paths produced, and the time spent
+// producing them, if the iterator is being analyzed. The time spent by the consumer of the
+// paths is excluded.
+func (ctx *Context) wrapPathSeqForAnalysis(node *analyzedIterator, started time.Time, pathSeq PathSeq) PathSeq {
+	if node == nil {
+		return pathSeq
+	}
+
+	node.duration.Add(int64(time.Since(started)))
+	return func(yield func(Path, error) bool) {
+		resumed := time.Now()
+		for path, err := range pathSeq {
+			node.duration.Add(int64(time.Since(resumed)))
+			if err == nil {
+				node.results.Add(1)
+			}
+
+			if !yield(path, err) {
+				return
+			}
+			resumed = time.Now()
+		}
+		node.duration.Add(int64(time.Since(resumed)))
+	}
+}
+
+// recordDispatch records that the iterator being evaluated was dispatched, if the query is
+// being analyzed.
+func (ctx *Context) recordDispatch(resp *dispatchv1.DispatchQueryPlanResponse) {
+	if ctx.Analyzer == nil || ctx.analyzed == nil || resp.GetMetadata() == nil {
+		return
+	}
+	ctx.Analyzer.recordDispatch(ctx.analyzed, resp.Metadata.CachedDispatchCount, resp.Metadata.GetDebugInfo().GetQueryPlanAnalysis())
+}
+
+// analyzedReader counts the queries made, and relationships read, by an iterator.
+type analyzedReader struct {
+	datastore.Reader
+	node *analyzedIterator
+}
+
+func (r *analyzedReader) QueryRelationships(ctx context.Context, filter datastore.RelationshipsFilter, opts ...options.QueryOptionsOption) (datastore.RelationshipIterator, error) {
+	r.node.datastoreQueries.Add(1)
+	it, err := r.Reader.QueryRelationships(ctx, filter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return r.countRows(it), nil
+}
+
+func (r *analyzedReader) ReverseQueryRelationships(ctx context.Context, subjectsFilter datastore.SubjectsFilter, opts ...options.ReverseQueryOptionsOption) (datastore.RelationshipIterator, error) {
+	r.node.datastoreQueries.Add(1)
+	it, err := r.Reader.ReverseQueryRelationships(ctx, subjectsFilter, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return r.countRows(it), nil
+}
+
+func (r *analyzedReader) countRows(it datastore.RelationshipIterator) datastore.RelationshipIterator {
+	return func(yield func(rel tuple.Relationship, err error) bool) {
+		for rel, err := range it {
+			if err == nil {
+				r.node.rowsRead.Add(1)
+			}
+			if !yield(rel, err) {
+				return
+			}
+		}
+	}
+}
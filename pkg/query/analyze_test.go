@@ -0,0 +1,179 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dispatchv1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+)
+
+func findAnalysis(analyses []*dispatchv1.QueryPlanAnalysis, matches func(*dispatchv1.QueryPlanAnalysis) bool) *dispatchv1.QueryPlanAnalysis {
+	for _, analysis := range analyses {
+		if matches(analysis) {
+			return analysis
+		}
+		if found := findAnalysis(analysis.Children, matches); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func sumRowsRead(analyses []*dispatchv1.QueryPlanAnalysis) uint64 {
+	var rows uint64
+	for _, analysis := range analyses {
+		rows += analysis.RowsRead + sumRowsRead(analysis.Children)
+	}
+	return rows
+}
+
+func TestAnalyzerRecordsIteratorStatistics(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name     string
+		executor Executor
+	}{
+		{"local", LocalExecutor{}},
+		{"parallel", NewParallelExecutor(4)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dispatcher := newLoopbackDispatcherForTesting(t)
+			edit, err := BuildIteratorFromSchema(dispatcher.schema, "document", "edit")
+			require.NoError(t, err)
+
+			ctx := &Context{
+				Context:           t.Context(),
+				Executor:          tc.executor,
+				Reader:            dispatcher.reader,
+				Analyzer:          NewAnalyzer(),
+				MaxRecursionDepth: 5,
+			}
+
+			pathSeq, err := ctx.Check(edit, NewObjects("document", "specialplan", "masterplan"), NewObject("user", "multiroleguy").WithEllipses())
+			require.Len(t, pathKeys(t, pathSeq, err), 1)
+
+			analyses := ctx.Analyzer.Analysis()
+			require.Len(t, analyses, 1)
+
+			root := analyses[0]
+			require.Equal(t, edit.Explain().Info, root.Iterator)
+			require.Equal(t, uint32(1), root.Calls)
+			require.Equal(t, uint64(1), root.Results)
+			require.False(t, root.Dispatched)
+			require.NotEmpty(t, root.Children)
+
+			// The relationships are read by the relation iterators, not by those above them.
+			require.Zero(t, root.DatastoreQueries)
+			relation := findAnalysis(analyses, func(analysis *dispatchv1.QueryPlanAnalysis) bool {
+				return analysis.DatastoreQueries > 0
+			})
+			require.NotNil(t, relation)
+			require.Positive(t, sumRowsRead(analyses))
+		})
+	}
+}
+
+func TestAnalyzerMergesDispatchedAnalysis(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newLoopbackDispatcherForTesting(t)
+	view, err := BuildIteratorFromSchema(dispatcher.schema, "document", "view")
+	require.NoError(t, err)
+
+	ctx := &Context{
+		Context:           t.Context(),
+		Executor:          NewRemoteExecutor(dispatcher, dispatcher.revision, 50, LocalExecutor{}),
+		Reader:            dispatcher.reader,
+		Analyzer:          NewAnalyzer(),
+		MaxRecursionDepth: 5,
+	}
+
+	pathSeq, err := ctx.Check(view, NewObjects("document", "masterplan"), NewObject("user", "product_manager").WithEllipses())
+	require.Len(t, pathKeys(t, pathSeq, err), 1)
+	require.NotZero(t, dispatcher.requests.Load())
+
+	analyses := ctx.Analyzer.Analysis()
+	dispatched := findAnalysis(analyses, func(analysis *dispatchv1.QueryPlanAnalysis) bool {
+		return analysis.Dispatched
+	})
+	require.NotNil(t, dispatched)
+
+	// The subiterators evaluated by the dispatcher are returned under the dispatched iterator.
+	require.NotEmpty(t, dispatched.Children)
+	require.Positive(t, sumRowsRead(dispatched.Children))
+}
+
+func TestAnalyzerRecordsEstimates(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newLoopbackDispatcherForTesting(t)
+	edit, err := BuildIteratorFromSchema(dispatcher.schema, "document", "edit")
+	require.NoError(t, err)
+
+	stats := Statistics{RelationshipCount: 100, ObjectTypeCount: 4}
+	ctx := &Context{
+		Context:           t.Context(),
+		Executor:          LocalExecutor{},
+		Reader:            dispatcher.reader,
+		Analyzer:          NewAnalyzerWithStatistics(stats),
+		MaxRecursionDepth: 5,
+	}
+
+	pathSeq, err := ctx.Check(edit, NewObjects("document", "specialplan"), NewObject("user", "multiroleguy").WithEllipses())
+	require.Len(t, pathKeys(t, pathSeq, err), 1)
+
+	analyses := ctx.Analyzer.Analysis()
+	require.Len(t, analyses, 1)
+
+	expected := stats.Estimate(edit)
+	require.NotNil(t, analyses[0].Estimate)
+	require.Equal(t, expected.Cost, analyses[0].Estimate.Cost)
+	require.Equal(t, expected.Selectivity, analyses[0].Estimate.Selectivity)
+
+	// Every iterator evaluated locally has an estimate.
+	require.Nil(t, findAnalysis(analyses, func(analysis *dispatchv1.QueryPlanAnalysis) bool {
+		return analysis.Estimate == nil
+	}))
+
+	// Without statistics, no estimates are recorded.
+	ctx.Analyzer = NewAnalyzer()
+	pathSeq, err = ctx.Check(edit, NewObjects("document", "specialplan"), NewObject("user", "multiroleguy").WithEllipses())
+	require.Len(t, pathKeys(t, pathSeq, err), 1)
+	require.Nil(t, ctx.Analyzer.Analysis()[0].Estimate)
+}
+
+func TestMergeAnalyses(t *testing.T) {
+	t.Parallel()
+
+	existing := []*dispatchv1.QueryPlanAnalysis{
+		{Iterator: "Union", Calls: 1, Results: 2, Children: []*dispatchv1.QueryPlanAnalysis{
+			{Iterator: "document#viewer", Calls: 1, RowsRead: 2},
+		}},
+	}
+	incoming := []*dispatchv1.QueryPlanAnalysis{
+		{Iterator: "Union", Calls: 2, Results: 1, Dispatched: true, Children: []*dispatchv1.QueryPlanAnalysis{
+			{Iterator: "document#viewer", Calls: 2, RowsRead: 1},
+			{Iterator: "document#editor", Calls: 1, RowsRead: 3},
+		}},
+		{Iterator: "Fixed", Calls: 1},
+	}
+
+	merged := mergeAnalyses(existing, incoming)
+	require.Len(t, merged, 2)
+	require.Equal(t, uint32(3), merged[0].Calls)
+	require.Equal(t, uint64(3), merged[0].Results)
+	require.True(t, merged[0].Dispatched)
+	require.Len(t, merged[0].Children, 2)
+	require.Equal(t, uint32(3), merged[0].Children[0].Calls)
+	require.Equal(t, uint64(3), merged[0].Children[0].RowsRead)
+	require.Equal(t, uint64(3), merged[0].Children[1].RowsRead)
+	require.Equal(t, "Fixed", merged[1].Iterator)
+
+	// The incoming analyses are copied, rather than shared.
+	merged[1].Calls++
+	require.Equal(t, uint32(1), incoming[1].Calls)
+}
@@ -11,12 +11,27 @@ import (
 //
 // Ex: `folder->owner` and `left->right`
 type Arrow struct {
-	left  Iterator
-	right Iterator
+	left      Iterator
+	right     Iterator
+	direction ArrowDirection
 }
 
 var _ Iterator = &Arrow{}
 
+// ArrowDirection is the strategy an Arrow uses to check its resources.
+type ArrowDirection int
+
+const (
+	// ArrowLeftToRight iterates the subjects of the left side for each resource,
+	// and checks each of them against the right side.
+	ArrowLeftToRight ArrowDirection = iota
+
+	// ArrowRightToLeft iterates the resources of the right side for the subject,
+	// and checks each of them against the left side. The right side must
+	// support IterResources.
+	ArrowRightToLeft
+)
+
 func NewArrow(left, right Iterator) *Arrow {
 	return &Arrow{
 		left:  left,
@@ -24,17 +39,30 @@ func NewArrow(left, right Iterator) *Arrow {
 	}
 }
 
+// WithDirection returns a copy of the arrow which checks its resources in the
+// given direction.
+func (a *Arrow) WithDirection(direction ArrowDirection) *Arrow {
+	return &Arrow{left: a.left, right: a.right, direction: direction}
+}
+
+// Direction returns the strategy the arrow uses to check its resources.
+func (a *Arrow) Direction() ArrowDirection {
+	return a.direction
+}
+
 func (a *Arrow) CheckImpl(ctx *Context, resources []Object, subject ObjectAndRelation) (PathSeq, error) {
-	// TODO -- the ordering, directionality, batching, everything can depend on other statistics.
+	// TODO -- batching can also depend on other statistics.
 	//
 	// There are three major strategies:
-	// - IterSubjects on the left, Check on the right (as per this implementation)
-	// - IterResources on the right, Check on the left
+	// - IterSubjects on the left, Check on the right (ArrowLeftToRight)
+	// - IterResources on the right, Check on the left (ArrowRightToLeft)
 	// - IterSubjects on left, IterResources on right, and intersect the two iterators here (especially if they are known to be sorted)
 	//
-	// But for now, this is a proof-of-concept, so the first one, one-by-one (no batching).
-	// This is going to be the crux of a lot of statistics optimizations -- statistics often
+	// The direction is chosen by the cost-based optimizer from statistics; statistics often
 	// don't restructure the tree, but can affect the best way to evaluate the tree, sometimes dynamically.
+	if a.direction == ArrowRightToLeft {
+		return a.checkRightToLeft(ctx, resources, subject)
+	}
 
 	return func(yield func(Path, error) bool) {
 		ctx.TraceStep(a, "processing %d resources", len(resources))
@@ -74,32 +102,7 @@ func (a *Arrow) CheckImpl(ctx *Context, resources []Object, subject ObjectAndRel
 					}
 					rightPathCount++
 
-					// Combine caveats from both sides using Path-based approach
-					// For arrow operations (left->right), both conditions must be satisfied (AND logic)
-					var combinedCaveat *core.CaveatExpression
-					switch {
-					case path.Caveat != nil && checkPath.Caveat != nil:
-						// Both sides have caveats - create combined caveat expression
-						combinedCaveat = caveats.And(path.Caveat, checkPath.Caveat)
-					case path.Caveat != nil:
-						// Only left side has caveat
-						combinedCaveat = path.Caveat
-					case checkPath.Caveat != nil:
-						// Only right side has caveat
-						combinedCaveat = checkPath.Caveat
-					}
-					// else both are nil, combinedCaveat remains nil
-
-					// Create combined path with resource from left and subject from right
-					combinedPath := Path{
-						Resource:   path.Resource,
-						Relation:   path.Relation,
-						Subject:    checkPath.Subject,
-						Caveat:     combinedCaveat,
-						Expiration: checkPath.Expiration,
-						Integrity:  checkPath.Integrity,
-						Metadata:   make(map[string]any),
-					}
+					combinedPath := combineArrowPaths(path, checkPath)
 
 					totalResultPaths++
 					if !yield(combinedPath, nil) {
@@ -117,25 +120,145 @@ func (a *Arrow) CheckImpl(ctx *Context, resources []Object, subject ObjectAndRel
 	}, nil
 }
 
+// checkRightToLeft finds the paths from the right side to the subject, and
+// checks the left side for each of the intermediate objects they start from.
+func (a *Arrow) checkRightToLeft(ctx *Context, resources []Object, subject ObjectAndRelation) (PathSeq, error) {
+	return func(yield func(Path, error) bool) {
+		ctx.TraceStep(a, "processing %d resources from subject %s:%s", len(resources), subject.ObjectType, subject.ObjectID)
+
+		rightSeq, err := ctx.IterResources(a.right, subject)
+		if err != nil {
+			yield(Path{}, err)
+			return
+		}
+
+		totalResultPaths := 0
+		rightPathCount := 0
+		for rightPath, err := range rightSeq {
+			if err != nil {
+				yield(Path{}, err)
+				return
+			}
+			rightPathCount++
+
+			intermediate := rightPath.Resource.WithEllipses()
+			ctx.TraceStep(a, "checking left side for subject %s:%s", intermediate.ObjectType, intermediate.ObjectID)
+
+			leftSeq, err := ctx.Check(a.left, resources, intermediate)
+			if err != nil {
+				yield(Path{}, err)
+				return
+			}
+
+			for leftPath, err := range leftSeq {
+				if err != nil {
+					yield(Path{}, err)
+					return
+				}
+
+				totalResultPaths++
+				if !yield(combineArrowPaths(leftPath, rightPath), nil) {
+					return
+				}
+			}
+		}
+
+		ctx.TraceStep(a, "right side returned %d paths", rightPathCount)
+		ctx.TraceStep(a, "arrow completed with %d total result paths", totalResultPaths)
+	}, nil
+}
+
+// combineArrowPaths combines a path on the left side of an arrow with a path
+// on the right side which starts from its subject.
+func combineArrowPaths(path, checkPath Path) Path {
+	// Combine caveats from both sides using Path-based approach
+	// For arrow operations (left->right), both conditions must be satisfied (AND logic)
+	var combinedCaveat *core.CaveatExpression
+	switch {
+	case path.Caveat != nil && checkPath.Caveat != nil:
+		// Both sides have caveats - create combined caveat expression
+		combinedCaveat = caveats.And(path.Caveat, checkPath.Caveat)
+	case path.Caveat != nil:
+		// Only left side has caveat
+		combinedCaveat = path.Caveat
+	case checkPath.Caveat != nil:
+		// Only right side has caveat
+		combinedCaveat = checkPath.Caveat
+	}
+	// else both are nil, combinedCaveat remains nil
+
+	// Create combined path with resource from left and subject from right
+	return Path{
+		Resource:   path.Resource,
+		Relation:   path.Relation,
+		Subject:    checkPath.Subject,
+		Caveat:     combinedCaveat,
+		Expiration: checkPath.Expiration,
+		Integrity:  checkPath.Integrity,
+		Metadata:   make(map[string]any),
+	}
+}
+
 func (a *Arrow) IterSubjectsImpl(ctx *Context, resource Object) (PathSeq, error) {
 	return nil, spiceerrors.MustBugf("unimplemented")
 }
 
+// IterResourcesImpl finds the paths from the right side to the subject, and the resources
+// of the left side reaching each of the intermediate objects they start from.
 func (a *Arrow) IterResourcesImpl(ctx *Context, subject ObjectAndRelation) (PathSeq, error) {
-	return nil, spiceerrors.MustBugf("unimplemented")
+	rightSeq, err := ctx.IterResources(a.right, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	combinedSeq := func(yield func(Path, error) bool) {
+		for rightPath, err := range rightSeq {
+			if err != nil {
+				yield(Path{}, err)
+				return
+			}
+
+			intermediate := rightPath.Resource.WithEllipses()
+			ctx.TraceStep(a, "iterating left side resources for subject %s:%s", intermediate.ObjectType, intermediate.ObjectID)
+
+			leftSeq, err := ctx.IterResources(a.left, intermediate)
+			if err != nil {
+				yield(Path{}, err)
+				return
+			}
+
+			for leftPath, err := range leftSeq {
+				if err != nil {
+					yield(Path{}, err)
+					return
+				}
+
+				if !yield(combineArrowPaths(leftPath, rightPath), nil) {
+					return
+				}
+			}
+		}
+	}
+
+	return DeduplicatePathSeq(combinedSeq), nil
 }
 
 func (a *Arrow) Clone() Iterator {
 	return &Arrow{
-		left:  a.left.Clone(),
-		right: a.right.Clone(),
+		left:      a.left.Clone(),
+		right:     a.right.Clone(),
+		direction: a.direction,
 	}
 }
 
 func (a *Arrow) Explain() Explain {
+	info := "Arrow"
+	if a.direction == ArrowRightToLeft {
+		info = "Arrow(right to left)"
+	}
 	return Explain{
 		Name:       "Arrow",
-		Info:       "Arrow",
+		Info:       info,
 		SubExplain: []Explain{a.left.Explain(), a.right.Explain()},
 	}
 }
@@ -145,5 +268,5 @@ func (a *Arrow) Subiterators() []Iterator {
 }
 
 func (a *Arrow) ReplaceSubiterators(newSubs []Iterator) (Iterator, error) {
-	return &Arrow{left: newSubs[0], right: newSubs[1]}, nil
+	return &Arrow{left: newSubs[0], right: newSubs[1], direction: a.direction}, nil
 }
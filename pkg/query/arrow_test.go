@@ -114,7 +114,7 @@ func TestArrowIterator(t *testing.T) {
 		})
 	})
 
-	t.Run("IterResources_Unimplemented", func(t *testing.T) {
+	t.Run("IterResources", func(t *testing.T) {
 		t.Parallel()
 
 		// Create context with LocalExecutor
@@ -123,9 +123,33 @@ func TestArrowIterator(t *testing.T) {
 			Executor: LocalExecutor{},
 		}
 
-		require.Panics(func() {
-			_, _ = ctx.IterResources(arrow, NewObject("user", "alice").WithEllipses())
-		})
+		// alice views project1, which is the parent of spec1.
+		pathSeq, err := ctx.IterResources(arrow, NewObject("user", "alice").WithEllipses())
+		require.NoError(err)
+
+		rels, err := CollectAll(pathSeq)
+		require.NoError(err)
+
+		expected := []Path{
+			MustPathFromString("document:spec1#parent@user:alice"),
+		}
+		require.Equal(expected, rels)
+	})
+
+	t.Run("IterResources_NoMatch", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := &Context{
+			Context:  t.Context(),
+			Executor: LocalExecutor{},
+		}
+
+		pathSeq, err := ctx.IterResources(arrow, NewObject("user", "nobody").WithEllipses())
+		require.NoError(err)
+
+		rels, err := CollectAll(pathSeq)
+		require.NoError(err)
+		require.Empty(rels)
 	})
 }
 
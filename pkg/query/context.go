@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/authzed/spicedb/internal/caveats"
 	"github.com/authzed/spicedb/pkg/datastore"
@@ -128,7 +129,10 @@ type Context struct {
 	CaveatContext     map[string]any
 	CaveatRunner      *caveats.CaveatRunner
 	TraceLogger       *TraceLogger // For debugging iterator execution
+	Analyzer          *Analyzer    // For recording the statistics of iterator execution (EXPLAIN ANALYZE)
 	MaxRecursionDepth int          // Maximum depth for recursive iterators (0 = use default of 10)
+
+	analyzed *analyzedIterator // The iterator being evaluated, if analyzing
 }
 
 func (ctx *Context) TraceStep(it Iterator, step string, data ...any) {
@@ -207,11 +211,15 @@ func (ctx *Context) Check(it Iterator, resources []Object, subject ObjectAndRela
 
 	tracedIterator := ctx.traceEnterIfEnabled(it, resources, subject)
 
-	pathSeq, err := ctx.Executor.Check(ctx, it, resources, subject)
+	execCtx, analyzed := ctx.enterAnalysis(it)
+	started := time.Now()
+
+	pathSeq, err := ctx.Executor.Check(execCtx, it, resources, subject)
 	if err != nil {
 		return nil, err
 	}
 
+	pathSeq = ctx.wrapPathSeqForAnalysis(analyzed, started, pathSeq)
 	return ctx.wrapPathSeqForTracing(tracedIterator, pathSeq), nil
 }
 
@@ -223,11 +231,15 @@ func (ctx *Context) IterSubjects(it Iterator, resource Object) (PathSeq, error)
 
 	tracedIterator := ctx.traceEnterIfEnabled(it, []Object{resource}, ObjectAndRelation{})
 
-	pathSeq, err := ctx.Executor.IterSubjects(ctx, it, resource)
+	execCtx, analyzed := ctx.enterAnalysis(it)
+	started := time.Now()
+
+	pathSeq, err := ctx.Executor.IterSubjects(execCtx, it, resource)
 	if err != nil {
 		return nil, err
 	}
 
+	pathSeq = ctx.wrapPathSeqForAnalysis(analyzed, started, pathSeq)
 	return ctx.wrapPathSeqForTracing(tracedIterator, pathSeq), nil
 }
 
@@ -239,11 +251,15 @@ func (ctx *Context) IterResources(it Iterator, subject ObjectAndRelation) (PathS
 
 	tracedIterator := ctx.traceEnterIfEnabled(it, []Object{}, subject)
 
-	pathSeq, err := ctx.Executor.IterResources(ctx, it, subject)
+	execCtx, analyzed := ctx.enterAnalysis(it)
+	started := time.Now()
+
+	pathSeq, err := ctx.Executor.IterResources(execCtx, it, subject)
 	if err != nil {
 		return nil, err
 	}
 
+	pathSeq = ctx.wrapPathSeqForAnalysis(analyzed, started, pathSeq)
 	return ctx.wrapPathSeqForTracing(tracedIterator, pathSeq), nil
 }
 
@@ -223,7 +223,60 @@ func (r *RelationIterator) iterSubjectsWildcardImpl(ctx *Context, resource Objec
 }
 
 func (r *RelationIterator) IterResourcesImpl(ctx *Context, subject ObjectAndRelation) (PathSeq, error) {
-	return nil, spiceerrors.MustBugf("unimplemented")
+	if subject.ObjectType != r.base.Type() {
+		ctx.TraceStep(r, "subject type %s doesn't match base type %s, returning empty", subject.ObjectType, r.base.Type())
+		return EmptyPathSeq(), nil
+	}
+
+	subjectID := subject.ObjectID
+	if r.base.Wildcard() {
+		subjectID = tuple.PublicWildcard // Look for "*" subjects
+	}
+
+	filter := datastore.RelationshipsFilter{
+		OptionalResourceType:     r.base.DefinitionName(),
+		OptionalResourceRelation: r.base.RelationName(),
+		OptionalSubjectsSelectors: []datastore.SubjectsSelector{
+			{
+				OptionalSubjectType: r.base.Type(),
+				OptionalSubjectIds:  []string{subjectID},
+				RelationFilter:      r.buildSubjectRelationFilter(),
+			},
+		},
+	}
+
+	ctx.TraceStep(r, "querying datastore for resources of %s:%s with subject %s:%s", r.base.DefinitionName(), r.base.RelationName(), subject.ObjectType, subjectID)
+
+	relIter, err := ctx.Reader.QueryRelationships(ctx, filter,
+		options.WithSkipCaveats(r.base.Caveat() == ""),
+		options.WithSkipExpiration(!r.base.Expiration()),
+		options.WithQueryShape(queryshape.MatchingResourcesForSubject),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.base.Wildcard() {
+		return convertRelationSeqToPathSeq(iter.Seq2[tuple.Relationship, error](relIter)), nil
+	}
+
+	// Replace the wildcard subject with the concrete subject
+	return func(yield func(Path, error) bool) {
+		for rel, err := range relIter {
+			if err != nil {
+				if !yield(Path{}, err) {
+					return
+				}
+				continue
+			}
+
+			concreteRel := rel
+			concreteRel.Subject = subject
+			if !yield(FromRelationship(concreteRel), nil) {
+				return
+			}
+		}
+	}, nil
 }
 
 func (r *RelationIterator) Clone() Iterator {
@@ -78,7 +78,12 @@ func (e *Exclusion) CheckImpl(ctx *Context, resources []Object, subject ObjectAn
 	}
 
 	ctx.TraceStep(e, "main set returned %d paths", len(mainPaths))
+	return e.exclude(ctx, mainPaths, resources, subject)
+}
 
+// exclude removes from the paths of the main set those excluded for the resources, or
+// makes them conditional on the caveats of the excluded paths.
+func (e *Exclusion) exclude(ctx *Context, mainPaths []Path, resources []Object, subject ObjectAndRelation) (PathSeq, error) {
 	// If main set is empty, return empty result
 	if len(mainPaths) == 0 {
 		ctx.TraceStep(e, "main set empty, returning empty")
@@ -137,8 +142,22 @@ func (e *Exclusion) IterSubjectsImpl(ctx *Context, resource Object) (PathSeq, er
 	return nil, spiceerrors.MustBugf("unimplemented")
 }
 
+// IterResourcesImpl iterates the resources of the main set for the subject, and checks
+// those found against the excluded set.
 func (e *Exclusion) IterResourcesImpl(ctx *Context, subject ObjectAndRelation) (PathSeq, error) {
-	return nil, spiceerrors.MustBugf("unimplemented")
+	ctx.TraceStep(e, "iterating resources of main set")
+	mainSeq, err := ctx.IterResources(e.mainSet, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	mainPaths, err := CollectAll(mainSeq)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.TraceStep(e, "main set returned %d paths", len(mainPaths))
+	return e.exclude(ctx, mainPaths, resourcesOf(mainPaths), subject)
 }
 
 func (e *Exclusion) Clone() Iterator {
@@ -287,12 +287,31 @@ func TestExclusionUnimplementedMethods(t *testing.T) {
 		}, "Should panic since method is unimplemented")
 	})
 
-	t.Run("IterResourcesImpl Unimplemented", func(t *testing.T) {
-		t.Parallel()
-		require.Panics(func() {
-			_, _ = ctx.IterResources(exclusion, NewObject("user", "alice").WithEllipses())
-		}, "Should panic since method is unimplemented")
-	})
+}
+
+func TestExclusionIterResources(t *testing.T) {
+	t.Parallel()
+
+	ctx := &Context{
+		Context:  t.Context(),
+		Executor: LocalExecutor{},
+	}
+
+	mainSet := NewFixedIterator(
+		MustPathFromString("document:doc1#viewer@user:alice"),
+		MustPathFromString("document:doc2#viewer@user:alice"),
+		MustPathFromString("document:doc3#viewer@user:bob"),
+	)
+	excludedSet := NewFixedIterator(
+		MustPathFromString("document:doc2#banned@user:alice"),
+	)
+
+	pathSeq, err := ctx.IterResources(NewExclusion(mainSet, excludedSet), NewObject("user", "alice").WithEllipses())
+	require.NoError(t, err)
+
+	paths, err := CollectAll(pathSeq)
+	require.NoError(t, err)
+	require.Equal(t, []Path{MustPathFromString("document:doc1#viewer@user:alice")}, paths)
 }
 
 func TestExclusionErrorHandling(t *testing.T) {
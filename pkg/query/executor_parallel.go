@@ -0,0 +1,230 @@
+package query
+
+import (
+	"context"
+	"sync"
+)
+
+// ParallelExecutor is an executor which checks the subiterators of unions and
+// intersections concurrently. All other iterators are evaluated as by the LocalExecutor.
+//
+// The concurrency limit is shared by every subtree of a query, so a ParallelExecutor
+// should be created for each query. Whenever the limit is reached, subiterators are
+// checked on the calling goroutine instead, so nested unions and intersections never
+// wait on each other.
+type ParallelExecutor struct {
+	slots chan struct{}
+}
+
+var _ Executor = &ParallelExecutor{}
+
+// NewParallelExecutor creates an executor which checks at most concurrencyLimit
+// subiterators concurrently, in addition to the calling goroutine. A limit of zero
+// checks every subiterator sequentially.
+func NewParallelExecutor(concurrencyLimit uint16) *ParallelExecutor {
+	return &ParallelExecutor{slots: make(chan struct{}, concurrencyLimit)}
+}
+
+// ConcurrencyLimit returns the number of subiterators checked concurrently by the executor.
+func (p *ParallelExecutor) ConcurrencyLimit() uint16 {
+	return uint16(cap(p.slots))
+}
+
+// Check tests if, for the underlying set of relationships (which may be a full expression or a basic lookup, depending on the iterator)
+// any of the `resources` are connected to `subject`.
+// Returns the sequence of matching paths, if they exist, at most `len(resources)`.
+func (p *ParallelExecutor) Check(ctx *Context, it Iterator, resources []Object, subject ObjectAndRelation) (PathSeq, error) {
+	// Traces are recorded in execution order, so traced queries are evaluated sequentially.
+	if ctx.TraceLogger == nil {
+		switch v := it.(type) {
+		case *Union:
+			if len(v.subIts) > 1 {
+				return p.checkUnion(ctx, v, resources, subject), nil
+			}
+		case *Intersection:
+			if len(v.subIts) > 1 {
+				return p.checkIntersection(ctx, v, resources, subject), nil
+			}
+		}
+	}
+	return it.CheckImpl(ctx, resources, subject)
+}
+
+// IterSubjects returns a sequence of all the paths in this set that match the given resource.
+func (p *ParallelExecutor) IterSubjects(ctx *Context, it Iterator, resource Object) (PathSeq, error) {
+	return it.IterSubjectsImpl(ctx, resource)
+}
+
+// IterResources returns a sequence of all the paths in this set that match the given subject.
+func (p *ParallelExecutor) IterResources(ctx *Context, it Iterator, subject ObjectAndRelation) (PathSeq, error) {
+	return it.IterResourcesImpl(ctx, subject)
+}
+
+// checkUnion checks the subiterators of the union concurrently, canceling those
+// remaining once every resource has a path without a caveat.
+func (p *ParallelExecutor) checkUnion(ctx *Context, u *Union, resources []Object, subject ObjectAndRelation) PathSeq {
+	return func(yield func(Path, error) bool) {
+		remaining := make(map[string]struct{}, len(resources))
+		for _, resource := range resources {
+			remaining[resource.Key()] = struct{}{}
+		}
+
+		results := make([][]Path, len(u.subIts))
+		err := p.checkConcurrently(ctx, u.subIts, resources, subject, func(idx int, paths []Path) bool {
+			results[idx] = paths
+			for _, path := range paths {
+				if path.Caveat == nil {
+					delete(remaining, path.Resource.Key())
+				}
+			}
+			return len(remaining) == 0
+		})
+		if err != nil {
+			yield(Path{}, err)
+			return
+		}
+
+		combinedSeq := func(yield func(Path, error) bool) {
+			for _, paths := range results {
+				for _, path := range paths {
+					if !yield(path, nil) {
+						return
+					}
+				}
+			}
+		}
+
+		for path, err := range DeduplicatePathSeq(combinedSeq) {
+			if !yield(path, err) {
+				return
+			}
+		}
+	}
+}
+
+// checkIntersection checks the subiterators of the intersection concurrently,
+// canceling those remaining once any of them finds no paths.
+func (p *ParallelExecutor) checkIntersection(ctx *Context, i *Intersection, resources []Object, subject ObjectAndRelation) PathSeq {
+	return func(yield func(Path, error) bool) {
+		results := make([][]Path, len(i.subIts))
+		foundEmpty := false
+		err := p.checkConcurrently(ctx, i.subIts, resources, subject, func(idx int, paths []Path) bool {
+			results[idx] = paths
+			foundEmpty = len(paths) == 0
+			return foundEmpty
+		})
+		if err != nil {
+			yield(Path{}, err)
+			return
+		}
+
+		if foundEmpty {
+			return
+		}
+
+		// Combine the results in the order of the subiterators, as the Intersection does.
+		pathsByKey, err := mergePathsByResource(results[0])
+		if err != nil {
+			yield(Path{}, err)
+			return
+		}
+
+		for _, paths := range results[1:] {
+			pathsByKey, err = intersectPathsByResource(pathsByKey, paths)
+			if err != nil {
+				yield(Path{}, err)
+				return
+			}
+		}
+
+		for _, path := range pathsByKey {
+			if !yield(path, nil) {
+				return
+			}
+		}
+	}
+}
+
+// checkConcurrently checks the resources against each of the subiterators, running as
+// many concurrently as there are free slots and the rest on the calling goroutine.
+//
+// onResult is called, one at a time, with the paths found by each subiterator as it
+// completes; if it returns true, the checks which have not completed are canceled and
+// their results discarded. The first error encountered also cancels the checks and is
+// returned.
+func (p *ParallelExecutor) checkConcurrently(
+	ctx *Context,
+	subIts []Iterator,
+	resources []Object,
+	subject ObjectAndRelation,
+	onResult func(idx int, paths []Path) bool,
+) error {
+	cancelCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	childCtx := *ctx
+	childCtx.Context = cancelCtx
+
+	var (
+		wg       sync.WaitGroup
+		lock     sync.Mutex
+		done     bool
+		firstErr error
+	)
+
+	check := func(idx int, it Iterator) {
+		paths, err := collectCheck(&childCtx, it, resources, subject)
+
+		lock.Lock()
+		defer lock.Unlock()
+
+		if done {
+			return
+		}
+
+		if err != nil {
+			firstErr = err
+			done = true
+			cancel()
+			return
+		}
+
+		if onResult(idx, paths) {
+			done = true
+			cancel()
+		}
+	}
+
+	isDone := func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return done
+	}
+
+	for idx, it := range subIts {
+		if isDone() {
+			break
+		}
+
+		select {
+		case p.slots <- struct{}{}:
+			wg.Go(func() {
+				defer func() { <-p.slots }()
+				check(idx, it)
+			})
+		default:
+			check(idx, it)
+		}
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+func collectCheck(ctx *Context, it Iterator, resources []Object, subject ObjectAndRelation) ([]Path, error) {
+	pathSeq, err := ctx.Check(it, resources, subject)
+	if err != nil {
+		return nil, err
+	}
+	return CollectAll(pathSeq)
+}
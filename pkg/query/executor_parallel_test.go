@@ -0,0 +1,262 @@
+package query
+
+import (
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/datastore/dsfortesting"
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	"github.com/authzed/spicedb/internal/testfixtures"
+	corev1 "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schema/v2"
+	"github.com/authzed/spicedb/pkg/spiceerrors"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// slowIterator checks its subiterator after a delay, recording how many slow
+// iterators are running at once and whether it was canceled while waiting.
+type slowIterator struct {
+	subIt      Iterator
+	delay      time.Duration
+	running    *atomic.Int32
+	maxRunning *atomic.Int32
+	canceled   atomic.Bool
+}
+
+var _ Iterator = &slowIterator{}
+
+func newSlowIterator(subIt Iterator, delay time.Duration, running, maxRunning *atomic.Int32) *slowIterator {
+	return &slowIterator{subIt: subIt, delay: delay, running: running, maxRunning: maxRunning}
+}
+
+func (s *slowIterator) CheckImpl(ctx *Context, resources []Object, subject ObjectAndRelation) (PathSeq, error) {
+	current := s.running.Add(1)
+	defer s.running.Add(-1)
+	for {
+		maxRunning := s.maxRunning.Load()
+		if current <= maxRunning || s.maxRunning.CompareAndSwap(maxRunning, current) {
+			break
+		}
+	}
+
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+		s.canceled.Store(true)
+		return nil, ctx.Err()
+	}
+
+	return ctx.Check(s.subIt, resources, subject)
+}
+
+func (s *slowIterator) IterSubjectsImpl(ctx *Context, resource Object) (PathSeq, error) {
+	return ctx.IterSubjects(s.subIt, resource)
+}
+
+func (s *slowIterator) IterResourcesImpl(ctx *Context, subject ObjectAndRelation) (PathSeq, error) {
+	return ctx.IterResources(s.subIt, subject)
+}
+
+func (s *slowIterator) Clone() Iterator {
+	return newSlowIterator(s.subIt.Clone(), s.delay, s.running, s.maxRunning)
+}
+
+func (s *slowIterator) Explain() Explain {
+	return Explain{Info: "Slow"}
+}
+
+func (s *slowIterator) Subiterators() []Iterator {
+	return nil
+}
+
+func (s *slowIterator) ReplaceSubiterators(newSubs []Iterator) (Iterator, error) {
+	return nil, spiceerrors.MustBugf("Trying to replace a leaf slowIterator's subiterators")
+}
+
+// checkResourceIDs checks the resources and returns the sorted IDs of those found.
+func checkResourceIDs(t *testing.T, ctx *Context, it Iterator, resources []Object, subject ObjectAndRelation) []string {
+	t.Helper()
+
+	pathSeq, err := ctx.Check(it, resources, subject)
+	require.NoError(t, err)
+
+	paths, err := CollectAll(pathSeq)
+	require.NoError(t, err)
+
+	resourceIDs := make([]string, 0, len(paths))
+	for _, path := range paths {
+		resourceIDs = append(resourceIDs, path.Resource.ObjectID)
+	}
+	slices.Sort(resourceIDs)
+	return resourceIDs
+}
+
+func TestParallelExecutorMatchesLocalExecutor(t *testing.T) {
+	t.Parallel()
+
+	resources := NewObjects("document", "doc1", "doc2", "doc3", "doc4")
+	subject := NewObject("user", "alice").WithEllipses()
+
+	trees := map[string]Iterator{
+		"union":        NewUnion(NewDocumentAccessFixedIterator(), NewMultiRoleFixedIterator(), NewEmptyFixedIterator()),
+		"intersection": NewIntersection(NewDocumentAccessFixedIterator(), NewMultiRoleFixedIterator()),
+		"empty intersection": NewIntersection(
+			NewDocumentAccessFixedIterator(),
+			NewEmptyFixedIterator(),
+		),
+		"nested": NewUnion(
+			NewIntersection(NewDocumentAccessFixedIterator(), NewSingleUserFixedIterator("alice")),
+			NewIntersection(NewMultiRoleFixedIterator(), NewUnion(NewDocumentAccessFixedIterator(), NewEmptyFixedIterator())),
+		),
+	}
+
+	for name, tree := range trees {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			localCtx := &Context{Context: t.Context(), Executor: LocalExecutor{}}
+			expected := checkResourceIDs(t, localCtx, tree, resources, subject)
+
+			for _, limit := range []uint16{0, 1, 10} {
+				parallelCtx := &Context{Context: t.Context(), Executor: NewParallelExecutor(limit)}
+				require.Equal(t, expected, checkResourceIDs(t, parallelCtx, tree, resources, subject), "limit %d", limit)
+			}
+		})
+	}
+}
+
+func TestParallelExecutorErrors(t *testing.T) {
+	t.Parallel()
+
+	for name, tree := range map[string]Iterator{
+		"union":        NewUnion(NewDocumentAccessFixedIterator(), NewFaultyIterator(true, false)),
+		"intersection": NewIntersection(NewDocumentAccessFixedIterator(), NewFaultyIterator(false, true)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			// doc7 has no path, so the union cannot complete before the faulty iterator.
+			ctx := &Context{Context: t.Context(), Executor: NewParallelExecutor(4)}
+			pathSeq, err := ctx.Check(tree, NewObjects("document", "doc1", "doc7"), NewObject("user", "alice").WithEllipses())
+			require.NoError(t, err)
+
+			_, err = CollectAll(pathSeq)
+			require.ErrorContains(t, err, "faulty iterator")
+		})
+	}
+}
+
+func TestParallelExecutorConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	for _, limit := range []uint16{0, 2} {
+		var running, maxRunning atomic.Int32
+		subIts := make([]Iterator, 0, 8)
+		for range 8 {
+			subIts = append(subIts, newSlowIterator(NewEmptyFixedIterator(), 20*time.Millisecond, &running, &maxRunning))
+		}
+
+		ctx := &Context{Context: t.Context(), Executor: NewParallelExecutor(limit)}
+		require.Empty(t, checkResourceIDs(t, ctx, NewUnion(subIts...), NewObjects("document", "doc1"), NewObject("user", "alice").WithEllipses()))
+
+		// The calling goroutine checks subiterators alongside those started for the limit.
+		require.LessOrEqual(t, maxRunning.Load(), int32(limit)+1)
+		if limit > 0 {
+			require.Greater(t, maxRunning.Load(), int32(1))
+		}
+	}
+}
+
+func TestParallelExecutorEarlyCancellation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("union with a path for every resource", func(t *testing.T) {
+		t.Parallel()
+
+		var running, maxRunning atomic.Int32
+		slow := newSlowIterator(NewMultiRoleFixedIterator(), time.Minute, &running, &maxRunning)
+
+		// The slow iterator is started concurrently, then the fixed iterator is
+		// checked on the calling goroutine and finds both resources.
+		ctx := &Context{Context: t.Context(), Executor: NewParallelExecutor(1)}
+		resourceIDs := checkResourceIDs(t, ctx, NewUnion(slow, NewDocumentAccessFixedIterator()), NewObjects("document", "doc1", "doc2"), NewObject("user", "alice").WithEllipses())
+		require.Equal(t, []string{"doc1", "doc2"}, resourceIDs)
+		require.True(t, slow.canceled.Load())
+	})
+
+	t.Run("union without a path for every resource", func(t *testing.T) {
+		t.Parallel()
+
+		var running, maxRunning atomic.Int32
+		slow := newSlowIterator(NewMultiRoleFixedIterator(), 10*time.Millisecond, &running, &maxRunning)
+
+		ctx := &Context{Context: t.Context(), Executor: NewParallelExecutor(1)}
+		resourceIDs := checkResourceIDs(t, ctx, NewUnion(slow, NewDocumentAccessFixedIterator()), NewObjects("document", "doc1", "doc3"), NewObject("user", "charlie").WithEllipses())
+		require.Equal(t, []string{"doc1", "doc3"}, resourceIDs)
+		require.False(t, slow.canceled.Load())
+	})
+
+	t.Run("union with caveated paths", func(t *testing.T) {
+		t.Parallel()
+
+		caveated := FromRelationship(tuple.MustParse("document:doc1#viewer@user:alice[somecaveat]"))
+		var running, maxRunning atomic.Int32
+		slow := newSlowIterator(NewDocumentAccessFixedIterator(), 10*time.Millisecond, &running, &maxRunning)
+
+		// A caveated path does not settle the resource, so the slow iterator is not canceled.
+		ctx := &Context{Context: t.Context(), Executor: NewParallelExecutor(1)}
+		pathSeq, err := ctx.Check(NewUnion(slow, NewFixedIterator(caveated)), NewObjects("document", "doc1"), NewObject("user", "alice").WithEllipses())
+		require.NoError(t, err)
+
+		paths, err := CollectAll(pathSeq)
+		require.NoError(t, err)
+		require.Len(t, paths, 1)
+		require.Nil(t, paths[0].Caveat, "the uncaveated path from the slow iterator must be merged in")
+		require.False(t, slow.canceled.Load())
+	})
+
+	t.Run("intersection with an empty subiterator", func(t *testing.T) {
+		t.Parallel()
+
+		var running, maxRunning atomic.Int32
+		slow := newSlowIterator(NewDocumentAccessFixedIterator(), time.Minute, &running, &maxRunning)
+
+		ctx := &Context{Context: t.Context(), Executor: NewParallelExecutor(1)}
+		require.Empty(t, checkResourceIDs(t, ctx, NewIntersection(slow, NewEmptyFixedIterator()), NewObjects("document", "doc1"), NewObject("user", "alice").WithEllipses()))
+		require.True(t, slow.canceled.Load())
+	})
+}
+
+func TestParallelExecutorWithDatastore(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	rawDS, err := dsfortesting.NewMemDBDatastoreForTesting(0, 0, memdb.DisableGC)
+	require.NoError(err)
+
+	ds, revision := testfixtures.StandardDatastoreWithData(rawDS, require)
+
+	objectDefs := []*corev1.NamespaceDefinition{testfixtures.UserNS.CloneVT(), testfixtures.FolderNS.CloneVT(), testfixtures.DocumentNS.CloneVT()}
+	dsSchema, err := schema.BuildSchemaFromDefinitions(objectDefs, nil)
+	require.NoError(err)
+
+	resources := NewObjects("document", "masterplan", "companyplan", "specialplan", "healthplan", "ownerplan")
+	for _, permission := range []string{"view", "edit", "view_and_edit"} {
+		it, err := BuildIteratorFromSchema(dsSchema, "document", permission)
+		require.NoError(err)
+
+		for _, userID := range []string{"owner", "legal", "multiroleguy", "chief_financial_officer", "villain"} {
+			subject := NewObject("user", userID).WithEllipses()
+
+			localCtx := &Context{Context: t.Context(), Executor: LocalExecutor{}, Reader: ds.SnapshotReader(revision), MaxRecursionDepth: 5}
+			expected := checkResourceIDs(t, localCtx, it, resources, subject)
+
+			parallelCtx := &Context{Context: t.Context(), Executor: NewParallelExecutor(4), Reader: ds.SnapshotReader(revision), MaxRecursionDepth: 5}
+			require.Equal(expected, checkResourceIDs(t, parallelCtx, it, resources, subject), "%s for %s", permission, userID)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package query
+
+import (
+	"context"
+	"sync/atomic"
+
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/authzed/spicedb/pkg/datastore"
+	dispatchv1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+)
+
+// QueryPlanDispatcher sends a serialized subtree of a query plan to be evaluated, usually
+// by whichever node of the cluster owns it.
+type QueryPlanDispatcher interface {
+	DispatchQueryPlan(ctx context.Context, req *dispatchv1.DispatchQueryPlanRequest) (*dispatchv1.DispatchQueryPlanResponse, error)
+}
+
+// RemoteExecutor is an executor which dispatches the subtrees of relations and permissions
+// that reach beyond the relationships of the resource, such as through arrows, to be
+// evaluated elsewhere. All other iterators are evaluated by the local executor given.
+//
+// Each dispatch uses one level of the depth remaining; once it is exhausted, or if the
+// query is being traced, subtrees are evaluated locally instead. Analyzed queries are
+// dispatched, with the analysis of each subtree returned by the node evaluating it.
+type RemoteExecutor struct {
+	dispatcher     QueryPlanDispatcher
+	revision       string
+	depthRemaining uint32
+	local          Executor
+
+	dispatchCount atomic.Uint32
+	depthRequired atomic.Uint32
+}
+
+var _ Executor = &RemoteExecutor{}
+
+// NewRemoteExecutor creates an executor which dispatches subtrees, evaluated at the given
+// revision, through the dispatcher.
+func NewRemoteExecutor(dispatcher QueryPlanDispatcher, revision datastore.Revision, depthRemaining uint32, local Executor) *RemoteExecutor {
+	return &RemoteExecutor{
+		dispatcher:     dispatcher,
+		revision:       revision.String(),
+		depthRemaining: depthRemaining,
+		local:          local,
+	}
+}
+
+// DispatchCount returns the number of dispatches made by this executor, including those
+// made by the nodes evaluating them.
+func (r *RemoteExecutor) DispatchCount() uint32 {
+	return r.dispatchCount.Load()
+}
+
+// DepthRequired returns the greatest depth required by the dispatches made by this executor.
+func (r *RemoteExecutor) DepthRequired() uint32 {
+	return r.depthRequired.Load()
+}
+
+// Check tests if, for the underlying set of relationships (which may be a full expression or a basic lookup, depending on the iterator)
+// any of the `resources` are connected to `subject`.
+// Returns the sequence of matching paths, if they exist, at most `len(resources)`.
+func (r *RemoteExecutor) Check(ctx *Context, it Iterator, resources []Object, subject ObjectAndRelation) (PathSeq, error) {
+	if len(resources) == 0 || !r.shouldDispatch(ctx, it) {
+		return r.local.Check(ctx, it, resources, subject)
+	}
+
+	// Resources are dispatched in a request for each of their types.
+	var resourceTypes []string
+	resourceIDsByType := make(map[string][]string)
+	for _, resource := range resources {
+		if _, ok := resourceIDsByType[resource.ObjectType]; !ok {
+			resourceTypes = append(resourceTypes, resource.ObjectType)
+		}
+		resourceIDsByType[resource.ObjectType] = append(resourceIDsByType[resource.ObjectType], resource.ObjectID)
+	}
+
+	var paths []Path
+	for _, resourceType := range resourceTypes {
+		found, err := r.dispatch(ctx, it, dispatchv1.DispatchQueryPlanRequest_CHECK, func(req *dispatchv1.DispatchQueryPlanRequest) {
+			req.ResourceType = resourceType
+			req.ResourceIds = resourceIDsByType[resourceType]
+			req.Subject = subject.ToCoreONR()
+		})
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, found...)
+	}
+	return pathSeqOf(paths), nil
+}
+
+// IterSubjects returns a sequence of all the paths in this set that match the given resource.
+func (r *RemoteExecutor) IterSubjects(ctx *Context, it Iterator, resource Object) (PathSeq, error) {
+	if !r.shouldDispatch(ctx, it) {
+		return r.local.IterSubjects(ctx, it, resource)
+	}
+
+	paths, err := r.dispatch(ctx, it, dispatchv1.DispatchQueryPlanRequest_ITER_SUBJECTS, func(req *dispatchv1.DispatchQueryPlanRequest) {
+		req.ResourceType = resource.ObjectType
+		req.ResourceIds = []string{resource.ObjectID}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pathSeqOf(paths), nil
+}
+
+// IterResources returns a sequence of all the paths in this set that match the given subject.
+func (r *RemoteExecutor) IterResources(ctx *Context, it Iterator, subject ObjectAndRelation) (PathSeq, error) {
+	if !r.shouldDispatch(ctx, it) {
+		return r.local.IterResources(ctx, it, subject)
+	}
+
+	paths, err := r.dispatch(ctx, it, dispatchv1.DispatchQueryPlanRequest_ITER_RESOURCES, func(req *dispatchv1.DispatchQueryPlanRequest) {
+		req.Subject = subject.ToCoreONR()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pathSeqOf(paths), nil
+}
+
+// shouldDispatch returns whether the iterator is to be dispatched, rather than evaluated locally.
+func (r *RemoteExecutor) shouldDispatch(ctx *Context, it Iterator) bool {
+	// Traces are recorded locally, so traced queries are not dispatched.
+	if ctx.TraceLogger != nil || r.depthRemaining == 0 {
+		return false
+	}
+
+	alias, ok := it.(*Alias)
+	return ok && reachesOtherRelations(alias.subIt)
+}
+
+// reachesOtherRelations returns whether evaluating the iterator walks to relationships of
+// other objects, such as through arrows. Relations and permissions which only read the
+// relationships of the resource itself are cheaper to evaluate locally than to dispatch.
+func reachesOtherRelations(it Iterator) bool {
+	switch it.(type) {
+	case *Arrow, *IntersectionArrow, *RecursiveIterator:
+		return true
+	}
+
+	for _, sub := range it.Subiterators() {
+		if reachesOtherRelations(sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// concurrencyLimit returns the concurrency limit of the local executor, which is sent with
+// each dispatch so that the subtrees are evaluated within the limit of the query. It is
+// nil if the local executor has no known limit.
+func (r *RemoteExecutor) concurrencyLimit() *uint32 {
+	var limit uint32
+	switch local := r.local.(type) {
+	case *ParallelExecutor:
+		limit = uint32(local.ConcurrencyLimit())
+	case LocalExecutor:
+		limit = 0
+	default:
+		return nil
+	}
+	return &limit
+}
+
+// dispatch serializes the iterator and dispatches it for the operation, returning the
+// paths found.
+func (r *RemoteExecutor) dispatch(
+	ctx *Context,
+	it Iterator,
+	operation dispatchv1.DispatchQueryPlanRequest_Operation,
+	setArguments func(req *dispatchv1.DispatchQueryPlanRequest),
+) ([]Path, error) {
+	plan, err := SerializeIterator(it)
+	if err != nil {
+		return nil, err
+	}
+
+	var caveatContext *structpb.Struct
+	if len(ctx.CaveatContext) > 0 {
+		caveatContext, err = structpb.NewStruct(ctx.CaveatContext)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req := &dispatchv1.DispatchQueryPlanRequest{
+		Metadata: &dispatchv1.ResolverMeta{
+			AtRevision:     r.revision,
+			DepthRemaining: r.depthRemaining,
+		},
+		Plan:              plan,
+		Operation:         operation,
+		CaveatContext:     caveatContext,
+		MaxRecursionDepth: uint32(max(ctx.MaxRecursionDepth, 0)),
+		Analyze:           ctx.Analyzer != nil,
+		ConcurrencyLimit:  r.concurrencyLimit(),
+	}
+	setArguments(req)
+
+	resp, err := r.dispatcher.DispatchQueryPlan(ctx, req)
+	ctx.recordDispatch(resp)
+	if resp.GetMetadata() != nil {
+		r.dispatchCount.Add(resp.Metadata.DispatchCount)
+		for {
+			current := r.depthRequired.Load()
+			if resp.Metadata.DepthRequired <= current || r.depthRequired.CompareAndSwap(current, resp.Metadata.DepthRequired) {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]Path, 0, len(resp.Paths))
+	for _, path := range resp.Paths {
+		paths = append(paths, PathFromProto(path))
+	}
+	return paths, nil
+}
+
+func pathSeqOf(paths []Path) PathSeq {
+	return func(yield func(Path, error) bool) {
+		for _, path := range paths {
+			if !yield(path, nil) {
+				return
+			}
+		}
+	}
+}
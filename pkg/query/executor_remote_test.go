@@ -0,0 +1,284 @@
+package query
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/datastore/dsfortesting"
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	"github.com/authzed/spicedb/internal/testfixtures"
+	"github.com/authzed/spicedb/pkg/datastore"
+	corev1 "github.com/authzed/spicedb/pkg/proto/core/v1"
+	dispatchv1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/schema/v2"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// loopbackDispatcher evaluates dispatched subtrees in-process, the same way a node of the
+// cluster would, after round-tripping them through their serialized form.
+type loopbackDispatcher struct {
+	t        *testing.T
+	reader   datastore.Reader
+	revision datastore.Revision
+	schema   *schema.Schema
+
+	requests atomic.Int32
+}
+
+func (l *loopbackDispatcher) DispatchQueryPlan(ctx context.Context, req *dispatchv1.DispatchQueryPlanRequest) (*dispatchv1.DispatchQueryPlanResponse, error) {
+	l.requests.Add(1)
+	require.NotZero(l.t, req.Metadata.DepthRemaining)
+
+	it, err := DeserializeIterator(req.Plan, l.schema)
+	if err != nil {
+		return nil, err
+	}
+
+	executor := NewRemoteExecutor(l, l.revision, req.Metadata.DepthRemaining-1, LocalExecutor{})
+	qctx := &Context{
+		Context:           ctx,
+		Executor:          executor,
+		Reader:            l.reader,
+		CaveatContext:     req.CaveatContext.AsMap(),
+		MaxRecursionDepth: int(req.MaxRecursionDepth),
+	}
+	if req.Analyze {
+		qctx.Analyzer = NewAnalyzer()
+	}
+
+	var pathSeq PathSeq
+	switch req.Operation {
+	case dispatchv1.DispatchQueryPlanRequest_CHECK:
+		pathSeq, err = it.CheckImpl(qctx, NewObjects(req.ResourceType, req.ResourceIds...), tuple.FromCoreObjectAndRelation(req.Subject))
+	case dispatchv1.DispatchQueryPlanRequest_ITER_SUBJECTS:
+		pathSeq, err = it.IterSubjectsImpl(qctx, NewObject(req.ResourceType, req.ResourceIds[0]))
+	case dispatchv1.DispatchQueryPlanRequest_ITER_RESOURCES:
+		pathSeq, err = it.IterResourcesImpl(qctx, tuple.FromCoreObjectAndRelation(req.Subject))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := CollectAll(pathSeq)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dispatchv1.DispatchQueryPlanResponse{
+		Metadata: &dispatchv1.ResponseMeta{
+			DispatchCount: executor.DispatchCount() + 1,
+			DepthRequired: executor.DepthRequired() + 1,
+		},
+	}
+	if qctx.Analyzer != nil {
+		resp.Metadata.DebugInfo = &dispatchv1.DebugInformation{
+			QueryPlanAnalysis: qctx.Analyzer.Analysis(),
+		}
+	}
+	for _, path := range paths {
+		resp.Paths = append(resp.Paths, PathToProto(path))
+	}
+	return resp, nil
+}
+
+func newLoopbackDispatcherForTesting(t *testing.T) *loopbackDispatcher {
+	t.Helper()
+
+	rawDS, err := dsfortesting.NewMemDBDatastoreForTesting(0, 0, memdb.DisableGC)
+	require.NoError(t, err)
+
+	ds, revision := testfixtures.StandardDatastoreWithData(rawDS, require.New(t))
+
+	objectDefs := []*corev1.NamespaceDefinition{testfixtures.UserNS.CloneVT(), testfixtures.FolderNS.CloneVT(), testfixtures.DocumentNS.CloneVT()}
+	dsSchema, err := schema.BuildSchemaFromDefinitions(objectDefs, nil)
+	require.NoError(t, err)
+
+	return &loopbackDispatcher{
+		t:        t,
+		reader:   ds.SnapshotReader(revision),
+		revision: revision,
+		schema:   dsSchema,
+	}
+}
+
+func pathKeys(t *testing.T, pathSeq PathSeq, err error) []string {
+	t.Helper()
+	require.NoError(t, err)
+
+	paths, err := CollectAll(pathSeq)
+	require.NoError(t, err)
+
+	keys := make([]string, 0, len(paths))
+	for _, path := range paths {
+		keys = append(keys, path.Resource.ObjectType+":"+path.Resource.ObjectID+"#"+path.Relation+"@"+path.Subject.ObjectType+":"+path.Subject.ObjectID)
+	}
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
+
+func TestRemoteExecutorMatchesLocalExecutor(t *testing.T) {
+	t.Parallel()
+
+	for _, relation := range []string{"view", "edit", "view_and_edit"} {
+		t.Run(relation, func(t *testing.T) {
+			t.Parallel()
+
+			dispatcher := newLoopbackDispatcherForTesting(t)
+			it, err := BuildIteratorFromSchema(dispatcher.schema, "document", relation)
+			require.NoError(t, err)
+
+			localCtx := &Context{
+				Context:           t.Context(),
+				Executor:          LocalExecutor{},
+				Reader:            dispatcher.reader,
+				MaxRecursionDepth: 5,
+			}
+
+			executor := NewRemoteExecutor(dispatcher, dispatcher.revision, 50, LocalExecutor{})
+			remoteCtx := &Context{
+				Context:           t.Context(),
+				Executor:          executor,
+				Reader:            dispatcher.reader,
+				MaxRecursionDepth: 5,
+			}
+
+			resources := NewObjects("document", "masterplan", "companyplan", "specialplan", "ownerplan", "healthplan")
+			for _, userID := range []string{"product_manager", "chief_financial_officer", "multiroleguy", "owner", "villain"} {
+				subject := NewObject("user", userID).WithEllipses()
+
+				expected, err := localCtx.Check(it, resources, subject)
+				found, remoteErr := remoteCtx.Check(it, resources, subject)
+				require.Equal(t, pathKeys(t, expected, err), pathKeys(t, found, remoteErr), "check for %s", userID)
+			}
+
+			require.Equal(t, uint32(dispatcher.requests.Load()), executor.DispatchCount())
+		})
+	}
+}
+
+func TestRemoteExecutorDispatchesOnlyReachingSubtrees(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newLoopbackDispatcherForTesting(t)
+
+	// `edit` only reads the relationships of `owner` and `editor`, so is never dispatched.
+	edit, err := BuildIteratorFromSchema(dispatcher.schema, "document", "edit")
+	require.NoError(t, err)
+
+	executor := NewRemoteExecutor(dispatcher, dispatcher.revision, 50, LocalExecutor{})
+	ctx := &Context{
+		Context:           t.Context(),
+		Executor:          executor,
+		Reader:            dispatcher.reader,
+		MaxRecursionDepth: 5,
+	}
+
+	pathSeq, err := ctx.Check(edit, NewObjects("document", "specialplan"), NewObject("user", "multiroleguy").WithEllipses())
+	require.Len(t, pathKeys(t, pathSeq, err), 1)
+	require.Zero(t, dispatcher.requests.Load())
+	require.Zero(t, executor.DispatchCount())
+
+	// `view` reaches the folders through `parent`, so is dispatched.
+	view, err := BuildIteratorFromSchema(dispatcher.schema, "document", "view")
+	require.NoError(t, err)
+
+	pathSeq, err = ctx.Check(view, NewObjects("document", "masterplan"), NewObject("user", "product_manager").WithEllipses())
+	require.Len(t, pathKeys(t, pathSeq, err), 1)
+	require.NotZero(t, dispatcher.requests.Load())
+	require.NotZero(t, executor.DepthRequired())
+}
+
+func TestRemoteExecutorFallsBackLocally(t *testing.T) {
+	t.Parallel()
+
+	dispatcher := newLoopbackDispatcherForTesting(t)
+	view, err := BuildIteratorFromSchema(dispatcher.schema, "document", "view")
+	require.NoError(t, err)
+
+	resources := NewObjects("document", "masterplan")
+	subject := NewObject("user", "product_manager").WithEllipses()
+
+	t.Run("no depth remaining", func(t *testing.T) {
+		ctx := &Context{
+			Context:           t.Context(),
+			Executor:          NewRemoteExecutor(dispatcher, dispatcher.revision, 0, LocalExecutor{}),
+			Reader:            dispatcher.reader,
+			MaxRecursionDepth: 5,
+		}
+
+		pathSeq, err := ctx.Check(view, resources, subject)
+		require.Len(t, pathKeys(t, pathSeq, err), 1)
+		require.Zero(t, dispatcher.requests.Load())
+	})
+
+	t.Run("traced", func(t *testing.T) {
+		ctx := &Context{
+			Context:           t.Context(),
+			Executor:          NewRemoteExecutor(dispatcher, dispatcher.revision, 50, LocalExecutor{}),
+			Reader:            dispatcher.reader,
+			TraceLogger:       NewTraceLogger(),
+			MaxRecursionDepth: 5,
+		}
+
+		pathSeq, err := ctx.Check(view, resources, subject)
+		require.Len(t, pathKeys(t, pathSeq, err), 1)
+		require.Zero(t, dispatcher.requests.Load())
+	})
+}
+
+// limitRecordingDispatcher records the concurrency limits of the requests it dispatches.
+type limitRecordingDispatcher struct {
+	*loopbackDispatcher
+
+	lock   sync.Mutex
+	limits []*uint32
+}
+
+func (d *limitRecordingDispatcher) DispatchQueryPlan(ctx context.Context, req *dispatchv1.DispatchQueryPlanRequest) (*dispatchv1.DispatchQueryPlanResponse, error) {
+	d.lock.Lock()
+	d.limits = append(d.limits, req.ConcurrencyLimit)
+	d.lock.Unlock()
+	return d.loopbackDispatcher.DispatchQueryPlan(ctx, req)
+}
+
+func TestRemoteExecutorSendsConcurrencyLimit(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name          string
+		local         Executor
+		expectedLimit uint32
+	}{
+		{"parallel", NewParallelExecutor(3), 3},
+		{"local", LocalExecutor{}, 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dispatcher := &limitRecordingDispatcher{loopbackDispatcher: newLoopbackDispatcherForTesting(t)}
+			view, err := BuildIteratorFromSchema(dispatcher.schema, "document", "view")
+			require.NoError(t, err)
+
+			ctx := &Context{
+				Context:           t.Context(),
+				Executor:          NewRemoteExecutor(dispatcher, dispatcher.revision, 50, tc.local),
+				Reader:            dispatcher.reader,
+				MaxRecursionDepth: 5,
+			}
+
+			pathSeq, err := ctx.Check(view, NewObjects("document", "masterplan"), NewObject("user", "product_manager").WithEllipses())
+			require.Len(t, pathKeys(t, pathSeq, err), 1)
+
+			require.NotEmpty(t, dispatcher.limits)
+			for _, limit := range dispatcher.limits {
+				require.NotNil(t, limit)
+				require.Equal(t, tc.expectedLimit, *limit)
+			}
+		})
+	}
+}
@@ -26,6 +26,14 @@ func (i *Intersection) addSubIterator(subIt Iterator) {
 }
 
 func (i *Intersection) CheckImpl(ctx *Context, resources []Object, subject ObjectAndRelation) (PathSeq, error) {
+	return i.intersect(ctx, resources, func(_ int, it Iterator, validResources []Object) (PathSeq, error) {
+		return ctx.Check(it, validResources, subject)
+	})
+}
+
+// intersect intersects the paths found by find for each subiterator, among the resources
+// for which every previous subiterator found a path.
+func (i *Intersection) intersect(ctx *Context, resources []Object, find func(iterIdx int, it Iterator, validResources []Object) (PathSeq, error)) (PathSeq, error) {
 	validResources := resources
 
 	// Track paths by resource key for combining with AND logic
@@ -34,7 +42,7 @@ func (i *Intersection) CheckImpl(ctx *Context, resources []Object, subject Objec
 	for iterIdx, it := range i.subIts {
 		ctx.TraceStep(i, "processing sub-iterator %d with %d resources", iterIdx, len(validResources))
 
-		pathSeq, err := ctx.Check(it, validResources, subject)
+		pathSeq, err := find(iterIdx, it, validResources)
 		if err != nil {
 			return nil, err
 		}
@@ -52,53 +60,17 @@ func (i *Intersection) CheckImpl(ctx *Context, resources []Object, subject Objec
 
 		if iterIdx == 0 {
 			// First iterator - initialize pathsByKey using endpoint-based keys
-			for _, path := range paths {
-				key := path.Resource.Key()
-				if existing, exists := pathsByKey[key]; !exists {
-					pathsByKey[key] = path
-				} else {
-					// If multiple paths for same endpoint in first iterator, merge with OR
-					merged, err := existing.MergeOr(path)
-					if err != nil {
-						return nil, err
-					}
-					pathsByKey[key] = merged
-				}
+			pathsByKey, err = mergePathsByResource(paths)
+			if err != nil {
+				return nil, err
 			}
 		} else {
 			// Subsequent iterators - intersect based on endpoints and combine caveats
-			newPathsByKey := make(map[string]Path)
-
-			// First collect all paths from this iterator by endpoint
-			currentIterPaths := make(map[string]Path)
-			for _, path := range paths {
-				key := path.Resource.Key()
-				if existing, exists := currentIterPaths[key]; !exists {
-					currentIterPaths[key] = path
-				} else {
-					// Multiple paths for same endpoint in current iterator, merge with OR
-					merged, err := existing.MergeOr(path)
-					if err != nil {
-						return nil, err
-					}
-					currentIterPaths[key] = merged
-				}
+			pathsByKey, err = intersectPathsByResource(pathsByKey, paths)
+			if err != nil {
+				return nil, err
 			}
 
-			// Now intersect: only keep endpoints that exist in both previous and current
-			for key, currentPath := range currentIterPaths {
-				if existing, exists := pathsByKey[key]; exists {
-					// Combine using intersection logic (AND)
-					combined, err := existing.MergeAnd(currentPath)
-					if err != nil {
-						return nil, err
-					}
-					newPathsByKey[key] = combined
-				}
-				// If endpoint not in previous results, it's filtered out (intersection)
-			}
-			pathsByKey = newPathsByKey
-
 			if len(pathsByKey) == 0 {
 				return func(yield func(Path, error) bool) {}, nil
 			}
@@ -125,12 +97,63 @@ func (i *Intersection) CheckImpl(ctx *Context, resources []Object, subject Objec
 	}, nil
 }
 
+// mergePathsByResource keys the paths by their resource, merging multiple paths
+// for the same resource with OR.
+func mergePathsByResource(paths []Path) (map[string]Path, error) {
+	pathsByKey := make(map[string]Path, len(paths))
+	for _, path := range paths {
+		key := path.Resource.Key()
+		if existing, exists := pathsByKey[key]; !exists {
+			pathsByKey[key] = path
+		} else {
+			merged, err := existing.MergeOr(path)
+			if err != nil {
+				return nil, err
+			}
+			pathsByKey[key] = merged
+		}
+	}
+	return pathsByKey, nil
+}
+
+// intersectPathsByResource keeps only the resources of pathsByKey which also have
+// one of the paths, combining the caveats of both with AND.
+func intersectPathsByResource(pathsByKey map[string]Path, paths []Path) (map[string]Path, error) {
+	// First collect all paths from this iterator by endpoint
+	currentIterPaths, err := mergePathsByResource(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	// Now intersect: only keep endpoints that exist in both previous and current
+	newPathsByKey := make(map[string]Path)
+	for key, currentPath := range currentIterPaths {
+		if existing, exists := pathsByKey[key]; exists {
+			// Combine using intersection logic (AND)
+			combined, err := existing.MergeAnd(currentPath)
+			if err != nil {
+				return nil, err
+			}
+			newPathsByKey[key] = combined
+		}
+		// If endpoint not in previous results, it's filtered out (intersection)
+	}
+	return newPathsByKey, nil
+}
+
 func (i *Intersection) IterSubjectsImpl(ctx *Context, resource Object) (PathSeq, error) {
 	return nil, spiceerrors.MustBugf("unimplemented")
 }
 
+// IterResourcesImpl iterates the resources of the first subiterator for the subject, and
+// checks those found against the remaining subiterators.
 func (i *Intersection) IterResourcesImpl(ctx *Context, subject ObjectAndRelation) (PathSeq, error) {
-	return nil, spiceerrors.MustBugf("unimplemented")
+	return i.intersect(ctx, nil, func(iterIdx int, it Iterator, validResources []Object) (PathSeq, error) {
+		if iterIdx == 0 {
+			return ctx.IterResources(it, subject)
+		}
+		return ctx.Check(it, validResources, subject)
+	})
 }
 
 func (i *Intersection) Clone() Iterator {
@@ -140,8 +140,43 @@ func (ia *IntersectionArrow) IterSubjectsImpl(ctx *Context, resource Object) (Pa
 	return nil, spiceerrors.MustBugf("unimplemented")
 }
 
+// IterResourcesImpl finds the resources reaching, through the left side, an object which
+// reaches the subject through the right side. As each of them must reach the subject
+// through all of the objects of its left side, they are then checked.
 func (ia *IntersectionArrow) IterResourcesImpl(ctx *Context, subject ObjectAndRelation) (PathSeq, error) {
-	return nil, spiceerrors.MustBugf("unimplemented")
+	rightSeq, err := ctx.IterResources(ia.right, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []Path
+	for rightPath, err := range rightSeq {
+		if err != nil {
+			return nil, err
+		}
+
+		intermediate := rightPath.Resource.WithEllipses()
+		ctx.TraceStep(ia, "iterating left side resources for subject %s:%s", intermediate.ObjectType, intermediate.ObjectID)
+
+		leftSeq, err := ctx.IterResources(ia.left, intermediate)
+		if err != nil {
+			return nil, err
+		}
+
+		leftPaths, err := CollectAll(leftSeq)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, leftPaths...)
+	}
+
+	resources := resourcesOf(candidates)
+	if len(resources) == 0 {
+		return EmptyPathSeq(), nil
+	}
+
+	ctx.TraceStep(ia, "checking %d candidate resources", len(resources))
+	return ia.CheckImpl(ctx, resources, subject)
 }
 
 func (ia *IntersectionArrow) Clone() Iterator {
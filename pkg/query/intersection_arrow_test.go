@@ -610,11 +610,39 @@ func TestIntersectionArrowIteratorUnimplementedMethods(t *testing.T) {
 		})
 	})
 
-	t.Run("IterResources_Unimplemented", func(t *testing.T) {
-		t.Parallel()
+}
 
-		require.Panics(func() {
-			_, _ = ctx.IterResources(intersectionArrow, ObjectAndRelation{ObjectType: "user", ObjectID: "alice"})
-		})
-	})
+func TestIntersectionArrowIteratorIterResources(t *testing.T) {
+	t.Parallel()
+
+	ctx := &Context{
+		Context:  t.Context(),
+		Executor: LocalExecutor{},
+	}
+
+	// doc1 is in both groups, but alice is only a member of group1; doc2 is only in group1.
+	leftIter := NewFixedIterator(
+		MustPathFromString("document:doc1#group@group:group1"),
+		MustPathFromString("document:doc1#group@group:group2"),
+		MustPathFromString("document:doc2#group@group:group1"),
+	)
+	rightIter := NewFixedIterator(
+		MustPathFromString("group:group1#member@user:alice"),
+		MustPathFromString("group:group2#member@user:bob"),
+	)
+
+	pathSeq, err := ctx.IterResources(NewIntersectionArrow(leftIter, rightIter), NewObject("user", "alice").WithEllipses())
+	require.NoError(t, err)
+
+	paths, err := CollectAll(pathSeq)
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+	require.Equal(t, NewObject("document", "doc2"), paths[0].Resource)
+
+	pathSeq, err = ctx.IterResources(NewIntersectionArrow(leftIter, rightIter), NewObject("user", "nobody").WithEllipses())
+	require.NoError(t, err)
+
+	paths, err = CollectAll(pathSeq)
+	require.NoError(t, err)
+	require.Empty(t, paths)
 }
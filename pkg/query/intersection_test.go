@@ -157,16 +157,45 @@ func TestIntersectionIterator(t *testing.T) {
 		})
 	})
 
-	t.Run("IterResources_Unimplemented", func(t *testing.T) {
+	t.Run("IterResources_Empty", func(t *testing.T) {
 		t.Parallel()
 
 		intersect := NewIntersection()
-		require.Panics(func() {
-			_, _ = ctx.IterResources(intersect, NewObject("user", "alice").WithEllipses())
-		})
+		pathSeq, err := ctx.IterResources(intersect, NewObject("user", "alice").WithEllipses())
+		require.NoError(err)
+
+		rels, err := CollectAll(pathSeq)
+		require.NoError(err)
+		require.Empty(rels)
 	})
 }
 
+func TestIntersectionIteratorIterResources(t *testing.T) {
+	t.Parallel()
+
+	ctx := &Context{
+		Context:  t.Context(),
+		Executor: LocalExecutor{},
+	}
+
+	viewers := NewFixedIterator(
+		MustPathFromString("document:doc1#viewer@user:alice"),
+		MustPathFromString("document:doc2#viewer@user:alice"),
+	)
+	editors := NewFixedIterator(
+		MustPathFromString("document:doc2#editor@user:alice"),
+		MustPathFromString("document:doc3#editor@user:alice"),
+	)
+
+	pathSeq, err := ctx.IterResources(NewIntersection(viewers, editors), NewObject("user", "alice").WithEllipses())
+	require.NoError(t, err)
+
+	paths, err := CollectAll(pathSeq)
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+	require.Equal(t, NewObject("document", "doc2"), paths[0].Resource)
+}
+
 func TestIntersectionIteratorClone(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,316 @@
+package query
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/schema/v2"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// Statistics are the estimates of the contents of a datastore that are used by the
+// cost-based optimizer.
+type Statistics struct {
+	// RelationshipCount is the estimated number of relationships in the datastore.
+	RelationshipCount uint64
+
+	// RelationshipCountsByType is the estimated number of relationships for each resource
+	// type. If nil, relationships are assumed to be spread evenly across ObjectTypeCount types,
+	// which is the case for the datastores that do not report per-type counts (all but memdb
+	// and SQLite), so their plans are only ordered by the shape of the schema.
+	RelationshipCountsByType map[string]uint64
+
+	// ObjectTypeCount is the number of object types defined in the datastore.
+	ObjectTypeCount int
+}
+
+// StatisticsFromDatastore builds the optimizer statistics from those reported by a datastore.
+func StatisticsFromDatastore(stats datastore.Stats) Statistics {
+	return Statistics{
+		RelationshipCount:        stats.EstimatedRelationshipCount,
+		RelationshipCountsByType: stats.RelationshipCountsByType,
+		ObjectTypeCount:          len(stats.ObjectTypeStatistics),
+	}
+}
+
+// CostEstimate is the cost-based optimizer's estimate of evaluating an iterator.
+type CostEstimate struct {
+	// Cost is the estimated number of datastore queries made to check a single resource
+	// against a single subject.
+	Cost float64
+
+	// Selectivity is the estimated probability, between 0 and 1, that checking a single
+	// resource against a single subject finds a path.
+	Selectivity float64
+
+	// Fanout is the estimated number of subjects reached from a single resource.
+	Fanout float64
+
+	// Fanin is the estimated number of resources reached from a single subject.
+	Fanin float64
+}
+
+// unknownEstimate is used for iterators the cost model knows nothing about.
+var unknownEstimate = CostEstimate{Cost: 1, Selectivity: 0.5, Fanout: 1, Fanin: 1}
+
+// Estimate returns the estimated cost of checking with the iterator tree, evaluated in its
+// current order.
+//
+// In the absence of statistics on the number of distinct objects, a relation of n
+// relationships is assumed to connect each resource to √n subjects and each subject
+// to √n resources, and to contain a given pair with a probability of √(n/N), where N
+// is the number of relationships in the datastore.
+func (s Statistics) Estimate(it Iterator) CostEstimate {
+	switch v := it.(type) {
+	case *RelationIterator:
+		count := s.relationshipCount(v.base)
+		spread := math.Max(1, math.Sqrt(count))
+		return CostEstimate{
+			Cost:        1,
+			Selectivity: math.Min(1, math.Sqrt(count/math.Max(1, float64(s.RelationshipCount)))),
+			Fanout:      spread,
+			Fanin:       spread,
+		}
+
+	case *FixedIterator:
+		if len(v.paths) == 0 {
+			return CostEstimate{}
+		}
+		return CostEstimate{Selectivity: 1, Fanout: float64(len(v.paths)), Fanin: float64(len(v.paths))}
+
+	case *RecursiveSentinel:
+		// The sentinel is replaced by the tree itself on each level of the recursion, which is
+		// accounted for by the RecursiveIterator.
+		return CostEstimate{}
+
+	case *Alias:
+		return s.Estimate(v.subIt)
+
+	case *CaveatIterator:
+		return s.Estimate(v.subiterator)
+
+	case *RecursiveIterator:
+		return s.Estimate(v.templateTree)
+
+	case *Union:
+		// A check is complete as soon as a path is found, so later children are
+		// only evaluated if the earlier ones found nothing.
+		estimate := CostEstimate{}
+		missProbability := 1.0
+		for _, sub := range v.subIts {
+			subEstimate := s.Estimate(sub)
+			estimate.Cost += missProbability * subEstimate.Cost
+			estimate.Fanout += subEstimate.Fanout
+			estimate.Fanin += subEstimate.Fanin
+			missProbability *= 1 - subEstimate.Selectivity
+		}
+		estimate.Selectivity = 1 - missProbability
+		return estimate
+
+	case *Intersection:
+		// A check is complete as soon as a child finds nothing, so later children are
+		// only evaluated if the earlier ones found a path.
+		if len(v.subIts) == 0 {
+			return CostEstimate{}
+		}
+		estimate := CostEstimate{Selectivity: 1, Fanout: math.Inf(1), Fanin: math.Inf(1)}
+		for _, sub := range v.subIts {
+			subEstimate := s.Estimate(sub)
+			estimate.Cost += estimate.Selectivity * subEstimate.Cost
+			estimate.Fanout = math.Min(estimate.Fanout, subEstimate.Fanout)
+			estimate.Fanin = math.Min(estimate.Fanin, subEstimate.Fanin)
+			estimate.Selectivity *= subEstimate.Selectivity
+		}
+		return estimate
+
+	case *Exclusion:
+		main := s.Estimate(v.mainSet)
+		excluded := s.Estimate(v.excluded)
+		return CostEstimate{
+			Cost:        main.Cost + main.Selectivity*excluded.Cost,
+			Selectivity: main.Selectivity * (1 - excluded.Selectivity),
+			Fanout:      main.Fanout,
+			Fanin:       main.Fanin,
+		}
+
+	case *Arrow:
+		left := s.Estimate(v.left)
+		right := s.Estimate(v.right)
+		return CostEstimate{
+			Cost:        arrowCost(left, right, v.direction),
+			Selectivity: 1 - math.Pow(1-right.Selectivity, left.Fanout),
+			Fanout:      left.Fanout * right.Fanout,
+			Fanin:       left.Fanin * right.Fanin,
+		}
+
+	case *IntersectionArrow:
+		left := s.Estimate(v.left)
+		right := s.Estimate(v.right)
+		return CostEstimate{
+			Cost:        arrowCost(left, right, ArrowLeftToRight),
+			Selectivity: math.Pow(right.Selectivity, left.Fanout),
+			Fanout:      left.Fanout * right.Fanout,
+			Fanin:       left.Fanin * right.Fanin,
+		}
+
+	default:
+		return unknownEstimate
+	}
+}
+
+// arrowCost estimates the cost of checking an arrow in the given direction: iterating
+// one side and checking the other side for each object reached.
+func arrowCost(left, right CostEstimate, direction ArrowDirection) float64 {
+	if direction == ArrowRightToLeft {
+		return right.Cost + right.Fanin*left.Cost
+	}
+	return left.Cost + left.Fanout*right.Cost
+}
+
+// relationshipCount estimates the number of relationships of a base relation, assuming
+// that the relationships of its resource type are spread evenly across the type's base
+// relations.
+func (s Statistics) relationshipCount(base *schema.BaseRelation) float64 {
+	var typeCount float64
+	switch {
+	case s.RelationshipCountsByType != nil:
+		typeCount = float64(s.RelationshipCountsByType[base.DefinitionName()])
+	default:
+		typeCount = float64(s.RelationshipCount) / float64(max(1, s.ObjectTypeCount))
+	}
+
+	baseRelationCount := 0
+	if relation := base.Parent(); relation != nil && relation.Parent() != nil {
+		for _, rel := range relation.Parent().Relations() {
+			baseRelationCount += len(rel.BaseRelations())
+		}
+	}
+	return typeCount / float64(max(1, baseRelationCount))
+}
+
+// CostBasedOptimizations returns the optimization functions which use the statistics to
+// choose how the iterator tree is evaluated, without changing its results:
+//
+//   - the children of unions are ordered so the most likely to find a path cheaply come first
+//   - the children of intersections are ordered so the most likely to find nothing cheaply come first
+//   - arrows iterate whichever of their sides is estimated to be cheaper
+func CostBasedOptimizations(stats Statistics) []OptimizerFunc {
+	return []OptimizerFunc{
+		WrapOptimizer(stats.reorderUnion),
+		WrapOptimizer(stats.reorderIntersection),
+		WrapOptimizer(stats.chooseArrowDirection),
+	}
+}
+
+// reorderUnion orders the children of a union by their cost per chance of finding a path,
+// which minimizes the expected cost of finding the first one.
+func (s Statistics) reorderUnion(u *Union) (Iterator, bool, error) {
+	return s.reorderBy(u, func(estimate CostEstimate) float64 {
+		return costRatio(estimate.Cost, estimate.Selectivity)
+	})
+}
+
+// reorderIntersection orders the children of an intersection by their cost per chance of
+// finding nothing, which minimizes the expected cost of short-circuiting.
+func (s Statistics) reorderIntersection(i *Intersection) (Iterator, bool, error) {
+	return s.reorderBy(i, func(estimate CostEstimate) float64 {
+		return costRatio(estimate.Cost, 1-estimate.Selectivity)
+	})
+}
+
+// reorderBy stably sorts the subiterators by the rank of their estimates, returning
+// whether their order changed.
+func (s Statistics) reorderBy(it Iterator, rank func(CostEstimate) float64) (Iterator, bool, error) {
+	type rankedIterator struct {
+		it   Iterator
+		rank float64
+	}
+
+	subs := it.Subiterators()
+	ranked := make([]rankedIterator, len(subs))
+	for idx, sub := range subs {
+		ranked[idx] = rankedIterator{it: sub, rank: rank(s.Estimate(sub))}
+	}
+
+	if slices.IsSortedFunc(ranked, func(a, b rankedIterator) int { return cmp.Compare(a.rank, b.rank) }) {
+		return it, false, nil
+	}
+
+	slices.SortStableFunc(ranked, func(a, b rankedIterator) int { return cmp.Compare(a.rank, b.rank) })
+
+	newSubs := make([]Iterator, len(ranked))
+	for idx, r := range ranked {
+		newSubs[idx] = r.it
+	}
+
+	newIt, err := it.ReplaceSubiterators(newSubs)
+	return newIt, true, err
+}
+
+// costRatio divides the cost by the probability, treating an impossible outcome as
+// infinitely expensive.
+func costRatio(cost, probability float64) float64 {
+	if probability <= 0 {
+		return math.Inf(1)
+	}
+	return cost / probability
+}
+
+// chooseArrowDirection picks the cheaper direction in which to check an arrow, which is
+// only right to left if the right side can iterate its resources.
+func (s Statistics) chooseArrowDirection(a *Arrow) (Iterator, bool, error) {
+	direction := ArrowLeftToRight
+	if canIterResources(a.right) {
+		left := s.Estimate(a.left)
+		right := s.Estimate(a.right)
+		if arrowCost(left, right, ArrowRightToLeft) < arrowCost(left, right, ArrowLeftToRight) {
+			direction = ArrowRightToLeft
+		}
+	}
+
+	if direction == a.direction {
+		return a, false, nil
+	}
+	return a.WithDirection(direction), true, nil
+}
+
+// canIterResources returns whether IterResources on the iterator returns the same paths
+// as checking each resource against the subject.
+func canIterResources(it Iterator) bool {
+	switch v := it.(type) {
+	case *RelationIterator:
+		// Subject relations other than ellipsis may bridge to a different subject
+		// type when checked, which is not supported when iterating resources.
+		return v.base.Wildcard() || v.base.Subrelation() == tuple.Ellipsis
+	case *Alias:
+		return canIterResources(v.subIt)
+	case *CaveatIterator:
+		return canIterResources(v.subiterator)
+	case *Union:
+		return !slices.ContainsFunc(v.subIts, func(sub Iterator) bool { return !canIterResources(sub) })
+	default:
+		return false
+	}
+}
+
+// ExplainWithEstimates returns the explanation of the iterator tree, with the estimated cost
+// of each iterator.
+func (s Statistics) ExplainWithEstimates(it Iterator) Explain {
+	explain := it.Explain()
+	estimate := s.Estimate(it)
+	explain.Estimate = &estimate
+
+	subs := it.Subiterators()
+	if len(subs) != len(explain.SubExplain) {
+		return explain
+	}
+
+	subExplains := make([]Explain, len(subs))
+	for idx, sub := range subs {
+		subExplains[idx] = s.ExplainWithEstimates(sub)
+	}
+	explain.SubExplain = subExplains
+	return explain
+}
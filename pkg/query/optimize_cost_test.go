@@ -0,0 +1,355 @@
+package query
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/authzed/spicedb/internal/datastore/dsfortesting"
+	"github.com/authzed/spicedb/internal/datastore/memdb"
+	"github.com/authzed/spicedb/internal/testfixtures"
+	"github.com/authzed/spicedb/pkg/datastore"
+	corev1 "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schema/v2"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// testStatistics describes a datastore where documents have many more
+// relationships than folders.
+var testStatistics = Statistics{
+	RelationshipCount: 10000,
+	RelationshipCountsByType: map[string]uint64{
+		"document": 9000,
+		"folder":   1000,
+	},
+	ObjectTypeCount: 3,
+}
+
+func TestStatisticsFromDatastore(t *testing.T) {
+	t.Parallel()
+
+	stats := StatisticsFromDatastore(datastore.Stats{
+		EstimatedRelationshipCount: 42,
+		RelationshipCountsByType:   map[string]uint64{"document": 42},
+		ObjectTypeStatistics:       make([]datastore.ObjectTypeStat, 2),
+	})
+	require.Equal(t, Statistics{
+		RelationshipCount:        42,
+		RelationshipCountsByType: map[string]uint64{"document": 42},
+		ObjectTypeCount:          2,
+	}, stats)
+}
+
+func TestEstimate(t *testing.T) {
+	t.Parallel()
+
+	docViewer := NewRelationIterator(createTestBaseRelation("document", "viewer", "user", tuple.Ellipsis))
+	folderViewer := NewRelationIterator(createTestBaseRelation("folder", "viewer", "user", tuple.Ellipsis))
+
+	t.Run("relation", func(t *testing.T) {
+		t.Parallel()
+
+		estimate := testStatistics.Estimate(folderViewer)
+		require.InDelta(t, 1, estimate.Cost, 0.001)
+		require.InDelta(t, 0.316, estimate.Selectivity, 0.001)
+		require.InDelta(t, 31.62, estimate.Fanout, 0.01)
+		require.InDelta(t, 31.62, estimate.Fanin, 0.01)
+	})
+
+	t.Run("relation without counts by type", func(t *testing.T) {
+		t.Parallel()
+
+		stats := Statistics{RelationshipCount: 300, ObjectTypeCount: 3}
+		estimate := stats.Estimate(folderViewer)
+		require.InDelta(t, 10, estimate.Fanout, 0.001)
+	})
+
+	t.Run("relation of type without relationships", func(t *testing.T) {
+		t.Parallel()
+
+		estimate := testStatistics.Estimate(NewRelationIterator(createTestBaseRelation("user", "manager", "user", tuple.Ellipsis)))
+		require.Zero(t, estimate.Selectivity)
+		require.InDelta(t, 1, estimate.Fanout, 0.001)
+	})
+
+	t.Run("union", func(t *testing.T) {
+		t.Parallel()
+
+		folder := testStatistics.Estimate(folderViewer)
+		doc := testStatistics.Estimate(docViewer)
+
+		estimate := testStatistics.Estimate(NewUnion(folderViewer, docViewer))
+		require.InDelta(t, 1+(1-folder.Selectivity), estimate.Cost, 0.001)
+		require.InDelta(t, 1-(1-folder.Selectivity)*(1-doc.Selectivity), estimate.Selectivity, 0.001)
+		require.InDelta(t, folder.Fanout+doc.Fanout, estimate.Fanout, 0.001)
+	})
+
+	t.Run("intersection", func(t *testing.T) {
+		t.Parallel()
+
+		folder := testStatistics.Estimate(folderViewer)
+		doc := testStatistics.Estimate(docViewer)
+
+		estimate := testStatistics.Estimate(NewIntersection(folderViewer, docViewer))
+		require.InDelta(t, 1+folder.Selectivity, estimate.Cost, 0.001)
+		require.InDelta(t, folder.Selectivity*doc.Selectivity, estimate.Selectivity, 0.001)
+		require.InDelta(t, folder.Fanout, estimate.Fanout, 0.001)
+	})
+
+	t.Run("empty fixed", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, CostEstimate{}, testStatistics.Estimate(NewEmptyFixedIterator()))
+	})
+
+	t.Run("unknown iterator", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, unknownEstimate, testStatistics.Estimate(NewFaultyIterator(false, false)))
+	})
+}
+
+func TestCostBasedReordering(t *testing.T) {
+	t.Parallel()
+
+	docViewer := NewRelationIterator(createTestBaseRelation("document", "viewer", "user", tuple.Ellipsis))
+	folderViewer := NewRelationIterator(createTestBaseRelation("folder", "viewer", "user", tuple.Ellipsis))
+	optimizations := CostBasedOptimizations(testStatistics)
+
+	t.Run("intersection puts the most selective child first", func(t *testing.T) {
+		t.Parallel()
+
+		result, changed, err := ApplyOptimizations(NewIntersection(docViewer, folderViewer), optimizations)
+		require.NoError(t, err)
+		require.True(t, changed)
+		require.Equal(t, []Iterator{folderViewer, docViewer}, result.Subiterators())
+
+		// Reapplying the optimizations makes no further changes.
+		_, changed, err = ApplyOptimizations(result, optimizations)
+		require.NoError(t, err)
+		require.False(t, changed)
+	})
+
+	t.Run("union puts the most likely child first", func(t *testing.T) {
+		t.Parallel()
+
+		result, changed, err := ApplyOptimizations(NewUnion(folderViewer, docViewer), optimizations)
+		require.NoError(t, err)
+		require.True(t, changed)
+		require.Equal(t, []Iterator{docViewer, folderViewer}, result.Subiterators())
+	})
+
+	t.Run("ordered children are unchanged", func(t *testing.T) {
+		t.Parallel()
+
+		union := NewUnion(docViewer, folderViewer)
+		result, changed, err := ApplyOptimizations(union, optimizations)
+		require.NoError(t, err)
+		require.False(t, changed)
+		require.Same(t, union, result)
+	})
+
+	t.Run("reordering lowers the estimated cost", func(t *testing.T) {
+		t.Parallel()
+
+		original := NewIntersection(docViewer, folderViewer)
+		result, _, err := ApplyOptimizations(original, optimizations)
+		require.NoError(t, err)
+		require.Less(t, testStatistics.Estimate(result).Cost, testStatistics.Estimate(original).Cost)
+	})
+}
+
+func TestChooseArrowDirection(t *testing.T) {
+	t.Parallel()
+
+	docParent := NewRelationIterator(createTestBaseRelation("document", "parent", "folder", tuple.Ellipsis))
+	folderViewer := NewRelationIterator(createTestBaseRelation("folder", "viewer", "user", tuple.Ellipsis))
+	folderEditor := NewRelationIterator(createTestBaseRelation("folder", "editor", "user", tuple.Ellipsis))
+	optimizations := CostBasedOptimizations(testStatistics)
+
+	t.Run("iterates the smaller side", func(t *testing.T) {
+		t.Parallel()
+
+		result, changed, err := ApplyOptimizations(NewArrow(docParent, folderViewer), optimizations)
+		require.NoError(t, err)
+		require.True(t, changed)
+		require.Equal(t, ArrowRightToLeft, result.(*Arrow).Direction())
+		require.Equal(t, "Arrow(right to left)", result.Explain().Info)
+
+		// The direction survives cloning and replacing subiterators.
+		require.Equal(t, ArrowRightToLeft, result.Clone().(*Arrow).Direction())
+		replaced, err := result.ReplaceSubiterators(result.Subiterators())
+		require.NoError(t, err)
+		require.Equal(t, ArrowRightToLeft, replaced.(*Arrow).Direction())
+	})
+
+	t.Run("keeps the larger side on the right", func(t *testing.T) {
+		t.Parallel()
+
+		folderParent := NewRelationIterator(createTestBaseRelation("folder", "parent", "folder", tuple.Ellipsis))
+		docViewer := NewRelationIterator(createTestBaseRelation("document", "viewer", "user", tuple.Ellipsis))
+
+		result, changed, err := ApplyOptimizations(NewArrow(folderParent, docViewer), optimizations)
+		require.NoError(t, err)
+		require.False(t, changed)
+		require.Equal(t, ArrowLeftToRight, result.(*Arrow).Direction())
+	})
+
+	t.Run("requires the right side to iterate resources", func(t *testing.T) {
+		t.Parallel()
+
+		arrow := NewArrow(docParent, NewIntersection(folderViewer, folderEditor))
+		result, _, err := ApplyOptimizations(arrow, optimizations)
+		require.NoError(t, err)
+		require.Equal(t, ArrowLeftToRight, result.(*Arrow).Direction())
+	})
+
+	t.Run("union of relations iterates resources", func(t *testing.T) {
+		t.Parallel()
+
+		arrow := NewArrow(docParent, NewUnion(folderViewer, folderEditor))
+		result, _, err := ApplyOptimizations(arrow, optimizations)
+		require.NoError(t, err)
+		require.Equal(t, ArrowRightToLeft, result.(*Arrow).Direction())
+	})
+
+	t.Run("subject relations do not iterate resources", func(t *testing.T) {
+		t.Parallel()
+
+		require.False(t, canIterResources(NewRelationIterator(createTestBaseRelation("folder", "viewer", "group", "member"))))
+		require.True(t, canIterResources(NewRelationIterator(createTestWildcardBaseRelation("folder", "viewer", "user"))))
+	})
+
+	t.Run("switches back when statistics change", func(t *testing.T) {
+		t.Parallel()
+
+		reversed := NewArrow(docParent, folderViewer).WithDirection(ArrowRightToLeft)
+		stats := Statistics{
+			RelationshipCount:        10000,
+			RelationshipCountsByType: map[string]uint64{"document": 10, "folder": 9990},
+		}
+
+		result, changed, err := ApplyOptimizations(reversed, CostBasedOptimizations(stats))
+		require.NoError(t, err)
+		require.True(t, changed)
+		require.Equal(t, ArrowLeftToRight, result.(*Arrow).Direction())
+	})
+}
+
+func TestExplainWithEstimates(t *testing.T) {
+	t.Parallel()
+
+	folderViewer := NewRelationIterator(createTestBaseRelation("folder", "viewer", "user", tuple.Ellipsis))
+	union := NewUnion(folderViewer, NewEmptyFixedIterator())
+
+	explain := testStatistics.ExplainWithEstimates(union)
+	require.NotNil(t, explain.Estimate)
+	require.Len(t, explain.SubExplain, 2)
+	require.Equal(t, testStatistics.Estimate(folderViewer), *explain.SubExplain[0].Estimate)
+	require.Equal(t, CostEstimate{}, *explain.SubExplain[1].Estimate)
+
+	require.Equal(t, "Union [cost=1.00 selectivity=0.316 fanout=31.6 fanin=31.6]\n"+
+		"\tRelation(folder:viewer -> user:..., caveat: false, expiration: false) [cost=1.00 selectivity=0.316 fanout=31.6 fanin=31.6]\n"+
+		"\tFixed(0 paths) [cost=0.00 selectivity=0.000 fanout=0.0 fanin=0.0]\n", explain.String())
+
+	// Explanations without estimates are unchanged.
+	require.NotContains(t, union.Explain().String(), "cost=")
+}
+
+func TestCostBasedOptimizationsPreserveResults(t *testing.T) {
+	t.Parallel()
+
+	require := require.New(t)
+	rawDS, err := dsfortesting.NewMemDBDatastoreForTesting(0, 0, memdb.DisableGC)
+	require.NoError(err)
+
+	ds, revision := testfixtures.StandardDatastoreWithData(rawDS, require)
+
+	dsStats, err := ds.Statistics(t.Context())
+	require.NoError(err)
+	stats := StatisticsFromDatastore(dsStats)
+
+	objectDefs := []*corev1.NamespaceDefinition{testfixtures.UserNS.CloneVT(), testfixtures.FolderNS.CloneVT(), testfixtures.DocumentNS.CloneVT()}
+	dsSchema, err := schema.BuildSchemaFromDefinitions(objectDefs, nil)
+	require.NoError(err)
+
+	ctx := &Context{
+		Context:           t.Context(),
+		Executor:          LocalExecutor{},
+		Reader:            ds.SnapshotReader(revision),
+		MaxRecursionDepth: 5,
+	}
+
+	objectIDs := map[string][]string{}
+	for _, relString := range testfixtures.StandardRelationships {
+		rel := tuple.MustParse(relString)
+		for _, onr := range []tuple.ObjectAndRelation{rel.Resource, rel.Subject} {
+			if !slices.Contains(objectIDs[onr.ObjectType], onr.ObjectID) {
+				objectIDs[onr.ObjectType] = append(objectIDs[onr.ObjectType], onr.ObjectID)
+			}
+		}
+	}
+
+	// checkAll returns the endpoints of the paths found for every pair of
+	// resource and user.
+	checkAll := func(it Iterator, resourceType string) []string {
+		var endpoints []string
+		for _, userID := range objectIDs["user"] {
+			pathSeq, err := ctx.Check(it, NewObjects(resourceType, objectIDs[resourceType]...), NewObject("user", userID).WithEllipses())
+			require.NoError(err)
+
+			paths, err := CollectAll(pathSeq)
+			require.NoError(err)
+			for _, path := range paths {
+				endpoints = append(endpoints, path.EndpointsKey())
+			}
+		}
+		slices.Sort(endpoints)
+		return slices.Compact(endpoints)
+	}
+
+	t.Run("arrow directions", func(t *testing.T) {
+		t.Parallel()
+
+		docDef, _ := dsSchema.GetTypeDefinition("document")
+		parentRel, _ := docDef.GetRelation("parent")
+		folderDef, _ := dsSchema.GetTypeDefinition("folder")
+		viewerRel, _ := folderDef.GetRelation("viewer")
+
+		var userViewer *schema.BaseRelation
+		for _, base := range viewerRel.BaseRelations() {
+			if base.Type() == "user" {
+				userViewer = base
+			}
+		}
+		require.NotNil(userViewer)
+
+		arrow := NewArrow(NewRelationIterator(parentRel.BaseRelations()[0]), NewRelationIterator(userViewer))
+		leftToRight := checkAll(arrow, "document")
+		require.NotEmpty(leftToRight)
+		require.Equal(leftToRight, checkAll(arrow.WithDirection(ArrowRightToLeft), "document"))
+	})
+
+	for _, tc := range []struct {
+		definition string
+		permission string
+	}{
+		{"document", "view"},
+		{"document", "edit"},
+		{"document", "view_and_edit"},
+		{"folder", "view"},
+	} {
+		t.Run(tc.definition+"#"+tc.permission, func(t *testing.T) {
+			t.Parallel()
+
+			it, err := BuildIteratorFromSchema(dsSchema, tc.definition, tc.permission)
+			require.NoError(err)
+
+			optimized, _, err := ApplyOptimizations(it, CostBasedOptimizations(stats))
+			require.NoError(err)
+
+			require.Equal(checkAll(it, tc.definition), checkAll(optimized, tc.definition))
+		})
+	}
+}
@@ -307,6 +307,21 @@ func CollectAll(seq PathSeq) ([]Path, error) {
 	return out, nil
 }
 
+// resourcesOf returns the distinct resources of the paths, in the order they are first found.
+func resourcesOf(paths []Path) []Object {
+	seen := make(map[string]struct{}, len(paths))
+	resources := make([]Object, 0, len(paths))
+	for _, path := range paths {
+		key := path.Resource.Key()
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		resources = append(resources, path.Resource)
+	}
+	return resources
+}
+
 // DeduplicatePathSeq returns a new PathSeq that deduplicates paths based on their
 // endpoints (resource and subject, excluding relation). Paths with the same endpoints
 // are merged using OR semantics (caveats are OR'd, no caveat wins over caveat).
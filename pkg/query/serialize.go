@@ -0,0 +1,341 @@
+package query
+
+import (
+	"fmt"
+	"slices"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	dispatchv1 "github.com/authzed/spicedb/pkg/proto/dispatch/v1"
+	"github.com/authzed/spicedb/pkg/schema/v2"
+	"github.com/authzed/spicedb/pkg/spiceerrors"
+	"github.com/authzed/spicedb/pkg/tuple"
+)
+
+// SerializeIterator converts an iterator tree into its wire form, so that it can be
+// dispatched to and evaluated by another node.
+func SerializeIterator(it Iterator) (*dispatchv1.QueryPlanNode, error) {
+	switch v := it.(type) {
+	case *RelationIterator:
+		if v.base.Parent() == nil || v.base.Parent().Parent() == nil {
+			return nil, spiceerrors.MustBugf("cannot serialize a relation iterator without a parent definition")
+		}
+		return &dispatchv1.QueryPlanNode{Node: &dispatchv1.QueryPlanNode_Relation{Relation: &dispatchv1.QueryPlanRelation{
+			DefinitionName:  v.base.DefinitionName(),
+			RelationName:    v.base.RelationName(),
+			SubjectType:     v.base.Type(),
+			SubjectRelation: v.base.Subrelation(),
+			CaveatName:      v.base.Caveat(),
+			Expiration:      v.base.Expiration(),
+			Wildcard:        v.base.Wildcard(),
+		}}}, nil
+
+	case *Union:
+		children, err := serializeIterators(v.subIts)
+		if err != nil {
+			return nil, err
+		}
+		return &dispatchv1.QueryPlanNode{Node: &dispatchv1.QueryPlanNode_Union{Union: &dispatchv1.QueryPlanChildren{Children: children}}}, nil
+
+	case *Intersection:
+		children, err := serializeIterators(v.subIts)
+		if err != nil {
+			return nil, err
+		}
+		return &dispatchv1.QueryPlanNode{Node: &dispatchv1.QueryPlanNode_Intersection{Intersection: &dispatchv1.QueryPlanChildren{Children: children}}}, nil
+
+	case *Exclusion:
+		children, err := serializeIterators([]Iterator{v.mainSet, v.excluded})
+		if err != nil {
+			return nil, err
+		}
+		return &dispatchv1.QueryPlanNode{Node: &dispatchv1.QueryPlanNode_Exclusion{Exclusion: &dispatchv1.QueryPlanExclusion{
+			MainSet:  children[0],
+			Excluded: children[1],
+		}}}, nil
+
+	case *Arrow:
+		children, err := serializeIterators([]Iterator{v.left, v.right})
+		if err != nil {
+			return nil, err
+		}
+		return &dispatchv1.QueryPlanNode{Node: &dispatchv1.QueryPlanNode_Arrow{Arrow: &dispatchv1.QueryPlanArrow{
+			Left:        children[0],
+			Right:       children[1],
+			RightToLeft: v.direction == ArrowRightToLeft,
+		}}}, nil
+
+	case *IntersectionArrow:
+		children, err := serializeIterators([]Iterator{v.left, v.right})
+		if err != nil {
+			return nil, err
+		}
+		return &dispatchv1.QueryPlanNode{Node: &dispatchv1.QueryPlanNode_IntersectionArrow{IntersectionArrow: &dispatchv1.QueryPlanArrow{
+			Left:  children[0],
+			Right: children[1],
+		}}}, nil
+
+	case *Alias:
+		child, err := SerializeIterator(v.subIt)
+		if err != nil {
+			return nil, err
+		}
+		return &dispatchv1.QueryPlanNode{Node: &dispatchv1.QueryPlanNode_Alias{Alias: &dispatchv1.QueryPlanAlias{
+			Relation: v.relation,
+			Child:    child,
+		}}}, nil
+
+	case *CaveatIterator:
+		child, err := SerializeIterator(v.subiterator)
+		if err != nil {
+			return nil, err
+		}
+		return &dispatchv1.QueryPlanNode{Node: &dispatchv1.QueryPlanNode_Caveat{Caveat: &dispatchv1.QueryPlanCaveat{
+			Caveat: v.caveat,
+			Child:  child,
+		}}}, nil
+
+	case *FixedIterator:
+		paths := make([]*dispatchv1.QueryPlanPath, 0, len(v.paths))
+		for _, path := range v.paths {
+			paths = append(paths, PathToProto(path))
+		}
+		return &dispatchv1.QueryPlanNode{Node: &dispatchv1.QueryPlanNode_Fixed{Fixed: &dispatchv1.QueryPlanFixed{Paths: paths}}}, nil
+
+	case *RecursiveIterator:
+		templateTree, err := SerializeIterator(v.templateTree)
+		if err != nil {
+			return nil, err
+		}
+		return &dispatchv1.QueryPlanNode{Node: &dispatchv1.QueryPlanNode_Recursive{Recursive: &dispatchv1.QueryPlanRecursive{
+			DefinitionName: v.definitionName,
+			RelationName:   v.relationName,
+			TemplateTree:   templateTree,
+		}}}, nil
+
+	case *RecursiveSentinel:
+		return &dispatchv1.QueryPlanNode{Node: &dispatchv1.QueryPlanNode_RecursiveSentinel{RecursiveSentinel: &dispatchv1.QueryPlanRecursiveSentinel{
+			DefinitionName:   v.definitionName,
+			RelationName:     v.relationName,
+			WithSubRelations: v.withSubRelations,
+		}}}, nil
+
+	default:
+		return nil, fmt.Errorf("cannot serialize iterator of type %T", it)
+	}
+}
+
+func serializeIterators(its []Iterator) ([]*dispatchv1.QueryPlanNode, error) {
+	nodes := make([]*dispatchv1.QueryPlanNode, 0, len(its))
+	for _, it := range its {
+		node, err := SerializeIterator(it)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// DeserializeIterator rebuilds an iterator tree from its wire form, resolving the relations
+// it references against the given schema.
+func DeserializeIterator(node *dispatchv1.QueryPlanNode, s *schema.Schema) (Iterator, error) {
+	switch v := node.GetNode().(type) {
+	case *dispatchv1.QueryPlanNode_Relation:
+		base, err := findBaseRelation(v.Relation, s)
+		if err != nil {
+			return nil, err
+		}
+		return NewRelationIterator(base), nil
+
+	case *dispatchv1.QueryPlanNode_Union:
+		children, err := deserializeIterators(v.Union.Children, s)
+		if err != nil {
+			return nil, err
+		}
+		return NewUnion(children...), nil
+
+	case *dispatchv1.QueryPlanNode_Intersection:
+		children, err := deserializeIterators(v.Intersection.Children, s)
+		if err != nil {
+			return nil, err
+		}
+		return NewIntersection(children...), nil
+
+	case *dispatchv1.QueryPlanNode_Exclusion:
+		children, err := deserializeIterators([]*dispatchv1.QueryPlanNode{v.Exclusion.MainSet, v.Exclusion.Excluded}, s)
+		if err != nil {
+			return nil, err
+		}
+		return NewExclusion(children[0], children[1]), nil
+
+	case *dispatchv1.QueryPlanNode_Arrow:
+		children, err := deserializeIterators([]*dispatchv1.QueryPlanNode{v.Arrow.Left, v.Arrow.Right}, s)
+		if err != nil {
+			return nil, err
+		}
+		arrow := NewArrow(children[0], children[1])
+		if v.Arrow.RightToLeft {
+			return arrow.WithDirection(ArrowRightToLeft), nil
+		}
+		return arrow, nil
+
+	case *dispatchv1.QueryPlanNode_IntersectionArrow:
+		children, err := deserializeIterators([]*dispatchv1.QueryPlanNode{v.IntersectionArrow.Left, v.IntersectionArrow.Right}, s)
+		if err != nil {
+			return nil, err
+		}
+		return NewIntersectionArrow(children[0], children[1]), nil
+
+	case *dispatchv1.QueryPlanNode_Alias:
+		child, err := DeserializeIterator(v.Alias.Child, s)
+		if err != nil {
+			return nil, err
+		}
+		return NewAlias(v.Alias.Relation, child), nil
+
+	case *dispatchv1.QueryPlanNode_Caveat:
+		child, err := DeserializeIterator(v.Caveat.Child, s)
+		if err != nil {
+			return nil, err
+		}
+		return NewCaveatIterator(child, v.Caveat.Caveat), nil
+
+	case *dispatchv1.QueryPlanNode_Fixed:
+		paths := make([]Path, 0, len(v.Fixed.Paths))
+		for _, path := range v.Fixed.Paths {
+			paths = append(paths, PathFromProto(path))
+		}
+		return NewFixedIterator(paths...), nil
+
+	case *dispatchv1.QueryPlanNode_Recursive:
+		templateTree, err := DeserializeIterator(v.Recursive.TemplateTree, s)
+		if err != nil {
+			return nil, err
+		}
+		return NewRecursiveIterator(templateTree, v.Recursive.DefinitionName, v.Recursive.RelationName), nil
+
+	case *dispatchv1.QueryPlanNode_RecursiveSentinel:
+		return NewRecursiveSentinel(v.RecursiveSentinel.DefinitionName, v.RecursiveSentinel.RelationName, v.RecursiveSentinel.WithSubRelations), nil
+
+	default:
+		return nil, fmt.Errorf("cannot deserialize query plan node of type %T", v)
+	}
+}
+
+func deserializeIterators(nodes []*dispatchv1.QueryPlanNode, s *schema.Schema) ([]Iterator, error) {
+	its := make([]Iterator, 0, len(nodes))
+	for _, node := range nodes {
+		it, err := DeserializeIterator(node, s)
+		if err != nil {
+			return nil, err
+		}
+		its = append(its, it)
+	}
+	return its, nil
+}
+
+// findBaseRelation finds the base relation in the schema matching the serialized relation.
+func findBaseRelation(rel *dispatchv1.QueryPlanRelation, s *schema.Schema) (*schema.BaseRelation, error) {
+	def, ok := s.GetTypeDefinition(rel.DefinitionName)
+	if !ok {
+		return nil, fmt.Errorf("definition `%s` not found in schema", rel.DefinitionName)
+	}
+
+	relation, ok := def.GetRelation(rel.RelationName)
+	if !ok {
+		return nil, fmt.Errorf("relation `%s` not found in definition `%s`", rel.RelationName, rel.DefinitionName)
+	}
+
+	for _, base := range relation.BaseRelations() {
+		if base.Type() == rel.SubjectType &&
+			base.Subrelation() == rel.SubjectRelation &&
+			base.Caveat() == rel.CaveatName &&
+			base.Expiration() == rel.Expiration &&
+			base.Wildcard() == rel.Wildcard {
+			return base, nil
+		}
+	}
+
+	return nil, fmt.Errorf("subject type `%s` not allowed on relation `%s#%s`", rel.SubjectType, rel.DefinitionName, rel.RelationName)
+}
+
+// ReferencedDefinitionNames returns the sorted names of the definitions whose relations
+// are referenced by the serialized iterator tree, which must be loaded to deserialize it.
+func ReferencedDefinitionNames(node *dispatchv1.QueryPlanNode) []string {
+	names := make(map[string]struct{})
+	collectDefinitionNames(node, names)
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	slices.Sort(sorted)
+	return sorted
+}
+
+func collectDefinitionNames(node *dispatchv1.QueryPlanNode, names map[string]struct{}) {
+	switch v := node.GetNode().(type) {
+	case *dispatchv1.QueryPlanNode_Relation:
+		names[v.Relation.DefinitionName] = struct{}{}
+	case *dispatchv1.QueryPlanNode_Union:
+		for _, child := range v.Union.Children {
+			collectDefinitionNames(child, names)
+		}
+	case *dispatchv1.QueryPlanNode_Intersection:
+		for _, child := range v.Intersection.Children {
+			collectDefinitionNames(child, names)
+		}
+	case *dispatchv1.QueryPlanNode_Exclusion:
+		collectDefinitionNames(v.Exclusion.MainSet, names)
+		collectDefinitionNames(v.Exclusion.Excluded, names)
+	case *dispatchv1.QueryPlanNode_Arrow:
+		collectDefinitionNames(v.Arrow.Left, names)
+		collectDefinitionNames(v.Arrow.Right, names)
+	case *dispatchv1.QueryPlanNode_IntersectionArrow:
+		collectDefinitionNames(v.IntersectionArrow.Left, names)
+		collectDefinitionNames(v.IntersectionArrow.Right, names)
+	case *dispatchv1.QueryPlanNode_Alias:
+		collectDefinitionNames(v.Alias.Child, names)
+	case *dispatchv1.QueryPlanNode_Caveat:
+		collectDefinitionNames(v.Caveat.Child, names)
+	case *dispatchv1.QueryPlanNode_Recursive:
+		collectDefinitionNames(v.Recursive.TemplateTree, names)
+	}
+}
+
+// PathToProto converts the Path into its wire form. Metadata is not included.
+func PathToProto(p Path) *dispatchv1.QueryPlanPath {
+	var expiration *timestamppb.Timestamp
+	if p.Expiration != nil {
+		expiration = timestamppb.New(*p.Expiration)
+	}
+
+	return &dispatchv1.QueryPlanPath{
+		Resource:   p.ResourceOAR().ToCoreONR(),
+		Subject:    p.Subject.ToCoreONR(),
+		Caveat:     p.Caveat,
+		Expiration: expiration,
+		Integrity:  p.Integrity,
+	}
+}
+
+// PathFromProto converts a Path from its wire form.
+func PathFromProto(p *dispatchv1.QueryPlanPath) Path {
+	resource := tuple.FromCoreObjectAndRelation(p.Resource)
+
+	path := Path{
+		Resource:  GetObject(resource),
+		Relation:  resource.Relation,
+		Subject:   tuple.FromCoreObjectAndRelation(p.Subject),
+		Caveat:    p.Caveat,
+		Integrity: p.Integrity,
+		Metadata:  make(map[string]any),
+	}
+
+	if p.Expiration != nil {
+		expiration := p.Expiration.AsTime()
+		path.Expiration = &expiration
+	}
+	return path
+}
@@ -0,0 +1,103 @@
+package query
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/authzed/spicedb/internal/caveats"
+	"github.com/authzed/spicedb/internal/testfixtures"
+	corev1 "github.com/authzed/spicedb/pkg/proto/core/v1"
+	"github.com/authzed/spicedb/pkg/schema/v2"
+)
+
+func TestSerializeIteratorRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	objectDefs := []*corev1.NamespaceDefinition{testfixtures.UserNS.CloneVT(), testfixtures.FolderNS.CloneVT(), testfixtures.DocumentNS.CloneVT()}
+	dsSchema, err := schema.BuildSchemaFromDefinitions(objectDefs, nil)
+	require.NoError(t, err)
+
+	for _, relation := range []string{"view", "edit", "view_and_edit", "owner", "parent"} {
+		t.Run(relation, func(t *testing.T) {
+			t.Parallel()
+
+			it, err := BuildIteratorFromSchema(dsSchema, "document", relation)
+			require.NoError(t, err)
+
+			node, err := SerializeIterator(it)
+			require.NoError(t, err)
+
+			deserialized, err := DeserializeIterator(node, dsSchema)
+			require.NoError(t, err)
+			require.Equal(t, it.Explain().String(), deserialized.Explain().String())
+
+			reserialized, err := SerializeIterator(deserialized)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(node, reserialized))
+		})
+	}
+}
+
+func TestSerializeIteratorFixedAndExclusion(t *testing.T) {
+	t.Parallel()
+
+	it := NewExclusion(
+		NewDocumentAccessFixedIterator(),
+		NewIntersectionArrow(NewSingleUserFixedIterator("alice"), NewEmptyFixedIterator()),
+	)
+
+	node, err := SerializeIterator(it)
+	require.NoError(t, err)
+	require.Empty(t, ReferencedDefinitionNames(node))
+
+	deserialized, err := DeserializeIterator(node, nil)
+	require.NoError(t, err)
+	require.Equal(t, it.Explain().String(), deserialized.Explain().String())
+}
+
+func TestReferencedDefinitionNames(t *testing.T) {
+	t.Parallel()
+
+	objectDefs := []*corev1.NamespaceDefinition{testfixtures.UserNS.CloneVT(), testfixtures.FolderNS.CloneVT(), testfixtures.DocumentNS.CloneVT()}
+	dsSchema, err := schema.BuildSchemaFromDefinitions(objectDefs, nil)
+	require.NoError(t, err)
+
+	it, err := BuildIteratorFromSchema(dsSchema, "document", "view")
+	require.NoError(t, err)
+
+	node, err := SerializeIterator(it)
+	require.NoError(t, err)
+	require.Equal(t, []string{"document", "folder"}, ReferencedDefinitionNames(node))
+
+	edit, err := BuildIteratorFromSchema(dsSchema, "document", "edit")
+	require.NoError(t, err)
+
+	node, err = SerializeIterator(edit)
+	require.NoError(t, err)
+	require.Equal(t, []string{"document"}, ReferencedDefinitionNames(node))
+}
+
+func TestPathProtoRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	expiration := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	path := Path{
+		Resource:   NewObject("document", "doc1"),
+		Relation:   "viewer",
+		Subject:    NewObject("user", "alice").WithEllipses(),
+		Caveat:     caveats.CaveatExprForTesting("test"),
+		Expiration: &expiration,
+		Metadata:   map[string]any{},
+	}
+
+	roundTripped := PathFromProto(PathToProto(path))
+	require.Equal(t, path.Resource, roundTripped.Resource)
+	require.Equal(t, path.Relation, roundTripped.Relation)
+	require.Equal(t, path.Subject, roundTripped.Subject)
+	require.True(t, proto.Equal(path.Caveat, roundTripped.Caveat))
+	require.NotNil(t, roundTripped.Expiration)
+	require.True(t, expiration.Equal(*roundTripped.Expiration))
+}
@@ -58,8 +58,9 @@ type Iterator interface {
 //
 // TODO: This can be extended with other interesting stats about the tree.
 type Explain struct {
-	Name       string // Short name for tracing (e.g., "Arrow", "Union")
-	Info       string // Full info for display
+	Name       string        // Short name for tracing (e.g., "Arrow", "Union")
+	Info       string        // Full info for display
+	Estimate   *CostEstimate // Estimated cost, if computed by Statistics.ExplainWithEstimates
 	SubExplain []Explain
 }
 
@@ -73,5 +74,10 @@ func (e Explain) IndentString(depth int) string {
 	for _, sub := range e.SubExplain {
 		sb.WriteString(sub.IndentString(depth + 1))
 	}
-	return fmt.Sprintf("%s%s\n%s", strings.Repeat("\t", depth), e.Info, sb.String())
+	info := e.Info
+	if e.Estimate != nil {
+		info += fmt.Sprintf(" [cost=%.2f selectivity=%.3f fanout=%.1f fanin=%.1f]",
+			e.Estimate.Cost, e.Estimate.Selectivity, e.Estimate.Fanout, e.Estimate.Fanin)
+	}
+	return fmt.Sprintf("%s%s\n%s", strings.Repeat("\t", depth), info, sb.String())
 }
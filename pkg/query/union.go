@@ -1,9 +1,5 @@
 package query
 
-import (
-	"github.com/authzed/spicedb/pkg/spiceerrors"
-)
-
 // Union the set of paths that are in any of underlying subiterators.
 // This is equivalent to `permission foo = bar | baz`
 type Union struct {
@@ -60,11 +56,45 @@ func (u *Union) CheckImpl(ctx *Context, resources []Object, subject ObjectAndRel
 }
 
 func (u *Union) IterSubjectsImpl(ctx *Context, resource Object) (PathSeq, error) {
-	return nil, spiceerrors.MustBugf("unimplemented")
+	return u.iterSubIterators(ctx, func(it Iterator) (PathSeq, error) {
+		return ctx.IterSubjects(it, resource)
+	})
 }
 
 func (u *Union) IterResourcesImpl(ctx *Context, subject ObjectAndRelation) (PathSeq, error) {
-	return nil, spiceerrors.MustBugf("unimplemented")
+	return u.iterSubIterators(ctx, func(it Iterator) (PathSeq, error) {
+		return ctx.IterResources(it, subject)
+	})
+}
+
+// iterSubIterators concatenates and deduplicates the sequences returned by
+// calling execute on each sub-iterator.
+func (u *Union) iterSubIterators(ctx *Context, execute func(Iterator) (PathSeq, error)) (PathSeq, error) {
+	combinedSeq := func(yield func(Path, error) bool) {
+		for iterIdx, it := range u.subIts {
+			pathSeq, err := execute(it)
+			if err != nil {
+				yield(Path{}, err)
+				return
+			}
+
+			pathCount := 0
+			for path, err := range pathSeq {
+				if err != nil {
+					yield(Path{}, err)
+					return
+				}
+				pathCount++
+				if !yield(path, nil) {
+					return
+				}
+			}
+
+			ctx.TraceStep(u, "sub-iterator %d returned %d paths", iterIdx, pathCount)
+		}
+	}
+
+	return DeduplicatePathSeq(combinedSeq), nil
 }
 
 func (u *Union) Clone() Iterator {
@@ -149,22 +149,53 @@ func TestUnionIterator(t *testing.T) {
 		require.Empty(paths, "nonexistent subject should return no results")
 	})
 
-	t.Run("IterSubjects_Unimplemented", func(t *testing.T) {
+	t.Run("IterSubjects", func(t *testing.T) {
 		t.Parallel()
 
-		union := NewUnion()
-		require.Panics(func() {
-			_, _ = ctx.IterSubjects(union, NewObject("document", "doc1"))
-		})
+		union := NewUnion(NewDocumentAccessFixedIterator(), NewMultiRoleFixedIterator())
+
+		pathSeq, err := ctx.IterSubjects(union, NewObject("document", "doc1"))
+		require.NoError(err)
+
+		paths, err := CollectAll(pathSeq)
+		require.NoError(err)
+
+		// alice and bob from the document access iterator, charlie from the
+		// multi-role iterator; alice's multiple roles are deduplicated.
+		subjectIDs := make([]string, 0, len(paths))
+		for _, path := range paths {
+			subjectIDs = append(subjectIDs, path.Subject.ObjectID)
+		}
+		require.ElementsMatch([]string{"alice", "bob", "charlie"}, subjectIDs)
 	})
 
-	t.Run("IterResources_Unimplemented", func(t *testing.T) {
+	t.Run("IterResources", func(t *testing.T) {
 		t.Parallel()
 
-		union := NewUnion()
-		require.Panics(func() {
-			_, _ = ctx.IterResources(union, NewObject("user", "alice").WithEllipses())
-		})
+		union := NewUnion(NewDocumentAccessFixedIterator(), NewMultiRoleFixedIterator())
+
+		pathSeq, err := ctx.IterResources(union, NewObject("user", "charlie").WithEllipses())
+		require.NoError(err)
+
+		paths, err := CollectAll(pathSeq)
+		require.NoError(err)
+
+		resourceIDs := make([]string, 0, len(paths))
+		for _, path := range paths {
+			resourceIDs = append(resourceIDs, path.Resource.ObjectID)
+		}
+		require.ElementsMatch([]string{"doc1", "doc2", "doc3"}, resourceIDs)
+	})
+
+	t.Run("IterResources_Empty", func(t *testing.T) {
+		t.Parallel()
+
+		pathSeq, err := ctx.IterResources(NewUnion(), NewObject("user", "alice").WithEllipses())
+		require.NoError(err)
+
+		paths, err := CollectAll(pathSeq)
+		require.NoError(err)
+		require.Empty(paths)
 	})
 }
 
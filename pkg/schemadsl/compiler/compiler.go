@@ -42,8 +42,9 @@ type CompiledSchema struct {
 	// order in which they were found.
 	OrderedDefinitions []SchemaDefinition
 
-	rootNode *dslNode
-	mapper   input.PositionMapper
+	rootNode     *dslNode
+	mapper       input.PositionMapper
+	schemaString string
 }
 
 // SourcePositionToRunePosition converts a source position to a rune position.
@@ -51,6 +52,21 @@ func (cs CompiledSchema) SourcePositionToRunePosition(source input.Source, posit
 	return cs.mapper.LineAndColToRunePosition(position.LineNumber, position.ColumnPosition, source)
 }
 
+// TextForLine returns the text of the given zero-indexed line in the source.
+func (cs CompiledSchema) TextForLine(source input.Source, lineNumber int) (string, error) {
+	return cs.mapper.TextForLine(lineNumber, source)
+}
+
+// RunePositionToSourcePosition converts a rune position to a source position.
+func (cs CompiledSchema) RunePositionToSourcePosition(source input.Source, runePosition int) (input.Position, error) {
+	lineNumber, columnPosition, err := cs.mapper.RunePositionToLineAndCol(runePosition, source)
+	if err != nil {
+		return input.Position{}, err
+	}
+
+	return input.Position{LineNumber: lineNumber, ColumnPosition: columnPosition}, nil
+}
+
 type config struct {
 	skipValidation   bool
 	objectTypePrefix *string
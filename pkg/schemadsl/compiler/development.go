@@ -1,10 +1,12 @@
 package compiler
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/authzed/spicedb/pkg/schemadsl/dslshape"
 	"github.com/authzed/spicedb/pkg/schemadsl/input"
+	"github.com/authzed/spicedb/pkg/schemadsl/parser"
 )
 
 // DSLNode is a node in the DSL AST.
@@ -85,6 +87,138 @@ func PositionToAstNodeChain(schema *CompiledSchema, source input.Source, positio
 	return &NodeChain{nodes: found, runePosition: runePosition}, nil
 }
 
+// WalkNodeChains invokes the function with the chain of each AST node, and its parents, found
+// in the given source, stopping at the first error returned.
+func WalkNodeChains(schema *CompiledSchema, source input.Source, fn func(*NodeChain) error) error {
+	rootSource, err := schema.rootNode.GetString(dslshape.NodePredicateSource)
+	if err != nil {
+		return err
+	}
+
+	if rootSource != string(source) {
+		return nil
+	}
+
+	return walkNodeChains(schema.rootNode, nil, fn)
+}
+
+func walkNodeChains(node *dslNode, parents []DSLNode, fn func(*NodeChain) error) error {
+	if !node.Has(dslshape.NodePredicateStartRune) {
+		return nil
+	}
+
+	startRune, err := node.GetInt(dslshape.NodePredicateStartRune)
+	if err != nil {
+		return err
+	}
+
+	nodes := make([]DSLNode, 0, len(parents)+1)
+	nodes = append(nodes, wrapper{node})
+	nodes = append(nodes, parents...)
+	if err := fn(&NodeChain{nodes: nodes, runePosition: startRune}); err != nil {
+		return err
+	}
+
+	for _, child := range node.AllSubNodes() {
+		if err := walkNodeChains(child, nodes, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ErrorNodeChain parses the given schema, which may be only partially written, and returns the
+// chain of the error node at which parsing failed, and its parents, if the schema could not be
+// fully parsed.
+func ErrorNodeChain(source input.Source, schemaString string) (*NodeChain, error) {
+	root := parser.Parse(createAstNode, source, schemaString).(*dslNode)
+	found, err := errorNodeChain(root)
+	if err != nil {
+		return nil, err
+	}
+
+	if found == nil {
+		return nil, nil
+	}
+
+	startRune, err := found[0].GetInt(dslshape.NodePredicateStartRune)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NodeChain{nodes: found, runePosition: startRune}, nil
+}
+
+// errorNodeChain returns the chain of the most deeply nested error node under the node, which
+// is that closest to the point at which parsing failed.
+func errorNodeChain(node *dslNode) ([]DSLNode, error) {
+	if node.GetType() == dslshape.NodeTypeError {
+		return []DSLNode{wrapper{node}}, nil
+	}
+
+	var deepest []DSLNode
+	for _, child := range node.AllSubNodes() {
+		childChain, err := errorNodeChain(child)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(childChain) > len(deepest) {
+			deepest = childChain
+		}
+	}
+
+	if deepest == nil {
+		return nil, nil
+	}
+
+	return append(deepest, wrapper{node}), nil
+}
+
+// NodeText returns the source text of the given AST node of the schema.
+func NodeText(schema *CompiledSchema, node DSLNode) (string, error) {
+	startRune, err := node.GetInt(dslshape.NodePredicateStartRune)
+	if err != nil {
+		return "", err
+	}
+
+	endRune, err := node.GetInt(dslshape.NodePredicateEndRune)
+	if err != nil {
+		return "", err
+	}
+
+	runes := []rune(schema.schemaString)
+	if startRune < 0 || endRune >= len(runes) || startRune > endRune {
+		return "", fmt.Errorf("node range %d-%d is outside of the schema", startRune, endRune)
+	}
+
+	return string(runes[startRune : endRune+1]), nil
+}
+
+// Parent returns the parent of the head node of the chain, if any.
+func (nc *NodeChain) Parent() DSLNode {
+	if len(nc.nodes) < 2 {
+		return nil
+	}
+
+	return nc.nodes[1]
+}
+
+// ParentOfType returns the parent of the first node of the given type in the chain, if any.
+func (nc *NodeChain) ParentOfType(nodeType dslshape.NodeType) DSLNode {
+	for index, node := range nc.nodes {
+		if node.GetType() == nodeType {
+			if index+1 < len(nc.nodes) {
+				return nc.nodes[index+1]
+			}
+			return nil
+		}
+	}
+
+	return nil
+}
+
 func runePositionToAstNodeChain(node *dslNode, runePosition int) ([]DSLNode, error) {
 	if !node.Has(dslshape.NodePredicateStartRune) {
 		return nil, nil
@@ -255,3 +255,127 @@ func TestPositionToAstNode(t *testing.T) {
 		})
 	}
 }
+
+func TestWalkNodeChains(t *testing.T) {
+	t.Parallel()
+
+	schema := `definition user {}
+
+definition document {
+	relation viewer: user
+}`
+
+	compiled, err := Compile(InputSchema{
+		Source:       input.Source("test"),
+		SchemaString: schema,
+	}, AllowUnprefixedObjectType())
+	require.NoError(t, err)
+
+	var texts []string
+	var chainLengths []int
+	err = WalkNodeChains(compiled, input.Source("test"), func(nodeChain *NodeChain) error {
+		text, err := NodeText(compiled, nodeChain.Head())
+		if err != nil {
+			return err
+		}
+
+		texts = append(texts, text)
+		chainLengths = append(chainLengths, len(nodeChain.nodes))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		schema,
+		"definition user {}",
+		"definition document {\n\trelation viewer: user\n}",
+		"relation viewer: user",
+		"user",
+		"user",
+	}, texts)
+	require.Equal(t, []int{1, 2, 2, 3, 4, 5}, chainLengths)
+
+	position, err := compiled.RunePositionToSourcePosition(input.Source("test"), len("definition user {}\n\ndefinition "))
+	require.NoError(t, err)
+	require.Equal(t, input.Position{LineNumber: 2, ColumnPosition: 11}, position)
+
+	var walked bool
+	err = WalkNodeChains(compiled, input.Source("other"), func(*NodeChain) error {
+		walked = true
+		return nil
+	})
+	require.NoError(t, err)
+	require.False(t, walked)
+}
+
+func TestErrorNodeChain(t *testing.T) {
+	t.Parallel()
+
+	tcs := []struct {
+		name     string
+		schema   string
+		expected []dslshape.NodeType
+	}{
+		{
+			"valid schema",
+			"definition user {}",
+			nil,
+		},
+		{
+			"missing type of relation",
+			"definition user {}\ndefinition document {\n\trelation viewer: ",
+			[]dslshape.NodeType{
+				dslshape.NodeTypeError,
+				dslshape.NodeTypeSpecificTypeReference,
+				dslshape.NodeTypeTypeReference,
+				dslshape.NodeTypeRelation,
+				dslshape.NodeTypeDefinition,
+				dslshape.NodeTypeFile,
+			},
+		},
+		{
+			"missing caveat",
+			"definition user {}\ndefinition document {\n\trelation viewer: user with ",
+			[]dslshape.NodeType{
+				dslshape.NodeTypeError,
+				dslshape.NodeTypeCaveatReference,
+				dslshape.NodeTypeSpecificTypeReference,
+				dslshape.NodeTypeTypeReference,
+				dslshape.NodeTypeRelation,
+				dslshape.NodeTypeDefinition,
+				dslshape.NodeTypeFile,
+			},
+		},
+		{
+			"missing permission expression",
+			"definition document {\n\trelation viewer: user\n\tpermission view = viewer + ",
+			[]dslshape.NodeType{
+				dslshape.NodeTypeError,
+				dslshape.NodeTypePermission,
+				dslshape.NodeTypeDefinition,
+				dslshape.NodeTypeFile,
+			},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			nodeChain, err := ErrorNodeChain(input.Source("test"), tc.schema)
+			require.NoError(t, err)
+
+			if tc.expected == nil {
+				require.Nil(t, nodeChain)
+				return
+			}
+
+			require.NotNil(t, nodeChain)
+
+			nodeTypes := make([]dslshape.NodeType, 0, len(nodeChain.nodes))
+			for _, node := range nodeChain.nodes {
+				nodeTypes = append(nodeTypes, node.GetType())
+			}
+			require.Equal(t, tc.expected, nodeTypes)
+		})
+	}
+}
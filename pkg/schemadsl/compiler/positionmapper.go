@@ -1,6 +1,7 @@
 package compiler
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/authzed/spicedb/pkg/schemadsl/input"
@@ -28,5 +29,8 @@ func (pm *positionMapper) LineAndColToRunePosition(lineNumber int, colPosition i
 
 func (pm *positionMapper) TextForLine(lineNumber int, _ input.Source) (string, error) {
 	lines := strings.Split(pm.schema.SchemaString, "\n")
+	if lineNumber < 0 || lineNumber >= len(lines) {
+		return "", fmt.Errorf("line %d is outside of the schema", lineNumber)
+	}
 	return lines[lineNumber], nil
 }
@@ -111,6 +111,7 @@ func translate(tctx *translationContext, root *dslNode) (*CompiledSchema, error)
 		OrderedDefinitions: orderedDefinitions,
 		rootNode:           root,
 		mapper:             tctx.mapper,
+		schemaString:       tctx.schemaString,
 	}, nil
 }
 
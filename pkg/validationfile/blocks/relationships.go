@@ -21,6 +21,10 @@ type ParsedRelationships struct {
 
 	// Relationships are the fully parsed relationships.
 	Relationships []tuple.Relationship
+
+	// RelationshipPositions are the positions of the relationships in the file, in the same
+	// order as Relationships.
+	RelationshipPositions []spiceerrors.SourcePosition
 }
 
 // UnmarshalYAML is a custom unmarshaller.
@@ -38,14 +42,20 @@ func (pr *ParsedRelationships) UnmarshalYAML(node *yamlv3.Node) error {
 	seenTuples := map[string]bool{}
 	lines := strings.Split(relationshipsString, "\n")
 	relationships := make([]tuple.Relationship, 0, len(lines))
+	positions := make([]spiceerrors.SourcePosition, 0, len(lines))
 	for index, line := range lines {
 		trimmed := strings.TrimSpace(line)
 		if len(trimmed) == 0 || strings.HasPrefix(trimmed, "//") {
 			continue
 		}
 
-		// +1 for the key, and *2 for newlines in YAML
-		errorLine, err := safecast.Convert[uint64](node.Line + 1 + (index * 2))
+		// +1 for the key, and *2 for newlines in folded YAML, which are written as blank lines.
+		lineNumber := node.Line + 1 + (index * 2)
+		if node.Style == yamlv3.LiteralStyle {
+			lineNumber = node.Line + 1 + index
+		}
+
+		errorLine, err := safecast.Convert[uint64](lineNumber)
 		if err != nil {
 			return err
 		}
@@ -75,9 +85,11 @@ func (pr *ParsedRelationships) UnmarshalYAML(node *yamlv3.Node) error {
 		}
 		seenTuples[tuple.StringWithoutCaveatOrExpiration(rel)] = true
 		relationships = append(relationships, rel)
+		positions = append(positions, spiceerrors.SourcePosition{LineNumber: lineNumber, ColumnPosition: node.Column})
 	}
 
 	pr.Relationships = relationships
+	pr.RelationshipPositions = positions
 	pr.SourcePosition = spiceerrors.SourcePosition{LineNumber: node.Line, ColumnPosition: node.Column}
 	return nil
 }
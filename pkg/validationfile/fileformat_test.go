@@ -170,6 +170,40 @@ relationships: >-
 	require.Equal(t, uint64(13), errWithSource.LineNumber)
 }
 
+func TestDecodeRelationshipsErrorLineNumberLiteral(t *testing.T) {
+	_, err := DecodeValidationFile([]byte(`schema: |-
+  definition user {}
+
+relationships: |-
+  document:firstdoc#writer@user:tom1
+  // some comment
+  document:firstdoc#writer@user:tom2
+  document:firstdoc#readeruser:fred
+`))
+
+	errWithSource, ok := spiceerrors.AsWithSourceError(err)
+	require.True(t, ok)
+
+	require.Equal(t, "error parsing relationship `document:firstdoc#readeruser:fred`: invalid relationship string", err.Error())
+	require.Equal(t, uint64(8), errWithSource.LineNumber)
+}
+
+func TestDecodeRelationshipPositions(t *testing.T) {
+	decoded, err := DecodeValidationFile([]byte(`schema: |-
+  definition user {}
+
+relationships: |-
+  document:firstdoc#writer@user:tom1
+
+  document:firstdoc#writer@user:tom2
+`))
+	require.NoError(t, err)
+	require.Equal(t, []spiceerrors.SourcePosition{
+		{LineNumber: 5, ColumnPosition: 16},
+		{LineNumber: 7, ColumnPosition: 16},
+	}, decoded.Relationships.RelationshipPositions)
+}
+
 func TestDecodeAssertionsErrorLineNumber(t *testing.T) {
 	_, err := DecodeValidationFile([]byte(`
 schema: >-
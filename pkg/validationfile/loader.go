@@ -15,8 +15,10 @@ import (
 	dsctx "github.com/authzed/spicedb/internal/middleware/datastore"
 	"github.com/authzed/spicedb/internal/namespace"
 	"github.com/authzed/spicedb/internal/relationships"
+	"github.com/authzed/spicedb/internal/services/shared"
 	caveattypes "github.com/authzed/spicedb/pkg/caveats/types"
 	"github.com/authzed/spicedb/pkg/datastore"
+	"github.com/authzed/spicedb/pkg/diff"
 	"github.com/authzed/spicedb/pkg/genutil/slicez"
 	core "github.com/authzed/spicedb/pkg/proto/core/v1"
 	"github.com/authzed/spicedb/pkg/schema"
@@ -166,8 +168,13 @@ func PopulateFromFilesContents(ctx context.Context, ds datastore.Datastore, cave
 
 	// Load the definitions and relationships into the datastore.
 	revision, err := ds.ReadWriteTx(ctx, func(ctx context.Context, rwt datastore.ReadWriteTransaction) error {
+		existing, err := existingSchema(ctx, rwt)
+		if err != nil {
+			return err
+		}
+
 		// Write the caveat definitions.
-		err := rwt.WriteCaveats(ctx, caveatDefs)
+		err = rwt.WriteCaveats(ctx, caveatDefs)
 		if err != nil {
 			return err
 		}
@@ -195,7 +202,16 @@ func PopulateFromFilesContents(ctx context.Context, ds datastore.Datastore, cave
 			}
 		}
 
-		return err
+		// Record the write of the definitions in the schema history.
+		if len(objectDefs) > 0 || len(caveatDefs) > 0 {
+			updated := diff.DiffableSchema{
+				ObjectDefinitions: overwriteDefinitions(existing.ObjectDefinitions, objectDefs),
+				CaveatDefinitions: overwriteDefinitions(existing.CaveatDefinitions, caveatDefs),
+			}
+			return shared.WriteSchemaHistoryEntry(ctx, rwt, caveatTypeSet, "", existing, updated)
+		}
+
+		return nil
 	})
 
 	slicez.ForEachChunk(updates, 500, func(chunked []tuple.RelationshipUpdate) {
@@ -224,6 +240,49 @@ func PopulateFromFilesContents(ctx context.Context, ds datastore.Datastore, cave
 	return &PopulatedValidationFile{schemaBuilder.String(), objectDefs, caveatDefs, rels, files}, revision, err
 }
 
+// existingSchema returns the definitions found in the datastore before they are loaded.
+func existingSchema(ctx context.Context, rwt datastore.ReadWriteTransaction) (diff.DiffableSchema, error) {
+	existingCaveats, err := rwt.ListAllCaveats(ctx)
+	if err != nil {
+		return diff.DiffableSchema{}, err
+	}
+
+	existingObjectDefs, err := rwt.ListAllNamespaces(ctx)
+	if err != nil {
+		return diff.DiffableSchema{}, err
+	}
+
+	return diff.DiffableSchema{
+		ObjectDefinitions: datastore.DefinitionsOf(existingObjectDefs),
+		CaveatDefinitions: datastore.DefinitionsOf(existingCaveats),
+	}, nil
+}
+
+// overwriteDefinitions returns the existing definitions, with those of the same name as a written
+// definition replaced by it, followed by the written definitions which did not exist.
+func overwriteDefinitions[T datastore.SchemaDefinition](existing []T, written []T) []T {
+	writtenByName := make(map[string]T, len(written))
+	for _, def := range written {
+		writtenByName[def.GetName()] = def
+	}
+
+	updated := make([]T, 0, len(existing)+len(written))
+	for _, def := range existing {
+		if writtenDef, ok := writtenByName[def.GetName()]; ok {
+			def = writtenDef
+			delete(writtenByName, def.GetName())
+		}
+		updated = append(updated, def)
+	}
+
+	for _, def := range written {
+		if _, ok := writtenByName[def.GetName()]; ok {
+			updated = append(updated, def)
+		}
+	}
+	return updated
+}
+
 // CompileSchema takes an InputSchema and returns the compiled schema, or else an error.
 // TODO: this is probably the wrong place for this, in part because it's coupling to the compiler
 // implementation.
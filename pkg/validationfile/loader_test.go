@@ -220,6 +220,41 @@ func TestPopulateFromFiles(t *testing.T) {
 	}
 }
 
+func TestPopulateFromFilesRecordsSchemaHistory(t *testing.T) {
+	require := require.New(t)
+
+	ds, err := dsfortesting.NewMemDBDatastoreForTesting(0, 0, 0)
+	require.NoError(err)
+
+	_, _, err = PopulateFromFiles(t.Context(), ds, caveattypes.Default.TypeSet, []string{"testdata/loader_no_comment.yaml"})
+	require.NoError(err)
+
+	// Files without definitions do not write the schema.
+	_, _, err = PopulateFromFiles(t.Context(), ds, caveattypes.Default.TypeSet, []string{"testdata/just_rels.yaml"})
+	require.NoError(err)
+
+	_, rev, err := PopulateFromFiles(t.Context(), ds, caveattypes.Default.TypeSet, []string{"testdata/basic_caveats.yaml"})
+	require.NoError(err)
+
+	entries, err := ds.SnapshotReader(rev).ReadSchemaHistory(t.Context(), datastore.NoRevision, 0)
+	require.NoError(err)
+	require.Len(entries, 2)
+
+	// The definitions loaded are written over those which already exist.
+	require.Contains(entries[0].Entry.SchemaText, "definition example/project")
+	require.Contains(entries[0].Entry.SchemaText, "caveat ")
+	require.Len(entries[0].Entry.Deltas, 3)
+	for _, delta := range entries[0].Entry.Deltas {
+		require.NotEqual("namespace-removed", delta.DeltaType)
+	}
+
+	require.Contains(entries[1].Entry.SchemaText, "definition example/project")
+	require.Len(entries[1].Entry.Deltas, 2)
+	for _, delta := range entries[1].Entry.Deltas {
+		require.Equal("namespace-added", delta.DeltaType)
+	}
+}
+
 func TestPopulationChunking(t *testing.T) {
 	require := require.New(t)
 